@@ -0,0 +1,142 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/neilfarmer/platform-spec/pkg/core"
+	"github.com/neilfarmer/platform-spec/pkg/output"
+	"github.com/neilfarmer/platform-spec/pkg/providers/remote"
+	"github.com/spf13/cobra"
+)
+
+var compareCmd = &cobra.Command{
+	Use:   "compare [user@]hostA [user@]hostB spec.yaml [spec.yaml...]",
+	Short: "Report tests whose results differ between two hosts",
+	Long: `Run the same specs against two remote systems over SSH and report every test
+whose status differs between them, e.g. passing on one host and failing on the
+other. Use it to find drift between machines that are meant to be identical.
+
+Exits with status 1 if any test differs or either host cannot be tested.`,
+	Example: `  platform-spec compare web-01 web-02 spec.yaml
+  platform-spec compare -u ubuntu -i ~/.ssh/id_rsa web-01 web-02 base.yaml web.yaml`,
+	Args: cobra.MinimumNArgs(3),
+	Run:  runCompare,
+}
+
+func init() {
+	rootCmd.AddCommand(compareCmd)
+
+	compareCmd.Flags().StringVarP(&identityFile, "identity", "i", "", "Path to SSH private key")
+	compareCmd.Flags().StringVarP(&remoteUser, "user", "u", "", "SSH user for hosts without a user@ prefix")
+	compareCmd.Flags().StringVar(&becomeUser, "become-user", "", "Run every test command as this user via sudo -n -u (requires passwordless sudo)")
+	compareCmd.Flags().StringSliceVar(&userFallback, "user-fallback", []string{"ssh-config", "env", "root"}, "Where to find the SSH user for hosts without a user@ prefix, in order (ssh-config, env, root)")
+	compareCmd.Flags().IntVarP(&remotePort, "port", "p", 22, "SSH port")
+	compareCmd.Flags().IntVarP(&timeout, "timeout", "t", 30, "Connection timeout in seconds")
+	compareCmd.Flags().BoolVar(&strictHostKeyChecking, "strict-host-key-checking", true, "Enable strict host key checking (default: true)")
+	compareCmd.Flags().StringVar(&knownHostsFile, "known-hosts-file", "", "Path to known_hosts file (default: ~/.ssh/known_hosts)")
+	compareCmd.Flags().BoolVar(&insecureIgnoreHostKey, "insecure-ignore-host-key", false, "Disable host key verification (INSECURE, not recommended)")
+	compareCmd.Flags().BoolVar(&acceptNewHostKeys, "accept-new-host-keys", false, "Add keys of hosts missing from known_hosts on first contact; changed keys are still rejected")
+	compareCmd.Flags().StringSliceVar(&sshKex, "ssh-kex", nil, "SSH key exchange algorithms to offer the target, in order of preference (default: library defaults)")
+	compareCmd.Flags().StringSliceVar(&sshCiphers, "ssh-ciphers", nil, "SSH ciphers to offer the target, in order of preference (default: library defaults)")
+	compareCmd.Flags().StringSliceVar(&sshHostKeyAlgos, "ssh-hostkey-algos", nil, "SSH host key algorithms to accept from the target, in order of preference (default: library defaults)")
+	compareCmd.Flags().BoolVar(&forwardAgent, "forward-agent", false, "Forward the local SSH agent to test commands on the target, like ssh -A (requires SSH_AUTH_SOCK)")
+	compareCmd.Flags().BoolVarP(&verbose, "verbose", "v", false, "Verbose output")
+	compareCmd.Flags().BoolVar(&noColor, "no-color", false, "Disable colored output")
+	compareCmd.Flags().StringVar(&testShell, "shell", "", "Shell that runs test commands on the targets (default: the spec's config.shell, else /bin/sh)")
+	compareCmd.Flags().StringArrayVar(&testEnv, "env", nil, "Environment variable to export for test commands, as KEY=VALUE (repeatable; overrides config.env)")
+	compareCmd.Flags().StringArrayVar(&testSensitiveEnv, "sensitive-env", nil, "Like --env, but the value is redacted from output (repeatable)")
+	compareCmd.Flags().StringVar(&seedFacts, "seed-facts", "", "JSON file of host facts ({\"os\": ..., \"arch\": ...}) used over gathered ones; complete facts skip gathering")
+	compareCmd.Flags().BoolVar(&allowMutating, "allow-mutating", false, "Run specs whose commands look destructive (rm, dd, mkfs, reboot, redirects into files)")
+	compareCmd.Flags().StringArrayVar(&specVars, "var", nil, "Value for ${var.name} placeholders in the spec, as name=value (repeatable; overrides the spec's variables)")
+	compareCmd.Flags().BoolVar(&allowMutating, "assume-yes", false, "Alias for --allow-mutating")
+}
+
+func runCompare(cmd *cobra.Command, args []string) {
+	output.NoColor = noColor
+
+	if err := core.ValidateShell(testShell); err != nil {
+		fmt.Fprintf(os.Stderr, "Invalid --shell: %v\n", err)
+		os.Exit(1)
+	}
+
+	userSources, err := remote.ParseUserSources(userFallback)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Invalid --user-fallback: %v\n", err)
+		os.Exit(1)
+	}
+	sshAlgorithms := remote.Algorithms{KeyExchanges: sshKex, Ciphers: sshCiphers, HostKeys: sshHostKeyAlgos}
+	if err := sshAlgorithms.Validate(); err != nil {
+		fmt.Fprintf(os.Stderr, "Invalid SSH algorithms: %v\n", err)
+		os.Exit(1)
+	}
+	if forwardAgent && os.Getenv("SSH_AUTH_SOCK") == "" {
+		fmt.Fprintf(os.Stderr, "Error: --forward-agent requires a running SSH agent (SSH_AUTH_SOCK is not set)\n")
+		os.Exit(1)
+	}
+
+	// Resolve both targets before running anything
+	targets := args[:2]
+	type host struct{ user, host string }
+	var hosts []host
+	for _, target := range targets {
+		user, hostname, err := remote.ResolveTarget(target, remoteUser, userSources)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		hosts = append(hosts, host{user: user, host: hostname})
+	}
+
+	var specs []*core.Spec
+	for _, specFile := range args[2:] {
+		spec, err := parseSpec(specFile)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to parse spec %s: %v\n", specFile, err)
+			os.Exit(1)
+		}
+		if err := applySpecOverrides(spec); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		specs = append(specs, spec)
+	}
+
+	if insecureIgnoreHostKey {
+		fmt.Fprintf(os.Stderr, "WARNING: SSH host key verification is disabled for 2 hosts (insecure mode)\n")
+	}
+
+	// Both hosts go through the same path as `test remote`
+	ctx := testContext()
+	var results []*core.HostResults
+	for _, h := range hosts {
+		config := &remote.Config{
+			Host:                  h.host,
+			Port:                  remotePort,
+			User:                  h.user,
+			BecomeUser:            becomeUser,
+			IdentityFile:          identityFile,
+			Timeout:               time.Duration(timeout) * time.Second,
+			StrictHostKeyChecking: strictHostKeyChecking,
+			KnownHostsFile:        knownHostsFile,
+			InsecureIgnoreHostKey: insecureIgnoreHostKey,
+			AcceptNewHostKeys:     acceptNewHostKeys,
+			Algorithms:            sshAlgorithms,
+			ForwardAgent:          forwardAgent,
+		}
+		hostResults, err := testSingleHost(ctx, h.host, h.user, specs, config, nil)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to test %s@%s: %v\n", h.user, h.host, err)
+			fmt.Print(output.PrintFailed())
+			os.Exit(1)
+		}
+		results = append(results, hostResults)
+	}
+
+	diffs := core.CompareResults(results[0], results[1])
+	fmt.Print(output.FormatComparison(results[0].Target, results[1].Target, diffs))
+	if len(diffs) > 0 {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,34 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+var explainCmd = &cobra.Command{
+	Use:   "explain spec.yaml",
+	Short: "Print the fully-resolved spec",
+	Long:  `Parse a spec file, merge its imports and apply defaults, then print the effective spec as YAML.`,
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		spec, err := parseSpec(args[0])
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to parse spec %s: %v\n", args[0], err)
+			os.Exit(1)
+		}
+
+		data, err := spec.ResolvedYAML()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Print(string(data))
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(explainCmd)
+	explainCmd.Flags().StringArrayVar(&specVars, "var", nil, "Value for ${var.name} placeholders in the spec, as name=value (repeatable; overrides the spec's variables)")
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/neilfarmer/platform-spec/pkg/lint"
+	"github.com/spf13/cobra"
+)
+
+var lintStrict bool
+
+var lintCmd = &cobra.Command{
+	Use:   "lint spec.yaml",
+	Short: "Check a spec for common mistakes",
+	Long: `Parse and validate a spec, then check it for suspicious patterns such as duplicate
+test names, tests that only check defaults, plain http:// for sensitive endpoints,
+insecure TLS and unused variables.
+
+Exits non-zero when an error is found. Warnings are advisory unless --strict is set.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		spec, err := parseSpec(args[0])
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to parse spec %s: %v\n", args[0], err)
+			os.Exit(1)
+		}
+
+		findings := lint.Lint(spec, lint.DefaultRules())
+		warnings := 0
+		for _, f := range findings {
+			fmt.Println(f)
+			if f.Severity == lint.SeverityWarning {
+				warnings++
+			}
+		}
+
+		if len(findings) == 0 {
+			fmt.Printf("%s: no issues found\n", args[0])
+			return
+		}
+		fmt.Printf("\n%s: %d error(s), %d warning(s)\n", args[0], len(findings)-warnings, warnings)
+
+		if lint.HasErrors(findings) || (lintStrict && warnings > 0) {
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	lintCmd.Flags().BoolVar(&lintStrict, "strict", false, "Exit non-zero on warnings as well as errors")
+	lintCmd.Flags().StringArrayVar(&specVars, "var", nil, "Value for ${var.name} placeholders in the spec, as name=value (repeatable; overrides the spec's variables)")
+	rootCmd.AddCommand(lintCmd)
+}
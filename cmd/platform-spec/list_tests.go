@@ -0,0 +1,47 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/neilfarmer/platform-spec/pkg/core"
+	"github.com/neilfarmer/platform-spec/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+var listTestsOutput string
+
+var listTestsCmd = &cobra.Command{
+	Use:   "list-tests",
+	Short: "List the supported test types and their fields",
+	Long: `List every supported test category with its fields, their types, whether they
+are required, their allowed values and the defaults applied when they are omitted.
+
+Use --output json for a machine-readable catalog.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		output.NoColor = noColor
+		categories := core.Catalog()
+
+		switch listTestsOutput {
+		case "human":
+			fmt.Print(output.FormatCatalog(categories))
+		case "json":
+			out, err := output.FormatCatalogJSON(categories)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			fmt.Print(out)
+		default:
+			fmt.Fprintf(os.Stderr, "Error: unknown output format '%s' (must be human or json)\n", listTestsOutput)
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	listTestsCmd.Flags().StringVarP(&listTestsOutput, "output", "o", "human", "Output format (human, json)")
+	listTestsCmd.Flags().BoolVar(&noColor, "no-color", false, "Disable colored output")
+	rootCmd.AddCommand(listTestsCmd)
+}
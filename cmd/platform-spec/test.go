@@ -1,23 +1,34 @@
 package main
 
 import (
+	"bufio"
 	"context"
 	"fmt"
+	"io"
 	"os"
+	"os/signal"
 	"path/filepath"
 	"runtime"
+	"slices"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/neilfarmer/platform-spec/pkg/core"
+	gcpplugin "github.com/neilfarmer/platform-spec/pkg/core/gcp"
 	k8splugin "github.com/neilfarmer/platform-spec/pkg/core/kubernetes"
+	osplugin "github.com/neilfarmer/platform-spec/pkg/core/openstack"
 	"github.com/neilfarmer/platform-spec/pkg/core/system"
 	"github.com/neilfarmer/platform-spec/pkg/inventory"
 	"github.com/neilfarmer/platform-spec/pkg/output"
+	"github.com/neilfarmer/platform-spec/pkg/providers/gcp"
 	"github.com/neilfarmer/platform-spec/pkg/providers/kubernetes"
 	"github.com/neilfarmer/platform-spec/pkg/providers/local"
+	"github.com/neilfarmer/platform-spec/pkg/providers/openstack"
 	"github.com/neilfarmer/platform-spec/pkg/providers/remote"
+	"github.com/neilfarmer/platform-spec/pkg/providers/winrm"
 	"github.com/neilfarmer/platform-spec/pkg/retry"
+	"github.com/neilfarmer/platform-spec/pkg/store"
 	"github.com/spf13/cobra"
 )
 
@@ -25,42 +36,132 @@ var (
 	// Remote connection flags
 	identityFile          string
 	inventoryFile         string
+	inventoryLimit        []string
+	userFallback          []string
+	remoteUser            string
+	becomeUser            string
+	useSudo               bool
+	sudoPasswordStdin     bool
 	remotePort            int
 	timeout               int
 	strictHostKeyChecking bool
 	knownHostsFile        string
 	insecureIgnoreHostKey bool
+	acceptNewHostKeys     bool
+	sshKex                []string
+	sshCiphers            []string
+	sshHostKeyAlgos       []string
+	forwardAgent          bool
 	jumpHost              string
 	jumpPort              int
 	jumpUser              string
 	jumpIdentityFile      string
+	jumpBreakerThreshold  int
+	jumpBreakerCooldown   string
 
 	// Kubernetes flags
 	kubeconfig    string
 	kubeContext   string
 	kubeNamespace string
+	kubeDryRun    bool
 
 	// Output flags
-	outputFormat string
-	verbose      bool
-	noColor      bool
+	outputFormat  string
+	verbose       bool
+	noColor       bool
+	resultsDB     string
+	baselineSave  string
+	jsonFile      string
+	junitFile     string
+	maxOutput     int
+	fullOutput    bool
+	prettyJSON    bool
+	testOrder     string
+	showCoverage  bool
+	firstFailures bool
 
 	// Parallel execution flags
-	parallel    string
-	maxParallel int
-	failFast    bool
+	parallel          string
+	maxParallel       int
+	failFast          bool
+	noFailFast        bool
+	haltOnHostFailure bool
+	bakeTime          string
+	heartbeat         string
+	hostTimeout       string
+
+	// Artifact collection flags
+	collectOnFailure []string
+	artifactsDir     string
+
+	// HTTP flags
+	httpProxy string
+
+	// Command execution flags
+	testShell        string
+	testEnv          []string
+	testSensitiveEnv []string
+	seedFacts        string
+	categories       []string
+	skipCategories   []string
+	allowMutating    bool
+	specVars         []string
 
 	// Retry flags
 	retries       int
 	retryDelay    string
 	retryBackoff  string
 	retryMaxDelay string
+	retryIf       []string
+	noRetryIf     []string
+	maxReconnects int
+	testRetries   int
+
+	// Local flags
+	sandbox      bool
+	sandboxAllow []string
+	minResources []string
+	watch        string
+	changesOnly  bool
+
+	// WinRM flags
+	winrmPort     int
+	winrmHTTPS    bool
+	winrmInsecure bool
+
+	// OpenStack flags
+	osCloud  string
+	osRegion string
+
+	// GCP flags
+	gcpProject string
 )
 
 var testCmd = &cobra.Command{
 	Use:   "test",
 	Short: "Run tests against infrastructure",
 	Long:  `Run tests against various infrastructure providers (remote, local, kubernetes, etc.)`,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		// Catch a bad --output before any tests run
+		if cmd.Flags().Lookup("output") == nil {
+			return nil
+		}
+		if maxOutput < 0 {
+			return fmt.Errorf("--max-output-lines must not be negative")
+		}
+		output.MaxOutputLines = maxOutput
+		output.FullOutput = fullOutput
+		output.PrettyJSON = prettyJSON
+		output.ShowCoverage = showCoverage
+		output.ShowRepresentativeFailures = firstFailures
+		if err := core.ValidateOrder(testOrder); err != nil {
+			return fmt.Errorf("invalid --order: %w", err)
+		}
+		if err := core.ValidateShell(testShell); err != nil {
+			return fmt.Errorf("invalid --shell: %w", err)
+		}
+		return output.ValidateFormat(outputFormat)
+	},
 }
 
 var remoteCmd = &cobra.Command{
@@ -89,12 +190,27 @@ var awsCmd = &cobra.Command{
 }
 
 var openstackCmd = &cobra.Command{
-	Use:   "openstack spec.yaml",
+	Use:   "openstack spec.yaml [spec2.yaml...]",
 	Short: "Test OpenStack infrastructure",
-	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println("OpenStack provider will be implemented in a future release")
-		os.Exit(0)
-	},
+	Long:  `Connect to an OpenStack cloud and run tests defined in YAML spec files. Credentials are read from clouds.yaml (--os-cloud or OS_CLOUD) or from OS_* environment variables.`,
+	Args:  cobra.MinimumNArgs(1),
+	Run:   runOpenStackTest,
+}
+
+var gcpCmd = &cobra.Command{
+	Use:   "gcp spec.yaml [spec2.yaml...]",
+	Short: "Test GCP infrastructure",
+	Long:  `Connect to Google Cloud and run tests defined in YAML spec files. Credentials are read from Application Default Credentials (gcloud auth application-default login or GOOGLE_APPLICATION_CREDENTIALS).`,
+	Args:  cobra.MinimumNArgs(1),
+	Run:   runGCPTest,
+}
+
+var winrmCmd = &cobra.Command{
+	Use:   "winrm [user@]host spec.yaml [spec2.yaml...]",
+	Short: "Test Windows systems via WinRM",
+	Long:  `Connect to Windows systems via WinRM and run tests defined in YAML spec files using PowerShell. The password is read from the WINRM_PASSWORD environment variable.`,
+	Args:  cobra.MinimumNArgs(2),
+	Run:   runWinRMTest,
 }
 
 var kubernetesCmd = &cobra.Command{
@@ -110,51 +226,208 @@ func init() {
 	// Remote command flags
 	remoteCmd.Flags().StringVarP(&identityFile, "identity", "i", "", "Path to SSH private key")
 	remoteCmd.Flags().StringVarP(&inventoryFile, "inventory", "I", "", "Path to inventory file containing hosts to test")
+	remoteCmd.Flags().StringSliceVar(&inventoryLimit, "limit", nil, "Only test inventory hosts with these labels (key=value, repeatable; all must match)")
+	remoteCmd.Flags().StringVarP(&remoteUser, "user", "u", "", "SSH user for hosts without a user@ prefix (inventory prefixes still win)")
+	remoteCmd.Flags().StringVar(&becomeUser, "become-user", "", "Run every test command as this user via sudo -u (passwordless sudo unless a sudo password is given)")
+	remoteCmd.Flags().BoolVar(&useSudo, "sudo", false, "Run every test command as root via sudo (same as --become-user root)")
+	remoteCmd.Flags().BoolVar(&sudoPasswordStdin, "sudo-password-stdin", false, "Read the sudo password for --sudo/--become-user from the first line of stdin (default: $SUDO_PASSWORD)")
+	remoteCmd.Flags().StringSliceVar(&userFallback, "user-fallback", []string{"ssh-config", "env", "root"}, "Where to find the SSH user for hosts without a user@ prefix, in order (ssh-config, env, root)")
 	remoteCmd.Flags().IntVarP(&remotePort, "port", "p", 22, "SSH port")
 	remoteCmd.Flags().IntVarP(&timeout, "timeout", "t", 30, "Connection timeout in seconds")
 	remoteCmd.Flags().BoolVar(&strictHostKeyChecking, "strict-host-key-checking", true, "Enable strict host key checking (default: true)")
 	remoteCmd.Flags().StringVar(&knownHostsFile, "known-hosts-file", "", "Path to known_hosts file (default: ~/.ssh/known_hosts)")
 	remoteCmd.Flags().BoolVar(&insecureIgnoreHostKey, "insecure-ignore-host-key", false, "Disable host key verification (INSECURE, not recommended)")
+	remoteCmd.Flags().BoolVar(&acceptNewHostKeys, "accept-new-host-keys", false, "Add keys of hosts missing from known_hosts on first contact; changed keys are still rejected")
+	remoteCmd.Flags().StringSliceVar(&sshKex, "ssh-kex", nil, "SSH key exchange algorithms to offer the target, in order of preference (default: library defaults)")
+	remoteCmd.Flags().StringSliceVar(&sshCiphers, "ssh-ciphers", nil, "SSH ciphers to offer the target, in order of preference (default: library defaults)")
+	remoteCmd.Flags().StringSliceVar(&sshHostKeyAlgos, "ssh-hostkey-algos", nil, "SSH host key algorithms to accept from the target, in order of preference (default: library defaults)")
+	remoteCmd.Flags().BoolVar(&forwardAgent, "forward-agent", false, "Forward the local SSH agent to test commands on the target, like ssh -A (requires SSH_AUTH_SOCK)")
 	remoteCmd.Flags().StringVarP(&jumpHost, "jump-host", "J", "", "Jump host (bastion) for SSH connection (format: [user@]host)")
 	remoteCmd.Flags().IntVar(&jumpPort, "jump-port", 22, "Jump host SSH port (default: 22)")
 	remoteCmd.Flags().StringVar(&jumpUser, "jump-user", "", "Jump host SSH user (overrides user from --jump-host)")
 	remoteCmd.Flags().StringVar(&jumpIdentityFile, "jump-identity", "", "SSH private key for jump host (defaults to --identity if not specified)")
+	remoteCmd.Flags().IntVar(&jumpBreakerThreshold, "jump-breaker-threshold", 5, "Consecutive jump host connection failures before further attempts fail fast without dialling (0 = disabled)")
+	remoteCmd.Flags().StringVar(&jumpBreakerCooldown, "jump-breaker-cooldown", "30s", "How long connections fail fast after the jump host breaker trips, before one attempt is let through")
 
 	// Retry flags
 	remoteCmd.Flags().IntVar(&retries, "retries", 3, "Number of retry attempts for transient failures (0 = no retries)")
 	remoteCmd.Flags().StringVar(&retryDelay, "retry-delay", "1s", "Initial delay between retry attempts (e.g., 1s, 500ms)")
-	remoteCmd.Flags().StringVar(&retryBackoff, "retry-backoff", "linear", "Retry backoff strategy: linear, exponential, jittered")
+	remoteCmd.Flags().StringVar(&retryBackoff, "retry-backoff", "linear", "Retry backoff strategy: linear, exponential, jittered, decorrelated")
 	remoteCmd.Flags().StringVar(&retryMaxDelay, "retry-max-delay", "30s", "Maximum delay between retry attempts")
+	remoteCmd.Flags().StringArrayVar(&retryIf, "retry-if", nil, "Regex for errors that should always be retried (repeatable)")
+	remoteCmd.Flags().StringArrayVar(&noRetryIf, "no-retry-if", nil, "Regex for errors that should never be retried (repeatable)")
+	remoteCmd.Flags().IntVar(&maxReconnects, "max-reconnects", 1, "Times a dropped connection is re-dialled before a command fails")
+	remoteCmd.Flags().IntVar(&testRetries, "test-retries", 0, "Retry attempts for test commands that fail transiently, e.g. connection reset by peer (0 = no retries)")
 
 	// Output flags (shared across all test commands)
-	remoteCmd.Flags().StringVarP(&outputFormat, "output", "o", "human", "Output format (human, json, junit)")
+	remoteCmd.Flags().StringVarP(&outputFormat, "output", "o", "human", "Output format (human, json, junit, tap, ndjson)")
 	remoteCmd.Flags().BoolVarP(&verbose, "verbose", "v", false, "Verbose output")
 	remoteCmd.Flags().BoolVar(&noColor, "no-color", false, "Disable colored output")
+	remoteCmd.Flags().StringVar(&resultsDB, "results-db", "", "Append per-test results to this SQLite database for trend tracking")
+	remoteCmd.Flags().StringVar(&baselineSave, "baseline-save", "", "Write normalized results (names and statuses only) to this JSON file as a baseline")
+	remoteCmd.Flags().StringVar(&jsonFile, "json-file", "", "Also write results as JSON to this file")
+	remoteCmd.Flags().StringVar(&junitFile, "junit-file", "", "Also write results as JUnit XML to this file")
+	remoteCmd.Flags().IntVar(&maxOutput, "max-output-lines", 0, "Truncate result messages and captured output to this many lines (0 = no limit)")
+	remoteCmd.Flags().BoolVar(&fullOutput, "full-output", false, "Keep JSON output untruncated when --max-output-lines is set")
+	remoteCmd.Flags().BoolVar(&prettyJSON, "pretty", true, "Indent JSON output for people; --pretty=false writes compact single-line JSON for ingestion")
+	remoteCmd.Flags().BoolVar(&showCoverage, "coverage", false, "Summarize how many tests of each category ran, passed, failed and were skipped")
+	remoteCmd.Flags().BoolVar(&firstFailures, "first-failures", false, "List each failing test once, with the first host it failed on and its message")
+	remoteCmd.Flags().StringVar(&testOrder, "order", "", "Order tests run and are reported in: category, name, or declaration (default: the spec's config.order, else category)")
+	remoteCmd.Flags().StringSliceVar(&categories, "categories", nil, "Only run tests of these categories, e.g. packages,services or kubernetes (others are reported as skipped)")
+	remoteCmd.Flags().StringSliceVar(&skipCategories, "skip-categories", nil, "Skip tests of these categories, e.g. kubernetes or gcp.buckets")
+	remoteCmd.Flags().BoolVar(&allowMutating, "allow-mutating", false, "Run specs whose commands look destructive (rm, dd, mkfs, reboot, redirects into files)")
+	remoteCmd.Flags().StringArrayVar(&specVars, "var", nil, "Value for ${var.name} placeholders in the spec, as name=value (repeatable; overrides the spec's variables)")
+	remoteCmd.Flags().BoolVar(&allowMutating, "assume-yes", false, "Alias for --allow-mutating")
+	remoteCmd.Flags().StringVar(&httpProxy, "http-proxy", "", "Default proxy URL for HTTP tests (NO_PROXY is honored)")
+	remoteCmd.Flags().StringVar(&testShell, "shell", "", "Shell that runs test commands on the target (default: the spec's config.shell, else /bin/sh)")
+	remoteCmd.Flags().StringArrayVar(&testEnv, "env", nil, "Environment variable to export for test commands, as KEY=VALUE (repeatable; overrides config.env)")
+	remoteCmd.Flags().StringArrayVar(&testSensitiveEnv, "sensitive-env", nil, "Like --env, but the value is redacted from output (repeatable)")
+	remoteCmd.Flags().StringVar(&seedFacts, "seed-facts", "", "JSON file of host facts ({\"os\": ..., \"arch\": ...}) used over gathered ones; complete facts skip gathering")
 
 	// Parallel execution flags
 	remoteCmd.Flags().StringVar(&parallel, "parallel", "1", "Number of concurrent workers (integer or 'auto' for auto-detect)")
 	remoteCmd.Flags().IntVar(&maxParallel, "max-parallel", 50, "Maximum number of concurrent workers (safety cap)")
-	remoteCmd.Flags().BoolVar(&failFast, "fail-fast", false, "Stop testing remaining hosts on first failure")
+	remoteCmd.Flags().BoolVar(&failFast, "fail-fast", false, "Stop on first failure: skip a host's remaining tests and the remaining hosts (overrides the spec's config.fail_fast)")
+	remoteCmd.Flags().BoolVar(&noFailFast, "no-fail-fast", false, "Run every test even if the spec sets config.fail_fast")
+	remoteCmd.Flags().BoolVar(&haltOnHostFailure, "halt-on-host-failure", false, "Test hosts strictly in order and stop at the first failing host, reporting the rest as skipped (for staged rollouts)")
+	remoteCmd.Flags().StringVar(&bakeTime, "bake-time", "", "Wait this long after each passing host before testing the next, in order, and stop at the first failing host (e.g., 5m; for staged rollouts)")
+	remoteCmd.Flags().StringVar(&hostTimeout, "timeout-per-host", "", "Maximum time for one host, including connecting and all specs (e.g., 5m; default unlimited)")
+	remoteCmd.Flags().StringArrayVar(&collectOnFailure, "collect-on-failure", nil, "Diagnostic command to run on hosts with failures, as \"cmd\" or \"cmd > file\" (repeatable)")
+	remoteCmd.Flags().StringVar(&artifactsDir, "artifacts-dir", "artifacts", "Directory for --collect-on-failure output (one subdirectory per host)")
+	remoteCmd.Flags().StringVar(&heartbeat, "heartbeat", "", "Print a progress line to stderr at this interval while hosts are tested (e.g., 60s; keeps idle CI jobs alive)")
 
 	// Local command flags
-	localCmd.Flags().StringVarP(&outputFormat, "output", "o", "human", "Output format (human, json, junit)")
+	localCmd.Flags().StringVarP(&outputFormat, "output", "o", "human", "Output format (human, json, junit, tap, ndjson)")
 	localCmd.Flags().BoolVarP(&verbose, "verbose", "v", false, "Verbose output")
 	localCmd.Flags().BoolVar(&noColor, "no-color", false, "Disable colored output")
+	localCmd.Flags().StringVar(&resultsDB, "results-db", "", "Append per-test results to this SQLite database for trend tracking")
+	localCmd.Flags().StringVar(&baselineSave, "baseline-save", "", "Write normalized results (names and statuses only) to this JSON file as a baseline")
+	localCmd.Flags().StringVar(&jsonFile, "json-file", "", "Also write results as JSON to this file")
+	localCmd.Flags().StringVar(&junitFile, "junit-file", "", "Also write results as JUnit XML to this file")
+	localCmd.Flags().IntVar(&maxOutput, "max-output-lines", 0, "Truncate result messages and captured output to this many lines (0 = no limit)")
+	localCmd.Flags().BoolVar(&fullOutput, "full-output", false, "Keep JSON output untruncated when --max-output-lines is set")
+	localCmd.Flags().BoolVar(&prettyJSON, "pretty", true, "Indent JSON output for people; --pretty=false writes compact single-line JSON for ingestion")
+	localCmd.Flags().BoolVar(&showCoverage, "coverage", false, "Summarize how many tests of each category ran, passed, failed and were skipped")
+	localCmd.Flags().BoolVar(&failFast, "fail-fast", false, "Stop at the first failing test (overrides the spec's config.fail_fast)")
+	localCmd.Flags().BoolVar(&noFailFast, "no-fail-fast", false, "Run every test even if the spec sets config.fail_fast")
+	localCmd.Flags().StringVar(&testOrder, "order", "", "Order tests run and are reported in: category, name, or declaration (default: the spec's config.order, else category)")
+	localCmd.Flags().StringSliceVar(&categories, "categories", nil, "Only run tests of these categories, e.g. packages,services or kubernetes (others are reported as skipped)")
+	localCmd.Flags().StringSliceVar(&skipCategories, "skip-categories", nil, "Skip tests of these categories, e.g. kubernetes or gcp.buckets")
+	localCmd.Flags().BoolVar(&allowMutating, "allow-mutating", false, "Run specs whose commands look destructive (rm, dd, mkfs, reboot, redirects into files)")
+	localCmd.Flags().StringArrayVar(&specVars, "var", nil, "Value for ${var.name} placeholders in the spec, as name=value (repeatable; overrides the spec's variables)")
+	localCmd.Flags().BoolVar(&allowMutating, "assume-yes", false, "Alias for --allow-mutating")
+	localCmd.Flags().StringVar(&httpProxy, "http-proxy", "", "Default proxy URL for HTTP tests (NO_PROXY is honored)")
+	localCmd.Flags().StringVar(&testShell, "shell", "", "Shell that runs test commands on the target (default: the spec's config.shell, else /bin/sh)")
+	localCmd.Flags().StringArrayVar(&testEnv, "env", nil, "Environment variable to export for test commands, as KEY=VALUE (repeatable; overrides config.env)")
+	localCmd.Flags().StringArrayVar(&testSensitiveEnv, "sensitive-env", nil, "Like --env, but the value is redacted from output (repeatable)")
+	localCmd.Flags().StringVar(&seedFacts, "seed-facts", "", "JSON file of host facts ({\"os\": ..., \"arch\": ...}) used over gathered ones; complete facts skip gathering")
+	localCmd.Flags().BoolVar(&sandbox, "sandbox", false, "Only allow tests to run binaries from the sandbox allowlist")
+	localCmd.Flags().StringSliceVar(&sandboxAllow, "sandbox-allow", nil, "Additional binaries to allow in sandbox mode (comma-separated or repeatable)")
+	localCmd.Flags().StringSliceVar(&minResources, "min-resources", nil, "Refuse to run unless this much is free, as memory=SIZE and/or disk=SIZE (e.g. memory=512Mi,disk=2Gi; disk is checked on /)")
+	localCmd.Flags().StringVar(&watch, "watch", "", "Re-run the specs at this interval until every test passes or you press Ctrl-C (e.g., 10s; test local only)")
+	localCmd.Flags().BoolVar(&changesOnly, "changes-only", false, "With --watch, print only tests whose status changed since the previous run; the first and final runs are printed in full")
+
+	// WinRM command flags
+	winrmCmd.Flags().IntVarP(&winrmPort, "port", "p", 0, "WinRM port (default: 5985, or 5986 with --https)")
+	winrmCmd.Flags().BoolVar(&winrmHTTPS, "https", false, "Use HTTPS for the WinRM connection")
+	winrmCmd.Flags().BoolVar(&winrmInsecure, "insecure", false, "Skip TLS certificate verification (INSECURE, not recommended)")
+	winrmCmd.Flags().IntVarP(&timeout, "timeout", "t", 60, "Operation timeout in seconds")
+	winrmCmd.Flags().StringVarP(&outputFormat, "output", "o", "human", "Output format (human, json, junit, tap, ndjson)")
+	winrmCmd.Flags().BoolVarP(&verbose, "verbose", "v", false, "Verbose output")
+	winrmCmd.Flags().BoolVar(&noColor, "no-color", false, "Disable colored output")
+	winrmCmd.Flags().StringVar(&resultsDB, "results-db", "", "Append per-test results to this SQLite database for trend tracking")
+	winrmCmd.Flags().StringVar(&baselineSave, "baseline-save", "", "Write normalized results (names and statuses only) to this JSON file as a baseline")
+	winrmCmd.Flags().StringVar(&jsonFile, "json-file", "", "Also write results as JSON to this file")
+	winrmCmd.Flags().StringVar(&junitFile, "junit-file", "", "Also write results as JUnit XML to this file")
+	winrmCmd.Flags().IntVar(&maxOutput, "max-output-lines", 0, "Truncate result messages and captured output to this many lines (0 = no limit)")
+	winrmCmd.Flags().BoolVar(&fullOutput, "full-output", false, "Keep JSON output untruncated when --max-output-lines is set")
+	winrmCmd.Flags().BoolVar(&prettyJSON, "pretty", true, "Indent JSON output for people; --pretty=false writes compact single-line JSON for ingestion")
+	winrmCmd.Flags().BoolVar(&showCoverage, "coverage", false, "Summarize how many tests of each category ran, passed, failed and were skipped")
+	winrmCmd.Flags().StringVar(&testOrder, "order", "", "Order tests run and are reported in: category, name, or declaration (default: the spec's config.order, else category)")
+	winrmCmd.Flags().StringSliceVar(&categories, "categories", nil, "Only run tests of these categories, e.g. packages,services or kubernetes (others are reported as skipped)")
+	winrmCmd.Flags().StringSliceVar(&skipCategories, "skip-categories", nil, "Skip tests of these categories, e.g. kubernetes or gcp.buckets")
+	winrmCmd.Flags().BoolVar(&allowMutating, "allow-mutating", false, "Run specs whose commands look destructive (rm, dd, mkfs, reboot, redirects into files)")
+	winrmCmd.Flags().StringArrayVar(&specVars, "var", nil, "Value for ${var.name} placeholders in the spec, as name=value (repeatable; overrides the spec's variables)")
+	winrmCmd.Flags().BoolVar(&allowMutating, "assume-yes", false, "Alias for --allow-mutating")
+
+	// OpenStack command flags
+	openstackCmd.Flags().StringVar(&osCloud, "os-cloud", "", "Cloud name from clouds.yaml (default: $OS_CLOUD)")
+	openstackCmd.Flags().StringVar(&osRegion, "region", "", "OpenStack region (default: from clouds.yaml or $OS_REGION_NAME)")
+	openstackCmd.Flags().StringVarP(&outputFormat, "output", "o", "human", "Output format (human, json, junit, tap, ndjson)")
+	openstackCmd.Flags().BoolVarP(&verbose, "verbose", "v", false, "Verbose output")
+	openstackCmd.Flags().BoolVar(&noColor, "no-color", false, "Disable colored output")
+	openstackCmd.Flags().StringVar(&resultsDB, "results-db", "", "Append per-test results to this SQLite database for trend tracking")
+	openstackCmd.Flags().StringVar(&baselineSave, "baseline-save", "", "Write normalized results (names and statuses only) to this JSON file as a baseline")
+	openstackCmd.Flags().StringVar(&jsonFile, "json-file", "", "Also write results as JSON to this file")
+	openstackCmd.Flags().StringVar(&junitFile, "junit-file", "", "Also write results as JUnit XML to this file")
+	openstackCmd.Flags().IntVar(&maxOutput, "max-output-lines", 0, "Truncate result messages and captured output to this many lines (0 = no limit)")
+	openstackCmd.Flags().BoolVar(&fullOutput, "full-output", false, "Keep JSON output untruncated when --max-output-lines is set")
+	openstackCmd.Flags().BoolVar(&prettyJSON, "pretty", true, "Indent JSON output for people; --pretty=false writes compact single-line JSON for ingestion")
+	openstackCmd.Flags().BoolVar(&showCoverage, "coverage", false, "Summarize how many tests of each category ran, passed, failed and were skipped")
+	openstackCmd.Flags().StringVar(&testOrder, "order", "", "Order tests run and are reported in: category, name, or declaration (default: the spec's config.order, else category)")
+	openstackCmd.Flags().StringSliceVar(&categories, "categories", nil, "Only run tests of these categories, e.g. packages,services or kubernetes (others are reported as skipped)")
+	openstackCmd.Flags().StringSliceVar(&skipCategories, "skip-categories", nil, "Skip tests of these categories, e.g. kubernetes or gcp.buckets")
+	openstackCmd.Flags().BoolVar(&allowMutating, "allow-mutating", false, "Run specs whose commands look destructive (rm, dd, mkfs, reboot, redirects into files)")
+	openstackCmd.Flags().StringArrayVar(&specVars, "var", nil, "Value for ${var.name} placeholders in the spec, as name=value (repeatable; overrides the spec's variables)")
+	openstackCmd.Flags().BoolVar(&allowMutating, "assume-yes", false, "Alias for --allow-mutating")
+
+	// GCP command flags
+	gcpCmd.Flags().StringVar(&gcpProject, "project", "", "Default GCP project ID for instance tests (default: $GOOGLE_CLOUD_PROJECT)")
+	gcpCmd.Flags().StringVarP(&outputFormat, "output", "o", "human", "Output format (human, json, junit, tap, ndjson)")
+	gcpCmd.Flags().BoolVarP(&verbose, "verbose", "v", false, "Verbose output")
+	gcpCmd.Flags().BoolVar(&noColor, "no-color", false, "Disable colored output")
+	gcpCmd.Flags().StringVar(&resultsDB, "results-db", "", "Append per-test results to this SQLite database for trend tracking")
+	gcpCmd.Flags().StringVar(&baselineSave, "baseline-save", "", "Write normalized results (names and statuses only) to this JSON file as a baseline")
+	gcpCmd.Flags().StringVar(&jsonFile, "json-file", "", "Also write results as JSON to this file")
+	gcpCmd.Flags().StringVar(&junitFile, "junit-file", "", "Also write results as JUnit XML to this file")
+	gcpCmd.Flags().IntVar(&maxOutput, "max-output-lines", 0, "Truncate result messages and captured output to this many lines (0 = no limit)")
+	gcpCmd.Flags().BoolVar(&fullOutput, "full-output", false, "Keep JSON output untruncated when --max-output-lines is set")
+	gcpCmd.Flags().BoolVar(&prettyJSON, "pretty", true, "Indent JSON output for people; --pretty=false writes compact single-line JSON for ingestion")
+	gcpCmd.Flags().BoolVar(&showCoverage, "coverage", false, "Summarize how many tests of each category ran, passed, failed and were skipped")
+	gcpCmd.Flags().StringVar(&testOrder, "order", "", "Order tests run and are reported in: category, name, or declaration (default: the spec's config.order, else category)")
+	gcpCmd.Flags().StringSliceVar(&categories, "categories", nil, "Only run tests of these categories, e.g. packages,services or kubernetes (others are reported as skipped)")
+	gcpCmd.Flags().StringSliceVar(&skipCategories, "skip-categories", nil, "Skip tests of these categories, e.g. kubernetes or gcp.buckets")
+	gcpCmd.Flags().BoolVar(&allowMutating, "allow-mutating", false, "Run specs whose commands look destructive (rm, dd, mkfs, reboot, redirects into files)")
+	gcpCmd.Flags().StringArrayVar(&specVars, "var", nil, "Value for ${var.name} placeholders in the spec, as name=value (repeatable; overrides the spec's variables)")
+	gcpCmd.Flags().BoolVar(&allowMutating, "assume-yes", false, "Alias for --allow-mutating")
 
 	// Kubernetes command flags
 	kubernetesCmd.Flags().StringVar(&kubeconfig, "kubeconfig", "", "Path to kubeconfig file (default: ~/.kube/config)")
 	kubernetesCmd.Flags().StringVar(&kubeContext, "context", "", "Kubernetes context to use")
 	kubernetesCmd.Flags().StringVar(&kubeNamespace, "namespace", "", "Default namespace for tests")
-	kubernetesCmd.Flags().StringVarP(&outputFormat, "output", "o", "human", "Output format (human, json, junit)")
+	kubernetesCmd.Flags().BoolVar(&kubeDryRun, "dry-run", false, "Print the kubectl and helm commands the spec's kubernetes tests would run, without running them")
+	kubernetesCmd.Flags().StringVarP(&outputFormat, "output", "o", "human", "Output format (human, json, junit, tap, ndjson)")
 	kubernetesCmd.Flags().BoolVarP(&verbose, "verbose", "v", false, "Verbose output")
 	kubernetesCmd.Flags().BoolVar(&noColor, "no-color", false, "Disable colored output")
+	kubernetesCmd.Flags().StringVar(&resultsDB, "results-db", "", "Append per-test results to this SQLite database for trend tracking")
+	kubernetesCmd.Flags().StringVar(&baselineSave, "baseline-save", "", "Write normalized results (names and statuses only) to this JSON file as a baseline")
+	kubernetesCmd.Flags().StringVar(&jsonFile, "json-file", "", "Also write results as JSON to this file")
+	kubernetesCmd.Flags().StringVar(&junitFile, "junit-file", "", "Also write results as JUnit XML to this file")
+	kubernetesCmd.Flags().IntVar(&maxOutput, "max-output-lines", 0, "Truncate result messages and captured output to this many lines (0 = no limit)")
+	kubernetesCmd.Flags().BoolVar(&fullOutput, "full-output", false, "Keep JSON output untruncated when --max-output-lines is set")
+	kubernetesCmd.Flags().BoolVar(&prettyJSON, "pretty", true, "Indent JSON output for people; --pretty=false writes compact single-line JSON for ingestion")
+	kubernetesCmd.Flags().BoolVar(&showCoverage, "coverage", false, "Summarize how many tests of each category ran, passed, failed and were skipped")
+	kubernetesCmd.Flags().BoolVar(&failFast, "fail-fast", false, "Stop at the first failing test (overrides the spec's config.fail_fast)")
+	kubernetesCmd.Flags().BoolVar(&noFailFast, "no-fail-fast", false, "Run every test even if the spec sets config.fail_fast")
+	kubernetesCmd.Flags().StringVar(&testOrder, "order", "", "Order tests run and are reported in: category, name, or declaration (default: the spec's config.order, else category)")
+	kubernetesCmd.Flags().StringSliceVar(&categories, "categories", nil, "Only run tests of these categories, e.g. packages,services or kubernetes (others are reported as skipped)")
+	kubernetesCmd.Flags().StringSliceVar(&skipCategories, "skip-categories", nil, "Skip tests of these categories, e.g. kubernetes or gcp.buckets")
+	kubernetesCmd.Flags().BoolVar(&allowMutating, "allow-mutating", false, "Run specs whose commands look destructive (rm, dd, mkfs, reboot, redirects into files)")
+	kubernetesCmd.Flags().StringArrayVar(&specVars, "var", nil, "Value for ${var.name} placeholders in the spec, as name=value (repeatable; overrides the spec's variables)")
+	kubernetesCmd.Flags().BoolVar(&allowMutating, "assume-yes", false, "Alias for --allow-mutating")
+	kubernetesCmd.Flags().StringVar(&httpProxy, "http-proxy", "", "Default proxy URL for HTTP tests (NO_PROXY is honored)")
+	kubernetesCmd.Flags().StringVar(&testShell, "shell", "", "Shell that runs test commands on the target (default: the spec's config.shell, else /bin/sh)")
+	kubernetesCmd.Flags().StringArrayVar(&testEnv, "env", nil, "Environment variable to export for test commands, as KEY=VALUE (repeatable; overrides config.env)")
+	kubernetesCmd.Flags().StringArrayVar(&testSensitiveEnv, "sensitive-env", nil, "Like --env, but the value is redacted from output (repeatable)")
 
 	// Add subcommands to test
 	testCmd.AddCommand(remoteCmd)
 	testCmd.AddCommand(localCmd)
 	testCmd.AddCommand(awsCmd)
 	testCmd.AddCommand(openstackCmd)
+	testCmd.AddCommand(gcpCmd)
 	testCmd.AddCommand(kubernetesCmd)
+	testCmd.AddCommand(winrmCmd)
 }
 
 // parseParallelFlag parses the --parallel flag value and returns the number of workers
@@ -189,20 +462,322 @@ func parseParallelFlag(parallelStr string, maxParallel int) (int, error) {
 	return workers, nil
 }
 
+// parseSpec parses a spec file, substituting --var values for ${var.name}
+// placeholders
+func parseSpec(path string) (*core.Spec, error) {
+	vars, err := core.ParseVariables(specVars)
+	if err != nil {
+		return nil, fmt.Errorf("invalid --var: %w", err)
+	}
+	return core.ParseSpecWithVariables(path, vars)
+}
+
+// applySpecOverrides applies CLI flag overrides to a parsed spec
+func applySpecOverrides(spec *core.Spec) error {
+	if failFast && noFailFast {
+		return fmt.Errorf("--fail-fast and --no-fail-fast cannot be combined")
+	}
+	if failFast {
+		spec.Config.FailFast = true
+	}
+	if noFailFast {
+		spec.Config.FailFast = false
+	}
+	if testOrder != "" {
+		spec.Config.Order = testOrder
+	}
+	if testShell != "" {
+		spec.Config.Shell = testShell
+	}
+	for _, assignment := range testEnv {
+		if err := setSpecEnv(spec, assignment, false); err != nil {
+			return fmt.Errorf("invalid --env: %w", err)
+		}
+	}
+	for _, assignment := range testSensitiveEnv {
+		if err := setSpecEnv(spec, assignment, true); err != nil {
+			return fmt.Errorf("invalid --sensitive-env: %w", err)
+		}
+	}
+	if httpProxy != "" {
+		if err := core.ValidateProxyURL(httpProxy); err != nil {
+			return fmt.Errorf("invalid --http-proxy: %w", err)
+		}
+		spec.ApplyHTTPProxy(httpProxy, noProxyFromEnv())
+	}
+	if !allowMutating {
+		if err := spec.CheckMutatingCommands(); err != nil {
+			return fmt.Errorf("%w; pass --allow-mutating to run it anyway", err)
+		}
+	}
+	return nil
+}
+
+// testContext returns a background context carrying the facts from
+// --seed-facts and the --categories/--skip-categories filter, if set
+func testContext() context.Context {
+	ctx := context.Background()
+	if seedFacts != "" {
+		facts, err := core.LoadFacts(seedFacts)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Invalid --seed-facts: %v\n", err)
+			os.Exit(1)
+		}
+		ctx = core.WithSeedFacts(ctx, facts)
+	}
+	filter, err := core.NewCategoryFilter(categories, skipCategories)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Invalid --categories/--skip-categories: %v\n", err)
+		os.Exit(1)
+	}
+	if filter != nil {
+		ctx = core.WithCategoryFilter(ctx, filter)
+	}
+	if verbose {
+		ctx = core.WithRetryHook(ctx, func(result core.Result, attempt int, nextDelay time.Duration) {
+			fmt.Fprintf(os.Stderr, "Check %d of '%s' failed: %s; retrying in %s\n", attempt, result.Name, result.Message, nextDelay)
+		})
+	}
+	return ctx
+}
+
+// setSpecEnv adds a KEY=VALUE assignment to the spec's config.env, marking
+// the key sensitive if requested
+func setSpecEnv(spec *core.Spec, assignment string, sensitive bool) error {
+	key, value, err := core.ParseEnvAssignment(assignment)
+	if err != nil {
+		return err
+	}
+	if spec.Config.Env == nil {
+		spec.Config.Env = make(map[string]string)
+	}
+	spec.Config.Env[key] = value
+	if sensitive && !slices.Contains(spec.Config.SensitiveEnv, key) {
+		spec.Config.SensitiveEnv = append(spec.Config.SensitiveEnv, key)
+	}
+	return nil
+}
+
+// checkSandboxedCommands rejects a spec whose hooks or command tests would run
+// binaries outside the local provider's sandbox allowlist, before any test is
+// executed. A spec's shell and env would apply to every command, so could run
+// another binary or change which one a name finds, and are rejected too.
+func checkSandboxedCommands(spec *core.Spec, provider *local.Provider) error {
+	if provider.Allowlist() == nil {
+		return nil
+	}
+	if spec.Config.Shell != "" {
+		return fmt.Errorf("config: shell is not allowed in sandbox mode")
+	}
+	if len(spec.Config.Env) > 0 {
+		return fmt.Errorf("config: env is not allowed in sandbox mode")
+	}
+	for i, hook := range spec.Config.Hooks.Before {
+		if err := provider.CheckCommand(hook); err != nil {
+			return fmt.Errorf("before hook %d: %w", i, err)
+		}
+	}
+	for i, hook := range spec.Config.Hooks.After {
+		if err := provider.CheckCommand(hook); err != nil {
+			return fmt.Errorf("after hook %d: %w", i, err)
+		}
+	}
+	return checkSandboxedTests(&spec.Tests, provider)
+}
+
+// checkSandboxedTests checks the command tests in a test set, including those
+// nested in composite tests
+func checkSandboxedTests(tests *core.Tests, provider *local.Provider) error {
+	for _, test := range tests.CommandContent {
+		if test.RunAs != "" {
+			return fmt.Errorf("command_content test '%s': run_as is not allowed in sandbox mode", test.Name)
+		}
+		if err := provider.CheckCommand(test.Command); err != nil {
+			return fmt.Errorf("command_content test '%s': %w", test.Name, err)
+		}
+	}
+	for _, test := range tests.CommandJSON {
+		if test.RunAs != "" {
+			return fmt.Errorf("command_json test '%s': run_as is not allowed in sandbox mode", test.Name)
+		}
+		if err := provider.CheckCommand(test.Command); err != nil {
+			return fmt.Errorf("command_json test '%s': %w", test.Name, err)
+		}
+	}
+	for _, test := range tests.Metrics {
+		if err := provider.CheckCommand(test.Command); err != nil {
+			return fmt.Errorf("metric test '%s': %w", test.Name, err)
+		}
+	}
+	for i := range tests.Composite {
+		if err := checkSandboxedTests(tests.Composite[i].Group(), provider); err != nil {
+			return fmt.Errorf("composite test '%s': %w", tests.Composite[i].Name, err)
+		}
+	}
+	return nil
+}
+
+// noProxyFromEnv returns the NO_PROXY entries from the environment
+func noProxyFromEnv() []string {
+	value := os.Getenv("NO_PROXY")
+	if value == "" {
+		value = os.Getenv("no_proxy")
+	}
+	if value == "" {
+		return nil
+	}
+	return strings.Split(value, ",")
+}
+
+// recordResults appends results to the --results-db database. Database errors
+// are reported as warnings so they never change the outcome of a run.
+func recordResults(results []*core.TestResults) {
+	if resultsDB == "" {
+		return
+	}
+
+	ctx := context.Background()
+	db, err := store.Open(ctx, resultsDB)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		return
+	}
+	defer db.Close()
+
+	if err := db.Record(ctx, time.Now(), results...); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to write results to %s: %v\n", resultsDB, err)
+	}
+}
+
+// targetResults wraps the results of a single-target run so they can be
+// written to the same sinks as a multi-host run
+func targetResults(results []*core.TestResults) *core.MultiHostResults {
+	host := &core.HostResults{Connected: true, SpecResults: results}
+	for _, r := range results {
+		host.Target = r.Target
+		host.Duration += r.Duration
+	}
+	return &core.MultiHostResults{Hosts: []*core.HostResults{host}, TotalDuration: host.Duration}
+}
+
+// writeOutputs renders results to the --json-file and --junit-file files when
+// set, and to stdout in the --output format if toStdout is true
+func writeOutputs(results *core.MultiHostResults, toStdout bool) {
+	var sinks []output.Sink
+	if toStdout {
+		sinks = append(sinks, output.Sink{Format: outputFormat, Out: os.Stdout})
+	}
+	files := []struct{ path, format string }{
+		{jsonFile, "json"},
+		{junitFile, "junit"},
+	}
+	for _, file := range files {
+		if file.path == "" {
+			continue
+		}
+		f, err := os.Create(file.path)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: failed to create %s output file: %v\n", file.format, err)
+			os.Exit(1)
+		}
+		defer f.Close()
+		sinks = append(sinks, output.Sink{Format: file.format, Out: f})
+	}
+
+	if err := output.WriteSinks(results, sinks...); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// saveBaseline writes results to the --baseline-save file
+func saveBaseline(results []*core.TestResults) {
+	if baselineSave == "" {
+		return
+	}
+	if err := output.WriteBaseline(baselineSave, results); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// resolveBecome applies --sudo to the become user and returns the sudo
+// password, read from stdin with --sudo-password-stdin or else from
+// $SUDO_PASSWORD
+func resolveBecome() (string, error) {
+	if useSudo {
+		if becomeUser != "" && becomeUser != "root" {
+			return "", fmt.Errorf("--sudo and --become-user %s cannot be combined", becomeUser)
+		}
+		becomeUser = "root"
+	}
+	if !sudoPasswordStdin {
+		return os.Getenv("SUDO_PASSWORD"), nil
+	}
+	if becomeUser == "" {
+		return "", fmt.Errorf("--sudo-password-stdin requires --sudo or --become-user")
+	}
+	line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil && err != io.EOF {
+		return "", fmt.Errorf("failed to read sudo password from stdin: %w", err)
+	}
+	password := strings.TrimRight(line, "\r\n")
+	if password == "" {
+		return "", fmt.Errorf("--sudo-password-stdin given but stdin held no password")
+	}
+	return password, nil
+}
+
+// commandRetries returns the command retry configuration for one host. With
+// --verbose, each retried command is reported with its attempt number.
+func commandRetries(config *retry.Config, host string) *retry.Config {
+	if config == nil || !verbose {
+		return config
+	}
+	hostConfig := *config
+	hostConfig.OnRetry = func(attempt int, err error, nextDelay time.Duration) {
+		fmt.Fprintf(os.Stderr, "Command on %s failed (attempt %d of %d): %v; retrying in %s\n", host, attempt, config.MaxRetries+1, err, nextDelay)
+	}
+	return &hostConfig
+}
+
+// printConnectionAttempts reports each failed connection attempt of a host,
+// how it was classified and how long the backoff before the next one was
+func printConnectionAttempts(hostResults *core.HostResults) {
+	for _, a := range hostResults.Attempts {
+		class := "non-retryable"
+		if a.Retryable {
+			class = "retryable"
+		}
+		next := ""
+		if a.NextDelay > 0 {
+			next = fmt.Sprintf("; retrying in %s", a.NextDelay)
+		}
+		fmt.Fprintf(os.Stderr, "Connection attempt %d to %s failed (%s): %s%s\n", a.Attempt, hostResults.Target, class, a.Error, next)
+	}
+}
+
 // testSingleHost tests a single host with the given specs
-func testSingleHost(ctx context.Context, host, user string, specs []*core.Spec, config *remote.Config) (*core.HostResults, error) {
+func testSingleHost(ctx context.Context, host, user string, specs []*core.Spec, config *remote.Config, artifacts []core.ArtifactCommand) (*core.HostResults, error) {
 	startTime := time.Now()
 	hostResults := &core.HostResults{
 		Target:    fmt.Sprintf("%s@%s", user, host),
 		Connected: false,
 	}
 
-	// Create remote provider
+	// Create remote provider, instrumented to collect connection metrics
 	remoteProvider := remote.NewProvider(config)
+	provider := core.NewInstrumentedProvider(remoteProvider)
 
 	// Connect to target
-	if err := remoteProvider.Connect(ctx); err != nil {
+	err := provider.Connect(ctx)
+	hostResults.Attempts = remoteProvider.ConnectionAttempts()
+	if verbose {
+		printConnectionAttempts(hostResults)
+	}
+	if err != nil {
 		hostResults.ConnectionError = err
+		hostResults.Metrics = provider.Metrics()
 		hostResults.Duration = time.Since(startTime)
 		return hostResults, err
 	}
@@ -211,13 +786,17 @@ func testSingleHost(ctx context.Context, host, user string, specs []*core.Spec,
 	hostResults.Connected = true
 
 	if verbose {
-		fmt.Printf("Connected to %s@%s\n\n", user, host)
+		fmt.Printf("Connected to %s@%s (%.2fs)\n\n", user, host, provider.Metrics().ConnectDuration.Seconds())
 	}
 
-	// Execute tests for each spec file
+	// Execute tests for each spec file, stopping once the host is out of time
 	for _, spec := range specs {
+		if ctx.Err() != nil {
+			break
+		}
+
 		// Execute tests with plugins
-		executor := core.NewExecutor(spec, remoteProvider, system.NewSystemPlugin(), k8splugin.NewKubernetesPlugin())
+		executor := core.NewExecutor(spec, provider, system.NewSystemPlugin(), k8splugin.NewKubernetesPlugin())
 		results, err := executor.Execute(ctx)
 		if err != nil {
 			return nil, fmt.Errorf("failed to execute tests: %w", err)
@@ -227,6 +806,22 @@ func testSingleHost(ctx context.Context, host, user string, specs []*core.Spec,
 		hostResults.SpecResults = append(hostResults.SpecResults, results)
 	}
 
+	// Gather debugging context from hosts with failures while still connected
+	if err := core.CollectArtifacts(ctx, provider, hostResults, artifactsDir, artifacts); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: collecting artifacts from %s@%s: %v\n", user, host, err)
+	}
+	if verbose {
+		for _, path := range hostResults.Artifacts {
+			fmt.Printf("Saved artifact %s\n", path)
+		}
+	}
+
+	hostResults.Metrics = provider.Metrics()
+	if verbose {
+		fmt.Printf("Connection metrics for %s@%s: connect %.2fs, %d commands, %d bytes transferred\n\n",
+			user, host, hostResults.Metrics.ConnectDuration.Seconds(), hostResults.Metrics.CommandCount, hostResults.Metrics.BytesTransferred)
+	}
+
 	hostResults.Duration = time.Since(startTime)
 	return hostResults, nil
 }
@@ -237,8 +832,29 @@ func runRemoteTest(cmd *cobra.Command, args []string) {
 
 	// Determine mode and parse arguments
 	var hosts []string
+	var hostLabels map[string]map[string]string
 	var specFiles []string
-	var defaultUser string
+
+	// Hosts without a user@ prefix get their user from these sources
+	userSources, err := remote.ParseUserSources(userFallback)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Invalid --user-fallback: %v\n", err)
+		os.Exit(1)
+	}
+	sshAlgorithms := remote.Algorithms{KeyExchanges: sshKex, Ciphers: sshCiphers, HostKeys: sshHostKeyAlgos}
+	if err := sshAlgorithms.Validate(); err != nil {
+		fmt.Fprintf(os.Stderr, "Invalid SSH algorithms: %v\n", err)
+		os.Exit(1)
+	}
+	if forwardAgent && os.Getenv("SSH_AUTH_SOCK") == "" {
+		fmt.Fprintf(os.Stderr, "Error: --forward-agent requires a running SSH agent (SSH_AUTH_SOCK is not set)\n")
+		os.Exit(1)
+	}
+	becomePassword, err := resolveBecome()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
 
 	if inventoryFile != "" {
 		// Inventory mode: all args are spec files
@@ -254,11 +870,21 @@ func runRemoteTest(cmd *cobra.Command, args []string) {
 			fmt.Fprintf(os.Stderr, "Failed to parse inventory file: %v\n", err)
 			os.Exit(1)
 		}
+		if len(inventoryLimit) > 0 {
+			selectors, err := inventory.ParseSelectors(inventoryLimit)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			inv = inv.Limit(selectors)
+			if len(inv.Hosts) == 0 {
+				fmt.Fprintf(os.Stderr, "Error: no inventory hosts match --limit %s\n", strings.Join(inventoryLimit, ","))
+				os.Exit(1)
+			}
+		}
+		// Inventory entries can optionally include a user@ prefix
 		hosts = inv.Hosts
-
-		// In inventory mode, user comes from flags or defaults to root
-		// Note: Inventory entries can optionally include user@ prefix
-		defaultUser = "root"
+		hostLabels = inv.Labels
 
 		if verbose {
 			fmt.Printf("Inventory mode: %d hosts from %s\n", len(hosts), inventoryFile)
@@ -266,6 +892,11 @@ func runRemoteTest(cmd *cobra.Command, args []string) {
 			fmt.Printf("\n")
 		}
 	} else {
+		if len(inventoryLimit) > 0 {
+			fmt.Fprintf(os.Stderr, "Error: --limit requires --inventory\n")
+			os.Exit(1)
+		}
+
 		// Single-host mode: first arg is target, rest are spec files
 		if len(args) < 2 {
 			fmt.Fprintf(os.Stderr, "Error: target and at least one spec file required\n")
@@ -276,15 +907,13 @@ func runRemoteTest(cmd *cobra.Command, args []string) {
 		target := args[0]
 		specFiles = args[1:]
 
-		// Parse target to extract user and host
-		user, host, err := remote.ParseTarget(target, "")
-		if err != nil {
+		// Check the target now; the user is resolved with the other hosts below
+		if _, _, err := remote.ResolveTarget(target, remoteUser, userSources); err != nil {
 			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 			os.Exit(1)
 		}
 
-		hosts = []string{host}
-		defaultUser = user
+		hosts = []string{target}
 
 		if verbose {
 			fmt.Printf("Target: %s\n", target)
@@ -296,11 +925,15 @@ func runRemoteTest(cmd *cobra.Command, args []string) {
 	// Parse and validate spec files FIRST (fail fast)
 	var specs []*core.Spec
 	for _, specFile := range specFiles {
-		spec, err := core.ParseSpec(specFile)
+		spec, err := parseSpec(specFile)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Failed to parse spec %s: %v\n", specFile, err)
 			os.Exit(1)
 		}
+		if err := applySpecOverrides(spec); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
 		specs = append(specs, spec)
 	}
 
@@ -311,6 +944,24 @@ func runRemoteTest(cmd *cobra.Command, args []string) {
 		os.Exit(1)
 	}
 
+	if haltOnHostFailure && workers > 1 {
+		fmt.Fprintf(os.Stderr, "Error: --halt-on-host-failure tests hosts in order and cannot be combined with --parallel\n")
+		os.Exit(1)
+	}
+
+	var bakeTimeLimit time.Duration
+	if bakeTime != "" {
+		bakeTimeLimit, err = time.ParseDuration(bakeTime)
+		if err != nil || bakeTimeLimit <= 0 {
+			fmt.Fprintf(os.Stderr, "Invalid --bake-time: %s (must be a positive duration, e.g. 5m)\n", bakeTime)
+			os.Exit(1)
+		}
+		if workers > 1 {
+			fmt.Fprintf(os.Stderr, "Error: --bake-time tests hosts in order and cannot be combined with --parallel\n")
+			os.Exit(1)
+		}
+	}
+
 	if verbose && workers > 1 {
 		fmt.Printf("Parallel execution: %d workers\n", workers)
 		if failFast {
@@ -349,9 +1000,19 @@ func runRemoteTest(cmd *cobra.Command, args []string) {
 		fmt.Printf("\n")
 	}
 
-	// Parse retry configuration
-	var retryConfig *retry.Config
-	if retries > 0 {
+	if maxReconnects < 1 {
+		fmt.Fprintf(os.Stderr, "Invalid --max-reconnects: %d (must be at least 1)\n", maxReconnects)
+		os.Exit(1)
+	}
+
+	if testRetries < 0 {
+		fmt.Fprintf(os.Stderr, "Invalid --test-retries: %d (must not be negative)\n", testRetries)
+		os.Exit(1)
+	}
+
+	// Parse retry configuration, shared by connection and command retries
+	var retryConfig, commandRetryConfig *retry.Config
+	if retries > 0 || testRetries > 0 {
 		initialDelay, err := time.ParseDuration(retryDelay)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Invalid --retry-delay: %v\n", err)
@@ -372,16 +1033,81 @@ func runRemoteTest(cmd *cobra.Command, args []string) {
 			strategy = retry.StrategyExponential
 		case "jittered":
 			strategy = retry.StrategyJittered
+		case "decorrelated":
+			strategy = retry.StrategyDecorrelated
 		default:
-			fmt.Fprintf(os.Stderr, "Invalid --retry-backoff: %s (must be linear, exponential, or jittered)\n", retryBackoff)
+			fmt.Fprintf(os.Stderr, "Invalid --retry-backoff: %s (must be linear, exponential, jittered, or decorrelated)\n", retryBackoff)
 			os.Exit(1)
 		}
 
-		retryConfig = &retry.Config{
-			MaxRetries:   retries,
-			InitialDelay: initialDelay,
-			MaxDelay:     maxDelay,
-			Strategy:     strategy,
+		if retries > 0 {
+			retryConfig = &retry.Config{
+				MaxRetries:   retries,
+				InitialDelay: initialDelay,
+				MaxDelay:     maxDelay,
+				Strategy:     strategy,
+			}
+		}
+		if testRetries > 0 {
+			commandRetryConfig = &retry.Config{
+				MaxRetries:   testRetries,
+				InitialDelay: initialDelay,
+				MaxDelay:     maxDelay,
+				Strategy:     strategy,
+			}
+		}
+	}
+
+	var heartbeatInterval time.Duration
+	if heartbeat != "" {
+		heartbeatInterval, err = time.ParseDuration(heartbeat)
+		if err != nil || heartbeatInterval <= 0 {
+			fmt.Fprintf(os.Stderr, "Invalid --heartbeat: %s (must be a positive duration, e.g. 60s)\n", heartbeat)
+			os.Exit(1)
+		}
+	}
+
+	var hostTimeoutLimit time.Duration
+	if hostTimeout != "" {
+		hostTimeoutLimit, err = time.ParseDuration(hostTimeout)
+		if err != nil || hostTimeoutLimit <= 0 {
+			fmt.Fprintf(os.Stderr, "Invalid --timeout-per-host: %s (must be a positive duration, e.g. 5m)\n", hostTimeout)
+			os.Exit(1)
+		}
+	}
+
+	var artifacts []core.ArtifactCommand
+	for _, spec := range collectOnFailure {
+		artifact, err := core.ParseArtifactCommand(spec)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Invalid --collect-on-failure: %v\n", err)
+			os.Exit(1)
+		}
+		artifacts = append(artifacts, artifact)
+	}
+
+	// Share one circuit breaker per jump host across all hosts in this run
+	if jumpBreakerThreshold < 0 {
+		fmt.Fprintf(os.Stderr, "Invalid --jump-breaker-threshold: %d (must be 0 to disable, or at least 1)\n", jumpBreakerThreshold)
+		os.Exit(1)
+	}
+	cooldown, err := time.ParseDuration(jumpBreakerCooldown)
+	if err != nil || cooldown <= 0 {
+		fmt.Fprintf(os.Stderr, "Invalid --jump-breaker-cooldown: %s (must be a positive duration, e.g. 30s)\n", jumpBreakerCooldown)
+		os.Exit(1)
+	}
+	var jumpBreaker *retry.Breaker
+	if parsedJumpHost != "" && jumpBreakerThreshold > 0 {
+		jumpBreaker = retry.NewBreaker(jumpBreakerThreshold, cooldown)
+	}
+
+	// Build retry classifier from user-supplied patterns
+	var retryClassifier retry.ErrorClassifier
+	if len(retryIf) > 0 || len(noRetryIf) > 0 {
+		retryClassifier, err = retry.NewPatternClassifier(retryIf, noRetryIf)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
 		}
 	}
 
@@ -398,7 +1124,7 @@ func runRemoteTest(cmd *cobra.Command, args []string) {
 	var jobs []core.HostJob
 	for _, hostEntry := range hosts {
 		// Parse host entry - may be "host" or "user@host"
-		parsedUser, parsedHost, err := remote.ParseTarget(hostEntry, defaultUser)
+		parsedUser, parsedHost, err := remote.ResolveTarget(hostEntry, remoteUser, userSources)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Failed to parse host entry '%s': %v\n", hostEntry, err)
 			fmt.Print(output.PrintFailed())
@@ -410,60 +1136,70 @@ func runRemoteTest(cmd *cobra.Command, args []string) {
 			Host:                  parsedHost,
 			Port:                  remotePort,
 			User:                  parsedUser,
+			BecomeUser:            becomeUser,
+			BecomePassword:        becomePassword,
 			IdentityFile:          identityFile,
 			Timeout:               time.Duration(timeout) * time.Second,
 			StrictHostKeyChecking: strictHostKeyChecking,
 			KnownHostsFile:        knownHostsFile,
 			InsecureIgnoreHostKey: insecureIgnoreHostKey,
+			AcceptNewHostKeys:     acceptNewHostKeys,
+			Algorithms:            sshAlgorithms,
+			ForwardAgent:          forwardAgent,
 			JumpHost:              parsedJumpHost,
 			JumpPort:              jumpPort,
 			JumpUser:              parsedJumpUser,
 			JumpIdentityFile:      jumpIdentityFile,
 			RetryConfig:           retryConfig,
+			CommandRetryConfig:    commandRetries(commandRetryConfig, hostEntry),
+			RetryClassifier:       retryClassifier,
+			JumpBreaker:           jumpBreaker,
+			MaxReconnects:         maxReconnects,
 		}
 
 		jobs = append(jobs, core.HostJob{
 			HostEntry: hostEntry,
 			User:      parsedUser,
+			Labels:    hostLabels[hostEntry],
 			Config:    config,
 		})
 	}
 
+	// Keep CI jobs with idle-output timeouts alive during long runs
+	var hb *core.Heartbeat
+	if heartbeatInterval > 0 {
+		hb = core.NewHeartbeat(os.Stderr, heartbeatInterval, len(jobs))
+	}
+
 	// Create test function wrapper
 	testFunc := func(ctx context.Context, job core.HostJob) (*core.HostResults, error) {
 		config := job.Config.(*remote.Config)
-		return testSingleHost(ctx, config.Host, config.User, specs, config)
+		if hb != nil {
+			defer hb.HostDone()
+		}
+		return testSingleHost(ctx, config.Host, config.User, specs, config, artifacts)
+	}
+	if hostTimeoutLimit > 0 {
+		testFunc = core.WithHostTimeout(hostTimeoutLimit, testFunc)
 	}
 
 	// Execute tests (sequential or parallel based on workers)
-	ctx := context.Background()
+	ctx := testContext()
+	if hb != nil {
+		hb.Start(ctx)
+	}
 	var multiResults *core.MultiHostResults
 	overallStart := time.Now()
 
 	if workers == 1 {
 		// Sequential execution (backward compatible)
-		multiResults = &core.MultiHostResults{
-			Hosts: make([]*core.HostResults, 0, len(jobs)),
-		}
-
-		for _, job := range jobs {
-			hostResult, err := testFunc(ctx, job)
-			if err != nil && hostResult == nil {
-				// Unexpected error (not a connection error)
-				fmt.Fprintf(os.Stderr, "Failed to test host: %v\n", err)
-				fmt.Print(output.PrintFailed())
-				os.Exit(1)
-			}
-
-			multiResults.Hosts = append(multiResults.Hosts, hostResult)
-
-			// Check fail-fast in sequential mode
-			if failFast && !hostResult.Success() {
-				if verbose {
-					fmt.Fprintf(os.Stderr, "Fail-fast: stopping due to failure on %s\n", hostResult.Target)
-				}
-				break
-			}
+		executor := core.NewSequentialExecutor(failFast, haltOnHostFailure, verbose)
+		executor.SetBakeTime(bakeTimeLimit)
+		multiResults, err = executor.Execute(ctx, jobs, testFunc)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to test host %v\n", err)
+			fmt.Print(output.PrintFailed())
+			os.Exit(1)
 		}
 	} else {
 		// Parallel execution
@@ -481,40 +1217,27 @@ func runRemoteTest(cmd *cobra.Command, args []string) {
 		}
 	}
 
+	if hb != nil {
+		hb.Stop()
+	}
 	multiResults.TotalDuration = time.Since(overallStart)
 
-	// Output results
-	if len(hosts) == 1 {
-		// Single-host mode: use existing output format for backward compatibility
-		hostResult := multiResults.Hosts[0]
-
-		if !hostResult.Connected {
-			fmt.Fprintf(os.Stderr, "Failed to connect: %v\n", hostResult.ConnectionError)
-			fmt.Print(output.PrintFailed())
-			os.Exit(1)
-		}
+	// Output results. A single unreachable host keeps its short console
+	// report, but output files still record the connection error.
+	if len(hosts) == 1 && !multiResults.Hosts[0].Connected {
+		fmt.Fprintf(os.Stderr, "Failed to connect: %v\n", multiResults.Hosts[0].ConnectionError)
+		writeOutputs(multiResults, false)
+		fmt.Print(output.PrintFailed())
+		os.Exit(1)
+	}
+	writeOutputs(multiResults, true)
 
-		for _, results := range hostResult.SpecResults {
-			switch outputFormat {
-			case "json":
-				fmt.Println("JSON output not yet implemented")
-			case "junit":
-				fmt.Println("JUnit output not yet implemented")
-			default:
-				fmt.Print(output.FormatHuman(results))
-			}
-		}
-	} else {
-		// Multi-host mode: use multi-host output format
-		switch outputFormat {
-		case "json":
-			fmt.Println("JSON output not yet implemented for multi-host")
-		case "junit":
-			fmt.Println("JUnit output not yet implemented for multi-host")
-		default:
-			fmt.Print(output.FormatMultiHostHuman(multiResults))
-		}
+	var allResults []*core.TestResults
+	for _, hostResult := range multiResults.Hosts {
+		allResults = append(allResults, hostResult.SpecResults...)
 	}
+	recordResults(allResults)
+	saveBaseline(allResults)
 
 	// Exit with error code if any tests failed
 	if !multiResults.Success() {
@@ -536,18 +1259,105 @@ func runLocalTest(cmd *cobra.Command, args []string) {
 
 	// Create local provider
 	localProvider := local.NewProvider()
+	if sandbox {
+		allowlist := append(append([]string{}, local.DefaultAllowlist...), sandboxAllow...)
+		localProvider = local.NewSandboxedProvider(allowlist)
+		if verbose {
+			fmt.Printf("Sandbox allowlist: %s\n\n", strings.Join(localProvider.Allowlist(), ", "))
+		}
+	}
 
-	// Execute tests for each spec file
-	ctx := context.Background()
+	// Refuse to start on a machine too constrained to run the checks
+	minimum, err := local.ParseMinResources(minResources)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Invalid --min-resources: %v\n", err)
+		os.Exit(1)
+	}
+	if err := local.CheckResources(minimum, local.SystemResources{}, "/"); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: not enough free resources to run tests: %v\n", err)
+		os.Exit(1)
+	}
+
+	var watchInterval time.Duration
+	if watch != "" {
+		watchInterval, err = time.ParseDuration(watch)
+		if err != nil || watchInterval <= 0 {
+			fmt.Fprintf(os.Stderr, "Invalid --watch: %s (must be a positive duration, e.g. 10s)\n", watch)
+			os.Exit(1)
+		}
+	}
+	if changesOnly && watch == "" {
+		fmt.Fprintf(os.Stderr, "Error: --changes-only requires --watch\n")
+		os.Exit(1)
+	}
+
+	ctx := testContext()
+	var allResults []*core.TestResults
+	if watchInterval > 0 {
+		watchCtx, stop := signal.NotifyContext(ctx, os.Interrupt)
+		allResults = core.Watch(watchCtx, watchInterval, changesOnly, func(context.Context) []*core.TestResults {
+			// Each run finishes even if interrupted; the watch ends after it
+			return runLocalSpecs(ctx, localProvider, specFiles)
+		}, printWatchIteration)
+		stop()
+		writeOutputs(targetResults(allResults), false)
+	} else {
+		allResults = runLocalSpecs(ctx, localProvider, specFiles)
+		writeOutputs(targetResults(allResults), true)
+	}
+
+	recordResults(allResults)
+	saveBaseline(allResults)
+
+	// Exit with error code if any tests failed
+	for _, results := range allResults {
+		if !results.Success() {
+			os.Exit(1)
+		}
+	}
+}
+
+// printWatchIteration prints one --watch run to stdout in the --output
+// format, or a note on stderr when no test changed
+func printWatchIteration(iteration int, results []*core.TestResults, full bool) {
+	if !full {
+		changed := 0
+		for _, r := range results {
+			changed += len(r.Results)
+		}
+		fmt.Fprintf(os.Stderr, "Watch: run %d, %d test(s) changed\n", iteration, changed)
+		if changed == 0 {
+			return
+		}
+	}
+	if err := output.WriteSinks(targetResults(results), output.Sink{Format: outputFormat, Out: os.Stdout}); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// runLocalSpecs parses and runs each spec file on localhost
+func runLocalSpecs(ctx context.Context, localProvider *local.Provider, specFiles []string) []*core.TestResults {
 	var allResults []*core.TestResults
 	for _, specFile := range specFiles {
 		// Parse spec
-		spec, err := core.ParseSpec(specFile)
+		spec, err := parseSpec(specFile)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Failed to parse spec %s: %v\n", specFile, err)
 			fmt.Print(output.PrintFailed())
 			os.Exit(1)
 		}
+		// Checked before overrides, which come from the command line, not the spec
+		if err := checkSandboxedCommands(spec, localProvider); err != nil {
+			fmt.Fprintf(os.Stderr, "Spec %s rejected: %v\n", specFile, err)
+			fmt.Print(output.PrintFailed())
+			os.Exit(1)
+		}
+		if err := applySpecOverrides(spec); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			fmt.Print(output.PrintFailed())
+			os.Exit(1)
+		}
 
 		// Execute tests with plugins
 		executor := core.NewExecutor(spec, localProvider, system.NewSystemPlugin(), k8splugin.NewKubernetesPlugin())
@@ -561,25 +1371,7 @@ func runLocalTest(cmd *cobra.Command, args []string) {
 		results.Target = "localhost"
 		allResults = append(allResults, results)
 	}
-
-	// Output results
-	for _, results := range allResults {
-		switch outputFormat {
-		case "json":
-			fmt.Println("JSON output not yet implemented")
-		case "junit":
-			fmt.Println("JUnit output not yet implemented")
-		default:
-			fmt.Print(output.FormatHuman(results))
-		}
-	}
-
-	// Exit with error code if any tests failed
-	for _, results := range allResults {
-		if !results.Success() {
-			os.Exit(1)
-		}
-	}
+	return allResults
 }
 
 func runKubernetesTest(cmd *cobra.Command, args []string) {
@@ -619,16 +1411,21 @@ func runKubernetesTest(cmd *cobra.Command, args []string) {
 	})
 
 	// Execute tests for each spec file
-	ctx := context.Background()
+	ctx := testContext()
 	var allResults []*core.TestResults
 	for _, specFile := range specFiles {
 		// Parse spec
-		spec, err := core.ParseSpec(specFile)
+		spec, err := parseSpec(specFile)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Failed to parse spec %s: %v\n", specFile, err)
 			fmt.Print(output.PrintFailed())
 			os.Exit(1)
 		}
+		if err := applySpecOverrides(spec); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			fmt.Print(output.PrintFailed())
+			os.Exit(1)
+		}
 
 		// Override config namespace if flag provided
 		if kubeNamespace != "" && spec.Config.KubernetesNamespace == "" {
@@ -638,6 +1435,11 @@ func runKubernetesTest(cmd *cobra.Command, args []string) {
 			spec.Config.KubernetesContext = kubeContext
 		}
 
+		if kubeDryRun {
+			printKubernetesPlan(ctx, specFile, spec, k8sProvider)
+			continue
+		}
+
 		// Execute tests with plugins
 		executor := core.NewExecutor(spec, k8sProvider, system.NewSystemPlugin(), k8splugin.NewKubernetesPlugin())
 		results, err := executor.Execute(ctx)
@@ -654,19 +1456,287 @@ func runKubernetesTest(cmd *cobra.Command, args []string) {
 		results.Target = targetStr
 		allResults = append(allResults, results)
 	}
+	if kubeDryRun {
+		return
+	}
 
 	// Output results
+	writeOutputs(targetResults(allResults), true)
+
+	recordResults(allResults)
+	saveBaseline(allResults)
+
+	// Exit with error code if any tests failed
 	for _, results := range allResults {
-		switch outputFormat {
-		case "json":
-			fmt.Println("JSON output not yet implemented")
-		case "junit":
-			fmt.Println("JUnit output not yet implemented")
-		default:
-			fmt.Print(output.FormatHuman(results))
+		if !results.Success() {
+			os.Exit(1)
+		}
+	}
+}
+
+// printKubernetesPlan prints the kubectl and helm commands a spec's kubernetes
+// tests would run, so their access scope can be reviewed before a real run
+func printKubernetesPlan(ctx context.Context, specFile string, spec *core.Spec, provider core.Provider) {
+	recorder := core.NewCommandRecorder()
+	k8splugin.NewKubernetesPlugin().Execute(core.WithCommandRecorder(ctx, recorder), spec, provider, false)
+
+	var defaults []string
+	if kubeconfig != "" {
+		defaults = append(defaults, "kubeconfig: "+kubeconfig)
+	}
+	if kubeContext != "" {
+		defaults = append(defaults, "context: "+kubeContext)
+	}
+	fmt.Printf("# %s", specFile)
+	if len(defaults) > 0 {
+		fmt.Printf(" (%s)", strings.Join(defaults, ", "))
+	}
+	fmt.Println()
+	for _, command := range recorder.Commands() {
+		fmt.Println(command)
+	}
+}
+
+func runWinRMTest(cmd *cobra.Command, args []string) {
+	target := args[0]
+	specFiles := args[1:]
+
+	// Set color output preference
+	output.NoColor = noColor
+
+	user, host, err := winrm.ParseTarget(target, "")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	if verbose {
+		fmt.Printf("Target: %s@%s (WinRM)\n", user, host)
+		fmt.Printf("Spec files: %v\n", specFiles)
+		fmt.Printf("\n")
+	}
+
+	// Create WinRM provider
+	winrmProvider := winrm.NewProvider(&winrm.Config{
+		Host:     host,
+		Port:     winrmPort,
+		User:     user,
+		Password: os.Getenv("WINRM_PASSWORD"),
+		HTTPS:    winrmHTTPS,
+		Insecure: winrmInsecure,
+		Timeout:  time.Duration(timeout) * time.Second,
+	})
+
+	ctx := testContext()
+	if err := winrmProvider.Connect(ctx); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		fmt.Print(output.PrintFailed())
+		os.Exit(1)
+	}
+	defer winrmProvider.Close()
+
+	// Execute tests for each spec file
+	var allResults []*core.TestResults
+	for _, specFile := range specFiles {
+		// Parse spec
+		spec, err := parseSpec(specFile)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to parse spec %s: %v\n", specFile, err)
+			fmt.Print(output.PrintFailed())
+			os.Exit(1)
+		}
+		if err := applySpecOverrides(spec); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			fmt.Print(output.PrintFailed())
+			os.Exit(1)
 		}
+
+		// Only system tests apply to Windows hosts
+		executor := core.NewExecutor(spec, winrmProvider, system.NewSystemPlugin())
+		results, err := executor.Execute(ctx)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to execute tests: %v\n", err)
+			fmt.Print(output.PrintFailed())
+			os.Exit(1)
+		}
+
+		results.Target = fmt.Sprintf("%s@%s", user, host)
+		allResults = append(allResults, results)
 	}
 
+	// Output results
+	writeOutputs(targetResults(allResults), true)
+
+	recordResults(allResults)
+	saveBaseline(allResults)
+
+	// Exit with error code if any tests failed
+	for _, results := range allResults {
+		if !results.Success() {
+			os.Exit(1)
+		}
+	}
+}
+
+func runOpenStackTest(cmd *cobra.Command, args []string) {
+	specFiles := args
+
+	// Set color output preference
+	output.NoColor = noColor
+
+	cloud := osCloud
+	if cloud == "" {
+		cloud = os.Getenv("OS_CLOUD")
+	}
+
+	if verbose {
+		fmt.Printf("Target: OpenStack\n")
+		if cloud != "" {
+			fmt.Printf("Cloud: %s\n", cloud)
+		}
+		if osRegion != "" {
+			fmt.Printf("Region: %s\n", osRegion)
+		}
+		fmt.Printf("Spec files: %v\n", specFiles)
+		fmt.Printf("\n")
+	}
+
+	// Create OpenStack provider
+	osProvider := openstack.NewProvider(&openstack.Config{
+		Cloud:  cloud,
+		Region: osRegion,
+	})
+
+	ctx := testContext()
+	if err := osProvider.Connect(ctx); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		fmt.Print(output.PrintFailed())
+		os.Exit(1)
+	}
+
+	// Execute tests for each spec file
+	var allResults []*core.TestResults
+	for _, specFile := range specFiles {
+		// Parse spec
+		spec, err := parseSpec(specFile)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to parse spec %s: %v\n", specFile, err)
+			fmt.Print(output.PrintFailed())
+			os.Exit(1)
+		}
+		if err := applySpecOverrides(spec); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			fmt.Print(output.PrintFailed())
+			os.Exit(1)
+		}
+
+		executor := core.NewExecutor(spec, osProvider, osplugin.NewOpenStackPlugin())
+		results, err := executor.Execute(ctx)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to execute tests: %v\n", err)
+			fmt.Print(output.PrintFailed())
+			os.Exit(1)
+		}
+
+		targetStr := "openstack"
+		if cloud != "" {
+			targetStr = fmt.Sprintf("openstack:%s", cloud)
+		}
+		results.Target = targetStr
+		allResults = append(allResults, results)
+	}
+
+	// Output results
+	writeOutputs(targetResults(allResults), true)
+
+	recordResults(allResults)
+	saveBaseline(allResults)
+
+	// Exit with error code if any tests failed
+	for _, results := range allResults {
+		if !results.Success() {
+			os.Exit(1)
+		}
+	}
+}
+
+func runGCPTest(cmd *cobra.Command, args []string) {
+	specFiles := args
+
+	// Set color output preference
+	output.NoColor = noColor
+
+	project := gcpProject
+	if project == "" {
+		project = os.Getenv("GOOGLE_CLOUD_PROJECT")
+	}
+	if project != "" {
+		if err := core.ValidateGCPProjectID(project); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	if verbose {
+		fmt.Printf("Target: GCP\n")
+		if project != "" {
+			fmt.Printf("Project: %s\n", project)
+		}
+		fmt.Printf("Spec files: %v\n", specFiles)
+		fmt.Printf("\n")
+	}
+
+	// Create GCP provider
+	gcpProvider := gcp.NewProvider(&gcp.Config{
+		Project: project,
+	})
+
+	ctx := testContext()
+	if err := gcpProvider.Connect(ctx); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		fmt.Print(output.PrintFailed())
+		os.Exit(1)
+	}
+	defer gcpProvider.Close()
+
+	// Execute tests for each spec file
+	var allResults []*core.TestResults
+	for _, specFile := range specFiles {
+		// Parse spec
+		spec, err := parseSpec(specFile)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to parse spec %s: %v\n", specFile, err)
+			fmt.Print(output.PrintFailed())
+			os.Exit(1)
+		}
+		if err := applySpecOverrides(spec); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			fmt.Print(output.PrintFailed())
+			os.Exit(1)
+		}
+
+		executor := core.NewExecutor(spec, gcpProvider, gcpplugin.NewGCPPlugin())
+		results, err := executor.Execute(ctx)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to execute tests: %v\n", err)
+			fmt.Print(output.PrintFailed())
+			os.Exit(1)
+		}
+
+		targetStr := "gcp"
+		if project != "" {
+			targetStr = fmt.Sprintf("gcp:%s", project)
+		}
+		results.Target = targetStr
+		allResults = append(allResults, results)
+	}
+
+	// Output results
+	writeOutputs(targetResults(allResults), true)
+
+	recordResults(allResults)
+	saveBaseline(allResults)
+
 	// Exit with error code if any tests failed
 	for _, results := range allResults {
 		if !results.Success() {
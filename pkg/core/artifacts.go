@@ -0,0 +1,102 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// ArtifactCommand is a diagnostic command whose output is saved to File when
+// a host has failures
+type ArtifactCommand struct {
+	Command string
+	File    string
+}
+
+var unsafeArtifactChars = regexp.MustCompile(`[^A-Za-z0-9._@]+`)
+
+// ParseArtifactCommand parses "cmd > file" or just "cmd". Without a file, the
+// name is derived from the command, e.g. "journalctl -xe" -> "journalctl-xe.log".
+func ParseArtifactCommand(spec string) (ArtifactCommand, error) {
+	command, file := spec, ""
+	if i := strings.LastIndex(spec, " > "); i >= 0 {
+		command, file = spec[:i], strings.TrimSpace(spec[i+3:])
+		if file == "" {
+			return ArtifactCommand{}, fmt.Errorf("artifact '%s': file name is empty", spec)
+		}
+	}
+	command = strings.TrimSpace(command)
+	if command == "" {
+		return ArtifactCommand{}, fmt.Errorf("artifact '%s': command is empty", spec)
+	}
+
+	if file == "" {
+		file = artifactName(command) + ".log"
+	} else if !filepath.IsLocal(file) {
+		return ArtifactCommand{}, fmt.Errorf("artifact '%s': file must be a relative path inside the host's artifact directory", spec)
+	}
+	return ArtifactCommand{Command: command, File: file}, nil
+}
+
+// artifactName reduces s to a safe file or directory name
+func artifactName(s string) string {
+	name := strings.Trim(unsafeArtifactChars.ReplaceAllString(s, "-"), "-.")
+	if len(name) > 64 {
+		name = name[:64]
+	}
+	if name == "" {
+		name = "artifact"
+	}
+	return name
+}
+
+// CollectArtifacts runs the diagnostic commands on a host that has failures and
+// saves their output under dir/<target>/. Hosts that passed, or never
+// connected, are left alone. A failing command does not stop the others; its
+// error is recorded in the artifact and returned. Written paths are recorded
+// in results.Artifacts.
+func CollectArtifacts(ctx context.Context, provider Provider, results *HostResults, dir string, commands []ArtifactCommand) error {
+	if len(commands) == 0 || !results.Connected || results.Success() {
+		return nil
+	}
+
+	hostDir := filepath.Join(dir, artifactName(results.Target))
+	if err := os.MkdirAll(hostDir, 0750); err != nil {
+		return fmt.Errorf("failed to create artifact directory: %w", err)
+	}
+
+	var errs []error
+	for _, artifact := range commands {
+		stdout, stderr, exitCode, err := provider.ExecuteCommand(ctx, artifact.Command)
+
+		var content strings.Builder
+		fmt.Fprintf(&content, "# %s\n", artifact.Command)
+		if err != nil {
+			fmt.Fprintf(&content, "# error: %v\n", err)
+			errs = append(errs, fmt.Errorf("artifact '%s': %w", artifact.Command, err))
+		} else if exitCode != 0 {
+			fmt.Fprintf(&content, "# exit code: %d\n", exitCode)
+		}
+		content.WriteString(stdout)
+		if stderr != "" {
+			content.WriteString("\n# stderr\n")
+			content.WriteString(stderr)
+		}
+
+		path := filepath.Join(hostDir, artifact.File)
+		if err := os.MkdirAll(filepath.Dir(path), 0750); err != nil {
+			errs = append(errs, fmt.Errorf("artifact '%s': %w", artifact.Command, err))
+			continue
+		}
+		if err := os.WriteFile(path, []byte(content.String()), 0600); err != nil {
+			errs = append(errs, fmt.Errorf("artifact '%s': %w", artifact.Command, err))
+			continue
+		}
+		results.Artifacts = append(results.Artifacts, path)
+	}
+	return errors.Join(errs...)
+}
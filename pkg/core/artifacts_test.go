@@ -0,0 +1,119 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParseArtifactCommand(t *testing.T) {
+	tests := []struct {
+		spec    string
+		want    ArtifactCommand
+		wantErr bool
+	}{
+		{spec: "journalctl -xe > journal.log", want: ArtifactCommand{Command: "journalctl -xe", File: "journal.log"}},
+		{spec: "journalctl -xe", want: ArtifactCommand{Command: "journalctl -xe", File: "journalctl-xe.log"}},
+		{spec: "kubectl describe pod api-0 > k8s/api-0.txt", want: ArtifactCommand{Command: "kubectl describe pod api-0", File: "k8s/api-0.txt"}},
+		{spec: "echo a > /tmp/x > out.txt", want: ArtifactCommand{Command: "echo a > /tmp/x", File: "out.txt"}},
+		{spec: "cat /etc/passwd > ../../passwd", wantErr: true},
+		{spec: "cat /etc/passwd > /tmp/passwd", wantErr: true},
+		{spec: " > out.txt", wantErr: true},
+		{spec: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			got, err := ParseArtifactCommand(tt.spec)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseArtifactCommand() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("ParseArtifactCommand() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCollectArtifacts_OnlyFailingHosts(t *testing.T) {
+	dir := t.TempDir()
+	provider := NewMockProvider()
+	provider.SetCommandResult("journalctl -xe", "nginx: bind() failed\n", "", 0, nil)
+	provider.SetCommandResult("systemctl status nginx", "Active: failed\n", "", 3, nil)
+	commands := []ArtifactCommand{
+		{Command: "journalctl -xe", File: "journal.log"},
+		{Command: "systemctl status nginx", File: "status.log"},
+	}
+
+	failing := &HostResults{
+		Target:    "root@web1",
+		Connected: true,
+		SpecResults: []*TestResults{{
+			Results: []Result{{Name: "nginx running", Status: StatusFail}},
+		}},
+	}
+	passing := &HostResults{
+		Target:    "root@web2",
+		Connected: true,
+		SpecResults: []*TestResults{{
+			Results: []Result{{Name: "nginx running", Status: StatusPass}},
+		}},
+	}
+	unreachable := &HostResults{Target: "root@web3", ConnectionError: errors.New("connection refused")}
+
+	for _, host := range []*HostResults{failing, passing, unreachable} {
+		if err := CollectArtifacts(context.Background(), provider, host, dir, commands); err != nil {
+			t.Fatalf("CollectArtifacts(%s) error = %v", host.Target, err)
+		}
+	}
+
+	journal, err := os.ReadFile(filepath.Join(dir, "root@web1", "journal.log"))
+	if err != nil {
+		t.Fatalf("failing host artifact missing: %v", err)
+	}
+	if !strings.Contains(string(journal), "nginx: bind() failed") {
+		t.Errorf("journal artifact = %q", journal)
+	}
+	status, err := os.ReadFile(filepath.Join(dir, "root@web1", "status.log"))
+	if err != nil || !strings.Contains(string(status), "# exit code: 3") {
+		t.Errorf("status artifact = %q, %v; a non-zero exit should still be saved", status, err)
+	}
+	if len(failing.Artifacts) != 2 {
+		t.Errorf("failing host Artifacts = %v, want 2 paths", failing.Artifacts)
+	}
+
+	for _, host := range []*HostResults{passing, unreachable} {
+		if _, err := os.Stat(filepath.Join(dir, host.Target)); !os.IsNotExist(err) {
+			t.Errorf("%s should have no artifact directory", host.Target)
+		}
+		if len(host.Artifacts) != 0 {
+			t.Errorf("%s Artifacts = %v, want none", host.Target, host.Artifacts)
+		}
+	}
+}
+
+func TestCollectArtifacts_CommandErrorContinues(t *testing.T) {
+	dir := t.TempDir()
+	provider := NewMockProvider()
+	provider.SetCommandResult("kubectl describe pod api-0", "", "", 0, errors.New("session closed"))
+	provider.SetCommandResult("uptime", "up 3 days\n", "", 0, nil)
+
+	host := &HostResults{
+		Target:      "root@k8s1",
+		Connected:   true,
+		SpecResults: []*TestResults{{Results: []Result{{Status: StatusError}}}},
+	}
+	err := CollectArtifacts(context.Background(), provider, host, dir, []ArtifactCommand{
+		{Command: "kubectl describe pod api-0", File: "describe.log"},
+		{Command: "uptime", File: "uptime.log"},
+	})
+	if err == nil || !strings.Contains(err.Error(), "session closed") {
+		t.Errorf("CollectArtifacts() error = %v, want the command error", err)
+	}
+	if len(host.Artifacts) != 2 {
+		t.Errorf("Artifacts = %v, want both files written", host.Artifacts)
+	}
+}
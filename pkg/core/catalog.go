@@ -0,0 +1,332 @@
+package core
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// CategoryInfo describes one test category: the YAML path it is written
+// under in a spec and the fields its tests accept
+type CategoryInfo struct {
+	Category      string      `json:"category"` // e.g. packages or kubernetes.pods
+	Fields        []FieldInfo `json:"fields"`
+	RequiresOneOf [][]string  `json:"requires_one_of,omitempty"` // At least one field of each group must be set
+}
+
+// FieldInfo describes one field of a test category
+type FieldInfo struct {
+	Name     string   `json:"name"`
+	Type     string   `json:"type"` // string, integer, number, boolean, duration, map, object, tests, or "list of <type>"
+	Required bool     `json:"required"`
+	Enum     []string `json:"enum,omitempty"`    // Allowed values
+	Default  string   `json:"default,omitempty"` // Value Validate sets when the field is omitted
+}
+
+// fieldRule records what Validate enforces for one field
+type fieldRule struct {
+	required bool
+	enum     []string
+	def      string
+}
+
+// categoryRules records what Validate enforces for one category. Every
+// category's name field is required and is not listed.
+type categoryRules struct {
+	fields map[string]fieldRule
+	oneOf  [][]string
+}
+
+var (
+	presentAbsent = []string{"present", "absent"}
+	statePresent  = fieldRule{enum: presentAbsent, def: "present"}
+	k8sNamespace  = fieldRule{def: "default"}
+	requiredField = fieldRule{required: true}
+)
+
+// catalogRules mirrors the checks and defaults in Spec.Validate. Keep it in
+// sync when adding a category or field.
+var catalogRules = map[string]categoryRules{
+	"packages": {fields: map[string]fieldRule{
+		"packages": requiredField,
+		"state":    statePresent,
+	}},
+	"files": {fields: map[string]fieldRule{
+		"path": requiredField,
+		"type": {enum: []string{"file", "directory"}, def: "file"},
+	}},
+	"services": {
+		fields: map[string]fieldRule{"state": {required: true, enum: []string{"running", "stopped"}}},
+		oneOf:  [][]string{{"service", "services"}},
+	},
+	"users": {fields: map[string]fieldRule{"user": requiredField}},
+	"groups": {fields: map[string]fieldRule{
+		"groups": requiredField,
+		"state":  statePresent,
+	}},
+	"file_content": {
+		fields: map[string]fieldRule{"path": requiredField},
+		oneOf:  [][]string{{"contains", "matches"}},
+	},
+	"command_content": {
+		fields: map[string]fieldRule{"command": requiredField},
+		oneOf:  [][]string{{"contains", "exit_code", "exit_code_in", "exit_code_not"}},
+	},
+	"docker": {
+		fields: map[string]fieldRule{
+			"state":          {enum: []string{"running", "stopped", "exists"}, def: "running"},
+			"restart_policy": {enum: []string{"no", "always", "on-failure", "unless-stopped"}},
+			"health":         {enum: []string{"healthy", "unhealthy", "starting", "none"}},
+		},
+		oneOf: [][]string{{"container", "containers"}},
+	},
+	"docker_images": {fields: map[string]fieldRule{
+		"image": requiredField,
+		"state": statePresent,
+	}},
+	"docker_networks": {fields: map[string]fieldRule{
+		"network": requiredField,
+		"state":   statePresent,
+	}},
+	"docker_volumes": {fields: map[string]fieldRule{
+		"volume": requiredField,
+		"state":  statePresent,
+	}},
+	"docker_compose": {
+		fields: map[string]fieldRule{"project": requiredField},
+		oneOf:  [][]string{{"all_services_up", "services"}},
+	},
+	"filesystems": {fields: map[string]fieldRule{
+		"path":  requiredField,
+		"state": {enum: []string{"mounted", "unmounted"}, def: "mounted"},
+	}},
+	"ping":       {fields: map[string]fieldRule{"host": requiredField}},
+	"dns":        {fields: map[string]fieldRule{"host": requiredField}},
+	"systeminfo": {fields: map[string]fieldRule{"version_match": {enum: []string{"exact", "prefix"}, def: "exact"}}},
+	"http": {fields: map[string]fieldRule{
+		"url":         requiredField,
+		"status_code": {def: "200"},
+		"method":      {enum: []string{"GET", "POST", "PUT", "DELETE", "PATCH", "HEAD", "OPTIONS"}, def: "GET"},
+	}},
+	"ports": {fields: map[string]fieldRule{
+		"port":     requiredField,
+		"protocol": {enum: []string{"tcp", "udp"}, def: "tcp"},
+		"state":    {enum: []string{"listening", "closed"}, def: "listening"},
+	}},
+	"service_registry": {fields: map[string]fieldRule{
+		"backend":     {required: true, enum: []string{"consul", "etcd"}},
+		"service":     requiredField,
+		"min_healthy": {def: "1"},
+		"address":     {def: "http://127.0.0.1:8500 (consul), http://127.0.0.1:2379 (etcd)"},
+	}},
+	"vault": {fields: map[string]fieldRule{"address": requiredField}},
+	"message_queues": {fields: map[string]fieldRule{
+		"backend":  {required: true, enum: []string{"kafka", "rabbitmq"}},
+		"address":  requiredField,
+		"topic":    requiredField,
+		"vhost":    {def: "/ (rabbitmq)"},
+		"username": {def: "guest (rabbitmq)"},
+		"password": {def: "guest (rabbitmq)"},
+	}},
+	"capabilities": {fields: map[string]fieldRule{"path": requiredField}},
+	"repositories": {fields: map[string]fieldRule{
+		"manager": {required: true, enum: []string{"apt", "yum", "dnf"}},
+		"repo":    requiredField,
+		"state":   statePresent,
+	}},
+	"systemd_properties": {fields: map[string]fieldRule{
+		"unit":       requiredField,
+		"properties": requiredField,
+	}},
+	"sshd_config": {fields: map[string]fieldRule{
+		"setting": requiredField,
+		"value":   requiredField,
+	}},
+	"logrotate": {
+		fields: map[string]fieldRule{"path": requiredField},
+		oneOf:  [][]string{{"max_size", "config_present"}},
+	},
+	"cron": {
+		fields: map[string]fieldRule{"state": statePresent},
+		oneOf:  [][]string{{"command", "schedule"}},
+	},
+	"processes": {fields: map[string]fieldRule{
+		"process": requiredField,
+		"state":   {enum: []string{"running", "absent"}, def: "running"},
+	}},
+	"metrics": {fields: map[string]fieldRule{
+		"command":  requiredField,
+		"operator": {required: true, enum: []string{"gt", "lt", "gte", "lte", "eq", "ne"}},
+	}},
+	"command_json": {fields: map[string]fieldRule{
+		"command": requiredField,
+		"json":    requiredField,
+	}},
+	"kubernetes.pods": {fields: map[string]fieldRule{
+		"pod":       requiredField,
+		"namespace": k8sNamespace,
+		"state":     {enum: []string{"running", "pending", "succeeded", "failed", "exists"}, def: "running"},
+	}},
+	"kubernetes.deployments": {fields: map[string]fieldRule{
+		"deployment":      requiredField,
+		"namespace":       k8sNamespace,
+		"state":           {enum: []string{"available", "progressing", "exists"}, def: "available"},
+		"rollout_timeout": {def: "5m (with wait_for_rollout)"},
+	}},
+	"kubernetes.services": {fields: map[string]fieldRule{
+		"service":   requiredField,
+		"namespace": k8sNamespace,
+		"type":      {enum: []string{"ClusterIP", "NodePort", "LoadBalancer", "ExternalName"}},
+	}},
+	"kubernetes.configmaps": {fields: map[string]fieldRule{
+		"configmap": requiredField,
+		"namespace": k8sNamespace,
+		"state":     statePresent,
+	}},
+	"kubernetes.namespaces": {fields: map[string]fieldRule{
+		"namespace": requiredField,
+		"state":     statePresent,
+	}},
+	"kubernetes.nodes": {oneOf: [][]string{{"count", "min_count", "min_ready", "min_version"}}},
+	"kubernetes.crds": {fields: map[string]fieldRule{
+		"crd":   requiredField,
+		"state": statePresent,
+	}},
+	"kubernetes.helm": {fields: map[string]fieldRule{
+		"release":   requiredField,
+		"namespace": k8sNamespace,
+		"state": {enum: []string{"deployed", "failed", "pending-install", "pending-upgrade", "pending-rollback",
+			"superseded", "uninstalling", "uninstalled"}, def: "deployed"},
+	}},
+	"kubernetes.storageclasses": {fields: map[string]fieldRule{
+		"storageclass": requiredField,
+		"state":        statePresent,
+	}},
+	"kubernetes.secrets": {fields: map[string]fieldRule{
+		"secret":    requiredField,
+		"namespace": k8sNamespace,
+		"state":     statePresent,
+		"type": {enum: []string{"Opaque", "kubernetes.io/service-account-token", "kubernetes.io/dockercfg",
+			"kubernetes.io/dockerconfigjson", "kubernetes.io/basic-auth", "kubernetes.io/ssh-auth",
+			"kubernetes.io/tls", "bootstrap.kubernetes.io/token"}},
+	}},
+	"kubernetes.ingress": {fields: map[string]fieldRule{
+		"ingress":   requiredField,
+		"namespace": k8sNamespace,
+		"state":     statePresent,
+	}},
+	"kubernetes.pvcs": {fields: map[string]fieldRule{
+		"pvc":       requiredField,
+		"namespace": k8sNamespace,
+		"state":     statePresent,
+		"status":    {enum: []string{"Bound", "Pending", "Lost"}},
+	}},
+	"kubernetes.statefulsets": {fields: map[string]fieldRule{
+		"statefulset": requiredField,
+		"namespace":   k8sNamespace,
+		"state":       {enum: []string{"available", "exists"}, def: "available"},
+	}},
+	"openstack.instances": {fields: map[string]fieldRule{
+		"instance": requiredField,
+		"state":    statePresent,
+		"status":   {def: "ACTIVE (when present)"},
+	}},
+	"openstack.volumes": {fields: map[string]fieldRule{
+		"volume": requiredField,
+		"state":  statePresent,
+	}},
+	"openstack.floating_ips": {fields: map[string]fieldRule{
+		"floating_ip": requiredField,
+		"instance":    requiredField,
+	}},
+	"gcp.instances": {fields: map[string]fieldRule{
+		"instance": requiredField,
+		"zone":     requiredField,
+		"project":  {def: "--project"},
+		"state":    statePresent,
+		"status":   {def: "RUNNING (when present)"},
+	}},
+	"gcp.buckets": {fields: map[string]fieldRule{
+		"bucket": requiredField,
+		"state":  statePresent,
+	}},
+	"composite": {oneOf: [][]string{{"any_of", "all_of"}}},
+}
+
+// Catalog lists every supported test category in spec order, with the fields
+// of its tests derived from the spec structs and the rules Validate enforces
+func Catalog() []CategoryInfo {
+	return catalogCategories(reflect.TypeOf(Tests{}), "")
+}
+
+// catalogCategories lists the categories of a Tests-like struct
+func catalogCategories(t reflect.Type, prefix string) []CategoryInfo {
+	var categories []CategoryInfo
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get("yaml"), ",")
+		switch field.Type.Kind() {
+		case reflect.Slice:
+			category := prefix + name
+			rules := catalogRules[category]
+			info := CategoryInfo{Category: category, RequiresOneOf: rules.oneOf}
+			for _, f := range catalogFields(field.Type.Elem()) {
+				rule := rules.fields[f.Name]
+				f.Required = f.Name == "name" || rule.required
+				f.Enum = rule.enum
+				f.Default = rule.def
+				info.Fields = append(info.Fields, f)
+			}
+			categories = append(categories, info)
+		case reflect.Struct:
+			categories = append(categories, catalogCategories(field.Type, prefix+name+".")...)
+		}
+	}
+	return categories
+}
+
+// catalogFields lists the YAML fields of a test struct, flattening inline
+// structs such as TestOptions
+func catalogFields(t reflect.Type) []FieldInfo {
+	var fields []FieldInfo
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, opts, _ := strings.Cut(field.Tag.Get("yaml"), ",")
+		if opts == "inline" {
+			fields = append(fields, catalogFields(field.Type)...)
+			continue
+		}
+		fields = append(fields, FieldInfo{Name: name, Type: catalogType(field.Type)})
+	}
+	return fields
+}
+
+// catalogType names the YAML type of a field
+func catalogType(t reflect.Type) string {
+	if t == reflect.TypeOf(time.Duration(0)) {
+		return "duration"
+	}
+	if t == reflect.TypeOf(Tests{}) {
+		return "tests"
+	}
+	switch t.Kind() {
+	case reflect.Pointer:
+		return catalogType(t.Elem())
+	case reflect.String:
+		return "string"
+	case reflect.Int, reflect.Int64:
+		return "integer"
+	case reflect.Float64:
+		return "number"
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Map:
+		return "map"
+	case reflect.Slice:
+		return "list of " + catalogType(t.Elem())
+	case reflect.Struct:
+		return "object"
+	}
+	return fmt.Sprint(t.Kind())
+}
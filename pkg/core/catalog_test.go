@@ -0,0 +1,96 @@
+package core
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCatalog(t *testing.T) {
+	categories := make(map[string]CategoryInfo)
+	for _, c := range Catalog() {
+		categories[c.Category] = c
+	}
+
+	field := func(category, name string) FieldInfo {
+		t.Helper()
+		c, ok := categories[category]
+		if !ok {
+			t.Fatalf("category %s not in catalog", category)
+		}
+		for _, f := range c.Fields {
+			if f.Name == name {
+				return f
+			}
+		}
+		t.Fatalf("category %s has no field %s", category, name)
+		return FieldInfo{}
+	}
+
+	tests := []struct {
+		category string
+		want     FieldInfo
+	}{
+		{"packages", FieldInfo{Name: "name", Type: "string", Required: true}},
+		{"packages", FieldInfo{Name: "packages", Type: "list of string", Required: true}},
+		{"packages", FieldInfo{Name: "state", Type: "string", Enum: []string{"present", "absent"}, Default: "present"}},
+		{"ports", FieldInfo{Name: "port", Type: "integer", Required: true}},
+		{"ports", FieldInfo{Name: "protocol", Type: "string", Enum: []string{"tcp", "udp"}, Default: "tcp"}},
+		{"http", FieldInfo{Name: "method", Type: "string", Enum: []string{"GET", "POST", "PUT", "DELETE", "PATCH", "HEAD", "OPTIONS"}, Default: "GET"}},
+		{"metrics", FieldInfo{Name: "threshold", Type: "number"}},
+		{"command_content", FieldInfo{Name: "exit_code", Type: "integer"}},
+		{"command_content", FieldInfo{Name: "retry", Type: "object"}},
+		{"command_content", FieldInfo{Name: "continue_on_failure", Type: "boolean"}},
+		{"kubernetes.pods", FieldInfo{Name: "state", Type: "string", Enum: []string{"running", "pending", "succeeded", "failed", "exists"}, Default: "running"}},
+		{"kubernetes.pods", FieldInfo{Name: "kubeconfig", Type: "string"}},
+		{"kubernetes.deployments", FieldInfo{Name: "rollout_timeout", Type: "duration", Default: "5m (with wait_for_rollout)"}},
+		{"kubernetes.services", FieldInfo{Name: "ports", Type: "list of object"}},
+		{"gcp.instances", FieldInfo{Name: "labels", Type: "map"}},
+		{"composite", FieldInfo{Name: "any_of", Type: "tests"}},
+	}
+	for _, tt := range tests {
+		if got := field(tt.category, tt.want.Name); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s.%s = %+v, want %+v", tt.category, tt.want.Name, got, tt.want)
+		}
+	}
+
+	if got := categories["services"].RequiresOneOf; !reflect.DeepEqual(got, [][]string{{"service", "services"}}) {
+		t.Errorf("services RequiresOneOf = %v", got)
+	}
+}
+
+// TestCatalogRulesMatchStructs guards against rules for categories or fields
+// that were renamed or removed
+func TestCatalogRulesMatchStructs(t *testing.T) {
+	fields := make(map[string]map[string]bool)
+	for _, c := range Catalog() {
+		fields[c.Category] = make(map[string]bool)
+		for _, f := range c.Fields {
+			fields[c.Category][f.Name] = true
+		}
+	}
+
+	for category, rules := range catalogRules {
+		known, ok := fields[category]
+		if !ok {
+			t.Errorf("catalogRules has unknown category %s", category)
+			continue
+		}
+		for name := range rules.fields {
+			if !known[name] {
+				t.Errorf("catalogRules[%s] has unknown field %s", category, name)
+			}
+		}
+		for _, group := range rules.oneOf {
+			for _, name := range group {
+				if !known[name] {
+					t.Errorf("catalogRules[%s] requires unknown field %s", category, name)
+				}
+			}
+		}
+	}
+	for category := range fields {
+		if _, ok := catalogRules[category]; !ok {
+			t.Errorf("category %s has no catalogRules entry", category)
+		}
+	}
+}
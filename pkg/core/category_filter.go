@@ -0,0 +1,92 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// CategoryFilter selects the test categories of a spec to run. A name matches
+// its category and, for groups such as kubernetes, every category under it.
+type CategoryFilter struct {
+	Include []string // Run only these categories; empty means all
+	Skip    []string // Never run these categories
+}
+
+// NewCategoryFilter returns a filter for the given category names, or nil if
+// both lists are empty. Names must be categories or groups of categories.
+func NewCategoryFilter(include, skip []string) (*CategoryFilter, error) {
+	if len(include) == 0 && len(skip) == 0 {
+		return nil, nil
+	}
+	known := make(map[string]bool)
+	for _, info := range Catalog() {
+		known[info.Category] = true
+		if group, _, found := strings.Cut(info.Category, "."); found {
+			known[group] = true
+		}
+	}
+	for _, name := range append(append([]string{}, include...), skip...) {
+		if !known[name] {
+			return nil, fmt.Errorf("unknown test category '%s' (see list-tests)", name)
+		}
+	}
+	return &CategoryFilter{Include: include, Skip: skip}, nil
+}
+
+// Selects reports whether tests of the category should run
+func (f *CategoryFilter) Selects(category string) bool {
+	if len(f.Include) > 0 && !matchesCategory(f.Include, category) {
+		return false
+	}
+	return !matchesCategory(f.Skip, category)
+}
+
+// matchesCategory reports whether category is one of names or in a group named
+func matchesCategory(names []string, category string) bool {
+	for _, name := range names {
+		if category == name || strings.HasPrefix(category, name+".") {
+			return true
+		}
+	}
+	return false
+}
+
+type categoryFilterKey struct{}
+
+// WithCategoryFilter returns a context in which executors run only the
+// categories the filter selects
+func WithCategoryFilter(ctx context.Context, filter *CategoryFilter) context.Context {
+	return context.WithValue(ctx, categoryFilterKey{}, filter)
+}
+
+// CategoryFilterFromContext returns the filter set with WithCategoryFilter, or nil
+func CategoryFilterFromContext(ctx context.Context) *CategoryFilter {
+	filter, _ := ctx.Value(categoryFilterKey{}).(*CategoryFilter)
+	return filter
+}
+
+// filterCategories returns a copy of the spec without the tests of categories
+// the filter leaves out, and a skipped result for each of those tests
+func (s *Spec) filterCategories(filter *CategoryFilter) (*Spec, []Result) {
+	out := *s
+	tests := reflect.ValueOf(&out.Tests).Elem()
+	var skipped []Result
+	for _, ref := range categoryRefs(reflect.ValueOf(s.Tests), nil) {
+		category := ref.categoryName()
+		if filter.Selects(category) {
+			continue
+		}
+		skipped = append(skipped, Result{
+			Name:       s.Tests.nameOf(ref),
+			Category:   category,
+			Status:     StatusSkip,
+			Message:    fmt.Sprintf("Category %s was not selected", category),
+			SkipReason: SkipCategoryNotSelected,
+		})
+		field := tests.FieldByIndex(ref.field)
+		field.Set(reflect.Zero(field.Type()))
+	}
+	return &out, skipped
+}
@@ -0,0 +1,110 @@
+package core_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/neilfarmer/platform-spec/pkg/core"
+	k8splugin "github.com/neilfarmer/platform-spec/pkg/core/kubernetes"
+	"github.com/neilfarmer/platform-spec/pkg/core/system"
+)
+
+// categorySpec has one test in each of packages, services and two
+// kubernetes categories
+func categorySpec() *core.Spec {
+	return &core.Spec{
+		Tests: core.Tests{
+			Packages: []core.PackageTest{{Name: "nginx installed", Packages: []string{"nginx"}, State: "present"}},
+			Services: []core.ServiceTest{{Name: "nginx running", Service: "nginx", State: "running"}},
+			Kubernetes: core.KubernetesTests{
+				Namespaces: []core.KubernetesNamespaceTest{{Name: "prod namespace", Namespace: "prod", State: "present"}},
+				Secrets:    []core.KubernetesSecretTest{{Name: "db secret", Secret: "db", Namespace: "prod", State: "present"}},
+			},
+		},
+	}
+}
+
+// runFiltered runs categorySpec with the filter and returns the results by
+// test name, along with the commands issued
+func runFiltered(t *testing.T, include, skip []string) (map[string]core.Result, []string) {
+	t.Helper()
+	filter, err := core.NewCategoryFilter(include, skip)
+	if err != nil {
+		t.Fatalf("NewCategoryFilter() error = %v", err)
+	}
+	provider := &recordingProvider{MockProvider: NewMockProvider()}
+	ctx := core.WithCategoryFilter(context.Background(), filter)
+	results, err := core.NewExecutor(categorySpec(), provider, system.NewSystemPlugin(), k8splugin.NewKubernetesPlugin()).Execute(ctx)
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	byName := make(map[string]core.Result)
+	for _, r := range results.Results {
+		byName[r.Name] = r
+	}
+	if len(byName) != 4 {
+		t.Fatalf("expected a result for each of the 4 tests, got %+v", results.Results)
+	}
+	return byName, provider.commands
+}
+
+func TestExecutor_IncludeCategories(t *testing.T) {
+	results, commands := runFiltered(t, []string{"packages", "kubernetes.namespaces"}, nil)
+
+	for _, name := range []string{"nginx installed", "prod namespace"} {
+		if results[name].Status == core.StatusSkip {
+			t.Errorf("%s should run, got %+v", name, results[name])
+		}
+	}
+	for name, category := range map[string]string{"nginx running": "services", "db secret": "kubernetes.secrets"} {
+		r := results[name]
+		if r.Status != core.StatusSkip || r.SkipReason != core.SkipCategoryNotSelected || r.Category != category {
+			t.Errorf("%s should be skipped as an unselected %s test, got %+v", name, category, r)
+		}
+	}
+	for _, command := range commands {
+		if strings.Contains(command, "systemctl") || strings.Contains(command, "secret") {
+			t.Errorf("unselected category ran %q", command)
+		}
+	}
+}
+
+func TestExecutor_SkipCategories(t *testing.T) {
+	results, commands := runFiltered(t, nil, []string{"kubernetes"})
+
+	for _, name := range []string{"prod namespace", "db secret"} {
+		if r := results[name]; r.Status != core.StatusSkip || r.SkipReason != core.SkipCategoryNotSelected {
+			t.Errorf("%s should be skipped with the kubernetes group, got %+v", name, r)
+		}
+	}
+	for _, name := range []string{"nginx installed", "nginx running"} {
+		if results[name].Status == core.StatusSkip {
+			t.Errorf("%s should run, got %+v", name, results[name])
+		}
+	}
+	for _, command := range commands {
+		if strings.Contains(command, "kubectl") {
+			t.Errorf("skipped category ran %q", command)
+		}
+	}
+
+	// Skips win over includes
+	results, _ = runFiltered(t, []string{"kubernetes"}, []string{"kubernetes.secrets"})
+	if results["prod namespace"].Status == core.StatusSkip || results["db secret"].Status != core.StatusSkip {
+		t.Errorf("expected only the namespace test to run, got %+v", results)
+	}
+}
+
+func TestNewCategoryFilter(t *testing.T) {
+	if filter, err := core.NewCategoryFilter(nil, nil); filter != nil || err != nil {
+		t.Errorf("NewCategoryFilter(nil, nil) = %v, %v, want no filter", filter, err)
+	}
+	if _, err := core.NewCategoryFilter([]string{"packages", "kubernetes", "gcp.instances"}, nil); err != nil {
+		t.Errorf("NewCategoryFilter() error = %v", err)
+	}
+	_, err := core.NewCategoryFilter(nil, []string{"package"})
+	if err == nil || !strings.Contains(err.Error(), "unknown test category 'package'") {
+		t.Errorf("NewCategoryFilter(package) error = %v, want unknown category", err)
+	}
+}
@@ -0,0 +1,79 @@
+package core
+
+import "fmt"
+
+// ResultDiff is a test whose outcome differs between two hosts. A status is
+// empty when the host did not report the test at all.
+type ResultDiff struct {
+	Spec     string
+	Name     string
+	StatusA  Status
+	StatusB  Status
+	MessageA string
+	MessageB string
+}
+
+// CompareResults reports the tests whose status differs between two hosts
+// that ran the same specs. Tests are matched by spec and test name; repeated
+// names are matched in the order they ran. Diffs follow host A's execution
+// order, then tests only host B reported.
+func CompareResults(a, b *HostResults) []ResultDiff {
+	type key struct {
+		spec, name string
+		n          int
+	}
+	keys := func(host *HostResults) ([]key, map[key]Result) {
+		var order []key
+		results := make(map[key]Result)
+		seen := make(map[string]int)
+		for _, spec := range host.SpecResults {
+			for _, r := range spec.Results {
+				id := spec.SpecName + "\x00" + r.Name
+				k := key{spec: spec.SpecName, name: r.Name, n: seen[id]}
+				seen[id]++
+				order = append(order, k)
+				results[k] = r
+			}
+		}
+		return order, results
+	}
+	orderA, resultsA := keys(a)
+	orderB, resultsB := keys(b)
+
+	var diffs []ResultDiff
+	add := func(k key) {
+		ra, okA := resultsA[k]
+		rb, okB := resultsB[k]
+		if okA && okB && ra.Status == rb.Status {
+			return
+		}
+		diffs = append(diffs, ResultDiff{
+			Spec:     k.spec,
+			Name:     k.name,
+			StatusA:  ra.Status,
+			StatusB:  rb.Status,
+			MessageA: ra.Message,
+			MessageB: rb.Message,
+		})
+	}
+	for _, k := range orderA {
+		add(k)
+	}
+	for _, k := range orderB {
+		if _, ok := resultsA[k]; !ok {
+			add(k)
+		}
+	}
+	return diffs
+}
+
+// Describe summarises the drift, e.g. "passed on A, failed on B"
+func (d ResultDiff) Describe(targetA, targetB string) string {
+	status := func(s Status) string {
+		if s == "" {
+			return "missing"
+		}
+		return string(s)
+	}
+	return fmt.Sprintf("%s on %s, %s on %s", status(d.StatusA), targetA, status(d.StatusB), targetB)
+}
@@ -0,0 +1,98 @@
+package core_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/neilfarmer/platform-spec/pkg/core"
+	"github.com/neilfarmer/platform-spec/pkg/core/system"
+)
+
+func TestCompareResults(t *testing.T) {
+	zero := 0
+	spec := &core.Spec{
+		Metadata: core.SpecMetadata{Name: "web"},
+		Tests: core.Tests{
+			CommandContent: []core.CommandContentTest{
+				{Name: "nginx config", Command: "nginx -t", ExitCode: &zero},
+				{Name: "uptime", Command: "uptime", ExitCode: &zero},
+				{Name: "selinux", Command: "getenforce", Contains: []string{"Enforcing"}},
+			},
+		},
+	}
+
+	// Two supposedly-identical hosts: B's nginx config is broken and SELinux
+	// is permissive on A
+	hostA := core.NewMockProvider()
+	hostA.SetCommandResult("getenforce", "Permissive\n", "", 0, nil)
+	hostB := core.NewMockProvider()
+	hostB.SetCommandResult("nginx -t", "", "nginx: [emerg] unknown directive\n", 1, nil)
+	hostB.SetCommandResult("getenforce", "Enforcing\n", "", 0, nil)
+
+	run := func(provider core.Provider) *core.HostResults {
+		results, err := core.NewExecutor(spec, provider, system.NewSystemPlugin()).Execute(context.Background())
+		if err != nil {
+			t.Fatalf("Execute() error = %v", err)
+		}
+		return &core.HostResults{Connected: true, SpecResults: []*core.TestResults{results}}
+	}
+	a, b := run(hostA), run(hostB)
+
+	diffs := core.CompareResults(a, b)
+	type drift struct {
+		name   string
+		aStat  core.Status
+		bStat  core.Status
+		detail string
+	}
+	var got []drift
+	for _, d := range diffs {
+		got = append(got, drift{d.Name, d.StatusA, d.StatusB, d.Describe("a", "b")})
+	}
+	want := []drift{
+		{"nginx config", core.StatusPass, core.StatusFail, "passed on a, failed on b"},
+		{"selinux", core.StatusFail, core.StatusPass, "failed on a, passed on b"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("CompareResults() = %+v, want %+v", got, want)
+	}
+	for _, d := range diffs {
+		if d.Spec != "web" {
+			t.Errorf("diff %q Spec = %q, want web", d.Name, d.Spec)
+		}
+	}
+
+	if diffs := core.CompareResults(a, a); len(diffs) != 0 {
+		t.Errorf("CompareResults() of a host with itself = %+v, want no diffs", diffs)
+	}
+}
+
+func TestCompareResults_MissingTests(t *testing.T) {
+	host := func(results ...core.Result) *core.HostResults {
+		return &core.HostResults{Connected: true, SpecResults: []*core.TestResults{{SpecName: "base", Results: results}}}
+	}
+	a := host(
+		core.Result{Name: "dup", Status: core.StatusPass},
+		core.Result{Name: "dup", Status: core.StatusPass},
+		core.Result{Name: "only a", Status: core.StatusPass},
+	)
+	b := host(
+		core.Result{Name: "dup", Status: core.StatusPass},
+		core.Result{Name: "dup", Status: core.StatusFail, Message: "second differs"},
+		core.Result{Name: "only b", Status: core.StatusSkip},
+	)
+
+	got := core.CompareResults(a, b)
+	want := []core.ResultDiff{
+		{Spec: "base", Name: "dup", StatusA: core.StatusPass, StatusB: core.StatusFail, MessageB: "second differs"},
+		{Spec: "base", Name: "only a", StatusA: core.StatusPass},
+		{Spec: "base", Name: "only b", StatusB: core.StatusSkip},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("CompareResults() = %+v, want %+v", got, want)
+	}
+	if desc := got[1].Describe("a", "b"); desc != "passed on a, missing on b" {
+		t.Errorf("Describe() = %q", desc)
+	}
+}
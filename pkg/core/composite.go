@@ -0,0 +1,106 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// executeCompositeTest runs the sub-tests of a composite test through the
+// executor's plugins and combines their statuses. any_of stops as soon as a
+// sub-test passes; all_of always runs every sub-test.
+func (e *Executor) executeCompositeTest(ctx context.Context, parent *Spec, test CompositeTest) Result {
+	start := time.Now()
+	result := Result{
+		Name:    test.Name,
+		Status:  StatusPass,
+		Details: make(map[string]interface{}),
+	}
+
+	anyOf := test.AnyOf != nil
+	sub := *parent
+	sub.Tests = *test.Group()
+
+	var subResults []Result
+	satisfied := func() bool { return anyOf && countStatus(subResults, StatusPass) > 0 }
+
+	for _, plugin := range e.plugins {
+		if satisfied() {
+			break
+		}
+		pluginResults, _ := plugin.Execute(ctx, &sub, e.provider, false)
+		subResults = append(subResults, pluginResults...)
+	}
+	for _, nested := range sub.Tests.Composite {
+		if satisfied() {
+			break
+		}
+		subResults = append(subResults, e.executeCompositeTest(ctx, &sub, nested))
+	}
+
+	summaries := make([]string, len(subResults))
+	for i, r := range subResults {
+		summaries[i] = fmt.Sprintf("%s: %s - %s", r.Name, r.Status, r.Message)
+	}
+	result.Details["sub_results"] = summaries
+
+	total := len(subResults)
+	passed := countStatus(subResults, StatusPass)
+	failed := countStatus(subResults, StatusFail)
+	errored := countStatus(subResults, StatusError)
+
+	switch {
+	case total == 0:
+		result.Status = StatusError
+		result.Message = "No sub-tests were run"
+	case anyOf && passed > 0:
+		result.Message = fmt.Sprintf("any_of satisfied: %s passed", firstWithStatus(subResults, StatusPass).Name)
+	case anyOf && failed == 0 && errored > 0:
+		result.Status = StatusError
+		result.Message = fmt.Sprintf("any_of: none of %d sub-tests passed (%d errors)", total, errored)
+	case anyOf:
+		result.Status = StatusFail
+		result.Message = fmt.Sprintf("any_of: none of %d sub-tests passed", total)
+	case failed > 0:
+		r := firstWithStatus(subResults, StatusFail)
+		result.Status = StatusFail
+		result.Message = fmt.Sprintf("all_of: %d of %d sub-tests failed (%s: %s)", failed, total, r.Name, r.Message)
+	case errored > 0:
+		r := firstWithStatus(subResults, StatusError)
+		result.Status = StatusError
+		result.Message = fmt.Sprintf("all_of: %d of %d sub-tests errored (%s: %s)", errored, total, r.Name, r.Message)
+	case passed == 0:
+		result.Status = StatusSkip
+		result.SkipReason = SkipSubTestsSkipped
+		result.Message = fmt.Sprintf("all_of: all %d sub-tests were skipped", total)
+	default:
+		result.Message = fmt.Sprintf("all_of: %d of %d sub-tests passed", passed, total)
+		if skipped := total - passed; skipped > 0 {
+			result.Message += fmt.Sprintf(" (%d skipped)", skipped)
+		}
+	}
+
+	result.Duration = time.Since(start)
+	return result
+}
+
+// countStatus returns the number of results with the given status
+func countStatus(results []Result, status Status) int {
+	count := 0
+	for _, r := range results {
+		if r.Status == status {
+			count++
+		}
+	}
+	return count
+}
+
+// firstWithStatus returns the first result with the given status
+func firstWithStatus(results []Result, status Status) Result {
+	for _, r := range results {
+		if r.Status == status {
+			return r
+		}
+	}
+	return Result{}
+}
@@ -0,0 +1,252 @@
+package core_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/neilfarmer/platform-spec/pkg/core"
+	"github.com/neilfarmer/platform-spec/pkg/core/system"
+)
+
+// recordingPlugin counts how many times it is executed
+type recordingPlugin struct {
+	calls int
+}
+
+func (p *recordingPlugin) Execute(ctx context.Context, spec *core.Spec, provider core.Provider, failFast bool) ([]core.Result, bool) {
+	p.calls++
+	return nil, false
+}
+
+func commandTest(name, command, want string) core.CommandContentTest {
+	return core.CommandContentTest{Name: name, Command: command, Contains: []string{want}}
+}
+
+func TestExecutor_CompositeAnyOf(t *testing.T) {
+	mock := NewMockProvider()
+	mock.SetCommandResult("systemctl is-active nginx", "failed", "", 3, nil)
+	mock.SetCommandResult("docker inspect -f '{{.State.Status}}' nginx", "running", "", 0, nil)
+
+	spec := &core.Spec{
+		Tests: core.Tests{
+			Composite: []core.CompositeTest{
+				{
+					Name: "nginx running as service or container",
+					AnyOf: &core.Tests{
+						CommandContent: []core.CommandContentTest{
+							commandTest("nginx service", "systemctl is-active nginx", "active"),
+							commandTest("nginx container", "docker inspect -f '{{.State.Status}}' nginx", "running"),
+						},
+					},
+				},
+			},
+		},
+	}
+	if err := spec.Validate(); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+
+	recorder := &recordingPlugin{}
+	executor := core.NewExecutor(spec, mock, system.NewSystemPlugin(), recorder)
+	results, err := executor.Execute(context.Background())
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	if len(results.Results) != 1 {
+		t.Fatalf("got %d results, want 1 composite result", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Status != core.StatusPass {
+		t.Errorf("Status = %v, want %v (message: %s)", result.Status, core.StatusPass, result.Message)
+	}
+	if !strings.Contains(result.Message, "nginx container passed") {
+		t.Errorf("Message %q does not name the passing sub-test", result.Message)
+	}
+	// recordingPlugin is called once for the top-level spec; the composite
+	// must stop before reaching it because a sub-test already passed
+	if recorder.calls != 1 {
+		t.Errorf("recording plugin called %d times, want 1 (any_of should short-circuit)", recorder.calls)
+	}
+}
+
+func TestExecutor_CompositeAllOfFailure(t *testing.T) {
+	mock := NewMockProvider()
+	mock.SetCommandResult("cat /etc/app/mode", "production", "", 0, nil)
+	mock.SetCommandResult("cat /etc/app/region", "us-west-2", "", 0, nil)
+
+	spec := &core.Spec{
+		Config: core.SpecConfig{FailFast: true},
+		Tests: core.Tests{
+			Composite: []core.CompositeTest{
+				{
+					Name: "app configured for us-east-1 production",
+					AllOf: &core.Tests{
+						CommandContent: []core.CommandContentTest{
+							commandTest("mode", "cat /etc/app/mode", "production"),
+							commandTest("region", "cat /etc/app/region", "us-east-1"),
+						},
+					},
+				},
+				{
+					Name: "never reached under fail-fast",
+					AllOf: &core.Tests{
+						CommandContent: []core.CommandContentTest{
+							commandTest("mode", "cat /etc/app/mode", "production"),
+						},
+					},
+				},
+			},
+		},
+	}
+	if err := spec.Validate(); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+
+	executor := core.NewExecutor(spec, mock, system.NewSystemPlugin())
+	results, err := executor.Execute(context.Background())
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	if len(results.Results) != 1 {
+		t.Fatalf("got %d results, want 1 (fail-fast after the failing composite)", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Status != core.StatusFail {
+		t.Errorf("Status = %v, want %v", result.Status, core.StatusFail)
+	}
+	if !strings.Contains(result.Message, "1 of 2 sub-tests failed (region:") {
+		t.Errorf("Message %q does not report the failing sub-test", result.Message)
+	}
+	subResults, _ := result.Details["sub_results"].([]string)
+	if len(subResults) != 2 {
+		t.Errorf("sub_results = %v, want both sub-tests", subResults)
+	}
+}
+
+func TestExecutor_CompositeContinueOnFailure(t *testing.T) {
+	mock := NewMockProvider()
+	mock.SetCommandResult("cat /etc/app/mode", "staging", "", 0, nil)
+	mock.SetCommandResult("cat /etc/app/region", "us-east-1", "", 0, nil)
+
+	spec := &core.Spec{
+		Config: core.SpecConfig{FailFast: true},
+		Tests: core.Tests{
+			Composite: []core.CompositeTest{
+				{
+					Name: "app in production",
+					AllOf: &core.Tests{
+						CommandContent: []core.CommandContentTest{
+							commandTest("mode", "cat /etc/app/mode", "production"),
+						},
+					},
+					TestOptions: core.TestOptions{ContinueOnFailure: true},
+				},
+				{
+					Name: "app in us-east-1",
+					AllOf: &core.Tests{
+						CommandContent: []core.CommandContentTest{
+							commandTest("region", "cat /etc/app/region", "us-east-1"),
+						},
+					},
+				},
+			},
+		},
+	}
+	if err := spec.Validate(); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+
+	executor := core.NewExecutor(spec, mock, system.NewSystemPlugin())
+	results, err := executor.Execute(context.Background())
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	if len(results.Results) != 2 {
+		t.Fatalf("got %d results, want 2 (continue_on_failure composite must not stop the run)", len(results.Results))
+	}
+	if results.Results[0].Status != core.StatusFail {
+		t.Errorf("first composite Status = %v, want %v", results.Results[0].Status, core.StatusFail)
+	}
+	if results.Results[1].Status != core.StatusPass {
+		t.Errorf("second composite Status = %v, want %v (message: %s)", results.Results[1].Status, core.StatusPass, results.Results[1].Message)
+	}
+}
+
+func TestExecutor_CompositeNested(t *testing.T) {
+	mock := NewMockProvider()
+	mock.SetCommandResult("cat /etc/role", "web", "", 0, nil)
+
+	spec := &core.Spec{
+		Tests: core.Tests{
+			Composite: []core.CompositeTest{
+				{
+					Name: "web or worker",
+					AnyOf: &core.Tests{
+						Composite: []core.CompositeTest{
+							{
+								Name: "worker",
+								AllOf: &core.Tests{
+									CommandContent: []core.CommandContentTest{commandTest("worker role", "cat /etc/role", "worker")},
+								},
+							},
+							{
+								Name: "web",
+								AllOf: &core.Tests{
+									CommandContent: []core.CommandContentTest{commandTest("web role", "cat /etc/role", "web")},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	if err := spec.Validate(); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+
+	results, err := core.NewExecutor(spec, mock, system.NewSystemPlugin()).Execute(context.Background())
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if len(results.Results) != 1 || results.Results[0].Status != core.StatusPass {
+		t.Fatalf("results = %+v, want a single passing composite", results.Results)
+	}
+}
+
+func TestExecutor_CompositeAllSkipped(t *testing.T) {
+	mock := core.NewMockProvider()
+	mock.SetOS(core.OSWindows)
+
+	spec := &core.Spec{
+		Tests: core.Tests{
+			Composite: []core.CompositeTest{
+				{
+					Name: "base packages",
+					AllOf: &core.Tests{
+						Packages: []core.PackageTest{
+							{Name: "curl installed", Packages: []string{"curl"}},
+							{Name: "jq installed", Packages: []string{"jq"}},
+						},
+					},
+				},
+			},
+		},
+	}
+	if err := spec.Validate(); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+
+	results, err := core.NewExecutor(spec, mock, system.NewSystemPlugin()).Execute(context.Background())
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	result := results.Results[0]
+	if result.Status != core.StatusSkip || result.SkipReason != core.SkipSubTestsSkipped {
+		t.Errorf("composite = %v (%q), want skipped with reason %q", result.Status, result.SkipReason, core.SkipSubTestsSkipped)
+	}
+}
@@ -0,0 +1,102 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// ConnectionLostError is returned by providers for a command cut short
+// because the connection to the target dropped
+type ConnectionLostError struct {
+	Err    error  // What the connection failed with
+	Stdout string // Output received before the connection dropped
+}
+
+func (e *ConnectionLostError) Error() string {
+	return fmt.Sprintf("connection lost: %v", e.Err)
+}
+
+func (e *ConnectionLostError) Unwrap() error {
+	return e.Err
+}
+
+// connectionMonitor records the connections lost while tests run, so a test
+// whose command was cut short is reported as such rather than by whatever
+// its check made of the failed command
+type connectionMonitor struct {
+	mu    sync.Mutex
+	drops int
+	last  *ConnectionLostError
+}
+
+// connectionMonitorKey is the context key for the connection monitor
+type connectionMonitorKey struct{}
+
+// withConnectionMonitor returns a context in which providers report lost
+// connections to RunTest
+func withConnectionMonitor(ctx context.Context) context.Context {
+	return context.WithValue(ctx, connectionMonitorKey{}, &connectionMonitor{})
+}
+
+// connectionMonitorFromContext returns the connection monitor, or nil
+func connectionMonitorFromContext(ctx context.Context) *connectionMonitor {
+	monitor, _ := ctx.Value(connectionMonitorKey{}).(*connectionMonitor)
+	return monitor
+}
+
+// ReportConnectionLost tells the test running in ctx that a command it ran
+// was cut short by a dropped connection. Providers call it for the
+// ConnectionLostError they return; outside an executor it does nothing.
+func ReportConnectionLost(ctx context.Context, err *ConnectionLostError) {
+	monitor := connectionMonitorFromContext(ctx)
+	if monitor == nil {
+		return
+	}
+	monitor.mu.Lock()
+	defer monitor.mu.Unlock()
+	monitor.drops++
+	monitor.last = err
+}
+
+// mark returns the number of connections lost so far
+func (m *connectionMonitor) mark() int {
+	if m == nil {
+		return 0
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.drops
+}
+
+// lostSince returns the last connection lost after mark was taken, or nil
+func (m *connectionMonitor) lostSince(mark int) *ConnectionLostError {
+	if m == nil {
+		return nil
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.drops == mark {
+		return nil
+	}
+	return m.last
+}
+
+// connectionLostResult replaces the result of a check whose connection
+// dropped with an error naming the cause. Output received before the drop is
+// attached as partial_output.
+func connectionLostResult(result Result, err *ConnectionLostError) Result {
+	lost := Result{
+		Name:        result.Name,
+		Category:    result.Category,
+		Status:      StatusError,
+		Message:     fmt.Sprintf("connection lost during test: %v", err.Err),
+		Duration:    result.Duration,
+		Details:     result.Details,
+		Attachments: result.Attachments,
+	}
+	if err.Stdout != "" {
+		lost.Attach("partial_output", err.Stdout)
+	}
+	return lost
+}
@@ -0,0 +1,96 @@
+package core
+
+import (
+	"reflect"
+	"sort"
+)
+
+// CategoryCoverage counts the results of one test category
+type CategoryCoverage struct {
+	Category string
+	Total    int
+	Passed   int
+	Failed   int
+	Skipped  int
+	Errors   int
+}
+
+func (c *CategoryCoverage) add(status Status) {
+	c.Total++
+	switch status {
+	case StatusPass:
+		c.Passed++
+	case StatusFail:
+		c.Failed++
+	case StatusSkip:
+		c.Skipped++
+	case StatusError:
+		c.Errors++
+	}
+}
+
+// CoverageReport summarises what a run exercised, per test category across
+// all hosts and specs. A category whose tests were all skipped stands out
+// with Skipped equal to Total.
+type CoverageReport struct {
+	Categories []CategoryCoverage // Sorted by category
+	Total      CategoryCoverage   // Counts across all categories
+	Evaluated  int                // Assertions evaluated: results that were not skipped
+}
+
+// UncategorizedCategory labels results with no category, e.g. from plugins
+// run outside an executor
+const UncategorizedCategory = "uncategorized"
+
+// Coverage counts the results of a run by test category
+func Coverage(results *MultiHostResults) CoverageReport {
+	report := CoverageReport{Total: CategoryCoverage{Category: "total"}}
+	byCategory := make(map[string]*CategoryCoverage)
+	for _, host := range results.Hosts {
+		for _, spec := range host.SpecResults {
+			for _, r := range spec.Results {
+				category := r.Category
+				if category == "" {
+					category = UncategorizedCategory
+				}
+				if byCategory[category] == nil {
+					byCategory[category] = &CategoryCoverage{Category: category}
+				}
+				byCategory[category].add(r.Status)
+				report.Total.add(r.Status)
+			}
+		}
+	}
+
+	for _, c := range byCategory {
+		report.Categories = append(report.Categories, *c)
+	}
+	sort.Slice(report.Categories, func(i, j int) bool {
+		return report.Categories[i].Category < report.Categories[j].Category
+	})
+	report.Evaluated = report.Total.Total - report.Total.Skipped
+	return report
+}
+
+// tagCategories sets the Category of each result from the spec test of the
+// same name. Tests sharing a name are matched in category order.
+func (s *Spec) tagCategories(results []Result) {
+	categories := make(map[string][]string)
+	for _, ref := range categoryRefs(reflect.ValueOf(s.Tests), nil) {
+		name := s.Tests.nameOf(ref)
+		categories[name] = append(categories[name], ref.categoryName())
+	}
+	for i := range results {
+		if results[i].Category != "" {
+			continue
+		}
+		queue := categories[results[i].Name]
+		if len(queue) == 0 {
+			continue
+		}
+		results[i].Category = queue[0]
+		if len(queue) > 1 {
+			categories[results[i].Name] = queue[1:]
+		}
+	}
+}
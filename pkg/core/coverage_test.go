@@ -0,0 +1,92 @@
+package core_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/neilfarmer/platform-spec/pkg/core"
+	"github.com/neilfarmer/platform-spec/pkg/core/system"
+)
+
+func TestExecutor_ResultCategories(t *testing.T) {
+	path := writeSpec(t, t.TempDir(), "spec.yaml", `version: "1.0"
+tests:
+  packages:
+    - name: "shared name"
+      packages: [curl]
+  command_content:
+    - name: "shared name"
+      command: "true"
+      exit_code: 0
+    - name: "uptime"
+      command: "uptime"
+      exit_code: 0
+  composite:
+    - name: "combined"
+      all_of:
+        command_content:
+          - name: "sub"
+            command: "true"
+            exit_code: 0
+`)
+	spec, err := core.ParseSpec(path)
+	if err != nil {
+		t.Fatalf("ParseSpec() error = %v", err)
+	}
+
+	results, err := core.NewExecutor(spec, core.NewMockProvider(), system.NewSystemPlugin()).Execute(context.Background())
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	got := make(map[string][]string)
+	for _, r := range results.Results {
+		got[r.Name] = append(got[r.Name], r.Category)
+	}
+	want := map[string][]string{
+		"shared name": {"packages", "command_content"},
+		"uptime":      {"command_content"},
+		"combined":    {"composite"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("categories = %v, want %v", got, want)
+	}
+}
+
+func TestCoverage(t *testing.T) {
+	host := func(results ...core.Result) *core.HostResults {
+		return &core.HostResults{Connected: true, SpecResults: []*core.TestResults{{Results: results}}}
+	}
+	results := &core.MultiHostResults{Hosts: []*core.HostResults{
+		host(
+			core.Result{Name: "nginx", Category: "packages", Status: core.StatusPass},
+			core.Result{Name: "curl", Category: "packages", Status: core.StatusFail},
+			core.Result{Name: "web", Category: "services", Status: core.StatusSkip},
+			core.Result{Name: "before hook", Category: "hooks", Status: core.StatusError},
+		),
+		host(
+			core.Result{Name: "nginx", Category: "packages", Status: core.StatusPass},
+			core.Result{Name: "curl", Category: "packages", Status: core.StatusPass},
+			core.Result{Name: "web", Category: "services", Status: core.StatusSkip},
+			core.Result{Name: "plugin only", Status: core.StatusPass},
+		),
+		{Target: "unreachable", Connected: false},
+	}}
+
+	report := core.Coverage(results)
+	want := []core.CategoryCoverage{
+		{Category: "hooks", Total: 1, Errors: 1},
+		{Category: "packages", Total: 4, Passed: 3, Failed: 1},
+		{Category: "services", Total: 2, Skipped: 2},
+		{Category: core.UncategorizedCategory, Total: 1, Passed: 1},
+	}
+	if !reflect.DeepEqual(report.Categories, want) {
+		t.Errorf("Categories = %+v, want %+v", report.Categories, want)
+	}
+	if want := (core.CategoryCoverage{Category: "total", Total: 8, Passed: 4, Failed: 1, Skipped: 2, Errors: 1}); report.Total != want {
+		t.Errorf("Total = %+v, want %+v", report.Total, want)
+	}
+	if report.Evaluated != 6 {
+		t.Errorf("Evaluated = %d, want 6", report.Evaluated)
+	}
+}
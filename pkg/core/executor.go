@@ -2,15 +2,21 @@ package core
 
 import (
 	"context"
+	"fmt"
+	"regexp"
+	"strconv"
 	"strings"
 	"time"
+
+	"github.com/neilfarmer/platform-spec/pkg/retry"
 )
 
 // Executor executes tests against a provider
 type Executor struct {
-	spec     *Spec
-	provider Provider
-	plugins  []Plugin
+	spec        *Spec
+	provider    Provider
+	plugins     []Plugin
+	classifiers map[string]retry.ErrorClassifier // Retry classifiers overriding DefaultRetryClassifiers
 }
 
 // Provider interface that all providers must implement
@@ -34,29 +40,216 @@ func NewExecutor(spec *Spec, provider Provider, plugins ...Plugin) *Executor {
 	}
 }
 
+// SetRetryClassifier sets which failed checks of a test category, e.g.
+// "http" or "kubernetes.pods", are re-checked when the test has retry
+// options, in place of the category's entry in DefaultRetryClassifiers
+func (e *Executor) SetRetryClassifier(category string, classifier retry.ErrorClassifier) {
+	if e.classifiers == nil {
+		e.classifiers = make(map[string]retry.ErrorClassifier)
+	}
+	e.classifiers[category] = classifier
+}
+
 // Execute runs all tests in the spec using registered plugins
 func (e *Executor) Execute(ctx context.Context) (*TestResults, error) {
 	startTime := time.Now()
 
 	results := &TestResults{
 		SpecName:  e.spec.Metadata.Name,
+		SpecHash:  e.spec.Hash(),
 		StartTime: startTime,
 		Results:   []Result{},
 	}
 
+	// Gather host facts once so executors can select OS-specific commands
+	if FactsFromContext(ctx) == nil {
+		ctx = WithFacts(ctx, GatherFacts(ctx, e.provider))
+	}
+	if commandCacheFromContext(ctx) == nil {
+		ctx = withCommandCache(ctx)
+	}
+
+	if e.spec.Config.Shell != "" {
+		ctx = WithShell(ctx, e.spec.Config.Shell)
+	}
+	if len(e.spec.Config.Env) > 0 {
+		ctx = WithEnv(ctx, e.spec.Config.Env)
+	}
+
+	ctx = withRetryClassifier(ctx, e.spec.categoryRetryClassifier(e.classifiers))
+	ctx = withConnectionMonitor(ctx)
+	if e.spec.Config.Timeout > 0 {
+		ctx = withTestTimeout(ctx, time.Duration(e.spec.Config.Timeout)*time.Second)
+	}
+
+	// Spec-wide retries apply to every test without its own retry options
+	if e.spec.Config.Retries > 0 {
+		ctx = WithRetryDefaults(ctx, &TestRetry{
+			Attempts: e.spec.Config.Retries + 1,
+			Interval: time.Duration(e.spec.Config.RetryInterval) * time.Second,
+		})
+	}
+
+	// Categories left out by a filter are dropped before dispatch and
+	// reported as skipped after the tests that ran
+	run := e
+	var unselected []Result
+	if filter := CategoryFilterFromContext(ctx); filter != nil {
+		var spec *Spec
+		spec, unselected = e.spec.filterCategories(filter)
+		run = &Executor{spec: spec, provider: e.provider, plugins: e.plugins, classifiers: e.classifiers}
+	}
+
+	// Missing tools abort the spec before any hook, which may need them too
+	if missing := e.checkRequires(ctx); missing != nil {
+		results.Results = append(results.Results, *missing)
+		results.Duration = time.Since(startTime)
+		return results, nil
+	}
+
+	// A failed before hook aborts the spec; after hooks run regardless
+	if failed := e.runHooks(ctx, "before", e.spec.Config.Hooks.Before); failed != nil {
+		results.Results = append(results.Results, *failed)
+	} else {
+		results.Results = append(results.Results, run.runTests(ctx)...)
+		results.Results = append(results.Results, unselected...)
+	}
+	if failed := e.runHooks(ctx, "after", e.spec.Config.Hooks.After); failed != nil {
+		results.Results = append(results.Results, *failed)
+	}
+	run.spec.tagCategories(results.Results)
+	e.spec.redactResults(results.Results)
+
+	results.Duration = time.Since(startTime)
+	return results, nil
+}
+
+// runTests runs the spec's tests with the registered plugins, then its
+// composite tests. With a name or declaration order, tests are dispatched
+// one at a time instead.
+func (e *Executor) runTests(ctx context.Context) []Result {
+	if order := e.spec.Config.Order; order == OrderName || order == OrderDeclaration {
+		return e.runTestsInOrder(ctx, e.spec.orderedTests())
+	}
+
+	var results []Result
+
 	// Execute each plugin in order
 	for _, plugin := range e.plugins {
 		pluginResults, shouldStop := plugin.Execute(ctx, e.spec, e.provider, e.spec.Config.FailFast)
-		results.Results = append(results.Results, pluginResults...)
+		results = append(results, pluginResults...)
 
-		// If plugin indicates we should stop (fail-fast), break
+		// If plugin indicates we should stop (fail-fast), stop here
 		if shouldStop {
+			return results
+		}
+	}
+
+	// Composite tests combine sub-tests handled by any plugin, so they run last
+	for _, test := range e.spec.Tests.Composite {
+		result := RunTest(ctx, test.TestOptions, func(ctx context.Context) Result {
+			return e.executeCompositeTest(ctx, e.spec, test)
+		})
+		results = append(results, result)
+		if e.spec.Config.FailFast && result.Status == StatusFail && !test.ContinueOnFailure {
 			break
 		}
 	}
+	return results
+}
 
-	results.Duration = time.Since(startTime)
-	return results, nil
+// runTestsInOrder runs the referenced tests one at a time, so results are
+// reported in the same order
+func (e *Executor) runTestsInOrder(ctx context.Context, refs []testRef) []Result {
+	var results []Result
+	for _, ref := range refs {
+		if ref.isComposite() {
+			test := e.spec.Tests.Composite[ref.index]
+			result := RunTest(ctx, test.TestOptions, func(ctx context.Context) Result {
+				return e.executeCompositeTest(ctx, e.spec, test)
+			})
+			results = append(results, result)
+			if e.spec.Config.FailFast && result.Status == StatusFail && !test.ContinueOnFailure {
+				return results
+			}
+			continue
+		}
+
+		single := *e.spec
+		single.Tests = e.spec.Tests.only(ref)
+		stop := false
+		for _, plugin := range e.plugins {
+			pluginResults, shouldStop := plugin.Execute(ctx, &single, e.provider, e.spec.Config.FailFast)
+			results = append(results, pluginResults...)
+			stop = stop || shouldStop
+		}
+		if stop {
+			return results
+		}
+	}
+	return results
+}
+
+// checkRequires looks up every command in the spec's requires list on the
+// target and returns an error result naming those missing, or nil if all are
+// there. Windows targets have no command -v to look them up with, so are not
+// checked.
+func (e *Executor) checkRequires(ctx context.Context) *Result {
+	if len(e.spec.Config.Requires) == 0 || TargetOS(ctx, e.provider) == OSWindows {
+		return nil
+	}
+	start := time.Now()
+	var missing []string
+	for _, tool := range e.spec.Config.Requires {
+		if !CommandAvailable(ctx, e.provider, tool) {
+			missing = append(missing, tool)
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+	return &Result{
+		Name:     "required tools",
+		Category: "requires",
+		Status:   StatusError,
+		Message:  fmt.Sprintf("missing tools: %s; spec tests were not run", strings.Join(missing, ", ")),
+		Duration: time.Since(start),
+		Details:  map[string]interface{}{"missing": missing},
+	}
+}
+
+// runHooks runs hook commands in order. The first failing command stops the
+// stage and is returned as an error result.
+func (e *Executor) runHooks(ctx context.Context, stage string, commands []string) *Result {
+	for _, command := range commands {
+		start := time.Now()
+		_, stderr, exitCode, err := e.provider.ExecuteCommand(ctx, command)
+		if err == nil && exitCode == 0 {
+			continue
+		}
+
+		message := fmt.Sprintf("%s hook '%s' failed", stage, command)
+		if err != nil {
+			message += fmt.Sprintf(": %v", err)
+		} else {
+			message += fmt.Sprintf(" with exit code %d", exitCode)
+			if stderr = strings.TrimSpace(stderr); stderr != "" {
+				message += ": " + stderr
+			}
+		}
+		if stage == "before" {
+			message += "; spec tests were not run"
+		}
+		return &Result{
+			Name:     stage + " hook",
+			Category: "hooks",
+			Status:   StatusError,
+			Message:  message,
+			Duration: time.Since(start),
+			Details:  map[string]interface{}{"command": command, "exit_code": exitCode},
+		}
+	}
+	return nil
 }
 
 
@@ -70,6 +263,55 @@ func ShellQuote(s string) string {
 	return "'" + strings.ReplaceAll(s, "'", "'\\''") + "'"
 }
 
+// ParseSize parses a size with an optional binary (Ki, Mi, Gi, ...) or decimal
+// (K, M, G, ...) suffix, e.g. "100Gi" or "500M", to bytes
+func ParseSize(size string) (int64, error) {
+	re := regexp.MustCompile(`^(\d+(?:\.\d+)?)(Ki|Mi|Gi|Ti|Pi|Ei|K|M|G|T|P|E)?$`)
+	matches := re.FindStringSubmatch(size)
+	if len(matches) == 0 {
+		return 0, fmt.Errorf("invalid size format: %s", size)
+	}
+
+	value, err := strconv.ParseFloat(matches[1], 64)
+	if err != nil {
+		return 0, err
+	}
+
+	unit := matches[2]
+	multiplier := int64(1)
+
+	switch unit {
+	case "Ki":
+		multiplier = 1024
+	case "Mi":
+		multiplier = 1024 * 1024
+	case "Gi":
+		multiplier = 1024 * 1024 * 1024
+	case "Ti":
+		multiplier = 1024 * 1024 * 1024 * 1024
+	case "Pi":
+		multiplier = 1024 * 1024 * 1024 * 1024 * 1024
+	case "Ei":
+		multiplier = 1024 * 1024 * 1024 * 1024 * 1024 * 1024
+	case "K":
+		multiplier = 1000
+	case "M":
+		multiplier = 1000 * 1000
+	case "G":
+		multiplier = 1000 * 1000 * 1000
+	case "T":
+		multiplier = 1000 * 1000 * 1000 * 1000
+	case "P":
+		multiplier = 1000 * 1000 * 1000 * 1000 * 1000
+	case "E":
+		multiplier = 1000 * 1000 * 1000 * 1000 * 1000 * 1000
+	case "":
+		multiplier = 1
+	}
+
+	return int64(value * float64(multiplier)), nil
+}
+
 
 
 
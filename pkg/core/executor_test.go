@@ -2,7 +2,11 @@ package core_test
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/neilfarmer/platform-spec/pkg/core"
 	k8splugin "github.com/neilfarmer/platform-spec/pkg/core/kubernetes"
@@ -80,6 +84,46 @@ func TestExecutor_FailFast(t *testing.T) {
 	}
 }
 
+func TestExecutor_FailFastContinueOnFailure(t *testing.T) {
+	mock := NewMockProvider()
+	mock.SetCommandResult("dpkg -l package1 2>/dev/null | grep '^ii'", "", "", 1, nil)
+	mock.SetCommandResult("rpm -q package1 2>/dev/null", "", "", 1, nil)
+	mock.SetCommandResult("apk info -e package1 2>/dev/null", "", "", 1, nil)
+
+	spec := &core.Spec{
+		Config: core.SpecConfig{
+			FailFast: true,
+		},
+		Tests: core.Tests{
+			Packages: []core.PackageTest{
+				{Name: "Informational", Packages: []string{"package1"}, State: "present", TestOptions: core.TestOptions{ContinueOnFailure: true}},
+				{Name: "Test 2", Packages: []string{"package1"}, State: "present"},
+				{Name: "Test 3", Packages: []string{"package3"}, State: "present"},
+			},
+		},
+	}
+
+	executor := core.NewExecutor(spec, mock, system.NewSystemPlugin(), k8splugin.NewKubernetesPlugin())
+	results, err := executor.Execute(context.Background())
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	// The continue_on_failure test is recorded as failed but does not stop the
+	// run; the next failure still triggers fail-fast
+	if len(results.Results) != 2 {
+		t.Fatalf("Expected 2 results, got %d", len(results.Results))
+	}
+	for _, r := range results.Results {
+		if r.Status != core.StatusFail {
+			t.Errorf("Test %q status = %v, want %v", r.Name, r.Status, core.StatusFail)
+		}
+	}
+	if results.Success() {
+		t.Error("Success() = true, want false: continue_on_failure must not hide the failure")
+	}
+}
+
 func TestExecutor_MultipleTests(t *testing.T) {
 	mock := NewMockProvider()
 	mock.SetCommandResult("dpkg -l docker-ce 2>/dev/null | grep '^ii'", "ii  docker-ce", "", 0, nil)
@@ -160,3 +204,694 @@ func containsMiddle(s, substr string) bool {
 	}
 	return false
 }
+
+func TestExecutor_GathersFactsForOSRouting(t *testing.T) {
+	mock := core.NewMockProvider()
+	mock.SetCommandResult("uname -sm 2>/dev/null", "Darwin arm64\n", "", 0, nil)
+	mock.SetCommandResult("launchctl list 'com.openssh.sshd' 2>/dev/null", "{\n\t\"PID\" = 412;\n};", "", 0, nil)
+
+	spec := &core.Spec{
+		Tests: core.Tests{
+			Services: []core.ServiceTest{
+				{Name: "sshd running", Service: "com.openssh.sshd", State: "running"},
+			},
+		},
+	}
+
+	executor := core.NewExecutor(spec, mock, system.NewSystemPlugin())
+	results, err := executor.Execute(context.Background())
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if len(results.Results) != 1 || results.Results[0].Status != core.StatusPass {
+		t.Errorf("Expected launchd service check to pass on a macOS host, got %+v", results.Results)
+	}
+}
+
+// recordingProvider records every command it is asked to run
+type recordingProvider struct {
+	*MockProvider
+	commands []string
+}
+
+func (r *recordingProvider) ExecuteCommand(ctx context.Context, command string) (stdout, stderr string, exitCode int, err error) {
+	r.commands = append(r.commands, command)
+	return r.MockProvider.ExecuteCommand(ctx, command)
+}
+
+func (r *recordingProvider) indexOf(command string) int {
+	for i, c := range r.commands {
+		if c == command {
+			return i
+		}
+	}
+	return -1
+}
+
+func TestExecutor_HooksRunAroundTests(t *testing.T) {
+	provider := &recordingProvider{MockProvider: NewMockProvider()}
+	provider.SetCommandResult("dpkg -l nginx 2>/dev/null | grep '^ii'", "", "", 1, nil)
+	provider.SetCommandResult("rpm -q nginx 2>/dev/null", "", "", 1, nil)
+	provider.SetCommandResult("apk info -e nginx 2>/dev/null", "", "", 1, nil)
+
+	spec := &core.Spec{
+		Config: core.SpecConfig{
+			Hooks: core.SpecHooks{
+				Before: []string{"systemctl start warm-cache", "sleep 1"},
+				After:  []string{"journalctl -u nginx -n 50"},
+			},
+		},
+		Tests: core.Tests{
+			Packages: []core.PackageTest{
+				{Name: "nginx installed", Packages: []string{"nginx"}, State: "present"},
+			},
+		},
+	}
+
+	executor := core.NewExecutor(spec, provider, system.NewSystemPlugin())
+	results, err := executor.Execute(context.Background())
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	warm := provider.indexOf("systemctl start warm-cache")
+	sleep := provider.indexOf("sleep 1")
+	test := provider.indexOf("dpkg -l nginx 2>/dev/null | grep '^ii'")
+	after := provider.indexOf("journalctl -u nginx -n 50")
+	if warm < 0 || !(warm < sleep && sleep < test && test < after) {
+		t.Errorf("commands ran out of order: %q", provider.commands)
+	}
+
+	// After hooks run even though the test failed, and do not add a result on success
+	if len(results.Results) != 1 || results.Results[0].Status != core.StatusFail {
+		t.Errorf("Expected only the failed package result, got %+v", results.Results)
+	}
+}
+
+func TestExecutor_BeforeHookFailureAbortsSpec(t *testing.T) {
+	provider := &recordingProvider{MockProvider: NewMockProvider()}
+	provider.SetCommandResult("systemctl start warm-cache", "", "unit not found\n", 5, nil)
+
+	spec := &core.Spec{
+		Config: core.SpecConfig{
+			Hooks: core.SpecHooks{
+				Before: []string{"systemctl start warm-cache", "never run"},
+				After:  []string{"journalctl -u nginx -n 50"},
+			},
+		},
+		Tests: core.Tests{
+			Packages: []core.PackageTest{
+				{Name: "nginx installed", Packages: []string{"nginx"}, State: "present"},
+			},
+		},
+	}
+
+	executor := core.NewExecutor(spec, provider, system.NewSystemPlugin())
+	results, err := executor.Execute(context.Background())
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	if provider.indexOf("never run") >= 0 || provider.indexOf("dpkg -l nginx 2>/dev/null | grep '^ii'") >= 0 {
+		t.Errorf("nothing should run after a failed before hook except after hooks: %q", provider.commands)
+	}
+	if provider.indexOf("journalctl -u nginx -n 50") < 0 {
+		t.Error("after hooks should run when a before hook fails")
+	}
+
+	if len(results.Results) != 1 {
+		t.Fatalf("Expected 1 result, got %+v", results.Results)
+	}
+	got := results.Results[0]
+	want := "before hook 'systemctl start warm-cache' failed with exit code 5: unit not found; spec tests were not run"
+	if got.Name != "before hook" || got.Status != core.StatusError || got.Message != want {
+		t.Errorf("before hook result = %+v, want error %q", got, want)
+	}
+}
+
+func TestExecutor_LooksUpCommandsOnce(t *testing.T) {
+	provider := &recordingProvider{MockProvider: NewMockProvider()}
+	spec := &core.Spec{
+		Tests: core.Tests{
+			Kubernetes: core.KubernetesTests{
+				Namespaces: []core.KubernetesNamespaceTest{
+					{Name: "prod exists", Namespace: "prod"},
+					{Name: "staging exists", Namespace: "staging"},
+					{Name: "dev exists", Namespace: "dev"},
+				},
+			},
+		},
+	}
+
+	if _, err := core.NewExecutor(spec, provider, k8splugin.NewKubernetesPlugin()).Execute(context.Background()); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	lookups := 0
+	for _, command := range provider.commands {
+		if command == "command -v 'kubectl' >/dev/null 2>&1" {
+			lookups++
+		}
+	}
+	if lookups != 1 {
+		t.Errorf("kubectl was looked up %d times, want once: %q", lookups, provider.commands)
+	}
+}
+
+func TestExecutor_MissingRequiredTools(t *testing.T) {
+	provider := &recordingProvider{MockProvider: NewMockProvider()}
+	provider.SetCommandResult("command -v 'helm' >/dev/null 2>&1", "", "", 1, nil)
+	provider.SetCommandResult("command -v 'docker' >/dev/null 2>&1", "", "", 1, nil)
+
+	spec := &core.Spec{
+		Config: core.SpecConfig{
+			Requires: []string{"kubectl", "helm", "docker"},
+			Hooks:    core.SpecHooks{Before: []string{"systemctl start warm-cache"}, After: []string{"collect-diag"}},
+		},
+		Tests: core.Tests{
+			Packages: []core.PackageTest{
+				{Name: "nginx installed", Packages: []string{"nginx"}, State: "present"},
+			},
+		},
+	}
+
+	results, err := core.NewExecutor(spec, provider, system.NewSystemPlugin()).Execute(context.Background())
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	// Every tool is looked up, and nothing else runs
+	if provider.indexOf("command -v 'kubectl' >/dev/null 2>&1") < 0 {
+		t.Errorf("kubectl was not looked up: %q", provider.commands)
+	}
+	for _, command := range []string{"systemctl start warm-cache", "dpkg -l nginx 2>/dev/null | grep '^ii'", "collect-diag"} {
+		if provider.indexOf(command) >= 0 {
+			t.Errorf("%q ran despite missing tools: %q", command, provider.commands)
+		}
+	}
+
+	if len(results.Results) != 1 {
+		t.Fatalf("Expected 1 result, got %+v", results.Results)
+	}
+	got := results.Results[0]
+	want := "missing tools: helm, docker; spec tests were not run"
+	if got.Name != "required tools" || got.Status != core.StatusError || got.Message != want {
+		t.Errorf("required tools result = %+v, want error %q", got, want)
+	}
+}
+
+func TestExecutor_RequiredToolsPresent(t *testing.T) {
+	provider := &recordingProvider{MockProvider: NewMockProvider()}
+	spec := &core.Spec{
+		Config: core.SpecConfig{Requires: []string{"kubectl"}},
+		Tests: core.Tests{
+			Packages: []core.PackageTest{
+				{Name: "nginx installed", Packages: []string{"nginx"}, State: "present"},
+			},
+		},
+	}
+
+	results, err := core.NewExecutor(spec, provider, system.NewSystemPlugin()).Execute(context.Background())
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if len(results.Results) != 1 || results.Results[0].Name != "nginx installed" {
+		t.Errorf("Expected only the package result, got %+v", results.Results)
+	}
+}
+
+func TestExecutor_AfterHookFailureIsReported(t *testing.T) {
+	provider := &recordingProvider{MockProvider: NewMockProvider()}
+	provider.SetCommandResult("collect-diag", "", "", 0, errors.New("session closed"))
+
+	spec := &core.Spec{
+		Config: core.SpecConfig{Hooks: core.SpecHooks{After: []string{"collect-diag"}}},
+	}
+
+	results, err := core.NewExecutor(spec, provider, system.NewSystemPlugin()).Execute(context.Background())
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if len(results.Results) != 1 || results.Results[0].Name != "after hook" ||
+		results.Results[0].Message != "after hook 'collect-diag' failed: session closed" {
+		t.Errorf("Expected an after hook error result, got %+v", results.Results)
+	}
+}
+
+// flakyPlugin checks each package test through core.RunTest, reporting a
+// refused connection until the test has been checked failures times
+type flakyPlugin struct {
+	failures int
+	calls    map[string]int
+}
+
+func (p *flakyPlugin) Execute(ctx context.Context, spec *core.Spec, provider core.Provider, failFast bool) ([]core.Result, bool) {
+	var results []core.Result
+	for _, test := range spec.Tests.Packages {
+		results = append(results, core.RunTest(ctx, test.TestOptions, func(ctx context.Context) core.Result {
+			p.calls[test.Name]++
+			if p.calls[test.Name] <= p.failures {
+				return core.Result{Name: test.Name, Status: core.StatusFail, Message: "dial tcp: connection refused"}
+			}
+			return core.Result{Name: test.Name, Status: core.StatusPass}
+		}))
+	}
+	return results, false
+}
+
+func TestExecutor_SpecRetries(t *testing.T) {
+	plugin := &flakyPlugin{failures: 2, calls: make(map[string]int)}
+	spec := &core.Spec{
+		Config: core.SpecConfig{Retries: 2},
+		Tests: core.Tests{
+			Packages: []core.PackageTest{
+				{Name: "inherits spec retries", Packages: []string{"nginx"}},
+				{Name: "overrides spec retries", Packages: []string{"nginx"},
+					TestOptions: core.TestOptions{Retry: &core.TestRetry{Attempts: 1}}},
+			},
+		},
+	}
+
+	results, err := core.NewExecutor(spec, NewMockProvider(), plugin).Execute(context.Background())
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if len(results.Results) != 2 {
+		t.Fatalf("Expected 2 results, got %d", len(results.Results))
+	}
+
+	inherited, overridden := results.Results[0], results.Results[1]
+	if inherited.Status != core.StatusPass || plugin.calls[inherited.Name] != 3 {
+		t.Errorf("spec retries: status %v after %d checks, want pass after 3", inherited.Status, plugin.calls[inherited.Name])
+	}
+	if inherited.Attempts != 3 || inherited.MaxAttempts != 3 {
+		t.Errorf("spec retries: attempt %d/%d, want 3/3", inherited.Attempts, inherited.MaxAttempts)
+	}
+	if overridden.Status != core.StatusFail || plugin.calls[overridden.Name] != 1 {
+		t.Errorf("per-test retry: status %v after %d checks, want fail after 1", overridden.Status, plugin.calls[overridden.Name])
+	}
+}
+
+func TestExecutor_RetryHook(t *testing.T) {
+	plugin := &flakyPlugin{failures: 2, calls: make(map[string]int)}
+	spec := &core.Spec{
+		Tests: core.Tests{
+			Packages: []core.PackageTest{{Name: "flaky", Packages: []string{"nginx"},
+				TestOptions: core.TestOptions{Retry: &core.TestRetry{Attempts: 5, Interval: time.Millisecond}}}},
+		},
+	}
+
+	var retried []int
+	ctx := core.WithRetryHook(context.Background(), func(result core.Result, attempt int, nextDelay time.Duration) {
+		if result.Name != "flaky" || result.Message != "dial tcp: connection refused" || nextDelay != time.Millisecond {
+			t.Errorf("hook got result %+v, next delay %s", result, nextDelay)
+		}
+		retried = append(retried, attempt)
+	})
+	results, err := core.NewExecutor(spec, NewMockProvider(), plugin).Execute(ctx)
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if results.Results[0].Status != core.StatusPass {
+		t.Errorf("status %v, want pass", results.Results[0].Status)
+	}
+	// The third check passes, so only the first two are retried
+	if len(retried) != 2 || retried[0] != 1 || retried[1] != 2 {
+		t.Errorf("retry hook attempts = %v, want [1 2]", retried)
+	}
+}
+
+func TestExecutor_SetRetryClassifier(t *testing.T) {
+	plugin := &flakyPlugin{failures: 2, calls: make(map[string]int)}
+	spec := &core.Spec{
+		Tests: core.Tests{
+			Packages: []core.PackageTest{{Name: "mirror down", Packages: []string{"nginx"},
+				TestOptions: core.TestOptions{Retry: &core.TestRetry{Attempts: 3, Interval: time.Millisecond}}}},
+		},
+	}
+
+	// A refused connection is transient by default, so the third check passes
+	results, err := core.NewExecutor(spec, NewMockProvider(), plugin).Execute(context.Background())
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if results.Results[0].Status != core.StatusPass || plugin.calls["mirror down"] != 3 {
+		t.Errorf("default: status %v after %d checks, want pass after 3", results.Results[0].Status, plugin.calls["mirror down"])
+	}
+
+	// A classifier for the packages category treats it as permanent
+	plugin.calls = make(map[string]int)
+	executor := core.NewExecutor(spec, NewMockProvider(), plugin)
+	var classified []string
+	executor.SetRetryClassifier("packages", func(err error) bool {
+		classified = append(classified, err.Error())
+		return false
+	})
+	// Classifiers for other categories are not consulted
+	executor.SetRetryClassifier("http", func(err error) bool {
+		t.Errorf("http classifier called for %v", err)
+		return true
+	})
+	results, err = executor.Execute(context.Background())
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if results.Results[0].Status != core.StatusFail || plugin.calls["mirror down"] != 1 {
+		t.Errorf("override: status %v after %d checks, want fail after 1", results.Results[0].Status, plugin.calls["mirror down"])
+	}
+	if len(classified) != 1 || !strings.Contains(classified[0], "connection refused") {
+		t.Errorf("classifier saw %v, want the refused connection", classified)
+	}
+}
+
+// httpStatusPlugin checks each HTTP test through core.RunTest, reporting the
+// status code its next check gets from statuses until they run out
+type httpStatusPlugin struct {
+	statuses []int
+	calls    int
+}
+
+func (p *httpStatusPlugin) Execute(ctx context.Context, spec *core.Spec, provider core.Provider, failFast bool) ([]core.Result, bool) {
+	var results []core.Result
+	for _, test := range spec.Tests.HTTP {
+		results = append(results, core.RunTest(ctx, test.TestOptions, func(ctx context.Context) core.Result {
+			p.calls++
+			if p.calls <= len(p.statuses) {
+				return core.Result{Name: test.Name, Status: core.StatusFail,
+					Message: fmt.Sprintf("Status code is %d, expected 200", p.statuses[p.calls-1])}
+			}
+			return core.Result{Name: test.Name, Status: core.StatusPass}
+		}))
+	}
+	return results, false
+}
+
+func TestExecutor_DefaultHTTPRetryClassifier(t *testing.T) {
+	tests := []struct {
+		name       string
+		statuses   []int
+		wantStatus core.Status
+		wantCalls  int
+	}{
+		{"unavailable backend is retried", []int{503, 502}, core.StatusPass, 3},
+		{"not found is permanent", []int{404}, core.StatusFail, 1},
+		{"retry stops at a permanent status", []int{503, 404}, core.StatusFail, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plugin := &httpStatusPlugin{statuses: tt.statuses}
+			spec := &core.Spec{
+				Tests: core.Tests{
+					HTTP: []core.HTTPTest{{Name: "health", URL: "http://localhost/health",
+						TestOptions: core.TestOptions{Retry: &core.TestRetry{Attempts: 3, Interval: time.Millisecond}}}},
+				},
+			}
+			results, err := core.NewExecutor(spec, NewMockProvider(), plugin).Execute(context.Background())
+			if err != nil {
+				t.Fatalf("Execute() error = %v", err)
+			}
+			if results.Results[0].Status != tt.wantStatus || plugin.calls != tt.wantCalls {
+				t.Errorf("status %v after %d checks, want %v after %d", results.Results[0].Status, plugin.calls, tt.wantStatus, tt.wantCalls)
+			}
+		})
+	}
+}
+
+// droppingProvider loses its connection after sending partial output on
+// the drop'th run of dpkg, counting from 1; every other command passes
+type droppingProvider struct {
+	drop  int
+	calls int
+}
+
+func (p *droppingProvider) ExecuteCommand(ctx context.Context, command string) (string, string, int, error) {
+	if !strings.HasPrefix(command, "dpkg") {
+		return "", "", 0, nil
+	}
+	p.calls++
+	if p.calls == p.drop {
+		lost := &core.ConnectionLostError{Err: errors.New("EOF"), Stdout: "partial"}
+		core.ReportConnectionLost(ctx, lost)
+		return "partial", "", -1, lost
+	}
+	return "ok", "", 0, nil
+}
+
+// commandPlugin checks each package test by running one command through
+// core.RunTest, failing the test if the command errors
+type commandPlugin struct{}
+
+func (commandPlugin) Execute(ctx context.Context, spec *core.Spec, provider core.Provider, failFast bool) ([]core.Result, bool) {
+	var results []core.Result
+	for _, test := range spec.Tests.Packages {
+		results = append(results, core.RunTest(ctx, test.TestOptions, func(ctx context.Context) core.Result {
+			if _, _, _, err := provider.ExecuteCommand(ctx, "dpkg -s nginx"); err != nil {
+				return core.Result{Name: test.Name, Status: core.StatusFail, Message: "Package not installed"}
+			}
+			return core.Result{Name: test.Name, Status: core.StatusPass}
+		}))
+	}
+	return results, false
+}
+
+func TestExecutor_ConnectionLost(t *testing.T) {
+	tests := []struct {
+		name         string
+		retry        *core.TestRetry
+		wantStatus   core.Status
+		wantAttempts int
+	}{
+		{name: "without retries", wantStatus: core.StatusError},
+		{name: "with retries", retry: &core.TestRetry{Attempts: 2}, wantStatus: core.StatusPass, wantAttempts: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := &core.Spec{
+				Tests: core.Tests{
+					Packages: []core.PackageTest{
+						{Name: "before drop", Packages: []string{"nginx"}},
+						{Name: "dropped", Packages: []string{"nginx"}, TestOptions: core.TestOptions{Retry: tt.retry}},
+						{Name: "after drop", Packages: []string{"nginx"}},
+					},
+				},
+			}
+			provider := &droppingProvider{drop: 2}
+			results, err := core.NewExecutor(spec, provider, commandPlugin{}).Execute(context.Background())
+			if err != nil {
+				t.Fatalf("Execute() error = %v", err)
+			}
+			if len(results.Results) != 3 {
+				t.Fatalf("Expected 3 results, got %d", len(results.Results))
+			}
+
+			before, dropped, after := results.Results[0], results.Results[1], results.Results[2]
+			if before.Status != core.StatusPass || after.Status != core.StatusPass {
+				t.Errorf("tests around the drop = %v and %v, want both to pass", before.Status, after.Status)
+			}
+			if dropped.Status != tt.wantStatus || dropped.Attempts != tt.wantAttempts {
+				t.Errorf("dropped test = %v after %d attempts (%s), want %v after %d",
+					dropped.Status, dropped.Attempts, dropped.Message, tt.wantStatus, tt.wantAttempts)
+			}
+			if tt.wantStatus == core.StatusError {
+				if dropped.Message != "connection lost during test: EOF" {
+					t.Errorf("dropped test message = %q", dropped.Message)
+				}
+				if dropped.Attachments["partial_output"] != "partial" {
+					t.Errorf("dropped test attachments = %v, want the partial output", dropped.Attachments)
+				}
+			}
+		})
+	}
+}
+
+func TestExecutor_NoSpecRetries(t *testing.T) {
+	plugin := &flakyPlugin{failures: 1, calls: make(map[string]int)}
+	spec := &core.Spec{
+		Tests: core.Tests{
+			Packages: []core.PackageTest{{Name: "no retries", Packages: []string{"nginx"}}},
+		},
+	}
+
+	results, err := core.NewExecutor(spec, NewMockProvider(), plugin).Execute(context.Background())
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if results.Results[0].Status != core.StatusFail || plugin.calls["no retries"] != 1 {
+		t.Errorf("status %v after %d checks, want fail after 1", results.Results[0].Status, plugin.calls["no retries"])
+	}
+}
+
+// envPlugin reports the environment providers would export, as a test that
+// echoes command output would
+type envPlugin struct{}
+
+func (envPlugin) Execute(ctx context.Context, spec *core.Spec, provider core.Provider, failFast bool) ([]core.Result, bool) {
+	env := strings.Join(core.EnvFromContext(ctx), " ")
+	return []core.Result{{
+		Name:    "env",
+		Status:  core.StatusFail,
+		Message: "command failed with " + env,
+		Details: map[string]interface{}{"stderr": env, "exit_code": 1},
+	}}, false
+}
+
+func TestExecutor_EnvRedaction(t *testing.T) {
+	spec := &core.Spec{
+		Config: core.SpecConfig{
+			Env:          map[string]string{"REGION": "eu-west-1", "API_TOKEN": "s3cr3t"},
+			SensitiveEnv: []string{"API_TOKEN"},
+		},
+	}
+
+	results, err := core.NewExecutor(spec, NewMockProvider(), envPlugin{}).Execute(context.Background())
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	want := "API_TOKEN=[REDACTED] LANG=C LC_ALL=C REGION=eu-west-1"
+	if got := results.Results[0].Message; got != "command failed with "+want {
+		t.Errorf("Message = %q, want the token redacted", got)
+	}
+	if got := results.Results[0].Details["stderr"]; got != want {
+		t.Errorf("Details[stderr] = %q, want %q", got, want)
+	}
+
+	resolved, err := spec.ResolvedYAML()
+	if err != nil {
+		t.Fatalf("ResolvedYAML() error = %v", err)
+	}
+	if strings.Contains(string(resolved), "s3cr3t") {
+		t.Errorf("ResolvedYAML() leaks a sensitive value:\n%s", resolved)
+	}
+	if spec.Config.Env["API_TOKEN"] != "s3cr3t" {
+		t.Error("ResolvedYAML() modified the spec's env")
+	}
+}
+
+func TestResolvedYAML_RedactsHTTPCredentialHeaders(t *testing.T) {
+	spec := &core.Spec{
+		Tests: core.Tests{
+			HTTP: []core.HTTPTest{{Name: "api", URL: "https://api.example.com/health", Headers: map[string]string{
+				"Authorization": "Bearer s3cr3t",
+				"Cookie":        "session=s3cr3t",
+				"Accept":        "application/json",
+			}}},
+		},
+	}
+
+	resolved, err := spec.ResolvedYAML()
+	if err != nil {
+		t.Fatalf("ResolvedYAML() error = %v", err)
+	}
+	if strings.Contains(string(resolved), "s3cr3t") || !strings.Contains(string(resolved), "Accept: application/json") {
+		t.Errorf("ResolvedYAML() should redact only credential headers:\n%s", resolved)
+	}
+	if spec.Tests.HTTP[0].Headers["Authorization"] != "Bearer s3cr3t" {
+		t.Error("ResolvedYAML() modified the spec's headers")
+	}
+}
+
+// attachPlugin attaches evidence that echoes the environment, once plainly
+// and once as a sensitive attachment
+type attachPlugin struct{}
+
+func (attachPlugin) Execute(ctx context.Context, spec *core.Spec, provider core.Provider, failFast bool) ([]core.Result, bool) {
+	result := core.Result{Name: "evidence", Status: core.StatusPass}
+	env := strings.Join(core.EnvFromContext(ctx), " ")
+	result.Attach("config", env)
+	result.AttachSensitive("secret", "password: hunter2")
+	return []core.Result{result}, false
+}
+
+func TestExecutor_AttachmentRedaction(t *testing.T) {
+	spec := &core.Spec{
+		Config: core.SpecConfig{
+			Env:          map[string]string{"API_TOKEN": "s3cr3t"},
+			SensitiveEnv: []string{"API_TOKEN"},
+		},
+	}
+
+	results, err := core.NewExecutor(spec, NewMockProvider(), attachPlugin{}).Execute(context.Background())
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	attachments := results.Results[0].Attachments
+	if got := attachments["config"]; got != "API_TOKEN=[REDACTED] LANG=C LC_ALL=C" {
+		t.Errorf("Attachments[config] = %q, want the token redacted", got)
+	}
+	if got := attachments["secret"]; got != core.Redacted {
+		t.Errorf("Attachments[secret] = %q, want it redacted by default", got)
+	}
+
+	spec.Config.ShowSensitiveAttachments = true
+	results, err = core.NewExecutor(spec, NewMockProvider(), attachPlugin{}).Execute(context.Background())
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	attachments = results.Results[0].Attachments
+	if got := attachments["secret"]; got != "password: hunter2" {
+		t.Errorf("Attachments[secret] = %q, want it shown with show_sensitive_attachments", got)
+	}
+	if got := attachments["config"]; got != "API_TOKEN=[REDACTED] LANG=C LC_ALL=C" {
+		t.Errorf("Attachments[config] = %q, want sensitive env values still redacted", got)
+	}
+}
+
+func TestExecutor_SeededFacts(t *testing.T) {
+	provider := &recordingProvider{MockProvider: NewMockProvider()}
+	spec := &core.Spec{
+		Tests: core.Tests{
+			Packages: []core.PackageTest{{Name: "nginx installed", Packages: []string{"nginx"}, State: "present"}},
+		},
+	}
+
+	// Seeded Windows facts route the spec to the Windows executors, which
+	// skip package tests, without probing the host
+	ctx := core.WithSeedFacts(context.Background(), &core.Facts{OS: core.OSWindows, Arch: "AMD64"})
+	results, err := core.NewExecutor(spec, provider, system.NewSystemPlugin()).Execute(ctx)
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if len(provider.commands) != 0 {
+		t.Errorf("expected no commands with complete seeded facts, got %v", provider.commands)
+	}
+	if len(results.Results) != 1 || results.Results[0].SkipReason != core.SkipUnsupportedPlatform {
+		t.Errorf("expected the package test skipped as unsupported on Windows, got %+v", results.Results)
+	}
+}
+
+func TestExecutor_TestTimeout(t *testing.T) {
+	spec := &core.Spec{
+		Config: core.SpecConfig{Timeout: 1},
+		Tests: core.Tests{
+			Packages: []core.PackageTest{
+				{Name: "hangs", Packages: []string{"nginx"}},
+			},
+		},
+	}
+	provider := core.NewMockProvider()
+	provider.SetCommandBlocking("dpkg -s nginx")
+
+	done := make(chan *core.TestResults, 1)
+	go func() {
+		results, err := core.NewExecutor(spec, provider, commandPlugin{}).Execute(context.Background())
+		if err != nil {
+			t.Errorf("Execute() error = %v", err)
+		}
+		done <- results
+	}()
+
+	var results *core.TestResults
+	select {
+	case results = <-done:
+	case <-time.After(10 * time.Second):
+		t.Fatal("Execute() did not return; the timeout never fired")
+	}
+	if results == nil || len(results.Results) != 1 {
+		t.Fatalf("Expected 1 result, got %+v", results)
+	}
+	result := results.Results[0]
+	if result.Status != core.StatusError || result.Message != "timed out after 1s" {
+		t.Errorf("result = %v %q, want error \"timed out after 1s\"", result.Status, result.Message)
+	}
+}
@@ -0,0 +1,68 @@
+package core
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ResolvedYAML returns the spec as YAML after imports have been merged and
+// defaults applied. Empty test categories and fields are omitted, and
+// sensitive env values and HTTP credential headers are redacted.
+func (s *Spec) ResolvedYAML() ([]byte, error) {
+	var node yaml.Node
+	if err := node.Encode(s.redacted()); err != nil {
+		return nil, fmt.Errorf("failed to encode spec: %w", err)
+	}
+	pruneEmpty(&node)
+
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&node); err != nil {
+		return nil, fmt.Errorf("failed to encode spec: %w", err)
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, fmt.Errorf("failed to encode spec: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// Hash returns a SHA-256 hex digest of the resolved spec. Two runs of an
+// unchanged spec share a hash, so stored results can be grouped by spec version.
+func (s *Spec) Hash() string {
+	data, err := s.ResolvedYAML()
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// pruneEmpty removes mapping entries whose values are null, empty strings,
+// or empty sequences and mappings, so unused test categories are not printed
+func pruneEmpty(node *yaml.Node) bool {
+	switch node.Kind {
+	case yaml.MappingNode:
+		var content []*yaml.Node
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if pruneEmpty(node.Content[i+1]) {
+				continue
+			}
+			content = append(content, node.Content[i], node.Content[i+1])
+		}
+		node.Content = content
+		return len(content) == 0
+	case yaml.SequenceNode:
+		for _, item := range node.Content {
+			pruneEmpty(item)
+		}
+		return len(node.Content) == 0
+	case yaml.ScalarNode:
+		return node.Tag == "!!null" || (node.Tag == "!!str" && node.Value == "")
+	}
+	return false
+}
@@ -0,0 +1,105 @@
+package core
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestResolvedYAML(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	baseSpec := `version: "1.0"
+variables:
+  env: staging
+  region: us-east-1
+tests:
+  packages:
+    - name: "base package"
+      packages: [curl]`
+	if err := os.WriteFile(filepath.Join(tmpDir, "base.yaml"), []byte(baseSpec), 0644); err != nil {
+		t.Fatalf("Failed to create base spec: %v", err)
+	}
+
+	mainSpec := `version: "1.0"
+imports:
+  - base.yaml
+variables:
+  env: production
+tests:
+  services:
+    - name: "nginx running"
+      service: nginx
+      state: running
+  http:
+    - name: "health"
+      url: http://localhost/health`
+	mainFile := filepath.Join(tmpDir, "main.yaml")
+	if err := os.WriteFile(mainFile, []byte(mainSpec), 0644); err != nil {
+		t.Fatalf("Failed to create main spec: %v", err)
+	}
+
+	spec, err := ParseSpec(mainFile)
+	if err != nil {
+		t.Fatalf("Failed to parse spec: %v", err)
+	}
+
+	data, err := spec.ResolvedYAML()
+	if err != nil {
+		t.Fatalf("ResolvedYAML() error: %v", err)
+	}
+	out := string(data)
+
+	wantContains := []string{
+		"state: present",      // package default
+		"state: running",      // service state
+		"status_code: 200",    // http default
+		"method: GET",         // http default
+		"env: production",     // main spec variable overrides import
+		"region: us-east-1",   // variable from import
+		"name: base package",  // imported test merged
+		"name: nginx running", // main spec test
+	}
+	for _, want := range wantContains {
+		if !strings.Contains(out, want) {
+			t.Errorf("ResolvedYAML() missing %q:\n%s", want, out)
+		}
+	}
+
+	// Unused categories and the imports list are not printed
+	for _, unwanted := range []string{"docker:", "kubernetes:", "imports:", "env: staging"} {
+		if strings.Contains(out, unwanted) {
+			t.Errorf("ResolvedYAML() should not contain %q:\n%s", unwanted, out)
+		}
+	}
+}
+
+func TestSpecHash(t *testing.T) {
+	spec := &Spec{
+		Tests: Tests{
+			Packages: []PackageTest{{Name: "curl", Packages: []string{"curl"}, State: "present"}},
+		},
+	}
+	same := &Spec{
+		Tests: Tests{
+			Packages: []PackageTest{{Name: "curl", Packages: []string{"curl"}, State: "present"}},
+		},
+	}
+	changed := &Spec{
+		Tests: Tests{
+			Packages: []PackageTest{{Name: "curl", Packages: []string{"curl"}, State: "absent"}},
+		},
+	}
+
+	hash := spec.Hash()
+	if len(hash) != 64 {
+		t.Fatalf("Hash() = %q, want a SHA-256 hex digest", hash)
+	}
+	if same.Hash() != hash {
+		t.Errorf("identical specs hash differently: %s vs %s", same.Hash(), hash)
+	}
+	if changed.Hash() == hash {
+		t.Error("changed spec has the same hash")
+	}
+}
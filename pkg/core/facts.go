@@ -0,0 +1,220 @@
+package core
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+)
+
+// Operating systems reported by providers and fact gathering
+const (
+	OSLinux   = "linux"
+	OSDarwin  = "darwin"
+	OSFreeBSD = "freebsd"
+	OSOpenBSD = "openbsd"
+	OSNetBSD  = "netbsd"
+	OSWindows = "windows"
+)
+
+// OSProvider is implemented by providers whose target operating system is known without probing it
+type OSProvider interface {
+	OS() string
+}
+
+// ProviderOS returns the target operating system of a provider, or "" if it is not known
+func ProviderOS(provider Provider) string {
+	if p, ok := provider.(OSProvider); ok {
+		return p.OS()
+	}
+	return ""
+}
+
+// Facts holds information gathered about a target host
+type Facts struct {
+	OS   string `json:"os,omitempty"`   // Operating system (linux, darwin, freebsd, windows, ...); "" if unknown
+	Arch string `json:"arch,omitempty"` // Machine architecture as reported by uname -m
+}
+
+// complete reports whether every fact is known, so nothing needs probing
+func (f *Facts) complete() bool {
+	return f.OS != "" && f.Arch != ""
+}
+
+// mergeFrom overrides facts with the non-empty facts of seed
+func (f *Facts) mergeFrom(seed *Facts) {
+	if seed.OS != "" {
+		f.OS = seed.OS
+	}
+	if seed.Arch != "" {
+		f.Arch = seed.Arch
+	}
+}
+
+// LoadFacts reads facts from a JSON file such as {"os": "linux", "arch": "x86_64"}
+func LoadFacts(path string) (*Facts, error) {
+	// #nosec G304 -- Reading user-specified facts file is intentional and required functionality
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read facts file: %w", err)
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	var facts Facts
+	if err := decoder.Decode(&facts); err != nil {
+		return nil, fmt.Errorf("failed to parse facts file %s: %w", path, err)
+	}
+	facts.OS = strings.ToLower(facts.OS)
+	return &facts, nil
+}
+
+type factsKey struct{}
+
+type seedFactsKey struct{}
+
+// WithSeedFacts returns a context whose fact gathering uses the given facts
+// over discovered ones, e.g. for air-gapped runs or repeated CI runs
+func WithSeedFacts(ctx context.Context, seed *Facts) context.Context {
+	return context.WithValue(ctx, seedFactsKey{}, seed)
+}
+
+// SeedFactsFromContext returns the facts set with WithSeedFacts, or nil
+func SeedFactsFromContext(ctx context.Context) *Facts {
+	seed, _ := ctx.Value(seedFactsKey{}).(*Facts)
+	return seed
+}
+
+// GatherFacts collects facts about the provider's target host.
+// Providers that know their OS are not probed; otherwise uname is used.
+// Facts seeded with WithSeedFacts win over discovered ones, and when they
+// are complete the host is not probed at all.
+func GatherFacts(ctx context.Context, provider Provider) *Facts {
+	seed := SeedFactsFromContext(ctx)
+	if seed != nil && seed.complete() {
+		return &Facts{OS: seed.OS, Arch: seed.Arch}
+	}
+	facts := discoverFacts(ctx, provider)
+	if seed != nil {
+		facts.mergeFrom(seed)
+	}
+	return facts
+}
+
+// discoverFacts probes the target host for facts
+func discoverFacts(ctx context.Context, provider Provider) *Facts {
+	facts := &Facts{OS: ProviderOS(provider)}
+	if facts.OS != "" {
+		return facts
+	}
+
+	stdout, _, exitCode, err := provider.ExecuteCommand(ctx, "uname -sm 2>/dev/null")
+	if err != nil || exitCode != 0 {
+		return facts
+	}
+
+	fields := strings.Fields(stdout)
+	if len(fields) > 0 {
+		facts.OS = strings.ToLower(fields[0])
+	}
+	if len(fields) > 1 {
+		facts.Arch = fields[1]
+	}
+	return facts
+}
+
+// WithFacts returns a context carrying the given host facts
+func WithFacts(ctx context.Context, facts *Facts) context.Context {
+	return context.WithValue(ctx, factsKey{}, facts)
+}
+
+// FactsFromContext returns the host facts stored in the context, or nil
+func FactsFromContext(ctx context.Context) *Facts {
+	facts, _ := ctx.Value(factsKey{}).(*Facts)
+	return facts
+}
+
+// TargetOS returns the operating system of the host being tested, using gathered
+// facts when available. Unknown systems are treated as Linux.
+func TargetOS(ctx context.Context, provider Provider) string {
+	if os := ProviderOS(provider); os != "" {
+		return os
+	}
+	if facts := FactsFromContext(ctx); facts != nil && facts.OS != "" {
+		return facts.OS
+	}
+	return OSLinux
+}
+
+// IsBSD reports whether the operating system uses BSD userland tools (including macOS)
+func IsBSD(os string) bool {
+	switch os {
+	case OSDarwin, OSFreeBSD, OSOpenBSD, OSNetBSD:
+		return true
+	}
+	return false
+}
+
+// commandCache remembers which commands were found on the target, so each is
+// looked up once per run rather than before every test that needs it
+type commandCache struct {
+	mu        sync.Mutex
+	available map[string]bool
+}
+
+type commandCacheKey struct{}
+
+// withCommandCache returns a context in which CommandAvailable caches its answers
+func withCommandCache(ctx context.Context) context.Context {
+	return context.WithValue(ctx, commandCacheKey{}, &commandCache{available: make(map[string]bool)})
+}
+
+// commandCacheFromContext returns the cache set with withCommandCache, or nil
+func commandCacheFromContext(ctx context.Context) *commandCache {
+	cache, _ := ctx.Value(commandCacheKey{}).(*commandCache)
+	return cache
+}
+
+// CommandLookup returns the command CommandAvailable runs to look up name
+func CommandLookup(name string) string {
+	return "command -v " + ShellQuote(name) + " >/dev/null 2>&1"
+}
+
+// CommandAvailable reports whether the named command is on the target's PATH.
+// Answers are cached for the run, so each command is looked up once. If the
+// check itself fails, the command is assumed present so the caller reports
+// the underlying error instead, and it is looked up again next time.
+func CommandAvailable(ctx context.Context, provider Provider, name string) bool {
+	cache := commandCacheFromContext(ctx)
+	if cache != nil {
+		cache.mu.Lock()
+		available, ok := cache.available[name]
+		cache.mu.Unlock()
+		if ok {
+			return available
+		}
+	}
+
+	_, _, exitCode, err := provider.ExecuteCommand(ctx, CommandLookup(name))
+	if err != nil {
+		return true
+	}
+	available := exitCode == 0
+	if cache != nil {
+		cache.mu.Lock()
+		cache.available[name] = available
+		cache.mu.Unlock()
+	}
+	return available
+}
+
+// RequireCommand returns an error naming the command if it is not installed on
+// the target, so executors can say so instead of parsing empty output
+func RequireCommand(ctx context.Context, provider Provider, name string) error {
+	if !CommandAvailable(ctx, provider, name) {
+		return fmt.Errorf("required command '%s' not found on target", name)
+	}
+	return nil
+}
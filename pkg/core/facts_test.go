@@ -0,0 +1,191 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestGatherFacts(t *testing.T) {
+	tests := []struct {
+		name     string
+		setup    func(*MockProvider)
+		wantOS   string
+		wantArch string
+	}{
+		{
+			name: "linux",
+			setup: func(m *MockProvider) {
+				m.SetCommandResult("uname -sm 2>/dev/null", "Linux x86_64\n", "", 0, nil)
+			},
+			wantOS:   OSLinux,
+			wantArch: "x86_64",
+		},
+		{
+			name: "macOS",
+			setup: func(m *MockProvider) {
+				m.SetCommandResult("uname -sm 2>/dev/null", "Darwin arm64\n", "", 0, nil)
+			},
+			wantOS:   OSDarwin,
+			wantArch: "arm64",
+		},
+		{
+			name: "provider reports OS without probing",
+			setup: func(m *MockProvider) {
+				m.SetOS(OSWindows)
+				m.SetCommandResult("uname -sm 2>/dev/null", "", "", 0, errors.New("should not be called"))
+			},
+			wantOS: OSWindows,
+		},
+		{
+			name: "uname fails",
+			setup: func(m *MockProvider) {
+				m.SetCommandResult("uname -sm 2>/dev/null", "", "not found", 127, nil)
+			},
+			wantOS: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := NewMockProvider()
+			tt.setup(mock)
+
+			facts := GatherFacts(context.Background(), mock)
+			if facts.OS != tt.wantOS {
+				t.Errorf("OS = %q, want %q", facts.OS, tt.wantOS)
+			}
+			if facts.Arch != tt.wantArch {
+				t.Errorf("Arch = %q, want %q", facts.Arch, tt.wantArch)
+			}
+		})
+	}
+}
+
+func TestTargetOS(t *testing.T) {
+	mock := NewMockProvider()
+	ctx := context.Background()
+
+	if got := TargetOS(ctx, mock); got != OSLinux {
+		t.Errorf("TargetOS() without facts = %q, want %q", got, OSLinux)
+	}
+
+	ctx = WithFacts(ctx, &Facts{OS: OSFreeBSD})
+	if got := TargetOS(ctx, mock); got != OSFreeBSD {
+		t.Errorf("TargetOS() with facts = %q, want %q", got, OSFreeBSD)
+	}
+
+	mock.SetOS(OSWindows)
+	if got := TargetOS(ctx, mock); got != OSWindows {
+		t.Errorf("TargetOS() should prefer the provider's OS, got %q", got)
+	}
+}
+
+func TestIsBSD(t *testing.T) {
+	for _, os := range []string{OSDarwin, OSFreeBSD, OSOpenBSD, OSNetBSD} {
+		if !IsBSD(os) {
+			t.Errorf("IsBSD(%q) = false, want true", os)
+		}
+	}
+	for _, os := range []string{OSLinux, OSWindows, ""} {
+		if IsBSD(os) {
+			t.Errorf("IsBSD(%q) = true, want false", os)
+		}
+	}
+}
+
+func TestCommandAvailable(t *testing.T) {
+	mock := NewMockProvider()
+	mock.SetCommandResult("command -v 'findmnt' >/dev/null 2>&1", "", "", 1, nil)
+	mock.SetCommandResult("command -v 'helm' >/dev/null 2>&1", "", "", 0, errors.New("session closed"))
+	ctx := context.Background()
+
+	if !CommandAvailable(ctx, mock, "kubectl") {
+		t.Error("CommandAvailable(kubectl) = false, want true")
+	}
+	if CommandAvailable(ctx, mock, "findmnt") {
+		t.Error("CommandAvailable(findmnt) = true, want false")
+	}
+	// A failed check leaves the real error to the executor
+	if !CommandAvailable(ctx, mock, "helm") {
+		t.Error("CommandAvailable(helm) should assume the command exists when the check fails")
+	}
+
+	err := RequireCommand(ctx, mock, "findmnt")
+	if err == nil || err.Error() != "required command 'findmnt' not found on target" {
+		t.Errorf("RequireCommand(findmnt) = %v", err)
+	}
+	if err := RequireCommand(ctx, mock, "kubectl"); err != nil {
+		t.Errorf("RequireCommand(kubectl) = %v, want nil", err)
+	}
+}
+
+func TestCommandAvailable_Cached(t *testing.T) {
+	mock := NewMockProvider()
+	mock.SetCommandResult("command -v 'findmnt' >/dev/null 2>&1", "", "", 1, nil)
+	mock.SetCommandResult("command -v 'helm' >/dev/null 2>&1", "", "", 0, errors.New("session closed"))
+	ctx := withCommandCache(context.Background())
+
+	if CommandAvailable(ctx, mock, "findmnt") {
+		t.Fatal("CommandAvailable(findmnt) = true, want false")
+	}
+	CommandAvailable(ctx, mock, "helm")
+
+	// Installed since, but the cached answer is used
+	mock.SetCommandResult("command -v 'findmnt' >/dev/null 2>&1", "", "", 0, nil)
+	if CommandAvailable(ctx, mock, "findmnt") {
+		t.Error("CommandAvailable(findmnt) looked the command up again")
+	}
+
+	// A failed check is not cached
+	mock.SetCommandResult("command -v 'helm' >/dev/null 2>&1", "", "", 1, nil)
+	if CommandAvailable(ctx, mock, "helm") {
+		t.Error("CommandAvailable(helm) cached the failed check")
+	}
+}
+
+func TestGatherFacts_Seeded(t *testing.T) {
+	mock := NewMockProvider()
+
+	// Complete seeds replace gathering entirely
+	ctx := WithSeedFacts(context.Background(), &Facts{OS: OSFreeBSD, Arch: "amd64"})
+	mock.SetCommandResult("uname -sm 2>/dev/null", "", "", 0, errors.New("should not be called"))
+	if facts := GatherFacts(ctx, mock); facts.OS != OSFreeBSD || facts.Arch != "amd64" {
+		t.Errorf("GatherFacts() = %+v, want the seeded facts", facts)
+	}
+
+	// Partial seeds are merged over discovered facts
+	mock.SetCommandResult("uname -sm 2>/dev/null", "Linux x86_64\n", "", 0, nil)
+	ctx = WithSeedFacts(context.Background(), &Facts{Arch: "aarch64"})
+	if facts := GatherFacts(ctx, mock); facts.OS != OSLinux || facts.Arch != "aarch64" {
+		t.Errorf("GatherFacts() = %+v, want linux/aarch64", facts)
+	}
+}
+
+func TestLoadFacts(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+
+	facts, err := LoadFacts(write("facts.json", `{"os": "Darwin", "arch": "arm64"}`))
+	if err != nil {
+		t.Fatalf("LoadFacts() error = %v", err)
+	}
+	if facts.OS != OSDarwin || facts.Arch != "arm64" {
+		t.Errorf("LoadFacts() = %+v, want darwin/arm64", facts)
+	}
+
+	if _, err := LoadFacts(write("typo.json", `{"operating_system": "linux"}`)); err == nil {
+		t.Error("LoadFacts() should reject unknown facts")
+	}
+	if _, err := LoadFacts(filepath.Join(dir, "missing.json")); err == nil {
+		t.Error("LoadFacts() should fail for a missing file")
+	}
+}
@@ -0,0 +1,63 @@
+package gcp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/neilfarmer/platform-spec/pkg/core"
+)
+
+// executeGCPBucketTest executes a GCS bucket test
+func executeGCPBucketTest(ctx context.Context, client Client, test core.GCPBucketTest) core.Result {
+	start := time.Now()
+	result := core.Result{
+		Name:    test.Name,
+		Status:  core.StatusPass,
+		Details: make(map[string]interface{}),
+	}
+
+	bucket, err := client.GetBucket(ctx, test.Bucket)
+	exists := err == nil
+	if err != nil && !errors.Is(err, ErrNotFound) {
+		result.Status = core.StatusError
+		result.Message = fmt.Sprintf("Error getting bucket %s: %v", test.Bucket, err)
+		result.Duration = time.Since(start)
+		return result
+	}
+
+	// Check state
+	if test.State == "present" && !exists {
+		result.Status = core.StatusFail
+		result.Message = fmt.Sprintf("Bucket %s not found", test.Bucket)
+		result.Duration = time.Since(start)
+		return result
+	}
+
+	if test.State == "absent" {
+		if exists {
+			result.Status = core.StatusFail
+			result.Message = fmt.Sprintf("Bucket %s exists but should be absent", test.Bucket)
+		} else {
+			result.Message = fmt.Sprintf("Bucket %s is absent", test.Bucket)
+		}
+		result.Duration = time.Since(start)
+		return result
+	}
+
+	result.Details["location"] = bucket.Location
+
+	// Check location (GCS reports locations in upper case)
+	if test.Location != "" && !strings.EqualFold(bucket.Location, test.Location) {
+		result.Status = core.StatusFail
+		result.Message = fmt.Sprintf("Bucket %s location is %s, expected %s", test.Bucket, bucket.Location, test.Location)
+		result.Duration = time.Since(start)
+		return result
+	}
+
+	result.Message = fmt.Sprintf("Bucket %s exists in %s", test.Bucket, bucket.Location)
+	result.Duration = time.Since(start)
+	return result
+}
@@ -0,0 +1,63 @@
+package gcp
+
+import (
+	"context"
+	"testing"
+
+	"github.com/neilfarmer/platform-spec/pkg/core"
+)
+
+func TestExecutor_GCPBucketTest(t *testing.T) {
+	tests := []struct {
+		name         string
+		bucketTest   core.GCPBucketTest
+		setupClient  func(*fakeClient)
+		wantStatus   core.Status
+		wantContains string
+	}{
+		{
+			name:         "bucket in expected location",
+			bucketTest:   core.GCPBucketTest{Name: "assets", Bucket: "assets", State: "present", Location: "europe-west1"},
+			setupClient:  func(c *fakeClient) { c.buckets["assets"] = Bucket{Name: "assets", Location: "EUROPE-WEST1"} },
+			wantStatus:   core.StatusPass,
+			wantContains: "exists in EUROPE-WEST1",
+		},
+		{
+			name:         "missing bucket",
+			bucketTest:   core.GCPBucketTest{Name: "assets", Bucket: "assets", State: "present"},
+			setupClient:  func(c *fakeClient) {},
+			wantStatus:   core.StatusFail,
+			wantContains: "Bucket assets not found",
+		},
+		{
+			name:         "wrong location",
+			bucketTest:   core.GCPBucketTest{Name: "assets", Bucket: "assets", State: "present", Location: "EU"},
+			setupClient:  func(c *fakeClient) { c.buckets["assets"] = Bucket{Name: "assets", Location: "US"} },
+			wantStatus:   core.StatusFail,
+			wantContains: "location is US, expected EU",
+		},
+		{
+			name:         "bucket should be absent",
+			bucketTest:   core.GCPBucketTest{Name: "old", Bucket: "old-assets", State: "absent"},
+			setupClient:  func(c *fakeClient) { c.buckets["old-assets"] = Bucket{Name: "old-assets", Location: "US"} },
+			wantStatus:   core.StatusFail,
+			wantContains: "exists but should be absent",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newFakeClient()
+			tt.setupClient(client)
+
+			result := executeGCPBucketTest(context.Background(), client, tt.bucketTest)
+
+			if result.Status != tt.wantStatus {
+				t.Errorf("Status = %v, want %v (message: %s)", result.Status, tt.wantStatus, result.Message)
+			}
+			if tt.wantContains != "" && !contains(result.Message, tt.wantContains) {
+				t.Errorf("Message %q does not contain %q", result.Message, tt.wantContains)
+			}
+		})
+	}
+}
@@ -0,0 +1,92 @@
+package gcp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/neilfarmer/platform-spec/pkg/core"
+)
+
+// executeGCPInstanceTest executes a GCE instance test
+func executeGCPInstanceTest(ctx context.Context, client Client, test core.GCPInstanceTest) core.Result {
+	start := time.Now()
+	result := core.Result{
+		Name:    test.Name,
+		Status:  core.StatusPass,
+		Details: make(map[string]interface{}),
+	}
+
+	instance, err := client.GetInstance(ctx, test.Project, test.Zone, test.Instance)
+	exists := err == nil
+	if err != nil && !errors.Is(err, ErrNotFound) {
+		result.Status = core.StatusError
+		result.Message = fmt.Sprintf("Error getting instance %s: %v", test.Instance, err)
+		result.Duration = time.Since(start)
+		return result
+	}
+
+	// Check state
+	if test.State == "present" && !exists {
+		result.Status = core.StatusFail
+		result.Message = fmt.Sprintf("Instance %s not found in zone %s", test.Instance, test.Zone)
+		result.Duration = time.Since(start)
+		return result
+	}
+
+	if test.State == "absent" {
+		if exists {
+			result.Status = core.StatusFail
+			result.Message = fmt.Sprintf("Instance %s exists but should be absent", test.Instance)
+		} else {
+			result.Message = fmt.Sprintf("Instance %s is absent", test.Instance)
+		}
+		result.Duration = time.Since(start)
+		return result
+	}
+
+	result.Details["status"] = instance.Status
+	result.Details["machine_type"] = instance.MachineType
+
+	// Check status
+	if test.Status != "" && instance.Status != test.Status {
+		result.Status = core.StatusFail
+		result.Message = fmt.Sprintf("Instance %s status is %s, expected %s", test.Instance, instance.Status, test.Status)
+		result.Duration = time.Since(start)
+		return result
+	}
+
+	// Check machine type
+	if test.MachineType != "" && instance.MachineType != test.MachineType {
+		result.Status = core.StatusFail
+		result.Message = fmt.Sprintf("Instance %s machine type is %s, expected %s", test.Instance, instance.MachineType, test.MachineType)
+		result.Duration = time.Since(start)
+		return result
+	}
+
+	// Check labels
+	var mismatched []string
+	for key, want := range test.Labels {
+		got, ok := instance.Labels[key]
+		if !ok {
+			mismatched = append(mismatched, fmt.Sprintf("%s (missing)", key))
+		} else if got != want {
+			mismatched = append(mismatched, fmt.Sprintf("%s=%s (expected %s)", key, got, want))
+		}
+	}
+	if len(mismatched) > 0 {
+		sort.Strings(mismatched)
+		result.Status = core.StatusFail
+		result.Message = fmt.Sprintf("Instance %s labels do not match: %s", test.Instance, strings.Join(mismatched, ", "))
+		result.Details["mismatched_labels"] = mismatched
+		result.Duration = time.Since(start)
+		return result
+	}
+
+	result.Message = fmt.Sprintf("Instance %s is %s", test.Instance, instance.Status)
+	result.Duration = time.Since(start)
+	return result
+}
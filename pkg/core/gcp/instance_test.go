@@ -0,0 +1,98 @@
+package gcp
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/neilfarmer/platform-spec/pkg/core"
+)
+
+func TestExecutor_GCPInstanceTest(t *testing.T) {
+	running := Instance{
+		Name:        "web-01",
+		Status:      "RUNNING",
+		MachineType: "e2-medium",
+		Labels:      map[string]string{"env": "prod", "team": "web"},
+	}
+
+	tests := []struct {
+		name         string
+		instanceTest core.GCPInstanceTest
+		setupClient  func(*fakeClient)
+		wantStatus   core.Status
+		wantContains string
+	}{
+		{
+			name: "running instance with machine type and labels",
+			instanceTest: core.GCPInstanceTest{
+				Name: "web", Instance: "web-01", Zone: "us-central1-a", State: "present", Status: "RUNNING",
+				MachineType: "e2-medium", Labels: map[string]string{"env": "prod"},
+			},
+			setupClient:  func(c *fakeClient) { c.instances["us-central1-a/web-01"] = running },
+			wantStatus:   core.StatusPass,
+			wantContains: "is RUNNING",
+		},
+		{
+			name:         "missing instance",
+			instanceTest: core.GCPInstanceTest{Name: "web", Instance: "web-01", Zone: "us-central1-a", State: "present", Status: "RUNNING"},
+			setupClient:  func(c *fakeClient) {},
+			wantStatus:   core.StatusFail,
+			wantContains: "not found in zone us-central1-a",
+		},
+		{
+			name:         "wrong status",
+			instanceTest: core.GCPInstanceTest{Name: "web", Instance: "web-01", Zone: "us-central1-a", State: "present", Status: "TERMINATED"},
+			setupClient:  func(c *fakeClient) { c.instances["us-central1-a/web-01"] = running },
+			wantStatus:   core.StatusFail,
+			wantContains: "status is RUNNING, expected TERMINATED",
+		},
+		{
+			name:         "wrong machine type",
+			instanceTest: core.GCPInstanceTest{Name: "web", Instance: "web-01", Zone: "us-central1-a", State: "present", MachineType: "n2-standard-4"},
+			setupClient:  func(c *fakeClient) { c.instances["us-central1-a/web-01"] = running },
+			wantStatus:   core.StatusFail,
+			wantContains: "machine type is e2-medium, expected n2-standard-4",
+		},
+		{
+			name: "label mismatch",
+			instanceTest: core.GCPInstanceTest{
+				Name: "web", Instance: "web-01", Zone: "us-central1-a", State: "present",
+				Labels: map[string]string{"env": "staging", "owner": "ops"},
+			},
+			setupClient:  func(c *fakeClient) { c.instances["us-central1-a/web-01"] = running },
+			wantStatus:   core.StatusFail,
+			wantContains: "env=prod (expected staging), owner (missing)",
+		},
+		{
+			name:         "instance absent as expected",
+			instanceTest: core.GCPInstanceTest{Name: "old", Instance: "web-00", Zone: "us-central1-a", State: "absent"},
+			setupClient:  func(c *fakeClient) {},
+			wantStatus:   core.StatusPass,
+			wantContains: "is absent",
+		},
+		{
+			name:         "API error",
+			instanceTest: core.GCPInstanceTest{Name: "web", Instance: "web-01", Zone: "us-central1-a", State: "present"},
+			setupClient:  func(c *fakeClient) { c.err = errors.New("permission denied") },
+			wantStatus:   core.StatusError,
+			wantContains: "permission denied",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newFakeClient()
+			tt.setupClient(client)
+
+			result := executeGCPInstanceTest(context.Background(), client, tt.instanceTest)
+
+			if result.Status != tt.wantStatus {
+				t.Errorf("Status = %v, want %v (message: %s)", result.Status, tt.wantStatus, result.Message)
+			}
+			if tt.wantContains != "" && !contains(result.Message, tt.wantContains) {
+				t.Errorf("Message %q does not contain %q", result.Message, tt.wantContains)
+			}
+		})
+	}
+}
@@ -0,0 +1,102 @@
+package gcp
+
+import (
+	"context"
+	"errors"
+
+	"github.com/neilfarmer/platform-spec/pkg/core"
+)
+
+// ErrNotFound is returned by a Client when the requested resource does not exist
+var ErrNotFound = errors.New("not found")
+
+// Instance is the subset of a GCE instance used by instance tests
+type Instance struct {
+	Name        string
+	Status      string            // GCE status (RUNNING, TERMINATED, ...)
+	MachineType string            // Machine type name, e.g. e2-medium
+	Labels      map[string]string // Instance labels
+}
+
+// Bucket is the subset of a GCS bucket used by bucket tests
+type Bucket struct {
+	Name     string
+	Location string // Bucket location, e.g. US, EUROPE-WEST1
+}
+
+// Client is implemented by providers that can query the GCP APIs.
+// An empty project selects the provider's default project.
+// Lookups return ErrNotFound for missing resources.
+type Client interface {
+	GetInstance(ctx context.Context, project, zone, name string) (*Instance, error)
+	GetBucket(ctx context.Context, name string) (*Bucket, error)
+}
+
+// GCPPlugin handles all GCP-specific tests
+type GCPPlugin struct{}
+
+// NewGCPPlugin creates a new GCP plugin
+func NewGCPPlugin() *GCPPlugin {
+	return &GCPPlugin{}
+}
+
+// Execute runs all GCP tests. The provider must implement Client.
+func (p *GCPPlugin) Execute(ctx context.Context, spec *core.Spec, provider core.Provider, failFast bool) ([]core.Result, bool) {
+	var results []core.Result
+
+	tests := spec.Tests.GCP
+	if len(tests.Instances) == 0 && len(tests.Buckets) == 0 {
+		return results, false
+	}
+
+	client, ok := provider.(Client)
+	if !ok {
+		return unsupportedProviderResults(tests), false
+	}
+
+	// Execute instance tests
+	for _, test := range tests.Instances {
+		result := core.RunTest(ctx, test.TestOptions, func(ctx context.Context) core.Result {
+			return executeGCPInstanceTest(ctx, client, test)
+		})
+		results = append(results, result)
+		if failFast && result.Status == core.StatusFail && !test.ContinueOnFailure {
+			return results, true
+		}
+	}
+
+	// Execute bucket tests
+	for _, test := range tests.Buckets {
+		result := core.RunTest(ctx, test.TestOptions, func(ctx context.Context) core.Result {
+			return executeGCPBucketTest(ctx, client, test)
+		})
+		results = append(results, result)
+		if failFast && result.Status == core.StatusFail && !test.ContinueOnFailure {
+			return results, true
+		}
+	}
+
+	return results, false
+}
+
+// unsupportedProviderResults returns error results for GCP tests run against a provider without API access
+func unsupportedProviderResults(tests core.GCPTests) []core.Result {
+	var names []string
+	for _, test := range tests.Instances {
+		names = append(names, test.Name)
+	}
+	for _, test := range tests.Buckets {
+		names = append(names, test.Name)
+	}
+
+	var results []core.Result
+	for _, name := range names {
+		results = append(results, core.Result{
+			Name:    name,
+			Status:  core.StatusError,
+			Message: "GCP tests require the gcp provider (platform-spec test gcp)",
+			Details: make(map[string]interface{}),
+		})
+	}
+	return results
+}
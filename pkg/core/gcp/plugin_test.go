@@ -0,0 +1,120 @@
+package gcp
+
+import (
+	"context"
+	"testing"
+
+	"github.com/neilfarmer/platform-spec/pkg/core"
+)
+
+// fakeClient is an in-memory Client keyed by zone/name for instances and name for buckets
+type fakeClient struct {
+	*core.MockProvider
+	instances map[string]Instance
+	buckets   map[string]Bucket
+	err       error
+}
+
+func newFakeClient() *fakeClient {
+	return &fakeClient{
+		MockProvider: core.NewMockProvider(),
+		instances:    make(map[string]Instance),
+		buckets:      make(map[string]Bucket),
+	}
+}
+
+func (f *fakeClient) GetInstance(ctx context.Context, project, zone, name string) (*Instance, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	instance, ok := f.instances[zone+"/"+name]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return &instance, nil
+}
+
+func (f *fakeClient) GetBucket(ctx context.Context, name string) (*Bucket, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	bucket, ok := f.buckets[name]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return &bucket, nil
+}
+
+func TestNewGCPPlugin(t *testing.T) {
+	plugin := NewGCPPlugin()
+	if plugin == nil {
+		t.Fatal("NewGCPPlugin returned nil")
+	}
+}
+
+func TestGCPPlugin_Execute(t *testing.T) {
+	client := newFakeClient()
+	client.instances["us-central1-a/web-01"] = Instance{Name: "web-01", Status: "RUNNING", MachineType: "e2-medium"}
+	client.buckets["assets"] = Bucket{Name: "assets", Location: "US"}
+
+	spec := &core.Spec{
+		Tests: core.Tests{
+			GCP: core.GCPTests{
+				Instances: []core.GCPInstanceTest{{Name: "Instance", Instance: "web-01", Zone: "us-central1-a", State: "present", Status: "RUNNING"}},
+				Buckets:   []core.GCPBucketTest{{Name: "Bucket", Bucket: "assets", State: "present"}},
+			},
+		},
+	}
+
+	results, shouldStop := NewGCPPlugin().Execute(context.Background(), spec, client, false)
+	if shouldStop {
+		t.Error("Execute() should not stop")
+	}
+	if len(results) != 2 {
+		t.Fatalf("Execute() returned %d results, want 2", len(results))
+	}
+	for _, result := range results {
+		if result.Status != core.StatusPass {
+			t.Errorf("%s: status = %v, want passed (message: %s)", result.Name, result.Status, result.Message)
+		}
+	}
+}
+
+func TestGCPPlugin_Execute_FailFast(t *testing.T) {
+	client := newFakeClient()
+
+	spec := &core.Spec{
+		Tests: core.Tests{
+			GCP: core.GCPTests{
+				Instances: []core.GCPInstanceTest{{Name: "Missing", Instance: "web-99", Zone: "us-central1-a", State: "present", Status: "RUNNING"}},
+				Buckets:   []core.GCPBucketTest{{Name: "Bucket", Bucket: "assets", State: "present"}},
+			},
+		},
+	}
+
+	results, shouldStop := NewGCPPlugin().Execute(context.Background(), spec, client, true)
+	if !shouldStop {
+		t.Error("Execute() should stop on first failure with fail-fast")
+	}
+	if len(results) != 1 {
+		t.Errorf("Execute() returned %d results, want 1", len(results))
+	}
+}
+
+func TestGCPPlugin_Execute_UnsupportedProvider(t *testing.T) {
+	spec := &core.Spec{
+		Tests: core.Tests{
+			GCP: core.GCPTests{
+				Buckets: []core.GCPBucketTest{{Name: "Bucket", Bucket: "assets", State: "present"}},
+			},
+		},
+	}
+
+	results, _ := NewGCPPlugin().Execute(context.Background(), spec, core.NewMockProvider(), false)
+	if len(results) != 1 || results[0].Status != core.StatusError {
+		t.Fatalf("Execute() = %+v, want one error result", results)
+	}
+	if !contains(results[0].Message, "gcp provider") {
+		t.Errorf("Message %q should mention the gcp provider", results[0].Message)
+	}
+}
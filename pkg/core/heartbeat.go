@@ -0,0 +1,83 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sync/atomic"
+	"time"
+)
+
+// Heartbeat periodically writes a "still running" line while hosts are being
+// tested, so CI systems that kill jobs after a period without output see the
+// run is alive. It is independent of the result formatters.
+type Heartbeat struct {
+	out      io.Writer
+	interval time.Duration
+	total    int
+	done     atomic.Int64
+	start    time.Time
+	stop     chan struct{}
+	stopped  chan struct{}
+
+	// ticks returns the tick channel and a function that releases it;
+	// replaced in tests to drive the heartbeat without waiting
+	ticks func(time.Duration) (<-chan time.Time, func())
+}
+
+// NewHeartbeat creates a heartbeat that reports progress across total hosts
+// to out every interval
+func NewHeartbeat(out io.Writer, interval time.Duration, total int) *Heartbeat {
+	return &Heartbeat{
+		out:      out,
+		interval: interval,
+		total:    total,
+		ticks: func(d time.Duration) (<-chan time.Time, func()) {
+			ticker := time.NewTicker(d)
+			return ticker.C, ticker.Stop
+		},
+	}
+}
+
+// Start begins emitting heartbeat lines until Stop is called or ctx is done
+func (h *Heartbeat) Start(ctx context.Context) {
+	h.start = time.Now()
+	h.stop = make(chan struct{})
+	h.stopped = make(chan struct{})
+
+	tick, release := h.ticks(h.interval)
+	go func() {
+		defer close(h.stopped)
+		defer release()
+		for {
+			select {
+			case <-tick:
+				fmt.Fprintf(h.out, "still running: %d/%d hosts done (%s elapsed)\n",
+					h.done.Load(), h.total, time.Since(h.start).Round(time.Second))
+			case <-h.stop:
+				return
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+// HostDone records that one more host has finished. Safe for concurrent use.
+func (h *Heartbeat) HostDone() {
+	h.done.Add(1)
+}
+
+// Stop stops the heartbeat and waits for it to finish writing, so no
+// heartbeat line can be interleaved with the final results
+func (h *Heartbeat) Stop() {
+	if h.stop == nil {
+		return
+	}
+	select {
+	case <-h.stop:
+	default:
+		close(h.stop)
+	}
+	<-h.stopped
+}
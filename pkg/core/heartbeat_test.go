@@ -0,0 +1,107 @@
+package core
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+// lineWriter forwards each write to a channel so tests can wait for output
+type lineWriter chan string
+
+func (w lineWriter) Write(p []byte) (int, error) {
+	w <- string(p)
+	return len(p), nil
+}
+
+// fakeTicks replaces the heartbeat's ticker with a channel driven by the test
+func fakeTicks(h *Heartbeat) (chan time.Time, *time.Duration, chan struct{}) {
+	tick := make(chan time.Time)
+	released := make(chan struct{})
+	var interval time.Duration
+	h.ticks = func(d time.Duration) (<-chan time.Time, func()) {
+		interval = d
+		return tick, func() { close(released) }
+	}
+	return tick, &interval, released
+}
+
+func TestHeartbeat_EmitsOnEachTick(t *testing.T) {
+	out := make(lineWriter, 1)
+	h := NewHeartbeat(out, 45*time.Second, 3)
+	tick, interval, released := fakeTicks(h)
+
+	h.Start(context.Background())
+	if *interval != 45*time.Second {
+		t.Errorf("ticker interval = %v, want 45s", *interval)
+	}
+
+	tick <- time.Now()
+	if line := <-out; !strings.HasPrefix(line, "still running: 0/3 hosts done") {
+		t.Errorf("first heartbeat = %q", line)
+	}
+
+	h.HostDone()
+	h.HostDone()
+	tick <- time.Now()
+	if line := <-out; !strings.HasPrefix(line, "still running: 2/3 hosts done") {
+		t.Errorf("second heartbeat = %q", line)
+	}
+
+	h.Stop()
+	select {
+	case <-released:
+	default:
+		t.Error("ticker not released after Stop")
+	}
+	// Stopping twice is harmless
+	h.Stop()
+}
+
+func TestHeartbeat_StopsWithContext(t *testing.T) {
+	out := make(lineWriter, 1)
+	h := NewHeartbeat(out, time.Minute, 1)
+	_, _, released := fakeTicks(h)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	h.Start(ctx)
+	cancel()
+
+	select {
+	case <-released:
+	case <-time.After(time.Second):
+		t.Fatal("heartbeat still running after context was cancelled")
+	}
+	h.Stop()
+}
+
+func TestHeartbeat_RealTicker(t *testing.T) {
+	out := make(lineWriter, 16)
+	h := NewHeartbeat(out, 10*time.Millisecond, 2)
+	h.Start(context.Background())
+
+	select {
+	case line := <-out:
+		if !strings.Contains(line, "0/2 hosts done") {
+			t.Errorf("heartbeat = %q", line)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("no heartbeat within 1s for a 10ms interval")
+	}
+
+	h.Stop()
+	// Drain anything written before Stop returned; nothing may follow
+	for len(out) > 0 {
+		<-out
+	}
+	time.Sleep(50 * time.Millisecond)
+	if len(out) != 0 {
+		t.Errorf("heartbeat wrote %d lines after Stop", len(out))
+	}
+}
+
+func TestHeartbeat_StopWithoutStart(t *testing.T) {
+	h := NewHeartbeat(make(lineWriter, 1), time.Second, 1)
+	h.Stop()
+}
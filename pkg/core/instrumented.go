@@ -0,0 +1,70 @@
+package core
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Connector is implemented by providers that establish a connection before running commands
+type Connector interface {
+	Connect(ctx context.Context) error
+}
+
+// ConnectionMetrics captures connection and command statistics for a host
+type ConnectionMetrics struct {
+	ConnectDuration  time.Duration // Time taken to establish the connection
+	CommandCount     int           // Number of commands executed
+	BytesTransferred int64         // Bytes sent (commands) and received (stdout/stderr)
+}
+
+// InstrumentedProvider wraps a Provider and records connection metrics
+type InstrumentedProvider struct {
+	provider Provider
+	mu       sync.Mutex
+	metrics  ConnectionMetrics
+}
+
+// NewInstrumentedProvider creates a new instrumented provider wrapping the given provider
+func NewInstrumentedProvider(provider Provider) *InstrumentedProvider {
+	return &InstrumentedProvider{provider: provider}
+}
+
+// Connect connects the wrapped provider (if it supports connecting) and records the connect time
+func (p *InstrumentedProvider) Connect(ctx context.Context) error {
+	connector, ok := p.provider.(Connector)
+	if !ok {
+		return nil
+	}
+
+	start := time.Now()
+	err := connector.Connect(ctx)
+	p.mu.Lock()
+	p.metrics.ConnectDuration = time.Since(start)
+	p.mu.Unlock()
+	return err
+}
+
+// ExecuteCommand executes a command on the wrapped provider and records its statistics
+func (p *InstrumentedProvider) ExecuteCommand(ctx context.Context, command string) (stdout, stderr string, exitCode int, err error) {
+	stdout, stderr, exitCode, err = p.provider.ExecuteCommand(ctx, command)
+
+	p.mu.Lock()
+	p.metrics.CommandCount++
+	p.metrics.BytesTransferred += int64(len(command) + len(stdout) + len(stderr))
+	p.mu.Unlock()
+
+	return stdout, stderr, exitCode, err
+}
+
+// OS returns the target operating system of the wrapped provider
+func (p *InstrumentedProvider) OS() string {
+	return ProviderOS(p.provider)
+}
+
+// Metrics returns a snapshot of the recorded metrics
+func (p *InstrumentedProvider) Metrics() ConnectionMetrics {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.metrics
+}
@@ -0,0 +1,81 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// slowConnectProvider is a MockProvider that also implements Connector
+type slowConnectProvider struct {
+	*MockProvider
+	delay time.Duration
+	err   error
+}
+
+func (p *slowConnectProvider) Connect(ctx context.Context) error {
+	time.Sleep(p.delay)
+	return p.err
+}
+
+func TestInstrumentedProvider_CountsCommands(t *testing.T) {
+	mock := NewMockProvider()
+	mock.SetCommandResult("echo hello", "hello\n", "", 0, nil)
+	mock.SetCommandResult("false", "", "oops", 1, nil)
+
+	provider := NewInstrumentedProvider(mock)
+	ctx := context.Background()
+
+	stdout, _, _, _ := provider.ExecuteCommand(ctx, "echo hello")
+	if stdout != "hello\n" {
+		t.Errorf("stdout = %q, want output from wrapped provider", stdout)
+	}
+	_, _, exitCode, _ := provider.ExecuteCommand(ctx, "false")
+	if exitCode != 1 {
+		t.Errorf("exitCode = %d, want 1", exitCode)
+	}
+
+	metrics := provider.Metrics()
+	if metrics.CommandCount != 2 {
+		t.Errorf("CommandCount = %d, want 2", metrics.CommandCount)
+	}
+	// "echo hello" (10) + "hello\n" (6) + "false" (5) + "oops" (4)
+	if metrics.BytesTransferred != 25 {
+		t.Errorf("BytesTransferred = %d, want 25", metrics.BytesTransferred)
+	}
+}
+
+func TestInstrumentedProvider_RecordsConnectTime(t *testing.T) {
+	inner := &slowConnectProvider{MockProvider: NewMockProvider(), delay: 20 * time.Millisecond}
+	provider := NewInstrumentedProvider(inner)
+
+	if err := provider.Connect(context.Background()); err != nil {
+		t.Fatalf("Connect returned error: %v", err)
+	}
+
+	if got := provider.Metrics().ConnectDuration; got < 20*time.Millisecond {
+		t.Errorf("ConnectDuration = %v, want at least 20ms", got)
+	}
+}
+
+func TestInstrumentedProvider_ConnectError(t *testing.T) {
+	connectErr := errors.New("connection refused")
+	inner := &slowConnectProvider{MockProvider: NewMockProvider(), err: connectErr}
+	provider := NewInstrumentedProvider(inner)
+
+	if err := provider.Connect(context.Background()); !errors.Is(err, connectErr) {
+		t.Errorf("Connect error = %v, want %v", err, connectErr)
+	}
+}
+
+func TestInstrumentedProvider_ConnectWithoutConnector(t *testing.T) {
+	provider := NewInstrumentedProvider(NewMockProvider())
+
+	if err := provider.Connect(context.Background()); err != nil {
+		t.Errorf("Connect on provider without Connector should be a no-op, got %v", err)
+	}
+	if provider.Metrics().ConnectDuration != 0 {
+		t.Error("ConnectDuration should be zero when nothing was connected")
+	}
+}
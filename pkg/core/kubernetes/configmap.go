@@ -19,8 +19,14 @@ func executeKubernetesConfigMapTest(ctx context.Context, provider core.Provider,
 		Details: make(map[string]interface{}),
 	}
 
-	// Build kubectl command
-	cmd := fmt.Sprintf("kubectl get configmap %s -n %s -o json 2>&1", test.ConfigMap, test.Namespace)
+	if err := core.RequireCommand(ctx, provider, "kubectl"); err != nil {
+		result.Status = core.StatusError
+		result.Message = err.Error()
+		result.Duration = time.Since(start)
+		return result
+	}
+
+	cmd := configMapCommand(test)
 	stdout, stderr, exitCode, err := provider.ExecuteCommand(ctx, cmd)
 
 	if err != nil {
@@ -64,6 +70,7 @@ func executeKubernetesConfigMapTest(ctx context.Context, provider core.Provider,
 	}
 
 	result.Details["namespace"] = test.Namespace
+	result.Attach("configmap", stdout)
 
 	// Check keys if specified
 	if len(test.HasKeys) > 0 {
@@ -98,3 +105,8 @@ func executeKubernetesConfigMapTest(ctx context.Context, provider core.Provider,
 	result.Duration = time.Since(start)
 	return result
 }
+
+// configMapCommand builds the kubectl command for a configmap test
+func configMapCommand(test core.KubernetesConfigMapTest) string {
+	return fmt.Sprintf("kubectl get configmap %s -n %s -o json%s 2>&1", test.ConfigMap, test.Namespace, kubectlFlags(test.KubernetesCluster))
+}
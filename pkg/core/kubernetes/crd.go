@@ -18,8 +18,14 @@ func executeKubernetesCRDTest(ctx context.Context, provider core.Provider, test
 		Details: make(map[string]interface{}),
 	}
 
-	// Build kubectl command
-	cmd := fmt.Sprintf("kubectl get crd %s -o json 2>&1", test.CRD)
+	if err := core.RequireCommand(ctx, provider, "kubectl"); err != nil {
+		result.Status = core.StatusError
+		result.Message = err.Error()
+		result.Duration = time.Since(start)
+		return result
+	}
+
+	cmd := crdCommand(test)
 	_, stderr, exitCode, err := provider.ExecuteCommand(ctx, cmd)
 
 	if err != nil {
@@ -73,3 +79,8 @@ func executeKubernetesCRDTest(ctx context.Context, provider core.Provider, test
 	result.Duration = time.Since(start)
 	return result
 }
+
+// crdCommand builds the kubectl command for a CRD test
+func crdCommand(test core.KubernetesCRDTest) string {
+	return fmt.Sprintf("kubectl get crd %s -o json%s 2>&1", test.CRD, kubectlFlags(test.KubernetesCluster))
+}
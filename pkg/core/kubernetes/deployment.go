@@ -3,11 +3,13 @@ package kubernetes
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"strings"
 	"time"
 
 	"github.com/neilfarmer/platform-spec/pkg/core"
+	"github.com/neilfarmer/platform-spec/pkg/retry"
 )
 
 // executeKubernetesDeploymentTest executes a Kubernetes deployment test
@@ -19,37 +21,27 @@ func executeKubernetesDeploymentTest(ctx context.Context, provider core.Provider
 		Details: make(map[string]interface{}),
 	}
 
-	// Build kubectl command
-	cmd := fmt.Sprintf("kubectl get deployment %s -n %s -o json 2>&1", test.Deployment, test.Namespace)
-	stdout, stderr, exitCode, err := provider.ExecuteCommand(ctx, cmd)
-
-	if err != nil {
-		result.Status = core.StatusError
-		result.Message = fmt.Sprintf("Error checking deployment %s: %v", test.Deployment, err)
-		result.Duration = time.Since(start)
-		return result
-	}
-
-	// Handle not found
-	if exitCode == 1 && strings.Contains(stderr, "not found") {
-		result.Status = core.StatusFail
-		result.Message = fmt.Sprintf("Deployment %s not found in namespace %s", test.Deployment, test.Namespace)
-		result.Duration = time.Since(start)
-		return result
-	}
-
-	if exitCode != 0 {
+	if err := core.RequireCommand(ctx, provider, "kubectl"); err != nil {
 		result.Status = core.StatusError
-		result.Message = fmt.Sprintf("kubectl error: %s", strings.TrimSpace(stderr))
+		result.Message = err.Error()
 		result.Duration = time.Since(start)
 		return result
 	}
 
-	// Parse JSON
 	var deployment map[string]interface{}
-	if err := json.Unmarshal([]byte(stdout), &deployment); err != nil {
-		result.Status = core.StatusError
-		result.Message = fmt.Sprintf("Failed to parse deployment JSON: %v", err)
+	var status core.Status
+	var message string
+	if test.WaitForRollout {
+		deployment, status, message = waitForRollout(ctx, provider, test)
+		if status == core.StatusPass {
+			result.Details["rollout"] = "complete"
+		}
+	} else {
+		deployment, status, message = getDeployment(ctx, provider, test)
+	}
+	if status != core.StatusPass {
+		result.Status = status
+		result.Message = message
 		result.Duration = time.Since(start)
 		return result
 	}
@@ -143,3 +135,122 @@ func executeKubernetesDeploymentTest(ctx context.Context, provider core.Provider
 	result.Duration = time.Since(start)
 	return result
 }
+
+// deploymentCommand builds the kubectl command for a deployment test
+func deploymentCommand(test core.KubernetesDeploymentTest) string {
+	return fmt.Sprintf("kubectl get deployment %s -n %s -o json%s 2>&1", test.Deployment, test.Namespace, kubectlFlags(test.KubernetesCluster))
+}
+
+// getDeployment fetches a deployment test's deployment. A status other than
+// pass comes with the message to report.
+func getDeployment(ctx context.Context, provider core.Provider, test core.KubernetesDeploymentTest) (map[string]interface{}, core.Status, string) {
+	stdout, stderr, exitCode, err := provider.ExecuteCommand(ctx, deploymentCommand(test))
+	if err != nil {
+		return nil, core.StatusError, fmt.Sprintf("Error checking deployment %s: %v", test.Deployment, err)
+	}
+
+	// Handle not found
+	if exitCode == 1 && strings.Contains(stderr, "not found") {
+		return nil, core.StatusFail, fmt.Sprintf("Deployment %s not found in namespace %s", test.Deployment, test.Namespace)
+	}
+
+	if exitCode != 0 {
+		return nil, core.StatusError, fmt.Sprintf("kubectl error: %s", strings.TrimSpace(stderr))
+	}
+
+	var deployment map[string]interface{}
+	if err := json.Unmarshal([]byte(stdout), &deployment); err != nil {
+		return nil, core.StatusError, fmt.Sprintf("Failed to parse deployment JSON: %v", err)
+	}
+	return deployment, core.StatusPass, ""
+}
+
+// rolloutPollInterval is how often waitForRollout re-fetches the deployment
+var rolloutPollInterval = 2 * time.Second
+
+// rolloutPending is returned while a deployment's rollout is still in progress
+type rolloutPending struct {
+	reason string
+}
+
+func (p *rolloutPending) Error() string {
+	return p.reason
+}
+
+// waitForRollout polls the deployment until its rollout is complete, or the
+// test's rollout timeout passes. Only an unfinished rollout is re-checked;
+// kubectl errors and missed progress deadlines are reported at once.
+func waitForRollout(ctx context.Context, provider core.Provider, test core.KubernetesDeploymentTest) (map[string]interface{}, core.Status, string) {
+	config := &retry.Config{
+		MaxRetries:   int(test.RolloutTimeout / rolloutPollInterval),
+		InitialDelay: rolloutPollInterval,
+		MaxDelay:     rolloutPollInterval,
+		Strategy:     retry.StrategyConstant,
+	}
+	isPending := func(err error) bool {
+		var pending *rolloutPending
+		return errors.As(err, &pending)
+	}
+
+	var deployment map[string]interface{}
+	status, message := core.StatusPass, ""
+	err := retry.Do(ctx, config, isPending, func() error {
+		if deployment, status, message = getDeployment(ctx, provider, test); status != core.StatusPass {
+			return errors.New(message)
+		}
+
+		pending, failed := rolloutStatus(deployment)
+		if failed != "" {
+			status, message = core.StatusFail, fmt.Sprintf("Deployment %s rollout failed: %s", test.Deployment, failed)
+			return errors.New(message)
+		}
+		if pending != "" {
+			status, message = core.StatusFail, fmt.Sprintf("Deployment %s rollout did not complete within %s: %s", test.Deployment, test.RolloutTimeout, pending)
+			return &rolloutPending{reason: pending}
+		}
+		status, message = core.StatusPass, ""
+		return nil
+	})
+	if err != nil && status == core.StatusPass {
+		// Cancelled between polls
+		status, message = core.StatusError, fmt.Sprintf("Deployment %s rollout wait stopped: %v", test.Deployment, err)
+	}
+	return deployment, status, message
+}
+
+// rolloutStatus reports why a deployment's rollout is not complete yet, or
+// why it has failed, following kubectl rollout status. Both are empty once
+// the new replica set is fully updated and available.
+func rolloutStatus(deployment map[string]interface{}) (pending, failed string) {
+	generation, _ := getNestedFloat64(deployment, "metadata", "generation")
+	observed, _ := getNestedFloat64(deployment, "status", "observedGeneration")
+	if observed < generation {
+		return "waiting for the deployment spec update to be observed", ""
+	}
+
+	conditions, _ := getNestedSlice(deployment, "status", "conditions")
+	for _, cond := range conditions {
+		if condMap, ok := cond.(map[string]interface{}); ok {
+			if condMap["type"] == "Progressing" && condMap["reason"] == "ProgressDeadlineExceeded" {
+				return "", "progress deadline exceeded"
+			}
+		}
+	}
+
+	desired := 1.0
+	if replicas, ok := getNestedFloat64(deployment, "spec", "replicas"); ok {
+		desired = replicas
+	}
+	updated, _ := getNestedFloat64(deployment, "status", "updatedReplicas")
+	current, _ := getNestedFloat64(deployment, "status", "replicas")
+	available, _ := getNestedFloat64(deployment, "status", "availableReplicas")
+	switch {
+	case updated < desired:
+		return fmt.Sprintf("%d of %d new replicas updated", int(updated), int(desired)), ""
+	case current > updated:
+		return fmt.Sprintf("%d old replicas pending termination", int(current-updated)), ""
+	case available < updated:
+		return fmt.Sprintf("%d of %d updated replicas available", int(available), int(updated)), ""
+	}
+	return "", ""
+}
@@ -3,6 +3,7 @@ package kubernetes
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/neilfarmer/platform-spec/pkg/core"
 )
@@ -140,3 +141,101 @@ func TestExecutor_KubernetesDeploymentTest(t *testing.T) {
 		})
 	}
 }
+
+// rolloutProvider answers each deployment fetch with the next response in
+// turn, repeating the last, to simulate a rollout progressing between polls
+type rolloutProvider struct {
+	responses []string
+	fetches   int
+}
+
+func (p *rolloutProvider) ExecuteCommand(ctx context.Context, command string) (string, string, int, error) {
+	if command != "kubectl get deployment api -n default -o json 2>&1" {
+		return "", "", 0, nil
+	}
+	response := p.responses[min(p.fetches, len(p.responses)-1)]
+	p.fetches++
+	return response, "", 0, nil
+}
+
+func TestExecutor_KubernetesDeploymentRollout(t *testing.T) {
+	defer func(interval time.Duration) { rolloutPollInterval = interval }(rolloutPollInterval)
+	rolloutPollInterval = time.Millisecond
+
+	const (
+		unobserved  = `{"metadata":{"generation":4},"spec":{"replicas":3},"status":{"observedGeneration":3,"replicas":3,"updatedReplicas":3,"availableReplicas":3}}`
+		updating    = `{"metadata":{"generation":4},"spec":{"replicas":3},"status":{"observedGeneration":4,"replicas":4,"updatedReplicas":2,"availableReplicas":3}}`
+		terminating = `{"metadata":{"generation":4},"spec":{"replicas":3},"status":{"observedGeneration":4,"replicas":4,"updatedReplicas":3,"availableReplicas":3}}`
+		complete    = `{"metadata":{"generation":4},"spec":{"replicas":3},"status":{"observedGeneration":4,"replicas":3,"updatedReplicas":3,"availableReplicas":3,` +
+			`"conditions":[{"type":"Available","status":"True"},{"type":"Progressing","status":"True","reason":"NewReplicaSetAvailable"}]}}`
+		deadline = `{"metadata":{"generation":4},"spec":{"replicas":3},"status":{"observedGeneration":4,"replicas":4,"updatedReplicas":1,"availableReplicas":3,` +
+			`"conditions":[{"type":"Progressing","status":"False","reason":"ProgressDeadlineExceeded"}]}}`
+	)
+
+	tests := []struct {
+		name         string
+		responses    []string
+		timeout      time.Duration
+		wantStatus   core.Status
+		wantContains string
+		wantFetches  int
+	}{
+		{
+			name:         "completes on a later poll",
+			responses:    []string{unobserved, updating, terminating, complete},
+			timeout:      time.Second,
+			wantStatus:   core.StatusPass,
+			wantContains: "Deployment api is available",
+			wantFetches:  4,
+		},
+		{
+			name:         "already complete",
+			responses:    []string{complete},
+			timeout:      time.Second,
+			wantStatus:   core.StatusPass,
+			wantContains: "Deployment api is available",
+			wantFetches:  1,
+		},
+		{
+			name:         "times out",
+			responses:    []string{updating},
+			timeout:      5 * time.Millisecond,
+			wantStatus:   core.StatusFail,
+			wantContains: "rollout did not complete within 5ms: 2 of 3 new replicas updated",
+			wantFetches:  6,
+		},
+		{
+			name:         "progress deadline exceeded",
+			responses:    []string{updating, deadline, complete},
+			timeout:      time.Second,
+			wantStatus:   core.StatusFail,
+			wantContains: "rollout failed: progress deadline exceeded",
+			wantFetches:  2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider := &rolloutProvider{responses: tt.responses}
+			test := core.KubernetesDeploymentTest{
+				Name:           "API rolled out",
+				Deployment:     "api",
+				Namespace:      "default",
+				State:          "available",
+				WaitForRollout: true,
+				RolloutTimeout: tt.timeout,
+			}
+
+			result := executeKubernetesDeploymentTest(context.Background(), provider, test)
+			if result.Status != tt.wantStatus {
+				t.Errorf("Status = %v, want %v (message: %s)", result.Status, tt.wantStatus, result.Message)
+			}
+			if !contains(result.Message, tt.wantContains) {
+				t.Errorf("Message %q does not contain %q", result.Message, tt.wantContains)
+			}
+			if provider.fetches != tt.wantFetches {
+				t.Errorf("fetched the deployment %d times, want %d", provider.fetches, tt.wantFetches)
+			}
+		})
+	}
+}
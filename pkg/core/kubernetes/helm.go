@@ -19,8 +19,15 @@ func executeKubernetesHelmTest(ctx context.Context, provider core.Provider, test
 		Details: make(map[string]interface{}),
 	}
 
+	if err := core.RequireCommand(ctx, provider, "helm"); err != nil {
+		result.Status = core.StatusError
+		result.Message = err.Error()
+		result.Duration = time.Since(start)
+		return result
+	}
+
 	// Check Helm release status
-	cmd := fmt.Sprintf("helm list -n %s -o json 2>&1", test.Namespace)
+	cmd := helmListCommand(test)
 	stdout, stderr, exitCode, err := provider.ExecuteCommand(ctx, cmd)
 
 	if err != nil {
@@ -82,7 +89,7 @@ func executeKubernetesHelmTest(ctx context.Context, provider core.Provider, test
 
 	// If all_pods_ready is set, check all pods from this release
 	if test.AllPodsReady {
-		podsReady, podsMessage := checkHelmReleasePods(ctx, provider, test.Release, test.Namespace)
+		podsReady, podsMessage := checkHelmReleasePods(ctx, provider, test.Release, test.Namespace, test.KubernetesCluster)
 		if !podsReady {
 			result.Status = core.StatusFail
 			result.Message = podsMessage
@@ -102,9 +109,8 @@ func executeKubernetesHelmTest(ctx context.Context, provider core.Provider, test
 }
 
 // checkHelmReleasePods checks if all pods from a Helm release are ready
-func checkHelmReleasePods(ctx context.Context, provider core.Provider, release, namespace string) (bool, string) {
-	// Query pods with Helm's standard label
-	cmd := fmt.Sprintf("kubectl get pods -n %s -l app.kubernetes.io/instance=%s -o json 2>&1", namespace, release)
+func checkHelmReleasePods(ctx context.Context, provider core.Provider, release, namespace string, cluster core.KubernetesCluster) (bool, string) {
+	cmd := helmPodsCommand(release, namespace, cluster)
 	stdout, stderr, exitCode, err := provider.ExecuteCommand(ctx, cmd)
 
 	if err != nil {
@@ -195,3 +201,15 @@ func checkHelmReleasePods(ctx context.Context, provider core.Provider, release,
 
 	return true, ""
 }
+
+// helmListCommand builds the helm command that lists the releases in a Helm
+// test's namespace
+func helmListCommand(test core.KubernetesHelmTest) string {
+	return fmt.Sprintf("helm list -n %s -o json%s 2>&1", test.Namespace, helmFlags(test.KubernetesCluster))
+}
+
+// helmPodsCommand builds the kubectl command that lists a release's pods by
+// Helm's standard instance label
+func helmPodsCommand(release, namespace string, cluster core.KubernetesCluster) string {
+	return fmt.Sprintf("kubectl get pods -n %s -l app.kubernetes.io/instance=%s -o json%s 2>&1", namespace, release, kubectlFlags(cluster))
+}
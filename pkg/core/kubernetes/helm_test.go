@@ -175,6 +175,28 @@ func TestExecutor_KubernetesHelmTest(t *testing.T) {
 			wantStatus:   core.StatusPass,
 			wantContains: "Helm release grafana is deployed",
 		},
+		{
+			name: "per-test kubeconfig and context",
+			helmTest: core.KubernetesHelmTest{
+				Name:         "Staging prometheus deployed",
+				Release:      "prometheus",
+				Namespace:    "monitoring",
+				State:        "deployed",
+				AllPodsReady: true,
+				KubernetesCluster: core.KubernetesCluster{
+					Kubeconfig: "/etc/kube/staging.yaml",
+					Context:    "staging-eu",
+				},
+			},
+			setupMock: func(m *core.MockProvider) {
+				m.SetCommandResult("helm list -n monitoring -o json --kubeconfig '/etc/kube/staging.yaml' --kube-context 'staging-eu' 2>&1", `[{"name":"prometheus","namespace":"monitoring","status":"deployed"}]`, "", 0, nil)
+				m.SetCommandResult("kubectl get pods -n monitoring -l app.kubernetes.io/instance=prometheus -o json --kubeconfig '/etc/kube/staging.yaml' --context 'staging-eu' 2>&1", `{"items":[
+					{"metadata":{"name":"prometheus-server"},"status":{"phase":"Running","containerStatuses":[{"ready":true}]}}
+				]}`, "", 0, nil)
+			},
+			wantStatus:   core.StatusPass,
+			wantContains: "with all pods ready",
+		},
 	}
 
 	for _, tt := range tests {
@@ -19,8 +19,14 @@ func executeKubernetesIngressTest(ctx context.Context, provider core.Provider, t
 		Details: make(map[string]interface{}),
 	}
 
-	// Build kubectl command
-	cmd := fmt.Sprintf("kubectl get ingress %s -n %s -o json 2>&1", test.Ingress, test.Namespace)
+	if err := core.RequireCommand(ctx, provider, "kubectl"); err != nil {
+		result.Status = core.StatusError
+		result.Message = err.Error()
+		result.Duration = time.Since(start)
+		return result
+	}
+
+	cmd := ingressCommand(test)
 	stdout, stderr, exitCode, err := provider.ExecuteCommand(ctx, cmd)
 
 	if err != nil {
@@ -169,3 +175,8 @@ func executeKubernetesIngressTest(ctx context.Context, provider core.Provider, t
 	result.Duration = time.Since(start)
 	return result
 }
+
+// ingressCommand builds the kubectl command for an ingress test
+func ingressCommand(test core.KubernetesIngressTest) string {
+	return fmt.Sprintf("kubectl get ingress %s -n %s -o json%s 2>&1", test.Ingress, test.Namespace, kubectlFlags(test.KubernetesCluster))
+}
@@ -19,8 +19,14 @@ func executeKubernetesNamespaceTest(ctx context.Context, provider core.Provider,
 		Details: make(map[string]interface{}),
 	}
 
-	// Build kubectl command
-	cmd := fmt.Sprintf("kubectl get namespace %s -o json 2>&1", test.Namespace)
+	if err := core.RequireCommand(ctx, provider, "kubectl"); err != nil {
+		result.Status = core.StatusError
+		result.Message = err.Error()
+		result.Duration = time.Since(start)
+		return result
+	}
+
+	cmd := namespaceCommand(test)
 	stdout, stderr, exitCode, err := provider.ExecuteCommand(ctx, cmd)
 
 	if err != nil {
@@ -88,3 +94,8 @@ func executeKubernetesNamespaceTest(ctx context.Context, provider core.Provider,
 	result.Duration = time.Since(start)
 	return result
 }
+
+// namespaceCommand builds the kubectl command for a namespace test
+func namespaceCommand(test core.KubernetesNamespaceTest) string {
+	return fmt.Sprintf("kubectl get namespace %s -o json%s 2>&1", test.Namespace, kubectlFlags(test.KubernetesCluster))
+}
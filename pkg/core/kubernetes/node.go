@@ -19,8 +19,14 @@ func executeKubernetesNodeTest(ctx context.Context, provider core.Provider, test
 		Details: make(map[string]interface{}),
 	}
 
-	// Build kubectl command
-	cmd := "kubectl get nodes -o json 2>&1"
+	if err := core.RequireCommand(ctx, provider, "kubectl"); err != nil {
+		result.Status = core.StatusError
+		result.Message = err.Error()
+		result.Duration = time.Since(start)
+		return result
+	}
+
+	cmd := nodesCommand(test)
 	stdout, stderr, exitCode, err := provider.ExecuteCommand(ctx, cmd)
 
 	if err != nil {
@@ -208,3 +214,8 @@ func isVersionAtLeast(actualVersion, minVersion string) bool {
 	// For full semver support, would use a proper semver library
 	return actual >= min
 }
+
+// nodesCommand builds the kubectl command that lists the nodes for a node test
+func nodesCommand(test core.KubernetesNodeTest) string {
+	return "kubectl get nodes -o json" + kubectlFlags(test.KubernetesCluster) + " 2>&1"
+}
@@ -0,0 +1,68 @@
+package kubernetes
+
+import (
+	"fmt"
+
+	"github.com/neilfarmer/platform-spec/pkg/core"
+)
+
+// recordCommands records every command the spec's Kubernetes tests would run
+// through provider, without running them: the lookup of kubectl or helm,
+// each test's commands as the provider would rewrite them, and a note on
+// deployments whose rollout is polled
+func recordCommands(recorder *core.CommandRecorder, provider core.Provider, tests core.KubernetesTests) {
+	record := func(tool string, commands ...string) {
+		recorder.Record(core.PlanCommand(provider, core.CommandLookup(tool)))
+		for _, command := range commands {
+			recorder.Record(core.PlanCommand(provider, command))
+		}
+	}
+
+	for _, test := range tests.Namespaces {
+		record("kubectl", namespaceCommand(test))
+	}
+	for _, test := range tests.Pods {
+		record("kubectl", podCommand(test))
+	}
+	for _, test := range tests.Deployments {
+		command := core.PlanCommand(provider, deploymentCommand(test))
+		if test.WaitForRollout {
+			command += fmt.Sprintf("  # repeated every %s for up to %s until the rollout completes", rolloutPollInterval, test.RolloutTimeout)
+		}
+		record("kubectl")
+		recorder.Record(command)
+	}
+	for _, test := range tests.Services {
+		record("kubectl", serviceCommand(test))
+	}
+	for _, test := range tests.ConfigMaps {
+		record("kubectl", configMapCommand(test))
+	}
+	for _, test := range tests.Nodes {
+		record("kubectl", nodesCommand(test))
+	}
+	for _, test := range tests.CRDs {
+		record("kubectl", crdCommand(test))
+	}
+	for _, test := range tests.Helm {
+		record("helm", helmListCommand(test))
+		if test.AllPodsReady {
+			record("helm", helmPodsCommand(test.Release, test.Namespace, test.KubernetesCluster))
+		}
+	}
+	for _, test := range tests.StorageClasses {
+		record("kubectl", storageClassCommand(test))
+	}
+	for _, test := range tests.Secrets {
+		record("kubectl", secretCommand(test))
+	}
+	for _, test := range tests.Ingress {
+		record("kubectl", ingressCommand(test))
+	}
+	for _, test := range tests.PVCs {
+		record("kubectl", pvcCommand(test))
+	}
+	for _, test := range tests.StatefulSets {
+		record("kubectl", statefulSetCommand(test))
+	}
+}
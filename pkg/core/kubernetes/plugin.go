@@ -14,123 +14,156 @@ func NewKubernetesPlugin() *KubernetesPlugin {
 	return &KubernetesPlugin{}
 }
 
-// Execute runs all Kubernetes tests
+// Execute runs all Kubernetes tests, or records their commands when the
+// context has a core.CommandRecorder
 func (p *KubernetesPlugin) Execute(ctx context.Context, spec *core.Spec, provider core.Provider, failFast bool) ([]core.Result, bool) {
+	// A dry run lists the commands for review instead of running them
+	if recorder := core.CommandRecorderFromContext(ctx); recorder != nil {
+		recordCommands(recorder, provider, spec.Tests.Kubernetes)
+		return nil, false
+	}
+
 	var results []core.Result
 
 	// Execute namespace tests
 	for _, test := range spec.Tests.Kubernetes.Namespaces {
-		result := executeKubernetesNamespaceTest(ctx, provider, test)
+		result := core.RunTest(ctx, test.TestOptions, func(ctx context.Context) core.Result {
+			return executeKubernetesNamespaceTest(ctx, provider, test)
+		})
 		results = append(results, result)
-		if failFast && result.Status == core.StatusFail {
+		if failFast && result.Status == core.StatusFail && !test.ContinueOnFailure {
 			return results, true
 		}
 	}
 
 	// Execute pod tests
 	for _, test := range spec.Tests.Kubernetes.Pods {
-		result := executeKubernetesPodTest(ctx, provider, test)
+		result := core.RunTest(ctx, test.TestOptions, func(ctx context.Context) core.Result {
+			return executeKubernetesPodTest(ctx, provider, test)
+		})
 		results = append(results, result)
-		if failFast && result.Status == core.StatusFail {
+		if failFast && result.Status == core.StatusFail && !test.ContinueOnFailure {
 			return results, true
 		}
 	}
 
 	// Execute deployment tests
 	for _, test := range spec.Tests.Kubernetes.Deployments {
-		result := executeKubernetesDeploymentTest(ctx, provider, test)
+		result := core.RunTest(ctx, test.TestOptions, func(ctx context.Context) core.Result {
+			return executeKubernetesDeploymentTest(ctx, provider, test)
+		})
 		results = append(results, result)
-		if failFast && result.Status == core.StatusFail {
+		if failFast && result.Status == core.StatusFail && !test.ContinueOnFailure {
 			return results, true
 		}
 	}
 
 	// Execute service tests
 	for _, test := range spec.Tests.Kubernetes.Services {
-		result := executeKubernetesServiceTest(ctx, provider, test)
+		result := core.RunTest(ctx, test.TestOptions, func(ctx context.Context) core.Result {
+			return executeKubernetesServiceTest(ctx, provider, test)
+		})
 		results = append(results, result)
-		if failFast && result.Status == core.StatusFail {
+		if failFast && result.Status == core.StatusFail && !test.ContinueOnFailure {
 			return results, true
 		}
 	}
 
 	// Execute configmap tests
 	for _, test := range spec.Tests.Kubernetes.ConfigMaps {
-		result := executeKubernetesConfigMapTest(ctx, provider, test)
+		result := core.RunTest(ctx, test.TestOptions, func(ctx context.Context) core.Result {
+			return executeKubernetesConfigMapTest(ctx, provider, test)
+		})
 		results = append(results, result)
-		if failFast && result.Status == core.StatusFail {
+		if failFast && result.Status == core.StatusFail && !test.ContinueOnFailure {
 			return results, true
 		}
 	}
 
 	// Execute node tests
 	for _, test := range spec.Tests.Kubernetes.Nodes {
-		result := executeKubernetesNodeTest(ctx, provider, test)
+		result := core.RunTest(ctx, test.TestOptions, func(ctx context.Context) core.Result {
+			return executeKubernetesNodeTest(ctx, provider, test)
+		})
 		results = append(results, result)
-		if failFast && result.Status == core.StatusFail {
+		if failFast && result.Status == core.StatusFail && !test.ContinueOnFailure {
 			return results, true
 		}
 	}
 
 	// Execute CRD tests
 	for _, test := range spec.Tests.Kubernetes.CRDs {
-		result := executeKubernetesCRDTest(ctx, provider, test)
+		result := core.RunTest(ctx, test.TestOptions, func(ctx context.Context) core.Result {
+			return executeKubernetesCRDTest(ctx, provider, test)
+		})
 		results = append(results, result)
-		if failFast && result.Status == core.StatusFail {
+		if failFast && result.Status == core.StatusFail && !test.ContinueOnFailure {
 			return results, true
 		}
 	}
 
 	// Execute Helm tests
 	for _, test := range spec.Tests.Kubernetes.Helm {
-		result := executeKubernetesHelmTest(ctx, provider, test)
+		result := core.RunTest(ctx, test.TestOptions, func(ctx context.Context) core.Result {
+			return executeKubernetesHelmTest(ctx, provider, test)
+		})
 		results = append(results, result)
-		if failFast && result.Status == core.StatusFail {
+		if failFast && result.Status == core.StatusFail && !test.ContinueOnFailure {
 			return results, true
 		}
 	}
 
 	// Execute StorageClass tests
 	for _, test := range spec.Tests.Kubernetes.StorageClasses {
-		result := executeKubernetesStorageClassTest(ctx, provider, test)
+		result := core.RunTest(ctx, test.TestOptions, func(ctx context.Context) core.Result {
+			return executeKubernetesStorageClassTest(ctx, provider, test)
+		})
 		results = append(results, result)
-		if failFast && result.Status == core.StatusFail {
+		if failFast && result.Status == core.StatusFail && !test.ContinueOnFailure {
 			return results, true
 		}
 	}
 
 	// Execute Secret tests
 	for _, test := range spec.Tests.Kubernetes.Secrets {
-		result := executeKubernetesSecretTest(ctx, provider, test)
+		result := core.RunTest(ctx, test.TestOptions, func(ctx context.Context) core.Result {
+			return executeKubernetesSecretTest(ctx, provider, test)
+		})
 		results = append(results, result)
-		if failFast && result.Status == core.StatusFail {
+		if failFast && result.Status == core.StatusFail && !test.ContinueOnFailure {
 			return results, true
 		}
 	}
 
 	// Execute Ingress tests
 	for _, test := range spec.Tests.Kubernetes.Ingress {
-		result := executeKubernetesIngressTest(ctx, provider, test)
+		result := core.RunTest(ctx, test.TestOptions, func(ctx context.Context) core.Result {
+			return executeKubernetesIngressTest(ctx, provider, test)
+		})
 		results = append(results, result)
-		if failFast && result.Status == core.StatusFail {
+		if failFast && result.Status == core.StatusFail && !test.ContinueOnFailure {
 			return results, true
 		}
 	}
 
 	// Execute PVC tests
 	for _, test := range spec.Tests.Kubernetes.PVCs {
-		result := executeKubernetesPVCTest(ctx, provider, test)
+		result := core.RunTest(ctx, test.TestOptions, func(ctx context.Context) core.Result {
+			return executeKubernetesPVCTest(ctx, provider, test)
+		})
 		results = append(results, result)
-		if failFast && result.Status == core.StatusFail {
+		if failFast && result.Status == core.StatusFail && !test.ContinueOnFailure {
 			return results, true
 		}
 	}
 
 	// Execute StatefulSet tests
 	for _, test := range spec.Tests.Kubernetes.StatefulSets {
-		result := executeKubernetesStatefulSetTest(ctx, provider, test)
+		result := core.RunTest(ctx, test.TestOptions, func(ctx context.Context) core.Result {
+			return executeKubernetesStatefulSetTest(ctx, provider, test)
+		})
 		results = append(results, result)
-		if failFast && result.Status == core.StatusFail {
+		if failFast && result.Status == core.StatusFail && !test.ContinueOnFailure {
 			return results, true
 		}
 	}
@@ -2,7 +2,9 @@ package kubernetes
 
 import (
 	"context"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/neilfarmer/platform-spec/pkg/core"
 )
@@ -147,3 +149,98 @@ func TestKubernetesPlugin_Execute_FailFast(t *testing.T) {
 		t.Errorf("Expected failure, got %v for test %q: %s", results[0].Status, results[0].Name, results[0].Message)
 	}
 }
+
+// refusingProvider fails the test if a dry run executes any command
+type refusingProvider struct{ t *testing.T }
+
+func (p refusingProvider) ExecuteCommand(ctx context.Context, command string) (string, string, int, error) {
+	p.t.Errorf("dry run executed %q", command)
+	return "", "", 1, nil
+}
+
+func TestKubernetesPlugin_DryRun(t *testing.T) {
+	spec := &core.Spec{
+		Tests: core.Tests{
+			Kubernetes: core.KubernetesTests{
+				Namespaces: []core.KubernetesNamespaceTest{{Name: "Namespace exists", Namespace: "shop"}},
+				Pods:       []core.KubernetesPodTest{{Name: "Web pod running", Pod: "web-0", Namespace: "shop"}},
+				Deployments: []core.KubernetesDeploymentTest{
+					{Name: "API available", Deployment: "api", Namespace: "shop"},
+					{Name: "API available in staging", Deployment: "api", Namespace: "shop",
+						KubernetesCluster: core.KubernetesCluster{Context: "staging"}},
+				},
+				Nodes: []core.KubernetesNodeTest{{Name: "Enough nodes", MinReady: 3}},
+				Helm: []core.KubernetesHelmTest{
+					{Name: "Prometheus deployed", Release: "prometheus", Namespace: "monitoring", AllPodsReady: true},
+					{Name: "Grafana deployed", Release: "grafana", Namespace: "monitoring"},
+				},
+				Secrets: []core.KubernetesSecretTest{{Name: "TLS secret", Secret: "shop-tls", Namespace: "shop"}},
+			},
+		},
+	}
+
+	recorder := core.NewCommandRecorder()
+	ctx := core.WithCommandRecorder(context.Background(), recorder)
+	results, shouldStop := NewKubernetesPlugin().Execute(ctx, spec, refusingProvider{t}, true)
+	if len(results) != 0 || shouldStop {
+		t.Errorf("dry run returned %d results, stop %v; want none", len(results), shouldStop)
+	}
+
+	want := []string{
+		"command -v 'kubectl' >/dev/null 2>&1",
+		"kubectl get namespace shop -o json 2>&1",
+		"kubectl get pod web-0 -n shop -o json 2>&1",
+		"kubectl get deployment api -n shop -o json 2>&1",
+		"kubectl get deployment api -n shop -o json --context 'staging' 2>&1",
+		"kubectl get nodes -o json 2>&1",
+		"command -v 'helm' >/dev/null 2>&1",
+		"helm list -n monitoring -o json 2>&1",
+		"kubectl get pods -n monitoring -l app.kubernetes.io/instance=prometheus -o json 2>&1",
+		"kubectl get secret shop-tls -n shop -o json 2>&1",
+	}
+	got := recorder.Commands()
+	if len(got) != len(want) {
+		t.Fatalf("recorded %d commands, want %d:\n%s", len(got), len(want), strings.Join(got, "\n"))
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("command %d = %q, want %q", i, got[i], want[i])
+		}
+	}
+}
+
+// planningProvider rewrites kubectl commands like the kubernetes provider
+// with a context, and fails the test if a dry run executes any command
+type planningProvider struct{ refusingProvider }
+
+func (p planningProvider) PlanCommand(command string) string {
+	if rest, ok := strings.CutPrefix(command, "kubectl "); ok {
+		return "kubectl --context=prod " + rest
+	}
+	return command
+}
+
+func TestKubernetesPlugin_DryRunMatchesExecution(t *testing.T) {
+	spec := &core.Spec{
+		Tests: core.Tests{
+			Kubernetes: core.KubernetesTests{
+				Deployments: []core.KubernetesDeploymentTest{
+					{Name: "API rolled out", Deployment: "api", Namespace: "shop", WaitForRollout: true, RolloutTimeout: time.Minute},
+				},
+			},
+		},
+	}
+
+	recorder := core.NewCommandRecorder()
+	ctx := core.WithCommandRecorder(context.Background(), recorder)
+	NewKubernetesPlugin().Execute(ctx, spec, planningProvider{refusingProvider{t}}, false)
+
+	want := []string{
+		"command -v 'kubectl' >/dev/null 2>&1",
+		"kubectl --context=prod get deployment api -n shop -o json 2>&1  # repeated every 2s for up to 1m0s until the rollout completes",
+	}
+	got := recorder.Commands()
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("recorded:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
@@ -19,8 +19,14 @@ func executeKubernetesPodTest(ctx context.Context, provider core.Provider, test
 		Details: make(map[string]interface{}),
 	}
 
-	// Build kubectl command
-	cmd := fmt.Sprintf("kubectl get pod %s -n %s -o json 2>&1", test.Pod, test.Namespace)
+	if err := core.RequireCommand(ctx, provider, "kubectl"); err != nil {
+		result.Status = core.StatusError
+		result.Message = err.Error()
+		result.Duration = time.Since(start)
+		return result
+	}
+
+	cmd := podCommand(test)
 	stdout, stderr, exitCode, err := provider.ExecuteCommand(ctx, cmd)
 
 	if err != nil {
@@ -122,6 +128,44 @@ func executeKubernetesPodTest(ctx context.Context, provider core.Provider, test
 		}
 	}
 
+	// Check the running image digest if specified. Tags are mutable, so the
+	// digest the kubelet resolved is what actually runs.
+	if test.ImageDigest != "" {
+		containerStatuses, _ := getNestedSlice(pod, "status", "containerStatuses")
+		var running []string
+		digestFound := false
+		for _, cs := range containerStatuses {
+			csMap, ok := cs.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			image, _ := csMap["image"].(string)
+			imageID, _ := csMap["imageID"].(string)
+			if test.Image != "" && !strings.Contains(image, test.Image) {
+				continue
+			}
+			digest := imageDigest(imageID)
+			if digest == test.ImageDigest {
+				digestFound = true
+				result.Details["image_digest"] = digest
+				break
+			}
+			if digest != "" {
+				running = append(running, digest)
+			}
+		}
+		if !digestFound {
+			result.Status = core.StatusFail
+			if len(running) == 0 {
+				result.Message = fmt.Sprintf("Pod %s has no container status reporting an image digest", test.Pod)
+			} else {
+				result.Message = fmt.Sprintf("Pod %s does not run image digest %s (running: %s)", test.Pod, test.ImageDigest, strings.Join(running, ", "))
+			}
+			result.Duration = time.Since(start)
+			return result
+		}
+	}
+
 	// Check labels if specified
 	if len(test.Labels) > 0 {
 		labels, _ := getNestedMap(pod, "metadata", "labels")
@@ -139,3 +183,20 @@ func executeKubernetesPodTest(ctx context.Context, provider core.Provider, test
 	result.Duration = time.Since(start)
 	return result
 }
+
+// podCommand builds the kubectl command for a pod test
+func podCommand(test core.KubernetesPodTest) string {
+	return fmt.Sprintf("kubectl get pod %s -n %s -o json%s 2>&1", test.Pod, test.Namespace, kubectlFlags(test.KubernetesCluster))
+}
+
+// imageDigest extracts the digest from a container status imageID such as
+// docker-pullable://nginx@sha256:..., which some runtimes report bare
+func imageDigest(imageID string) string {
+	if i := strings.LastIndex(imageID, "@"); i >= 0 {
+		return imageID[i+1:]
+	}
+	if strings.HasPrefix(imageID, "sha256:") {
+		return imageID
+	}
+	return ""
+}
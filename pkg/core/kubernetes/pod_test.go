@@ -2,6 +2,7 @@ package kubernetes
 
 import (
 	"context"
+	"strings"
 	"testing"
 
 	"github.com/neilfarmer/platform-spec/pkg/core"
@@ -132,6 +133,91 @@ func TestExecutor_KubernetesPodTest(t *testing.T) {
 			wantStatus:   core.StatusPass,
 			wantContains: "Pod nginx-abc123 is running",
 		},
+		{
+			name: "kubectl not installed",
+			podTest: core.KubernetesPodTest{
+				Name:      "Nginx pod running",
+				Pod:       "nginx-abc123",
+				Namespace: "default",
+				State:     "running",
+			},
+			setupMock: func(m *core.MockProvider) {
+				m.SetCommandResult("command -v 'kubectl' >/dev/null 2>&1", "", "", 1, nil)
+			},
+			wantStatus:   core.StatusError,
+			wantContains: "required command 'kubectl' not found on target",
+		},
+		{
+			name: "image digest matches",
+			podTest: core.KubernetesPodTest{
+				Name:        "Nginx runs pinned digest",
+				Pod:         "nginx-abc123",
+				Namespace:   "default",
+				State:       "running",
+				Image:       "nginx:1.21",
+				ImageDigest: "sha256:" + strings.Repeat("a", 64),
+			},
+			setupMock: func(m *core.MockProvider) {
+				m.SetCommandResult("kubectl get pod nginx-abc123 -n default -o json 2>&1", `{"metadata":{"name":"nginx-abc123"},"spec":{"containers":[{"name":"nginx","image":"nginx:1.21"}]},"status":{"phase":"Running","containerStatuses":[`+
+					`{"name":"sidecar","image":"docker.io/library/envoy:1.28","imageID":"docker.io/library/envoy@sha256:`+strings.Repeat("b", 64)+`"},`+
+					`{"name":"nginx","image":"docker.io/library/nginx:1.21","imageID":"docker-pullable://nginx@sha256:`+strings.Repeat("a", 64)+`"}]}}`, "", 0, nil)
+			},
+			wantStatus:   core.StatusPass,
+			wantContains: "Pod nginx-abc123 is running",
+		},
+		{
+			name: "tag matches but digest drifted",
+			podTest: core.KubernetesPodTest{
+				Name:        "Nginx runs pinned digest",
+				Pod:         "nginx-abc123",
+				Namespace:   "default",
+				State:       "running",
+				Image:       "nginx:1.21",
+				ImageDigest: "sha256:" + strings.Repeat("a", 64),
+			},
+			setupMock: func(m *core.MockProvider) {
+				m.SetCommandResult("kubectl get pod nginx-abc123 -n default -o json 2>&1", `{"metadata":{"name":"nginx-abc123"},"spec":{"containers":[{"name":"nginx","image":"nginx:1.21"}]},"status":{"phase":"Running","containerStatuses":[`+
+					`{"name":"nginx","image":"docker.io/library/nginx:1.21","imageID":"docker-pullable://nginx@sha256:`+strings.Repeat("c", 64)+`"}]}}`, "", 0, nil)
+			},
+			wantStatus:   core.StatusFail,
+			wantContains: "does not run image digest sha256:" + strings.Repeat("a", 64) + " (running: sha256:" + strings.Repeat("c", 64) + ")",
+		},
+		{
+			name: "image digest on a container with another image",
+			podTest: core.KubernetesPodTest{
+				Name:        "Nginx runs pinned digest",
+				Pod:         "nginx-abc123",
+				Namespace:   "default",
+				State:       "running",
+				Image:       "nginx:1.21",
+				ImageDigest: "sha256:" + strings.Repeat("b", 64),
+			},
+			setupMock: func(m *core.MockProvider) {
+				m.SetCommandResult("kubectl get pod nginx-abc123 -n default -o json 2>&1", `{"metadata":{"name":"nginx-abc123"},"spec":{"containers":[{"name":"nginx","image":"nginx:1.21"}]},"status":{"phase":"Running","containerStatuses":[`+
+					`{"name":"sidecar","image":"docker.io/library/envoy:1.28","imageID":"sha256:`+strings.Repeat("b", 64)+`"},`+
+					`{"name":"nginx","image":"docker.io/library/nginx:1.21","imageID":"docker-pullable://nginx@sha256:`+strings.Repeat("a", 64)+`"}]}}`, "", 0, nil)
+			},
+			wantStatus:   core.StatusFail,
+			wantContains: "does not run image digest",
+		},
+		{
+			name: "per-test kubeconfig and context",
+			podTest: core.KubernetesPodTest{
+				Name:      "Staging nginx pod running",
+				Pod:       "nginx-abc123",
+				Namespace: "default",
+				State:     "running",
+				KubernetesCluster: core.KubernetesCluster{
+					Kubeconfig: "/etc/kube/staging.yaml",
+					Context:    "staging-eu",
+				},
+			},
+			setupMock: func(m *core.MockProvider) {
+				m.SetCommandResult("kubectl get pod nginx-abc123 -n default -o json --kubeconfig '/etc/kube/staging.yaml' --context 'staging-eu' 2>&1", `{"metadata":{"name":"nginx-abc123"},"status":{"phase":"Running"}}`, "", 0, nil)
+			},
+			wantStatus:   core.StatusPass,
+			wantContains: "Pod nginx-abc123 is running",
+		},
 	}
 
 	for _, tt := range tests {
@@ -4,8 +4,6 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"regexp"
-	"strconv"
 	"strings"
 	"time"
 
@@ -21,8 +19,14 @@ func executeKubernetesPVCTest(ctx context.Context, provider core.Provider, test
 		Details: make(map[string]interface{}),
 	}
 
-	// Build kubectl command
-	cmd := fmt.Sprintf("kubectl get pvc %s -n %s -o json 2>&1", test.PVC, test.Namespace)
+	if err := core.RequireCommand(ctx, provider, "kubectl"); err != nil {
+		result.Status = core.StatusError
+		result.Message = err.Error()
+		result.Duration = time.Since(start)
+		return result
+	}
+
+	cmd := pvcCommand(test)
 	stdout, stderr, exitCode, err := provider.ExecuteCommand(ctx, cmd)
 
 	if err != nil {
@@ -120,7 +124,7 @@ func executeKubernetesPVCTest(ctx context.Context, provider core.Provider, test
 		}
 
 		// Parse capacities (e.g., "100Gi")
-		minBytes, err := parseStorageSize(test.MinCapacity)
+		minBytes, err := core.ParseSize(test.MinCapacity)
 		if err != nil {
 			result.Status = core.StatusError
 			result.Message = fmt.Sprintf("Invalid min_capacity format: %v", err)
@@ -128,7 +132,7 @@ func executeKubernetesPVCTest(ctx context.Context, provider core.Provider, test
 			return result
 		}
 
-		actualBytes, err := parseStorageSize(actualCapacity)
+		actualBytes, err := core.ParseSize(actualCapacity)
 		if err != nil {
 			result.Status = core.StatusError
 			result.Message = fmt.Sprintf("Failed to parse actual capacity: %v", err)
@@ -165,50 +169,7 @@ func executeKubernetesPVCTest(ctx context.Context, provider core.Provider, test
 	return result
 }
 
-// parseStorageSize parses Kubernetes storage sizes (e.g., "100Gi", "1Ti", "500Mi") to bytes
-func parseStorageSize(size string) (int64, error) {
-	re := regexp.MustCompile(`^(\d+(?:\.\d+)?)(Ki|Mi|Gi|Ti|Pi|Ei|K|M|G|T|P|E)?$`)
-	matches := re.FindStringSubmatch(size)
-	if len(matches) == 0 {
-		return 0, fmt.Errorf("invalid storage size format: %s", size)
-	}
-
-	value, err := strconv.ParseFloat(matches[1], 64)
-	if err != nil {
-		return 0, err
-	}
-
-	unit := matches[2]
-	multiplier := int64(1)
-
-	switch unit {
-	case "Ki":
-		multiplier = 1024
-	case "Mi":
-		multiplier = 1024 * 1024
-	case "Gi":
-		multiplier = 1024 * 1024 * 1024
-	case "Ti":
-		multiplier = 1024 * 1024 * 1024 * 1024
-	case "Pi":
-		multiplier = 1024 * 1024 * 1024 * 1024 * 1024
-	case "Ei":
-		multiplier = 1024 * 1024 * 1024 * 1024 * 1024 * 1024
-	case "K":
-		multiplier = 1000
-	case "M":
-		multiplier = 1000 * 1000
-	case "G":
-		multiplier = 1000 * 1000 * 1000
-	case "T":
-		multiplier = 1000 * 1000 * 1000 * 1000
-	case "P":
-		multiplier = 1000 * 1000 * 1000 * 1000 * 1000
-	case "E":
-		multiplier = 1000 * 1000 * 1000 * 1000 * 1000 * 1000
-	case "":
-		multiplier = 1
-	}
-
-	return int64(value * float64(multiplier)), nil
+// pvcCommand builds the kubectl command for a PVC test
+func pvcCommand(test core.KubernetesPVCTest) string {
+	return fmt.Sprintf("kubectl get pvc %s -n %s -o json%s 2>&1", test.PVC, test.Namespace, kubectlFlags(test.KubernetesCluster))
 }
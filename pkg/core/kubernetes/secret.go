@@ -19,8 +19,14 @@ func executeKubernetesSecretTest(ctx context.Context, provider core.Provider, te
 		Details: make(map[string]interface{}),
 	}
 
-	// Build kubectl command
-	cmd := fmt.Sprintf("kubectl get secret %s -n %s -o json 2>&1", test.Secret, test.Namespace)
+	if err := core.RequireCommand(ctx, provider, "kubectl"); err != nil {
+		result.Status = core.StatusError
+		result.Message = err.Error()
+		result.Duration = time.Since(start)
+		return result
+	}
+
+	cmd := secretCommand(test)
 	stdout, stderr, exitCode, err := provider.ExecuteCommand(ctx, cmd)
 
 	if err != nil {
@@ -72,6 +78,7 @@ func executeKubernetesSecretTest(ctx context.Context, provider core.Provider, te
 	}
 
 	result.Details["namespace"] = test.Namespace
+	result.AttachSensitive("secret", stdout)
 
 	// Parse secret JSON for type and key checks
 	var secret map[string]interface{}
@@ -125,3 +132,8 @@ func executeKubernetesSecretTest(ctx context.Context, provider core.Provider, te
 	result.Duration = time.Since(start)
 	return result
 }
+
+// secretCommand builds the kubectl command for a secret test
+func secretCommand(test core.KubernetesSecretTest) string {
+	return fmt.Sprintf("kubectl get secret %s -n %s -o json%s 2>&1", test.Secret, test.Namespace, kubectlFlags(test.KubernetesCluster))
+}
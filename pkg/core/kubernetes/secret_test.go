@@ -231,3 +231,33 @@ func TestExecutor_KubernetesSecretTest(t *testing.T) {
 		})
 	}
 }
+
+func TestExecutor_KubernetesSecretAttachment(t *testing.T) {
+	secretJSON := `{"metadata":{"name":"db-password"},"type":"Opaque","data":{"password":"aHVudGVyMg=="}}`
+	mock := core.NewMockProvider()
+	mock.SetCommandResult("kubectl get secret db-password -n default -o json 2>&1", secretJSON, "", 0, nil)
+	spec := &core.Spec{
+		Tests: core.Tests{
+			Kubernetes: core.KubernetesTests{
+				Secrets: []core.KubernetesSecretTest{{Name: "db password", Secret: "db-password", Namespace: "default", State: "present"}},
+			},
+		},
+	}
+
+	results, err := core.NewExecutor(spec, mock, NewKubernetesPlugin()).Execute(context.Background())
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if got := results.Results[0].Attachments["secret"]; got != core.Redacted {
+		t.Errorf("Attachments[secret] = %q, want secret data redacted by default", got)
+	}
+
+	spec.Config.ShowSensitiveAttachments = true
+	results, err = core.NewExecutor(spec, mock, NewKubernetesPlugin()).Execute(context.Background())
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if got := results.Results[0].Attachments["secret"]; got != secretJSON {
+		t.Errorf("Attachments[secret] = %q, want the kubectl JSON", got)
+	}
+}
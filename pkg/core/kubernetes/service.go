@@ -19,8 +19,14 @@ func executeKubernetesServiceTest(ctx context.Context, provider core.Provider, t
 		Details: make(map[string]interface{}),
 	}
 
-	// Build kubectl command
-	cmd := fmt.Sprintf("kubectl get service %s -n %s -o json 2>&1", test.Service, test.Namespace)
+	if err := core.RequireCommand(ctx, provider, "kubectl"); err != nil {
+		result.Status = core.StatusError
+		result.Message = err.Error()
+		result.Duration = time.Since(start)
+		return result
+	}
+
+	cmd := serviceCommand(test)
 	stdout, stderr, exitCode, err := provider.ExecuteCommand(ctx, cmd)
 
 	if err != nil {
@@ -114,3 +120,8 @@ func executeKubernetesServiceTest(ctx context.Context, provider core.Provider, t
 	result.Duration = time.Since(start)
 	return result
 }
+
+// serviceCommand builds the kubectl command for a service test
+func serviceCommand(test core.KubernetesServiceTest) string {
+	return fmt.Sprintf("kubectl get service %s -n %s -o json%s 2>&1", test.Service, test.Namespace, kubectlFlags(test.KubernetesCluster))
+}
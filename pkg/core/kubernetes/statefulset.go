@@ -19,8 +19,14 @@ func executeKubernetesStatefulSetTest(ctx context.Context, provider core.Provide
 		Details: make(map[string]interface{}),
 	}
 
-	// Build kubectl command
-	cmd := fmt.Sprintf("kubectl get statefulset %s -n %s -o json 2>&1", test.StatefulSet, test.Namespace)
+	if err := core.RequireCommand(ctx, provider, "kubectl"); err != nil {
+		result.Status = core.StatusError
+		result.Message = err.Error()
+		result.Duration = time.Since(start)
+		return result
+	}
+
+	cmd := statefulSetCommand(test)
 	stdout, stderr, exitCode, err := provider.ExecuteCommand(ctx, cmd)
 
 	if err != nil {
@@ -109,3 +115,8 @@ func executeKubernetesStatefulSetTest(ctx context.Context, provider core.Provide
 	result.Duration = time.Since(start)
 	return result
 }
+
+// statefulSetCommand builds the kubectl command for a StatefulSet test
+func statefulSetCommand(test core.KubernetesStatefulSetTest) string {
+	return fmt.Sprintf("kubectl get statefulset %s -n %s -o json%s 2>&1", test.StatefulSet, test.Namespace, kubectlFlags(test.KubernetesCluster))
+}
@@ -18,8 +18,14 @@ func executeKubernetesStorageClassTest(ctx context.Context, provider core.Provid
 		Details: make(map[string]interface{}),
 	}
 
-	// Build kubectl command
-	cmd := fmt.Sprintf("kubectl get storageclass %s -o json 2>&1", test.StorageClass)
+	if err := core.RequireCommand(ctx, provider, "kubectl"); err != nil {
+		result.Status = core.StatusError
+		result.Message = err.Error()
+		result.Duration = time.Since(start)
+		return result
+	}
+
+	cmd := storageClassCommand(test)
 	_, stderr, exitCode, err := provider.ExecuteCommand(ctx, cmd)
 
 	if err != nil {
@@ -73,3 +79,8 @@ func executeKubernetesStorageClassTest(ctx context.Context, provider core.Provid
 	result.Duration = time.Since(start)
 	return result
 }
+
+// storageClassCommand builds the kubectl command for a StorageClass test
+func storageClassCommand(test core.KubernetesStorageClassTest) string {
+	return fmt.Sprintf("kubectl get storageclass %s -o json%s 2>&1", test.StorageClass, kubectlFlags(test.KubernetesCluster))
+}
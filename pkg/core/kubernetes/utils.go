@@ -1,5 +1,34 @@
 package kubernetes
 
+import "github.com/neilfarmer/platform-spec/pkg/core"
+
+// kubectlFlags returns the flags, with a leading space, that point a kubectl
+// command at a test's cluster override. They follow any the provider adds, so
+// the override wins.
+func kubectlFlags(cluster core.KubernetesCluster) string {
+	var flags string
+	if cluster.Kubeconfig != "" {
+		flags += " --kubeconfig " + core.ShellQuote(cluster.Kubeconfig)
+	}
+	if cluster.Context != "" {
+		flags += " --context " + core.ShellQuote(cluster.Context)
+	}
+	return flags
+}
+
+// helmFlags is kubectlFlags for helm, which names the context flag
+// --kube-context
+func helmFlags(cluster core.KubernetesCluster) string {
+	var flags string
+	if cluster.Kubeconfig != "" {
+		flags += " --kubeconfig " + core.ShellQuote(cluster.Kubeconfig)
+	}
+	if cluster.Context != "" {
+		flags += " --kube-context " + core.ShellQuote(cluster.Context)
+	}
+	return flags
+}
+
 // JSON helper functions for navigating kubectl JSON output
 
 // getNestedString navigates nested maps to extract a string value
@@ -0,0 +1,272 @@
+package core
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// matrixPlaceholder matches ${matrix.axis} references in matrix test templates
+var matrixPlaceholder = regexp.MustCompile(`\$\{matrix\.([^}]*)\}`)
+
+// matrixAxisName matches valid matrix axis names
+var matrixAxisName = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// matrixAxis is one named list of values a matrix test expands across
+type matrixAxis struct {
+	name   string
+	values []*yaml.Node
+}
+
+// expandMatrices replaces every test under the document's tests section that
+// declares a matrix with one concrete test per combination of axis values,
+// substituting ${matrix.axis} placeholders. Multiple axes expand to their
+// cartesian product, the first axis varying slowest.
+func expandMatrices(doc *yaml.Node) error {
+	if len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		return nil
+	}
+	root := doc.Content[0]
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		if root.Content[i].Value == "tests" {
+			return expandTestsNode(root.Content[i+1])
+		}
+	}
+	return nil
+}
+
+// expandTestsNode expands matrix tests in a tests mapping, recursing into
+// provider groups such as kubernetes and into composite sub-tests
+func expandTestsNode(node *yaml.Node) error {
+	if node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		category, value := node.Content[i].Value, node.Content[i+1]
+		switch value.Kind {
+		case yaml.MappingNode:
+			if err := expandTestsNode(value); err != nil {
+				return err
+			}
+		case yaml.SequenceNode:
+			var tests []*yaml.Node
+			for _, test := range value.Content {
+				// Composite sub-tests expand their own matrices first
+				if category == "composite" {
+					for _, group := range []string{"any_of", "all_of"} {
+						if sub := mappingValue(test, group); sub != nil {
+							if err := expandTestsNode(sub); err != nil {
+								return err
+							}
+						}
+					}
+				}
+				expanded, err := expandMatrixTest(test)
+				if err != nil {
+					return err
+				}
+				tests = append(tests, expanded...)
+			}
+			value.Content = tests
+		}
+	}
+	return nil
+}
+
+// expandMatrixTest returns the concrete tests for one test entry: the entry
+// itself if it has no matrix, or one copy per combination of axis values
+func expandMatrixTest(test *yaml.Node) ([]*yaml.Node, error) {
+	if test.Kind != yaml.MappingNode {
+		return []*yaml.Node{test}, nil
+	}
+	matrixIndex := -1
+	for i := 0; i+1 < len(test.Content); i += 2 {
+		if test.Content[i].Value == "matrix" {
+			matrixIndex = i
+			break
+		}
+	}
+	if matrixIndex < 0 {
+		if name := firstMatrixPlaceholder(test); name != "" {
+			return nil, fmt.Errorf("line %d: ${matrix.%s} is used in a test without a matrix", test.Line, name)
+		}
+		return []*yaml.Node{test}, nil
+	}
+
+	axes, err := parseMatrixAxes(test.Content[matrixIndex+1])
+	if err != nil {
+		return nil, err
+	}
+
+	// The template is the test without its matrix key
+	template := &yaml.Node{Kind: yaml.MappingNode, Tag: test.Tag, Line: test.Line, Column: test.Column}
+	template.Content = append(append([]*yaml.Node{}, test.Content[:matrixIndex]...), test.Content[matrixIndex+2:]...)
+
+	if err := checkMatrixReferences(template, axes, test.Line); err != nil {
+		return nil, err
+	}
+
+	var tests []*yaml.Node
+	for _, combo := range matrixCombinations(axes) {
+		tests = append(tests, instantiateMatrixTest(template, axes, combo))
+	}
+	return tests, nil
+}
+
+// parseMatrixAxes reads a matrix mapping of axis names to lists of scalar values
+func parseMatrixAxes(node *yaml.Node) ([]matrixAxis, error) {
+	if node.Kind != yaml.MappingNode || len(node.Content) == 0 {
+		return nil, fmt.Errorf("line %d: matrix must map axis names to lists of values, e.g. port: [80, 443]", node.Line)
+	}
+	var axes []matrixAxis
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		name, values := node.Content[i].Value, node.Content[i+1]
+		if !matrixAxisName.MatchString(name) {
+			return nil, fmt.Errorf("line %d: matrix axis '%s' must be letters, digits and underscores", node.Content[i].Line, name)
+		}
+		if values.Kind != yaml.SequenceNode || len(values.Content) == 0 {
+			return nil, fmt.Errorf("line %d: matrix axis '%s' must be a non-empty list of values", values.Line, name)
+		}
+		for _, value := range values.Content {
+			if value.Kind != yaml.ScalarNode {
+				return nil, fmt.Errorf("line %d: matrix axis '%s' values must be strings, numbers or booleans", value.Line, name)
+			}
+		}
+		axes = append(axes, matrixAxis{name: name, values: values.Content})
+	}
+	return axes, nil
+}
+
+// checkMatrixReferences returns an error if the template references an axis
+// the matrix does not define, or the matrix has an axis the template never uses
+func checkMatrixReferences(template *yaml.Node, axes []matrixAxis, line int) error {
+	defined := make(map[string]bool)
+	for _, axis := range axes {
+		defined[axis.name] = true
+	}
+	used := make(map[string]bool)
+	walkScalars(template, func(scalar *yaml.Node) {
+		for _, match := range matrixPlaceholder.FindAllStringSubmatch(scalar.Value, -1) {
+			used[match[1]] = true
+		}
+	})
+
+	var undefined []string
+	for name := range used {
+		if !defined[name] {
+			undefined = append(undefined, name)
+		}
+	}
+	if len(undefined) > 0 {
+		sort.Strings(undefined)
+		return fmt.Errorf("line %d: ${matrix.%s} does not name a matrix axis", line, undefined[0])
+	}
+	for _, axis := range axes {
+		if !used[axis.name] {
+			return fmt.Errorf("line %d: matrix axis '%s' is not used by the test; reference it as ${matrix.%s}", line, axis.name, axis.name)
+		}
+	}
+	return nil
+}
+
+// matrixCombinations returns every combination of axis value indices, the
+// first axis varying slowest
+func matrixCombinations(axes []matrixAxis) [][]int {
+	combos := [][]int{{}}
+	for _, axis := range axes {
+		var next [][]int
+		for _, combo := range combos {
+			for i := range axis.values {
+				next = append(next, append(append([]int{}, combo...), i))
+			}
+		}
+		combos = next
+	}
+	return combos
+}
+
+// instantiateMatrixTest copies the template with the combination's values
+// substituted. A scalar that is exactly one placeholder takes the value's YAML
+// type, so port: ${matrix.port} stays an integer. Axes the name does not
+// mention are appended to it, e.g. "web port (port=80)", to keep names unique.
+func instantiateMatrixTest(template *yaml.Node, axes []matrixAxis, combo []int) *yaml.Node {
+	values := make(map[string]*yaml.Node, len(axes))
+	for i, axis := range axes {
+		values[axis.name] = axis.values[combo[i]]
+	}
+
+	test := copyNode(template)
+	walkScalars(test, func(scalar *yaml.Node) {
+		if match := matrixPlaceholder.FindStringSubmatch(scalar.Value); match != nil && match[0] == scalar.Value {
+			scalar.Tag = values[match[1]].Tag
+			scalar.Style = values[match[1]].Style
+			scalar.Value = values[match[1]].Value
+			return
+		}
+		scalar.Value = matrixPlaceholder.ReplaceAllStringFunc(scalar.Value, func(placeholder string) string {
+			return values[matrixPlaceholder.FindStringSubmatch(placeholder)[1]].Value
+		})
+	})
+
+	if name := mappingValue(test, "name"); name != nil && name.Kind == yaml.ScalarNode {
+		var unnamed []string
+		for _, axis := range axes {
+			if !strings.Contains(mappingValue(template, "name").Value, "${matrix."+axis.name+"}") {
+				unnamed = append(unnamed, axis.name+"="+values[axis.name].Value)
+			}
+		}
+		if len(unnamed) > 0 {
+			name.Value += " (" + strings.Join(unnamed, ", ") + ")"
+		}
+	}
+	return test
+}
+
+// firstMatrixPlaceholder returns the axis of the first ${matrix.axis}
+// reference in node, or ""
+func firstMatrixPlaceholder(node *yaml.Node) string {
+	name := ""
+	walkScalars(node, func(scalar *yaml.Node) {
+		if match := matrixPlaceholder.FindStringSubmatch(scalar.Value); match != nil && name == "" {
+			name = match[1]
+		}
+	})
+	return name
+}
+
+// mappingValue returns the value for key in a mapping node, or nil
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// walkScalars calls fn for every scalar under node, including mapping keys
+func walkScalars(node *yaml.Node, fn func(*yaml.Node)) {
+	if node.Kind == yaml.ScalarNode {
+		fn(node)
+		return
+	}
+	for _, child := range node.Content {
+		walkScalars(child, fn)
+	}
+}
+
+// copyNode returns a deep copy of node
+func copyNode(node *yaml.Node) *yaml.Node {
+	out := *node
+	out.Content = make([]*yaml.Node, len(node.Content))
+	for i, child := range node.Content {
+		out.Content[i] = copyNode(child)
+	}
+	return &out
+}
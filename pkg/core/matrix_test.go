@@ -0,0 +1,186 @@
+package core
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// parseMatrixSpec writes a spec with the given tests section and parses it
+func parseMatrixSpec(t *testing.T, tests string) (*Spec, error) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "spec.yaml")
+	content := "version: \"1.0\"\nmetadata:\n  name: matrix\ntests:\n" + tests
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return ParseSpec(path)
+}
+
+func TestMatrix_SingleAxis(t *testing.T) {
+	spec, err := parseMatrixSpec(t, `  ports:
+    - name: "Port ${matrix.port} listening"
+      port: ${matrix.port}
+      state: listening
+      matrix:
+        port: [80, 443, 8080]
+`)
+	if err != nil {
+		t.Fatalf("ParseSpec() error = %v", err)
+	}
+
+	want := []PortTest{
+		{Name: "Port 80 listening", Port: 80, Protocol: "tcp", State: "listening"},
+		{Name: "Port 443 listening", Port: 443, Protocol: "tcp", State: "listening"},
+		{Name: "Port 8080 listening", Port: 8080, Protocol: "tcp", State: "listening"},
+	}
+	if !reflect.DeepEqual(spec.Tests.Ports, want) {
+		t.Errorf("Ports = %+v, want %+v", spec.Tests.Ports, want)
+	}
+}
+
+func TestMatrix_TwoAxes(t *testing.T) {
+	spec, err := parseMatrixSpec(t, `  packages:
+    - name: "nginx installed"
+      packages: [nginx]
+      state: present
+  http:
+    - name: "${matrix.host} responds"
+      url: "https://${matrix.host}:${matrix.port}/health"
+      status_code: 200
+      matrix:
+        host: [web-1, web-2]
+        port: [443, 8443]
+`)
+	if err != nil {
+		t.Fatalf("ParseSpec() error = %v", err)
+	}
+
+	var names, urls []string
+	for _, test := range spec.Tests.HTTP {
+		names = append(names, test.Name)
+		urls = append(urls, test.URL)
+	}
+	wantNames := []string{
+		"web-1 responds (port=443)",
+		"web-1 responds (port=8443)",
+		"web-2 responds (port=443)",
+		"web-2 responds (port=8443)",
+	}
+	wantURLs := []string{
+		"https://web-1:443/health",
+		"https://web-1:8443/health",
+		"https://web-2:443/health",
+		"https://web-2:8443/health",
+	}
+	if !reflect.DeepEqual(names, wantNames) {
+		t.Errorf("names = %q, want %q", names, wantNames)
+	}
+	if !reflect.DeepEqual(urls, wantURLs) {
+		t.Errorf("urls = %q, want %q", urls, wantURLs)
+	}
+
+	// Expanded tests keep their place in declaration order
+	spec.Config.Order = OrderDeclaration
+	var order []string
+	for _, ref := range spec.orderedTests() {
+		order = append(order, spec.Tests.nameOf(ref))
+	}
+	if want := append([]string{"nginx installed"}, wantNames...); !reflect.DeepEqual(order, want) {
+		t.Errorf("declaration order = %q, want %q", order, want)
+	}
+}
+
+func TestMatrix_CompositeSubTests(t *testing.T) {
+	spec, err := parseMatrixSpec(t, `  composite:
+    - name: "some proxy listening"
+      any_of:
+        ports:
+          - name: "proxy on ${matrix.port}"
+            port: ${matrix.port}
+            state: listening
+            matrix:
+              port: [3128, 8080]
+`)
+	if err != nil {
+		t.Fatalf("ParseSpec() error = %v", err)
+	}
+	ports := spec.Tests.Composite[0].AnyOf.Ports
+	if len(ports) != 2 || ports[0].Port != 3128 || ports[1].Name != "proxy on 8080" {
+		t.Errorf("any_of ports = %+v, want two expanded tests", ports)
+	}
+}
+
+func TestMatrix_Errors(t *testing.T) {
+	tests := []struct {
+		name  string
+		tests string
+		want  string
+	}{
+		{
+			name: "undefined axis",
+			tests: `  ports:
+    - name: "Port ${matrix.prot}"
+      port: 80
+      state: listening
+      matrix:
+        port: [80]
+`,
+			want: "line 6: ${matrix.prot} does not name a matrix axis",
+		},
+		{
+			name: "unused axis",
+			tests: `  ports:
+    - name: "Port ${matrix.port}"
+      port: ${matrix.port}
+      state: listening
+      matrix:
+        port: [80]
+        protocol: [tcp, udp]
+`,
+			want: "matrix axis 'protocol' is not used by the test",
+		},
+		{
+			name: "placeholder without a matrix",
+			tests: `  ports:
+    - name: "Port ${matrix.port}"
+      port: 80
+      state: listening
+`,
+			want: "${matrix.port} is used in a test without a matrix",
+		},
+		{
+			name: "empty axis",
+			tests: `  ports:
+    - name: "Port ${matrix.port}"
+      port: ${matrix.port}
+      state: listening
+      matrix:
+        port: []
+`,
+			want: "matrix axis 'port' must be a non-empty list of values",
+		},
+		{
+			name: "non-scalar values",
+			tests: `  ports:
+    - name: "Port ${matrix.port}"
+      port: ${matrix.port}
+      state: listening
+      matrix:
+        port: [[80, 443]]
+`,
+			want: "values must be strings, numbers or booleans",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseMatrixSpec(t, tt.tests)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("ParseSpec() error = %v, want it to contain %q", err, tt.want)
+			}
+		})
+	}
+}
@@ -5,6 +5,7 @@ import "context"
 // MockProvider is a mock implementation of the Provider interface for testing
 type MockProvider struct {
 	commands map[string]mockCommandResult
+	os       string
 }
 
 type mockCommandResult struct {
@@ -12,6 +13,7 @@ type mockCommandResult struct {
 	stderr   string
 	exitCode int
 	err      error
+	blocks   bool
 }
 
 // NewMockProvider creates a new MockProvider
@@ -31,10 +33,30 @@ func (m *MockProvider) SetCommandResult(command string, stdout, stderr string, e
 	}
 }
 
+// SetCommandBlocking makes a command hang until its context is done, like a
+// command stuck on an unresponsive mount
+func (m *MockProvider) SetCommandBlocking(command string) {
+	m.commands[command] = mockCommandResult{blocks: true}
+}
+
 // ExecuteCommand executes a command and returns the mocked result
 func (m *MockProvider) ExecuteCommand(ctx context.Context, command string) (stdout, stderr string, exitCode int, err error) {
 	if result, ok := m.commands[command]; ok {
+		if result.blocks {
+			<-ctx.Done()
+			return "", "", -1, ctx.Err()
+		}
 		return result.stdout, result.stderr, result.exitCode, result.err
 	}
 	return "", "", 0, nil
 }
+
+// SetOS sets the operating system reported by the mock (empty = unknown)
+func (m *MockProvider) SetOS(os string) {
+	m.os = os
+}
+
+// OS returns the operating system set with SetOS
+func (m *MockProvider) OS() string {
+	return m.os
+}
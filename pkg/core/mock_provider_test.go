@@ -86,3 +86,20 @@ func TestMockProvider_MultipleCommands(t *testing.T) {
 		t.Errorf("cmd3 failed: got (%q, %q, %d, %v)", stdout, stderr, exitCode, err)
 	}
 }
+
+func TestProviderOS(t *testing.T) {
+	mock := core.NewMockProvider()
+	if got := core.ProviderOS(mock); got != "" {
+		t.Errorf("Expected unknown OS by default, got %q", got)
+	}
+
+	mock.SetOS(core.OSWindows)
+	if got := core.ProviderOS(mock); got != core.OSWindows {
+		t.Errorf("Expected OS %q, got %q", core.OSWindows, got)
+	}
+
+	// The instrumented wrapper should report the wrapped provider's OS
+	if got := core.ProviderOS(core.NewInstrumentedProvider(mock)); got != core.OSWindows {
+		t.Errorf("Expected instrumented provider OS %q, got %q", core.OSWindows, got)
+	}
+}
@@ -0,0 +1,231 @@
+package core
+
+import (
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/neilfarmer/platform-spec/pkg/shell"
+)
+
+// mutatingCommands are binaries that delete data or take the target down.
+// mkfs variants such as mkfs.ext4 are matched by prefix.
+var mutatingCommands = map[string]bool{
+	"rm": true, "dd": true, "mkfs": true, "shred": true, "wipefs": true,
+	"truncate": true, "reboot": true, "shutdown": true, "poweroff": true,
+	"halt": true,
+}
+
+// commandPrefixes run the command that follows them, e.g. sudo rm, mapped to
+// their options that take a value
+var commandPrefixes = map[string][]string{
+	"sudo":    {"-u", "-g", "-h", "-p", "-C", "-D", "-R", "-T", "-U", "-r", "-t"},
+	"doas":    {"-u", "-C"},
+	"env":     {"-u", "-C", "--unset", "--chdir"},
+	"nohup":   nil,
+	"nice":    {"-n", "--adjustment"},
+	"exec":    {"-a"},
+	"command": nil,
+	"xargs":   {"-a", "-d", "-E", "-I", "-L", "-n", "-P", "-s", "--arg-file", "--delimiter", "--max-args", "--max-procs"},
+	"timeout": {"-s", "-k", "--signal", "--kill-after"},
+}
+
+// shells run the string argument of -c as a command line
+var shells = map[string]bool{
+	"sh": true, "bash": true, "dash": true, "ash": true, "ksh": true, "zsh": true,
+}
+
+// powerActions are the systemctl commands that take the target down
+var powerActions = map[string]bool{
+	"reboot": true, "poweroff": true, "halt": true, "kexec": true, "soft-reboot": true,
+}
+
+// CheckMutatingCommands returns an error naming the first hook or command test
+// whose command looks destructive: one that runs rm, dd, mkfs, reboot or a
+// similar binary, writes files with tee or find, or redirects output into a
+// file. platform-spec verifies
+// hosts rather than changing them, so such specs only run with --allow-mutating.
+func (s *Spec) CheckMutatingCommands() error {
+	for i, hook := range s.Config.Hooks.Before {
+		if token := MutatingToken(hook); token != "" {
+			return fmt.Errorf("before hook %d: command uses '%s'", i, token)
+		}
+	}
+	for i, hook := range s.Config.Hooks.After {
+		if token := MutatingToken(hook); token != "" {
+			return fmt.Errorf("after hook %d: command uses '%s'", i, token)
+		}
+	}
+	return checkMutatingTests(&s.Tests)
+}
+
+// checkMutatingTests checks the command tests in a test set, including those
+// nested in composite tests
+func checkMutatingTests(tests *Tests) error {
+	for _, test := range tests.CommandContent {
+		if token := MutatingToken(test.Command); token != "" {
+			return fmt.Errorf("command_content test '%s': command uses '%s'", test.Name, token)
+		}
+	}
+	for _, test := range tests.CommandJSON {
+		if token := MutatingToken(test.Command); token != "" {
+			return fmt.Errorf("command_json test '%s': command uses '%s'", test.Name, token)
+		}
+	}
+	for _, test := range tests.Metrics {
+		if token := MutatingToken(test.Command); token != "" {
+			return fmt.Errorf("metric test '%s': command uses '%s'", test.Name, token)
+		}
+	}
+	for i := range tests.Composite {
+		if err := checkMutatingTests(tests.Composite[i].Group()); err != nil {
+			return fmt.Errorf("composite test '%s': %w", tests.Composite[i].Name, err)
+		}
+	}
+	return nil
+}
+
+// MutatingToken returns the first destructive part of a shell command line,
+// such as "rm" or "> /etc/hosts", or "" if it has none. Quoted text is not a
+// command, except the command line given to sh -c or eval; commands inside
+// $(...) and backticks are checked. Redirects to /dev/null and between file
+// descriptors (2>&1) are not destructive.
+func MutatingToken(command string) string {
+	for _, cmd := range shell.Parse(command) {
+		if token := mutatingCommand(cmd); token != "" {
+			return token
+		}
+	}
+	return ""
+}
+
+// mutatingCommand returns the destructive part of a simple command, or ""
+func mutatingCommand(cmd shell.Command) string {
+	for _, r := range cmd.Redirects {
+		if r.Writes() {
+			return strings.TrimSpace("> " + r.Target)
+		}
+	}
+
+	name, args := cmd.Name, cmd.Args
+	for name != "" {
+		base := filepath.Base(name)
+		_, prefix := commandPrefixes[base]
+		switch {
+		case mutatingCommands[base] || strings.HasPrefix(base, "mkfs."):
+			return base
+		case base == "command" && (slices.Contains(args, "-v") || slices.Contains(args, "-V")):
+			// Only looks the command up
+			return ""
+		case prefix:
+			name, args = prefixedCommand(base, args)
+		case base == "eval":
+			return MutatingToken(strings.Join(args, " "))
+		case shells[base]:
+			if script, ok := shellScript(args); ok {
+				return MutatingToken(script)
+			}
+			return ""
+		case base == "find":
+			return findToken(args)
+		case base == "tee":
+			for _, arg := range args {
+				if !strings.HasPrefix(arg, "-") && (shell.Redirect{Op: ">", Target: arg}).Writes() {
+					return "tee"
+				}
+			}
+			return ""
+		case base == "systemctl":
+			for _, arg := range args {
+				if !strings.HasPrefix(arg, "-") {
+					if powerActions[arg] {
+						return "systemctl " + arg
+					}
+					break
+				}
+			}
+			return ""
+		default:
+			return ""
+		}
+	}
+	return ""
+}
+
+// prefixedCommand returns the command and arguments run by a prefix such as
+// sudo or timeout, skipping the prefix's options, env's assignments and
+// timeout's duration
+func prefixedCommand(prefix string, args []string) (string, []string) {
+	needDuration := prefix == "timeout"
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		switch {
+		case strings.HasPrefix(arg, "-"):
+			if slices.Contains(commandPrefixes[prefix], arg) {
+				i++
+			}
+		case prefix == "env" && assignmentWord(arg):
+		case needDuration:
+			needDuration = false
+		default:
+			return arg, args[i+1:]
+		}
+	}
+	return "", nil
+}
+
+// shellScript returns the command line a shell such as sh or bash runs with
+// -c, and false if it runs a script file or reads commands from stdin
+func shellScript(args []string) (string, bool) {
+	command := false
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		switch {
+		case arg == "--" || strings.HasPrefix(arg, "--"):
+			// Long options such as --norc
+		case len(arg) > 1 && (arg[0] == '-' || arg[0] == '+'):
+			if arg[0] == '-' && strings.Contains(arg, "c") {
+				command = true
+			}
+			if strings.HasSuffix(arg, "o") {
+				// -o and +o take an option name, e.g. -o pipefail
+				i++
+			}
+		case command:
+			return arg, true
+		default:
+			return "", false
+		}
+	}
+	return "", false
+}
+
+// findToken returns the destructive part of a find command: -delete, an
+// action writing a file such as -fprint, or a destructive -exec command
+func findToken(args []string) string {
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "-delete", "-fprint", "-fprint0", "-fprintf", "-fls":
+			return "find " + args[i]
+		case "-exec", "-execdir", "-ok", "-okdir":
+			end := i + 1
+			for end < len(args) && args[end] != ";" && args[end] != "+" {
+				end++
+			}
+			if end > i+1 {
+				if token := mutatingCommand(shell.Command{Name: args[i+1], Args: args[i+2 : end]}); token != "" {
+					return token
+				}
+			}
+			i = end
+		}
+	}
+	return ""
+}
+
+// assignmentWord reports whether w is a NAME=value variable assignment
+func assignmentWord(w string) bool {
+	name, _, found := strings.Cut(w, "=")
+	return found && envKeyPattern.MatchString(name)
+}
@@ -0,0 +1,109 @@
+package core
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMutatingToken(t *testing.T) {
+	tests := []struct {
+		command string
+		want    string
+	}{
+		{"rm -rf /tmp/cache", "rm"},
+		{"sudo -n /bin/rm -f /etc/motd", "rm"},
+		{"dd if=/dev/zero of=/dev/sda bs=1M", "dd"},
+		{"mkfs.ext4 /dev/sdb1", "mkfs.ext4"},
+		{"systemctl is-active nginx && reboot", "reboot"},
+		{"echo ok > /etc/issue", "> /etc/issue"},
+		{"date >>/var/log/checks.log", "> /var/log/checks.log"},
+		{"ls $(rm -rf /srv)", "rm"},
+		{`echo "$(shutdown -h now)"`, "shutdown"},
+		{"if true; then halt; fi", "halt"},
+		{"LC_ALL=C rm /tmp/x", "rm"},
+		{"sh -c 'rm -rf /'", "rm"},
+		{`bash -c "reboot"`, "reboot"},
+		{"bash -euo pipefail -c 'echo ok > /etc/motd'", "> /etc/motd"},
+		{`sudo -u root sh -c "echo $(shred /dev/sda)"`, "shred"},
+		{"eval rm -rf /x", "rm"},
+		{"eval 'echo x > /etc/hosts'", "> /etc/hosts"},
+		{"timeout 5 rm -rf /x", "rm"},
+		{"timeout -s KILL 5s dd if=/dev/zero of=/dev/sda", "dd"},
+		{"find / -delete", "find -delete"},
+		{`find . -exec rm {} \;`, "rm"},
+		{"find /etc -name '*.conf' -execdir /bin/rm -f {} +", "rm"},
+		{"find / -fprint /etc/cron.d/job", "find -fprint"},
+		{"echo x | tee /etc/hosts", "tee"},
+		{"echo x | sudo tee -a /etc/hosts", "tee"},
+		{"truncate -s0 /var/log/syslog", "truncate"},
+		{"systemctl reboot", "systemctl reboot"},
+		{"systemctl --no-wall poweroff", "systemctl poweroff"},
+		{"xargs -n 1 rm < list", "rm"},
+		{"env -u HOME FOO=1 rm /x", "rm"},
+
+		{"docker --version", ""},
+		{"cat /etc/os-release 2>/dev/null", ""},
+		{"kubectl version 2>&1 | head -1", ""},
+		{"echo rm -rf / && grep dd /etc/fstab", ""},
+		{"echo 'rm -rf /' '> /etc/passwd'", ""},
+		{"sort < /etc/passwd", ""},
+		{"test -f /etc/passwd &>/dev/null", ""},
+		{"systemctl status sshd >&2", ""},
+		{"echo $((3>2))", ""},
+		{"test $((1 << 4)) -gt 8 && echo big", ""},
+		{"sh -c 'cat /etc/hostname 2>/dev/null'", ""},
+		{"bash script.sh rm", ""},
+		{"timeout 5 curl -s http://localhost", ""},
+		{"find /var/log -name '*.log' -exec grep -l error {} +", ""},
+		{"echo x | tee /dev/null", ""},
+		{"echo x | tee", ""},
+		{"systemctl is-active reboot.target", ""},
+		{"command -v rm >/dev/null 2>&1", ""},
+		{"echo 'eval rm -rf /'", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.command, func(t *testing.T) {
+			if got := MutatingToken(tt.command); got != tt.want {
+				t.Errorf("MutatingToken(%q) = %q, want %q", tt.command, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckMutatingCommands(t *testing.T) {
+	spec, err := parseMatrixSpec(t, `  command_content:
+    - name: "Clear cache"
+      command: "rm -rf /var/cache/app && echo done"
+      contains: ["done"]
+`)
+	if err != nil {
+		t.Fatalf("ParseSpec() error = %v", err)
+	}
+	// The spec is valid; only the safety check rejects it
+	err = spec.CheckMutatingCommands()
+	if err == nil || !strings.Contains(err.Error(), "command_content test 'Clear cache': command uses 'rm'") {
+		t.Errorf("CheckMutatingCommands() error = %v, want rm rejected", err)
+	}
+
+	spec = &Spec{
+		Config: SpecConfig{Hooks: SpecHooks{After: []string{"systemctl start nginx", "echo x > /tmp/marker"}}},
+		Tests: Tests{Composite: []CompositeTest{{
+			Name:  "any metric",
+			AnyOf: &Tests{Metrics: []MetricTest{{Name: "load", Command: "cat /proc/loadavg | cut -d' ' -f1"}}},
+		}}},
+	}
+	err = spec.CheckMutatingCommands()
+	if err == nil || !strings.Contains(err.Error(), "after hook 1: command uses '> /tmp/marker'") {
+		t.Errorf("CheckMutatingCommands() error = %v, want hook rejected", err)
+	}
+
+	spec.Config.Hooks.After = spec.Config.Hooks.After[:1]
+	if err := spec.CheckMutatingCommands(); err != nil {
+		t.Errorf("CheckMutatingCommands() error = %v, want read-only spec accepted", err)
+	}
+	spec.Tests.Composite[0].AnyOf.Metrics[0].Command = "dd if=/dev/sda count=1"
+	if err := spec.CheckMutatingCommands(); err == nil || !strings.Contains(err.Error(), "composite test 'any metric': metric test 'load'") {
+		t.Errorf("CheckMutatingCommands() error = %v, want composite metric rejected", err)
+	}
+}
@@ -0,0 +1,48 @@
+package openstack
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/neilfarmer/platform-spec/pkg/core"
+)
+
+// executeOpenStackFloatingIPTest executes a floating IP association test
+func executeOpenStackFloatingIPTest(ctx context.Context, client Client, test core.OpenStackFloatingIPTest) core.Result {
+	start := time.Now()
+	result := core.Result{
+		Name:    test.Name,
+		Status:  core.StatusPass,
+		Details: make(map[string]interface{}),
+	}
+
+	instance, err := client.GetInstance(ctx, test.Instance)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			result.Status = core.StatusFail
+			result.Message = fmt.Sprintf("Instance %s not found", test.Instance)
+		} else {
+			result.Status = core.StatusError
+			result.Message = fmt.Sprintf("Error getting instance %s: %v", test.Instance, err)
+		}
+		result.Duration = time.Since(start)
+		return result
+	}
+
+	result.Details["floating_ips"] = instance.FloatingIPs
+
+	for _, address := range instance.FloatingIPs {
+		if address == test.FloatingIP {
+			result.Message = fmt.Sprintf("Floating IP %s is associated with instance %s", test.FloatingIP, test.Instance)
+			result.Duration = time.Since(start)
+			return result
+		}
+	}
+
+	result.Status = core.StatusFail
+	result.Message = fmt.Sprintf("Floating IP %s is not associated with instance %s", test.FloatingIP, test.Instance)
+	result.Duration = time.Since(start)
+	return result
+}
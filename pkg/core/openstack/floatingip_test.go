@@ -0,0 +1,60 @@
+package openstack
+
+import (
+	"context"
+	"testing"
+
+	"github.com/neilfarmer/platform-spec/pkg/core"
+)
+
+func TestExecutor_OpenStackFloatingIPTest(t *testing.T) {
+	tests := []struct {
+		name           string
+		floatingIPTest core.OpenStackFloatingIPTest
+		setupClient    func(*fakeClient)
+		wantStatus     core.Status
+		wantContains   string
+	}{
+		{
+			name:           "floating IP associated",
+			floatingIPTest: core.OpenStackFloatingIPTest{Name: "public", FloatingIP: "203.0.113.10", Instance: "web-01"},
+			setupClient: func(c *fakeClient) {
+				c.instances = []Instance{{ID: "srv-1", Name: "web-01", FloatingIPs: []string{"203.0.113.10"}}}
+			},
+			wantStatus:   core.StatusPass,
+			wantContains: "is associated",
+		},
+		{
+			name:           "floating IP not associated",
+			floatingIPTest: core.OpenStackFloatingIPTest{Name: "public", FloatingIP: "203.0.113.10", Instance: "web-01"},
+			setupClient: func(c *fakeClient) {
+				c.instances = []Instance{{ID: "srv-1", Name: "web-01", FloatingIPs: []string{"203.0.113.11"}}}
+			},
+			wantStatus:   core.StatusFail,
+			wantContains: "is not associated",
+		},
+		{
+			name:           "instance not found",
+			floatingIPTest: core.OpenStackFloatingIPTest{Name: "public", FloatingIP: "203.0.113.10", Instance: "web-01"},
+			setupClient:    func(c *fakeClient) {},
+			wantStatus:     core.StatusFail,
+			wantContains:   "not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newFakeClient()
+			tt.setupClient(client)
+
+			result := executeOpenStackFloatingIPTest(context.Background(), client, tt.floatingIPTest)
+
+			if result.Status != tt.wantStatus {
+				t.Errorf("Status = %v, want %v (message: %s)", result.Status, tt.wantStatus, result.Message)
+			}
+			if tt.wantContains != "" && !contains(result.Message, tt.wantContains) {
+				t.Errorf("Message %q does not contain %q", result.Message, tt.wantContains)
+			}
+		})
+	}
+}
@@ -0,0 +1,63 @@
+package openstack
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/neilfarmer/platform-spec/pkg/core"
+)
+
+// executeOpenStackInstanceTest executes a Nova instance test
+func executeOpenStackInstanceTest(ctx context.Context, client Client, test core.OpenStackInstanceTest) core.Result {
+	start := time.Now()
+	result := core.Result{
+		Name:    test.Name,
+		Status:  core.StatusPass,
+		Details: make(map[string]interface{}),
+	}
+
+	instance, err := client.GetInstance(ctx, test.Instance)
+	exists := err == nil
+	if err != nil && !errors.Is(err, ErrNotFound) {
+		result.Status = core.StatusError
+		result.Message = fmt.Sprintf("Error getting instance %s: %v", test.Instance, err)
+		result.Duration = time.Since(start)
+		return result
+	}
+
+	// Check state
+	if test.State == "present" && !exists {
+		result.Status = core.StatusFail
+		result.Message = fmt.Sprintf("Instance %s not found", test.Instance)
+		result.Duration = time.Since(start)
+		return result
+	}
+
+	if test.State == "absent" {
+		if exists {
+			result.Status = core.StatusFail
+			result.Message = fmt.Sprintf("Instance %s exists but should be absent", test.Instance)
+		} else {
+			result.Message = fmt.Sprintf("Instance %s is absent", test.Instance)
+		}
+		result.Duration = time.Since(start)
+		return result
+	}
+
+	result.Details["id"] = instance.ID
+	result.Details["status"] = instance.Status
+
+	// Check status
+	if test.Status != "" && instance.Status != test.Status {
+		result.Status = core.StatusFail
+		result.Message = fmt.Sprintf("Instance %s status is %s, expected %s", test.Instance, instance.Status, test.Status)
+		result.Duration = time.Since(start)
+		return result
+	}
+
+	result.Message = fmt.Sprintf("Instance %s is %s", test.Instance, instance.Status)
+	result.Duration = time.Since(start)
+	return result
+}
@@ -0,0 +1,95 @@
+package openstack
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/neilfarmer/platform-spec/pkg/core"
+)
+
+func TestExecutor_OpenStackInstanceTest(t *testing.T) {
+	tests := []struct {
+		name         string
+		instanceTest core.OpenStackInstanceTest
+		setupClient  func(*fakeClient)
+		wantStatus   core.Status
+		wantContains string
+	}{
+		{
+			name:         "active instance by name",
+			instanceTest: core.OpenStackInstanceTest{Name: "web", Instance: "web-01", State: "present", Status: "ACTIVE"},
+			setupClient: func(c *fakeClient) {
+				c.instances = []Instance{{ID: "srv-1", Name: "web-01", Status: "ACTIVE"}}
+			},
+			wantStatus:   core.StatusPass,
+			wantContains: "is ACTIVE",
+		},
+		{
+			name:         "active instance by ID",
+			instanceTest: core.OpenStackInstanceTest{Name: "web", Instance: "srv-1", State: "present", Status: "ACTIVE"},
+			setupClient: func(c *fakeClient) {
+				c.instances = []Instance{{ID: "srv-1", Name: "web-01", Status: "ACTIVE"}}
+			},
+			wantStatus:   core.StatusPass,
+			wantContains: "is ACTIVE",
+		},
+		{
+			name:         "instance shut off",
+			instanceTest: core.OpenStackInstanceTest{Name: "web", Instance: "web-01", State: "present", Status: "ACTIVE"},
+			setupClient: func(c *fakeClient) {
+				c.instances = []Instance{{ID: "srv-1", Name: "web-01", Status: "SHUTOFF"}}
+			},
+			wantStatus:   core.StatusFail,
+			wantContains: "status is SHUTOFF, expected ACTIVE",
+		},
+		{
+			name:         "instance not found",
+			instanceTest: core.OpenStackInstanceTest{Name: "web", Instance: "web-01", State: "present", Status: "ACTIVE"},
+			setupClient:  func(c *fakeClient) {},
+			wantStatus:   core.StatusFail,
+			wantContains: "not found",
+		},
+		{
+			name:         "instance absent as expected",
+			instanceTest: core.OpenStackInstanceTest{Name: "old", Instance: "legacy-01", State: "absent"},
+			setupClient:  func(c *fakeClient) {},
+			wantStatus:   core.StatusPass,
+			wantContains: "is absent",
+		},
+		{
+			name:         "instance exists but should be absent",
+			instanceTest: core.OpenStackInstanceTest{Name: "old", Instance: "legacy-01", State: "absent"},
+			setupClient: func(c *fakeClient) {
+				c.instances = []Instance{{ID: "srv-9", Name: "legacy-01", Status: "ACTIVE"}}
+			},
+			wantStatus:   core.StatusFail,
+			wantContains: "should be absent",
+		},
+		{
+			name:         "API error",
+			instanceTest: core.OpenStackInstanceTest{Name: "web", Instance: "web-01", State: "present", Status: "ACTIVE"},
+			setupClient: func(c *fakeClient) {
+				c.err = errors.New("401 Unauthorized")
+			},
+			wantStatus:   core.StatusError,
+			wantContains: "401 Unauthorized",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newFakeClient()
+			tt.setupClient(client)
+
+			result := executeOpenStackInstanceTest(context.Background(), client, tt.instanceTest)
+
+			if result.Status != tt.wantStatus {
+				t.Errorf("Status = %v, want %v (message: %s)", result.Status, tt.wantStatus, result.Message)
+			}
+			if tt.wantContains != "" && !contains(result.Message, tt.wantContains) {
+				t.Errorf("Message %q does not contain %q", result.Message, tt.wantContains)
+			}
+		})
+	}
+}
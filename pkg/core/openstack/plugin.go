@@ -0,0 +1,118 @@
+package openstack
+
+import (
+	"context"
+	"errors"
+
+	"github.com/neilfarmer/platform-spec/pkg/core"
+)
+
+// ErrNotFound is returned by a Client when the requested resource does not exist
+var ErrNotFound = errors.New("not found")
+
+// Instance is the subset of a Nova server used by instance tests
+type Instance struct {
+	ID          string
+	Name        string
+	Status      string   // Nova status (ACTIVE, SHUTOFF, ERROR, ...)
+	FloatingIPs []string // Floating IP addresses associated with the instance
+}
+
+// Volume is the subset of a Cinder volume used by volume tests
+type Volume struct {
+	ID         string
+	Name       string
+	Status     string   // Cinder status (available, in-use, ...)
+	SizeGB     int      // Size in GB
+	AttachedTo []string // IDs of instances the volume is attached to
+}
+
+// Client is implemented by providers that can query the OpenStack APIs.
+// Lookups accept a name or an ID and return ErrNotFound for missing resources.
+type Client interface {
+	GetInstance(ctx context.Context, nameOrID string) (*Instance, error)
+	GetVolume(ctx context.Context, nameOrID string) (*Volume, error)
+}
+
+// OpenStackPlugin handles all OpenStack-specific tests
+type OpenStackPlugin struct{}
+
+// NewOpenStackPlugin creates a new OpenStack plugin
+func NewOpenStackPlugin() *OpenStackPlugin {
+	return &OpenStackPlugin{}
+}
+
+// Execute runs all OpenStack tests. The provider must implement Client.
+func (p *OpenStackPlugin) Execute(ctx context.Context, spec *core.Spec, provider core.Provider, failFast bool) ([]core.Result, bool) {
+	var results []core.Result
+
+	tests := spec.Tests.OpenStack
+	if len(tests.Instances) == 0 && len(tests.Volumes) == 0 && len(tests.FloatingIPs) == 0 {
+		return results, false
+	}
+
+	client, ok := provider.(Client)
+	if !ok {
+		return unsupportedProviderResults(tests), false
+	}
+
+	// Execute instance tests
+	for _, test := range tests.Instances {
+		result := core.RunTest(ctx, test.TestOptions, func(ctx context.Context) core.Result {
+			return executeOpenStackInstanceTest(ctx, client, test)
+		})
+		results = append(results, result)
+		if failFast && result.Status == core.StatusFail && !test.ContinueOnFailure {
+			return results, true
+		}
+	}
+
+	// Execute volume tests
+	for _, test := range tests.Volumes {
+		result := core.RunTest(ctx, test.TestOptions, func(ctx context.Context) core.Result {
+			return executeOpenStackVolumeTest(ctx, client, test)
+		})
+		results = append(results, result)
+		if failFast && result.Status == core.StatusFail && !test.ContinueOnFailure {
+			return results, true
+		}
+	}
+
+	// Execute floating IP tests
+	for _, test := range tests.FloatingIPs {
+		result := core.RunTest(ctx, test.TestOptions, func(ctx context.Context) core.Result {
+			return executeOpenStackFloatingIPTest(ctx, client, test)
+		})
+		results = append(results, result)
+		if failFast && result.Status == core.StatusFail && !test.ContinueOnFailure {
+			return results, true
+		}
+	}
+
+	return results, false
+}
+
+// unsupportedProviderResults returns error results for OpenStack tests run against a provider without API access
+func unsupportedProviderResults(tests core.OpenStackTests) []core.Result {
+	var names []string
+	for _, test := range tests.Instances {
+		names = append(names, test.Name)
+	}
+	for _, test := range tests.Volumes {
+		names = append(names, test.Name)
+	}
+	for _, test := range tests.FloatingIPs {
+		names = append(names, test.Name)
+	}
+
+	var results []core.Result
+	for _, name := range names {
+		results = append(results, core.Result{
+			Name:    name,
+			Status:  core.StatusError,
+			Message: "OpenStack tests require the openstack provider (platform-spec test openstack)",
+			Details: make(map[string]interface{}),
+		})
+	}
+	return results
+}
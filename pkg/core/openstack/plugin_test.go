@@ -0,0 +1,126 @@
+package openstack
+
+import (
+	"context"
+	"testing"
+
+	"github.com/neilfarmer/platform-spec/pkg/core"
+)
+
+// fakeClient is an in-memory Client keyed by resource name and ID
+type fakeClient struct {
+	*core.MockProvider
+	instances []Instance
+	volumes   []Volume
+	err       error
+}
+
+func newFakeClient() *fakeClient {
+	return &fakeClient{MockProvider: core.NewMockProvider()}
+}
+
+func (f *fakeClient) GetInstance(ctx context.Context, nameOrID string) (*Instance, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	for i := range f.instances {
+		if f.instances[i].ID == nameOrID || f.instances[i].Name == nameOrID {
+			return &f.instances[i], nil
+		}
+	}
+	return nil, ErrNotFound
+}
+
+func (f *fakeClient) GetVolume(ctx context.Context, nameOrID string) (*Volume, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	for i := range f.volumes {
+		if f.volumes[i].ID == nameOrID || f.volumes[i].Name == nameOrID {
+			return &f.volumes[i], nil
+		}
+	}
+	return nil, ErrNotFound
+}
+
+func TestNewOpenStackPlugin(t *testing.T) {
+	plugin := NewOpenStackPlugin()
+	if plugin == nil {
+		t.Fatal("NewOpenStackPlugin returned nil")
+	}
+}
+
+func TestOpenStackPlugin_Execute(t *testing.T) {
+	client := newFakeClient()
+	client.instances = []Instance{{ID: "srv-1", Name: "web-01", Status: "ACTIVE", FloatingIPs: []string{"203.0.113.10"}}}
+	client.volumes = []Volume{{ID: "vol-1", Name: "web-data", Status: "in-use", SizeGB: 100, AttachedTo: []string{"srv-1"}}}
+
+	spec := &core.Spec{
+		Tests: core.Tests{
+			OpenStack: core.OpenStackTests{
+				Instances:   []core.OpenStackInstanceTest{{Name: "Instance", Instance: "web-01", State: "present", Status: "ACTIVE"}},
+				Volumes:     []core.OpenStackVolumeTest{{Name: "Volume", Volume: "web-data", State: "present", AttachedTo: "web-01"}},
+				FloatingIPs: []core.OpenStackFloatingIPTest{{Name: "Floating IP", FloatingIP: "203.0.113.10", Instance: "web-01"}},
+			},
+		},
+	}
+
+	results, shouldStop := NewOpenStackPlugin().Execute(context.Background(), spec, client, false)
+	if shouldStop {
+		t.Error("Execute() should not stop")
+	}
+	if len(results) != 3 {
+		t.Fatalf("Execute() returned %d results, want 3", len(results))
+	}
+	for _, result := range results {
+		if result.Status != core.StatusPass {
+			t.Errorf("%s: status = %v, want passed (message: %s)", result.Name, result.Status, result.Message)
+		}
+	}
+}
+
+func TestOpenStackPlugin_Execute_FailFast(t *testing.T) {
+	client := newFakeClient()
+
+	spec := &core.Spec{
+		Tests: core.Tests{
+			OpenStack: core.OpenStackTests{
+				Instances: []core.OpenStackInstanceTest{{Name: "Missing", Instance: "web-99", State: "present", Status: "ACTIVE"}},
+				Volumes:   []core.OpenStackVolumeTest{{Name: "Volume", Volume: "web-data", State: "present"}},
+			},
+		},
+	}
+
+	results, shouldStop := NewOpenStackPlugin().Execute(context.Background(), spec, client, true)
+	if !shouldStop {
+		t.Error("Execute() should stop on first failure with fail-fast")
+	}
+	if len(results) != 1 {
+		t.Errorf("Execute() returned %d results, want 1", len(results))
+	}
+}
+
+func TestOpenStackPlugin_Execute_UnsupportedProvider(t *testing.T) {
+	spec := &core.Spec{
+		Tests: core.Tests{
+			OpenStack: core.OpenStackTests{
+				Instances: []core.OpenStackInstanceTest{{Name: "Instance", Instance: "web-01", State: "present"}},
+			},
+		},
+	}
+
+	results, _ := NewOpenStackPlugin().Execute(context.Background(), spec, core.NewMockProvider(), false)
+	if len(results) != 1 || results[0].Status != core.StatusError {
+		t.Fatalf("Execute() = %+v, want one error result", results)
+	}
+	if !contains(results[0].Message, "openstack provider") {
+		t.Errorf("Message %q should mention the openstack provider", results[0].Message)
+	}
+}
+
+func TestOpenStackPlugin_Execute_NoTests(t *testing.T) {
+	results, _ := NewOpenStackPlugin().Execute(context.Background(), &core.Spec{}, core.NewMockProvider(), false)
+	if len(results) != 0 {
+		t.Errorf("Execute() returned %d results, want 0", len(results))
+	}
+}
@@ -0,0 +1,105 @@
+package openstack
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/neilfarmer/platform-spec/pkg/core"
+)
+
+// executeOpenStackVolumeTest executes a Cinder volume test
+func executeOpenStackVolumeTest(ctx context.Context, client Client, test core.OpenStackVolumeTest) core.Result {
+	start := time.Now()
+	result := core.Result{
+		Name:    test.Name,
+		Status:  core.StatusPass,
+		Details: make(map[string]interface{}),
+	}
+
+	volume, err := client.GetVolume(ctx, test.Volume)
+	exists := err == nil
+	if err != nil && !errors.Is(err, ErrNotFound) {
+		result.Status = core.StatusError
+		result.Message = fmt.Sprintf("Error getting volume %s: %v", test.Volume, err)
+		result.Duration = time.Since(start)
+		return result
+	}
+
+	// Check state
+	if test.State == "present" && !exists {
+		result.Status = core.StatusFail
+		result.Message = fmt.Sprintf("Volume %s not found", test.Volume)
+		result.Duration = time.Since(start)
+		return result
+	}
+
+	if test.State == "absent" {
+		if exists {
+			result.Status = core.StatusFail
+			result.Message = fmt.Sprintf("Volume %s exists but should be absent", test.Volume)
+		} else {
+			result.Message = fmt.Sprintf("Volume %s is absent", test.Volume)
+		}
+		result.Duration = time.Since(start)
+		return result
+	}
+
+	result.Details["id"] = volume.ID
+	result.Details["status"] = volume.Status
+	result.Details["size_gb"] = volume.SizeGB
+
+	// Check status
+	if test.Status != "" && volume.Status != test.Status {
+		result.Status = core.StatusFail
+		result.Message = fmt.Sprintf("Volume %s status is %s, expected %s", test.Volume, volume.Status, test.Status)
+		result.Duration = time.Since(start)
+		return result
+	}
+
+	// Check size
+	if test.SizeGB > 0 && volume.SizeGB != test.SizeGB {
+		result.Status = core.StatusFail
+		result.Message = fmt.Sprintf("Volume %s size is %dGB, expected %dGB", test.Volume, volume.SizeGB, test.SizeGB)
+		result.Duration = time.Since(start)
+		return result
+	}
+
+	// Check attachment
+	if test.AttachedTo != "" {
+		instance, err := client.GetInstance(ctx, test.AttachedTo)
+		if err != nil {
+			if errors.Is(err, ErrNotFound) {
+				result.Status = core.StatusFail
+				result.Message = fmt.Sprintf("Instance %s for volume %s attachment not found", test.AttachedTo, test.Volume)
+			} else {
+				result.Status = core.StatusError
+				result.Message = fmt.Sprintf("Error getting instance %s: %v", test.AttachedTo, err)
+			}
+			result.Duration = time.Since(start)
+			return result
+		}
+
+		attached := false
+		for _, id := range volume.AttachedTo {
+			if id == instance.ID {
+				attached = true
+				break
+			}
+		}
+		if !attached {
+			result.Status = core.StatusFail
+			result.Message = fmt.Sprintf("Volume %s is not attached to instance %s", test.Volume, test.AttachedTo)
+			result.Duration = time.Since(start)
+			return result
+		}
+		result.Message = fmt.Sprintf("Volume %s is attached to instance %s", test.Volume, test.AttachedTo)
+		result.Duration = time.Since(start)
+		return result
+	}
+
+	result.Message = fmt.Sprintf("Volume %s exists", test.Volume)
+	result.Duration = time.Since(start)
+	return result
+}
@@ -0,0 +1,96 @@
+package openstack
+
+import (
+	"context"
+	"testing"
+
+	"github.com/neilfarmer/platform-spec/pkg/core"
+)
+
+func TestExecutor_OpenStackVolumeTest(t *testing.T) {
+	tests := []struct {
+		name         string
+		volumeTest   core.OpenStackVolumeTest
+		setupClient  func(*fakeClient)
+		wantStatus   core.Status
+		wantContains string
+	}{
+		{
+			name:       "volume attached with correct size",
+			volumeTest: core.OpenStackVolumeTest{Name: "data", Volume: "web-data", State: "present", Status: "in-use", SizeGB: 100, AttachedTo: "web-01"},
+			setupClient: func(c *fakeClient) {
+				c.instances = []Instance{{ID: "srv-1", Name: "web-01", Status: "ACTIVE"}}
+				c.volumes = []Volume{{ID: "vol-1", Name: "web-data", Status: "in-use", SizeGB: 100, AttachedTo: []string{"srv-1"}}}
+			},
+			wantStatus:   core.StatusPass,
+			wantContains: "attached to instance web-01",
+		},
+		{
+			name:         "missing volume",
+			volumeTest:   core.OpenStackVolumeTest{Name: "data", Volume: "web-data", State: "present"},
+			setupClient:  func(c *fakeClient) {},
+			wantStatus:   core.StatusFail,
+			wantContains: "Volume web-data not found",
+		},
+		{
+			name:       "wrong size",
+			volumeTest: core.OpenStackVolumeTest{Name: "data", Volume: "web-data", State: "present", SizeGB: 200},
+			setupClient: func(c *fakeClient) {
+				c.volumes = []Volume{{ID: "vol-1", Name: "web-data", Status: "available", SizeGB: 100}}
+			},
+			wantStatus:   core.StatusFail,
+			wantContains: "size is 100GB, expected 200GB",
+		},
+		{
+			name:       "wrong status",
+			volumeTest: core.OpenStackVolumeTest{Name: "data", Volume: "web-data", State: "present", Status: "in-use"},
+			setupClient: func(c *fakeClient) {
+				c.volumes = []Volume{{ID: "vol-1", Name: "web-data", Status: "available", SizeGB: 100}}
+			},
+			wantStatus:   core.StatusFail,
+			wantContains: "status is available, expected in-use",
+		},
+		{
+			name:       "attached to a different instance",
+			volumeTest: core.OpenStackVolumeTest{Name: "data", Volume: "web-data", State: "present", AttachedTo: "web-01"},
+			setupClient: func(c *fakeClient) {
+				c.instances = []Instance{{ID: "srv-1", Name: "web-01"}, {ID: "srv-2", Name: "web-02"}}
+				c.volumes = []Volume{{ID: "vol-1", Name: "web-data", Status: "in-use", AttachedTo: []string{"srv-2"}}}
+			},
+			wantStatus:   core.StatusFail,
+			wantContains: "not attached to instance web-01",
+		},
+		{
+			name:       "attachment instance missing",
+			volumeTest: core.OpenStackVolumeTest{Name: "data", Volume: "web-data", State: "present", AttachedTo: "web-01"},
+			setupClient: func(c *fakeClient) {
+				c.volumes = []Volume{{ID: "vol-1", Name: "web-data", Status: "available"}}
+			},
+			wantStatus:   core.StatusFail,
+			wantContains: "Instance web-01 for volume web-data attachment not found",
+		},
+		{
+			name:         "volume absent as expected",
+			volumeTest:   core.OpenStackVolumeTest{Name: "scratch", Volume: "scratch", State: "absent"},
+			setupClient:  func(c *fakeClient) {},
+			wantStatus:   core.StatusPass,
+			wantContains: "is absent",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newFakeClient()
+			tt.setupClient(client)
+
+			result := executeOpenStackVolumeTest(context.Background(), client, tt.volumeTest)
+
+			if result.Status != tt.wantStatus {
+				t.Errorf("Status = %v, want %v (message: %s)", result.Status, tt.wantStatus, result.Message)
+			}
+			if tt.wantContains != "" && !contains(result.Message, tt.wantContains) {
+				t.Errorf("Message %q does not contain %q", result.Message, tt.wantContains)
+			}
+		})
+	}
+}
@@ -0,0 +1,201 @@
+package core
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Test execution orders for SpecConfig.Order
+const (
+	OrderCategory    = "category"    // By test type, in plugin order (default)
+	OrderName        = "name"        // Alphabetically by test name, for stable diffs between runs
+	OrderDeclaration = "declaration" // As written in the spec file, imports first
+)
+
+// ValidateOrder returns an error if order is not a supported test order
+func ValidateOrder(order string) error {
+	switch order {
+	case "", OrderCategory, OrderName, OrderDeclaration:
+		return nil
+	}
+	return fmt.Errorf("order must be 'category', 'name' or 'declaration', got '%s'", order)
+}
+
+// testRef locates one test in a Tests struct: the field index path of its
+// category slice and its position in that slice
+type testRef struct {
+	field []int
+	index int
+}
+
+// category identifies the referenced test's category slice
+func (r testRef) category() string {
+	return fmt.Sprint(r.field)
+}
+
+// categoryName returns the YAML path of the referenced test's category, e.g.
+// packages or kubernetes.pods
+func (r testRef) categoryName() string {
+	t := reflect.TypeOf(Tests{})
+	var parts []string
+	for _, i := range r.field {
+		field := t.Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get("yaml"), ",")
+		parts = append(parts, name)
+		t = field.Type
+	}
+	return strings.Join(parts, ".")
+}
+
+// isComposite reports whether r points at a composite test
+func (r testRef) isComposite() bool {
+	field, _ := reflect.TypeOf(Tests{}).FieldByName("Composite")
+	return reflect.DeepEqual(r.field, field.Index)
+}
+
+// categoryRefs lists every test in a Tests-like struct in field order
+func categoryRefs(v reflect.Value, prefix []int) []testRef {
+	var refs []testRef
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Field(i)
+		path := append(append([]int{}, prefix...), i)
+		switch field.Kind() {
+		case reflect.Slice:
+			for j := 0; j < field.Len(); j++ {
+				refs = append(refs, testRef{field: path, index: j})
+			}
+		case reflect.Struct:
+			refs = append(refs, categoryRefs(field, path)...)
+		}
+	}
+	return refs
+}
+
+// parseDeclaredOrder records the order tests are written in a parsed spec
+// file
+func parseDeclaredOrder(doc *yaml.Node) []testRef {
+	if len(doc.Content) == 0 {
+		return nil
+	}
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		if root.Content[i].Value == "tests" {
+			return declaredRefs(root.Content[i+1], reflect.TypeOf(Tests{}), nil)
+		}
+	}
+	return nil
+}
+
+// declaredRefs lists the tests under a `tests:` mapping node in the order
+// they appear in the file
+func declaredRefs(node *yaml.Node, t reflect.Type, prefix []int) []testRef {
+	if node.Kind != yaml.MappingNode {
+		return nil
+	}
+
+	var refs []testRef
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i].Value, node.Content[i+1]
+		for j := 0; j < t.NumField(); j++ {
+			name, _, _ := strings.Cut(t.Field(j).Tag.Get("yaml"), ",")
+			if name != key {
+				continue
+			}
+			path := append(append([]int{}, prefix...), j)
+			switch t.Field(j).Type.Kind() {
+			case reflect.Slice:
+				if value.Kind == yaml.SequenceNode {
+					for k := range value.Content {
+						refs = append(refs, testRef{field: path, index: k})
+					}
+				}
+			case reflect.Struct:
+				refs = append(refs, declaredRefs(value, t.Field(j).Type, path)...)
+			}
+			break
+		}
+	}
+	return refs
+}
+
+// declaredOrder returns the spec's tests in declaration order. Tests the
+// parser did not record, e.g. in specs built in code, follow in category order.
+func (s *Spec) declaredOrder() []testRef {
+	v := reflect.ValueOf(s.Tests)
+	seen := make(map[string]bool)
+	var refs []testRef
+	add := func(ref testRef) {
+		id := fmt.Sprintf("%s/%d", ref.category(), ref.index)
+		if seen[id] || ref.index >= v.FieldByIndex(ref.field).Len() {
+			return
+		}
+		seen[id] = true
+		refs = append(refs, ref)
+	}
+	for _, ref := range s.declared {
+		add(ref)
+	}
+	for _, ref := range categoryRefs(v, nil) {
+		add(ref)
+	}
+	return refs
+}
+
+// orderedTests returns the spec's tests in the order set by Config.Order
+func (s *Spec) orderedTests() []testRef {
+	switch s.Config.Order {
+	case OrderDeclaration:
+		return s.declaredOrder()
+	case OrderName:
+		refs := categoryRefs(reflect.ValueOf(s.Tests), nil)
+		sort.SliceStable(refs, func(i, j int) bool {
+			return s.Tests.nameOf(refs[i]) < s.Tests.nameOf(refs[j])
+		})
+		return refs
+	default:
+		return categoryRefs(reflect.ValueOf(s.Tests), nil)
+	}
+}
+
+// mergeDeclared concatenates the declaration orders of specs whose tests were
+// merged in the same order, shifting indices past the earlier specs' tests
+func mergeDeclared(specs []*Spec) []testRef {
+	offsets := make(map[string]int)
+	var refs []testRef
+	for _, spec := range specs {
+		for _, ref := range spec.declaredOrder() {
+			refs = append(refs, testRef{field: ref.field, index: offsets[ref.category()] + ref.index})
+		}
+		for _, ref := range categoryRefs(reflect.ValueOf(spec.Tests), nil) {
+			if ref.index == 0 {
+				offsets[ref.category()] += reflect.ValueOf(spec.Tests).FieldByIndex(ref.field).Len()
+			}
+		}
+	}
+	return refs
+}
+
+// nameOf returns the Name of the referenced test
+func (t Tests) nameOf(ref testRef) string {
+	test := reflect.ValueOf(t).FieldByIndex(ref.field).Index(ref.index)
+	if name := test.FieldByName("Name"); name.IsValid() && name.Kind() == reflect.String {
+		return name.String()
+	}
+	return ""
+}
+
+// only returns a Tests holding just the referenced test
+func (t Tests) only(ref testRef) Tests {
+	var out Tests
+	src := reflect.ValueOf(t).FieldByIndex(ref.field)
+	dst := reflect.ValueOf(&out).Elem().FieldByIndex(ref.field)
+	dst.Set(src.Slice(ref.index, ref.index+1))
+	return out
+}
@@ -0,0 +1,178 @@
+package core_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/neilfarmer/platform-spec/pkg/core"
+	"github.com/neilfarmer/platform-spec/pkg/core/system"
+)
+
+const orderSpec = `version: "1.0"
+tests:
+  command_content:
+    - name: "b-command"
+      command: "echo b"
+      exit_code: 0
+    - name: "d-command"
+      command: "echo d"
+      exit_code: 0
+  packages:
+    - name: "c-package"
+      packages: [curl]
+  composite:
+    - name: "a-composite"
+      all_of:
+        command_content:
+          - name: "sub"
+            command: "true"
+            exit_code: 0
+`
+
+// writeSpec writes a spec file into dir and returns its path
+func writeSpec(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write spec: %v", err)
+	}
+	return path
+}
+
+// resultNames runs spec against an empty mock and returns the result names in order
+func resultNames(t *testing.T, spec *core.Spec) []string {
+	t.Helper()
+	results, err := core.NewExecutor(spec, core.NewMockProvider(), system.NewSystemPlugin()).Execute(context.Background())
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	names := make([]string, len(results.Results))
+	for i, r := range results.Results {
+		names[i] = r.Name
+	}
+	return names
+}
+
+func TestExecutor_Order(t *testing.T) {
+	path := writeSpec(t, t.TempDir(), "spec.yaml", orderSpec)
+
+	tests := []struct {
+		order string
+		want  []string
+	}{
+		{order: "", want: []string{"c-package", "b-command", "d-command", "a-composite"}},
+		{order: core.OrderCategory, want: []string{"c-package", "b-command", "d-command", "a-composite"}},
+		{order: core.OrderName, want: []string{"a-composite", "b-command", "c-package", "d-command"}},
+		{order: core.OrderDeclaration, want: []string{"b-command", "d-command", "c-package", "a-composite"}},
+	}
+
+	for _, tt := range tests {
+		t.Run("order "+tt.order, func(t *testing.T) {
+			spec, err := core.ParseSpec(path)
+			if err != nil {
+				t.Fatalf("ParseSpec() error = %v", err)
+			}
+			spec.Config.Order = tt.order
+
+			if got := resultNames(t, spec); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("results = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExecutor_DeclarationOrderWithImports(t *testing.T) {
+	dir := t.TempDir()
+	writeSpec(t, dir, "base.yaml", `version: "1.0"
+tests:
+  command_content:
+    - name: "base-command"
+      command: "echo base"
+      exit_code: 0
+  packages:
+    - name: "base-package"
+      packages: [bash]
+`)
+	path := writeSpec(t, dir, "main.yaml", `version: "1.0"
+imports: [base.yaml]
+config:
+  order: declaration
+tests:
+  packages:
+    - name: "main-package"
+      packages: [curl]
+  command_content:
+    - name: "main-command"
+      command: "echo main"
+      exit_code: 0
+`)
+
+	spec, err := core.ParseSpec(path)
+	if err != nil {
+		t.Fatalf("ParseSpec() error = %v", err)
+	}
+	want := []string{"base-command", "base-package", "main-package", "main-command"}
+	if got := resultNames(t, spec); !reflect.DeepEqual(got, want) {
+		t.Errorf("results = %v, want %v", got, want)
+	}
+}
+
+func TestExecutor_OrderFailFast(t *testing.T) {
+	mock := core.NewMockProvider()
+	mock.SetCommandResult("echo b", "", "", 1, nil)
+
+	spec := &core.Spec{
+		Config: core.SpecConfig{FailFast: true, Order: core.OrderName},
+		Tests: core.Tests{
+			CommandContent: []core.CommandContentTest{
+				{Name: "c", Command: "echo c"},
+				{Name: "b", Command: "echo b", ExitCode: new(int)},
+				{Name: "a", Command: "echo a"},
+			},
+		},
+	}
+
+	results, err := core.NewExecutor(spec, mock, system.NewSystemPlugin()).Execute(context.Background())
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if len(results.Results) != 2 || results.Results[0].Name != "a" || results.Results[1].Status != core.StatusFail {
+		t.Errorf("results = %+v, want a then the failing b", results.Results)
+	}
+}
+
+func TestExecutor_OrderInParallelMode(t *testing.T) {
+	path := writeSpec(t, t.TempDir(), "spec.yaml", orderSpec)
+	spec, err := core.ParseSpec(path)
+	if err != nil {
+		t.Fatalf("ParseSpec() error = %v", err)
+	}
+	spec.Config.Order = core.OrderName
+
+	jobs := []core.HostJob{{HostEntry: "host1"}, {HostEntry: "host2"}, {HostEntry: "host3"}}
+	testFunc := func(ctx context.Context, job core.HostJob) (*core.HostResults, error) {
+		results, err := core.NewExecutor(spec, core.NewMockProvider(), system.NewSystemPlugin()).Execute(ctx)
+		return &core.HostResults{Target: job.HostEntry, Connected: true, SpecResults: []*core.TestResults{results}}, err
+	}
+
+	multi, err := core.NewParallelExecutor(3, false, false).Execute(jobs, testFunc)
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	want := []string{"a-composite", "b-command", "c-package", "d-command"}
+	for i, host := range multi.Hosts {
+		if host.Target != jobs[i].HostEntry {
+			t.Errorf("host %d = %s, want %s", i, host.Target, jobs[i].HostEntry)
+		}
+		var got []string
+		for _, r := range host.SpecResults[0].Results {
+			got = append(got, r.Name)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("%s results = %v, want %v", host.Target, got, want)
+		}
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"sort"
+	"strings"
 	"sync"
 	"time"
 )
@@ -12,7 +14,43 @@ import (
 type HostJob struct {
 	HostEntry string
 	User      string
-	Config    interface{} // Provider-specific config (e.g., *remote.Config)
+	Labels    map[string]string // Inventory labels, copied to the host's results
+	Config    interface{}       // Provider-specific config (e.g., *remote.Config)
+}
+
+// target returns the job's host as user@host, as tested hosts report it
+func (job HostJob) target() string {
+	if job.User == "" || strings.Contains(job.HostEntry, "@") {
+		return job.HostEntry
+	}
+	return job.User + "@" + job.HostEntry
+}
+
+// WithHostTimeout bounds each call of testFunc to timeout, covering the
+// connection and every spec. A host that runs out of time is marked TimedOut
+// (or, if it never connected, given a connection error) so the run moves on.
+// testFunc must honor its context for the limit to take effect.
+func WithHostTimeout(timeout time.Duration, testFunc func(context.Context, HostJob) (*HostResults, error)) func(context.Context, HostJob) (*HostResults, error) {
+	return func(ctx context.Context, job HostJob) (*HostResults, error) {
+		hostCtx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+
+		result, err := testFunc(hostCtx, job)
+		if hostCtx.Err() != context.DeadlineExceeded || ctx.Err() != nil {
+			return result, err
+		}
+
+		timeoutErr := fmt.Errorf("host timed out after %s", timeout)
+		if result == nil {
+			result = &HostResults{Target: job.target(), Duration: timeout}
+		}
+		if result.Connected {
+			result.TimedOut = true
+		} else {
+			result.ConnectionError = timeoutErr
+		}
+		return result, timeoutErr
+	}
 }
 
 // ProgressTracker tracks test progress
@@ -29,16 +67,28 @@ type ParallelExecutor struct {
 	workers    int
 	failFast   bool
 	verbose    bool
-	jobChan    chan HostJob
-	resultChan chan *HostResults
+	jobChan    chan queuedJob
+	resultChan chan queuedResult
 	ctx        context.Context
 	cancel     context.CancelFunc
 	wg         sync.WaitGroup
 	mu         sync.Mutex
-	results    []*HostResults
+	results    []queuedResult
 	progress   ProgressTracker
 }
 
+// queuedJob and queuedResult carry a host's position in the job list, so
+// results can be reported in that order however hosts finish
+type queuedJob struct {
+	index int
+	job   HostJob
+}
+
+type queuedResult struct {
+	index  int
+	result *HostResults
+}
+
 // NewParallelExecutor creates a new parallel executor
 func NewParallelExecutor(workers int, failFast bool, verbose bool) *ParallelExecutor {
 	ctx, cancel := context.WithCancel(context.Background())
@@ -46,8 +96,8 @@ func NewParallelExecutor(workers int, failFast bool, verbose bool) *ParallelExec
 		workers:    workers,
 		failFast:   failFast,
 		verbose:    verbose,
-		jobChan:    make(chan HostJob, workers*2), // Buffer for smoother flow
-		resultChan: make(chan *HostResults, workers),
+		jobChan:    make(chan queuedJob, workers*2), // Buffer for smoother flow
+		resultChan: make(chan queuedResult, workers),
 		ctx:        ctx,
 		cancel:     cancel,
 	}
@@ -69,9 +119,9 @@ func (pe *ParallelExecutor) Execute(jobs []HostJob, testFunc func(context.Contex
 	}
 
 	// Feed jobs to workers
-	for _, job := range jobs {
+	for i, job := range jobs {
 		select {
-		case pe.jobChan <- job:
+		case pe.jobChan <- queuedJob{index: i, job: job}:
 		case <-pe.ctx.Done():
 			// Fail-fast triggered, stop sending jobs
 			break
@@ -86,8 +136,15 @@ func (pe *ParallelExecutor) Execute(jobs []HostJob, testFunc func(context.Contex
 	// Wait for result collector to finish
 	<-collectorDone
 
+	// Report hosts in job order, not completion order
+	sort.Slice(pe.results, func(i, j int) bool { return pe.results[i].index < pe.results[j].index })
+	hosts := make([]*HostResults, len(pe.results))
+	for i, queued := range pe.results {
+		hosts[i] = queued.result
+	}
+
 	return &MultiHostResults{
-		Hosts:         pe.results,
+		Hosts:         hosts,
 		TotalDuration: time.Since(startTime),
 	}, nil
 }
@@ -96,7 +153,8 @@ func (pe *ParallelExecutor) Execute(jobs []HostJob, testFunc func(context.Contex
 func (pe *ParallelExecutor) worker(id int, testFunc func(context.Context, HostJob) (*HostResults, error)) {
 	defer pe.wg.Done()
 
-	for job := range pe.jobChan {
+	for queued := range pe.jobChan {
+		job := queued.job
 		select {
 		case <-pe.ctx.Done():
 			// Fail-fast triggered, stop processing
@@ -104,10 +162,13 @@ func (pe *ParallelExecutor) worker(id int, testFunc func(context.Context, HostJo
 		default:
 			// Execute test for this host
 			result, _ := testFunc(pe.ctx, job)
+			if result.Labels == nil {
+				result.Labels = job.Labels
+			}
 
 			// Send result to collector
 			select {
-			case pe.resultChan <- result:
+			case pe.resultChan <- queuedResult{index: queued.index, result: result}:
 			case <-pe.ctx.Done():
 				return
 			}
@@ -125,9 +186,10 @@ func (pe *ParallelExecutor) worker(id int, testFunc func(context.Context, HostJo
 func (pe *ParallelExecutor) collectResults(done chan struct{}) {
 	defer close(done)
 
-	for result := range pe.resultChan {
+	for queued := range pe.resultChan {
+		result := queued.result
 		pe.mu.Lock()
-		pe.results = append(pe.results, result)
+		pe.results = append(pe.results, queued)
 		pe.progress.completedHosts++
 
 		if !result.Connected {
@@ -155,3 +217,87 @@ func (pe *ParallelExecutor) printProgress() {
 		pe.progress.connErrors,
 	)
 }
+
+// SequentialExecutor tests hosts one at a time, strictly in job order
+type SequentialExecutor struct {
+	failFast      bool
+	haltOnFailure bool
+	verbose       bool
+	bakeTime      time.Duration
+}
+
+// NewSequentialExecutor creates a sequential executor. failFast stops the run
+// after the first failing host. haltOnFailure does the same but reports every
+// host it did not reach as skipped, so a staged rollout shows where it stopped.
+func NewSequentialExecutor(failFast, haltOnFailure, verbose bool) *SequentialExecutor {
+	return &SequentialExecutor{failFast: failFast, haltOnFailure: haltOnFailure, verbose: verbose}
+}
+
+// SetBakeTime makes the executor wait d after each passing host before it
+// tests the next, so a rollout soaks on every host before moving on. A bake
+// time implies haltOnFailure: a host that fails stops the rollout.
+func (se *SequentialExecutor) SetBakeTime(d time.Duration) {
+	se.bakeTime = d
+}
+
+// Execute runs tests on each host in turn. It returns an error if testFunc
+// fails without producing results for a host. Cancelling ctx while waiting
+// out the bake time ends the run with the hosts tested so far.
+func (se *SequentialExecutor) Execute(ctx context.Context, jobs []HostJob, testFunc func(context.Context, HostJob) (*HostResults, error)) (*MultiHostResults, error) {
+	startTime := time.Now()
+	results := &MultiHostResults{Hosts: make([]*HostResults, 0, len(jobs))}
+
+	for i, job := range jobs {
+		result, err := testFunc(ctx, job)
+		if err != nil && result == nil {
+			return nil, fmt.Errorf("%s: %w", job.HostEntry, err)
+		}
+		result.Labels = job.Labels
+		results.Hosts = append(results.Hosts, result)
+
+		if result.Success() {
+			if se.bakeTime > 0 && i < len(jobs)-1 && !se.bake(ctx, result.Target) {
+				break
+			}
+			continue
+		}
+		if se.haltOnFailure || se.bakeTime > 0 {
+			if se.verbose {
+				fmt.Fprintf(os.Stderr, "Halting: %s failed, skipping %d remaining host(s)\n", result.Target, len(jobs)-i-1)
+			}
+			for _, skipped := range jobs[i+1:] {
+				results.Hosts = append(results.Hosts, &HostResults{
+					Target:  skipped.target(),
+					Labels:  skipped.Labels,
+					Skipped: true,
+				})
+			}
+			break
+		}
+		if se.failFast {
+			if se.verbose {
+				fmt.Fprintf(os.Stderr, "Fail-fast: stopping due to failure on %s\n", result.Target)
+			}
+			break
+		}
+	}
+
+	results.TotalDuration = time.Since(startTime)
+	return results, nil
+}
+
+// bake waits out the bake time after target passed. It returns false if ctx
+// is cancelled first.
+func (se *SequentialExecutor) bake(ctx context.Context, target string) bool {
+	if se.verbose {
+		fmt.Fprintf(os.Stderr, "Baking: %s passed, waiting %s before the next host\n", target, se.bakeTime)
+	}
+	timer := time.NewTimer(se.bakeTime)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
@@ -42,6 +42,40 @@ func TestParallelExecutor_Sequential(t *testing.T) {
 	}
 }
 
+func TestParallelExecutor_PropagatesLabels(t *testing.T) {
+	executor := NewParallelExecutor(2, false, false)
+
+	jobs := []HostJob{
+		{HostEntry: "web1", Labels: map[string]string{"role": "web"}},
+		{HostEntry: "db1", Labels: map[string]string{"role": "db", "datacenter": "eu-west"}},
+		{HostEntry: "bare"},
+	}
+
+	testFunc := func(ctx context.Context, job HostJob) (*HostResults, error) {
+		return &HostResults{Target: job.HostEntry, Connected: job.HostEntry != "db1"}, nil
+	}
+
+	results, err := executor.Execute(jobs, testFunc)
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	byTarget := make(map[string]*HostResults)
+	for _, host := range results.Hosts {
+		byTarget[host.Target] = host
+	}
+	if got := byTarget["web1"].Labels["role"]; got != "web" {
+		t.Errorf("web1 role = %q, want web", got)
+	}
+	// Labels are kept for hosts that fail to connect
+	if got := byTarget["db1"].Labels["datacenter"]; got != "eu-west" {
+		t.Errorf("db1 datacenter = %q, want eu-west", got)
+	}
+	if byTarget["bare"].Labels != nil {
+		t.Errorf("bare labels = %v, want none", byTarget["bare"].Labels)
+	}
+}
+
 func TestParallelExecutor_Parallel(t *testing.T) {
 	// Test that parallel execution works
 	executor := NewParallelExecutor(4, false, false)
@@ -289,3 +323,273 @@ func TestParallelExecutor_VerboseMode(t *testing.T) {
 		t.Errorf("Expected 2 results, got %d", len(results.Hosts))
 	}
 }
+
+func TestParallelExecutor_HostTimeout(t *testing.T) {
+	executor := NewParallelExecutor(3, false, false)
+
+	jobs := []HostJob{
+		{HostEntry: "fast1"},
+		{HostEntry: "slow"},
+		{HostEntry: "fast2"},
+		{HostEntry: "unreachable"},
+	}
+
+	testFunc := func(ctx context.Context, job HostJob) (*HostResults, error) {
+		switch job.HostEntry {
+		case "slow":
+			// Connected and passed one spec, then hangs until cut off
+			result := &HostResults{
+				Target:      job.HostEntry,
+				Connected:   true,
+				SpecResults: []*TestResults{{Results: []Result{{Name: "first", Status: StatusPass}}}},
+			}
+			<-ctx.Done()
+			return result, nil
+		case "unreachable":
+			// Hangs while connecting
+			<-ctx.Done()
+			return &HostResults{Target: job.HostEntry, ConnectionError: ctx.Err()}, ctx.Err()
+		}
+		return &HostResults{Target: job.HostEntry, Connected: true}, nil
+	}
+
+	start := time.Now()
+	results, err := executor.Execute(jobs, WithHostTimeout(50*time.Millisecond, testFunc))
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Fatalf("Execute() took %v, slow hosts were not cut off", elapsed)
+	}
+
+	byTarget := make(map[string]*HostResults)
+	for _, host := range results.Hosts {
+		byTarget[host.Target] = host
+	}
+	for _, name := range []string{"fast1", "fast2"} {
+		if host := byTarget[name]; host == nil || !host.Success() || host.TimedOut {
+			t.Errorf("%s = %+v, want success", name, host)
+		}
+	}
+
+	slow := byTarget["slow"]
+	if !slow.TimedOut || slow.Success() {
+		t.Errorf("slow host TimedOut = %v, Success = %v, want timed out and failed", slow.TimedOut, slow.Success())
+	}
+	if len(slow.SpecResults) != 1 {
+		t.Errorf("slow host should keep its partial results, got %d specs", len(slow.SpecResults))
+	}
+
+	unreachable := byTarget["unreachable"]
+	if unreachable.Connected || unreachable.ConnectionError == nil ||
+		unreachable.ConnectionError.Error() != "host timed out after 50ms" {
+		t.Errorf("unreachable host = %+v, want timeout connection error", unreachable)
+	}
+
+	_, passed, failed, connErrors := results.Summary()
+	if passed != 2 || failed != 2 || connErrors != 1 {
+		t.Errorf("Summary() = passed %d, failed %d, connection errors %d", passed, failed, connErrors)
+	}
+}
+
+func TestWithHostTimeout_FinishesInTime(t *testing.T) {
+	testFunc := WithHostTimeout(time.Second, func(ctx context.Context, job HostJob) (*HostResults, error) {
+		return &HostResults{Target: job.HostEntry, Connected: true}, nil
+	})
+
+	result, err := testFunc(context.Background(), HostJob{HostEntry: "host1"})
+	if err != nil || result.TimedOut || !result.Success() {
+		t.Errorf("testFunc() = %+v, %v, want success", result, err)
+	}
+}
+
+func TestWithHostTimeout_TargetMatchesTestedHosts(t *testing.T) {
+	testFunc := WithHostTimeout(10*time.Millisecond, func(ctx context.Context, job HostJob) (*HostResults, error) {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	})
+
+	result, _ := testFunc(context.Background(), HostJob{HostEntry: "web1", User: "deploy"})
+	if result == nil || result.Target != "deploy@web1" {
+		t.Errorf("timed out result = %+v, want target deploy@web1", result)
+	}
+}
+
+func TestSequentialExecutor_HaltOnFailure(t *testing.T) {
+	jobs := []HostJob{
+		{HostEntry: "canary", User: "deploy"},
+		{HostEntry: "web1", User: "deploy"},
+		{HostEntry: "web2", User: "deploy", Labels: map[string]string{"role": "web"}},
+		{HostEntry: "ops@web3", User: "ops"},
+	}
+
+	var tested []string
+	testFunc := func(ctx context.Context, job HostJob) (*HostResults, error) {
+		tested = append(tested, job.HostEntry)
+		result := &HostResults{Target: job.User + "@" + job.HostEntry, Connected: true}
+		if job.HostEntry == "web1" {
+			result.SpecResults = []*TestResults{{Results: []Result{{Name: "nginx running", Status: StatusFail}}}}
+		}
+		return result, nil
+	}
+
+	results, err := NewSequentialExecutor(false, true, false).Execute(context.Background(), jobs, testFunc)
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	if want := []string{"canary", "web1"}; fmt.Sprint(tested) != fmt.Sprint(want) {
+		t.Errorf("tested hosts = %v, want %v", tested, want)
+	}
+	if len(results.Hosts) != 4 {
+		t.Fatalf("Expected all 4 hosts reported, got %d", len(results.Hosts))
+	}
+	for i, host := range results.Hosts {
+		if wantSkipped := i >= 2; host.Skipped != wantSkipped {
+			t.Errorf("host %d (%s) Skipped = %v, want %v", i, host.Target, host.Skipped, wantSkipped)
+		}
+	}
+	if target := results.Hosts[2].Target; target != "deploy@web2" {
+		t.Errorf("skipped host Target = %q, want deploy@web2", target)
+	}
+	if target := results.Hosts[3].Target; target != "ops@web3" {
+		t.Errorf("skipped host Target = %q, want ops@web3", target)
+	}
+	if results.Hosts[2].Labels["role"] != "web" {
+		t.Errorf("skipped host Labels = %v, want role=web", results.Hosts[2].Labels)
+	}
+
+	total, passed, failed, connErrors := results.Summary()
+	if total != 4 || passed != 1 || failed != 1 || connErrors != 0 || results.SkippedHosts() != 2 {
+		t.Errorf("Summary() = %d total, %d passed, %d failed, %d conn errors, %d skipped; want 4, 1, 1, 0, 2",
+			total, passed, failed, connErrors, results.SkippedHosts())
+	}
+	if results.Success() {
+		t.Error("Success() = true for a halted run")
+	}
+}
+
+func TestSequentialExecutor_FailFastAndContinue(t *testing.T) {
+	jobs := []HostJob{{HostEntry: "host1"}, {HostEntry: "host2"}, {HostEntry: "host3"}}
+	testFunc := func(ctx context.Context, job HostJob) (*HostResults, error) {
+		return &HostResults{Target: job.HostEntry, Connected: job.HostEntry != "host2"}, nil
+	}
+
+	tests := []struct {
+		name      string
+		failFast  bool
+		wantHosts int
+	}{
+		{name: "continue past failures", failFast: false, wantHosts: 3},
+		{name: "fail-fast stops without reporting the rest", failFast: true, wantHosts: 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results, err := NewSequentialExecutor(tt.failFast, false, false).Execute(context.Background(), jobs, testFunc)
+			if err != nil {
+				t.Fatalf("Execute() error = %v", err)
+			}
+			if len(results.Hosts) != tt.wantHosts {
+				t.Errorf("Expected %d hosts, got %d", tt.wantHosts, len(results.Hosts))
+			}
+			if results.SkippedHosts() != 0 {
+				t.Errorf("SkippedHosts() = %d, want 0", results.SkippedHosts())
+			}
+		})
+	}
+}
+
+func TestSequentialExecutor_BakeTime(t *testing.T) {
+	const bakeTime = 50 * time.Millisecond
+	jobs := []HostJob{{HostEntry: "canary"}, {HostEntry: "web1"}, {HostEntry: "web2"}, {HostEntry: "web3"}}
+
+	var started []time.Time
+	testFunc := func(ctx context.Context, job HostJob) (*HostResults, error) {
+		started = append(started, time.Now())
+		result := &HostResults{Target: job.HostEntry, Connected: true}
+		if job.HostEntry == "web2" {
+			result.SpecResults = []*TestResults{{Results: []Result{{Name: "nginx running", Status: StatusFail}}}}
+		}
+		return result, nil
+	}
+
+	executor := NewSequentialExecutor(false, true, false)
+	executor.SetBakeTime(bakeTime)
+	results, err := executor.Execute(context.Background(), jobs, testFunc)
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	if len(started) != 3 {
+		t.Fatalf("tested %d hosts, want 3 (halted at web2)", len(started))
+	}
+	for i := 1; i < len(started); i++ {
+		if gap := started[i].Sub(started[i-1]); gap < bakeTime {
+			t.Errorf("host %d started %s after host %d, want at least the %s bake time", i, gap, i-1, bakeTime)
+		}
+	}
+	if !results.Hosts[3].Skipped {
+		t.Errorf("host after the failure Skipped = false, want true")
+	}
+}
+
+func TestSequentialExecutor_BakeTimeHaltsOnFailure(t *testing.T) {
+	jobs := []HostJob{{HostEntry: "canary"}, {HostEntry: "web1"}, {HostEntry: "web2"}}
+
+	var tested []string
+	testFunc := func(ctx context.Context, job HostJob) (*HostResults, error) {
+		tested = append(tested, job.HostEntry)
+		result := &HostResults{Target: job.HostEntry, Connected: true}
+		if job.HostEntry == "web1" {
+			result.SpecResults = []*TestResults{{Results: []Result{{Name: "nginx running", Status: StatusFail}}}}
+		}
+		return result, nil
+	}
+
+	// Neither failFast nor haltOnFailure: the bake time alone stops the rollout
+	executor := NewSequentialExecutor(false, false, false)
+	executor.SetBakeTime(time.Millisecond)
+	results, err := executor.Execute(context.Background(), jobs, testFunc)
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	if want := []string{"canary", "web1"}; fmt.Sprint(tested) != fmt.Sprint(want) {
+		t.Errorf("tested hosts = %v, want %v", tested, want)
+	}
+	if len(results.Hosts) != 3 || !results.Hosts[2].Skipped {
+		t.Errorf("want web2 reported as skipped, got %d hosts", len(results.Hosts))
+	}
+}
+
+func TestSequentialExecutor_BakeTimeCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var tested int
+	testFunc := func(ctx context.Context, job HostJob) (*HostResults, error) {
+		tested++
+		cancel()
+		return &HostResults{Target: job.HostEntry, Connected: true}, nil
+	}
+
+	executor := NewSequentialExecutor(false, false, false)
+	executor.SetBakeTime(time.Hour)
+	results, err := executor.Execute(ctx, []HostJob{{HostEntry: "host1"}, {HostEntry: "host2"}}, testFunc)
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if tested != 1 || len(results.Hosts) != 1 {
+		t.Errorf("tested %d hosts and reported %d, want 1 and 1", tested, len(results.Hosts))
+	}
+}
+
+func TestSequentialExecutor_Error(t *testing.T) {
+	testFunc := func(ctx context.Context, job HostJob) (*HostResults, error) {
+		return nil, fmt.Errorf("failed to execute tests")
+	}
+	_, err := NewSequentialExecutor(false, true, false).Execute(context.Background(), []HostJob{{HostEntry: "host1"}}, testFunc)
+	if err == nil || err.Error() != "host1: failed to execute tests" {
+		t.Errorf("Execute() error = %v, want host1: failed to execute tests", err)
+	}
+}
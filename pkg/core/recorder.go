@@ -0,0 +1,68 @@
+package core
+
+import (
+	"context"
+	"sync"
+)
+
+// CommandRecorder collects the commands a plugin would run, for a dry run that
+// lists them for review instead of running them
+type CommandRecorder struct {
+	mu       sync.Mutex
+	commands []string
+	seen     map[string]bool
+}
+
+// NewCommandRecorder creates an empty CommandRecorder
+func NewCommandRecorder() *CommandRecorder {
+	return &CommandRecorder{seen: make(map[string]bool)}
+}
+
+// Record adds commands not already recorded, in order
+func (r *CommandRecorder) Record(commands ...string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, command := range commands {
+		if !r.seen[command] {
+			r.seen[command] = true
+			r.commands = append(r.commands, command)
+		}
+	}
+}
+
+// Commands returns the recorded commands in the order first recorded
+func (r *CommandRecorder) Commands() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string(nil), r.commands...)
+}
+
+// CommandPlanner is implemented by providers that change commands before
+// running them, such as adding a kubectl --context, so that a dry run lists
+// each command as it would run
+type CommandPlanner interface {
+	PlanCommand(command string) string
+}
+
+// PlanCommand returns command as provider would run it
+func PlanCommand(provider Provider, command string) string {
+	if planner, ok := provider.(CommandPlanner); ok {
+		return planner.PlanCommand(command)
+	}
+	return command
+}
+
+type recorderKey struct{}
+
+// WithCommandRecorder returns a context in which plugins that support dry
+// runs record their commands with recorder instead of running tests
+func WithCommandRecorder(ctx context.Context, recorder *CommandRecorder) context.Context {
+	return context.WithValue(ctx, recorderKey{}, recorder)
+}
+
+// CommandRecorderFromContext returns the recorder set with
+// WithCommandRecorder, or nil when tests should run
+func CommandRecorderFromContext(ctx context.Context) *CommandRecorder {
+	recorder, _ := ctx.Value(recorderKey{}).(*CommandRecorder)
+	return recorder
+}
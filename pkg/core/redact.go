@@ -0,0 +1,99 @@
+package core
+
+import (
+	"sort"
+	"strings"
+)
+
+// sensitiveValues returns the values of the env keys listed in
+// config.sensitive_env, longest first so overlapping values redact fully
+func (s *Spec) sensitiveValues() []string {
+	var values []string
+	for _, key := range s.Config.SensitiveEnv {
+		if value := s.Config.Env[key]; value != "" {
+			values = append(values, value)
+		}
+	}
+	sort.Slice(values, func(i, j int) bool { return len(values[i]) > len(values[j]) })
+	return values
+}
+
+// redact replaces each sensitive value in text with Redacted
+func redact(text string, values []string) string {
+	for _, value := range values {
+		text = strings.ReplaceAll(text, value, Redacted)
+	}
+	return text
+}
+
+// redactResults removes sensitive env values from result messages, string
+// details and attachments, which often echo command output, and replaces
+// sensitive attachments unless the spec asks to show them
+func (s *Spec) redactResults(results []Result) {
+	values := s.sensitiveValues()
+	for i := range results {
+		if !s.Config.ShowSensitiveAttachments {
+			for name := range results[i].sensitive {
+				results[i].Attachments[name] = Redacted
+			}
+		}
+		if len(values) == 0 {
+			continue
+		}
+		results[i].Message = redact(results[i].Message, values)
+		for key, detail := range results[i].Details {
+			if text, ok := detail.(string); ok {
+				results[i].Details[key] = redact(text, values)
+			}
+		}
+		for name, attachment := range results[i].Attachments {
+			results[i].Attachments[name] = redact(attachment, values)
+		}
+	}
+}
+
+// redacted returns a copy of the spec whose sensitive env values and HTTP
+// credential headers are replaced with Redacted, for printing
+func (s *Spec) redacted() *Spec {
+	out := *s
+	if len(s.Config.SensitiveEnv) > 0 {
+		out.Config.Env = make(map[string]string, len(s.Config.Env))
+		for key, value := range s.Config.Env {
+			out.Config.Env[key] = value
+		}
+		for _, key := range s.Config.SensitiveEnv {
+			out.Config.Env[key] = Redacted
+		}
+	}
+	if len(s.Tests.HTTP) > 0 {
+		out.Tests.HTTP = make([]HTTPTest, len(s.Tests.HTTP))
+		for i, test := range s.Tests.HTTP {
+			test.Headers = RedactHeaders(test.Headers)
+			out.Tests.HTTP[i] = test
+		}
+	}
+	return &out
+}
+
+// SensitiveHeader reports whether an HTTP header carries credentials, such
+// as Authorization, Proxy-Authorization or Cookie
+func SensitiveHeader(name string) bool {
+	name = strings.ToLower(name)
+	return strings.Contains(name, "authorization") || strings.Contains(name, "cookie")
+}
+
+// RedactHeaders returns a copy of headers with the values of sensitive
+// headers replaced with Redacted
+func RedactHeaders(headers map[string]string) map[string]string {
+	if headers == nil {
+		return nil
+	}
+	out := make(map[string]string, len(headers))
+	for name, value := range headers {
+		if SensitiveHeader(name) {
+			value = Redacted
+		}
+		out[name] = value
+	}
+	return out
+}
@@ -0,0 +1,185 @@
+package core
+
+import (
+	"context"
+	"reflect"
+	"time"
+
+	"github.com/neilfarmer/platform-spec/pkg/retry"
+)
+
+// checkFailure carries a failed result through retry.Do
+type checkFailure struct {
+	result Result
+	lost   *ConnectionLostError // Set if the check's connection dropped
+}
+
+func (f *checkFailure) Error() string {
+	return f.result.Message
+}
+
+// retryDefaultsKey is the context key for spec-wide retry options
+type retryDefaultsKey struct{}
+
+// WithRetryDefaults returns a context in which tests without their own retry
+// options are retried as set by defaults
+func WithRetryDefaults(ctx context.Context, defaults *TestRetry) context.Context {
+	return context.WithValue(ctx, retryDefaultsKey{}, defaults)
+}
+
+// retryDefaultsFromContext returns the spec-wide retry options, or nil
+func retryDefaultsFromContext(ctx context.Context) *TestRetry {
+	defaults, _ := ctx.Value(retryDefaultsKey{}).(*TestRetry)
+	return defaults
+}
+
+// DefaultRetryClassifiers decide, per test category, which failed checks of
+// tests with retry options are re-checked. Categories without an entry use
+// retry.IsTransientError.
+var DefaultRetryClassifiers = map[string]retry.ErrorClassifier{
+	// A proxy answering for a backend that is still starting
+	"http": retry.AnyOf(retry.IsNetworkError, retry.ContainsAny("status code is 502", "status code is 503", "status code is 504")),
+	// A service that has not bound its port yet
+	"ports": retry.AnyOf(retry.IsNetworkError, retry.ContainsAny("is not listening")),
+	// A resolver or route that blipped, common over WAN links
+	"dns":  retry.AnyOf(retry.IsNetworkError, retry.ContainsAny("DNS resolution failed")),
+	"ping": retry.AnyOf(retry.IsNetworkError, retry.ContainsAny("is not reachable")),
+}
+
+// retryClassifierKey is the context key for the test retry classifier
+type retryClassifierKey struct{}
+
+// withRetryClassifier returns a context in which RunTest re-checks the
+// failures classifier accepts
+func withRetryClassifier(ctx context.Context, classifier retry.ErrorClassifier) context.Context {
+	return context.WithValue(ctx, retryClassifierKey{}, classifier)
+}
+
+// retryClassifierFromContext returns the test retry classifier, defaulting to
+// retry.IsTransientError
+func retryClassifierFromContext(ctx context.Context) retry.ErrorClassifier {
+	if classifier, ok := ctx.Value(retryClassifierKey{}).(retry.ErrorClassifier); ok {
+		return classifier
+	}
+	return retry.IsTransientError
+}
+
+// retryHookKey is the context key for the test retry hook
+type retryHookKey struct{}
+
+// RetryHook is told about a failed check that will be retried: the failed
+// result, the number of the check starting at 1, and the wait before the next
+type RetryHook func(result Result, attempt int, nextDelay time.Duration)
+
+// WithRetryHook returns a context in which RunTest reports each retried check
+// to hook, e.g. to log it
+func WithRetryHook(ctx context.Context, hook RetryHook) context.Context {
+	return context.WithValue(ctx, retryHookKey{}, hook)
+}
+
+// retryHookFromContext returns the test retry hook, or nil
+func retryHookFromContext(ctx context.Context) RetryHook {
+	hook, _ := ctx.Value(retryHookKey{}).(RetryHook)
+	return hook
+}
+
+// RunTest runs a single test check. If the test has retry options, a failure
+// that looks transient is re-checked after the retry interval until it passes
+// or the attempts run out; assertion mismatches are reported immediately.
+// What counts as transient depends on the test's category. The last attempt's
+// result is returned, timed across all attempts and recording how many were
+// made. Tests without retry options fall back to the spec-wide defaults in
+// ctx, if any. A check whose connection dropped is an error, and always
+// transient: the provider reconnects for the next check. Each check is given
+// the context to run its commands with; under a spec timeout it expires
+// after that long and the check is reported as timed out.
+func RunTest(ctx context.Context, opts TestOptions, check func(context.Context) Result) Result {
+	monitor := connectionMonitorFromContext(ctx)
+	timeout := testTimeoutFromContext(ctx)
+	checkOnce := func() (Result, *ConnectionLostError) {
+		mark := monitor.mark()
+		result := runCheck(ctx, timeout, check)
+		if lost := monitor.lostSince(mark); lost != nil {
+			return connectionLostResult(result, lost), lost
+		}
+		return result, nil
+	}
+
+	testRetry := opts.Retry
+	if testRetry == nil {
+		testRetry = retryDefaultsFromContext(ctx)
+	}
+	if testRetry == nil || testRetry.Attempts <= 1 {
+		result, _ := checkOnce()
+		return result
+	}
+
+	config := &retry.Config{
+		MaxRetries:   testRetry.Attempts - 1,
+		InitialDelay: testRetry.Interval,
+		MaxDelay:     testRetry.Interval,
+		Strategy:     retry.StrategyConstant,
+	}
+	if hook := retryHookFromContext(ctx); hook != nil {
+		config.OnRetry = func(attempt int, err error, nextDelay time.Duration) {
+			if failure, ok := err.(*checkFailure); ok {
+				hook(failure.result, attempt, nextDelay)
+			}
+		}
+	}
+
+	start := time.Now()
+	attempts := 0
+	var result Result
+	// The outcome is carried by result; retry.Do's error only says why it stopped
+	classifier := retryClassifierFromContext(ctx)
+	_ = retry.Do(ctx, config, func(err error) bool {
+		if failure, ok := err.(*checkFailure); ok && failure.lost != nil {
+			return true
+		}
+		return classifier(err)
+	}, func() error {
+		attempts++
+		var lost *ConnectionLostError
+		result, lost = checkOnce()
+		if result.Status == StatusFail || result.Status == StatusError {
+			return &checkFailure{result: result, lost: lost}
+		}
+		return nil
+	})
+
+	result.Attempts = attempts
+	result.MaxAttempts = testRetry.Attempts
+	result.Duration = time.Since(start)
+	return result
+}
+
+// categoryRetryClassifier returns a classifier that judges a failed check by
+// the classifier for its test's category: an override, else the default for
+// the category, else retry.IsTransientError. The category is found from the
+// spec test of the same name.
+func (s *Spec) categoryRetryClassifier(overrides map[string]retry.ErrorClassifier) retry.ErrorClassifier {
+	categories := make(map[string]string)
+	for _, ref := range categoryRefs(reflect.ValueOf(s.Tests), nil) {
+		name := s.Tests.nameOf(ref)
+		if _, ok := categories[name]; !ok {
+			categories[name] = ref.categoryName()
+		}
+	}
+	return func(err error) bool {
+		category := ""
+		if failure, ok := err.(*checkFailure); ok {
+			category = failure.result.Category
+			if category == "" {
+				category = categories[failure.result.Name]
+			}
+		}
+		if classifier, ok := overrides[category]; ok {
+			return classifier(err)
+		}
+		if classifier, ok := DefaultRetryClassifiers[category]; ok {
+			return classifier(err)
+		}
+		return retry.IsTransientError(err)
+	}
+}
@@ -0,0 +1,109 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// DefaultShell runs test commands unless a spec or --shell sets another. The
+// executors' commands use POSIX sh syntax (pipes, 2>/dev/null).
+const DefaultShell = "/bin/sh"
+
+// ValidateShell returns an error if shell is not an absolute path to a shell
+func ValidateShell(shell string) error {
+	if shell == "" {
+		return nil
+	}
+	if !filepath.IsAbs(shell) || strings.ContainsAny(shell, " \t'\"") {
+		return fmt.Errorf("shell must be an absolute path like /bin/bash, got '%s'", shell)
+	}
+	return nil
+}
+
+type shellKey struct{}
+
+// WithShell returns a context in which providers run commands with shell
+func WithShell(ctx context.Context, shell string) context.Context {
+	return context.WithValue(ctx, shellKey{}, shell)
+}
+
+// ShellFromContext returns the shell set with WithShell, or DefaultShell
+func ShellFromContext(ctx context.Context) string {
+	if shell, _ := ctx.Value(shellKey{}).(string); shell != "" {
+		return shell
+	}
+	return DefaultShell
+}
+
+// ShellCommand wraps command to run with the context's shell and environment,
+// e.g. env 'LANG=C' 'LC_ALL=C' /bin/bash -c 'cmd', for providers whose
+// transport has its own default shell
+func ShellCommand(ctx context.Context, command string) string {
+	wrapped := ShellFromContext(ctx) + " -c " + ShellQuote(command)
+	env := EnvFromContext(ctx)
+	quoted := make([]string, len(env))
+	for i, assignment := range env {
+		quoted[i] = ShellQuote(assignment)
+	}
+	return "env " + strings.Join(quoted, " ") + " " + wrapped
+}
+
+// Redacted replaces sensitive values in output
+const Redacted = "[REDACTED]"
+
+var envKeyPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// ValidateEnvKey returns an error if key is not a valid environment variable name
+func ValidateEnvKey(key string) error {
+	if !envKeyPattern.MatchString(key) {
+		return fmt.Errorf("env key '%s' must contain only letters, digits and underscores, and not start with a digit", key)
+	}
+	return nil
+}
+
+// ParseEnvAssignment parses a KEY=VALUE assignment
+func ParseEnvAssignment(assignment string) (key, value string, err error) {
+	key, value, found := strings.Cut(assignment, "=")
+	if !found {
+		return "", "", fmt.Errorf("'%s' must be KEY=VALUE", assignment)
+	}
+	if err := ValidateEnvKey(key); err != nil {
+		return "", "", err
+	}
+	return key, value, nil
+}
+
+type envKey struct{}
+
+// WithEnv returns a context in which providers export env to every command
+func WithEnv(ctx context.Context, env map[string]string) context.Context {
+	return context.WithValue(ctx, envKey{}, env)
+}
+
+// CommandLocale is exported to every command so that the output executors
+// parse (findmnt, df, systemctl) is untranslated on any host. A spec's env
+// can override it.
+var CommandLocale = map[string]string{"LANG": "C", "LC_ALL": "C"}
+
+// EnvFromContext returns CommandLocale overlaid with the environment set with
+// WithEnv, as KEY=VALUE assignments sorted by key
+func EnvFromContext(ctx context.Context) []string {
+	env, _ := ctx.Value(envKey{}).(map[string]string)
+	merged := make(map[string]string, len(CommandLocale)+len(env))
+	for key, value := range CommandLocale {
+		merged[key] = value
+	}
+	for key, value := range env {
+		merged[key] = value
+	}
+	assignments := make([]string, 0, len(merged))
+	for key, value := range merged {
+		assignments = append(assignments, key+"="+value)
+	}
+	sort.Strings(assignments)
+	return assignments
+}
@@ -0,0 +1,61 @@
+package core
+
+import (
+	"context"
+	"testing"
+)
+
+func TestValidateShell(t *testing.T) {
+	for _, shell := range []string{"", "/bin/sh", "/usr/local/bin/bash"} {
+		if err := ValidateShell(shell); err != nil {
+			t.Errorf("ValidateShell(%q) error = %v", shell, err)
+		}
+	}
+	for _, shell := range []string{"bash", "/bin/bash -l", "/bin/'sh'"} {
+		if err := ValidateShell(shell); err == nil {
+			t.Errorf("ValidateShell(%q) expected error", shell)
+		}
+	}
+}
+
+func TestShellCommand(t *testing.T) {
+	ctx := context.Background()
+	if got, want := ShellCommand(ctx, "echo 'hi' | wc -l"), `env 'LANG=C' 'LC_ALL=C' /bin/sh -c 'echo '\''hi'\'' | wc -l'`; got != want {
+		t.Errorf("ShellCommand() = %q, want %q", got, want)
+	}
+
+	ctx = WithShell(ctx, "/bin/bash")
+	if got, want := ShellCommand(ctx, "uptime"), "env 'LANG=C' 'LC_ALL=C' /bin/bash -c 'uptime'"; got != want {
+		t.Errorf("ShellCommand() with shell = %q, want %q", got, want)
+	}
+}
+
+func TestShellCommandEnv(t *testing.T) {
+	ctx := WithEnv(context.Background(), map[string]string{"PATH": "/opt/bin:/usr/bin", "GREETING": "it's me"})
+	want := `env 'GREETING=it'\''s me' 'LANG=C' 'LC_ALL=C' 'PATH=/opt/bin:/usr/bin' /bin/sh -c 'uptime'`
+	if got := ShellCommand(ctx, "uptime"); got != want {
+		t.Errorf("ShellCommand() with env = %q, want %q", got, want)
+	}
+
+	// A spec's env overrides the C locale
+	ctx = WithEnv(context.Background(), map[string]string{"LC_ALL": "de_DE.UTF-8"})
+	want = `env 'LANG=C' 'LC_ALL=de_DE.UTF-8' /bin/sh -c 'uptime'`
+	if got := ShellCommand(ctx, "uptime"); got != want {
+		t.Errorf("ShellCommand() with locale override = %q, want %q", got, want)
+	}
+}
+
+func TestParseEnvAssignment(t *testing.T) {
+	key, value, err := ParseEnvAssignment("DSN=postgres://db?sslmode=disable")
+	if err != nil || key != "DSN" || value != "postgres://db?sslmode=disable" {
+		t.Errorf("ParseEnvAssignment() = %q, %q, %v", key, value, err)
+	}
+	if _, value, err := ParseEnvAssignment("EMPTY="); err != nil || value != "" {
+		t.Errorf("ParseEnvAssignment(EMPTY=) = %q, %v", value, err)
+	}
+	for _, assignment := range []string{"NOVALUE", "1ST=x", "MY-VAR=x", "=x"} {
+		if _, _, err := ParseEnvAssignment(assignment); err == nil {
+			t.Errorf("ParseEnvAssignment(%q) expected error", assignment)
+		}
+	}
+}
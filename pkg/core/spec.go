@@ -2,10 +2,16 @@ package core
 
 import (
 	"fmt"
+	"net"
+	"net/url"
 	"os"
 	"path/filepath"
+	"reflect"
+	"regexp"
 	"strings"
+	"time"
 
+	"github.com/neilfarmer/platform-spec/pkg/jsonpath"
 	"gopkg.in/yaml.v3"
 )
 
@@ -17,6 +23,9 @@ type Spec struct {
 	Config    SpecConfig             `yaml:"config"`
 	Variables map[string]interface{} `yaml:"variables"`
 	Tests     Tests                  `yaml:"tests"`
+
+	declared      []testRef       // Order tests appear in the file(s), for order: declaration
+	usedVariables map[string]bool // Variables referenced as ${var.name} in the file(s)
 }
 
 // SpecMetadata contains metadata about the spec
@@ -28,30 +37,89 @@ type SpecMetadata struct {
 
 // SpecConfig contains configuration options
 type SpecConfig struct {
-	FailFast            bool   `yaml:"fail_fast"`
-	Parallel            bool   `yaml:"parallel"`
-	Timeout             int    `yaml:"timeout"`
-	KubernetesContext   string `yaml:"kubernetes_context,omitempty"`
-	KubernetesNamespace string `yaml:"kubernetes_namespace,omitempty"`
+	FailFast                 bool              `yaml:"fail_fast"`
+	Parallel                 bool              `yaml:"parallel"`
+	Timeout                  int               `yaml:"timeout"` // Seconds each test may run before it errors (default: no limit)
+	KubernetesContext        string            `yaml:"kubernetes_context,omitempty"`
+	KubernetesNamespace      string            `yaml:"kubernetes_namespace,omitempty"`
+	Hooks                    SpecHooks         `yaml:"hooks,omitempty"`
+	Order                    string            `yaml:"order,omitempty"`                      // category (default), name, or declaration
+	Retries                  int               `yaml:"retries,omitempty"`                    // Default re-checks of transient failures for every test
+	RetryInterval            int               `yaml:"retry_interval,omitempty"`             // Default seconds between re-checks
+	Shell                    string            `yaml:"shell,omitempty"`                      // Shell that runs test commands (default /bin/sh)
+	Env                      map[string]string `yaml:"env,omitempty"`                        // Variables exported for every command on the target
+	SensitiveEnv             []string          `yaml:"sensitive_env,omitempty"`              // Env keys whose values are redacted from output
+	ShowSensitiveAttachments bool              `yaml:"show_sensitive_attachments,omitempty"` // Keep evidence such as secret data unredacted in results
+	Requires                 []string          `yaml:"requires,omitempty"`                   // Commands the target must have on its PATH before any test runs
+}
+
+// SpecHooks lists shell commands run on the target around a spec's tests,
+// e.g. to warm a cache or collect diagnostics
+type SpecHooks struct {
+	Before []string `yaml:"before,omitempty"` // Run in order before the tests; a failure aborts the spec
+	After  []string `yaml:"after,omitempty"`  // Run in order after the tests, even if they failed
+}
+
+// TestOptions holds settings shared by every test type. It is embedded
+// inline, so its fields sit alongside each test's own fields in YAML.
+type TestOptions struct {
+	ContinueOnFailure bool       `yaml:"continue_on_failure,omitempty"` // A failure is recorded but does not trigger fail-fast
+	Retry             *TestRetry `yaml:"retry,omitempty"`               // Re-check transient failures before reporting them
+}
+
+// TestRetry re-runs a test whose failure looks transient, such as a refused
+// connection or a pod that is not ready yet
+type TestRetry struct {
+	Attempts int           `yaml:"attempts"`           // Total attempts, including the first
+	Interval time.Duration `yaml:"interval,omitempty"` // Wait between attempts, with a unit (e.g., 2s)
 }
 
 // Tests contains all test definitions
 type Tests struct {
-	Packages       []PackageTest        `yaml:"packages"`
-	Files          []FileTest           `yaml:"files"`
-	Services       []ServiceTest        `yaml:"services"`
-	Users          []UserTest           `yaml:"users"`
-	Groups         []GroupTest          `yaml:"groups"`
-	FileContent    []FileContentTest    `yaml:"file_content"`
-	CommandContent []CommandContentTest `yaml:"command_content"`
-	Docker         []DockerTest         `yaml:"docker"`
-	Filesystems    []FilesystemTest     `yaml:"filesystems"`
-	Ping           []PingTest           `yaml:"ping"`
-	DNS            []DNSTest            `yaml:"dns"`
-	SystemInfo     []SystemInfoTest     `yaml:"systeminfo"`
-	HTTP           []HTTPTest           `yaml:"http"`
-	Ports          []PortTest           `yaml:"ports"`
-	Kubernetes     KubernetesTests      `yaml:"kubernetes"`
+	Packages        []PackageTest         `yaml:"packages"`
+	Files           []FileTest            `yaml:"files"`
+	Services        []ServiceTest         `yaml:"services"`
+	Users           []UserTest            `yaml:"users"`
+	Groups          []GroupTest           `yaml:"groups"`
+	FileContent     []FileContentTest     `yaml:"file_content"`
+	CommandContent  []CommandContentTest  `yaml:"command_content"`
+	Docker          []DockerTest          `yaml:"docker"`
+	DockerImages    []DockerImageTest     `yaml:"docker_images"`
+	DockerNetworks  []DockerNetworkTest   `yaml:"docker_networks"`
+	DockerVolumes   []DockerVolumeTest    `yaml:"docker_volumes"`
+	DockerCompose   []DockerComposeTest   `yaml:"docker_compose"`
+	Filesystems     []FilesystemTest      `yaml:"filesystems"`
+	Ping            []PingTest            `yaml:"ping"`
+	DNS             []DNSTest             `yaml:"dns"`
+	SystemInfo      []SystemInfoTest      `yaml:"systeminfo"`
+	HTTP            []HTTPTest            `yaml:"http"`
+	Ports           []PortTest            `yaml:"ports"`
+	ServiceRegistry []ServiceRegistryTest `yaml:"service_registry"`
+	Vault           []VaultTest           `yaml:"vault"`
+	MessageQueues   []MessageQueueTest    `yaml:"message_queues"`
+	Capabilities    []CapabilitiesTest    `yaml:"capabilities"`
+	Repositories    []RepositoryTest      `yaml:"repositories"`
+	SystemdProps    []SystemdPropertyTest `yaml:"systemd_properties"`
+	SSHConfig       []SSHConfigTest       `yaml:"sshd_config"`
+	LogRotate       []LogRotateTest       `yaml:"logrotate"`
+	Cron            []CronTest            `yaml:"cron"`
+	Processes       []ProcessTest         `yaml:"processes"`
+	Metrics         []MetricTest          `yaml:"metrics"`
+	CommandJSON     []CommandJSONTest     `yaml:"command_json"`
+	Kubernetes      KubernetesTests       `yaml:"kubernetes"`
+	OpenStack       OpenStackTests        `yaml:"openstack"`
+	GCP             GCPTests              `yaml:"gcp"`
+	Composite       []CompositeTest       `yaml:"composite"`
+}
+
+// CompositeTest groups inline sub-tests and combines their results.
+// Exactly one of AnyOf or AllOf must be set.
+type CompositeTest struct {
+	Name  string `yaml:"name"`
+	AnyOf *Tests `yaml:"any_of,omitempty"` // Passes if any sub-test passes
+	AllOf *Tests `yaml:"all_of,omitempty"` // Passes if all sub-tests pass
+
+	TestOptions `yaml:",inline"`
 }
 
 // PackageTest represents a package installation test
@@ -60,17 +128,37 @@ type PackageTest struct {
 	Packages []string `yaml:"packages"`
 	State    string   `yaml:"state"` // present, absent
 	Version  string   `yaml:"version,omitempty"`
+
+	TestOptions `yaml:",inline"`
+}
+
+// RepositoryTest represents a package repository configuration test
+type RepositoryTest struct {
+	Name    string `yaml:"name"`
+	Manager string `yaml:"manager"`           // apt, yum or dnf
+	Repo    string `yaml:"repo"`              // apt: repository URL; yum/dnf: repo id or base URL
+	State   string `yaml:"state"`             // present, absent
+	GPGKey  string `yaml:"gpg_key,omitempty"` // Absolute path to the repository's signing key on the target
+
+	TestOptions `yaml:",inline"`
 }
 
 // FileTest represents a file/directory test
 type FileTest struct {
-	Name      string `yaml:"name"`
-	Path      string `yaml:"path"`
-	Type      string `yaml:"type"` // file, directory
-	Owner     string `yaml:"owner,omitempty"`
-	Group     string `yaml:"group,omitempty"`
-	Mode      string `yaml:"mode,omitempty"`
-	Recursive bool   `yaml:"recursive,omitempty"`
+	Name          string `yaml:"name"`
+	Path          string `yaml:"path"`
+	Type          string `yaml:"type"` // file, directory
+	Owner         string `yaml:"owner,omitempty"`
+	Group         string `yaml:"group,omitempty"`
+	Mode          string `yaml:"mode,omitempty"`
+	Recursive     bool   `yaml:"recursive,omitempty"`
+	MaxAgeSeconds int    `yaml:"max_age_seconds,omitempty"` // fail if modified longer ago than this
+	MinAgeSeconds int    `yaml:"min_age_seconds,omitempty"` // fail if modified more recently than this
+	MinEntries    int    `yaml:"min_entries,omitempty"`     // directory: minimum number of entries
+	MaxEntries    int    `yaml:"max_entries,omitempty"`     // directory: maximum number of entries
+	Empty         *bool  `yaml:"empty,omitempty"`           // directory: must (true) or must not (false) be empty
+
+	TestOptions `yaml:",inline"`
 }
 
 // ServiceTest represents a service status test
@@ -80,14 +168,59 @@ type ServiceTest struct {
 	Services []string `yaml:"services,omitempty"`
 	State    string   `yaml:"state"`   // running, stopped
 	Enabled  bool     `yaml:"enabled"` // should be enabled on boot
+
+	TestOptions `yaml:",inline"`
+}
+
+// SystemdPropertyTest represents a systemd unit property test
+type SystemdPropertyTest struct {
+	Name       string            `yaml:"name"`
+	Unit       string            `yaml:"unit"`       // Unit name, e.g. nginx.service
+	Properties map[string]string `yaml:"properties"` // Expected values as shown by systemctl show, e.g. Restart: always
+
+	TestOptions `yaml:",inline"`
+}
+
+// SSHConfigTest represents an effective sshd configuration test (sshd -T)
+type SSHConfigTest struct {
+	Name    string `yaml:"name"`
+	Setting string `yaml:"setting"` // sshd keyword, e.g. PermitRootLogin (case-insensitive)
+	Value   string `yaml:"value"`   // Expected value, e.g. no
+
+	TestOptions `yaml:",inline"`
 }
 
 // CommandContentTest represents a command output test
 type CommandContentTest struct {
-	Name     string   `yaml:"name"`
-	Command  string   `yaml:"command"`
-	Contains []string `yaml:"contains,omitempty"`
-	ExitCode int      `yaml:"exit_code,omitempty"`
+	Name        string   `yaml:"name"`
+	Command     string   `yaml:"command"`
+	Contains    []string `yaml:"contains,omitempty"`
+	ExitCode    *int     `yaml:"exit_code,omitempty"`     // Exact exit code (nil: not checked)
+	ExitCodeIn  []int    `yaml:"exit_code_in,omitempty"`  // Exit code must be one of these
+	ExitCodeNot []int    `yaml:"exit_code_not,omitempty"` // Exit code must not be any of these
+	RunAs       string   `yaml:"run_as,omitempty"`        // Run the command as this user via sudo -u
+
+	TestOptions `yaml:",inline"`
+}
+
+// CommandJSONTest represents a test on fields of a command's JSON output
+type CommandJSONTest struct {
+	Name    string            `yaml:"name"`
+	Command string            `yaml:"command"`
+	JSON    map[string]string `yaml:"json"`             // JSONPath expression -> expected value, e.g. $.ServerVersion: "24.0.7"
+	RunAs   string            `yaml:"run_as,omitempty"` // Run the command as this user via sudo -u
+
+	TestOptions `yaml:",inline"`
+}
+
+// MetricTest represents a numeric threshold test on a command's output
+type MetricTest struct {
+	Name      string  `yaml:"name"`
+	Command   string  `yaml:"command"`   // Command that prints a single number
+	Operator  string  `yaml:"operator"`  // gt, lt, gte, lte, eq, ne
+	Threshold float64 `yaml:"threshold"` // Value the output is compared with
+
+	TestOptions `yaml:",inline"`
 }
 
 // UserTest represents a user test
@@ -97,6 +230,8 @@ type UserTest struct {
 	Groups []string `yaml:"groups,omitempty"`
 	Shell  string   `yaml:"shell,omitempty"`
 	Home   string   `yaml:"home,omitempty"`
+
+	TestOptions `yaml:",inline"`
 }
 
 // GroupTest represents a group test
@@ -104,25 +239,109 @@ type GroupTest struct {
 	Name   string   `yaml:"name"`
 	Groups []string `yaml:"groups"`
 	State  string   `yaml:"state"` // present, absent
+
+	TestOptions `yaml:",inline"`
+}
+
+// LogRotateTest represents a log file size and rotation test
+type LogRotateTest struct {
+	Name          string `yaml:"name"`
+	Path          string `yaml:"path"`                     // Absolute path to the log file
+	MaxSizeBytes  string `yaml:"max_size,omitempty"`       // Maximum file size, e.g. 500M or 1Gi
+	ConfigPresent bool   `yaml:"config_present,omitempty"` // Require a logrotate config covering the file
+
+	TestOptions `yaml:",inline"`
+}
+
+// CronTest represents a scheduled job test against crontab entries
+type CronTest struct {
+	Name     string `yaml:"name"`
+	User     string `yaml:"user,omitempty"`     // Check this user's crontab; empty checks /etc/crontab and /etc/cron.d
+	Command  string `yaml:"command,omitempty"`  // Substring of the entry's command
+	Schedule string `yaml:"schedule,omitempty"` // Schedule of the entry, e.g. "0 2 * * *" or @daily
+	State    string `yaml:"state"`              // present, absent
+
+	TestOptions `yaml:",inline"`
+}
+
+// ProcessTest represents a running process count test
+type ProcessTest struct {
+	Name     string `yaml:"name"`
+	Process  string `yaml:"process"`             // Regular expression matched against process command lines
+	State    string `yaml:"state"`               // running, absent
+	MinCount int    `yaml:"min_count,omitempty"` // Fewest matching processes when running (default: 1)
+	MaxCount int    `yaml:"max_count,omitempty"` // Most matching processes when running (0 = no limit)
+	User     string `yaml:"user,omitempty"`      // Only count processes running as this user
+
+	TestOptions `yaml:",inline"`
 }
 
 // FileContentTest represents a file content test
 type FileContentTest struct {
-	Name     string   `yaml:"name"`
-	Path     string   `yaml:"path"`
-	Contains []string `yaml:"contains,omitempty"` // strings that must be present
-	Matches  string   `yaml:"matches,omitempty"`  // regex pattern to match
+	Name      string   `yaml:"name"`
+	Path      string   `yaml:"path"`
+	Contains  []string `yaml:"contains,omitempty"`  // strings that must be present
+	Matches   string   `yaml:"matches,omitempty"`   // regex pattern to match
+	Sensitive bool     `yaml:"sensitive,omitempty"` // matching lines are redacted from result attachments
+
+	TestOptions `yaml:",inline"`
 }
 
 // DockerTest represents a Docker container test
 type DockerTest struct {
-	Name          string   `yaml:"name"`
-	Container     string   `yaml:"container,omitempty"`
-	Containers    []string `yaml:"containers,omitempty"`
-	State         string   `yaml:"state"`          // running, stopped, exists
-	Image         string   `yaml:"image,omitempty"`
-	RestartPolicy string   `yaml:"restart_policy,omitempty"` // no, always, on-failure, unless-stopped
-	Health        string   `yaml:"health,omitempty"`         // healthy, unhealthy, starting, none
+	Name             string   `yaml:"name"`
+	Container        string   `yaml:"container,omitempty"`
+	Containers       []string `yaml:"containers,omitempty"`
+	State            string   `yaml:"state"` // running, stopped, exists
+	Image            string   `yaml:"image,omitempty"`
+	RestartPolicy    string   `yaml:"restart_policy,omitempty"`     // no, always, on-failure, unless-stopped
+	Health           string   `yaml:"health,omitempty"`             // healthy, unhealthy, starting, none
+	MemoryLimit      string   `yaml:"memory_limit,omitempty"`       // Maximum memory limit, e.g. 512Mi or 1G
+	CPUShares        int      `yaml:"cpu_shares,omitempty"`         // Expected relative CPU weight (docker default 1024)
+	MinUptimeSeconds int      `yaml:"min_uptime_seconds,omitempty"` // Minimum seconds since the container last started
+	MaxRestarts      *int     `yaml:"max_restarts,omitempty"`       // Maximum restart count (nil: not checked)
+
+	TestOptions `yaml:",inline"`
+}
+
+// DockerImageTest represents a Docker image presence test
+type DockerImageTest struct {
+	Name   string `yaml:"name"`
+	Image  string `yaml:"image"`            // Image reference, e.g. nginx:1.25 or registry.example.com/app:v2
+	State  string `yaml:"state"`            // present, absent
+	Digest string `yaml:"digest,omitempty"` // Expected sha256 digest (repo digest or image ID)
+
+	TestOptions `yaml:",inline"`
+}
+
+// DockerNetworkTest represents a Docker network test
+type DockerNetworkTest struct {
+	Name    string `yaml:"name"`
+	Network string `yaml:"network"`
+	Driver  string `yaml:"driver,omitempty"` // e.g. bridge, overlay, macvlan
+	State   string `yaml:"state"`            // present, absent
+
+	TestOptions `yaml:",inline"`
+}
+
+// DockerVolumeTest represents a Docker volume test
+type DockerVolumeTest struct {
+	Name   string `yaml:"name"`
+	Volume string `yaml:"volume"`
+	Driver string `yaml:"driver,omitempty"` // e.g. local
+	State  string `yaml:"state"`            // present, absent
+
+	TestOptions `yaml:",inline"`
+}
+
+// DockerComposeTest represents a Docker Compose project health test
+type DockerComposeTest struct {
+	Name             string   `yaml:"name"`
+	Project          string   `yaml:"project"`
+	AllServicesUp    bool     `yaml:"all_services_up,omitempty"` // Every service in the project must be up
+	ExpectedServices []string `yaml:"services,omitempty"`        // These services must exist and be up
+
+	TestOptions `yaml:",inline"`
 }
 
 // FilesystemTest represents a filesystem/mount point test
@@ -134,18 +353,24 @@ type FilesystemTest struct {
 	Options         []string `yaml:"options,omitempty"`          // rw, ro, noexec, nosuid, etc.
 	MinSizeGB       int      `yaml:"min_size_gb,omitempty"`      // minimum size in GB
 	MaxUsagePercent int      `yaml:"max_usage_percent,omitempty"` // maximum usage percentage
+
+	TestOptions `yaml:",inline"`
 }
 
 // PingTest represents a network reachability test
 type PingTest struct {
 	Name string `yaml:"name"`
 	Host string `yaml:"host"`
+
+	TestOptions `yaml:",inline"`
 }
 
 // DNSTest represents a DNS resolution test
 type DNSTest struct {
 	Name string `yaml:"name"`
 	Host string `yaml:"host"`
+
+	TestOptions `yaml:",inline"`
 }
 
 // SystemInfoTest represents a system information validation test
@@ -158,6 +383,8 @@ type SystemInfoTest struct {
 	Hostname       string `yaml:"hostname,omitempty"`        // short hostname
 	FQDN           string `yaml:"fqdn,omitempty"`            // fully qualified domain name
 	VersionMatch   string `yaml:"version_match,omitempty"`   // "exact" or "prefix" (default: exact)
+
+	TestOptions `yaml:",inline"`
 }
 
 // HTTPTest represents an HTTP endpoint test
@@ -169,38 +396,125 @@ type HTTPTest struct {
 	Method          string   `yaml:"method,omitempty"`           // HTTP method (default: GET)
 	Insecure        bool     `yaml:"insecure,omitempty"`         // skip TLS verification (default: false)
 	FollowRedirects bool     `yaml:"follow_redirects,omitempty"` // follow HTTP redirects (default: false)
+	Proxy           string   `yaml:"proxy,omitempty"`            // proxy URL (default: target's proxy environment)
+	NoProxy         []string `yaml:"no_proxy,omitempty"`         // hosts/domains that bypass the proxy
+
+	Headers map[string]string `yaml:"headers,omitempty"` // request headers; credential headers are redacted from output
+	Body    string            `yaml:"body,omitempty"`    // request body (POST, PUT and PATCH only)
+
+	JSONPath map[string]string `yaml:"json_path,omitempty"` // JSONPath expression -> expected value, evaluated against the JSON response body
+
+	TestOptions `yaml:",inline"`
 }
 
 // PortTest represents a port/socket listening test
 type PortTest struct {
 	Name     string `yaml:"name"`
 	Port     int    `yaml:"port"`
-	Protocol string `yaml:"protocol,omitempty"` // tcp or udp (default: tcp)
-	State    string `yaml:"state,omitempty"`    // listening or closed (default: listening)
+	Protocol string `yaml:"protocol,omitempty"`       // tcp or udp (default: tcp)
+	State    string `yaml:"state,omitempty"`          // listening or closed (default: listening)
+	Address  string `yaml:"listen_address,omitempty"` // Only count listeners bound to this IP, e.g. 127.0.0.1 or ::
+
+	TestOptions `yaml:",inline"`
+}
+
+// ServiceRegistryTest represents a service registration test against Consul or etcd
+type ServiceRegistryTest struct {
+	Name       string `yaml:"name"`
+	Backend    string `yaml:"backend"`               // consul or etcd
+	Service    string `yaml:"service"`               // Service name
+	MinHealthy int    `yaml:"min_healthy,omitempty"` // Minimum healthy instances (default: 1)
+	Address    string `yaml:"address,omitempty"`     // Registry HTTP API URL (default: http://127.0.0.1:8500 for consul, http://127.0.0.1:2379 for etcd)
+
+	TestOptions `yaml:",inline"`
+}
+
+// VaultTest represents a Vault health test against /v1/sys/health
+type VaultTest struct {
+	Name              string `yaml:"name"`
+	Address           string `yaml:"address"`                      // Vault URL, e.g. https://vault.internal:8200
+	ExpectUnsealed    bool   `yaml:"expect_unsealed,omitempty"`    // Fail if Vault is sealed
+	ExpectInitialized bool   `yaml:"expect_initialized,omitempty"` // Fail if Vault is not initialized
+	ExpectActive      bool   `yaml:"expect_active,omitempty"`      // Fail if the node is a standby
+	Insecure          bool   `yaml:"insecure,omitempty"`           // skip TLS verification (default: false)
+	CACert            string `yaml:"ca_cert,omitempty"`            // CA bundle path on the target
+
+	TestOptions `yaml:",inline"`
+}
+
+// MessageQueueTest represents a Kafka or RabbitMQ reachability test
+type MessageQueueTest struct {
+	Name          string `yaml:"name"`
+	Backend       string `yaml:"backend"`                  // kafka or rabbitmq
+	Address       string `yaml:"address"`                  // Kafka bootstrap broker (host:port) or RabbitMQ management API URL
+	Topic         string `yaml:"topic"`                    // Kafka topic or RabbitMQ queue
+	MinPartitions int    `yaml:"min_partitions,omitempty"` // Kafka: minimum partition count
+	MinConsumers  int    `yaml:"min_consumers,omitempty"`  // RabbitMQ: minimum consumer count
+	VHost         string `yaml:"vhost,omitempty"`          // RabbitMQ: virtual host (default: "/")
+	Username      string `yaml:"username,omitempty"`       // RabbitMQ: management API user (default: "guest")
+	Password      string `yaml:"password,omitempty"`       // RabbitMQ: management API password (default: "guest")
+
+	TestOptions `yaml:",inline"`
+}
+
+// CapabilitiesTest represents a Linux file capabilities test
+type CapabilitiesTest struct {
+	Name     string   `yaml:"name"`
+	Path     string   `yaml:"path"`     // Absolute path to the binary
+	Expected []string `yaml:"expected"` // Exact capability set, e.g. cap_net_bind_service+ep (empty: no capabilities)
+
+	TestOptions `yaml:",inline"`
 }
 
 // Kubernetes test types
 
+// KubernetesCluster overrides the provider's kubeconfig and context for one
+// test, so a spec can check several clusters
+type KubernetesCluster struct {
+	Kubeconfig string `yaml:"kubeconfig,omitempty"` // Absolute path to a kubeconfig file
+	Context    string `yaml:"context,omitempty"`    // Context in the kubeconfig
+}
+
+// validate checks the override's kubeconfig path and context name
+func (c KubernetesCluster) validate() error {
+	if c.Kubeconfig != "" && !filepath.IsAbs(c.Kubeconfig) {
+		return fmt.Errorf("kubeconfig must be an absolute path, got '%s'", c.Kubeconfig)
+	}
+	if strings.ContainsAny(c.Context, " \t\n") {
+		return fmt.Errorf("context '%s' must not contain whitespace", c.Context)
+	}
+	return nil
+}
+
 // KubernetesPodTest represents a Kubernetes pod test
 type KubernetesPodTest struct {
-	Name      string            `yaml:"name"`
-	Pod       string            `yaml:"pod"`
-	Namespace string            `yaml:"namespace,omitempty"`
-	State     string            `yaml:"state,omitempty"`  // running, pending, succeeded, failed, exists
-	Ready     bool              `yaml:"ready,omitempty"`  // all containers ready
-	Image     string            `yaml:"image,omitempty"`  // container image contains match
-	Labels    map[string]string `yaml:"labels,omitempty"` // validate labels
+	Name        string            `yaml:"name"`
+	Pod         string            `yaml:"pod"`
+	Namespace   string            `yaml:"namespace,omitempty"`
+	State       string            `yaml:"state,omitempty"`        // running, pending, succeeded, failed, exists
+	Ready       bool              `yaml:"ready,omitempty"`        // all containers ready
+	Image       string            `yaml:"image,omitempty"`        // container image contains match
+	ImageDigest string            `yaml:"image_digest,omitempty"` // sha256 digest a container (matching image, if set) runs
+	Labels      map[string]string `yaml:"labels,omitempty"`       // validate labels
+
+	KubernetesCluster `yaml:",inline"`
+	TestOptions       `yaml:",inline"`
 }
 
 // KubernetesDeploymentTest represents a Kubernetes deployment test
 type KubernetesDeploymentTest struct {
-	Name          string `yaml:"name"`
-	Deployment    string `yaml:"deployment"`
-	Namespace     string `yaml:"namespace,omitempty"`
-	State         string `yaml:"state,omitempty"`          // available, progressing, exists
-	Replicas      int    `yaml:"replicas,omitempty"`       // desired replicas
-	ReadyReplicas int    `yaml:"ready_replicas,omitempty"` // ready replicas
-	Image         string `yaml:"image,omitempty"`          // container image contains match
+	Name           string        `yaml:"name"`
+	Deployment     string        `yaml:"deployment"`
+	Namespace      string        `yaml:"namespace,omitempty"`
+	State          string        `yaml:"state,omitempty"`            // available, progressing, exists
+	Replicas       int           `yaml:"replicas,omitempty"`         // desired replicas
+	ReadyReplicas  int           `yaml:"ready_replicas,omitempty"`   // ready replicas
+	Image          string        `yaml:"image,omitempty"`            // container image contains match
+	WaitForRollout bool          `yaml:"wait_for_rollout,omitempty"` // Poll until the rollout completes, like kubectl rollout status
+	RolloutTimeout time.Duration `yaml:"rollout_timeout,omitempty"`  // How long to wait for the rollout (default: 5m)
+
+	KubernetesCluster `yaml:",inline"`
+	TestOptions       `yaml:",inline"`
 }
 
 // KubernetesServiceTest represents a Kubernetes service test
@@ -211,6 +525,9 @@ type KubernetesServiceTest struct {
 	Type      string                    `yaml:"type,omitempty"`     // ClusterIP, NodePort, LoadBalancer, ExternalName
 	Ports     []KubernetesServicePort   `yaml:"ports,omitempty"`    // validate ports
 	Selector  map[string]string         `yaml:"selector,omitempty"` // validate selector labels
+
+	KubernetesCluster `yaml:",inline"`
+	TestOptions       `yaml:",inline"`
 }
 
 // KubernetesServicePort represents a port in a Kubernetes service
@@ -226,6 +543,9 @@ type KubernetesConfigMapTest struct {
 	Namespace string   `yaml:"namespace,omitempty"`
 	State     string   `yaml:"state,omitempty"`    // present, absent
 	HasKeys   []string `yaml:"has_keys,omitempty"` // keys that must exist in data
+
+	KubernetesCluster `yaml:",inline"`
+	TestOptions       `yaml:",inline"`
 }
 
 // KubernetesNamespaceTest represents a Kubernetes namespace test
@@ -234,6 +554,9 @@ type KubernetesNamespaceTest struct {
 	Namespace string            `yaml:"namespace"`
 	State     string            `yaml:"state,omitempty"`  // present, absent
 	Labels    map[string]string `yaml:"labels,omitempty"` // validate labels
+
+	KubernetesCluster `yaml:",inline"`
+	TestOptions       `yaml:",inline"`
 }
 
 // KubernetesNodeTest represents a Kubernetes node test
@@ -244,6 +567,9 @@ type KubernetesNodeTest struct {
 	MinReady   int               `yaml:"min_ready,omitempty"`   // Minimum ready nodes
 	MinVersion string            `yaml:"min_version,omitempty"` // Minimum kubelet version (e.g., "v1.28.0")
 	Labels     map[string]string `yaml:"labels,omitempty"`      // Label selector for filtering nodes
+
+	KubernetesCluster `yaml:",inline"`
+	TestOptions       `yaml:",inline"`
 }
 
 // KubernetesCRDTest represents a Kubernetes CustomResourceDefinition test
@@ -251,6 +577,9 @@ type KubernetesCRDTest struct {
 	Name  string `yaml:"name"`
 	CRD   string `yaml:"crd"`                 // CRD name (e.g., "certificates.cert-manager.io")
 	State string `yaml:"state,omitempty"`     // present, absent
+
+	KubernetesCluster `yaml:",inline"`
+	TestOptions       `yaml:",inline"`
 }
 
 // KubernetesHelmTest represents a Kubernetes Helm release test
@@ -260,6 +589,9 @@ type KubernetesHelmTest struct {
 	Namespace     string `yaml:"namespace,omitempty"`         // Namespace where release is installed
 	State         string `yaml:"state,omitempty"`             // deployed, failed, pending-install, pending-upgrade, etc.
 	AllPodsReady  bool   `yaml:"all_pods_ready,omitempty"`    // Check all pods from release are ready
+
+	KubernetesCluster `yaml:",inline"`
+	TestOptions       `yaml:",inline"`
 }
 
 // KubernetesStorageClassTest represents a Kubernetes StorageClass test
@@ -267,6 +599,9 @@ type KubernetesStorageClassTest struct {
 	Name         string `yaml:"name"`
 	StorageClass string `yaml:"storageclass"`        // StorageClass name (e.g., "fast-ssd", "standard")
 	State        string `yaml:"state,omitempty"`     // present, absent
+
+	KubernetesCluster `yaml:",inline"`
+	TestOptions       `yaml:",inline"`
 }
 
 // KubernetesSecretTest represents a Kubernetes Secret test
@@ -277,6 +612,9 @@ type KubernetesSecretTest struct {
 	State     string   `yaml:"state,omitempty"`     // present, absent (default: "present")
 	Type      string   `yaml:"type,omitempty"`      // Secret type (Opaque, kubernetes.io/tls, etc.)
 	HasKeys   []string `yaml:"has_keys,omitempty"`  // Keys that must exist in data
+
+	KubernetesCluster `yaml:",inline"`
+	TestOptions       `yaml:",inline"`
 }
 
 // KubernetesIngressTest represents a Kubernetes Ingress test
@@ -288,6 +626,9 @@ type KubernetesIngressTest struct {
 	Hosts        []string `yaml:"hosts,omitempty"`        // Expected hosts
 	TLS          bool     `yaml:"tls,omitempty"`          // Check if TLS is configured
 	IngressClass string   `yaml:"ingress_class,omitempty"` // Expected ingress class
+
+	KubernetesCluster `yaml:",inline"`
+	TestOptions       `yaml:",inline"`
 }
 
 // KubernetesPVCTest represents a Kubernetes PersistentVolumeClaim test
@@ -299,6 +640,9 @@ type KubernetesPVCTest struct {
 	Status       string `yaml:"status,omitempty"`       // Bound, Pending, Lost (default: not checked)
 	StorageClass string `yaml:"storage_class,omitempty"` // Expected storage class
 	MinCapacity  string `yaml:"min_capacity,omitempty"`  // Minimum capacity (e.g., "100Gi")
+
+	KubernetesCluster `yaml:",inline"`
+	TestOptions       `yaml:",inline"`
 }
 
 // KubernetesStatefulSetTest represents a Kubernetes StatefulSet test
@@ -309,6 +653,9 @@ type KubernetesStatefulSetTest struct {
 	State         string `yaml:"state,omitempty"`       // available, exists (default: "available")
 	Replicas      int    `yaml:"replicas,omitempty"`    // Exact replica count
 	ReadyReplicas int    `yaml:"ready_replicas,omitempty"` // Exact ready replica count
+
+	KubernetesCluster `yaml:",inline"`
+	TestOptions       `yaml:",inline"`
 }
 
 // KubernetesTests groups all Kubernetes test types
@@ -328,15 +675,96 @@ type KubernetesTests struct {
 	StatefulSets   []KubernetesStatefulSetTest   `yaml:"statefulsets"`
 }
 
+// OpenStack test types
+
+// OpenStackInstanceTest represents a Nova instance test
+type OpenStackInstanceTest struct {
+	Name     string `yaml:"name"`
+	Instance string `yaml:"instance"`         // Instance name or ID
+	State    string `yaml:"state,omitempty"`  // present, absent (default: "present")
+	Status   string `yaml:"status,omitempty"` // Nova status, e.g. ACTIVE, SHUTOFF (default: "ACTIVE" when present)
+
+	TestOptions `yaml:",inline"`
+}
+
+// OpenStackVolumeTest represents a Cinder volume test
+type OpenStackVolumeTest struct {
+	Name       string `yaml:"name"`
+	Volume     string `yaml:"volume"`                // Volume name or ID
+	State      string `yaml:"state,omitempty"`       // present, absent (default: "present")
+	Status     string `yaml:"status,omitempty"`      // Cinder status, e.g. available, in-use
+	SizeGB     int    `yaml:"size_gb,omitempty"`     // Exact size in GB
+	AttachedTo string `yaml:"attached_to,omitempty"` // Instance name or ID the volume must be attached to
+
+	TestOptions `yaml:",inline"`
+}
+
+// OpenStackFloatingIPTest represents a floating IP association test
+type OpenStackFloatingIPTest struct {
+	Name       string `yaml:"name"`
+	FloatingIP string `yaml:"floating_ip"` // Floating IP address
+	Instance   string `yaml:"instance"`    // Instance name or ID the address must be associated with
+
+	TestOptions `yaml:",inline"`
+}
+
+// OpenStackTests groups all OpenStack test types
+type OpenStackTests struct {
+	Instances   []OpenStackInstanceTest   `yaml:"instances"`
+	Volumes     []OpenStackVolumeTest     `yaml:"volumes"`
+	FloatingIPs []OpenStackFloatingIPTest `yaml:"floating_ips"`
+}
+
+// GCP test types
+
+// GCPInstanceTest represents a GCE instance test
+type GCPInstanceTest struct {
+	Name        string            `yaml:"name"`
+	Instance    string            `yaml:"instance"`               // Instance name
+	Zone        string            `yaml:"zone"`                   // Zone, e.g. us-central1-a
+	Project     string            `yaml:"project,omitempty"`      // Project ID (default: --project)
+	State       string            `yaml:"state,omitempty"`        // present, absent (default: "present")
+	Status      string            `yaml:"status,omitempty"`       // GCE status, e.g. RUNNING, TERMINATED (default: "RUNNING" when present)
+	MachineType string            `yaml:"machine_type,omitempty"` // Machine type, e.g. e2-medium
+	Labels      map[string]string `yaml:"labels,omitempty"`       // Labels that must be set with these values
+
+	TestOptions `yaml:",inline"`
+}
+
+// GCPBucketTest represents a GCS bucket test
+type GCPBucketTest struct {
+	Name     string `yaml:"name"`
+	Bucket   string `yaml:"bucket"`             // Bucket name
+	State    string `yaml:"state,omitempty"`    // present, absent (default: "present")
+	Location string `yaml:"location,omitempty"` // Bucket location, e.g. US, EUROPE-WEST1 (case-insensitive)
+
+	TestOptions `yaml:",inline"`
+}
+
+// GCPTests groups all GCP test types
+type GCPTests struct {
+	Instances []GCPInstanceTest `yaml:"instances"`
+	Buckets   []GCPBucketTest   `yaml:"buckets"`
+}
+
 // ParseSpec parses a YAML spec file and processes imports
 func ParseSpec(path string) (*Spec, error) {
+	return ParseSpecWithVariables(path, nil)
+}
+
+// ParseSpecWithVariables parses a YAML spec file and processes imports,
+// resolving ${var.name} placeholders from vars and the spec's variables
+// section, and ${env.NAME} placeholders from the environment. vars take
+// precedence over the spec's variables.
+func ParseSpecWithVariables(path string, vars map[string]string) (*Spec, error) {
 	// Use an empty visited set for the initial call
 	visited := make(map[string]bool)
-	return parseSpecWithImports(path, visited)
+	return parseSpecWithImports(path, visited, variableNodes(vars))
 }
 
-// parseSpecWithImports recursively parses a spec file and its imports
-func parseSpecWithImports(path string, visited map[string]bool) (*Spec, error) {
+// parseSpecWithImports recursively parses a spec file and its imports.
+// Imported files see the variables of the files importing them.
+func parseSpecWithImports(path string, visited map[string]bool, inherited map[string]*yaml.Node) (*Spec, error) {
 	// Clean the path to prevent directory traversal attacks (CWE-22)
 	cleanPath := filepath.Clean(path)
 
@@ -355,7 +783,7 @@ func parseSpecWithImports(path string, visited map[string]bool) (*Spec, error) {
 	visited[absPath] = true
 
 	// Parse the spec file (without processing imports yet)
-	spec, err := parseSpecFile(cleanPath)
+	spec, vars, err := parseSpecFile(cleanPath, inherited)
 	if err != nil {
 		return nil, err
 	}
@@ -375,7 +803,7 @@ func parseSpecWithImports(path string, visited map[string]bool) (*Spec, error) {
 			}
 
 			// Recursively parse the imported spec
-			importedSpec, err := parseSpecWithImports(resolvedPath, visited)
+			importedSpec, err := parseSpecWithImports(resolvedPath, visited, vars)
 			if err != nil {
 				return nil, fmt.Errorf("failed to import %s: %w", importPath, err)
 			}
@@ -391,15 +819,16 @@ func parseSpecWithImports(path string, visited map[string]bool) (*Spec, error) {
 	return spec, nil
 }
 
-// parseSpecFile parses a single YAML spec file without processing imports
-func parseSpecFile(path string) (*Spec, error) {
+// parseSpecFile parses a single YAML spec file without processing imports,
+// and returns the variables its imports can reference
+func parseSpecFile(path string, inherited map[string]*yaml.Node) (*Spec, map[string]*yaml.Node, error) {
 	// Clean the path to prevent directory traversal attacks (CWE-22)
 	cleanPath := filepath.Clean(path)
 
 	// Validate that the file has a YAML extension
 	ext := strings.ToLower(filepath.Ext(cleanPath))
 	if ext != ".yaml" && ext != ".yml" {
-		return nil, fmt.Errorf("spec file must have .yaml or .yml extension, got: %s", ext)
+		return nil, nil, fmt.Errorf("spec file must have .yaml or .yml extension, got: %s", ext)
 	}
 
 	// Read the spec file
@@ -407,25 +836,48 @@ func parseSpecFile(path string) (*Spec, error) {
 	// CLI tool with their own permissions to read their own spec files.
 	data, err := os.ReadFile(cleanPath)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read spec file: %w", err)
+		return nil, nil, fmt.Errorf("failed to read spec file: %w", err)
+	}
+
+	// Matrix tests are expanded on the YAML tree, before decoding, so
+	// placeholders can stand in for non-string fields such as ports
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, nil, enhanceYAMLError(err, cleanPath)
+	}
+	// Variables are substituted first, so they can appear in matrix values
+	vars, err := fileVariables(&doc, inherited)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid variable in %s: %w", cleanPath, err)
+	}
+	usedVariables, err := substituteVariables(&doc, vars)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid variable in %s: %w", cleanPath, err)
+	}
+	if err := expandMatrices(&doc); err != nil {
+		return nil, nil, fmt.Errorf("invalid matrix in %s: %w", cleanPath, err)
 	}
 
 	var spec Spec
-	if err := yaml.Unmarshal(data, &spec); err != nil {
-		// Check for common YAML structure errors and provide helpful messages
-		errMsg := err.Error()
-		if strings.Contains(errMsg, "cannot unmarshal !!seq into core.Tests") {
-			return nil, fmt.Errorf("invalid spec format: 'tests' should contain test types (packages, files, services, etc.), not a list.\n\nExample correct format:\ntests:\n  packages:\n    - name: \"test name\"\n      packages:\n        - package-name\n\nSee examples/ directory for reference")
+	if len(doc.Content) > 0 {
+		if err := doc.Decode(&spec); err != nil {
+			// Check for common YAML structure errors and provide helpful messages
+			errMsg := err.Error()
+			if strings.Contains(errMsg, "cannot unmarshal !!seq into core.Tests") {
+				return nil, nil, fmt.Errorf("invalid spec format: 'tests' should contain test types (packages, files, services, etc.), not a list.\n\nExample correct format:\ntests:\n  packages:\n    - name: \"test name\"\n      packages:\n        - package-name\n\nSee examples/ directory for reference")
+			}
+			// Enhance other YAML errors with helpful context
+			return nil, nil, enhanceYAMLError(err, cleanPath)
 		}
-		// Enhance other YAML errors with helpful context
-		return nil, enhanceYAMLError(err, cleanPath)
 	}
 
 	if err := spec.Validate(); err != nil {
-		return nil, fmt.Errorf("spec validation failed: %w", err)
+		return nil, nil, fmt.Errorf("spec validation failed: %w", err)
 	}
+	spec.declared = parseDeclaredOrder(&doc)
+	spec.usedVariables = usedVariables
 
-	return &spec, nil
+	return &spec, vars, nil
 }
 
 // mergeSpecs merges imported specs into the main spec
@@ -476,12 +928,29 @@ func mergeSpecs(mainSpec *Spec, importedSpecs []*Spec) *Spec {
 		merged.Tests.FileContent = append(merged.Tests.FileContent, imported.Tests.FileContent...)
 		merged.Tests.CommandContent = append(merged.Tests.CommandContent, imported.Tests.CommandContent...)
 		merged.Tests.Docker = append(merged.Tests.Docker, imported.Tests.Docker...)
+		merged.Tests.DockerImages = append(merged.Tests.DockerImages, imported.Tests.DockerImages...)
+		merged.Tests.DockerNetworks = append(merged.Tests.DockerNetworks, imported.Tests.DockerNetworks...)
+		merged.Tests.DockerVolumes = append(merged.Tests.DockerVolumes, imported.Tests.DockerVolumes...)
+		merged.Tests.DockerCompose = append(merged.Tests.DockerCompose, imported.Tests.DockerCompose...)
 		merged.Tests.Filesystems = append(merged.Tests.Filesystems, imported.Tests.Filesystems...)
 		merged.Tests.Ping = append(merged.Tests.Ping, imported.Tests.Ping...)
 		merged.Tests.DNS = append(merged.Tests.DNS, imported.Tests.DNS...)
 		merged.Tests.SystemInfo = append(merged.Tests.SystemInfo, imported.Tests.SystemInfo...)
 		merged.Tests.HTTP = append(merged.Tests.HTTP, imported.Tests.HTTP...)
 		merged.Tests.Ports = append(merged.Tests.Ports, imported.Tests.Ports...)
+		merged.Tests.ServiceRegistry = append(merged.Tests.ServiceRegistry, imported.Tests.ServiceRegistry...)
+		merged.Tests.Vault = append(merged.Tests.Vault, imported.Tests.Vault...)
+		merged.Tests.MessageQueues = append(merged.Tests.MessageQueues, imported.Tests.MessageQueues...)
+		merged.Tests.Capabilities = append(merged.Tests.Capabilities, imported.Tests.Capabilities...)
+		merged.Tests.Repositories = append(merged.Tests.Repositories, imported.Tests.Repositories...)
+		merged.Tests.SystemdProps = append(merged.Tests.SystemdProps, imported.Tests.SystemdProps...)
+		merged.Tests.SSHConfig = append(merged.Tests.SSHConfig, imported.Tests.SSHConfig...)
+		merged.Tests.LogRotate = append(merged.Tests.LogRotate, imported.Tests.LogRotate...)
+		merged.Tests.Cron = append(merged.Tests.Cron, imported.Tests.Cron...)
+		merged.Tests.Processes = append(merged.Tests.Processes, imported.Tests.Processes...)
+		merged.Tests.Metrics = append(merged.Tests.Metrics, imported.Tests.Metrics...)
+		merged.Tests.CommandJSON = append(merged.Tests.CommandJSON, imported.Tests.CommandJSON...)
+		merged.Tests.Composite = append(merged.Tests.Composite, imported.Tests.Composite...)
 
 		// Merge Kubernetes tests
 		merged.Tests.Kubernetes.Pods = append(merged.Tests.Kubernetes.Pods, imported.Tests.Kubernetes.Pods...)
@@ -497,6 +966,11 @@ func mergeSpecs(mainSpec *Spec, importedSpecs []*Spec) *Spec {
 		merged.Tests.Kubernetes.Ingress = append(merged.Tests.Kubernetes.Ingress, imported.Tests.Kubernetes.Ingress...)
 		merged.Tests.Kubernetes.PVCs = append(merged.Tests.Kubernetes.PVCs, imported.Tests.Kubernetes.PVCs...)
 		merged.Tests.Kubernetes.StatefulSets = append(merged.Tests.Kubernetes.StatefulSets, imported.Tests.Kubernetes.StatefulSets...)
+		merged.Tests.OpenStack.Instances = append(merged.Tests.OpenStack.Instances, imported.Tests.OpenStack.Instances...)
+		merged.Tests.OpenStack.Volumes = append(merged.Tests.OpenStack.Volumes, imported.Tests.OpenStack.Volumes...)
+		merged.Tests.OpenStack.FloatingIPs = append(merged.Tests.OpenStack.FloatingIPs, imported.Tests.OpenStack.FloatingIPs...)
+		merged.Tests.GCP.Instances = append(merged.Tests.GCP.Instances, imported.Tests.GCP.Instances...)
+		merged.Tests.GCP.Buckets = append(merged.Tests.GCP.Buckets, imported.Tests.GCP.Buckets...)
 	}
 
 	// Append main spec's tests last
@@ -508,12 +982,29 @@ func mergeSpecs(mainSpec *Spec, importedSpecs []*Spec) *Spec {
 	merged.Tests.FileContent = append(merged.Tests.FileContent, mainSpec.Tests.FileContent...)
 	merged.Tests.CommandContent = append(merged.Tests.CommandContent, mainSpec.Tests.CommandContent...)
 	merged.Tests.Docker = append(merged.Tests.Docker, mainSpec.Tests.Docker...)
+	merged.Tests.DockerImages = append(merged.Tests.DockerImages, mainSpec.Tests.DockerImages...)
+	merged.Tests.DockerNetworks = append(merged.Tests.DockerNetworks, mainSpec.Tests.DockerNetworks...)
+	merged.Tests.DockerVolumes = append(merged.Tests.DockerVolumes, mainSpec.Tests.DockerVolumes...)
+	merged.Tests.DockerCompose = append(merged.Tests.DockerCompose, mainSpec.Tests.DockerCompose...)
 	merged.Tests.Filesystems = append(merged.Tests.Filesystems, mainSpec.Tests.Filesystems...)
 	merged.Tests.Ping = append(merged.Tests.Ping, mainSpec.Tests.Ping...)
 	merged.Tests.DNS = append(merged.Tests.DNS, mainSpec.Tests.DNS...)
 	merged.Tests.SystemInfo = append(merged.Tests.SystemInfo, mainSpec.Tests.SystemInfo...)
 	merged.Tests.HTTP = append(merged.Tests.HTTP, mainSpec.Tests.HTTP...)
 	merged.Tests.Ports = append(merged.Tests.Ports, mainSpec.Tests.Ports...)
+	merged.Tests.ServiceRegistry = append(merged.Tests.ServiceRegistry, mainSpec.Tests.ServiceRegistry...)
+	merged.Tests.Vault = append(merged.Tests.Vault, mainSpec.Tests.Vault...)
+	merged.Tests.MessageQueues = append(merged.Tests.MessageQueues, mainSpec.Tests.MessageQueues...)
+	merged.Tests.Capabilities = append(merged.Tests.Capabilities, mainSpec.Tests.Capabilities...)
+	merged.Tests.Repositories = append(merged.Tests.Repositories, mainSpec.Tests.Repositories...)
+	merged.Tests.SystemdProps = append(merged.Tests.SystemdProps, mainSpec.Tests.SystemdProps...)
+	merged.Tests.SSHConfig = append(merged.Tests.SSHConfig, mainSpec.Tests.SSHConfig...)
+	merged.Tests.LogRotate = append(merged.Tests.LogRotate, mainSpec.Tests.LogRotate...)
+	merged.Tests.Cron = append(merged.Tests.Cron, mainSpec.Tests.Cron...)
+	merged.Tests.Processes = append(merged.Tests.Processes, mainSpec.Tests.Processes...)
+	merged.Tests.Metrics = append(merged.Tests.Metrics, mainSpec.Tests.Metrics...)
+	merged.Tests.CommandJSON = append(merged.Tests.CommandJSON, mainSpec.Tests.CommandJSON...)
+	merged.Tests.Composite = append(merged.Tests.Composite, mainSpec.Tests.Composite...)
 
 	// Append main spec's Kubernetes tests
 	merged.Tests.Kubernetes.Pods = append(merged.Tests.Kubernetes.Pods, mainSpec.Tests.Kubernetes.Pods...)
@@ -529,16 +1020,144 @@ func mergeSpecs(mainSpec *Spec, importedSpecs []*Spec) *Spec {
 	merged.Tests.Kubernetes.Ingress = append(merged.Tests.Kubernetes.Ingress, mainSpec.Tests.Kubernetes.Ingress...)
 	merged.Tests.Kubernetes.PVCs = append(merged.Tests.Kubernetes.PVCs, mainSpec.Tests.Kubernetes.PVCs...)
 	merged.Tests.Kubernetes.StatefulSets = append(merged.Tests.Kubernetes.StatefulSets, mainSpec.Tests.Kubernetes.StatefulSets...)
+	merged.Tests.OpenStack.Instances = append(merged.Tests.OpenStack.Instances, mainSpec.Tests.OpenStack.Instances...)
+	merged.Tests.OpenStack.Volumes = append(merged.Tests.OpenStack.Volumes, mainSpec.Tests.OpenStack.Volumes...)
+	merged.Tests.OpenStack.FloatingIPs = append(merged.Tests.OpenStack.FloatingIPs, mainSpec.Tests.OpenStack.FloatingIPs...)
+	merged.Tests.GCP.Instances = append(merged.Tests.GCP.Instances, mainSpec.Tests.GCP.Instances...)
+	merged.Tests.GCP.Buckets = append(merged.Tests.GCP.Buckets, mainSpec.Tests.GCP.Buckets...)
+
+	merged.declared = mergeDeclared(append(append([]*Spec{}, importedSpecs...), mainSpec))
+	merged.usedVariables = make(map[string]bool)
+	for _, spec := range append(append([]*Spec{}, importedSpecs...), mainSpec) {
+		for name := range spec.usedVariables {
+			merged.usedVariables[name] = true
+		}
+	}
 
 	return merged
 }
 
+// ValidateProxyURL checks that a proxy URL has a supported scheme and a host
+func ValidateProxyURL(proxy string) error {
+	u, err := url.Parse(proxy)
+	if err != nil {
+		return fmt.Errorf("invalid proxy URL '%s': %w", proxy, err)
+	}
+	validSchemes := map[string]bool{"http": true, "https": true, "socks4": true, "socks5": true, "socks5h": true}
+	if !validSchemes[u.Scheme] {
+		return fmt.Errorf("invalid proxy URL '%s': scheme must be one of http, https, socks4, socks5, socks5h", proxy)
+	}
+	if u.Host == "" {
+		return fmt.Errorf("invalid proxy URL '%s': host is required", proxy)
+	}
+	return nil
+}
+
+var dockerDigestPattern = regexp.MustCompile(`^sha256:[a-f0-9]{64}$`)
+
+// composeProjectPattern matches names Docker Compose accepts for -p
+var composeProjectPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+
+// commandNamePattern matches a command looked up on PATH, e.g. kubectl or g++
+var commandNamePattern = regexp.MustCompile(`^[A-Za-z0-9_][A-Za-z0-9._+-]*$`)
+
+var (
+	gcpProjectIDPattern    = regexp.MustCompile(`^[a-z][-a-z0-9]{4,28}[a-z0-9]$`)
+	gcpResourceNamePattern = regexp.MustCompile(`^[a-z]([-a-z0-9]{0,61}[a-z0-9])?$`)
+	gcpZonePattern         = regexp.MustCompile(`^[a-z]+-[a-z]+[0-9]+-[a-z]$`)
+	gcpBucketNamePattern   = regexp.MustCompile(`^[a-z0-9][-a-z0-9_.]{1,220}[a-z0-9]$`)
+)
+
+// httpHeaderName matches an HTTP header field name (an RFC 9110 token)
+var httpHeaderName = regexp.MustCompile(`^[A-Za-z0-9!#$%&'*+.^_|~-]+$`)
+
+// runAsUserPattern matches a POSIX user name accepted by run_as
+var runAsUserPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_.-]{0,31}$`)
+
+// systemdPropertyPattern matches a systemd property name such as MemoryMax
+var systemdPropertyPattern = regexp.MustCompile(`^[A-Z][A-Za-z0-9]*$`)
+
+// capabilityPattern matches a capability clause such as cap_net_bind_service+ep
+// or cap_net_admin,cap_net_raw=eip
+var capabilityPattern = regexp.MustCompile(`(?i)^cap_[a-z0-9_]+(,cap_[a-z0-9_]+)*[+=][eip]+$`)
+
+// ValidateGCPProjectID checks that a GCP project ID is well formed
+func ValidateGCPProjectID(project string) error {
+	if !gcpProjectIDPattern.MatchString(project) {
+		return fmt.Errorf("invalid GCP project ID '%s': must be 6-30 lowercase letters, digits or hyphens, starting with a letter", project)
+	}
+	return nil
+}
+
+// ApplyHTTPProxy sets a default proxy on HTTP tests that don't define their own
+func (s *Spec) ApplyHTTPProxy(proxy string, noProxy []string) {
+	for i := range s.Tests.HTTP {
+		ht := &s.Tests.HTTP[i]
+		if ht.Proxy != "" {
+			continue
+		}
+		ht.Proxy = proxy
+		if len(ht.NoProxy) == 0 {
+			ht.NoProxy = noProxy
+		}
+	}
+}
+
 // Validate validates the spec
 func (s *Spec) Validate() error {
 	if s.Version == "" {
 		s.Version = "1.0"
 	}
 
+	if err := ValidateOrder(s.Config.Order); err != nil {
+		return fmt.Errorf("config: %w", err)
+	}
+
+	if err := ValidateShell(s.Config.Shell); err != nil {
+		return fmt.Errorf("config: %w", err)
+	}
+	for key := range s.Config.Env {
+		if err := ValidateEnvKey(key); err != nil {
+			return fmt.Errorf("config: %w", err)
+		}
+	}
+	for _, key := range s.Config.SensitiveEnv {
+		if _, ok := s.Config.Env[key]; !ok {
+			return fmt.Errorf("config: sensitive_env key '%s' is not set in env", key)
+		}
+	}
+	if s.Config.Retries < 0 {
+		return fmt.Errorf("config: retries cannot be negative")
+	}
+	if s.Config.RetryInterval < 0 {
+		return fmt.Errorf("config: retry_interval cannot be negative")
+	}
+	if s.Config.Timeout < 0 {
+		return fmt.Errorf("config: timeout cannot be negative")
+	}
+	seenRequires := make(map[string]bool)
+	for _, tool := range s.Config.Requires {
+		if !commandNamePattern.MatchString(tool) {
+			return fmt.Errorf("config: requires entry '%s' must be a command name like kubectl", tool)
+		}
+		if seenRequires[tool] {
+			return fmt.Errorf("config: requires lists '%s' more than once", tool)
+		}
+		seenRequires[tool] = true
+	}
+
+	// Validate hooks
+	for i, hook := range s.Config.Hooks.Before {
+		if strings.TrimSpace(hook) == "" {
+			return fmt.Errorf("config: before hook %d is empty", i)
+		}
+	}
+	for i, hook := range s.Config.Hooks.After {
+		if strings.TrimSpace(hook) == "" {
+			return fmt.Errorf("config: after hook %d is empty", i)
+		}
+	}
+
 	// Validate package tests
 	for i := range s.Tests.Packages {
 		pt := &s.Tests.Packages[i]
@@ -556,6 +1175,39 @@ func (s *Spec) Validate() error {
 		}
 	}
 
+	// Validate repository tests
+	for i := range s.Tests.Repositories {
+		rt := &s.Tests.Repositories[i]
+		if rt.Name == "" {
+			return fmt.Errorf("repository test %d: name is required", i)
+		}
+		if rt.Manager != "apt" && rt.Manager != "yum" && rt.Manager != "dnf" {
+			return fmt.Errorf("repository test '%s': manager must be 'apt', 'yum' or 'dnf'", rt.Name)
+		}
+		if strings.TrimSpace(rt.Repo) == "" {
+			return fmt.Errorf("repository test '%s': repo is required", rt.Name)
+		}
+		if rt.Manager == "apt" {
+			if u, err := url.Parse(rt.Repo); err != nil || u.Scheme == "" || u.Host == "" {
+				return fmt.Errorf("repository test '%s': apt repo must be a URL", rt.Name)
+			}
+		}
+		if rt.State == "" {
+			rt.State = "present"
+		}
+		if rt.State != "present" && rt.State != "absent" {
+			return fmt.Errorf("repository test '%s': state must be 'present' or 'absent'", rt.Name)
+		}
+		if rt.GPGKey != "" {
+			if !strings.HasPrefix(rt.GPGKey, "/") {
+				return fmt.Errorf("repository test '%s': gpg_key must be an absolute path", rt.Name)
+			}
+			if rt.State == "absent" {
+				return fmt.Errorf("repository test '%s': gpg_key cannot be used with state 'absent'", rt.Name)
+			}
+		}
+	}
+
 	// Validate file tests
 	for i := range s.Tests.Files {
 		ft := &s.Tests.Files[i]
@@ -571,6 +1223,24 @@ func (s *Spec) Validate() error {
 		if ft.Type != "file" && ft.Type != "directory" {
 			return fmt.Errorf("file test '%s': type must be 'file' or 'directory'", ft.Name)
 		}
+		if ft.MaxAgeSeconds < 0 || ft.MinAgeSeconds < 0 {
+			return fmt.Errorf("file test '%s': max_age_seconds and min_age_seconds must be >= 0", ft.Name)
+		}
+		if ft.MaxAgeSeconds > 0 && ft.MinAgeSeconds > ft.MaxAgeSeconds {
+			return fmt.Errorf("file test '%s': min_age_seconds must not exceed max_age_seconds", ft.Name)
+		}
+		if (ft.MinEntries != 0 || ft.MaxEntries != 0 || ft.Empty != nil) && ft.Type != "directory" {
+			return fmt.Errorf("file test '%s': min_entries, max_entries and empty only apply to directories", ft.Name)
+		}
+		if ft.MinEntries < 0 || ft.MaxEntries < 0 {
+			return fmt.Errorf("file test '%s': min_entries and max_entries must be >= 0", ft.Name)
+		}
+		if ft.MaxEntries > 0 && ft.MinEntries > ft.MaxEntries {
+			return fmt.Errorf("file test '%s': min_entries must not exceed max_entries", ft.Name)
+		}
+		if ft.Empty != nil && *ft.Empty && ft.MinEntries > 0 {
+			return fmt.Errorf("file test '%s': empty: true conflicts with min_entries", ft.Name)
+		}
 	}
 
 	// Validate service tests
@@ -586,6 +1256,40 @@ func (s *Spec) Validate() error {
 		}
 	}
 
+	// Validate systemd property tests
+	for i, sp := range s.Tests.SystemdProps {
+		if sp.Name == "" {
+			return fmt.Errorf("systemd property test %d: name is required", i)
+		}
+		if strings.TrimSpace(sp.Unit) == "" {
+			return fmt.Errorf("systemd property test '%s': unit is required", sp.Name)
+		}
+		if len(sp.Properties) == 0 {
+			return fmt.Errorf("systemd property test '%s': at least one property is required", sp.Name)
+		}
+		for prop := range sp.Properties {
+			if !systemdPropertyPattern.MatchString(prop) {
+				return fmt.Errorf("systemd property test '%s': invalid property name '%s'", sp.Name, prop)
+			}
+		}
+	}
+
+	// Validate sshd config tests
+	for i, sc := range s.Tests.SSHConfig {
+		if sc.Name == "" {
+			return fmt.Errorf("sshd_config test %d: name is required", i)
+		}
+		if strings.TrimSpace(sc.Setting) == "" {
+			return fmt.Errorf("sshd_config test '%s': setting is required", sc.Name)
+		}
+		if strings.ContainsAny(strings.TrimSpace(sc.Setting), " \t") {
+			return fmt.Errorf("sshd_config test '%s': setting must be a single sshd keyword", sc.Name)
+		}
+		if strings.TrimSpace(sc.Value) == "" {
+			return fmt.Errorf("sshd_config test '%s': value is required", sc.Name)
+		}
+	}
+
 	// Validate user tests
 	for i, ut := range s.Tests.Users {
 		if ut.Name == "" {
@@ -613,6 +1317,79 @@ func (s *Spec) Validate() error {
 		}
 	}
 
+	// Validate logrotate tests
+	for i, lt := range s.Tests.LogRotate {
+		if lt.Name == "" {
+			return fmt.Errorf("logrotate test %d: name is required", i)
+		}
+		if lt.Path == "" {
+			return fmt.Errorf("logrotate test '%s': path is required", lt.Name)
+		}
+		if !strings.HasPrefix(lt.Path, "/") {
+			return fmt.Errorf("logrotate test '%s': path must be absolute", lt.Name)
+		}
+		if lt.MaxSizeBytes == "" && !lt.ConfigPresent {
+			return fmt.Errorf("logrotate test '%s': max_size or config_present is required", lt.Name)
+		}
+		if lt.MaxSizeBytes != "" {
+			if _, err := ParseSize(lt.MaxSizeBytes); err != nil {
+				return fmt.Errorf("logrotate test '%s': %v", lt.Name, err)
+			}
+		}
+	}
+
+	// Validate cron tests
+	for i := range s.Tests.Cron {
+		ct := &s.Tests.Cron[i]
+		if ct.Name == "" {
+			return fmt.Errorf("cron test %d: name is required", i)
+		}
+		if strings.TrimSpace(ct.Command) == "" && strings.TrimSpace(ct.Schedule) == "" {
+			return fmt.Errorf("cron test '%s': command or schedule is required", ct.Name)
+		}
+		if ct.User != "" && !runAsUserPattern.MatchString(ct.User) {
+			return fmt.Errorf("cron test '%s': invalid user '%s'", ct.Name, ct.User)
+		}
+		if ct.State == "" {
+			ct.State = "present"
+		}
+		if ct.State != "present" && ct.State != "absent" {
+			return fmt.Errorf("cron test '%s': state must be 'present' or 'absent'", ct.Name)
+		}
+	}
+
+	// Validate process tests
+	for i := range s.Tests.Processes {
+		pt := &s.Tests.Processes[i]
+		if pt.Name == "" {
+			return fmt.Errorf("process test %d: name is required", i)
+		}
+		if pt.Process == "" {
+			return fmt.Errorf("process test '%s': process is required", pt.Name)
+		}
+		if _, err := regexp.Compile(pt.Process); err != nil {
+			return fmt.Errorf("process test '%s': invalid process pattern: %v", pt.Name, err)
+		}
+		if pt.User != "" && !runAsUserPattern.MatchString(pt.User) {
+			return fmt.Errorf("process test '%s': invalid user '%s'", pt.Name, pt.User)
+		}
+		if pt.State == "" {
+			pt.State = "running"
+		}
+		if pt.State != "running" && pt.State != "absent" {
+			return fmt.Errorf("process test '%s': state must be 'running' or 'absent'", pt.Name)
+		}
+		if pt.MinCount < 0 || pt.MaxCount < 0 {
+			return fmt.Errorf("process test '%s': min_count and max_count must not be negative", pt.Name)
+		}
+		if pt.MaxCount > 0 && pt.MinCount > pt.MaxCount {
+			return fmt.Errorf("process test '%s': min_count (%d) must not be greater than max_count (%d)", pt.Name, pt.MinCount, pt.MaxCount)
+		}
+		if pt.State == "absent" && (pt.MinCount > 0 || pt.MaxCount > 0) {
+			return fmt.Errorf("process test '%s': min_count and max_count only apply when state is running", pt.Name)
+		}
+	}
+
 	// Validate file content tests
 	for i, fct := range s.Tests.FileContent {
 		if fct.Name == "" {
@@ -634,8 +1411,50 @@ func (s *Spec) Validate() error {
 		if ct.Command == "" {
 			return fmt.Errorf("command_content test '%s': command is required", ct.Name)
 		}
-		if len(ct.Contains) == 0 && ct.ExitCode == 0 {
-			return fmt.Errorf("command_content test '%s': either contains or exit_code is required", ct.Name)
+		if len(ct.Contains) == 0 && ct.ExitCode == nil && len(ct.ExitCodeIn) == 0 && len(ct.ExitCodeNot) == 0 {
+			return fmt.Errorf("command_content test '%s': one of contains, exit_code, exit_code_in or exit_code_not is required", ct.Name)
+		}
+		if ct.ExitCode != nil && len(ct.ExitCodeIn) > 0 {
+			return fmt.Errorf("command_content test '%s': exit_code and exit_code_in are mutually exclusive", ct.Name)
+		}
+		if ct.RunAs != "" && !runAsUserPattern.MatchString(ct.RunAs) {
+			return fmt.Errorf("command_content test '%s': invalid run_as user '%s'", ct.Name, ct.RunAs)
+		}
+	}
+
+	// Validate command JSON tests
+	for i, cj := range s.Tests.CommandJSON {
+		if cj.Name == "" {
+			return fmt.Errorf("command_json test %d: name is required", i)
+		}
+		if strings.TrimSpace(cj.Command) == "" {
+			return fmt.Errorf("command_json test '%s': command is required", cj.Name)
+		}
+		if len(cj.JSON) == 0 {
+			return fmt.Errorf("command_json test '%s': at least one json path is required", cj.Name)
+		}
+		for expr := range cj.JSON {
+			if _, err := jsonpath.Compile(expr); err != nil {
+				return fmt.Errorf("command_json test '%s': %v", cj.Name, err)
+			}
+		}
+		if cj.RunAs != "" && !runAsUserPattern.MatchString(cj.RunAs) {
+			return fmt.Errorf("command_json test '%s': invalid run_as user '%s'", cj.Name, cj.RunAs)
+		}
+	}
+
+	// Validate metric tests
+	for i, mt := range s.Tests.Metrics {
+		if mt.Name == "" {
+			return fmt.Errorf("metric test %d: name is required", i)
+		}
+		if strings.TrimSpace(mt.Command) == "" {
+			return fmt.Errorf("metric test '%s': command is required", mt.Name)
+		}
+		switch mt.Operator {
+		case "gt", "lt", "gte", "lte", "eq", "ne":
+		default:
+			return fmt.Errorf("metric test '%s': operator must be one of gt, lt, gte, lte, eq, ne", mt.Name)
 		}
 	}
 
@@ -671,6 +1490,113 @@ func (s *Spec) Validate() error {
 				return fmt.Errorf("docker test '%s': health must be 'healthy', 'unhealthy', 'starting', or 'none'", dt.Name)
 			}
 		}
+		if dt.MemoryLimit != "" {
+			if limit, err := ParseSize(dt.MemoryLimit); err != nil || limit <= 0 {
+				return fmt.Errorf("docker test '%s': memory_limit must be a positive size like '512Mi' or '1G', got '%s'", dt.Name, dt.MemoryLimit)
+			}
+		}
+		if dt.CPUShares < 0 {
+			return fmt.Errorf("docker test '%s': cpu_shares must be positive", dt.Name)
+		}
+		if dt.MinUptimeSeconds < 0 {
+			return fmt.Errorf("docker test '%s': min_uptime_seconds cannot be negative", dt.Name)
+		}
+		if dt.MinUptimeSeconds > 0 && dt.State != "running" {
+			return fmt.Errorf("docker test '%s': min_uptime_seconds requires state 'running'", dt.Name)
+		}
+		if dt.MaxRestarts != nil && *dt.MaxRestarts < 0 {
+			return fmt.Errorf("docker test '%s': max_restarts cannot be negative", dt.Name)
+		}
+	}
+
+	// Validate docker image tests
+	for i := range s.Tests.DockerImages {
+		it := &s.Tests.DockerImages[i]
+		if it.Name == "" {
+			return fmt.Errorf("docker image test %d: name is required", i)
+		}
+		if strings.TrimSpace(it.Image) == "" {
+			return fmt.Errorf("docker image test '%s': image is required", it.Name)
+		}
+		if strings.ContainsAny(it.Image, " \t'\"") {
+			return fmt.Errorf("docker image test '%s': image must not contain whitespace or quotes", it.Name)
+		}
+		if it.State == "" {
+			it.State = "present"
+		}
+		if it.State != "present" && it.State != "absent" {
+			return fmt.Errorf("docker image test '%s': state must be 'present' or 'absent'", it.Name)
+		}
+		if it.Digest != "" {
+			if !dockerDigestPattern.MatchString(it.Digest) {
+				return fmt.Errorf("docker image test '%s': digest must be 'sha256:' followed by 64 hex characters", it.Name)
+			}
+			if it.State == "absent" {
+				return fmt.Errorf("docker image test '%s': digest cannot be used with state 'absent'", it.Name)
+			}
+		}
+	}
+
+	// Validate docker network tests
+	for i := range s.Tests.DockerNetworks {
+		nt := &s.Tests.DockerNetworks[i]
+		if nt.Name == "" {
+			return fmt.Errorf("docker network test %d: name is required", i)
+		}
+		if strings.TrimSpace(nt.Network) == "" {
+			return fmt.Errorf("docker network test '%s': network is required", nt.Name)
+		}
+		if nt.State == "" {
+			nt.State = "present"
+		}
+		if nt.State != "present" && nt.State != "absent" {
+			return fmt.Errorf("docker network test '%s': state must be 'present' or 'absent'", nt.Name)
+		}
+		if nt.Driver != "" && nt.State == "absent" {
+			return fmt.Errorf("docker network test '%s': driver cannot be used with state 'absent'", nt.Name)
+		}
+	}
+
+	// Validate docker volume tests
+	for i := range s.Tests.DockerVolumes {
+		vt := &s.Tests.DockerVolumes[i]
+		if vt.Name == "" {
+			return fmt.Errorf("docker volume test %d: name is required", i)
+		}
+		if strings.TrimSpace(vt.Volume) == "" {
+			return fmt.Errorf("docker volume test '%s': volume is required", vt.Name)
+		}
+		if vt.State == "" {
+			vt.State = "present"
+		}
+		if vt.State != "present" && vt.State != "absent" {
+			return fmt.Errorf("docker volume test '%s': state must be 'present' or 'absent'", vt.Name)
+		}
+		if vt.Driver != "" && vt.State == "absent" {
+			return fmt.Errorf("docker volume test '%s': driver cannot be used with state 'absent'", vt.Name)
+		}
+	}
+
+	// Validate docker compose tests
+	for i := range s.Tests.DockerCompose {
+		ct := &s.Tests.DockerCompose[i]
+		if ct.Name == "" {
+			return fmt.Errorf("docker compose test %d: name is required", i)
+		}
+		if ct.Project == "" {
+			return fmt.Errorf("docker compose test '%s': project is required", ct.Name)
+		}
+		if !composeProjectPattern.MatchString(ct.Project) {
+			return fmt.Errorf("docker compose test '%s': project must contain only lowercase letters, digits, dashes and underscores, starting with a letter or digit", ct.Name)
+		}
+		if !ct.AllServicesUp && len(ct.ExpectedServices) == 0 {
+			return fmt.Errorf("docker compose test '%s': all_services_up or services is required", ct.Name)
+		}
+		for j, service := range ct.ExpectedServices {
+			if strings.TrimSpace(service) == "" {
+				return fmt.Errorf("docker compose test '%s': service %d is empty", ct.Name, j)
+			}
+		}
 	}
 
 	// Validate filesystem tests
@@ -754,6 +1680,28 @@ func (s *Spec) Validate() error {
 		if !validMethods[ht.Method] {
 			return fmt.Errorf("http test '%s': method must be one of GET, POST, PUT, DELETE, PATCH, HEAD, OPTIONS", ht.Name)
 		}
+		if ht.Body != "" && ht.Method != "POST" && ht.Method != "PUT" && ht.Method != "PATCH" {
+			return fmt.Errorf("http test '%s': body is only allowed with method POST, PUT or PATCH, not %s", ht.Name, ht.Method)
+		}
+		for name, value := range ht.Headers {
+			if !httpHeaderName.MatchString(name) {
+				return fmt.Errorf("http test '%s': invalid header name '%s'", ht.Name, name)
+			}
+			if strings.ContainsAny(value, "\r\n") {
+				return fmt.Errorf("http test '%s': header '%s' must not contain line breaks", ht.Name, name)
+			}
+		}
+		for expr := range ht.JSONPath {
+			if _, err := jsonpath.Compile(expr); err != nil {
+				return fmt.Errorf("http test '%s': %v", ht.Name, err)
+			}
+		}
+		// Validate proxy URL if specified
+		if ht.Proxy != "" {
+			if err := ValidateProxyURL(ht.Proxy); err != nil {
+				return fmt.Errorf("http test '%s': %w", ht.Name, err)
+			}
+		}
 	}
 
 	// Validate port tests
@@ -781,6 +1729,128 @@ func (s *Spec) Validate() error {
 		if pt.State != "listening" && pt.State != "closed" {
 			return fmt.Errorf("port test '%s': state must be 'listening' or 'closed'", pt.State)
 		}
+		if pt.Address != "" && net.ParseIP(pt.Address) == nil {
+			return fmt.Errorf("port test '%s': listen_address must be an IP address such as 0.0.0.0, 127.0.0.1 or ::", pt.Name)
+		}
+	}
+
+	// Validate service registry tests
+	for i := range s.Tests.ServiceRegistry {
+		rt := &s.Tests.ServiceRegistry[i]
+		if rt.Name == "" {
+			return fmt.Errorf("service registry test %d: name is required", i)
+		}
+		if rt.Backend != "consul" && rt.Backend != "etcd" {
+			return fmt.Errorf("service registry test '%s': backend must be 'consul' or 'etcd'", rt.Name)
+		}
+		if strings.TrimSpace(rt.Service) == "" {
+			return fmt.Errorf("service registry test '%s': service is required", rt.Name)
+		}
+		if strings.ContainsAny(rt.Service, "/?#") {
+			return fmt.Errorf("service registry test '%s': service must not contain '/', '?' or '#'", rt.Name)
+		}
+		if rt.MinHealthy < 0 {
+			return fmt.Errorf("service registry test '%s': min_healthy must be >= 0", rt.Name)
+		}
+		// Set default minimum healthy instances
+		if rt.MinHealthy == 0 {
+			rt.MinHealthy = 1
+		}
+		// Set default address per backend
+		if rt.Address == "" {
+			if rt.Backend == "consul" {
+				rt.Address = "http://127.0.0.1:8500"
+			} else {
+				rt.Address = "http://127.0.0.1:2379"
+			}
+		}
+		if u, err := url.Parse(rt.Address); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("service registry test '%s': address must be an http or https URL", rt.Name)
+		}
+	}
+
+	// Validate Vault tests
+	for i := range s.Tests.Vault {
+		vt := &s.Tests.Vault[i]
+		if vt.Name == "" {
+			return fmt.Errorf("vault test %d: name is required", i)
+		}
+		if vt.Address == "" {
+			return fmt.Errorf("vault test '%s': address is required", vt.Name)
+		}
+		if u, err := url.Parse(vt.Address); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("vault test '%s': address must be an http or https URL", vt.Name)
+		}
+		if vt.Insecure && vt.CACert != "" {
+			return fmt.Errorf("vault test '%s': insecure and ca_cert are mutually exclusive", vt.Name)
+		}
+	}
+
+	// Validate message queue tests
+	for i := range s.Tests.MessageQueues {
+		mt := &s.Tests.MessageQueues[i]
+		if mt.Name == "" {
+			return fmt.Errorf("message queue test %d: name is required", i)
+		}
+		if mt.Address == "" {
+			return fmt.Errorf("message queue test '%s': address is required", mt.Name)
+		}
+		if strings.TrimSpace(mt.Topic) == "" {
+			return fmt.Errorf("message queue test '%s': topic is required", mt.Name)
+		}
+		if mt.MinPartitions < 0 {
+			return fmt.Errorf("message queue test '%s': min_partitions must be >= 0", mt.Name)
+		}
+		if mt.MinConsumers < 0 {
+			return fmt.Errorf("message queue test '%s': min_consumers must be >= 0", mt.Name)
+		}
+		switch mt.Backend {
+		case "kafka":
+			if _, _, err := net.SplitHostPort(mt.Address); err != nil {
+				return fmt.Errorf("message queue test '%s': kafka address must be host:port", mt.Name)
+			}
+			if mt.MinConsumers > 0 || mt.VHost != "" || mt.Username != "" || mt.Password != "" {
+				return fmt.Errorf("message queue test '%s': min_consumers, vhost, username and password only apply to rabbitmq", mt.Name)
+			}
+		case "rabbitmq":
+			if u, err := url.Parse(mt.Address); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+				return fmt.Errorf("message queue test '%s': rabbitmq address must be the management API URL (http or https)", mt.Name)
+			}
+			if mt.MinPartitions > 0 {
+				return fmt.Errorf("message queue test '%s': min_partitions only applies to kafka", mt.Name)
+			}
+			// Set RabbitMQ defaults
+			if mt.VHost == "" {
+				mt.VHost = "/"
+			}
+			if mt.Username == "" {
+				mt.Username = "guest"
+			}
+			if mt.Password == "" {
+				mt.Password = "guest"
+			}
+		default:
+			return fmt.Errorf("message queue test '%s': backend must be 'kafka' or 'rabbitmq'", mt.Name)
+		}
+	}
+
+	// Validate capabilities tests
+	for i := range s.Tests.Capabilities {
+		ct := &s.Tests.Capabilities[i]
+		if ct.Name == "" {
+			return fmt.Errorf("capabilities test %d: name is required", i)
+		}
+		if ct.Path == "" {
+			return fmt.Errorf("capabilities test '%s': path is required", ct.Name)
+		}
+		if !strings.HasPrefix(ct.Path, "/") {
+			return fmt.Errorf("capabilities test '%s': path must be absolute", ct.Name)
+		}
+		for _, c := range ct.Expected {
+			if !capabilityPattern.MatchString(c) {
+				return fmt.Errorf("capabilities test '%s': invalid capability '%s' (expected e.g. cap_net_bind_service+ep)", ct.Name, c)
+			}
+		}
 	}
 
 	// Validate Kubernetes namespace tests
@@ -789,6 +1859,9 @@ func (s *Spec) Validate() error {
 		if nt.Name == "" {
 			return fmt.Errorf("kubernetes namespace test %d: name is required", i)
 		}
+		if err := nt.KubernetesCluster.validate(); err != nil {
+			return fmt.Errorf("kubernetes namespace test '%s': %w", nt.Name, err)
+		}
 		if nt.Namespace == "" {
 			return fmt.Errorf("kubernetes namespace test '%s': namespace is required", nt.Name)
 		}
@@ -808,6 +1881,9 @@ func (s *Spec) Validate() error {
 		if pt.Name == "" {
 			return fmt.Errorf("kubernetes pod test %d: name is required", i)
 		}
+		if err := pt.KubernetesCluster.validate(); err != nil {
+			return fmt.Errorf("kubernetes pod test '%s': %w", pt.Name, err)
+		}
 		if pt.Pod == "" {
 			return fmt.Errorf("kubernetes pod test '%s': pod is required", pt.Name)
 		}
@@ -827,6 +1903,9 @@ func (s *Spec) Validate() error {
 		if !validStates[pt.State] {
 			return fmt.Errorf("kubernetes pod test '%s': state must be one of: running, pending, succeeded, failed, exists", pt.Name)
 		}
+		if pt.ImageDigest != "" && !dockerDigestPattern.MatchString(pt.ImageDigest) {
+			return fmt.Errorf("kubernetes pod test '%s': image_digest must be 'sha256:' followed by 64 hex characters", pt.Name)
+		}
 	}
 
 	// Validate Kubernetes deployment tests
@@ -835,6 +1914,9 @@ func (s *Spec) Validate() error {
 		if dt.Name == "" {
 			return fmt.Errorf("kubernetes deployment test %d: name is required", i)
 		}
+		if err := dt.KubernetesCluster.validate(); err != nil {
+			return fmt.Errorf("kubernetes deployment test '%s': %w", dt.Name, err)
+		}
 		if dt.Deployment == "" {
 			return fmt.Errorf("kubernetes deployment test '%s': deployment is required", dt.Name)
 		}
@@ -861,6 +1943,15 @@ func (s *Spec) Validate() error {
 		if dt.ReadyReplicas < 0 {
 			return fmt.Errorf("kubernetes deployment test '%s': ready_replicas must be >= 0", dt.Name)
 		}
+		if dt.RolloutTimeout < 0 {
+			return fmt.Errorf("kubernetes deployment test '%s': rollout_timeout cannot be negative", dt.Name)
+		}
+		if dt.RolloutTimeout > 0 && !dt.WaitForRollout {
+			return fmt.Errorf("kubernetes deployment test '%s': rollout_timeout requires wait_for_rollout", dt.Name)
+		}
+		if dt.WaitForRollout && dt.RolloutTimeout == 0 {
+			dt.RolloutTimeout = 5 * time.Minute
+		}
 	}
 
 	// Validate Kubernetes service tests
@@ -869,6 +1960,9 @@ func (s *Spec) Validate() error {
 		if st.Name == "" {
 			return fmt.Errorf("kubernetes service test %d: name is required", i)
 		}
+		if err := st.KubernetesCluster.validate(); err != nil {
+			return fmt.Errorf("kubernetes service test '%s': %w", st.Name, err)
+		}
 		if st.Service == "" {
 			return fmt.Errorf("kubernetes service test '%s': service is required", st.Name)
 		}
@@ -909,6 +2003,9 @@ func (s *Spec) Validate() error {
 		if ct.Name == "" {
 			return fmt.Errorf("kubernetes configmap test %d: name is required", i)
 		}
+		if err := ct.KubernetesCluster.validate(); err != nil {
+			return fmt.Errorf("kubernetes configmap test '%s': %w", ct.Name, err)
+		}
 		if ct.ConfigMap == "" {
 			return fmt.Errorf("kubernetes configmap test '%s': configmap is required", ct.Name)
 		}
@@ -935,6 +2032,9 @@ func (s *Spec) Validate() error {
 		if nt.Name == "" {
 			return fmt.Errorf("kubernetes node test %d: name is required", i)
 		}
+		if err := nt.KubernetesCluster.validate(); err != nil {
+			return fmt.Errorf("kubernetes node test '%s': %w", nt.Name, err)
+		}
 		// At least one check must be specified
 		if nt.Count == 0 && nt.MinCount == 0 && nt.MinReady == 0 && nt.MinVersion == "" {
 			return fmt.Errorf("kubernetes node test '%s': at least one of count, min_count, min_ready, or min_version is required", nt.Name)
@@ -957,6 +2057,9 @@ func (s *Spec) Validate() error {
 		if ct.Name == "" {
 			return fmt.Errorf("kubernetes crd test %d: name is required", i)
 		}
+		if err := ct.KubernetesCluster.validate(); err != nil {
+			return fmt.Errorf("kubernetes crd test '%s': %w", ct.Name, err)
+		}
 		if ct.CRD == "" {
 			return fmt.Errorf("kubernetes crd test '%s': crd is required", ct.Name)
 		}
@@ -976,6 +2079,9 @@ func (s *Spec) Validate() error {
 		if ht.Name == "" {
 			return fmt.Errorf("kubernetes helm test %d: name is required", i)
 		}
+		if err := ht.KubernetesCluster.validate(); err != nil {
+			return fmt.Errorf("kubernetes helm test '%s': %w", ht.Name, err)
+		}
 		if ht.Release == "" {
 			return fmt.Errorf("kubernetes helm test '%s': release is required", ht.Name)
 		}
@@ -1012,6 +2118,9 @@ func (s *Spec) Validate() error {
 		if st.Name == "" {
 			return fmt.Errorf("kubernetes storageclass test %d: name is required", i)
 		}
+		if err := st.KubernetesCluster.validate(); err != nil {
+			return fmt.Errorf("kubernetes storageclass test '%s': %w", st.Name, err)
+		}
 		if st.StorageClass == "" {
 			return fmt.Errorf("kubernetes storageclass test '%s': storageclass is required", st.Name)
 		}
@@ -1031,6 +2140,9 @@ func (s *Spec) Validate() error {
 		if st.Name == "" {
 			return fmt.Errorf("kubernetes secret test %d: name is required", i)
 		}
+		if err := st.KubernetesCluster.validate(); err != nil {
+			return fmt.Errorf("kubernetes secret test '%s': %w", st.Name, err)
+		}
 		if st.Secret == "" {
 			return fmt.Errorf("kubernetes secret test '%s': secret is required", st.Name)
 		}
@@ -1070,6 +2182,9 @@ func (s *Spec) Validate() error {
 		if it.Name == "" {
 			return fmt.Errorf("kubernetes ingress test %d: name is required", i)
 		}
+		if err := it.KubernetesCluster.validate(); err != nil {
+			return fmt.Errorf("kubernetes ingress test '%s': %w", it.Name, err)
+		}
 		if it.Ingress == "" {
 			return fmt.Errorf("kubernetes ingress test '%s': ingress is required", it.Name)
 		}
@@ -1093,6 +2208,9 @@ func (s *Spec) Validate() error {
 		if pt.Name == "" {
 			return fmt.Errorf("kubernetes pvc test %d: name is required", i)
 		}
+		if err := pt.KubernetesCluster.validate(); err != nil {
+			return fmt.Errorf("kubernetes pvc test '%s': %w", pt.Name, err)
+		}
 		if pt.PVC == "" {
 			return fmt.Errorf("kubernetes pvc test '%s': pvc is required", pt.Name)
 		}
@@ -1127,6 +2245,9 @@ func (s *Spec) Validate() error {
 		if st.Name == "" {
 			return fmt.Errorf("kubernetes statefulset test %d: name is required", i)
 		}
+		if err := st.KubernetesCluster.validate(); err != nil {
+			return fmt.Errorf("kubernetes statefulset test '%s': %w", st.Name, err)
+		}
 		if st.StatefulSet == "" {
 			return fmt.Errorf("kubernetes statefulset test '%s': statefulset is required", st.Name)
 		}
@@ -1151,5 +2272,197 @@ func (s *Spec) Validate() error {
 		}
 	}
 
+	// Validate OpenStack instance tests
+	for i := range s.Tests.OpenStack.Instances {
+		it := &s.Tests.OpenStack.Instances[i]
+		if it.Name == "" {
+			return fmt.Errorf("openstack instance test %d: name is required", i)
+		}
+		if strings.TrimSpace(it.Instance) == "" {
+			return fmt.Errorf("openstack instance test '%s': instance is required", it.Name)
+		}
+		// Set default state
+		if it.State == "" {
+			it.State = "present"
+		}
+		if it.State != "present" && it.State != "absent" {
+			return fmt.Errorf("openstack instance test '%s': state must be 'present' or 'absent'", it.Name)
+		}
+		// Set default status
+		if it.State == "present" && it.Status == "" {
+			it.Status = "ACTIVE"
+		}
+	}
+
+	// Validate OpenStack volume tests
+	for i := range s.Tests.OpenStack.Volumes {
+		vt := &s.Tests.OpenStack.Volumes[i]
+		if vt.Name == "" {
+			return fmt.Errorf("openstack volume test %d: name is required", i)
+		}
+		if strings.TrimSpace(vt.Volume) == "" {
+			return fmt.Errorf("openstack volume test '%s': volume is required", vt.Name)
+		}
+		// Set default state
+		if vt.State == "" {
+			vt.State = "present"
+		}
+		if vt.State != "present" && vt.State != "absent" {
+			return fmt.Errorf("openstack volume test '%s': state must be 'present' or 'absent'", vt.Name)
+		}
+		if vt.SizeGB < 0 {
+			return fmt.Errorf("openstack volume test '%s': size_gb must be >= 0", vt.Name)
+		}
+	}
+
+	// Validate OpenStack floating IP tests
+	for i := range s.Tests.OpenStack.FloatingIPs {
+		ft := &s.Tests.OpenStack.FloatingIPs[i]
+		if ft.Name == "" {
+			return fmt.Errorf("openstack floating ip test %d: name is required", i)
+		}
+		if net.ParseIP(ft.FloatingIP) == nil {
+			return fmt.Errorf("openstack floating ip test '%s': floating_ip must be a valid IP address", ft.Name)
+		}
+		if strings.TrimSpace(ft.Instance) == "" {
+			return fmt.Errorf("openstack floating ip test '%s': instance is required", ft.Name)
+		}
+	}
+
+	// Validate GCP instance tests
+	for i := range s.Tests.GCP.Instances {
+		it := &s.Tests.GCP.Instances[i]
+		if it.Name == "" {
+			return fmt.Errorf("gcp instance test %d: name is required", i)
+		}
+		if !gcpResourceNamePattern.MatchString(it.Instance) {
+			return fmt.Errorf("gcp instance test '%s': instance must be a valid GCE instance name", it.Name)
+		}
+		if !gcpZonePattern.MatchString(it.Zone) {
+			return fmt.Errorf("gcp instance test '%s': zone must be a valid GCE zone (e.g. us-central1-a)", it.Name)
+		}
+		if it.Project != "" {
+			if err := ValidateGCPProjectID(it.Project); err != nil {
+				return fmt.Errorf("gcp instance test '%s': %w", it.Name, err)
+			}
+		}
+		// Set default state
+		if it.State == "" {
+			it.State = "present"
+		}
+		if it.State != "present" && it.State != "absent" {
+			return fmt.Errorf("gcp instance test '%s': state must be 'present' or 'absent'", it.Name)
+		}
+		// Set default status
+		if it.State == "present" && it.Status == "" {
+			it.Status = "RUNNING"
+		}
+	}
+
+	// Validate GCP bucket tests
+	for i := range s.Tests.GCP.Buckets {
+		bt := &s.Tests.GCP.Buckets[i]
+		if bt.Name == "" {
+			return fmt.Errorf("gcp bucket test %d: name is required", i)
+		}
+		if !gcpBucketNamePattern.MatchString(bt.Bucket) {
+			return fmt.Errorf("gcp bucket test '%s': bucket must be a valid GCS bucket name", bt.Name)
+		}
+		// Set default state
+		if bt.State == "" {
+			bt.State = "present"
+		}
+		if bt.State != "present" && bt.State != "absent" {
+			return fmt.Errorf("gcp bucket test '%s': state must be 'present' or 'absent'", bt.Name)
+		}
+	}
+
+	// Validate options shared by every test type
+	if err := validateTestOptions(reflect.ValueOf(s.Tests)); err != nil {
+		return err
+	}
+
+	// Validate composite tests
+	for i := range s.Tests.Composite {
+		ct := &s.Tests.Composite[i]
+		if ct.Name == "" {
+			return fmt.Errorf("composite test %d: name is required", i)
+		}
+		if (ct.AnyOf == nil) == (ct.AllOf == nil) {
+			return fmt.Errorf("composite test '%s': exactly one of any_of or all_of is required", ct.Name)
+		}
+		group := ct.Group()
+		if group.Count() == 0 {
+			return fmt.Errorf("composite test '%s': at least one sub-test is required", ct.Name)
+		}
+		// Validate the sub-tests as a spec of their own so defaults are applied
+		sub := &Spec{Tests: *group}
+		if err := sub.Validate(); err != nil {
+			return fmt.Errorf("composite test '%s': %w", ct.Name, err)
+		}
+		*group = sub.Tests
+	}
+
+	return nil
+}
+
+// validateTestOptions checks the TestOptions of every test in a Tests-like struct
+func validateTestOptions(v reflect.Value) error {
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Field(i)
+		switch field.Kind() {
+		case reflect.Slice:
+			for j := 0; j < field.Len(); j++ {
+				test := field.Index(j)
+				opts := test.FieldByName("TestOptions").Interface().(TestOptions)
+				if opts.Retry == nil {
+					continue
+				}
+				name := test.FieldByName("Name").String()
+				if opts.Retry.Attempts < 1 {
+					return fmt.Errorf("test '%s': retry attempts must be at least 1", name)
+				}
+				if opts.Retry.Interval < 0 {
+					return fmt.Errorf("test '%s': retry interval must not be negative", name)
+				}
+				// A bare number decodes as nanoseconds, so was almost certainly meant as seconds
+				if opts.Retry.Interval > 0 && opts.Retry.Interval < time.Millisecond {
+					return fmt.Errorf("test '%s': retry interval %s is under 1ms; give it a unit, e.g. 2s", name, opts.Retry.Interval)
+				}
+			}
+		case reflect.Struct:
+			if err := validateTestOptions(field); err != nil {
+				return err
+			}
+		}
+	}
 	return nil
 }
+
+// Group returns the sub-tests of a composite test
+func (c *CompositeTest) Group() *Tests {
+	if c.AnyOf != nil {
+		return c.AnyOf
+	}
+	return c.AllOf
+}
+
+// Count returns the total number of tests, including nested categories
+func (t Tests) Count() int {
+	return countTests(reflect.ValueOf(t))
+}
+
+// countTests sums the lengths of the test slices in a Tests-like struct
+func countTests(v reflect.Value) int {
+	count := 0
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Field(i)
+		switch field.Kind() {
+		case reflect.Slice:
+			count += field.Len()
+		case reflect.Struct:
+			count += countTests(field)
+		}
+	}
+	return count
+}
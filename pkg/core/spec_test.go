@@ -5,6 +5,7 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 )
 
 func TestParseSpec(t *testing.T) {
@@ -346,6 +347,242 @@ tests:
       method: INVALID`,
 			wantErr: true,
 		},
+		{
+			name: "test with continue_on_failure",
+			yaml: `version: "1.0"
+config:
+  fail_fast: true
+tests:
+  packages:
+    - name: "test"
+      packages: [bash]
+      state: present
+      continue_on_failure: true`,
+			wantErr: false,
+		},
+		{
+			name: "test with retry",
+			yaml: `version: "1.0"
+tests:
+  kubernetes:
+    pods:
+      - name: "api pod ready"
+        pod: api-0
+        retry:
+          attempts: 5
+          interval: 2s`,
+			wantErr: false,
+		},
+		{
+			name: "retry with zero attempts",
+			yaml: `version: "1.0"
+tests:
+  http:
+    - name: "health"
+      url: http://localhost:8080/health
+      retry:
+        attempts: 0`,
+			wantErr: true,
+		},
+		{
+			name: "retry with invalid interval",
+			yaml: `version: "1.0"
+tests:
+  http:
+    - name: "health"
+      url: http://localhost:8080/health
+      retry:
+        attempts: 3
+        interval: soon`,
+			wantErr: true,
+		},
+		{
+			name: "retry with interval without a unit",
+			yaml: `version: "1.0"
+tests:
+  http:
+    - name: "health"
+      url: http://localhost:8080/health
+      retry:
+        attempts: 3
+        interval: 5`,
+			wantErr: true,
+		},
+		{
+			name: "config with spec-wide retries",
+			yaml: `version: "1.0"
+config:
+  retries: 3
+  retry_interval: 2
+tests:
+  http:
+    - name: "health"
+      url: http://localhost:8080/health`,
+			wantErr: false,
+		},
+		{
+			name: "config with negative retries",
+			yaml: `version: "1.0"
+config:
+  retries: -1
+tests:
+  http:
+    - name: "health"
+      url: http://localhost:8080/health`,
+			wantErr: true,
+		},
+		{
+			name: "config with negative retry interval",
+			yaml: `version: "1.0"
+config:
+  retries: 2
+  retry_interval: -5
+tests:
+  http:
+    - name: "health"
+      url: http://localhost:8080/health`,
+			wantErr: true,
+		},
+		{
+			name: "config with negative timeout",
+			yaml: `version: "1.0"
+config:
+  timeout: -1
+tests:
+  http:
+    - name: "health"
+      url: http://localhost:8080/health`,
+			wantErr: true,
+		},
+		{
+			name: "config with required tools",
+			yaml: `version: "1.0"
+config:
+  requires: [kubectl, helm, docker-compose]
+tests:
+  http:
+    - name: "health"
+      url: http://localhost:8080/health`,
+			wantErr: false,
+		},
+		{
+			name: "config with a command line in requires",
+			yaml: `version: "1.0"
+config:
+  requires: ["kubectl version"]
+tests:
+  http:
+    - name: "health"
+      url: http://localhost:8080/health`,
+			wantErr: true,
+		},
+		{
+			name: "config with duplicate requires",
+			yaml: `version: "1.0"
+config:
+  requires: [helm, helm]
+tests:
+  http:
+    - name: "health"
+      url: http://localhost:8080/health`,
+			wantErr: true,
+		},
+		{
+			name: "config with relative shell",
+			yaml: `version: "1.0"
+config:
+  shell: bash
+tests:
+  packages:
+    - name: "test"
+      packages: [nginx]`,
+			wantErr: true,
+		},
+		{
+			name: "config with env",
+			yaml: `version: "1.0"
+config:
+  env:
+    PATH: /opt/app/bin:/usr/bin:/bin
+    API_TOKEN: s3cr3t
+  sensitive_env: [API_TOKEN]
+tests:
+  packages:
+    - name: "test"
+      packages: [nginx]`,
+			wantErr: false,
+		},
+		{
+			name: "config with invalid env key",
+			yaml: `version: "1.0"
+config:
+  env:
+    MY-VAR: value
+tests:
+  packages:
+    - name: "test"
+      packages: [nginx]`,
+			wantErr: true,
+		},
+		{
+			name: "config with sensitive key missing from env",
+			yaml: `version: "1.0"
+config:
+  sensitive_env: [API_TOKEN]
+tests:
+  packages:
+    - name: "test"
+      packages: [nginx]`,
+			wantErr: true,
+		},
+		{
+			name: "config with hooks",
+			yaml: `version: "1.0"
+config:
+  hooks:
+    before: ["systemctl start warm-cache"]
+    after: ["journalctl -u nginx -n 50"]
+tests:
+  packages:
+    - name: "test"
+      packages: [nginx]`,
+			wantErr: false,
+		},
+		{
+			name: "empty before hook",
+			yaml: `version: "1.0"
+config:
+  hooks:
+    before: ["  "]
+tests:
+  packages:
+    - name: "test"
+      packages: [nginx]`,
+			wantErr: true,
+		},
+		{
+			name: "invalid order",
+			yaml: `version: "1.0"
+config:
+  order: random
+tests:
+  packages:
+    - name: "test"
+      packages: [nginx]`,
+			wantErr: true,
+		},
+		{
+			name: "empty after hook",
+			yaml: `version: "1.0"
+config:
+  hooks:
+    after: [""]
+tests:
+  packages:
+    - name: "test"
+      packages: [nginx]`,
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -994,580 +1231,1345 @@ func TestValidationErrorPaths(t *testing.T) {
 		wantErr string
 	}{
 		{
-			name: "service test without service or services",
+			name: "service test without service or services",
+			spec: &Spec{
+				Tests: Tests{
+					Services: []ServiceTest{{Name: "test"}},
+				},
+			},
+			wantErr: "service or services is required",
+		},
+		{
+			name: "docker test with both container and containers",
+			spec: &Spec{
+				Tests: Tests{
+					Docker: []DockerTest{{Name: "test", Container: "c1", Containers: []string{"c2"}}},
+				},
+			},
+			wantErr: "cannot specify both container and containers",
+		},
+		{
+			name: "kubernetes test with relative kubeconfig",
+			spec: &Spec{
+				Tests: Tests{
+					Kubernetes: KubernetesTests{
+						Pods: []KubernetesPodTest{{Name: "test", Pod: "nginx",
+							KubernetesCluster: KubernetesCluster{Kubeconfig: "kube/staging.yaml"}}},
+					},
+				},
+			},
+			wantErr: "kubernetes pod test 'test': kubeconfig must be an absolute path",
+		},
+		{
+			name: "kubernetes pod test with malformed image digest",
+			spec: &Spec{
+				Tests: Tests{
+					Kubernetes: KubernetesTests{
+						Pods: []KubernetesPodTest{{Name: "test", Pod: "nginx", ImageDigest: "sha256:abc"}},
+					},
+				},
+			},
+			wantErr: "image_digest must be 'sha256:' followed by 64 hex characters",
+		},
+		{
+			name: "kubernetes deployment rollout timeout without wait",
+			spec: &Spec{
+				Tests: Tests{
+					Kubernetes: KubernetesTests{
+						Deployments: []KubernetesDeploymentTest{{Name: "test", Deployment: "api", RolloutTimeout: time.Minute}},
+					},
+				},
+			},
+			wantErr: "rollout_timeout requires wait_for_rollout",
+		},
+		{
+			name: "kubernetes test with whitespace in context",
+			spec: &Spec{
+				Tests: Tests{
+					Kubernetes: KubernetesTests{
+						Helm: []KubernetesHelmTest{{Name: "test", Release: "prometheus",
+							KubernetesCluster: KubernetesCluster{Context: "staging eu"}}},
+					},
+				},
+			},
+			wantErr: "context 'staging eu' must not contain whitespace",
+		},
+		{
+			name: "port out of range - too low",
+			spec: &Spec{
+				Tests: Tests{
+					Ports: []PortTest{{Name: "test", Port: 0}},
+				},
+			},
+			wantErr: "port must be between 1 and 65535",
+		},
+		{
+			name: "port out of range - too high",
+			spec: &Spec{
+				Tests: Tests{
+					Ports: []PortTest{{Name: "test", Port: 70000}},
+				},
+			},
+			wantErr: "port must be between 1 and 65535",
+		},
+		{
+			name: "group test without groups",
+			spec: &Spec{
+				Tests: Tests{
+					Groups: []GroupTest{{Name: "test", Groups: []string{}}},
+				},
+			},
+			wantErr: "at least one group is required",
+		},
+		{
+			name: "user test without user",
+			spec: &Spec{
+				Tests: Tests{
+					Users: []UserTest{{Name: "test"}},
+				},
+			},
+			wantErr: "user is required",
+		},
+		{
+			name: "file_content test without path",
+			spec: &Spec{
+				Tests: Tests{
+					FileContent: []FileContentTest{{Name: "test", Contains: []string{"text"}}},
+				},
+			},
+			wantErr: "path is required",
+		},
+		{
+			name: "file_content test without contains or matches",
+			spec: &Spec{
+				Tests: Tests{
+					FileContent: []FileContentTest{{Name: "test", Path: "/tmp/file"}},
+				},
+			},
+			wantErr: "either contains or matches is required",
+		},
+		{
+			name: "command_content test without command",
+			spec: &Spec{
+				Tests: Tests{
+					CommandContent: []CommandContentTest{{Name: "test", Contains: []string{"text"}}},
+				},
+			},
+			wantErr: "command is required",
+		},
+		{
+			name: "command_content test without contains or exit_code",
+			spec: &Spec{
+				Tests: Tests{
+					CommandContent: []CommandContentTest{{Name: "test", Command: "echo hello"}},
+				},
+			},
+			wantErr: "one of contains, exit_code, exit_code_in or exit_code_not is required",
+		},
+		{
+			name: "package test with empty packages list",
+			spec: &Spec{
+				Tests: Tests{
+					Packages: []PackageTest{{Name: "test", Packages: []string{}}},
+				},
+			},
+			wantErr: "at least one package is required",
+		},
+		{
+			name: "port protocol udp is valid",
+			spec: &Spec{
+				Tests: Tests{
+					Ports: []PortTest{{Name: "test", Port: 53, Protocol: "udp"}},
+				},
+			},
+			wantErr: "",
+		},
+		{
+			name: "invalid port protocol",
+			spec: &Spec{
+				Tests: Tests{
+					Ports: []PortTest{{Name: "test", Port: 80, Protocol: "sctp"}},
+				},
+			},
+			wantErr: "protocol must be 'tcp' or 'udp'",
+		},
+		{
+			name: "valid port listen address",
+			spec: &Spec{
+				Tests: Tests{
+					Ports: []PortTest{{Name: "test", Port: 5432, Address: "::1"}},
+				},
+			},
+			wantErr: "",
+		},
+		{
+			name: "invalid port listen address",
+			spec: &Spec{
+				Tests: Tests{
+					Ports: []PortTest{{Name: "test", Port: 5432, Address: "localhost"}},
+				},
+			},
+			wantErr: "listen_address must be an IP address",
+		},
+		{
+			name: "invalid port state",
+			spec: &Spec{
+				Tests: Tests{
+					Ports: []PortTest{{Name: "test", Port: 80, State: "open"}},
+				},
+			},
+			wantErr: "state must be 'listening' or 'closed'",
+		},
+		{
+			name: "filesystem invalid state",
+			spec: &Spec{
+				Tests: Tests{
+					Filesystems: []FilesystemTest{{Name: "test", Path: "/mnt", State: "present"}},
+				},
+			},
+			wantErr: "state must be 'mounted' or 'unmounted'",
+		},
+		{
+			name: "filesystem invalid max usage percent negative",
+			spec: &Spec{
+				Tests: Tests{
+					Filesystems: []FilesystemTest{{Name: "test", Path: "/mnt", MaxUsagePercent: -1}},
+				},
+			},
+			wantErr: "max_usage_percent must be between 0 and 100",
+		},
+		{
+			name: "filesystem invalid max usage percent over 100",
+			spec: &Spec{
+				Tests: Tests{
+					Filesystems: []FilesystemTest{{Name: "test", Path: "/mnt", MaxUsagePercent: 101}},
+				},
+			},
+			wantErr: "max_usage_percent must be between 0 and 100",
+		},
+		{
+			name: "filesystem invalid min size gb",
+			spec: &Spec{
+				Tests: Tests{
+					Filesystems: []FilesystemTest{{Name: "test", Path: "/mnt", MinSizeGB: -5}},
+				},
+			},
+			wantErr: "min_size_gb must be >= 0",
+		},
+		{
+			name: "docker invalid restart policy",
+			spec: &Spec{
+				Tests: Tests{
+					Docker: []DockerTest{{Name: "test", Container: "c1", RestartPolicy: "sometimes"}},
+				},
+			},
+			wantErr: "restart_policy must be 'no', 'always', 'on-failure', or 'unless-stopped'",
+		},
+		{
+			name: "docker invalid health",
+			spec: &Spec{
+				Tests: Tests{
+					Docker: []DockerTest{{Name: "test", Container: "c1", Health: "sick"}},
+				},
+			},
+			wantErr: "health must be 'healthy', 'unhealthy', 'starting', or 'none'",
+		},
+		{
+			name: "docker invalid memory limit",
+			spec: &Spec{
+				Tests: Tests{
+					Docker: []DockerTest{{Name: "test", Container: "c1", MemoryLimit: "512MB"}},
+				},
+			},
+			wantErr: "memory_limit must be a positive size like '512Mi' or '1G', got '512MB'",
+		},
+		{
+			name: "docker negative cpu shares",
+			spec: &Spec{
+				Tests: Tests{
+					Docker: []DockerTest{{Name: "test", Container: "c1", CPUShares: -1}},
+				},
+			},
+			wantErr: "cpu_shares must be positive",
+		},
+		{
+			name: "docker min uptime on stopped container",
+			spec: &Spec{
+				Tests: Tests{
+					Docker: []DockerTest{{Name: "test", Container: "c1", State: "stopped", MinUptimeSeconds: 60}},
+				},
+			},
+			wantErr: "min_uptime_seconds requires state 'running'",
+		},
+		{
+			name: "docker negative max restarts",
+			spec: &Spec{
+				Tests: Tests{
+					Docker: []DockerTest{{Name: "test", Container: "c1", MaxRestarts: intPtr(-1)}},
+				},
+			},
+			wantErr: "max_restarts cannot be negative",
+		},
+		{
+			name: "kubernetes namespace without namespace field",
+			spec: &Spec{
+				Tests: Tests{
+					Kubernetes: KubernetesTests{
+						Namespaces: []KubernetesNamespaceTest{{Name: "test"}},
+					},
+				},
+			},
+			wantErr: "namespace is required",
+		},
+		{
+			name: "kubernetes pod without pod field",
+			spec: &Spec{
+				Tests: Tests{
+					Kubernetes: KubernetesTests{
+						Pods: []KubernetesPodTest{{Name: "test"}},
+					},
+				},
+			},
+			wantErr: "pod is required",
+		},
+		{
+			name: "kubernetes pod invalid state",
+			spec: &Spec{
+				Tests: Tests{
+					Kubernetes: KubernetesTests{
+						Pods: []KubernetesPodTest{{Name: "test", Pod: "mypod", State: "crashed"}},
+					},
+				},
+			},
+			wantErr: "state must be one of: running, pending, succeeded, failed, exists",
+		},
+		{
+			name: "kubernetes deployment without deployment field",
+			spec: &Spec{
+				Tests: Tests{
+					Kubernetes: KubernetesTests{
+						Deployments: []KubernetesDeploymentTest{{Name: "test"}},
+					},
+				},
+			},
+			wantErr: "deployment is required",
+		},
+		{
+			name: "kubernetes deployment invalid state",
+			spec: &Spec{
+				Tests: Tests{
+					Kubernetes: KubernetesTests{
+						Deployments: []KubernetesDeploymentTest{{Name: "test", Deployment: "myapp", State: "ready"}},
+					},
+				},
+			},
+			wantErr: "state must be one of: available, progressing, exists",
+		},
+		{
+			name: "kubernetes deployment invalid replicas",
+			spec: &Spec{
+				Tests: Tests{
+					Kubernetes: KubernetesTests{
+						Deployments: []KubernetesDeploymentTest{{Name: "test", Deployment: "myapp", Replicas: -1}},
+					},
+				},
+			},
+			wantErr: "replicas must be >= 0",
+		},
+		{
+			name: "kubernetes deployment invalid ready_replicas",
+			spec: &Spec{
+				Tests: Tests{
+					Kubernetes: KubernetesTests{
+						Deployments: []KubernetesDeploymentTest{{Name: "test", Deployment: "myapp", ReadyReplicas: -2}},
+					},
+				},
+			},
+			wantErr: "ready_replicas must be >= 0",
+		},
+		{
+			name: "kubernetes node without name",
+			spec: &Spec{
+				Tests: Tests{
+					Kubernetes: KubernetesTests{
+						Nodes: []KubernetesNodeTest{{Count: 3}},
+					},
+				},
+			},
+			wantErr: "name is required",
+		},
+		{
+			name: "kubernetes node invalid count",
+			spec: &Spec{
+				Tests: Tests{
+					Kubernetes: KubernetesTests{
+						Nodes: []KubernetesNodeTest{{Name: "test", Count: -1}},
+					},
+				},
+			},
+			wantErr: "count must be non-negative",
+		},
+		{
+			name: "kubernetes node invalid min_count",
+			spec: &Spec{
+				Tests: Tests{
+					Kubernetes: KubernetesTests{
+						Nodes: []KubernetesNodeTest{{Name: "test", MinCount: -1}},
+					},
+				},
+			},
+			wantErr: "min_count must be non-negative",
+		},
+		{
+			name: "kubernetes node invalid min_ready",
+			spec: &Spec{
+				Tests: Tests{
+					Kubernetes: KubernetesTests{
+						Nodes: []KubernetesNodeTest{{Name: "test", MinReady: -1}},
+					},
+				},
+			},
+			wantErr: "min_ready must be non-negative",
+		},
+		{
+			name: "kubernetes crd without name",
+			spec: &Spec{
+				Tests: Tests{
+					Kubernetes: KubernetesTests{
+						CRDs: []KubernetesCRDTest{{CRD: "certificates.cert-manager.io"}},
+					},
+				},
+			},
+			wantErr: "name is required",
+		},
+		{
+			name: "kubernetes crd without crd field",
+			spec: &Spec{
+				Tests: Tests{
+					Kubernetes: KubernetesTests{
+						CRDs: []KubernetesCRDTest{{Name: "test"}},
+					},
+				},
+			},
+			wantErr: "crd is required",
+		},
+		{
+			name: "kubernetes crd invalid state",
+			spec: &Spec{
+				Tests: Tests{
+					Kubernetes: KubernetesTests{
+						CRDs: []KubernetesCRDTest{{Name: "test", CRD: "test.example.com", State: "installed"}},
+					},
+				},
+			},
+			wantErr: "state must be 'present' or 'absent'",
+		},
+		{
+			name: "kubernetes helm without name",
+			spec: &Spec{
+				Tests: Tests{
+					Kubernetes: KubernetesTests{
+						Helm: []KubernetesHelmTest{{Release: "prometheus"}},
+					},
+				},
+			},
+			wantErr: "name is required",
+		},
+		{
+			name: "kubernetes helm without release",
+			spec: &Spec{
+				Tests: Tests{
+					Kubernetes: KubernetesTests{
+						Helm: []KubernetesHelmTest{{Name: "test"}},
+					},
+				},
+			},
+			wantErr: "release is required",
+		},
+		{
+			name: "kubernetes helm invalid state",
+			spec: &Spec{
+				Tests: Tests{
+					Kubernetes: KubernetesTests{
+						Helm: []KubernetesHelmTest{{Name: "test", Release: "prometheus", State: "running"}},
+					},
+				},
+			},
+			wantErr: "state must be one of",
+		},
+		{
+			name: "kubernetes storageclass without name",
+			spec: &Spec{
+				Tests: Tests{
+					Kubernetes: KubernetesTests{
+						StorageClasses: []KubernetesStorageClassTest{{StorageClass: "fast-ssd"}},
+					},
+				},
+			},
+			wantErr: "name is required",
+		},
+		{
+			name: "kubernetes storageclass without storageclass field",
+			spec: &Spec{
+				Tests: Tests{
+					Kubernetes: KubernetesTests{
+						StorageClasses: []KubernetesStorageClassTest{{Name: "test"}},
+					},
+				},
+			},
+			wantErr: "storageclass is required",
+		},
+		{
+			name: "kubernetes storageclass invalid state",
+			spec: &Spec{
+				Tests: Tests{
+					Kubernetes: KubernetesTests{
+						StorageClasses: []KubernetesStorageClassTest{{Name: "test", StorageClass: "fast-ssd", State: "active"}},
+					},
+				},
+			},
+			wantErr: "state must be 'present' or 'absent'",
+		},
+		{
+			name: "kubernetes secret without name",
+			spec: &Spec{
+				Tests: Tests{
+					Kubernetes: KubernetesTests{
+						Secrets: []KubernetesSecretTest{{Secret: "db-password"}},
+					},
+				},
+			},
+			wantErr: "name is required",
+		},
+		{
+			name: "kubernetes secret without secret field",
+			spec: &Spec{
+				Tests: Tests{
+					Kubernetes: KubernetesTests{
+						Secrets: []KubernetesSecretTest{{Name: "test"}},
+					},
+				},
+			},
+			wantErr: "secret is required",
+		},
+		{
+			name: "kubernetes secret invalid state",
+			spec: &Spec{
+				Tests: Tests{
+					Kubernetes: KubernetesTests{
+						Secrets: []KubernetesSecretTest{{Name: "test", Secret: "db-password", State: "active"}},
+					},
+				},
+			},
+			wantErr: "state must be 'present' or 'absent'",
+		},
+		{
+			name: "kubernetes secret invalid type",
+			spec: &Spec{
+				Tests: Tests{
+					Kubernetes: KubernetesTests{
+						Secrets: []KubernetesSecretTest{{Name: "test", Secret: "db-password", Type: "invalid-type"}},
+					},
+				},
+			},
+			wantErr: "type must be one of",
+		},
+		{
+			name: "kubernetes ingress without name",
+			spec: &Spec{
+				Tests: Tests{
+					Kubernetes: KubernetesTests{
+						Ingress: []KubernetesIngressTest{{Ingress: "myapp-ingress"}},
+					},
+				},
+			},
+			wantErr: "name is required",
+		},
+		{
+			name: "kubernetes ingress without ingress field",
+			spec: &Spec{
+				Tests: Tests{
+					Kubernetes: KubernetesTests{
+						Ingress: []KubernetesIngressTest{{Name: "test"}},
+					},
+				},
+			},
+			wantErr: "ingress is required",
+		},
+		{
+			name: "kubernetes ingress invalid state",
+			spec: &Spec{
+				Tests: Tests{
+					Kubernetes: KubernetesTests{
+						Ingress: []KubernetesIngressTest{{Name: "test", Ingress: "myapp-ingress", State: "ready"}},
+					},
+				},
+			},
+			wantErr: "state must be 'present' or 'absent'",
+		},
+		{
+			name: "kubernetes pvc without name",
+			spec: &Spec{
+				Tests: Tests{
+					Kubernetes: KubernetesTests{
+						PVCs: []KubernetesPVCTest{{PVC: "data-pvc"}},
+					},
+				},
+			},
+			wantErr: "name is required",
+		},
+		{
+			name: "kubernetes pvc without pvc field",
+			spec: &Spec{
+				Tests: Tests{
+					Kubernetes: KubernetesTests{
+						PVCs: []KubernetesPVCTest{{Name: "test"}},
+					},
+				},
+			},
+			wantErr: "pvc is required",
+		},
+		{
+			name: "kubernetes pvc invalid state",
+			spec: &Spec{
+				Tests: Tests{
+					Kubernetes: KubernetesTests{
+						PVCs: []KubernetesPVCTest{{Name: "test", PVC: "data-pvc", State: "active"}},
+					},
+				},
+			},
+			wantErr: "state must be 'present' or 'absent'",
+		},
+		{
+			name: "kubernetes pvc invalid status",
+			spec: &Spec{
+				Tests: Tests{
+					Kubernetes: KubernetesTests{
+						PVCs: []KubernetesPVCTest{{Name: "test", PVC: "data-pvc", Status: "Ready"}},
+					},
+				},
+			},
+			wantErr: "status must be 'Bound', 'Pending', or 'Lost'",
+		},
+		{
+			name: "kubernetes statefulset without name",
+			spec: &Spec{
+				Tests: Tests{
+					Kubernetes: KubernetesTests{
+						StatefulSets: []KubernetesStatefulSetTest{{StatefulSet: "postgres"}},
+					},
+				},
+			},
+			wantErr: "name is required",
+		},
+		{
+			name: "kubernetes statefulset without statefulset field",
+			spec: &Spec{
+				Tests: Tests{
+					Kubernetes: KubernetesTests{
+						StatefulSets: []KubernetesStatefulSetTest{{Name: "test"}},
+					},
+				},
+			},
+			wantErr: "statefulset is required",
+		},
+		{
+			name: "kubernetes statefulset invalid state",
+			spec: &Spec{
+				Tests: Tests{
+					Kubernetes: KubernetesTests{
+						StatefulSets: []KubernetesStatefulSetTest{{Name: "test", StatefulSet: "postgres", State: "running"}},
+					},
+				},
+			},
+			wantErr: "state must be 'available' or 'exists'",
+		},
+		{
+			name: "kubernetes statefulset invalid replicas",
+			spec: &Spec{
+				Tests: Tests{
+					Kubernetes: KubernetesTests{
+						StatefulSets: []KubernetesStatefulSetTest{{Name: "test", StatefulSet: "postgres", Replicas: -1}},
+					},
+				},
+			},
+			wantErr: "replicas must be >= 0",
+		},
+		{
+			name: "kubernetes statefulset invalid ready_replicas",
+			spec: &Spec{
+				Tests: Tests{
+					Kubernetes: KubernetesTests{
+						StatefulSets: []KubernetesStatefulSetTest{{Name: "test", StatefulSet: "postgres", ReadyReplicas: -1}},
+					},
+				},
+			},
+			wantErr: "ready_replicas must be >= 0",
+		},
+		{
+			name: "http test with valid proxy",
+			spec: &Spec{
+				Tests: Tests{
+					HTTP: []HTTPTest{{Name: "test", URL: "http://example.com", Proxy: "http://proxy.corp:3128"}},
+				},
+			},
+			wantErr: "",
+		},
+		{
+			name: "http test with unsupported proxy scheme",
+			spec: &Spec{
+				Tests: Tests{
+					HTTP: []HTTPTest{{Name: "test", URL: "http://example.com", Proxy: "ftp://proxy.corp:21"}},
+				},
+			},
+			wantErr: "scheme must be one of http, https, socks4, socks5, socks5h",
+		},
+		{
+			name: "http test with proxy missing host",
+			spec: &Spec{
+				Tests: Tests{
+					HTTP: []HTTPTest{{Name: "test", URL: "http://example.com", Proxy: "http://"}},
+				},
+			},
+			wantErr: "host is required",
+		},
+		{
+			name: "openstack instance test without instance",
+			spec: &Spec{
+				Tests: Tests{
+					OpenStack: OpenStackTests{Instances: []OpenStackInstanceTest{{Name: "test"}}},
+				},
+			},
+			wantErr: "instance is required",
+		},
+		{
+			name: "openstack instance test with invalid state",
+			spec: &Spec{
+				Tests: Tests{
+					OpenStack: OpenStackTests{Instances: []OpenStackInstanceTest{{Name: "test", Instance: "web-01", State: "running"}}},
+				},
+			},
+			wantErr: "state must be 'present' or 'absent'",
+		},
+		{
+			name: "openstack volume test without volume",
+			spec: &Spec{
+				Tests: Tests{
+					OpenStack: OpenStackTests{Volumes: []OpenStackVolumeTest{{Name: "test"}}},
+				},
+			},
+			wantErr: "volume is required",
+		},
+		{
+			name: "openstack volume test with negative size",
+			spec: &Spec{
+				Tests: Tests{
+					OpenStack: OpenStackTests{Volumes: []OpenStackVolumeTest{{Name: "test", Volume: "data", SizeGB: -1}}},
+				},
+			},
+			wantErr: "size_gb must be >= 0",
+		},
+		{
+			name: "openstack floating ip test with invalid address",
+			spec: &Spec{
+				Tests: Tests{
+					OpenStack: OpenStackTests{FloatingIPs: []OpenStackFloatingIPTest{{Name: "test", FloatingIP: "not-an-ip", Instance: "web-01"}}},
+				},
+			},
+			wantErr: "floating_ip must be a valid IP address",
+		},
+		{
+			name: "openstack floating ip test without instance",
+			spec: &Spec{
+				Tests: Tests{
+					OpenStack: OpenStackTests{FloatingIPs: []OpenStackFloatingIPTest{{Name: "test", FloatingIP: "203.0.113.10"}}},
+				},
+			},
+			wantErr: "instance is required",
+		},
+		{
+			name: "openstack instance test defaults to ACTIVE",
+			spec: &Spec{
+				Tests: Tests{
+					OpenStack: OpenStackTests{Instances: []OpenStackInstanceTest{{Name: "test", Instance: "web-01"}}},
+				},
+			},
+			wantErr: "",
+		},
+		{
+			name: "gcp instance test without name",
+			spec: &Spec{
+				Tests: Tests{
+					GCP: GCPTests{Instances: []GCPInstanceTest{{Instance: "web-01", Zone: "us-central1-a"}}},
+				},
+			},
+			wantErr: "name is required",
+		},
+		{
+			name: "gcp instance test with invalid instance name",
+			spec: &Spec{
+				Tests: Tests{
+					GCP: GCPTests{Instances: []GCPInstanceTest{{Name: "test", Instance: "Web_01", Zone: "us-central1-a"}}},
+				},
+			},
+			wantErr: "instance must be a valid GCE instance name",
+		},
+		{
+			name: "gcp instance test with invalid zone",
+			spec: &Spec{
+				Tests: Tests{
+					GCP: GCPTests{Instances: []GCPInstanceTest{{Name: "test", Instance: "web-01", Zone: "us-central1"}}},
+				},
+			},
+			wantErr: "zone must be a valid GCE zone",
+		},
+		{
+			name: "gcp instance test with invalid project",
+			spec: &Spec{
+				Tests: Tests{
+					GCP: GCPTests{Instances: []GCPInstanceTest{{Name: "test", Instance: "web-01", Zone: "us-central1-a", Project: "My_Project"}}},
+				},
+			},
+			wantErr: "invalid GCP project ID",
+		},
+		{
+			name: "gcp instance test defaults to RUNNING",
+			spec: &Spec{
+				Tests: Tests{
+					GCP: GCPTests{Instances: []GCPInstanceTest{{Name: "test", Instance: "web-01", Zone: "us-central1-a", Project: "my-project"}}},
+				},
+			},
+			wantErr: "",
+		},
+		{
+			name: "gcp bucket test with invalid bucket name",
+			spec: &Spec{
+				Tests: Tests{
+					GCP: GCPTests{Buckets: []GCPBucketTest{{Name: "test", Bucket: "My Bucket"}}},
+				},
+			},
+			wantErr: "bucket must be a valid GCS bucket name",
+		},
+		{
+			name: "gcp bucket test with invalid state",
+			spec: &Spec{
+				Tests: Tests{
+					GCP: GCPTests{Buckets: []GCPBucketTest{{Name: "test", Bucket: "my-bucket", State: "deleted"}}},
+				},
+			},
+			wantErr: "state must be 'present' or 'absent'",
+		},
+		{
+			name: "service registry test with invalid backend",
+			spec: &Spec{
+				Tests: Tests{
+					ServiceRegistry: []ServiceRegistryTest{{Name: "test", Backend: "zookeeper", Service: "api"}},
+				},
+			},
+			wantErr: "backend must be 'consul' or 'etcd'",
+		},
+		{
+			name: "service registry test without service",
 			spec: &Spec{
 				Tests: Tests{
-					Services: []ServiceTest{{Name: "test"}},
+					ServiceRegistry: []ServiceRegistryTest{{Name: "test", Backend: "consul"}},
 				},
 			},
-			wantErr: "service or services is required",
+			wantErr: "service is required",
 		},
 		{
-			name: "docker test with both container and containers",
+			name: "service registry test with invalid address",
 			spec: &Spec{
 				Tests: Tests{
-					Docker: []DockerTest{{Name: "test", Container: "c1", Containers: []string{"c2"}}},
+					ServiceRegistry: []ServiceRegistryTest{{Name: "test", Backend: "etcd", Service: "api", Address: "etcd:2379"}},
 				},
 			},
-			wantErr: "cannot specify both container and containers",
+			wantErr: "address must be an http or https URL",
 		},
 		{
-			name: "port out of range - too low",
+			name: "vault test without address",
 			spec: &Spec{
 				Tests: Tests{
-					Ports: []PortTest{{Name: "test", Port: 0}},
+					Vault: []VaultTest{{Name: "test", ExpectUnsealed: true}},
 				},
 			},
-			wantErr: "port must be between 1 and 65535",
+			wantErr: "address is required",
 		},
 		{
-			name: "port out of range - too high",
+			name: "vault test with invalid address",
 			spec: &Spec{
 				Tests: Tests{
-					Ports: []PortTest{{Name: "test", Port: 70000}},
+					Vault: []VaultTest{{Name: "test", Address: "vault.internal:8200"}},
 				},
 			},
-			wantErr: "port must be between 1 and 65535",
+			wantErr: "address must be an http or https URL",
 		},
 		{
-			name: "group test without groups",
+			name: "vault test with insecure and ca_cert",
 			spec: &Spec{
 				Tests: Tests{
-					Groups: []GroupTest{{Name: "test", Groups: []string{}}},
+					Vault: []VaultTest{{Name: "test", Address: "https://vault.internal:8200", Insecure: true, CACert: "/etc/ssl/vault-ca.pem"}},
 				},
 			},
-			wantErr: "at least one group is required",
+			wantErr: "insecure and ca_cert are mutually exclusive",
 		},
 		{
-			name: "user test without user",
+			name: "message queue test with invalid backend",
 			spec: &Spec{
 				Tests: Tests{
-					Users: []UserTest{{Name: "test"}},
+					MessageQueues: []MessageQueueTest{{Name: "test", Backend: "nats", Address: "nats:4222", Topic: "orders"}},
 				},
 			},
-			wantErr: "user is required",
+			wantErr: "backend must be 'kafka' or 'rabbitmq'",
 		},
 		{
-			name: "file_content test without path",
+			name: "message queue test without topic",
 			spec: &Spec{
 				Tests: Tests{
-					FileContent: []FileContentTest{{Name: "test", Contains: []string{"text"}}},
+					MessageQueues: []MessageQueueTest{{Name: "test", Backend: "kafka", Address: "kafka:9092"}},
 				},
 			},
-			wantErr: "path is required",
+			wantErr: "topic is required",
 		},
 		{
-			name: "file_content test without contains or matches",
+			name: "kafka test with URL address",
 			spec: &Spec{
 				Tests: Tests{
-					FileContent: []FileContentTest{{Name: "test", Path: "/tmp/file"}},
+					MessageQueues: []MessageQueueTest{{Name: "test", Backend: "kafka", Address: "http://kafka:9092", Topic: "orders"}},
 				},
 			},
-			wantErr: "either contains or matches is required",
+			wantErr: "kafka address must be host:port",
 		},
 		{
-			name: "command_content test without command",
+			name: "kafka test with rabbitmq fields",
 			spec: &Spec{
 				Tests: Tests{
-					CommandContent: []CommandContentTest{{Name: "test", Contains: []string{"text"}}},
+					MessageQueues: []MessageQueueTest{{Name: "test", Backend: "kafka", Address: "kafka:9092", Topic: "orders", MinConsumers: 1}},
 				},
 			},
-			wantErr: "command is required",
+			wantErr: "only apply to rabbitmq",
 		},
 		{
-			name: "command_content test without contains or exit_code",
+			name: "rabbitmq test with host:port address",
 			spec: &Spec{
 				Tests: Tests{
-					CommandContent: []CommandContentTest{{Name: "test", Command: "echo hello"}},
+					MessageQueues: []MessageQueueTest{{Name: "test", Backend: "rabbitmq", Address: "rabbit:15672", Topic: "orders"}},
 				},
 			},
-			wantErr: "either contains or exit_code is required",
+			wantErr: "rabbitmq address must be the management API URL",
 		},
 		{
-			name: "package test with empty packages list",
+			name: "rabbitmq test with min_partitions",
 			spec: &Spec{
 				Tests: Tests{
-					Packages: []PackageTest{{Name: "test", Packages: []string{}}},
+					MessageQueues: []MessageQueueTest{{Name: "test", Backend: "rabbitmq", Address: "http://rabbit:15672", Topic: "orders", MinPartitions: 3}},
 				},
 			},
-			wantErr: "at least one package is required",
+			wantErr: "min_partitions only applies to kafka",
 		},
 		{
-			name: "port protocol udp is valid",
+			name: "file test with negative max_age_seconds",
 			spec: &Spec{
 				Tests: Tests{
-					Ports: []PortTest{{Name: "test", Port: 53, Protocol: "udp"}},
+					Files: []FileTest{{Name: "test", Path: "/var/log/app.log", MaxAgeSeconds: -1}},
 				},
 			},
-			wantErr: "",
+			wantErr: "max_age_seconds and min_age_seconds must be >= 0",
 		},
 		{
-			name: "invalid port protocol",
+			name: "file test with min age above max age",
 			spec: &Spec{
 				Tests: Tests{
-					Ports: []PortTest{{Name: "test", Port: 80, Protocol: "sctp"}},
+					Files: []FileTest{{Name: "test", Path: "/var/log/app.log", MinAgeSeconds: 600, MaxAgeSeconds: 60}},
 				},
 			},
-			wantErr: "protocol must be 'tcp' or 'udp'",
+			wantErr: "min_age_seconds must not exceed max_age_seconds",
 		},
 		{
-			name: "invalid port state",
+			name: "file test with entry bounds on a file",
 			spec: &Spec{
 				Tests: Tests{
-					Ports: []PortTest{{Name: "test", Port: 80, State: "open"}},
+					Files: []FileTest{{Name: "test", Path: "/etc/hosts", Type: "file", MaxEntries: 3}},
 				},
 			},
-			wantErr: "state must be 'listening' or 'closed'",
+			wantErr: "only apply to directories",
 		},
 		{
-			name: "filesystem invalid state",
+			name: "file test with min entries above max entries",
 			spec: &Spec{
 				Tests: Tests{
-					Filesystems: []FilesystemTest{{Name: "test", Path: "/mnt", State: "present"}},
+					Files: []FileTest{{Name: "test", Path: "/opt/plugins", Type: "directory", MinEntries: 5, MaxEntries: 2}},
 				},
 			},
-			wantErr: "state must be 'mounted' or 'unmounted'",
+			wantErr: "min_entries must not exceed max_entries",
 		},
 		{
-			name: "filesystem invalid max usage percent negative",
+			name: "capabilities test with relative path",
 			spec: &Spec{
 				Tests: Tests{
-					Filesystems: []FilesystemTest{{Name: "test", Path: "/mnt", MaxUsagePercent: -1}},
+					Capabilities: []CapabilitiesTest{{Name: "test", Path: "usr/sbin/nginx"}},
 				},
 			},
-			wantErr: "max_usage_percent must be between 0 and 100",
+			wantErr: "path must be absolute",
 		},
 		{
-			name: "filesystem invalid max usage percent over 100",
+			name: "capabilities test with invalid capability",
 			spec: &Spec{
 				Tests: Tests{
-					Filesystems: []FilesystemTest{{Name: "test", Path: "/mnt", MaxUsagePercent: 101}},
+					Capabilities: []CapabilitiesTest{{Name: "test", Path: "/usr/sbin/nginx", Expected: []string{"net_bind_service"}}},
 				},
 			},
-			wantErr: "max_usage_percent must be between 0 and 100",
+			wantErr: "invalid capability 'net_bind_service'",
 		},
 		{
-			name: "filesystem invalid min size gb",
+			name: "repository test with invalid manager",
 			spec: &Spec{
 				Tests: Tests{
-					Filesystems: []FilesystemTest{{Name: "test", Path: "/mnt", MinSizeGB: -5}},
+					Repositories: []RepositoryTest{{Name: "test", Manager: "zypper", Repo: "oss"}},
 				},
 			},
-			wantErr: "min_size_gb must be >= 0",
+			wantErr: "manager must be 'apt', 'yum' or 'dnf'",
 		},
 		{
-			name: "docker invalid restart policy",
+			name: "apt repository test with non-URL repo",
 			spec: &Spec{
 				Tests: Tests{
-					Docker: []DockerTest{{Name: "test", Container: "c1", RestartPolicy: "sometimes"}},
+					Repositories: []RepositoryTest{{Name: "test", Manager: "apt", Repo: "ppa:deadsnakes/ppa"}},
 				},
 			},
-			wantErr: "restart_policy must be 'no', 'always', 'on-failure', or 'unless-stopped'",
+			wantErr: "apt repo must be a URL",
 		},
 		{
-			name: "docker invalid health",
+			name: "repository test with relative gpg_key",
 			spec: &Spec{
 				Tests: Tests{
-					Docker: []DockerTest{{Name: "test", Container: "c1", Health: "sick"}},
+					Repositories: []RepositoryTest{{Name: "test", Manager: "yum", Repo: "epel", GPGKey: "RPM-GPG-KEY-EPEL-9"}},
 				},
 			},
-			wantErr: "health must be 'healthy', 'unhealthy', 'starting', or 'none'",
+			wantErr: "gpg_key must be an absolute path",
 		},
 		{
-			name: "kubernetes namespace without namespace field",
+			name: "docker image test without image",
 			spec: &Spec{
 				Tests: Tests{
-					Kubernetes: KubernetesTests{
-						Namespaces: []KubernetesNamespaceTest{{Name: "test"}},
-					},
+					DockerImages: []DockerImageTest{{Name: "test"}},
 				},
 			},
-			wantErr: "namespace is required",
+			wantErr: "image is required",
 		},
 		{
-			name: "kubernetes pod without pod field",
+			name: "docker image test with invalid state",
 			spec: &Spec{
 				Tests: Tests{
-					Kubernetes: KubernetesTests{
-						Pods: []KubernetesPodTest{{Name: "test"}},
-					},
+					DockerImages: []DockerImageTest{{Name: "test", Image: "nginx", State: "pulled"}},
 				},
 			},
-			wantErr: "pod is required",
+			wantErr: "state must be 'present' or 'absent'",
 		},
 		{
-			name: "kubernetes pod invalid state",
+			name: "docker image test with malformed digest",
 			spec: &Spec{
 				Tests: Tests{
-					Kubernetes: KubernetesTests{
-						Pods: []KubernetesPodTest{{Name: "test", Pod: "mypod", State: "crashed"}},
-					},
+					DockerImages: []DockerImageTest{{Name: "test", Image: "nginx", Digest: "sha256:abc"}},
 				},
 			},
-			wantErr: "state must be one of: running, pending, succeeded, failed, exists",
+			wantErr: "digest must be 'sha256:' followed by 64 hex characters",
 		},
 		{
-			name: "kubernetes deployment without deployment field",
+			name: "docker network test without network",
 			spec: &Spec{
 				Tests: Tests{
-					Kubernetes: KubernetesTests{
-						Deployments: []KubernetesDeploymentTest{{Name: "test"}},
-					},
+					DockerNetworks: []DockerNetworkTest{{Name: "test"}},
 				},
 			},
-			wantErr: "deployment is required",
+			wantErr: "network is required",
 		},
 		{
-			name: "kubernetes deployment invalid state",
+			name: "docker network test with invalid state",
 			spec: &Spec{
 				Tests: Tests{
-					Kubernetes: KubernetesTests{
-						Deployments: []KubernetesDeploymentTest{{Name: "test", Deployment: "myapp", State: "ready"}},
-					},
+					DockerNetworks: []DockerNetworkTest{{Name: "test", Network: "appnet", State: "running"}},
 				},
 			},
-			wantErr: "state must be one of: available, progressing, exists",
+			wantErr: "state must be 'present' or 'absent'",
 		},
 		{
-			name: "kubernetes deployment invalid replicas",
+			name: "docker volume test without volume",
 			spec: &Spec{
 				Tests: Tests{
-					Kubernetes: KubernetesTests{
-						Deployments: []KubernetesDeploymentTest{{Name: "test", Deployment: "myapp", Replicas: -1}},
-					},
+					DockerVolumes: []DockerVolumeTest{{Name: "test"}},
 				},
 			},
-			wantErr: "replicas must be >= 0",
+			wantErr: "volume is required",
 		},
 		{
-			name: "kubernetes deployment invalid ready_replicas",
+			name: "docker volume test with driver and state absent",
 			spec: &Spec{
 				Tests: Tests{
-					Kubernetes: KubernetesTests{
-						Deployments: []KubernetesDeploymentTest{{Name: "test", Deployment: "myapp", ReadyReplicas: -2}},
-					},
+					DockerVolumes: []DockerVolumeTest{{Name: "test", Volume: "pgdata", Driver: "local", State: "absent"}},
 				},
 			},
-			wantErr: "ready_replicas must be >= 0",
+			wantErr: "driver cannot be used with state 'absent'",
 		},
 		{
-			name: "kubernetes node without name",
+			name: "docker compose test with invalid project",
 			spec: &Spec{
 				Tests: Tests{
-					Kubernetes: KubernetesTests{
-						Nodes: []KubernetesNodeTest{{Count: 3}},
-					},
+					DockerCompose: []DockerComposeTest{{Name: "test", Project: "My Shop", AllServicesUp: true}},
 				},
 			},
-			wantErr: "name is required",
+			wantErr: "project must contain only lowercase letters",
 		},
 		{
-			name: "kubernetes node invalid count",
+			name: "docker compose test without services",
 			spec: &Spec{
 				Tests: Tests{
-					Kubernetes: KubernetesTests{
-						Nodes: []KubernetesNodeTest{{Name: "test", Count: -1}},
-					},
+					DockerCompose: []DockerComposeTest{{Name: "test", Project: "shop"}},
 				},
 			},
-			wantErr: "count must be non-negative",
+			wantErr: "all_services_up or services is required",
 		},
 		{
-			name: "kubernetes node invalid min_count",
+			name: "systemd property test without properties",
 			spec: &Spec{
 				Tests: Tests{
-					Kubernetes: KubernetesTests{
-						Nodes: []KubernetesNodeTest{{Name: "test", MinCount: -1}},
-					},
+					SystemdProps: []SystemdPropertyTest{{Name: "test", Unit: "nginx.service"}},
 				},
 			},
-			wantErr: "min_count must be non-negative",
+			wantErr: "at least one property is required",
 		},
 		{
-			name: "kubernetes node invalid min_ready",
+			name: "systemd property test with invalid property name",
 			spec: &Spec{
 				Tests: Tests{
-					Kubernetes: KubernetesTests{
-						Nodes: []KubernetesNodeTest{{Name: "test", MinReady: -1}},
-					},
+					SystemdProps: []SystemdPropertyTest{{Name: "test", Unit: "nginx.service", Properties: map[string]string{"restart; reboot": "always"}}},
 				},
 			},
-			wantErr: "min_ready must be non-negative",
+			wantErr: "invalid property name",
 		},
 		{
-			name: "kubernetes crd without name",
+			name: "sshd_config test without value",
 			spec: &Spec{
 				Tests: Tests{
-					Kubernetes: KubernetesTests{
-						CRDs: []KubernetesCRDTest{{CRD: "certificates.cert-manager.io"}},
-					},
+					SSHConfig: []SSHConfigTest{{Name: "test", Setting: "PermitRootLogin"}},
 				},
 			},
-			wantErr: "name is required",
+			wantErr: "value is required",
 		},
 		{
-			name: "kubernetes crd without crd field",
+			name: "sshd_config test with multi-word setting",
 			spec: &Spec{
 				Tests: Tests{
-					Kubernetes: KubernetesTests{
-						CRDs: []KubernetesCRDTest{{Name: "test"}},
-					},
+					SSHConfig: []SSHConfigTest{{Name: "test", Setting: "PermitRootLogin no", Value: "no"}},
 				},
 			},
-			wantErr: "crd is required",
+			wantErr: "setting must be a single sshd keyword",
 		},
 		{
-			name: "kubernetes crd invalid state",
+			name: "logrotate test without checks",
 			spec: &Spec{
 				Tests: Tests{
-					Kubernetes: KubernetesTests{
-						CRDs: []KubernetesCRDTest{{Name: "test", CRD: "test.example.com", State: "installed"}},
-					},
+					LogRotate: []LogRotateTest{{Name: "test", Path: "/var/log/syslog"}},
 				},
 			},
-			wantErr: "state must be 'present' or 'absent'",
+			wantErr: "max_size or config_present is required",
 		},
 		{
-			name: "kubernetes helm without name",
+			name: "logrotate test with invalid max_size",
 			spec: &Spec{
 				Tests: Tests{
-					Kubernetes: KubernetesTests{
-						Helm: []KubernetesHelmTest{{Release: "prometheus"}},
-					},
+					LogRotate: []LogRotateTest{{Name: "test", Path: "/var/log/syslog", MaxSizeBytes: "lots"}},
 				},
 			},
-			wantErr: "name is required",
+			wantErr: "invalid size format",
 		},
 		{
-			name: "kubernetes helm without release",
+			name: "cron test without command or schedule",
 			spec: &Spec{
 				Tests: Tests{
-					Kubernetes: KubernetesTests{
-						Helm: []KubernetesHelmTest{{Name: "test"}},
-					},
+					Cron: []CronTest{{Name: "test", User: "backup"}},
 				},
 			},
-			wantErr: "release is required",
+			wantErr: "command or schedule is required",
 		},
 		{
-			name: "kubernetes helm invalid state",
+			name: "cron test with invalid state",
 			spec: &Spec{
 				Tests: Tests{
-					Kubernetes: KubernetesTests{
-						Helm: []KubernetesHelmTest{{Name: "test", Release: "prometheus", State: "running"}},
-					},
+					Cron: []CronTest{{Name: "test", Command: "backup.sh", State: "enabled"}},
 				},
 			},
-			wantErr: "state must be one of",
+			wantErr: "state must be 'present' or 'absent'",
 		},
 		{
-			name: "kubernetes storageclass without name",
+			name: "http test with body on GET",
 			spec: &Spec{
 				Tests: Tests{
-					Kubernetes: KubernetesTests{
-						StorageClasses: []KubernetesStorageClassTest{{StorageClass: "fast-ssd"}},
-					},
+					HTTP: []HTTPTest{{Name: "test", URL: "http://localhost", Body: `{"a":1}`}},
 				},
 			},
-			wantErr: "name is required",
+			wantErr: "body is only allowed with method POST, PUT or PATCH, not GET",
 		},
 		{
-			name: "kubernetes storageclass without storageclass field",
+			name: "http test with invalid header name",
 			spec: &Spec{
 				Tests: Tests{
-					Kubernetes: KubernetesTests{
-						StorageClasses: []KubernetesStorageClassTest{{Name: "test"}},
-					},
+					HTTP: []HTTPTest{{Name: "test", URL: "http://localhost", Headers: map[string]string{"X Token": "a"}}},
 				},
 			},
-			wantErr: "storageclass is required",
+			wantErr: "invalid header name 'X Token'",
 		},
 		{
-			name: "kubernetes storageclass invalid state",
+			name: "http test with line break in header",
 			spec: &Spec{
 				Tests: Tests{
-					Kubernetes: KubernetesTests{
-						StorageClasses: []KubernetesStorageClassTest{{Name: "test", StorageClass: "fast-ssd", State: "active"}},
-					},
+					HTTP: []HTTPTest{{Name: "test", URL: "http://localhost", Headers: map[string]string{"X-Token": "a\r\nHost: evil"}}},
 				},
 			},
-			wantErr: "state must be 'present' or 'absent'",
+			wantErr: "header 'X-Token' must not contain line breaks",
 		},
 		{
-			name: "kubernetes secret without name",
+			name: "http test with invalid json path",
 			spec: &Spec{
 				Tests: Tests{
-					Kubernetes: KubernetesTests{
-						Secrets: []KubernetesSecretTest{{Secret: "db-password"}},
-					},
+					HTTP: []HTTPTest{{Name: "test", URL: "http://localhost", JSONPath: map[string]string{"$.items[x]": "a"}}},
 				},
 			},
-			wantErr: "name is required",
+			wantErr: "bad index [x]",
 		},
 		{
-			name: "kubernetes secret without secret field",
+			name: "process test without process",
 			spec: &Spec{
 				Tests: Tests{
-					Kubernetes: KubernetesTests{
-						Secrets: []KubernetesSecretTest{{Name: "test"}},
-					},
+					Processes: []ProcessTest{{Name: "test", MinCount: 4}},
 				},
 			},
-			wantErr: "secret is required",
+			wantErr: "process is required",
 		},
 		{
-			name: "kubernetes secret invalid state",
+			name: "process test with negative count",
 			spec: &Spec{
 				Tests: Tests{
-					Kubernetes: KubernetesTests{
-						Secrets: []KubernetesSecretTest{{Name: "test", Secret: "db-password", State: "active"}},
-					},
+					Processes: []ProcessTest{{Name: "test", Process: "nginx", MinCount: -1}},
 				},
 			},
-			wantErr: "state must be 'present' or 'absent'",
+			wantErr: "min_count and max_count must not be negative",
 		},
 		{
-			name: "kubernetes secret invalid type",
+			name: "process test with min_count above max_count",
 			spec: &Spec{
 				Tests: Tests{
-					Kubernetes: KubernetesTests{
-						Secrets: []KubernetesSecretTest{{Name: "test", Secret: "db-password", Type: "invalid-type"}},
-					},
+					Processes: []ProcessTest{{Name: "test", Process: "nginx", MinCount: 4, MaxCount: 2}},
 				},
 			},
-			wantErr: "type must be one of",
+			wantErr: "min_count (4) must not be greater than max_count (2)",
 		},
 		{
-			name: "kubernetes ingress without name",
+			name: "process test with counts when absent",
 			spec: &Spec{
 				Tests: Tests{
-					Kubernetes: KubernetesTests{
-						Ingress: []KubernetesIngressTest{{Ingress: "myapp-ingress"}},
-					},
+					Processes: []ProcessTest{{Name: "test", Process: "telnetd", State: "absent", MaxCount: 1}},
 				},
 			},
-			wantErr: "name is required",
+			wantErr: "only apply when state is running",
 		},
 		{
-			name: "kubernetes ingress without ingress field",
+			name: "process test with invalid pattern",
 			spec: &Spec{
 				Tests: Tests{
-					Kubernetes: KubernetesTests{
-						Ingress: []KubernetesIngressTest{{Name: "test"}},
-					},
+					Processes: []ProcessTest{{Name: "test", Process: "nginx("}},
 				},
 			},
-			wantErr: "ingress is required",
+			wantErr: "invalid process pattern",
 		},
 		{
-			name: "kubernetes ingress invalid state",
+			name: "metric test without command",
 			spec: &Spec{
 				Tests: Tests{
-					Kubernetes: KubernetesTests{
-						Ingress: []KubernetesIngressTest{{Name: "test", Ingress: "myapp-ingress", State: "ready"}},
-					},
+					Metrics: []MetricTest{{Name: "test", Operator: "lt", Threshold: 10}},
 				},
 			},
-			wantErr: "state must be 'present' or 'absent'",
+			wantErr: "command is required",
 		},
 		{
-			name: "kubernetes pvc without name",
+			name: "metric test with invalid operator",
 			spec: &Spec{
 				Tests: Tests{
-					Kubernetes: KubernetesTests{
-						PVCs: []KubernetesPVCTest{{PVC: "data-pvc"}},
-					},
+					Metrics: []MetricTest{{Name: "test", Command: "echo 1", Operator: "<", Threshold: 10}},
 				},
 			},
-			wantErr: "name is required",
+			wantErr: "operator must be one of gt, lt, gte, lte, eq, ne",
 		},
 		{
-			name: "kubernetes pvc without pvc field",
+			name: "command_json test without paths",
 			spec: &Spec{
 				Tests: Tests{
-					Kubernetes: KubernetesTests{
-						PVCs: []KubernetesPVCTest{{Name: "test"}},
-					},
+					CommandJSON: []CommandJSONTest{{Name: "test", Command: "docker info --format '{{json .}}'"}},
 				},
 			},
-			wantErr: "pvc is required",
+			wantErr: "at least one json path is required",
 		},
 		{
-			name: "kubernetes pvc invalid state",
+			name: "command_json test with invalid path",
 			spec: &Spec{
 				Tests: Tests{
-					Kubernetes: KubernetesTests{
-						PVCs: []KubernetesPVCTest{{Name: "test", PVC: "data-pvc", State: "active"}},
-					},
+					CommandJSON: []CommandJSONTest{{Name: "test", Command: "docker info --format '{{json .}}'", JSON: map[string]string{"$.Plugins[x]": "y"}}},
 				},
 			},
-			wantErr: "state must be 'present' or 'absent'",
+			wantErr: "bad index",
 		},
 		{
-			name: "kubernetes pvc invalid status",
+			name: "command_content test with invalid run_as",
 			spec: &Spec{
 				Tests: Tests{
-					Kubernetes: KubernetesTests{
-						PVCs: []KubernetesPVCTest{{Name: "test", PVC: "data-pvc", Status: "Ready"}},
-					},
+					CommandContent: []CommandContentTest{{Name: "test", Command: "id", Contains: []string{"uid"}, RunAs: "app; rm -rf /"}},
 				},
 			},
-			wantErr: "status must be 'Bound', 'Pending', or 'Lost'",
+			wantErr: "invalid run_as user",
 		},
 		{
-			name: "kubernetes statefulset without name",
+			name: "command_content test with exit_code and exit_code_in",
 			spec: &Spec{
 				Tests: Tests{
-					Kubernetes: KubernetesTests{
-						StatefulSets: []KubernetesStatefulSetTest{{StatefulSet: "postgres"}},
-					},
+					CommandContent: []CommandContentTest{{Name: "test", Command: "true", ExitCode: intPtr(0), ExitCodeIn: []int{0, 1}}},
 				},
 			},
-			wantErr: "name is required",
+			wantErr: "exit_code and exit_code_in are mutually exclusive",
 		},
 		{
-			name: "kubernetes statefulset without statefulset field",
+			name: "command_content test with explicit exit code 0",
 			spec: &Spec{
 				Tests: Tests{
-					Kubernetes: KubernetesTests{
-						StatefulSets: []KubernetesStatefulSetTest{{Name: "test"}},
-					},
+					CommandContent: []CommandContentTest{{Name: "test", Command: "true", ExitCode: intPtr(0)}},
 				},
 			},
-			wantErr: "statefulset is required",
 		},
 		{
-			name: "kubernetes statefulset invalid state",
+			name: "composite test with both any_of and all_of",
 			spec: &Spec{
 				Tests: Tests{
-					Kubernetes: KubernetesTests{
-						StatefulSets: []KubernetesStatefulSetTest{{Name: "test", StatefulSet: "postgres", State: "running"}},
-					},
+					Composite: []CompositeTest{{Name: "test", AnyOf: &Tests{}, AllOf: &Tests{}}},
 				},
 			},
-			wantErr: "state must be 'available' or 'exists'",
+			wantErr: "exactly one of any_of or all_of is required",
 		},
 		{
-			name: "kubernetes statefulset invalid replicas",
+			name: "composite test without sub-tests",
 			spec: &Spec{
 				Tests: Tests{
-					Kubernetes: KubernetesTests{
-						StatefulSets: []KubernetesStatefulSetTest{{Name: "test", StatefulSet: "postgres", Replicas: -1}},
-					},
+					Composite: []CompositeTest{{Name: "test", AnyOf: &Tests{}}},
 				},
 			},
-			wantErr: "replicas must be >= 0",
+			wantErr: "at least one sub-test is required",
 		},
 		{
-			name: "kubernetes statefulset invalid ready_replicas",
+			name: "composite test with invalid sub-test",
 			spec: &Spec{
 				Tests: Tests{
-					Kubernetes: KubernetesTests{
-						StatefulSets: []KubernetesStatefulSetTest{{Name: "test", StatefulSet: "postgres", ReadyReplicas: -1}},
-					},
+					Composite: []CompositeTest{{Name: "test", AllOf: &Tests{Services: []ServiceTest{{Name: "nginx", Service: "nginx", State: "up"}}}}},
 				},
 			},
-			wantErr: "ready_replicas must be >= 0",
+			wantErr: "composite test 'test': service test 'nginx': state must be 'running' or 'stopped'",
 		},
 	}
 
@@ -2526,3 +3528,34 @@ tests:
 		}
 	})
 }
+
+func TestApplyHTTPProxy(t *testing.T) {
+	spec := &Spec{
+		Tests: Tests{
+			HTTP: []HTTPTest{
+				{Name: "inherits", URL: "http://example.com"},
+				{Name: "keeps own", URL: "http://example.com", Proxy: "http://own:8080", NoProxy: []string{"own.local"}},
+			},
+		},
+	}
+
+	spec.ApplyHTTPProxy("http://proxy.corp:3128", []string{"localhost"})
+
+	if got := spec.Tests.HTTP[0].Proxy; got != "http://proxy.corp:3128" {
+		t.Errorf("Proxy = %q, want default proxy", got)
+	}
+	if len(spec.Tests.HTTP[0].NoProxy) != 1 || spec.Tests.HTTP[0].NoProxy[0] != "localhost" {
+		t.Errorf("NoProxy = %v, want [localhost]", spec.Tests.HTTP[0].NoProxy)
+	}
+	if got := spec.Tests.HTTP[1].Proxy; got != "http://own:8080" {
+		t.Errorf("Proxy = %q, want test-level proxy to win", got)
+	}
+	if spec.Tests.HTTP[1].NoProxy[0] != "own.local" {
+		t.Errorf("NoProxy = %v, want test-level no_proxy to win", spec.Tests.HTTP[1].NoProxy)
+	}
+}
+
+// intPtr returns a pointer to n
+func intPtr(n int) *int {
+	return &n
+}
@@ -0,0 +1,142 @@
+package system
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/neilfarmer/platform-spec/pkg/core"
+)
+
+// executeCapabilitiesTest executes a Linux file capabilities test
+func executeCapabilitiesTest(ctx context.Context, provider core.Provider, test core.CapabilitiesTest) core.Result {
+	start := time.Now()
+	result := core.Result{
+		Name:    test.Name,
+		Status:  core.StatusPass,
+		Details: make(map[string]interface{}),
+	}
+
+	// getcap usually lives in sbin, which is not on PATH for unprivileged users
+	path := core.ShellQuote(test.Path)
+	cmd := fmt.Sprintf("if [ -e %s ]; then PATH=\"$PATH:/usr/sbin:/sbin\" getcap %s; else echo 'notfound'; fi", path, path)
+	stdout, stderr, exitCode, err := provider.ExecuteCommand(ctx, cmd)
+	if err != nil {
+		result.Status = core.StatusError
+		result.Message = fmt.Sprintf("Error checking capabilities: %v", err)
+		result.Duration = time.Since(start)
+		return result
+	}
+	if exitCode == 127 {
+		result.Status = core.StatusError
+		result.Message = "getcap is not installed on the target"
+		result.Duration = time.Since(start)
+		return result
+	}
+	if exitCode != 0 {
+		result.Status = core.StatusError
+		result.Message = fmt.Sprintf("getcap failed for %s: %s", test.Path, strings.TrimSpace(stderr))
+		result.Duration = time.Since(start)
+		return result
+	}
+
+	output := strings.TrimSpace(stdout)
+	if output == "notfound" {
+		result.Status = core.StatusFail
+		result.Message = fmt.Sprintf("Path %s does not exist", test.Path)
+		result.Duration = time.Since(start)
+		return result
+	}
+
+	actual := parseGetcap(output, test.Path)
+	expected := make(map[string]bool)
+	for _, c := range test.Expected {
+		for _, capability := range parseCapabilityClause(c) {
+			expected[capability] = true
+		}
+	}
+
+	var missing, extra []string
+	for capability := range expected {
+		if !actual[capability] {
+			missing = append(missing, capability)
+		}
+	}
+	for capability := range actual {
+		if !expected[capability] {
+			extra = append(extra, capability)
+		}
+	}
+	sort.Strings(missing)
+	sort.Strings(extra)
+
+	result.Details["capabilities"] = sortedKeys(actual)
+
+	if len(missing) > 0 || len(extra) > 0 {
+		var problems []string
+		if len(missing) > 0 {
+			problems = append(problems, fmt.Sprintf("missing %s", strings.Join(missing, ", ")))
+		}
+		if len(extra) > 0 {
+			problems = append(problems, fmt.Sprintf("unexpected %s", strings.Join(extra, ", ")))
+		}
+		result.Status = core.StatusFail
+		result.Message = fmt.Sprintf("Capabilities of %s do not match: %s", test.Path, strings.Join(problems, "; "))
+		result.Duration = time.Since(start)
+		return result
+	}
+
+	if len(actual) == 0 {
+		result.Message = fmt.Sprintf("%s has no file capabilities", test.Path)
+	} else {
+		result.Message = fmt.Sprintf("%s has capabilities %s", test.Path, strings.Join(sortedKeys(actual), ", "))
+	}
+	result.Duration = time.Since(start)
+	return result
+}
+
+// parseGetcap parses getcap output into a set of capability+flags entries.
+// Both the old format ("/bin/ping = cap_net_raw+ep") and the libcap 2.41+
+// format ("/bin/ping cap_net_raw=ep") are supported. Empty output means the
+// file has no capabilities.
+func parseGetcap(output, path string) map[string]bool {
+	caps := make(map[string]bool)
+	output = strings.TrimSpace(strings.TrimPrefix(output, path))
+	output = strings.TrimSpace(strings.TrimPrefix(output, "="))
+	for _, clause := range strings.Fields(output) {
+		for _, capability := range parseCapabilityClause(clause) {
+			caps[capability] = true
+		}
+	}
+	return caps
+}
+
+// parseCapabilityClause expands a clause such as "cap_net_admin,cap_net_raw=ep"
+// into normalized entries ("cap_net_admin+ep", "cap_net_raw+ep")
+func parseCapabilityClause(clause string) []string {
+	clause = strings.ToLower(clause)
+	i := strings.IndexAny(clause, "+=")
+	if i < 0 {
+		return nil
+	}
+	flags := []byte(clause[i+1:])
+	sort.Slice(flags, func(a, b int) bool { return flags[a] < flags[b] })
+
+	var caps []string
+	for _, name := range strings.Split(clause[:i], ",") {
+		caps = append(caps, name+"+"+string(flags))
+	}
+	return caps
+}
+
+// sortedKeys returns the keys of a set in sorted order
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for k := range set {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,126 @@
+package system
+
+import (
+	"context"
+	"testing"
+
+	"github.com/neilfarmer/platform-spec/pkg/core"
+)
+
+func TestExecutor_CapabilitiesTest(t *testing.T) {
+	const nginxCmd = `if [ -e '/usr/sbin/nginx' ]; then PATH="$PATH:/usr/sbin:/sbin" getcap '/usr/sbin/nginx'; else echo 'notfound'; fi`
+
+	tests := []struct {
+		name         string
+		capTest      core.CapabilitiesTest
+		setupMock    func(*core.MockProvider)
+		wantStatus   core.Status
+		wantContains string
+	}{
+		{
+			name: "matching capability (libcap 2.41+ format)",
+			capTest: core.CapabilitiesTest{
+				Name:     "nginx binds low ports",
+				Path:     "/usr/sbin/nginx",
+				Expected: []string{"cap_net_bind_service+ep"},
+			},
+			setupMock: func(m *core.MockProvider) {
+				m.SetCommandResult(nginxCmd, "/usr/sbin/nginx cap_net_bind_service=ep\n", "", 0, nil)
+			},
+			wantStatus:   core.StatusPass,
+			wantContains: "has capabilities cap_net_bind_service+ep",
+		},
+		{
+			name: "matching capabilities (old format)",
+			capTest: core.CapabilitiesTest{
+				Name:     "nginx caps",
+				Path:     "/usr/sbin/nginx",
+				Expected: []string{"cap_net_raw+pe", "cap_net_bind_service+ep"},
+			},
+			setupMock: func(m *core.MockProvider) {
+				m.SetCommandResult(nginxCmd, "/usr/sbin/nginx = cap_net_bind_service,cap_net_raw+ep\n", "", 0, nil)
+			},
+			wantStatus: core.StatusPass,
+		},
+		{
+			name: "unexpected extra capability",
+			capTest: core.CapabilitiesTest{
+				Name:     "nginx binds low ports",
+				Path:     "/usr/sbin/nginx",
+				Expected: []string{"cap_net_bind_service+ep"},
+			},
+			setupMock: func(m *core.MockProvider) {
+				m.SetCommandResult(nginxCmd, "/usr/sbin/nginx cap_net_bind_service,cap_sys_admin=ep\n", "", 0, nil)
+			},
+			wantStatus:   core.StatusFail,
+			wantContains: "unexpected cap_sys_admin+ep",
+		},
+		{
+			name: "missing capability",
+			capTest: core.CapabilitiesTest{
+				Name:     "nginx binds low ports",
+				Path:     "/usr/sbin/nginx",
+				Expected: []string{"cap_net_bind_service+ep"},
+			},
+			setupMock: func(m *core.MockProvider) {
+				m.SetCommandResult(nginxCmd, "", "", 0, nil)
+			},
+			wantStatus:   core.StatusFail,
+			wantContains: "missing cap_net_bind_service+ep",
+		},
+		{
+			name: "no capabilities expected",
+			capTest: core.CapabilitiesTest{
+				Name: "nginx has no caps",
+				Path: "/usr/sbin/nginx",
+			},
+			setupMock: func(m *core.MockProvider) {
+				m.SetCommandResult(nginxCmd, "", "", 0, nil)
+			},
+			wantStatus:   core.StatusPass,
+			wantContains: "has no file capabilities",
+		},
+		{
+			name: "binary does not exist",
+			capTest: core.CapabilitiesTest{
+				Name:     "nginx binds low ports",
+				Path:     "/usr/sbin/nginx",
+				Expected: []string{"cap_net_bind_service+ep"},
+			},
+			setupMock: func(m *core.MockProvider) {
+				m.SetCommandResult(nginxCmd, "notfound\n", "", 0, nil)
+			},
+			wantStatus:   core.StatusFail,
+			wantContains: "does not exist",
+		},
+		{
+			name: "getcap not installed",
+			capTest: core.CapabilitiesTest{
+				Name:     "nginx binds low ports",
+				Path:     "/usr/sbin/nginx",
+				Expected: []string{"cap_net_bind_service+ep"},
+			},
+			setupMock: func(m *core.MockProvider) {
+				m.SetCommandResult(nginxCmd, "", "sh: getcap: not found", 127, nil)
+			},
+			wantStatus:   core.StatusError,
+			wantContains: "getcap is not installed",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := core.NewMockProvider()
+			tt.setupMock(mock)
+
+			result := executeCapabilitiesTest(context.Background(), mock, tt.capTest)
+
+			if result.Status != tt.wantStatus {
+				t.Errorf("Status = %v, want %v (message: %s)", result.Status, tt.wantStatus, result.Message)
+			}
+			if tt.wantContains != "" && !contains(result.Message, tt.wantContains) {
+				t.Errorf("Message %q does not contain %q", result.Message, tt.wantContains)
+			}
+		})
+	}
+}
@@ -3,6 +3,7 @@ package system
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
@@ -19,7 +20,7 @@ func executeCommandContentTest(ctx context.Context, provider core.Provider, test
 	}
 
 	// Execute the command
-	stdout, stderr, exitCode, err := provider.ExecuteCommand(ctx, test.Command)
+	stdout, stderr, exitCode, err := executeCommandAs(ctx, provider, test.Command, test.RunAs)
 	if err != nil {
 		result.Status = core.StatusError
 		result.Message = fmt.Sprintf("Error executing command '%s': %v", test.Command, err)
@@ -32,9 +33,21 @@ func executeCommandContentTest(ctx context.Context, provider core.Provider, test
 	result.Details["stderr_length"] = len(stderr)
 
 	// Check exit code if specified
-	if test.ExitCode != 0 && exitCode != test.ExitCode {
+	if test.ExitCode != nil && exitCode != *test.ExitCode {
 		result.Status = core.StatusFail
-		result.Message = fmt.Sprintf("Command exit code is %d, expected %d", exitCode, test.ExitCode)
+		result.Message = fmt.Sprintf("Command exit code is %d, expected %d", exitCode, *test.ExitCode)
+		result.Duration = time.Since(start)
+		return result
+	}
+	if len(test.ExitCodeIn) > 0 && !containsInt(test.ExitCodeIn, exitCode) {
+		result.Status = core.StatusFail
+		result.Message = fmt.Sprintf("Command exit code is %d, expected one of %s", exitCode, joinInts(test.ExitCodeIn))
+		result.Duration = time.Since(start)
+		return result
+	}
+	if containsInt(test.ExitCodeNot, exitCode) {
+		result.Status = core.StatusFail
+		result.Message = fmt.Sprintf("Command exit code is %d, expected none of %s", exitCode, joinInts(test.ExitCodeNot))
 		result.Duration = time.Since(start)
 		return result
 	}
@@ -54,12 +67,13 @@ func executeCommandContentTest(ctx context.Context, provider core.Provider, test
 	}
 
 	// Build success message
-	if len(test.Contains) > 0 && test.ExitCode != 0 {
-		result.Message = fmt.Sprintf("Command exited with code %d and output contains all %d strings", test.ExitCode, len(test.Contains))
+	checksExitCode := test.ExitCode != nil || len(test.ExitCodeIn) > 0 || len(test.ExitCodeNot) > 0
+	if len(test.Contains) > 0 && checksExitCode {
+		result.Message = fmt.Sprintf("Command exited with code %d and output contains all %d strings", exitCode, len(test.Contains))
 	} else if len(test.Contains) > 0 {
 		result.Message = fmt.Sprintf("Command output contains all %d strings", len(test.Contains))
-	} else if test.ExitCode != 0 {
-		result.Message = fmt.Sprintf("Command exited with expected code %d", test.ExitCode)
+	} else if checksExitCode {
+		result.Message = fmt.Sprintf("Command exited with expected code %d", exitCode)
 	} else {
 		result.Message = "Command executed successfully"
 	}
@@ -67,3 +81,22 @@ func executeCommandContentTest(ctx context.Context, provider core.Provider, test
 	result.Duration = time.Since(start)
 	return result
 }
+
+// containsInt reports whether values contains n
+func containsInt(values []int, n int) bool {
+	for _, v := range values {
+		if v == n {
+			return true
+		}
+	}
+	return false
+}
+
+// joinInts formats integers as a comma-separated list
+func joinInts(values []int) string {
+	parts := make([]string, len(values))
+	for i, v := range values {
+		parts[i] = strconv.Itoa(v)
+	}
+	return strings.Join(parts, ", ")
+}
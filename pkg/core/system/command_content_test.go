@@ -46,20 +46,20 @@ func TestExecutor_CommandContentTest(t *testing.T) {
 			commandContentTest: core.CommandContentTest{
 				Name:     "Service status",
 				Command:  "systemctl is-active docker",
-				ExitCode: 0,
+				ExitCode: intPtr(0),
 			},
 			setupMock: func(m *core.MockProvider) {
 				m.SetCommandResult("systemctl is-active docker", "active", "", 0, nil)
 			},
 			wantStatus:   core.StatusPass,
-			wantContains: "executed successfully",
+			wantContains: "exited with expected code 0",
 		},
 		{
 			name: "command wrong exit code",
 			commandContentTest: core.CommandContentTest{
 				Name:     "Service should fail",
 				Command:  "systemctl is-active nonexistent",
-				ExitCode: 3,
+				ExitCode: intPtr(3),
 			},
 			setupMock: func(m *core.MockProvider) {
 				m.SetCommandResult("systemctl is-active nonexistent", "inactive", "", 0, nil)
@@ -85,7 +85,7 @@ func TestExecutor_CommandContentTest(t *testing.T) {
 			commandContentTest: core.CommandContentTest{
 				Name:     "Success with content",
 				Command:  "echo hello",
-				ExitCode: 0,
+				ExitCode: intPtr(0),
 				Contains: []string{"hello"},
 			},
 			setupMock: func(m *core.MockProvider) {
@@ -94,6 +94,128 @@ func TestExecutor_CommandContentTest(t *testing.T) {
 			wantStatus:   core.StatusPass,
 			wantContains: "contains all 1",
 		},
+		{
+			name: "command runs as another user",
+			commandContentTest: core.CommandContentTest{
+				Name:     "App user can read config",
+				Command:  "cat /etc/app/config.yml",
+				Contains: []string{"listen:"},
+				RunAs:    "app",
+			},
+			setupMock: func(m *core.MockProvider) {
+				m.SetCommandResult("sudo -n -u 'app' -- sh -c 'cat /etc/app/config.yml'", "listen: 0.0.0.0:8080\n", "", 0, nil)
+			},
+			wantStatus:   core.StatusPass,
+			wantContains: "contains all 1 strings",
+		},
+		{
+			name: "switching user is denied",
+			commandContentTest: core.CommandContentTest{
+				Name:     "App user can read config",
+				Command:  "cat /etc/app/config.yml",
+				Contains: []string{"listen:"},
+				RunAs:    "app",
+			},
+			setupMock: func(m *core.MockProvider) {
+				m.SetCommandResult("sudo -n -u 'app' -- sh -c 'cat /etc/app/config.yml'", "", "sudo: a password is required\n", 1, nil)
+			},
+			wantStatus:   core.StatusError,
+			wantContains: "cannot run as app: sudo: a password is required",
+		},
+		{
+			name: "sudo not installed",
+			commandContentTest: core.CommandContentTest{
+				Name:     "App user can read config",
+				Command:  "cat /etc/app/config.yml",
+				Contains: []string{"listen:"},
+				RunAs:    "app",
+			},
+			setupMock: func(m *core.MockProvider) {
+				m.SetCommandResult("sudo -n -u 'app' -- sh -c 'cat /etc/app/config.yml'", "", "sh: 1: sudo: not found\n", 127, nil)
+			},
+			wantStatus:   core.StatusError,
+			wantContains: "sudo is not available",
+		},
+		{
+			name: "run_as on Windows",
+			commandContentTest: core.CommandContentTest{
+				Name:     "Service account",
+				Command:  "whoami",
+				Contains: []string{"svc"},
+				RunAs:    "svc",
+			},
+			setupMock: func(m *core.MockProvider) {
+				m.SetOS(core.OSWindows)
+			},
+			wantStatus:   core.StatusError,
+			wantContains: "not supported on Windows",
+		},
+		{
+			name: "explicit exit code 0 fails on non-zero exit",
+			commandContentTest: core.CommandContentTest{
+				Name:     "Config valid",
+				Command:  "nginx -t",
+				ExitCode: intPtr(0),
+			},
+			setupMock: func(m *core.MockProvider) {
+				m.SetCommandResult("nginx -t", "", "nginx: configuration file test failed", 1, nil)
+			},
+			wantStatus:   core.StatusFail,
+			wantContains: "exit code is 1, expected 0",
+		},
+		{
+			name: "exit code in allowed set",
+			commandContentTest: core.CommandContentTest{
+				Name:       "Unit not failed",
+				Command:    "systemctl is-active app",
+				ExitCodeIn: []int{0, 3},
+			},
+			setupMock: func(m *core.MockProvider) {
+				m.SetCommandResult("systemctl is-active app", "inactive", "", 3, nil)
+			},
+			wantStatus:   core.StatusPass,
+			wantContains: "exited with expected code 3",
+		},
+		{
+			name: "exit code outside allowed set",
+			commandContentTest: core.CommandContentTest{
+				Name:       "Unit not failed",
+				Command:    "systemctl is-active app",
+				ExitCodeIn: []int{0, 3},
+			},
+			setupMock: func(m *core.MockProvider) {
+				m.SetCommandResult("systemctl is-active app", "failed", "", 4, nil)
+			},
+			wantStatus:   core.StatusFail,
+			wantContains: "exit code is 4, expected one of 0, 3",
+		},
+		{
+			name: "exit code in forbidden set",
+			commandContentTest: core.CommandContentTest{
+				Name:        "Binary present",
+				Command:     "app --version",
+				ExitCodeNot: []int{126, 127},
+			},
+			setupMock: func(m *core.MockProvider) {
+				m.SetCommandResult("app --version", "", "sh: app: not found", 127, nil)
+			},
+			wantStatus:   core.StatusFail,
+			wantContains: "exit code is 127, expected none of 126, 127",
+		},
+		{
+			name: "exit code not in forbidden set",
+			commandContentTest: core.CommandContentTest{
+				Name:        "Binary present",
+				Command:     "app --version",
+				ExitCodeNot: []int{126, 127},
+				Contains:    []string{"app"},
+			},
+			setupMock: func(m *core.MockProvider) {
+				m.SetCommandResult("app --version", "app 1.2.3", "", 2, nil)
+			},
+			wantStatus:   core.StatusPass,
+			wantContains: "exited with code 2 and output contains all 1 strings",
+		},
 	}
 
 	for _, tt := range tests {
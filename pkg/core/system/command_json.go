@@ -0,0 +1,95 @@
+package system
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/neilfarmer/platform-spec/pkg/core"
+	"github.com/neilfarmer/platform-spec/pkg/jsonpath"
+)
+
+// executeCommandJSONTest executes a command JSON output test
+func executeCommandJSONTest(ctx context.Context, provider core.Provider, test core.CommandJSONTest) core.Result {
+	start := time.Now()
+	result := core.Result{
+		Name:    test.Name,
+		Status:  core.StatusPass,
+		Details: make(map[string]interface{}),
+	}
+
+	stdout, stderr, exitCode, err := executeCommandAs(ctx, provider, test.Command, test.RunAs)
+	if err != nil {
+		result.Status = core.StatusError
+		result.Message = fmt.Sprintf("Error executing command '%s': %v", test.Command, err)
+		result.Duration = time.Since(start)
+		return result
+	}
+	if exitCode != 0 {
+		result.Status = core.StatusError
+		result.Message = fmt.Sprintf("Command exited with code %d: %s", exitCode, strings.TrimSpace(stderr))
+		result.Duration = time.Since(start)
+		return result
+	}
+
+	doc, err := jsonpath.Decode([]byte(stdout))
+	if err != nil {
+		result.Status = core.StatusError
+		result.Message = fmt.Sprintf("Command output is not valid JSON: %v", err)
+		result.Duration = time.Since(start)
+		return result
+	}
+
+	if status, message := checkJSONPaths(doc, test.JSON, "command output", result.Details); status != core.StatusPass {
+		result.Status = status
+		result.Message = message
+		result.Duration = time.Since(start)
+		return result
+	}
+
+	result.Message = fmt.Sprintf("Command output matches all %d JSON paths", len(test.JSON))
+	result.Duration = time.Since(start)
+	return result
+}
+
+// checkJSONPaths evaluates each path against doc in a stable order and
+// compares it with the expected value, recording the values found in details.
+// It returns StatusPass, or the status and message of the first mismatch;
+// source names the document in messages, e.g. "command output".
+func checkJSONPaths(doc interface{}, paths map[string]string, source string, details map[string]interface{}) (core.Status, string) {
+	exprs := make([]string, 0, len(paths))
+	for expr := range paths {
+		exprs = append(exprs, expr)
+	}
+	sort.Strings(exprs)
+
+	for _, expr := range exprs {
+		expected := paths[expr]
+		values, err := jsonpath.Evaluate(doc, expr)
+		if errors.Is(err, jsonpath.ErrNoMatch) {
+			return core.StatusFail, fmt.Sprintf("Path %s not found in %s", expr, source)
+		}
+		if err != nil {
+			return core.StatusError, fmt.Sprintf("Error evaluating %s: %v", expr, err)
+		}
+		details[expr] = strings.Join(values, ", ")
+
+		if !containsString(values, expected) {
+			return core.StatusFail, fmt.Sprintf("Path %s is %s, expected %s", expr, strings.Join(values, ", "), expected)
+		}
+	}
+	return core.StatusPass, ""
+}
+
+// containsString reports whether values contains s
+func containsString(values []string, s string) bool {
+	for _, v := range values {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
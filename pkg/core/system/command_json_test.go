@@ -0,0 +1,113 @@
+package system
+
+import (
+	"context"
+	"testing"
+
+	"github.com/neilfarmer/platform-spec/pkg/core"
+)
+
+func TestExecutor_CommandJSONTest(t *testing.T) {
+	const dockerInfo = `{"ServerVersion":"24.0.7","Containers":12,"Swarm":{"LocalNodeState":"inactive"},"Plugins":{"Network":["bridge","host"]}}`
+	const cmd = "docker info --format '{{json .}}'"
+
+	tests := []struct {
+		name         string
+		json         map[string]string
+		stdout       string
+		exitCode     int
+		wantStatus   core.Status
+		wantContains string
+	}{
+		{
+			name: "all paths match",
+			json: map[string]string{
+				"$.ServerVersion":        "24.0.7",
+				"$.Containers":           "12",
+				"$.Swarm.LocalNodeState": "inactive",
+				"$.Plugins.Network[0]":   "bridge",
+			},
+			stdout:       dockerInfo,
+			wantStatus:   core.StatusPass,
+			wantContains: "matches all 4 JSON paths",
+		},
+		{
+			name:         "value mismatch",
+			json:         map[string]string{"$.ServerVersion": "25.0.0"},
+			stdout:       dockerInfo,
+			wantStatus:   core.StatusFail,
+			wantContains: "Path $.ServerVersion is 24.0.7, expected 25.0.0",
+		},
+		{
+			name:         "missing path",
+			json:         map[string]string{"$.Swarm.NodeID": "abc"},
+			stdout:       dockerInfo,
+			wantStatus:   core.StatusFail,
+			wantContains: "Path $.Swarm.NodeID not found",
+		},
+		{
+			name:         "wildcard matches any element",
+			json:         map[string]string{"$.Plugins.Network[*]": "host"},
+			stdout:       dockerInfo,
+			wantStatus:   core.StatusPass,
+			wantContains: "matches all 1 JSON paths",
+		},
+		{
+			name:         "wildcard matches no element",
+			json:         map[string]string{"$.Plugins.Network[*]": "overlay"},
+			stdout:       dockerInfo,
+			wantStatus:   core.StatusFail,
+			wantContains: "Path $.Plugins.Network[*] is bridge, host, expected overlay",
+		},
+		{
+			name:         "output is not JSON",
+			json:         map[string]string{"$.ServerVersion": "24.0.7"},
+			stdout:       "Cannot connect to the Docker daemon",
+			wantStatus:   core.StatusError,
+			wantContains: "not valid JSON",
+		},
+		{
+			name:         "command fails",
+			json:         map[string]string{"$.ServerVersion": "24.0.7"},
+			exitCode:     1,
+			wantStatus:   core.StatusError,
+			wantContains: "exited with code 1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := core.NewMockProvider()
+			mock.SetCommandResult(cmd, tt.stdout, "", tt.exitCode, nil)
+
+			result := executeCommandJSONTest(context.Background(), mock, core.CommandJSONTest{
+				Name:    "Docker info",
+				Command: cmd,
+				JSON:    tt.json,
+			})
+
+			if result.Status != tt.wantStatus {
+				t.Errorf("Status = %v, want %v (message: %s)", result.Status, tt.wantStatus, result.Message)
+			}
+			if tt.wantContains != "" && !contains(result.Message, tt.wantContains) {
+				t.Errorf("Message %q does not contain %q", result.Message, tt.wantContains)
+			}
+		})
+	}
+}
+
+func TestExecutor_CommandJSONTest_RunAs(t *testing.T) {
+	mock := core.NewMockProvider()
+	mock.SetCommandResult("sudo -n -u 'app' -- sh -c 'app-cli status --json'", `{"healthy":true}`, "", 0, nil)
+
+	result := executeCommandJSONTest(context.Background(), mock, core.CommandJSONTest{
+		Name:    "App status as app user",
+		Command: "app-cli status --json",
+		JSON:    map[string]string{"$.healthy": "true"},
+		RunAs:   "app",
+	})
+
+	if result.Status != core.StatusPass {
+		t.Errorf("Status = %v, want %v (message: %s)", result.Status, core.StatusPass, result.Message)
+	}
+}
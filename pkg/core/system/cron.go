@@ -0,0 +1,210 @@
+package system
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/neilfarmer/platform-spec/pkg/core"
+)
+
+// systemCrontabCommand prints the system crontab and its drop-in files, each
+// line prefixed with the file it came from
+const systemCrontabCommand = "grep -H '' /etc/crontab /etc/cron.d/* 2>/dev/null"
+
+// cronAliases maps schedule shorthands to the five fields they stand for
+var cronAliases = map[string]string{
+	"@hourly":   "0 * * * *",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@weekly":   "0 0 * * 0",
+	"@monthly":  "0 0 1 * *",
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+}
+
+// cronEntry is one scheduled job from a crontab
+type cronEntry struct {
+	Source   string // crontab the entry came from, e.g. /etc/cron.d/backup
+	User     string // user the job runs as
+	Schedule string
+	Command  string
+}
+
+// executeCronTest executes a scheduled job test
+func executeCronTest(ctx context.Context, provider core.Provider, test core.CronTest) core.Result {
+	start := time.Now()
+	result := core.Result{
+		Name:    test.Name,
+		Status:  core.StatusPass,
+		Details: make(map[string]interface{}),
+	}
+
+	entries, err := readCronEntries(ctx, provider, test.User)
+	if err != nil {
+		result.Status = core.StatusError
+		result.Message = fmt.Sprintf("Error reading crontabs: %v", err)
+		result.Duration = time.Since(start)
+		return result
+	}
+
+	job := describeCronJob(test)
+	match, found := findCronEntry(entries, test)
+	if found {
+		result.Details["source"] = match.Source
+		result.Details["schedule"] = match.Schedule
+		result.Details["command"] = match.Command
+	}
+
+	switch {
+	case test.State == "present" && !found:
+		result.Status = core.StatusFail
+		result.Message = fmt.Sprintf("No cron job %s found in %s", job, cronScope(test.User))
+	case test.State == "present":
+		result.Message = fmt.Sprintf("Cron job %s is scheduled at %s as %s (%s)", job, match.Schedule, match.User, match.Source)
+	case found:
+		result.Status = core.StatusFail
+		result.Message = fmt.Sprintf("Cron job %s is scheduled at %s as %s (%s), expected absent", job, match.Schedule, match.User, match.Source)
+	default:
+		result.Message = fmt.Sprintf("No cron job %s in %s", job, cronScope(test.User))
+	}
+	result.Duration = time.Since(start)
+	return result
+}
+
+// readCronEntries returns the entries of the system crontabs, narrowed to
+// those running as user and joined by the user's own crontab if user is set
+func readCronEntries(ctx context.Context, provider core.Provider, user string) ([]cronEntry, error) {
+	stdout, _, _, err := provider.ExecuteCommand(ctx, systemCrontabCommand)
+	if err != nil {
+		return nil, fmt.Errorf("system crontabs: %w", err)
+	}
+	var entries []cronEntry
+	for _, line := range strings.Split(stdout, "\n") {
+		source, line, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		if entry, ok := parseCronLine(line, true); ok && (user == "" || entry.User == user) {
+			entry.Source = source
+			entries = append(entries, entry)
+		}
+	}
+	if user == "" {
+		return entries, nil
+	}
+
+	stdout, stderr, exitCode, err := provider.ExecuteCommand(ctx, fmt.Sprintf("crontab -l -u %s", core.ShellQuote(user)))
+	if err != nil {
+		return nil, fmt.Errorf("crontab of %s: %w", user, err)
+	}
+	if exitCode != 0 {
+		// A user without a crontab is not an error
+		if strings.Contains(stderr, "no crontab") {
+			return entries, nil
+		}
+		return nil, fmt.Errorf("crontab of %s: %s", user, strings.TrimSpace(stderr))
+	}
+	for _, line := range strings.Split(stdout, "\n") {
+		if entry, ok := parseCronLine(line, false); ok {
+			entry.Source = "crontab of " + user
+			entry.User = user
+			entries = append(entries, entry)
+		}
+	}
+	return entries, nil
+}
+
+// parseCronLine parses a crontab line into an entry. System crontab lines
+// name the user between the schedule and the command. Comments, blank lines
+// and variable assignments such as MAILTO=root are not entries.
+func parseCronLine(line string, system bool) (cronEntry, bool) {
+	line = strings.TrimSpace(line)
+	if line == "" || strings.HasPrefix(line, "#") {
+		return cronEntry{}, false
+	}
+	fields := strings.Fields(line)
+	if strings.Contains(fields[0], "=") {
+		return cronEntry{}, false
+	}
+
+	scheduleFields := 5
+	if strings.HasPrefix(fields[0], "@") {
+		scheduleFields = 1
+	}
+	userFields := 0
+	if system {
+		userFields = 1
+	}
+	if len(fields) <= scheduleFields+userFields {
+		return cronEntry{}, false
+	}
+
+	entry := cronEntry{Schedule: strings.Join(fields[:scheduleFields], " ")}
+	if system {
+		entry.User = fields[scheduleFields]
+	}
+	entry.Command = afterFields(line, scheduleFields+userFields)
+	return entry, true
+}
+
+// afterFields returns line with its first n whitespace-separated fields and
+// the whitespace after them removed, keeping the rest as written
+func afterFields(line string, n int) string {
+	rest := line
+	for i := 0; i < n; i++ {
+		rest = strings.TrimLeft(rest, " \t")
+		if end := strings.IndexAny(rest, " \t"); end >= 0 {
+			rest = rest[end:]
+		} else {
+			rest = ""
+		}
+	}
+	return strings.TrimLeft(rest, " \t")
+}
+
+// findCronEntry returns the first entry matching the test's command and schedule
+func findCronEntry(entries []cronEntry, test core.CronTest) (cronEntry, bool) {
+	schedule := normalizeCronSchedule(test.Schedule)
+	for _, entry := range entries {
+		if test.Command != "" && !strings.Contains(entry.Command, test.Command) {
+			continue
+		}
+		if schedule != "" && normalizeCronSchedule(entry.Schedule) != schedule {
+			continue
+		}
+		return entry, true
+	}
+	return cronEntry{}, false
+}
+
+// normalizeCronSchedule collapses whitespace and expands shorthands such as
+// @daily, so equivalent schedules compare equal
+func normalizeCronSchedule(schedule string) string {
+	schedule = strings.Join(strings.Fields(schedule), " ")
+	if expanded, ok := cronAliases[schedule]; ok {
+		return expanded
+	}
+	return schedule
+}
+
+// describeCronJob names the job a test looks for in messages
+func describeCronJob(test core.CronTest) string {
+	switch {
+	case test.Command != "" && test.Schedule != "":
+		return fmt.Sprintf("'%s' at '%s'", test.Command, test.Schedule)
+	case test.Command != "":
+		return fmt.Sprintf("'%s'", test.Command)
+	default:
+		return fmt.Sprintf("at '%s'", test.Schedule)
+	}
+}
+
+// cronScope describes the crontabs a test searched
+func cronScope(user string) string {
+	if user == "" {
+		return "/etc/crontab or /etc/cron.d"
+	}
+	return fmt.Sprintf("the crontabs of %s", user)
+}
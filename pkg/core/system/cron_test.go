@@ -0,0 +1,152 @@
+package system
+
+import (
+	"context"
+	"testing"
+
+	"github.com/neilfarmer/platform-spec/pkg/core"
+)
+
+func TestExecutor_CronTest(t *testing.T) {
+	const systemCrontabs = `/etc/crontab:SHELL=/bin/sh
+/etc/crontab:# m h dom mon dow user	command
+/etc/crontab:17 *	* * *	root    cd / && run-parts --report /etc/cron.hourly
+/etc/cron.d/backup:MAILTO=ops@example.com
+/etc/cron.d/backup:30 2 * * *  backup  /usr/local/bin/backup.sh --full >/var/log/backup.log 2>&1
+/etc/cron.d/certbot:@daily root certbot -q renew
+`
+	const backupCrontab = `# DO NOT EDIT THIS FILE
+0 * * * * /usr/local/bin/backup.sh --incremental
+`
+
+	tests := []struct {
+		name         string
+		cronTest     core.CronTest
+		setupMock    func(*core.MockProvider)
+		wantStatus   core.Status
+		wantContains string
+		wantSource   string
+	}{
+		{
+			name:     "system job present",
+			cronTest: core.CronTest{Name: "Full backup", Command: "backup.sh --full", Schedule: "30 2 * * *", State: "present"},
+			setupMock: func(m *core.MockProvider) {
+				m.SetCommandResult(systemCrontabCommand, systemCrontabs, "", 0, nil)
+			},
+			wantStatus:   core.StatusPass,
+			wantContains: "is scheduled at 30 2 * * * as backup (/etc/cron.d/backup)",
+			wantSource:   "/etc/cron.d/backup",
+		},
+		{
+			name:     "schedule shorthand matches expanded fields",
+			cronTest: core.CronTest{Name: "Cert renewal", Command: "certbot", Schedule: "0 0 * * *", State: "present"},
+			setupMock: func(m *core.MockProvider) {
+				m.SetCommandResult(systemCrontabCommand, systemCrontabs, "", 0, nil)
+			},
+			wantStatus: core.StatusPass,
+			wantSource: "/etc/cron.d/certbot",
+		},
+		{
+			name:     "schedule differs",
+			cronTest: core.CronTest{Name: "Full backup", Command: "backup.sh --full", Schedule: "0 3 * * *", State: "present"},
+			setupMock: func(m *core.MockProvider) {
+				m.SetCommandResult(systemCrontabCommand, systemCrontabs, "", 0, nil)
+			},
+			wantStatus:   core.StatusFail,
+			wantContains: "No cron job 'backup.sh --full' at '0 3 * * *' found in /etc/crontab or /etc/cron.d",
+		},
+		{
+			name:     "user crontab entry",
+			cronTest: core.CronTest{Name: "Incremental backup", User: "backup", Command: "--incremental", State: "present"},
+			setupMock: func(m *core.MockProvider) {
+				m.SetCommandResult(systemCrontabCommand, systemCrontabs, "", 0, nil)
+				m.SetCommandResult("crontab -l -u 'backup'", backupCrontab, "", 0, nil)
+			},
+			wantStatus: core.StatusPass,
+			wantSource: "crontab of backup",
+		},
+		{
+			name:     "user's system entries are included",
+			cronTest: core.CronTest{Name: "Full backup", User: "backup", Command: "--full", State: "present"},
+			setupMock: func(m *core.MockProvider) {
+				m.SetCommandResult(systemCrontabCommand, systemCrontabs, "", 0, nil)
+				m.SetCommandResult("crontab -l -u 'backup'", "", "no crontab for backup\n", 1, nil)
+			},
+			wantStatus: core.StatusPass,
+			wantSource: "/etc/cron.d/backup",
+		},
+		{
+			name:     "other users' entries are ignored",
+			cronTest: core.CronTest{Name: "Hourly jobs", User: "backup", Command: "run-parts", State: "present"},
+			setupMock: func(m *core.MockProvider) {
+				m.SetCommandResult(systemCrontabCommand, systemCrontabs, "", 0, nil)
+				m.SetCommandResult("crontab -l -u 'backup'", "", "no crontab for backup\n", 1, nil)
+			},
+			wantStatus:   core.StatusFail,
+			wantContains: "found in the crontabs of backup",
+		},
+		{
+			name:     "job absent",
+			cronTest: core.CronTest{Name: "No legacy sync", Command: "legacy-sync", State: "absent"},
+			setupMock: func(m *core.MockProvider) {
+				m.SetCommandResult(systemCrontabCommand, systemCrontabs, "", 0, nil)
+			},
+			wantStatus:   core.StatusPass,
+			wantContains: "No cron job 'legacy-sync' in /etc/crontab or /etc/cron.d",
+		},
+		{
+			name:     "job expected absent is present",
+			cronTest: core.CronTest{Name: "No hourly run-parts", Command: "run-parts", State: "absent"},
+			setupMock: func(m *core.MockProvider) {
+				m.SetCommandResult(systemCrontabCommand, systemCrontabs, "", 0, nil)
+			},
+			wantStatus:   core.StatusFail,
+			wantContains: "expected absent",
+		},
+		{
+			name:     "unreadable user crontab",
+			cronTest: core.CronTest{Name: "App job", User: "app", Command: "report", State: "present"},
+			setupMock: func(m *core.MockProvider) {
+				m.SetCommandResult(systemCrontabCommand, systemCrontabs, "", 0, nil)
+				m.SetCommandResult("crontab -l -u 'app'", "", "must be privileged to use -u\n", 1, nil)
+			},
+			wantStatus:   core.StatusError,
+			wantContains: "crontab of app: must be privileged",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := core.NewMockProvider()
+			tt.setupMock(mock)
+
+			result := executeCronTest(context.Background(), mock, tt.cronTest)
+
+			if result.Status != tt.wantStatus {
+				t.Errorf("Status = %v, want %v (message: %s)", result.Status, tt.wantStatus, result.Message)
+			}
+			if tt.wantContains != "" && !contains(result.Message, tt.wantContains) {
+				t.Errorf("Message = %q, want it to contain %q", result.Message, tt.wantContains)
+			}
+			if tt.wantSource != "" && result.Details["source"] != tt.wantSource {
+				t.Errorf("Details[source] = %v, want %q", result.Details["source"], tt.wantSource)
+			}
+		})
+	}
+}
+
+func TestParseCronLine(t *testing.T) {
+	entry, ok := parseCronLine("30 2 * * *  backup  /usr/local/bin/backup.sh  --full", true)
+	if !ok || entry.Schedule != "30 2 * * *" || entry.User != "backup" || entry.Command != "/usr/local/bin/backup.sh  --full" {
+		t.Errorf("parseCronLine(system) = %+v, %v", entry, ok)
+	}
+	entry, ok = parseCronLine("@reboot /opt/app/start.sh", false)
+	if !ok || entry.Schedule != "@reboot" || entry.Command != "/opt/app/start.sh" {
+		t.Errorf("parseCronLine(@reboot) = %+v, %v", entry, ok)
+	}
+	for _, line := range []string{"", "# 0 * * * * comment", "MAILTO=root", "PATH = /usr/bin", "0 * * * *"} {
+		if entry, ok := parseCronLine(line, false); ok {
+			t.Errorf("parseCronLine(%q) = %+v, want no entry", line, entry)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package system
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/neilfarmer/platform-spec/pkg/core"
+)
+
+// curlRequest describes an HTTP request made with curl on the target
+type curlRequest struct {
+	Method   string   // HTTP method (default: GET)
+	URL      string   // Request URL
+	Body     string   // Request body, sent with --data
+	Headers  []string // Extra request headers ("Name: value")
+	User     string   // Basic auth credentials ("user:password")
+	Insecure bool     // Skip TLS verification
+	CACert   string   // CA bundle path on the target
+}
+
+// curlResponse is the result of a request made with curl on the target
+type curlResponse struct {
+	Body       string
+	StatusCode int
+	ExitCode   int    // curl exit code; non-zero means the request did not complete
+	Stderr     string // curl error output
+}
+
+// curlCommand builds the curl command line for a request
+func curlCommand(req curlRequest) string {
+	cmdParts := []string{"curl -sS"}
+	if req.Method != "" && req.Method != "GET" {
+		cmdParts = append(cmdParts, fmt.Sprintf("-X %s", req.Method))
+	}
+	if req.Insecure {
+		cmdParts = append(cmdParts, "-k")
+	}
+	if req.CACert != "" {
+		cmdParts = append(cmdParts, fmt.Sprintf("--cacert %s", core.ShellQuote(req.CACert)))
+	}
+	if req.User != "" {
+		cmdParts = append(cmdParts, fmt.Sprintf("-u %s", core.ShellQuote(req.User)))
+	}
+	for _, header := range req.Headers {
+		cmdParts = append(cmdParts, fmt.Sprintf("-H %s", core.ShellQuote(header)))
+	}
+	if req.Body != "" {
+		cmdParts = append(cmdParts, fmt.Sprintf("--data %s", core.ShellQuote(req.Body)))
+	}
+	// curl expands \n in the write-out format itself, so this works in any POSIX shell
+	cmdParts = append(cmdParts, "-w '\\n%{http_code}'")
+	cmdParts = append(cmdParts, core.ShellQuote(req.URL))
+	return strings.Join(cmdParts, " ")
+}
+
+// runCurl makes an HTTP request with curl on the target. An error is returned
+// only if the command could not be executed or its output could not be parsed.
+func runCurl(ctx context.Context, provider core.Provider, req curlRequest) (*curlResponse, error) {
+	stdout, stderr, exitCode, err := provider.ExecuteCommand(ctx, curlCommand(req))
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &curlResponse{ExitCode: exitCode, Stderr: strings.TrimSpace(stderr)}
+	if exitCode != 0 {
+		return resp, nil
+	}
+
+	// Last line is the status code, everything else is the body
+	stdout = strings.TrimRight(stdout, "\n")
+	body, statusCodeStr := "", stdout
+	if idx := strings.LastIndex(stdout, "\n"); idx >= 0 {
+		body, statusCodeStr = stdout[:idx], stdout[idx+1:]
+	}
+	if _, err := fmt.Sscanf(statusCodeStr, "%d", &resp.StatusCode); err != nil {
+		return nil, fmt.Errorf("failed to parse status code: %s", statusCodeStr)
+	}
+	resp.Body = body
+	return resp, nil
+}
@@ -3,6 +3,7 @@ package system
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/neilfarmer/platform-spec/pkg/core"
 )
@@ -110,3 +111,55 @@ func TestExecutor_DNSTest(t *testing.T) {
 		})
 	}
 }
+
+func TestSystemPlugin_NetworkRetry(t *testing.T) {
+	const dig = "dig +short 'db.internal' 2>/dev/null || getent hosts 'db.internal' 2>/dev/null | awk '{print $1}'"
+	const ping = "ping -c 1 -W 5 'db.internal' 2>&1"
+	unresolved := flakyResponse{exitCode: 1}
+	resolved := flakyResponse{stdout: "10.0.0.7\n"}
+	lost := flakyResponse{stdout: "1 packets transmitted, 0 received, 100% packet loss", exitCode: 1}
+	reachable := flakyResponse{stdout: "1 packets transmitted, 1 received, 0% packet loss"}
+
+	tests := []struct {
+		name      string
+		command   string
+		responses []flakyResponse
+		tests     core.Tests
+	}{
+		{
+			name:      "dns resolves on second attempt",
+			command:   dig,
+			responses: []flakyResponse{unresolved, resolved},
+			tests:     core.Tests{DNS: []core.DNSTest{{Name: "db resolves", Host: "db.internal"}}},
+		},
+		{
+			name:      "ping answers on second attempt",
+			command:   ping,
+			responses: []flakyResponse{lost, reachable},
+			tests:     core.Tests{Ping: []core.PingTest{{Name: "db reachable", Host: "db.internal"}}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			retry := &core.TestRetry{Attempts: 3, Interval: time.Millisecond}
+			for i := range tt.tests.DNS {
+				tt.tests.DNS[i].Retry = retry
+			}
+			for i := range tt.tests.Ping {
+				tt.tests.Ping[i].Retry = retry
+			}
+			provider := &flakyProvider{MockProvider: core.NewMockProvider(), command: tt.command, responses: tt.responses}
+
+			results, err := core.NewExecutor(&core.Spec{Tests: tt.tests}, provider, NewSystemPlugin()).Execute(context.Background())
+			if err != nil {
+				t.Fatalf("Execute() error = %v", err)
+			}
+			result := results.Results[0]
+			if result.Status != core.StatusPass || result.Attempts != 2 || provider.calls != 2 {
+				t.Errorf("result = %v after %d attempts and %d lookups (%s), want pass after 2",
+					result.Status, result.Attempts, provider.calls, result.Message)
+			}
+		})
+	}
+}
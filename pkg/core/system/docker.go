@@ -3,6 +3,7 @@ package system
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
@@ -18,6 +19,13 @@ func executeDockerTest(ctx context.Context, provider core.Provider, test core.Do
 		Details: make(map[string]interface{}),
 	}
 
+	if err := core.RequireCommand(ctx, provider, "docker"); err != nil {
+		result.Status = core.StatusError
+		result.Message = err.Error()
+		result.Duration = time.Since(start)
+		return result
+	}
+
 	// Get list of containers to check
 	containers := test.Containers
 	if test.Container != "" {
@@ -26,8 +34,8 @@ func executeDockerTest(ctx context.Context, provider core.Provider, test core.Do
 
 	for _, container := range containers {
 		// Use docker inspect to get container details
-		// Format: {{.State.Status}}|{{.Config.Image}}|{{.HostConfig.RestartPolicy.Name}}|{{.State.Health.Status}}
-		inspectCmd := fmt.Sprintf("docker inspect --format '{{.State.Status}}|{{.Config.Image}}|{{.HostConfig.RestartPolicy.Name}}|{{if .State.Health}}{{.State.Health.Status}}{{else}}none{{end}}' %s 2>/dev/null", container)
+		// Format: {{.State.Status}}|{{.Config.Image}}|{{.HostConfig.RestartPolicy.Name}}|{{.State.Health.Status}}|{{.HostConfig.Memory}}|{{.HostConfig.CpuShares}}|{{.State.StartedAt}}|{{.RestartCount}}
+		inspectCmd := fmt.Sprintf("docker inspect --format '{{.State.Status}}|{{.Config.Image}}|{{.HostConfig.RestartPolicy.Name}}|{{if .State.Health}}{{.State.Health.Status}}{{else}}none{{end}}|{{.HostConfig.Memory}}|{{.HostConfig.CpuShares}}|{{.State.StartedAt}}|{{.RestartCount}}' %s 2>/dev/null", container)
 		stdout, _, exitCode, err := provider.ExecuteCommand(ctx, inspectCmd)
 		if err != nil {
 			result.Status = core.StatusError
@@ -48,7 +56,7 @@ func executeDockerTest(ctx context.Context, provider core.Provider, test core.Do
 			// Parse docker inspect output
 			stdout = strings.TrimSpace(stdout)
 			parts := strings.Split(stdout, "|")
-			if len(parts) != 4 {
+			if len(parts) != 8 {
 				result.Status = core.StatusError
 				result.Message = fmt.Sprintf("Unexpected docker inspect output for %s", container)
 				result.Duration = time.Since(start)
@@ -60,6 +68,22 @@ func executeDockerTest(ctx context.Context, provider core.Provider, test core.Do
 			restartPolicy := parts[2] // no, always, on-failure, unless-stopped
 			health := parts[3]        // healthy, unhealthy, starting, none
 
+			// Memory limit in bytes is 0 when unlimited; CPU shares are 0 when
+			// left at the default
+			memory, memErr := strconv.ParseInt(parts[4], 10, 64)
+			cpuShares, cpuErr := strconv.Atoi(parts[5])
+			startedAt := parts[6]
+			restarts, restartErr := strconv.Atoi(parts[7])
+			if memErr != nil || cpuErr != nil || restartErr != nil {
+				result.Status = core.StatusError
+				result.Message = fmt.Sprintf("Unexpected docker inspect output for %s", container)
+				result.Duration = time.Since(start)
+				return result
+			}
+			if cpuShares == 0 {
+				cpuShares = 1024
+			}
+
 			// Check state
 			if test.State == "running" && status != "running" {
 				result.Status = core.StatusFail
@@ -97,6 +121,56 @@ func executeDockerTest(ctx context.Context, provider core.Provider, test core.Do
 				break
 			}
 
+			// Check memory limit if specified; an unlimited container fails
+			if test.MemoryLimit != "" {
+				maxMemory, _ := core.ParseSize(test.MemoryLimit) // validated by the spec
+				if memory == 0 {
+					result.Status = core.StatusFail
+					result.Message = fmt.Sprintf("Container %s has no memory limit, expected at most %s", container, test.MemoryLimit)
+					result.Details[container] = "memory: unlimited"
+					break
+				}
+				if memory > maxMemory {
+					result.Status = core.StatusFail
+					result.Message = fmt.Sprintf("Container %s memory limit is %d bytes, expected at most %s (%d bytes)", container, memory, test.MemoryLimit, maxMemory)
+					result.Details[container] = fmt.Sprintf("memory: %d", memory)
+					break
+				}
+			}
+
+			// Check CPU shares if specified
+			if test.CPUShares != 0 && cpuShares != test.CPUShares {
+				result.Status = core.StatusFail
+				result.Message = fmt.Sprintf("Container %s CPU shares are %d, expected %d", container, cpuShares, test.CPUShares)
+				result.Details[container] = fmt.Sprintf("cpu_shares: %d", cpuShares)
+				break
+			}
+
+			// Check the container has stayed up; docker reports "running" even
+			// while a crash-looping container is between restarts
+			if test.MaxRestarts != nil && restarts > *test.MaxRestarts {
+				result.Status = core.StatusFail
+				result.Message = fmt.Sprintf("Container %s has restarted %d times, expected at most %d", container, restarts, *test.MaxRestarts)
+				result.Details[container] = fmt.Sprintf("restarts: %d", restarts)
+				break
+			}
+			if test.MinUptimeSeconds > 0 {
+				started, err := time.Parse(time.RFC3339Nano, startedAt)
+				if err != nil {
+					result.Status = core.StatusError
+					result.Message = fmt.Sprintf("Unexpected start time for container %s: %s", container, startedAt)
+					result.Duration = time.Since(start)
+					return result
+				}
+				uptime := time.Since(started).Truncate(time.Second)
+				if uptime < time.Duration(test.MinUptimeSeconds)*time.Second {
+					result.Status = core.StatusFail
+					result.Message = fmt.Sprintf("Container %s has been up for %s, expected at least %ds", container, uptime, test.MinUptimeSeconds)
+					result.Details[container] = fmt.Sprintf("uptime: %s", uptime)
+					break
+				}
+			}
+
 			// Record container details
 			containerInfo := fmt.Sprintf("status: %s", status)
 			if test.Image != "" {
@@ -108,6 +182,15 @@ func executeDockerTest(ctx context.Context, provider core.Provider, test core.Do
 			if test.Health != "" {
 				containerInfo += fmt.Sprintf(", health: %s", health)
 			}
+			if test.MemoryLimit != "" {
+				containerInfo += fmt.Sprintf(", memory: %d", memory)
+			}
+			if test.CPUShares != 0 {
+				containerInfo += fmt.Sprintf(", cpu_shares: %d", cpuShares)
+			}
+			if test.MaxRestarts != nil || test.MinUptimeSeconds > 0 {
+				containerInfo += fmt.Sprintf(", restarts: %d, started: %s", restarts, startedAt)
+			}
 			result.Details[container] = containerInfo
 		}
 	}
@@ -130,8 +213,186 @@ func executeDockerTest(ctx context.Context, provider core.Provider, test core.Do
 		if test.Health != "" {
 			result.Message += fmt.Sprintf(" and health status")
 		}
+		if test.MemoryLimit != "" || test.CPUShares != 0 {
+			result.Message += " within resource limits"
+		}
+		if test.MaxRestarts != nil || test.MinUptimeSeconds > 0 {
+			result.Message += " and stable"
+		}
+	}
+
+	result.Duration = time.Since(start)
+	return result
+}
+
+// executeDockerImageTest checks that a Docker image is pulled (or absent) and,
+// optionally, that it matches an expected digest
+func executeDockerImageTest(ctx context.Context, provider core.Provider, test core.DockerImageTest) core.Result {
+	start := time.Now()
+	result := core.Result{
+		Name:    test.Name,
+		Status:  core.StatusPass,
+		Details: make(map[string]interface{}),
+	}
+
+	if err := core.RequireCommand(ctx, provider, "docker"); err != nil {
+		result.Status = core.StatusError
+		result.Message = err.Error()
+		result.Duration = time.Since(start)
+		return result
+	}
+
+	// Format: <image ID>|<repo digests, comma-separated>
+	inspectCmd := fmt.Sprintf("docker image inspect --format '{{.Id}}|{{join .RepoDigests \",\"}}' %s", core.ShellQuote(test.Image))
+	stdout, stderr, exitCode, err := provider.ExecuteCommand(ctx, inspectCmd)
+	if err != nil {
+		result.Status = core.StatusError
+		result.Message = fmt.Sprintf("Error inspecting image %s: %v", test.Image, err)
+		result.Duration = time.Since(start)
+		return result
+	}
+
+	if exitCode != 0 {
+		// Distinguish a missing image from docker itself failing
+		stderr = strings.TrimSpace(stderr)
+		if !strings.Contains(strings.ToLower(stderr), "no such image") {
+			result.Status = core.StatusError
+			result.Message = fmt.Sprintf("docker image inspect failed for %s: %s", test.Image, stderr)
+		} else if test.State == "present" {
+			result.Status = core.StatusFail
+			result.Message = fmt.Sprintf("Image %s is not present", test.Image)
+		} else {
+			result.Message = fmt.Sprintf("Image %s is absent", test.Image)
+		}
+		result.Duration = time.Since(start)
+		return result
+	}
+
+	id, repoDigests, found := strings.Cut(strings.TrimSpace(stdout), "|")
+	if !found {
+		result.Status = core.StatusError
+		result.Message = fmt.Sprintf("Unexpected docker image inspect output for %s", test.Image)
+		result.Duration = time.Since(start)
+		return result
+	}
+	result.Details["id"] = id
+	if repoDigests != "" {
+		result.Details["repo_digests"] = repoDigests
+	}
+
+	if test.State == "absent" {
+		result.Status = core.StatusFail
+		result.Message = fmt.Sprintf("Image %s is present, expected absent", test.Image)
+		result.Duration = time.Since(start)
+		return result
+	}
+
+	if test.Digest != "" && !imageHasDigest(id, repoDigests, test.Digest) {
+		result.Status = core.StatusFail
+		result.Message = fmt.Sprintf("Image %s does not match digest %s", test.Image, test.Digest)
+		result.Duration = time.Since(start)
+		return result
+	}
+
+	result.Message = fmt.Sprintf("Image %s is present", test.Image)
+	if test.Digest != "" {
+		result.Message += " with expected digest"
+	}
+	result.Duration = time.Since(start)
+	return result
+}
+
+// imageHasDigest reports whether digest is the image ID or one of its repo
+// digests (name@sha256:...)
+func imageHasDigest(id, repoDigests, digest string) bool {
+	if id == digest {
+		return true
+	}
+	for _, repoDigest := range strings.Split(repoDigests, ",") {
+		if _, d, ok := strings.Cut(repoDigest, "@"); ok && d == digest {
+			return true
+		}
+	}
+	return false
+}
+
+// executeDockerNetworkTest checks that a Docker network exists (or not) and
+// uses the expected driver
+func executeDockerNetworkTest(ctx context.Context, provider core.Provider, test core.DockerNetworkTest) core.Result {
+	return executeDockerObjectTest(ctx, provider, test.Name, "network", test.Network, test.Driver, test.State)
+}
+
+// executeDockerVolumeTest checks that a Docker volume exists (or not) and
+// uses the expected driver
+func executeDockerVolumeTest(ctx context.Context, provider core.Provider, test core.DockerVolumeTest) core.Result {
+	return executeDockerObjectTest(ctx, provider, test.Name, "volume", test.Volume, test.Driver, test.State)
+}
+
+// executeDockerObjectTest inspects a named Docker network or volume, which
+// share the same inspect command shape and driver field
+func executeDockerObjectTest(ctx context.Context, provider core.Provider, name, kind, object, driver, state string) core.Result {
+	start := time.Now()
+	result := core.Result{
+		Name:    name,
+		Status:  core.StatusPass,
+		Details: make(map[string]interface{}),
+	}
+
+	if err := core.RequireCommand(ctx, provider, "docker"); err != nil {
+		result.Status = core.StatusError
+		result.Message = err.Error()
+		result.Duration = time.Since(start)
+		return result
+	}
+
+	title := strings.ToUpper(kind[:1]) + kind[1:]
+
+	inspectCmd := fmt.Sprintf("docker %s inspect --format '{{.Driver}}' %s", kind, core.ShellQuote(object))
+	stdout, stderr, exitCode, err := provider.ExecuteCommand(ctx, inspectCmd)
+	if err != nil {
+		result.Status = core.StatusError
+		result.Message = fmt.Sprintf("Error inspecting %s %s: %v", kind, object, err)
+		result.Duration = time.Since(start)
+		return result
+	}
+
+	if exitCode != 0 {
+		// Distinguish a missing object from docker itself failing
+		stderr = strings.TrimSpace(stderr)
+		if !strings.Contains(strings.ToLower(stderr), "no such "+kind) {
+			result.Status = core.StatusError
+			result.Message = fmt.Sprintf("docker %s inspect failed for %s: %s", kind, object, stderr)
+		} else if state == "present" {
+			result.Status = core.StatusFail
+			result.Message = fmt.Sprintf("%s %s does not exist", title, object)
+		} else {
+			result.Message = fmt.Sprintf("%s %s is absent", title, object)
+		}
+		result.Duration = time.Since(start)
+		return result
 	}
 
+	actualDriver := strings.TrimSpace(stdout)
+	result.Details["driver"] = actualDriver
+
+	if state == "absent" {
+		result.Status = core.StatusFail
+		result.Message = fmt.Sprintf("%s %s exists, expected absent", title, object)
+		result.Duration = time.Since(start)
+		return result
+	}
+
+	if driver != "" && actualDriver != driver {
+		result.Status = core.StatusFail
+		result.Message = fmt.Sprintf("%s %s driver is %s, expected %s", title, object, actualDriver, driver)
+		result.Duration = time.Since(start)
+		return result
+	}
+
+	result.Message = fmt.Sprintf("%s %s exists", title, object)
+	if driver != "" {
+		result.Message += fmt.Sprintf(" with driver %s", driver)
+	}
 	result.Duration = time.Since(start)
 	return result
 }
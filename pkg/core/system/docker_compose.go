@@ -0,0 +1,212 @@
+package system
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/neilfarmer/platform-spec/pkg/core"
+)
+
+// composeContainer is one container of a compose service, as reported by
+// `docker compose ps --format json`
+type composeContainer struct {
+	Name    string `json:"Name"`
+	Service string `json:"Service"`
+	State   string `json:"State"`  // running, exited, restarting, paused, ...
+	Health  string `json:"Health"` // healthy, unhealthy, starting, or empty without a healthcheck
+}
+
+// up reports whether the container is running and, if it has a healthcheck,
+// healthy
+func (c composeContainer) up() bool {
+	return c.State == "running" && (c.Health == "" || c.Health == "healthy")
+}
+
+// describe summarises the container's state, e.g. "running (healthy)"
+func (c composeContainer) describe() string {
+	if c.Health == "" {
+		return c.State
+	}
+	return fmt.Sprintf("%s (%s)", c.State, c.Health)
+}
+
+// executeDockerComposeTest checks that the services of a Docker Compose project
+// are running and healthy
+func executeDockerComposeTest(ctx context.Context, provider core.Provider, test core.DockerComposeTest) core.Result {
+	start := time.Now()
+	result := core.Result{
+		Name:    test.Name,
+		Status:  core.StatusPass,
+		Details: make(map[string]interface{}),
+	}
+
+	if err := core.RequireCommand(ctx, provider, "docker"); err != nil {
+		result.Status = core.StatusError
+		result.Message = err.Error()
+		result.Duration = time.Since(start)
+		return result
+	}
+
+	containers, err := composeContainers(ctx, provider, test.Project)
+	if err != nil {
+		result.Status = core.StatusError
+		result.Message = err.Error()
+		result.Duration = time.Since(start)
+		return result
+	}
+	if len(containers) == 0 {
+		result.Status = core.StatusFail
+		result.Message = fmt.Sprintf("Compose project %s has no containers", test.Project)
+		result.Duration = time.Since(start)
+		return result
+	}
+
+	services := make(map[string][]composeContainer)
+	for _, c := range containers {
+		services[c.Service] = append(services[c.Service], c)
+	}
+	for service, members := range services {
+		states := make([]string, 0, len(members))
+		for _, c := range members {
+			states = append(states, c.describe())
+		}
+		result.Details[service] = strings.Join(states, ", ")
+	}
+
+	// Listed services must exist; all_services_up widens the check to every
+	// service in the project
+	check := test.ExpectedServices
+	for _, service := range check {
+		if _, ok := services[service]; !ok {
+			result.Status = core.StatusFail
+			result.Message = fmt.Sprintf("Service %s not found in compose project %s", service, test.Project)
+			result.Duration = time.Since(start)
+			return result
+		}
+	}
+	if test.AllServicesUp {
+		check = make([]string, 0, len(services))
+		for service := range services {
+			check = append(check, service)
+		}
+		sort.Strings(check)
+	}
+
+	var down []string
+	for _, service := range check {
+		for _, c := range services[service] {
+			if !c.up() {
+				down = append(down, fmt.Sprintf("%s (%s)", service, c.describe()))
+				break
+			}
+		}
+	}
+	if len(down) > 0 {
+		result.Status = core.StatusFail
+		result.Message = fmt.Sprintf("Compose project %s has services not up: %s", test.Project, strings.Join(down, ", "))
+		result.Duration = time.Since(start)
+		return result
+	}
+
+	if test.AllServicesUp {
+		result.Message = fmt.Sprintf("All %d services in compose project %s are up", len(check), test.Project)
+	} else {
+		result.Message = fmt.Sprintf("Services %s in compose project %s are up", strings.Join(check, ", "), test.Project)
+	}
+	result.Duration = time.Since(start)
+	return result
+}
+
+// composeContainers lists a compose project's containers. Compose v2 reports
+// them as JSON; when the `docker compose` plugin is missing, compose v1
+// projects are found through the project label both versions set on their
+// containers.
+func composeContainers(ctx context.Context, provider core.Provider, project string) ([]composeContainer, error) {
+	cmd := fmt.Sprintf("docker compose -p %s ps --all --format json", core.ShellQuote(project))
+	stdout, stderr, exitCode, err := provider.ExecuteCommand(ctx, cmd)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list compose project %s: %v", project, err)
+	}
+	if exitCode == 0 {
+		containers, err := parseComposePS(stdout)
+		if err != nil {
+			return nil, fmt.Errorf("unexpected docker compose ps output for %s: %v", project, err)
+		}
+		return containers, nil
+	}
+	if !strings.Contains(stderr, "is not a docker command") && !strings.Contains(stderr, "unknown command") {
+		return nil, fmt.Errorf("docker compose ps failed for %s: %s", project, strings.TrimSpace(stderr))
+	}
+
+	// Compose v1: docker-compose has no JSON output, so read the containers
+	// from docker ps
+	cmd = fmt.Sprintf("docker ps -a --filter %s --format '{{.Names}}|{{.Label \"com.docker.compose.service\"}}|{{.State}}|{{.Status}}'",
+		core.ShellQuote("label=com.docker.compose.project="+project))
+	stdout, stderr, exitCode, err = provider.ExecuteCommand(ctx, cmd)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list compose project %s: %v", project, err)
+	}
+	if exitCode != 0 {
+		return nil, fmt.Errorf("docker ps failed for compose project %s: %s", project, strings.TrimSpace(stderr))
+	}
+
+	var containers []composeContainer
+	for _, line := range strings.Split(strings.TrimSpace(stdout), "\n") {
+		if line == "" {
+			continue
+		}
+		parts := strings.SplitN(line, "|", 4)
+		if len(parts) != 4 {
+			return nil, fmt.Errorf("unexpected docker ps output for compose project %s: %s", project, line)
+		}
+		containers = append(containers, composeContainer{
+			Name:    parts[0],
+			Service: parts[1],
+			State:   parts[2],
+			Health:  healthFromStatus(parts[3]),
+		})
+	}
+	return containers, nil
+}
+
+// parseComposePS decodes `docker compose ps --format json` output, which is a
+// JSON array before compose 2.21 and one object per line after
+func parseComposePS(stdout string) ([]composeContainer, error) {
+	stdout = strings.TrimSpace(stdout)
+	var containers []composeContainer
+	if strings.HasPrefix(stdout, "[") {
+		if err := json.Unmarshal([]byte(stdout), &containers); err != nil {
+			return nil, err
+		}
+		return containers, nil
+	}
+	for _, line := range strings.Split(stdout, "\n") {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		var c composeContainer
+		if err := json.Unmarshal([]byte(line), &c); err != nil {
+			return nil, err
+		}
+		containers = append(containers, c)
+	}
+	return containers, nil
+}
+
+// healthFromStatus extracts the health from a docker ps status such as
+// "Up 5 minutes (healthy)"
+func healthFromStatus(status string) string {
+	switch {
+	case strings.Contains(status, "(healthy)"):
+		return "healthy"
+	case strings.Contains(status, "(unhealthy)"):
+		return "unhealthy"
+	case strings.Contains(status, "(health: starting)"):
+		return "starting"
+	}
+	return ""
+}
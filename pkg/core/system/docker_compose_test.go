@@ -0,0 +1,120 @@
+package system
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/neilfarmer/platform-spec/pkg/core"
+)
+
+func TestExecutor_DockerComposeTest(t *testing.T) {
+	const psCmd = "docker compose -p 'shop' ps --all --format json"
+	const v1Cmd = "docker ps -a --filter 'label=com.docker.compose.project=shop' --format '{{.Names}}|{{.Label \"com.docker.compose.service\"}}|{{.State}}|{{.Status}}'"
+
+	fullyUp := `{"Name":"shop-web-1","Service":"web","State":"running","Health":""}
+{"Name":"shop-db-1","Service":"db","State":"running","Health":"healthy"}
+{"Name":"shop-worker-1","Service":"worker","State":"running","Health":""}
+{"Name":"shop-worker-2","Service":"worker","State":"running","Health":""}
+`
+	partiallyDown := `[{"Name":"shop-web-1","Service":"web","State":"running","Health":""},` +
+		`{"Name":"shop-db-1","Service":"db","State":"running","Health":"unhealthy"},` +
+		`{"Name":"shop-worker-1","Service":"worker","State":"exited","Health":""}]`
+
+	tests := []struct {
+		name         string
+		composeTest  core.DockerComposeTest
+		setupMock    func(*core.MockProvider)
+		wantStatus   core.Status
+		wantContains string
+	}{
+		{
+			name:        "all services up",
+			composeTest: core.DockerComposeTest{Name: "shop stack", Project: "shop", AllServicesUp: true},
+			setupMock: func(m *core.MockProvider) {
+				m.SetCommandResult(psCmd, fullyUp, "", 0, nil)
+			},
+			wantStatus:   core.StatusPass,
+			wantContains: "All 3 services in compose project shop are up",
+		},
+		{
+			name:        "listed services up",
+			composeTest: core.DockerComposeTest{Name: "shop stack", Project: "shop", ExpectedServices: []string{"web", "db"}},
+			setupMock: func(m *core.MockProvider) {
+				m.SetCommandResult(psCmd, fullyUp, "", 0, nil)
+			},
+			wantStatus:   core.StatusPass,
+			wantContains: "Services web, db in compose project shop are up",
+		},
+		{
+			name:        "partially down stack",
+			composeTest: core.DockerComposeTest{Name: "shop stack", Project: "shop", AllServicesUp: true},
+			setupMock: func(m *core.MockProvider) {
+				m.SetCommandResult(psCmd, partiallyDown, "", 0, nil)
+			},
+			wantStatus:   core.StatusFail,
+			wantContains: "db (running (unhealthy)), worker (exited)",
+		},
+		{
+			name:        "listed services ignore other down services",
+			composeTest: core.DockerComposeTest{Name: "shop web", Project: "shop", ExpectedServices: []string{"web"}},
+			setupMock: func(m *core.MockProvider) {
+				m.SetCommandResult(psCmd, partiallyDown, "", 0, nil)
+			},
+			wantStatus:   core.StatusPass,
+			wantContains: "Services web in compose project shop are up",
+		},
+		{
+			name:        "listed service missing",
+			composeTest: core.DockerComposeTest{Name: "shop stack", Project: "shop", ExpectedServices: []string{"cache"}},
+			setupMock: func(m *core.MockProvider) {
+				m.SetCommandResult(psCmd, fullyUp, "", 0, nil)
+			},
+			wantStatus:   core.StatusFail,
+			wantContains: "Service cache not found",
+		},
+		{
+			name:        "project has no containers",
+			composeTest: core.DockerComposeTest{Name: "shop stack", Project: "shop", AllServicesUp: true},
+			setupMock: func(m *core.MockProvider) {
+				m.SetCommandResult(psCmd, "", "", 0, nil)
+			},
+			wantStatus:   core.StatusFail,
+			wantContains: "has no containers",
+		},
+		{
+			name:        "compose v1 fallback",
+			composeTest: core.DockerComposeTest{Name: "shop stack", Project: "shop", AllServicesUp: true},
+			setupMock: func(m *core.MockProvider) {
+				m.SetCommandResult(psCmd, "", "docker: 'compose' is not a docker command.\n", 1, nil)
+				m.SetCommandResult(v1Cmd, "shop_web_1|web|running|Up 2 hours\nshop_db_1|db|running|Up 2 hours (health: starting)\n", "", 0, nil)
+			},
+			wantStatus:   core.StatusFail,
+			wantContains: "db (running (starting))",
+		},
+		{
+			name:        "docker daemon unavailable",
+			composeTest: core.DockerComposeTest{Name: "shop stack", Project: "shop", AllServicesUp: true},
+			setupMock: func(m *core.MockProvider) {
+				m.SetCommandResult(psCmd, "", "Cannot connect to the Docker daemon at unix:///var/run/docker.sock\n", 1, nil)
+			},
+			wantStatus:   core.StatusError,
+			wantContains: "Cannot connect to the Docker daemon",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := core.NewMockProvider()
+			tt.setupMock(mock)
+
+			result := executeDockerComposeTest(context.Background(), mock, tt.composeTest)
+			if result.Status != tt.wantStatus {
+				t.Errorf("Status = %v, want %v (message: %s)", result.Status, tt.wantStatus, result.Message)
+			}
+			if !strings.Contains(result.Message, tt.wantContains) {
+				t.Errorf("Message = %q, want it to contain %q", result.Message, tt.wantContains)
+			}
+		})
+	}
+}
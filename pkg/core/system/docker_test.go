@@ -2,7 +2,9 @@ package system
 
 import (
 	"context"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/neilfarmer/platform-spec/pkg/core"
 )
@@ -23,7 +25,7 @@ func TestExecutor_DockerTest(t *testing.T) {
 				State:     "running",
 			},
 			setupMock: func(m *core.MockProvider) {
-				m.SetCommandResult("docker inspect --format '{{.State.Status}}|{{.Config.Image}}|{{.HostConfig.RestartPolicy.Name}}|{{if .State.Health}}{{.State.Health.Status}}{{else}}none{{end}}' nginx 2>/dev/null", "running|nginx:latest|always|none", "", 0, nil)
+				m.SetCommandResult("docker inspect --format '{{.State.Status}}|{{.Config.Image}}|{{.HostConfig.RestartPolicy.Name}}|{{if .State.Health}}{{.State.Health.Status}}{{else}}none{{end}}|{{.HostConfig.Memory}}|{{.HostConfig.CpuShares}}|{{.State.StartedAt}}|{{.RestartCount}}' nginx 2>/dev/null", "running|nginx:latest|always|none|0|0|2026-01-01T00:00:00Z|0", "", 0, nil)
 			},
 			wantStatus:   core.StatusPass,
 			wantContains: "containers are running",
@@ -36,7 +38,7 @@ func TestExecutor_DockerTest(t *testing.T) {
 				State:     "stopped",
 			},
 			setupMock: func(m *core.MockProvider) {
-				m.SetCommandResult("docker inspect --format '{{.State.Status}}|{{.Config.Image}}|{{.HostConfig.RestartPolicy.Name}}|{{if .State.Health}}{{.State.Health.Status}}{{else}}none{{end}}' old-app 2>/dev/null", "exited|app:1.0|no|none", "", 0, nil)
+				m.SetCommandResult("docker inspect --format '{{.State.Status}}|{{.Config.Image}}|{{.HostConfig.RestartPolicy.Name}}|{{if .State.Health}}{{.State.Health.Status}}{{else}}none{{end}}|{{.HostConfig.Memory}}|{{.HostConfig.CpuShares}}|{{.State.StartedAt}}|{{.RestartCount}}' old-app 2>/dev/null", "exited|app:1.0|no|none|0|0|2026-01-01T00:00:00Z|0", "", 0, nil)
 			},
 			wantStatus:   core.StatusPass,
 			wantContains: "containers are stopped",
@@ -49,7 +51,7 @@ func TestExecutor_DockerTest(t *testing.T) {
 				State:     "running",
 			},
 			setupMock: func(m *core.MockProvider) {
-				m.SetCommandResult("docker inspect --format '{{.State.Status}}|{{.Config.Image}}|{{.HostConfig.RestartPolicy.Name}}|{{if .State.Health}}{{.State.Health.Status}}{{else}}none{{end}}' missing 2>/dev/null", "", "", 1, nil)
+				m.SetCommandResult("docker inspect --format '{{.State.Status}}|{{.Config.Image}}|{{.HostConfig.RestartPolicy.Name}}|{{if .State.Health}}{{.State.Health.Status}}{{else}}none{{end}}|{{.HostConfig.Memory}}|{{.HostConfig.CpuShares}}|{{.State.StartedAt}}|{{.RestartCount}}' missing 2>/dev/null", "", "", 1, nil)
 			},
 			wantStatus:   core.StatusFail,
 			wantContains: "does not exist",
@@ -62,7 +64,7 @@ func TestExecutor_DockerTest(t *testing.T) {
 				State:     "stopped",
 			},
 			setupMock: func(m *core.MockProvider) {
-				m.SetCommandResult("docker inspect --format '{{.State.Status}}|{{.Config.Image}}|{{.HostConfig.RestartPolicy.Name}}|{{if .State.Health}}{{.State.Health.Status}}{{else}}none{{end}}' app 2>/dev/null", "running|app:latest|always|none", "", 0, nil)
+				m.SetCommandResult("docker inspect --format '{{.State.Status}}|{{.Config.Image}}|{{.HostConfig.RestartPolicy.Name}}|{{if .State.Health}}{{.State.Health.Status}}{{else}}none{{end}}|{{.HostConfig.Memory}}|{{.HostConfig.CpuShares}}|{{.State.StartedAt}}|{{.RestartCount}}' app 2>/dev/null", "running|app:latest|always|none|0|0|2026-01-01T00:00:00Z|0", "", 0, nil)
 			},
 			wantStatus:   core.StatusFail,
 			wantContains: "is running, expected stopped",
@@ -76,7 +78,7 @@ func TestExecutor_DockerTest(t *testing.T) {
 				Image:     "nginx:1.21",
 			},
 			setupMock: func(m *core.MockProvider) {
-				m.SetCommandResult("docker inspect --format '{{.State.Status}}|{{.Config.Image}}|{{.HostConfig.RestartPolicy.Name}}|{{if .State.Health}}{{.State.Health.Status}}{{else}}none{{end}}' web 2>/dev/null", "running|nginx:1.21|always|none", "", 0, nil)
+				m.SetCommandResult("docker inspect --format '{{.State.Status}}|{{.Config.Image}}|{{.HostConfig.RestartPolicy.Name}}|{{if .State.Health}}{{.State.Health.Status}}{{else}}none{{end}}|{{.HostConfig.Memory}}|{{.HostConfig.CpuShares}}|{{.State.StartedAt}}|{{.RestartCount}}' web 2>/dev/null", "running|nginx:1.21|always|none|0|0|2026-01-01T00:00:00Z|0", "", 0, nil)
 			},
 			wantStatus:   core.StatusPass,
 			wantContains: "with correct image",
@@ -90,7 +92,7 @@ func TestExecutor_DockerTest(t *testing.T) {
 				Image:     "nginx:1.22",
 			},
 			setupMock: func(m *core.MockProvider) {
-				m.SetCommandResult("docker inspect --format '{{.State.Status}}|{{.Config.Image}}|{{.HostConfig.RestartPolicy.Name}}|{{if .State.Health}}{{.State.Health.Status}}{{else}}none{{end}}' web 2>/dev/null", "running|nginx:1.21|always|none", "", 0, nil)
+				m.SetCommandResult("docker inspect --format '{{.State.Status}}|{{.Config.Image}}|{{.HostConfig.RestartPolicy.Name}}|{{if .State.Health}}{{.State.Health.Status}}{{else}}none{{end}}|{{.HostConfig.Memory}}|{{.HostConfig.CpuShares}}|{{.State.StartedAt}}|{{.RestartCount}}' web 2>/dev/null", "running|nginx:1.21|always|none|0|0|2026-01-01T00:00:00Z|0", "", 0, nil)
 			},
 			wantStatus:   core.StatusFail,
 			wantContains: "image is",
@@ -104,7 +106,7 @@ func TestExecutor_DockerTest(t *testing.T) {
 				RestartPolicy: "always",
 			},
 			setupMock: func(m *core.MockProvider) {
-				m.SetCommandResult("docker inspect --format '{{.State.Status}}|{{.Config.Image}}|{{.HostConfig.RestartPolicy.Name}}|{{if .State.Health}}{{.State.Health.Status}}{{else}}none{{end}}' db 2>/dev/null", "running|postgres:14|always|none", "", 0, nil)
+				m.SetCommandResult("docker inspect --format '{{.State.Status}}|{{.Config.Image}}|{{.HostConfig.RestartPolicy.Name}}|{{if .State.Health}}{{.State.Health.Status}}{{else}}none{{end}}|{{.HostConfig.Memory}}|{{.HostConfig.CpuShares}}|{{.State.StartedAt}}|{{.RestartCount}}' db 2>/dev/null", "running|postgres:14|always|none|0|0|2026-01-01T00:00:00Z|0", "", 0, nil)
 			},
 			wantStatus:   core.StatusPass,
 			wantContains: "restart policy",
@@ -118,7 +120,7 @@ func TestExecutor_DockerTest(t *testing.T) {
 				RestartPolicy: "on-failure",
 			},
 			setupMock: func(m *core.MockProvider) {
-				m.SetCommandResult("docker inspect --format '{{.State.Status}}|{{.Config.Image}}|{{.HostConfig.RestartPolicy.Name}}|{{if .State.Health}}{{.State.Health.Status}}{{else}}none{{end}}' db 2>/dev/null", "running|postgres:14|always|none", "", 0, nil)
+				m.SetCommandResult("docker inspect --format '{{.State.Status}}|{{.Config.Image}}|{{.HostConfig.RestartPolicy.Name}}|{{if .State.Health}}{{.State.Health.Status}}{{else}}none{{end}}|{{.HostConfig.Memory}}|{{.HostConfig.CpuShares}}|{{.State.StartedAt}}|{{.RestartCount}}' db 2>/dev/null", "running|postgres:14|always|none|0|0|2026-01-01T00:00:00Z|0", "", 0, nil)
 			},
 			wantStatus:   core.StatusFail,
 			wantContains: "restart policy is",
@@ -132,7 +134,7 @@ func TestExecutor_DockerTest(t *testing.T) {
 				Health:    "healthy",
 			},
 			setupMock: func(m *core.MockProvider) {
-				m.SetCommandResult("docker inspect --format '{{.State.Status}}|{{.Config.Image}}|{{.HostConfig.RestartPolicy.Name}}|{{if .State.Health}}{{.State.Health.Status}}{{else}}none{{end}}' api 2>/dev/null", "running|api:v1|always|healthy", "", 0, nil)
+				m.SetCommandResult("docker inspect --format '{{.State.Status}}|{{.Config.Image}}|{{.HostConfig.RestartPolicy.Name}}|{{if .State.Health}}{{.State.Health.Status}}{{else}}none{{end}}|{{.HostConfig.Memory}}|{{.HostConfig.CpuShares}}|{{.State.StartedAt}}|{{.RestartCount}}' api 2>/dev/null", "running|api:v1|always|healthy|0|0|2026-01-01T00:00:00Z|0", "", 0, nil)
 			},
 			wantStatus:   core.StatusPass,
 			wantContains: "health status",
@@ -146,7 +148,7 @@ func TestExecutor_DockerTest(t *testing.T) {
 				Health:    "healthy",
 			},
 			setupMock: func(m *core.MockProvider) {
-				m.SetCommandResult("docker inspect --format '{{.State.Status}}|{{.Config.Image}}|{{.HostConfig.RestartPolicy.Name}}|{{if .State.Health}}{{.State.Health.Status}}{{else}}none{{end}}' api 2>/dev/null", "running|api:v1|always|unhealthy", "", 0, nil)
+				m.SetCommandResult("docker inspect --format '{{.State.Status}}|{{.Config.Image}}|{{.HostConfig.RestartPolicy.Name}}|{{if .State.Health}}{{.State.Health.Status}}{{else}}none{{end}}|{{.HostConfig.Memory}}|{{.HostConfig.CpuShares}}|{{.State.StartedAt}}|{{.RestartCount}}' api 2>/dev/null", "running|api:v1|always|unhealthy|0|0|2026-01-01T00:00:00Z|0", "", 0, nil)
 			},
 			wantStatus:   core.StatusFail,
 			wantContains: "health is",
@@ -162,7 +164,7 @@ func TestExecutor_DockerTest(t *testing.T) {
 				Health:        "healthy",
 			},
 			setupMock: func(m *core.MockProvider) {
-				m.SetCommandResult("docker inspect --format '{{.State.Status}}|{{.Config.Image}}|{{.HostConfig.RestartPolicy.Name}}|{{if .State.Health}}{{.State.Health.Status}}{{else}}none{{end}}' webapp 2>/dev/null", "running|nginx:alpine|unless-stopped|healthy", "", 0, nil)
+				m.SetCommandResult("docker inspect --format '{{.State.Status}}|{{.Config.Image}}|{{.HostConfig.RestartPolicy.Name}}|{{if .State.Health}}{{.State.Health.Status}}{{else}}none{{end}}|{{.HostConfig.Memory}}|{{.HostConfig.CpuShares}}|{{.State.StartedAt}}|{{.RestartCount}}' webapp 2>/dev/null", "running|nginx:alpine|unless-stopped|healthy|0|0|2026-01-01T00:00:00Z|0", "", 0, nil)
 			},
 			wantStatus:   core.StatusPass,
 			wantContains: "are running",
@@ -175,8 +177,8 @@ func TestExecutor_DockerTest(t *testing.T) {
 				State:      "running",
 			},
 			setupMock: func(m *core.MockProvider) {
-				m.SetCommandResult("docker inspect --format '{{.State.Status}}|{{.Config.Image}}|{{.HostConfig.RestartPolicy.Name}}|{{if .State.Health}}{{.State.Health.Status}}{{else}}none{{end}}' web1 2>/dev/null", "running|nginx:latest|always|none", "", 0, nil)
-				m.SetCommandResult("docker inspect --format '{{.State.Status}}|{{.Config.Image}}|{{.HostConfig.RestartPolicy.Name}}|{{if .State.Health}}{{.State.Health.Status}}{{else}}none{{end}}' web2 2>/dev/null", "running|nginx:latest|always|none", "", 0, nil)
+				m.SetCommandResult("docker inspect --format '{{.State.Status}}|{{.Config.Image}}|{{.HostConfig.RestartPolicy.Name}}|{{if .State.Health}}{{.State.Health.Status}}{{else}}none{{end}}|{{.HostConfig.Memory}}|{{.HostConfig.CpuShares}}|{{.State.StartedAt}}|{{.RestartCount}}' web1 2>/dev/null", "running|nginx:latest|always|none|0|0|2026-01-01T00:00:00Z|0", "", 0, nil)
+				m.SetCommandResult("docker inspect --format '{{.State.Status}}|{{.Config.Image}}|{{.HostConfig.RestartPolicy.Name}}|{{if .State.Health}}{{.State.Health.Status}}{{else}}none{{end}}|{{.HostConfig.Memory}}|{{.HostConfig.CpuShares}}|{{.State.StartedAt}}|{{.RestartCount}}' web2 2>/dev/null", "running|nginx:latest|always|none|0|0|2026-01-01T00:00:00Z|0", "", 0, nil)
 			},
 			wantStatus:   core.StatusPass,
 			wantContains: "2 containers are running",
@@ -189,8 +191,8 @@ func TestExecutor_DockerTest(t *testing.T) {
 				State:      "running",
 			},
 			setupMock: func(m *core.MockProvider) {
-				m.SetCommandResult("docker inspect --format '{{.State.Status}}|{{.Config.Image}}|{{.HostConfig.RestartPolicy.Name}}|{{if .State.Health}}{{.State.Health.Status}}{{else}}none{{end}}' web1 2>/dev/null", "running|nginx:latest|always|none", "", 0, nil)
-				m.SetCommandResult("docker inspect --format '{{.State.Status}}|{{.Config.Image}}|{{.HostConfig.RestartPolicy.Name}}|{{if .State.Health}}{{.State.Health.Status}}{{else}}none{{end}}' missing 2>/dev/null", "", "", 1, nil)
+				m.SetCommandResult("docker inspect --format '{{.State.Status}}|{{.Config.Image}}|{{.HostConfig.RestartPolicy.Name}}|{{if .State.Health}}{{.State.Health.Status}}{{else}}none{{end}}|{{.HostConfig.Memory}}|{{.HostConfig.CpuShares}}|{{.State.StartedAt}}|{{.RestartCount}}' web1 2>/dev/null", "running|nginx:latest|always|none|0|0|2026-01-01T00:00:00Z|0", "", 0, nil)
+				m.SetCommandResult("docker inspect --format '{{.State.Status}}|{{.Config.Image}}|{{.HostConfig.RestartPolicy.Name}}|{{if .State.Health}}{{.State.Health.Status}}{{else}}none{{end}}|{{.HostConfig.Memory}}|{{.HostConfig.CpuShares}}|{{.State.StartedAt}}|{{.RestartCount}}' missing 2>/dev/null", "", "", 1, nil)
 			},
 			wantStatus:   core.StatusFail,
 			wantContains: "does not exist",
@@ -203,11 +205,154 @@ func TestExecutor_DockerTest(t *testing.T) {
 				State:     "exists",
 			},
 			setupMock: func(m *core.MockProvider) {
-				m.SetCommandResult("docker inspect --format '{{.State.Status}}|{{.Config.Image}}|{{.HostConfig.RestartPolicy.Name}}|{{if .State.Health}}{{.State.Health.Status}}{{else}}none{{end}}' test 2>/dev/null", "created|test:v1|no|none", "", 0, nil)
+				m.SetCommandResult("docker inspect --format '{{.State.Status}}|{{.Config.Image}}|{{.HostConfig.RestartPolicy.Name}}|{{if .State.Health}}{{.State.Health.Status}}{{else}}none{{end}}|{{.HostConfig.Memory}}|{{.HostConfig.CpuShares}}|{{.State.StartedAt}}|{{.RestartCount}}' test 2>/dev/null", "created|test:v1|no|none|0|0|2026-01-01T00:00:00Z|0", "", 0, nil)
 			},
 			wantStatus:   core.StatusPass,
 			wantContains: "exist",
 		},
+		{
+			name: "memory limit within bound",
+			dockerTest: core.DockerTest{
+				Name:        "Check api limits",
+				Container:   "api",
+				State:       "running",
+				MemoryLimit: "512Mi",
+			},
+			setupMock: func(m *core.MockProvider) {
+				m.SetCommandResult("docker inspect --format '{{.State.Status}}|{{.Config.Image}}|{{.HostConfig.RestartPolicy.Name}}|{{if .State.Health}}{{.State.Health.Status}}{{else}}none{{end}}|{{.HostConfig.Memory}}|{{.HostConfig.CpuShares}}|{{.State.StartedAt}}|{{.RestartCount}}' api 2>/dev/null", "running|api:2.0|always|none|268435456|0|2026-01-01T00:00:00Z|0", "", 0, nil)
+			},
+			wantStatus:   core.StatusPass,
+			wantContains: "within resource limits",
+		},
+		{
+			name: "memory limit matches exactly",
+			dockerTest: core.DockerTest{
+				Name:        "Check api limits",
+				Container:   "api",
+				State:       "running",
+				MemoryLimit: "512Mi",
+			},
+			setupMock: func(m *core.MockProvider) {
+				m.SetCommandResult("docker inspect --format '{{.State.Status}}|{{.Config.Image}}|{{.HostConfig.RestartPolicy.Name}}|{{if .State.Health}}{{.State.Health.Status}}{{else}}none{{end}}|{{.HostConfig.Memory}}|{{.HostConfig.CpuShares}}|{{.State.StartedAt}}|{{.RestartCount}}' api 2>/dev/null", "running|api:2.0|always|none|536870912|0|2026-01-01T00:00:00Z|0", "", 0, nil)
+			},
+			wantStatus:   core.StatusPass,
+			wantContains: "within resource limits",
+		},
+		{
+			name: "memory limit exceeds bound",
+			dockerTest: core.DockerTest{
+				Name:        "Check api limits",
+				Container:   "api",
+				State:       "running",
+				MemoryLimit: "512Mi",
+			},
+			setupMock: func(m *core.MockProvider) {
+				m.SetCommandResult("docker inspect --format '{{.State.Status}}|{{.Config.Image}}|{{.HostConfig.RestartPolicy.Name}}|{{if .State.Health}}{{.State.Health.Status}}{{else}}none{{end}}|{{.HostConfig.Memory}}|{{.HostConfig.CpuShares}}|{{.State.StartedAt}}|{{.RestartCount}}' api 2>/dev/null", "running|api:2.0|always|none|1073741824|0|2026-01-01T00:00:00Z|0", "", 0, nil)
+			},
+			wantStatus:   core.StatusFail,
+			wantContains: "memory limit is 1073741824 bytes, expected at most 512Mi",
+		},
+		{
+			name: "memory unlimited",
+			dockerTest: core.DockerTest{
+				Name:        "Check api limits",
+				Container:   "api",
+				State:       "running",
+				MemoryLimit: "512Mi",
+			},
+			setupMock: func(m *core.MockProvider) {
+				m.SetCommandResult("docker inspect --format '{{.State.Status}}|{{.Config.Image}}|{{.HostConfig.RestartPolicy.Name}}|{{if .State.Health}}{{.State.Health.Status}}{{else}}none{{end}}|{{.HostConfig.Memory}}|{{.HostConfig.CpuShares}}|{{.State.StartedAt}}|{{.RestartCount}}' api 2>/dev/null", "running|api:2.0|always|none|0|0|2026-01-01T00:00:00Z|0", "", 0, nil)
+			},
+			wantStatus:   core.StatusFail,
+			wantContains: "has no memory limit",
+		},
+		{
+			name: "cpu shares match",
+			dockerTest: core.DockerTest{
+				Name:      "Check api limits",
+				Container: "api",
+				State:     "running",
+				CPUShares: 512,
+			},
+			setupMock: func(m *core.MockProvider) {
+				m.SetCommandResult("docker inspect --format '{{.State.Status}}|{{.Config.Image}}|{{.HostConfig.RestartPolicy.Name}}|{{if .State.Health}}{{.State.Health.Status}}{{else}}none{{end}}|{{.HostConfig.Memory}}|{{.HostConfig.CpuShares}}|{{.State.StartedAt}}|{{.RestartCount}}' api 2>/dev/null", "running|api:2.0|always|none|0|512|2026-01-01T00:00:00Z|0", "", 0, nil)
+			},
+			wantStatus:   core.StatusPass,
+			wantContains: "within resource limits",
+		},
+		{
+			name: "cpu shares default",
+			dockerTest: core.DockerTest{
+				Name:      "Check api limits",
+				Container: "api",
+				State:     "running",
+				CPUShares: 1024,
+			},
+			setupMock: func(m *core.MockProvider) {
+				m.SetCommandResult("docker inspect --format '{{.State.Status}}|{{.Config.Image}}|{{.HostConfig.RestartPolicy.Name}}|{{if .State.Health}}{{.State.Health.Status}}{{else}}none{{end}}|{{.HostConfig.Memory}}|{{.HostConfig.CpuShares}}|{{.State.StartedAt}}|{{.RestartCount}}' api 2>/dev/null", "running|api:2.0|always|none|0|0|2026-01-01T00:00:00Z|0", "", 0, nil)
+			},
+			wantStatus:   core.StatusPass,
+			wantContains: "within resource limits",
+		},
+		{
+			name: "cpu shares differ",
+			dockerTest: core.DockerTest{
+				Name:      "Check api limits",
+				Container: "api",
+				State:     "running",
+				CPUShares: 512,
+			},
+			setupMock: func(m *core.MockProvider) {
+				m.SetCommandResult("docker inspect --format '{{.State.Status}}|{{.Config.Image}}|{{.HostConfig.RestartPolicy.Name}}|{{if .State.Health}}{{.State.Health.Status}}{{else}}none{{end}}|{{.HostConfig.Memory}}|{{.HostConfig.CpuShares}}|{{.State.StartedAt}}|{{.RestartCount}}' api 2>/dev/null", "running|api:2.0|always|none|0|2048|2026-01-01T00:00:00Z|0", "", 0, nil)
+			},
+			wantStatus:   core.StatusFail,
+			wantContains: "CPU shares are 2048, expected 512",
+		},
+		{
+			name: "stable container",
+			dockerTest: core.DockerTest{
+				Name:             "Worker stable",
+				Container:        "worker",
+				State:            "running",
+				MinUptimeSeconds: 300,
+				MaxRestarts:      intPtr(0),
+			},
+			setupMock: func(m *core.MockProvider) {
+				startedAt := time.Now().Add(-2 * time.Hour).UTC().Format(time.RFC3339Nano)
+				m.SetCommandResult("docker inspect --format '{{.State.Status}}|{{.Config.Image}}|{{.HostConfig.RestartPolicy.Name}}|{{if .State.Health}}{{.State.Health.Status}}{{else}}none{{end}}|{{.HostConfig.Memory}}|{{.HostConfig.CpuShares}}|{{.State.StartedAt}}|{{.RestartCount}}' worker 2>/dev/null", "running|worker:1.4|always|none|0|0|"+startedAt+"|0", "", 0, nil)
+			},
+			wantStatus:   core.StatusPass,
+			wantContains: "and stable",
+		},
+		{
+			name: "crash-looping container",
+			dockerTest: core.DockerTest{
+				Name:        "Worker stable",
+				Container:   "worker",
+				State:       "running",
+				MaxRestarts: intPtr(3),
+			},
+			setupMock: func(m *core.MockProvider) {
+				m.SetCommandResult("docker inspect --format '{{.State.Status}}|{{.Config.Image}}|{{.HostConfig.RestartPolicy.Name}}|{{if .State.Health}}{{.State.Health.Status}}{{else}}none{{end}}|{{.HostConfig.Memory}}|{{.HostConfig.CpuShares}}|{{.State.StartedAt}}|{{.RestartCount}}' worker 2>/dev/null", "running|worker:1.4|always|none|0|0|2026-01-01T00:00:00Z|47", "", 0, nil)
+			},
+			wantStatus:   core.StatusFail,
+			wantContains: "has restarted 47 times, expected at most 3",
+		},
+		{
+			name: "container started recently",
+			dockerTest: core.DockerTest{
+				Name:             "Worker stable",
+				Container:        "worker",
+				State:            "running",
+				MinUptimeSeconds: 300,
+			},
+			setupMock: func(m *core.MockProvider) {
+				startedAt := time.Now().Add(-10 * time.Second).UTC().Format(time.RFC3339Nano)
+				m.SetCommandResult("docker inspect --format '{{.State.Status}}|{{.Config.Image}}|{{.HostConfig.RestartPolicy.Name}}|{{if .State.Health}}{{.State.Health.Status}}{{else}}none{{end}}|{{.HostConfig.Memory}}|{{.HostConfig.CpuShares}}|{{.State.StartedAt}}|{{.RestartCount}}' worker 2>/dev/null", "running|worker:1.4|always|none|0|0|"+startedAt+"|1", "", 0, nil)
+			},
+			wantStatus:   core.StatusFail,
+			wantContains: "expected at least 300s",
+		},
 		{
 			name: "unexpected docker inspect output",
 			dockerTest: core.DockerTest{
@@ -216,11 +361,24 @@ func TestExecutor_DockerTest(t *testing.T) {
 				State:     "running",
 			},
 			setupMock: func(m *core.MockProvider) {
-				m.SetCommandResult("docker inspect --format '{{.State.Status}}|{{.Config.Image}}|{{.HostConfig.RestartPolicy.Name}}|{{if .State.Health}}{{.State.Health.Status}}{{else}}none{{end}}' bad 2>/dev/null", "running|incomplete", "", 0, nil)
+				m.SetCommandResult("docker inspect --format '{{.State.Status}}|{{.Config.Image}}|{{.HostConfig.RestartPolicy.Name}}|{{if .State.Health}}{{.State.Health.Status}}{{else}}none{{end}}|{{.HostConfig.Memory}}|{{.HostConfig.CpuShares}}|{{.State.StartedAt}}|{{.RestartCount}}' bad 2>/dev/null", "running|incomplete", "", 0, nil)
 			},
 			wantStatus:   core.StatusError,
 			wantContains: "Unexpected docker inspect output",
 		},
+		{
+			name: "docker not installed",
+			dockerTest: core.DockerTest{
+				Name:      "Check nginx container",
+				Container: "nginx",
+				State:     "running",
+			},
+			setupMock: func(m *core.MockProvider) {
+				m.SetCommandResult("command -v 'docker' >/dev/null 2>&1", "", "", 127, nil)
+			},
+			wantStatus:   core.StatusError,
+			wantContains: "required command 'docker' not found on target",
+		},
 	}
 
 	for _, tt := range tests {
@@ -244,3 +402,266 @@ func TestExecutor_DockerTest(t *testing.T) {
 		})
 	}
 }
+
+func TestExecutor_DockerImageTest(t *testing.T) {
+	const (
+		inspectCmd = `docker image inspect --format '{{.Id}}|{{join .RepoDigests ","}}' 'nginx:1.25'`
+		imageID    = "sha256:4f67c83422ec747235357c04556616234e66fc3fa39cb4f40b2d4441ddd8f100"
+		repoDigest = "sha256:0d17b565c37bcbd895e9d92315a05c1c3c9a29f762b011a10c54a66cd53c9b31"
+	)
+	present := imageID + "|nginx@" + repoDigest + ",registry.example.com/nginx@" + repoDigest
+
+	tests := []struct {
+		name         string
+		imageTest    core.DockerImageTest
+		setupMock    func(*core.MockProvider)
+		wantStatus   core.Status
+		wantContains string
+	}{
+		{
+			name:      "image present",
+			imageTest: core.DockerImageTest{Name: "nginx pulled", Image: "nginx:1.25", State: "present"},
+			setupMock: func(m *core.MockProvider) {
+				m.SetCommandResult(inspectCmd, present+"\n", "", 0, nil)
+			},
+			wantStatus:   core.StatusPass,
+			wantContains: "Image nginx:1.25 is present",
+		},
+		{
+			name:      "image missing",
+			imageTest: core.DockerImageTest{Name: "nginx pulled", Image: "nginx:1.25", State: "present"},
+			setupMock: func(m *core.MockProvider) {
+				m.SetCommandResult(inspectCmd, "\n", "Error response from daemon: No such image: nginx:1.25\n", 1, nil)
+			},
+			wantStatus:   core.StatusFail,
+			wantContains: "is not present",
+		},
+		{
+			name:      "image absent as expected",
+			imageTest: core.DockerImageTest{Name: "old nginx removed", Image: "nginx:1.25", State: "absent"},
+			setupMock: func(m *core.MockProvider) {
+				m.SetCommandResult(inspectCmd, "\n", "Error: No such image: nginx:1.25\n", 1, nil)
+			},
+			wantStatus:   core.StatusPass,
+			wantContains: "is absent",
+		},
+		{
+			name:      "image present but should be absent",
+			imageTest: core.DockerImageTest{Name: "old nginx removed", Image: "nginx:1.25", State: "absent"},
+			setupMock: func(m *core.MockProvider) {
+				m.SetCommandResult(inspectCmd, present, "", 0, nil)
+			},
+			wantStatus:   core.StatusFail,
+			wantContains: "expected absent",
+		},
+		{
+			name:      "repo digest matches",
+			imageTest: core.DockerImageTest{Name: "pinned nginx", Image: "nginx:1.25", State: "present", Digest: repoDigest},
+			setupMock: func(m *core.MockProvider) {
+				m.SetCommandResult(inspectCmd, present, "", 0, nil)
+			},
+			wantStatus:   core.StatusPass,
+			wantContains: "with expected digest",
+		},
+		{
+			name:      "image ID matches",
+			imageTest: core.DockerImageTest{Name: "pinned nginx", Image: "nginx:1.25", State: "present", Digest: imageID},
+			setupMock: func(m *core.MockProvider) {
+				m.SetCommandResult(inspectCmd, imageID+"|", "", 0, nil)
+			},
+			wantStatus:   core.StatusPass,
+			wantContains: "with expected digest",
+		},
+		{
+			name: "digest mismatch",
+			imageTest: core.DockerImageTest{
+				Name: "pinned nginx", Image: "nginx:1.25", State: "present",
+				Digest: "sha256:1111111111111111111111111111111111111111111111111111111111111111",
+			},
+			setupMock: func(m *core.MockProvider) {
+				m.SetCommandResult(inspectCmd, present, "", 0, nil)
+			},
+			wantStatus:   core.StatusFail,
+			wantContains: "does not match digest",
+		},
+		{
+			name:      "docker daemon unavailable",
+			imageTest: core.DockerImageTest{Name: "nginx pulled", Image: "nginx:1.25", State: "present"},
+			setupMock: func(m *core.MockProvider) {
+				m.SetCommandResult(inspectCmd, "", "Cannot connect to the Docker daemon at unix:///var/run/docker.sock\n", 1, nil)
+			},
+			wantStatus:   core.StatusError,
+			wantContains: "Cannot connect to the Docker daemon",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := core.NewMockProvider()
+			tt.setupMock(mock)
+
+			result := executeDockerImageTest(context.Background(), mock, tt.imageTest)
+			if result.Status != tt.wantStatus {
+				t.Errorf("Status = %v, want %v (message: %s)", result.Status, tt.wantStatus, result.Message)
+			}
+			if !strings.Contains(result.Message, tt.wantContains) {
+				t.Errorf("Message = %q, want it to contain %q", result.Message, tt.wantContains)
+			}
+		})
+	}
+}
+
+func TestExecutor_DockerNetworkTest(t *testing.T) {
+	const inspectCmd = "docker network inspect --format '{{.Driver}}' 'app_backend'"
+
+	tests := []struct {
+		name         string
+		networkTest  core.DockerNetworkTest
+		setupMock    func(*core.MockProvider)
+		wantStatus   core.Status
+		wantContains string
+	}{
+		{
+			name:        "network exists",
+			networkTest: core.DockerNetworkTest{Name: "backend network", Network: "app_backend", State: "present"},
+			setupMock: func(m *core.MockProvider) {
+				m.SetCommandResult(inspectCmd, "bridge\n", "", 0, nil)
+			},
+			wantStatus:   core.StatusPass,
+			wantContains: "Network app_backend exists",
+		},
+		{
+			name:        "network exists with expected driver",
+			networkTest: core.DockerNetworkTest{Name: "backend network", Network: "app_backend", Driver: "overlay", State: "present"},
+			setupMock: func(m *core.MockProvider) {
+				m.SetCommandResult(inspectCmd, "overlay\n", "", 0, nil)
+			},
+			wantStatus:   core.StatusPass,
+			wantContains: "with driver overlay",
+		},
+		{
+			name:        "network has wrong driver",
+			networkTest: core.DockerNetworkTest{Name: "backend network", Network: "app_backend", Driver: "overlay", State: "present"},
+			setupMock: func(m *core.MockProvider) {
+				m.SetCommandResult(inspectCmd, "bridge\n", "", 0, nil)
+			},
+			wantStatus:   core.StatusFail,
+			wantContains: "driver is bridge, expected overlay",
+		},
+		{
+			name:        "network missing",
+			networkTest: core.DockerNetworkTest{Name: "backend network", Network: "app_backend", State: "present"},
+			setupMock: func(m *core.MockProvider) {
+				m.SetCommandResult(inspectCmd, "\n", "Error response from daemon: network app_backend not found\nError: No such network: app_backend\n", 1, nil)
+			},
+			wantStatus:   core.StatusFail,
+			wantContains: "does not exist",
+		},
+		{
+			name:        "network absent as expected",
+			networkTest: core.DockerNetworkTest{Name: "legacy network removed", Network: "app_backend", State: "absent"},
+			setupMock: func(m *core.MockProvider) {
+				m.SetCommandResult(inspectCmd, "\n", "Error: No such network: app_backend\n", 1, nil)
+			},
+			wantStatus:   core.StatusPass,
+			wantContains: "is absent",
+		},
+		{
+			name:        "network exists but should be absent",
+			networkTest: core.DockerNetworkTest{Name: "legacy network removed", Network: "app_backend", State: "absent"},
+			setupMock: func(m *core.MockProvider) {
+				m.SetCommandResult(inspectCmd, "bridge\n", "", 0, nil)
+			},
+			wantStatus:   core.StatusFail,
+			wantContains: "expected absent",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := core.NewMockProvider()
+			tt.setupMock(mock)
+
+			result := executeDockerNetworkTest(context.Background(), mock, tt.networkTest)
+			if result.Status != tt.wantStatus {
+				t.Errorf("Status = %v, want %v (message: %s)", result.Status, tt.wantStatus, result.Message)
+			}
+			if !strings.Contains(result.Message, tt.wantContains) {
+				t.Errorf("Message = %q, want it to contain %q", result.Message, tt.wantContains)
+			}
+		})
+	}
+}
+
+func TestExecutor_DockerVolumeTest(t *testing.T) {
+	const inspectCmd = "docker volume inspect --format '{{.Driver}}' 'pgdata'"
+
+	tests := []struct {
+		name         string
+		volumeTest   core.DockerVolumeTest
+		setupMock    func(*core.MockProvider)
+		wantStatus   core.Status
+		wantContains string
+	}{
+		{
+			name:       "volume exists with expected driver",
+			volumeTest: core.DockerVolumeTest{Name: "postgres data", Volume: "pgdata", Driver: "local", State: "present"},
+			setupMock: func(m *core.MockProvider) {
+				m.SetCommandResult(inspectCmd, "local\n", "", 0, nil)
+			},
+			wantStatus:   core.StatusPass,
+			wantContains: "Volume pgdata exists with driver local",
+		},
+		{
+			name:       "volume has wrong driver",
+			volumeTest: core.DockerVolumeTest{Name: "postgres data", Volume: "pgdata", Driver: "rexray/ebs", State: "present"},
+			setupMock: func(m *core.MockProvider) {
+				m.SetCommandResult(inspectCmd, "local\n", "", 0, nil)
+			},
+			wantStatus:   core.StatusFail,
+			wantContains: "driver is local, expected rexray/ebs",
+		},
+		{
+			name:       "volume missing",
+			volumeTest: core.DockerVolumeTest{Name: "postgres data", Volume: "pgdata", State: "present"},
+			setupMock: func(m *core.MockProvider) {
+				m.SetCommandResult(inspectCmd, "\n", "Error response from daemon: get pgdata: no such volume\n", 1, nil)
+			},
+			wantStatus:   core.StatusFail,
+			wantContains: "Volume pgdata does not exist",
+		},
+		{
+			name:       "volume absent as expected",
+			volumeTest: core.DockerVolumeTest{Name: "scratch volume removed", Volume: "pgdata", State: "absent"},
+			setupMock: func(m *core.MockProvider) {
+				m.SetCommandResult(inspectCmd, "\n", "Error: No such volume: pgdata\n", 1, nil)
+			},
+			wantStatus:   core.StatusPass,
+			wantContains: "is absent",
+		},
+		{
+			name:       "docker permission denied",
+			volumeTest: core.DockerVolumeTest{Name: "postgres data", Volume: "pgdata", State: "present"},
+			setupMock: func(m *core.MockProvider) {
+				m.SetCommandResult(inspectCmd, "", "permission denied while trying to connect to the Docker daemon socket\n", 1, nil)
+			},
+			wantStatus:   core.StatusError,
+			wantContains: "permission denied",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := core.NewMockProvider()
+			tt.setupMock(mock)
+
+			result := executeDockerVolumeTest(context.Background(), mock, tt.volumeTest)
+			if result.Status != tt.wantStatus {
+				t.Errorf("Status = %v, want %v (message: %s)", result.Status, tt.wantStatus, result.Message)
+			}
+			if !strings.Contains(result.Message, tt.wantContains) {
+				t.Errorf("Message = %q, want it to contain %q", result.Message, tt.wantContains)
+			}
+		})
+	}
+}
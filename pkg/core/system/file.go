@@ -3,6 +3,7 @@ package system
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
@@ -11,6 +12,10 @@ import (
 
 // executeFileTest executes a file test
 func executeFileTest(ctx context.Context, provider core.Provider, test core.FileTest) core.Result {
+	if core.TargetOS(ctx, provider) == core.OSWindows {
+		return executeWindowsFileTest(ctx, provider, test)
+	}
+
 	start := time.Now()
 	result := core.Result{
 		Name:    test.Name,
@@ -18,8 +23,13 @@ func executeFileTest(ctx context.Context, provider core.Provider, test core.File
 		Details: make(map[string]interface{}),
 	}
 
-	// Implementation moved from assertions/file.go
-	stdout, _, exitCode, err := provider.ExecuteCommand(ctx, fmt.Sprintf("stat -c '%%F:%%U:%%G:%%a' %s 2>/dev/null || echo 'notfound'", test.Path))
+	// GNU and BSD stat take different format flags but produce the same "type:owner:group:mode" output
+	bsd := core.IsBSD(core.TargetOS(ctx, provider))
+	statFormat := "-c '%F:%U:%G:%a'"
+	if bsd {
+		statFormat = "-f '%HT:%Su:%Sg:%Lp'"
+	}
+	stdout, _, exitCode, err := provider.ExecuteCommand(ctx, fmt.Sprintf("stat %s %s 2>/dev/null || echo 'notfound'", statFormat, test.Path))
 	if err != nil {
 		result.Status = core.StatusError
 		result.Message = fmt.Sprintf("Error checking path %s: %v", test.Path, err)
@@ -85,11 +95,186 @@ func executeFileTest(ctx context.Context, provider core.Provider, test core.File
 		}
 	}
 
+	// Check owner, group and mode of everything below a directory
+	if test.Recursive && (test.Owner != "" || test.Group != "" || test.Mode != "") {
+		offending, err := findOffendingPaths(ctx, provider, test)
+		if err != nil {
+			result.Status = core.StatusError
+			result.Message = fmt.Sprintf("Error checking %s recursively: %v", test.Path, err)
+			result.Duration = time.Since(start)
+			return result
+		}
+		if len(offending) > 0 {
+			shown := offending
+			more := ""
+			if len(offending) > maxOffendingPaths {
+				shown = offending[:maxOffendingPaths]
+				more = ", ..."
+			}
+			result.Status = core.StatusFail
+			result.Message = fmt.Sprintf("Paths under %s do not match the expected owner/group/mode: %s%s", test.Path, strings.Join(shown, ", "), more)
+			result.Details["offending_paths"] = shown
+			result.Duration = time.Since(start)
+			return result
+		}
+	}
+
+	// Check modification age
+	if test.MaxAgeSeconds > 0 || test.MinAgeSeconds > 0 {
+		age, err := fileAge(ctx, provider, test.Path, bsd)
+		if err != nil {
+			result.Status = core.StatusError
+			result.Message = fmt.Sprintf("Error checking age of %s: %v", test.Path, err)
+			result.Duration = time.Since(start)
+			return result
+		}
+		result.Details["age_seconds"] = age
+
+		if test.MaxAgeSeconds > 0 && age > int64(test.MaxAgeSeconds) {
+			result.Status = core.StatusFail
+			result.Message = fmt.Sprintf("Path %s was modified %ds ago, expected at most %ds", test.Path, age, test.MaxAgeSeconds)
+			result.Duration = time.Since(start)
+			return result
+		}
+		if test.MinAgeSeconds > 0 && age < int64(test.MinAgeSeconds) {
+			result.Status = core.StatusFail
+			result.Message = fmt.Sprintf("Path %s was modified %ds ago, expected at least %ds", test.Path, age, test.MinAgeSeconds)
+			result.Duration = time.Since(start)
+			return result
+		}
+	}
+
+	// Check directory entries
+	if test.MinEntries > 0 || test.MaxEntries > 0 || test.Empty != nil {
+		entries, err := directoryEntryCount(ctx, provider, test.Path)
+		if err != nil {
+			result.Status = core.StatusError
+			result.Message = fmt.Sprintf("Error counting entries in %s: %v", test.Path, err)
+			result.Duration = time.Since(start)
+			return result
+		}
+		result.Details["entries"] = entries
+
+		if test.Empty != nil && *test.Empty && entries > 0 {
+			result.Status = core.StatusFail
+			result.Message = fmt.Sprintf("Directory %s has %d entries, expected it to be empty", test.Path, entries)
+			result.Duration = time.Since(start)
+			return result
+		}
+		if test.Empty != nil && !*test.Empty && entries == 0 {
+			result.Status = core.StatusFail
+			result.Message = fmt.Sprintf("Directory %s is empty, expected entries", test.Path)
+			result.Duration = time.Since(start)
+			return result
+		}
+		if test.MinEntries > 0 && entries < test.MinEntries {
+			result.Status = core.StatusFail
+			result.Message = fmt.Sprintf("Directory %s has %d entries, expected at least %d", test.Path, entries, test.MinEntries)
+			result.Duration = time.Since(start)
+			return result
+		}
+		if test.MaxEntries > 0 && entries > test.MaxEntries {
+			result.Status = core.StatusFail
+			result.Message = fmt.Sprintf("Directory %s has %d entries, expected at most %d", test.Path, entries, test.MaxEntries)
+			result.Duration = time.Since(start)
+			return result
+		}
+	}
+
 	result.Message = fmt.Sprintf("Path %s exists with correct properties", test.Path)
 	result.Duration = time.Since(start)
 	return result
 }
 
+// fileAge returns the seconds since a path was last modified, measured
+// against the target's clock so local clock skew does not matter
+func fileAge(ctx context.Context, provider core.Provider, path string, bsd bool) (int64, error) {
+	mtimeFormat := "-c %Y"
+	if bsd {
+		mtimeFormat = "-f %m"
+	}
+	stdout, stderr, exitCode, err := provider.ExecuteCommand(ctx, fmt.Sprintf("stat %s %s && date +%%s", mtimeFormat, core.ShellQuote(path)))
+	if err != nil {
+		return 0, err
+	}
+	if exitCode != 0 {
+		return 0, fmt.Errorf("stat failed: %s", strings.TrimSpace(stderr))
+	}
+
+	fields := strings.Fields(stdout)
+	if len(fields) != 2 {
+		return 0, fmt.Errorf("unexpected output %q", strings.TrimSpace(stdout))
+	}
+	mtime, err := strconv.ParseInt(fields[0], 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid modification time %q", fields[0])
+	}
+	now, err := strconv.ParseInt(fields[1], 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid current time %q", fields[1])
+	}
+
+	age := now - mtime
+	if age < 0 {
+		// Modified "in the future" (e.g. clock adjusted after the write)
+		age = 0
+	}
+	return age, nil
+}
+
+// maxOffendingPaths is the number of mismatching paths reported by a recursive file test
+const maxOffendingPaths = 5
+
+// findOffendingPaths returns paths under test.Path whose owner, group or mode
+// differ from the test's expectations. At most maxOffendingPaths+1 are returned
+// so callers can tell whether the list was truncated.
+func findOffendingPaths(ctx context.Context, provider core.Provider, test core.FileTest) ([]string, error) {
+	var conditions []string
+	if test.Owner != "" {
+		conditions = append(conditions, fmt.Sprintf("! -user %s", core.ShellQuote(test.Owner)))
+	}
+	if test.Group != "" {
+		conditions = append(conditions, fmt.Sprintf("! -group %s", core.ShellQuote(test.Group)))
+	}
+	if test.Mode != "" {
+		conditions = append(conditions, fmt.Sprintf("! -perm %s", normalizeMode(test.Mode)))
+	}
+
+	cmd := fmt.Sprintf("find %s \\( %s \\) -print 2>/dev/null | head -n %d", core.ShellQuote(test.Path), strings.Join(conditions, " -o "), maxOffendingPaths+1)
+	stdout, stderr, exitCode, err := provider.ExecuteCommand(ctx, cmd)
+	if err != nil {
+		return nil, err
+	}
+	if exitCode != 0 {
+		return nil, fmt.Errorf("find failed: %s", strings.TrimSpace(stderr))
+	}
+
+	var paths []string
+	for _, line := range strings.Split(stdout, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			paths = append(paths, line)
+		}
+	}
+	return paths, nil
+}
+
+// directoryEntryCount returns the number of entries in a directory, including hidden ones
+func directoryEntryCount(ctx context.Context, provider core.Provider, path string) (int, error) {
+	stdout, stderr, exitCode, err := provider.ExecuteCommand(ctx, fmt.Sprintf("ls -A %s | wc -l", core.ShellQuote(path)))
+	if err != nil {
+		return 0, err
+	}
+	if exitCode != 0 {
+		return 0, fmt.Errorf("ls failed: %s", strings.TrimSpace(stderr))
+	}
+
+	count, err := strconv.Atoi(strings.TrimSpace(stdout))
+	if err != nil {
+		return 0, fmt.Errorf("unexpected output %q", strings.TrimSpace(stdout))
+	}
+	return count, nil
+}
+
 func normalizeFileType(statType string) string {
 	statType = strings.ToLower(statType)
 	if strings.Contains(statType, "directory") {
@@ -3,6 +3,7 @@ package system
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/neilfarmer/platform-spec/pkg/core"
@@ -79,6 +80,8 @@ func executeFileContentTest(ctx context.Context, provider core.Provider, test co
 		result.Details["pattern"] = "matched"
 	}
 
+	attachFileContentEvidence(ctx, provider, test, &result)
+
 	if len(test.Contains) > 0 && test.Matches != "" {
 		result.Message = fmt.Sprintf("File %s contains all %d strings and matches pattern", test.Path, len(test.Contains))
 	} else if len(test.Contains) > 0 {
@@ -90,3 +93,34 @@ func executeFileContentTest(ctx context.Context, provider core.Provider, test co
 	result.Duration = time.Since(start)
 	return result
 }
+
+// maxEvidenceLines caps the matching lines attached to a file content result
+const maxEvidenceLines = 50
+
+// attachFileContentEvidence attaches the numbered lines that satisfied the
+// contains and matches checks, as an audit trail of what was found. A failure
+// to read them does not change the result.
+func attachFileContentEvidence(ctx context.Context, provider core.Provider, test core.FileContentTest, result *core.Result) {
+	attach := result.Attach
+	if test.Sensitive {
+		attach = result.AttachSensitive
+	}
+
+	if len(test.Contains) > 0 {
+		patterns := make([]string, 0, len(test.Contains))
+		for _, searchStr := range test.Contains {
+			patterns = append(patterns, "-e "+core.ShellQuote(searchStr))
+		}
+		cmd := fmt.Sprintf("grep -n -m %d -F %s %s", maxEvidenceLines, strings.Join(patterns, " "), test.Path)
+		if stdout, _, exitCode, err := provider.ExecuteCommand(ctx, cmd); err == nil && exitCode == 0 && stdout != "" {
+			attach("contains", stdout)
+		}
+	}
+
+	if test.Matches != "" {
+		cmd := fmt.Sprintf("grep -n -m %d -E %s %s", maxEvidenceLines, core.ShellQuote(test.Matches), test.Path)
+		if stdout, _, exitCode, err := provider.ExecuteCommand(ctx, cmd); err == nil && exitCode == 0 && stdout != "" {
+			attach("matches", stdout)
+		}
+	}
+}
@@ -140,3 +140,40 @@ func TestExecutor_FileContentTest(t *testing.T) {
 		})
 	}
 }
+
+func TestExecutor_FileContentEvidence(t *testing.T) {
+	test := core.FileContentTest{
+		Name:     "SSH hardened",
+		Path:     "/etc/ssh/sshd_config",
+		Contains: []string{"X11Forwarding no"},
+		Matches:  "^PermitRootLogin no$",
+	}
+	mock := core.NewMockProvider()
+	mock.SetCommandResult("test -f /etc/ssh/sshd_config && test -r /etc/ssh/sshd_config", "", "", 0, nil)
+	mock.SetCommandResult("grep -F 'X11Forwarding no' /etc/ssh/sshd_config >/dev/null 2>&1", "", "", 0, nil)
+	mock.SetCommandResult("grep -E '^PermitRootLogin no$' /etc/ssh/sshd_config >/dev/null 2>&1", "", "", 0, nil)
+	mock.SetCommandResult("grep -n -m 50 -F -e 'X11Forwarding no' /etc/ssh/sshd_config", "12:X11Forwarding no\n", "", 0, nil)
+	mock.SetCommandResult("grep -n -m 50 -E '^PermitRootLogin no$' /etc/ssh/sshd_config", "34:PermitRootLogin no\n", "", 0, nil)
+
+	result := executeFileContentTest(context.Background(), mock, test)
+	if result.Status != core.StatusPass {
+		t.Fatalf("Status = %v, want pass: %s", result.Status, result.Message)
+	}
+	if got := result.Attachments["contains"]; got != "12:X11Forwarding no\n" {
+		t.Errorf("Attachments[contains] = %q", got)
+	}
+	if got := result.Attachments["matches"]; got != "34:PermitRootLogin no\n" {
+		t.Errorf("Attachments[matches] = %q", got)
+	}
+
+	// Sensitive files keep their evidence out of results unless the spec asks
+	test.Sensitive = true
+	spec := &core.Spec{Tests: core.Tests{FileContent: []core.FileContentTest{test}}}
+	results, err := core.NewExecutor(spec, mock, NewSystemPlugin()).Execute(context.Background())
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if got := results.Results[0].Attachments["matches"]; got != core.Redacted {
+		t.Errorf("Attachments[matches] = %q, want %q for a sensitive file", got, core.Redacted)
+	}
+}
@@ -85,6 +85,163 @@ func TestExecutor_FileTest(t *testing.T) {
 			wantStatus:   core.StatusFail,
 			wantContains: "mode is 755, expected 700",
 		},
+		{
+			name: "log file written recently",
+			fileTest: core.FileTest{
+				Name:          "App log fresh",
+				Path:          "/var/log/app.log",
+				Type:          "file",
+				MaxAgeSeconds: 300,
+			},
+			setupMock: func(m *core.MockProvider) {
+				m.SetCommandResult("stat -c '%F:%U:%G:%a' /var/log/app.log 2>/dev/null || echo 'notfound'", "regular file:app:app:644", "", 0, nil)
+				m.SetCommandResult("stat -c %Y '/var/log/app.log' && date +%s", "1760000000\n1760000060\n", "", 0, nil)
+			},
+			wantStatus:   core.StatusPass,
+			wantContains: "exists with correct properties",
+		},
+		{
+			name: "stale log file",
+			fileTest: core.FileTest{
+				Name:          "App log fresh",
+				Path:          "/var/log/app.log",
+				Type:          "file",
+				MaxAgeSeconds: 300,
+			},
+			setupMock: func(m *core.MockProvider) {
+				m.SetCommandResult("stat -c '%F:%U:%G:%a' /var/log/app.log 2>/dev/null || echo 'notfound'", "regular file:app:app:644", "", 0, nil)
+				m.SetCommandResult("stat -c %Y '/var/log/app.log' && date +%s", "1760000000\n1760003600\n", "", 0, nil)
+			},
+			wantStatus:   core.StatusFail,
+			wantContains: "modified 3600s ago, expected at most 300s",
+		},
+		{
+			name: "lock file not yet stale",
+			fileTest: core.FileTest{
+				Name:          "Stale lock",
+				Path:          "/var/run/job.lock",
+				Type:          "file",
+				MinAgeSeconds: 3600,
+			},
+			setupMock: func(m *core.MockProvider) {
+				m.SetCommandResult("stat -c '%F:%U:%G:%a' /var/run/job.lock 2>/dev/null || echo 'notfound'", "regular empty file:root:root:644", "", 0, nil)
+				m.SetCommandResult("stat -c %Y '/var/run/job.lock' && date +%s", "1760000000\n1760000120\n", "", 0, nil)
+			},
+			wantStatus:   core.StatusFail,
+			wantContains: "modified 120s ago, expected at least 3600s",
+		},
+		{
+			name: "deferred queue is empty",
+			fileTest: core.FileTest{
+				Name:  "No deferred mail",
+				Path:  "/var/spool/postfix/deferred",
+				Type:  "directory",
+				Empty: boolPtr(true),
+			},
+			setupMock: func(m *core.MockProvider) {
+				m.SetCommandResult("stat -c '%F:%U:%G:%a' /var/spool/postfix/deferred 2>/dev/null || echo 'notfound'", "directory:postfix:root:700", "", 0, nil)
+				m.SetCommandResult("ls -A '/var/spool/postfix/deferred' | wc -l", "0\n", "", 0, nil)
+			},
+			wantStatus:   core.StatusPass,
+			wantContains: "exists with correct properties",
+		},
+		{
+			name: "deferred queue not empty",
+			fileTest: core.FileTest{
+				Name:  "No deferred mail",
+				Path:  "/var/spool/postfix/deferred",
+				Type:  "directory",
+				Empty: boolPtr(true),
+			},
+			setupMock: func(m *core.MockProvider) {
+				m.SetCommandResult("stat -c '%F:%U:%G:%a' /var/spool/postfix/deferred 2>/dev/null || echo 'notfound'", "directory:postfix:root:700", "", 0, nil)
+				m.SetCommandResult("ls -A '/var/spool/postfix/deferred' | wc -l", "4\n", "", 0, nil)
+			},
+			wantStatus:   core.StatusFail,
+			wantContains: "has 4 entries, expected it to be empty",
+		},
+		{
+			name: "plugin directory within range",
+			fileTest: core.FileTest{
+				Name:       "Plugins installed",
+				Path:       "/opt/app/plugins",
+				Type:       "directory",
+				MinEntries: 2,
+				MaxEntries: 5,
+			},
+			setupMock: func(m *core.MockProvider) {
+				m.SetCommandResult("stat -c '%F:%U:%G:%a' /opt/app/plugins 2>/dev/null || echo 'notfound'", "directory:app:app:755", "", 0, nil)
+				m.SetCommandResult("ls -A '/opt/app/plugins' | wc -l", "3\n", "", 0, nil)
+			},
+			wantStatus:   core.StatusPass,
+			wantContains: "exists with correct properties",
+		},
+		{
+			name: "plugin directory over max",
+			fileTest: core.FileTest{
+				Name:       "Plugins installed",
+				Path:       "/opt/app/plugins",
+				Type:       "directory",
+				MinEntries: 2,
+				MaxEntries: 5,
+			},
+			setupMock: func(m *core.MockProvider) {
+				m.SetCommandResult("stat -c '%F:%U:%G:%a' /opt/app/plugins 2>/dev/null || echo 'notfound'", "directory:app:app:755", "", 0, nil)
+				m.SetCommandResult("ls -A '/opt/app/plugins' | wc -l", "7\n", "", 0, nil)
+			},
+			wantStatus:   core.StatusFail,
+			wantContains: "has 7 entries, expected at most 5",
+		},
+		{
+			name: "data directory recursively owned",
+			fileTest: core.FileTest{
+				Name:      "Postgres data",
+				Path:      "/var/lib/postgresql",
+				Type:      "directory",
+				Owner:     "postgres",
+				Group:     "postgres",
+				Recursive: true,
+			},
+			setupMock: func(m *core.MockProvider) {
+				m.SetCommandResult("stat -c '%F:%U:%G:%a' /var/lib/postgresql 2>/dev/null || echo 'notfound'", "directory:postgres:postgres:700", "", 0, nil)
+				m.SetCommandResult("find '/var/lib/postgresql' \\( ! -user 'postgres' -o ! -group 'postgres' \\) -print 2>/dev/null | head -n 6", "", "", 0, nil)
+			},
+			wantStatus:   core.StatusPass,
+			wantContains: "exists with correct properties",
+		},
+		{
+			name: "data directory with offending files",
+			fileTest: core.FileTest{
+				Name:      "Postgres data",
+				Path:      "/var/lib/postgresql",
+				Type:      "directory",
+				Owner:     "postgres",
+				Mode:      "0700",
+				Recursive: true,
+			},
+			setupMock: func(m *core.MockProvider) {
+				m.SetCommandResult("stat -c '%F:%U:%G:%a' /var/lib/postgresql 2>/dev/null || echo 'notfound'", "directory:postgres:postgres:700", "", 0, nil)
+				m.SetCommandResult("find '/var/lib/postgresql' \\( ! -user 'postgres' -o ! -perm 700 \\) -print 2>/dev/null | head -n 6", "/var/lib/postgresql/16/main/pg_hba.conf\n/var/lib/postgresql/backup\n", "", 0, nil)
+			},
+			wantStatus:   core.StatusFail,
+			wantContains: "do not match the expected owner/group/mode: /var/lib/postgresql/16/main/pg_hba.conf, /var/lib/postgresql/backup",
+		},
+		{
+			name: "offending paths are truncated",
+			fileTest: core.FileTest{
+				Name:      "App data",
+				Path:      "/srv/data",
+				Type:      "directory",
+				Owner:     "app",
+				Recursive: true,
+			},
+			setupMock: func(m *core.MockProvider) {
+				m.SetCommandResult("stat -c '%F:%U:%G:%a' /srv/data 2>/dev/null || echo 'notfound'", "directory:app:app:755", "", 0, nil)
+				m.SetCommandResult("find '/srv/data' \\( ! -user 'app' \\) -print 2>/dev/null | head -n 6", "/srv/data/a\n/srv/data/b\n/srv/data/c\n/srv/data/d\n/srv/data/e\n/srv/data/f\n", "", 0, nil)
+			},
+			wantStatus:   core.StatusFail,
+			wantContains: "/srv/data/e, ...",
+		},
 	}
 
 	for _, tt := range tests {
@@ -108,3 +265,22 @@ func TestExecutor_FileTest(t *testing.T) {
 		})
 	}
 }
+
+func TestExecutor_FileTest_BSDStat(t *testing.T) {
+	mock := core.NewMockProvider()
+	mock.SetCommandResult("stat -f '%HT:%Su:%Sg:%Lp' /etc/hosts 2>/dev/null || echo 'notfound'", "Regular File:root:wheel:644\n", "", 0, nil)
+
+	ctx := core.WithFacts(context.Background(), &core.Facts{OS: core.OSDarwin})
+	result := executeFileTest(ctx, mock, core.FileTest{
+		Name:  "hosts file",
+		Path:  "/etc/hosts",
+		Type:  "file",
+		Owner: "root",
+		Group: "wheel",
+		Mode:  "0644",
+	})
+
+	if result.Status != core.StatusPass {
+		t.Errorf("Status = %v, want %v (message: %s)", result.Status, core.StatusPass, result.Message)
+	}
+}
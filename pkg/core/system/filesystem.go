@@ -18,8 +18,19 @@ func executeFilesystemTest(ctx context.Context, provider core.Provider, test cor
 		Details: make(map[string]interface{}),
 	}
 
-	// Check if path is mounted using findmnt
-	stdout, _, exitCode, err := provider.ExecuteCommand(ctx, fmt.Sprintf("findmnt --noheadings --output TARGET,FSTYPE,OPTIONS,SIZE,USED,USE%% --target %s 2>/dev/null", test.Path))
+	// Check if path is mounted
+	targetOS := core.TargetOS(ctx, provider)
+	lookup := lookupMountLinux
+	if core.IsBSD(targetOS) {
+		lookup = lookupMountBSD
+	} else if err := core.RequireCommand(ctx, provider, "findmnt"); err != nil {
+		// Without findmnt every path would look unmounted
+		result.Status = core.StatusError
+		result.Message = err.Error()
+		result.Duration = time.Since(start)
+		return result
+	}
+	fields, isMounted, err := lookup(ctx, provider, test.Path)
 	if err != nil {
 		result.Status = core.StatusError
 		result.Message = fmt.Sprintf("Error checking filesystem %s: %v", test.Path, err)
@@ -27,8 +38,6 @@ func executeFilesystemTest(ctx context.Context, provider core.Provider, test cor
 		return result
 	}
 
-	isMounted := (exitCode == 0 && stdout != "")
-
 	// Check mount state
 	if test.State == "mounted" && !isMounted {
 		result.Status = core.StatusFail
@@ -50,8 +59,6 @@ func executeFilesystemTest(ctx context.Context, provider core.Provider, test cor
 	}
 
 	// Parse mount information
-	stdout = strings.TrimSpace(stdout)
-	fields := strings.Fields(stdout)
 	if len(fields) < 6 {
 		result.Status = core.StatusError
 		result.Message = fmt.Sprintf("Unexpected findmnt output for %s", test.Path)
@@ -102,7 +109,11 @@ func executeFilesystemTest(ctx context.Context, provider core.Provider, test cor
 	// Check minimum size
 	if test.MinSizeGB > 0 {
 		// Get size in GB - use df for more reliable size info
-		stdout, _, _, err := provider.ExecuteCommand(ctx, fmt.Sprintf("df -BG --output=size %s | tail -1 | tr -d 'G '", test.Path))
+		sizeCmd := fmt.Sprintf("df -BG --output=size %s | tail -1 | tr -d 'G '", test.Path)
+		if core.IsBSD(targetOS) {
+			sizeCmd = fmt.Sprintf("df -g %s | tail -1 | awk '{print $2}'", test.Path)
+		}
+		stdout, _, _, err := provider.ExecuteCommand(ctx, sizeCmd)
 		if err == nil {
 			var actualSizeGB int
 			_, scanErr := fmt.Sscanf(strings.TrimSpace(stdout), "%d", &actualSizeGB)
@@ -151,3 +162,68 @@ func executeFilesystemTest(ctx context.Context, provider core.Provider, test cor
 	result.Duration = time.Since(start)
 	return result
 }
+
+// lookupMountLinux returns the findmnt fields (target, fstype, options, size, used, use%)
+// for the filesystem containing path
+func lookupMountLinux(ctx context.Context, provider core.Provider, path string) (fields []string, mounted bool, err error) {
+	stdout, _, exitCode, err := provider.ExecuteCommand(ctx, fmt.Sprintf("findmnt --noheadings --output TARGET,FSTYPE,OPTIONS,SIZE,USED,USE%% --target %s 2>/dev/null", path))
+	if err != nil {
+		return nil, false, err
+	}
+	if exitCode != 0 || stdout == "" {
+		return nil, false, nil
+	}
+	return strings.Fields(strings.TrimSpace(stdout)), true, nil
+}
+
+// lookupMountBSD builds the same fields as lookupMountLinux on BSD and macOS, which lack
+// findmnt, from df (size and usage) and mount (type and options) output
+func lookupMountBSD(ctx context.Context, provider core.Provider, path string) (fields []string, mounted bool, err error) {
+	stdout, _, exitCode, err := provider.ExecuteCommand(ctx, fmt.Sprintf("df -P -k %s 2>/dev/null | tail -1", path))
+	if err != nil {
+		return nil, false, err
+	}
+	// Filesystem 1024-blocks Used Available Capacity Mounted-on
+	dfFields := strings.Fields(stdout)
+	if exitCode != 0 || len(dfFields) < 6 {
+		return nil, false, nil
+	}
+	target := strings.Join(dfFields[5:], " ")
+
+	stdout, _, _, err = provider.ExecuteCommand(ctx, "mount 2>/dev/null")
+	if err != nil {
+		return nil, false, err
+	}
+
+	// Lines look like: /dev/disk1s1 on / (apfs, local, journaled)
+	for _, line := range strings.Split(stdout, "\n") {
+		marker := " on " + target + " ("
+		idx := strings.Index(line, marker)
+		if idx < 0 {
+			continue
+		}
+		attrs := strings.TrimSuffix(strings.TrimSpace(line[idx+len(marker):]), ")")
+		parts := strings.Split(attrs, ", ")
+		return []string{
+			target,
+			parts[0],
+			strings.Join(parts[1:], ","),
+			kilobytesToGB(dfFields[1]),
+			kilobytesToGB(dfFields[2]),
+			dfFields[4],
+		}, true, nil
+	}
+
+	return nil, false, nil
+}
+
+// kilobytesToGB formats a df kilobyte count the way findmnt reports sizes:
+// in gigabytes with one decimal, dropped when it is zero (1.9G, 20G)
+func kilobytesToGB(kb string) string {
+	var n int64
+	if _, err := fmt.Sscanf(kb, "%d", &n); err != nil {
+		return kb
+	}
+	size := fmt.Sprintf("%.1f", float64(n)/(1024*1024))
+	return strings.TrimSuffix(size, ".0") + "G"
+}
@@ -241,6 +241,19 @@ func TestExecutor_FilesystemTest(t *testing.T) {
 			wantStatus:   core.StatusError,
 			wantContains: "Error parsing filesystem usage percent",
 		},
+		{
+			name: "findmnt not installed",
+			filesystemTest: core.FilesystemTest{
+				Name:  "Data volume",
+				Path:  "/data",
+				State: "mounted",
+			},
+			setupMock: func(m *core.MockProvider) {
+				m.SetCommandResult("command -v 'findmnt' >/dev/null 2>&1", "", "", 1, nil)
+			},
+			wantStatus:   core.StatusError,
+			wantContains: "required command 'findmnt' not found on target",
+		},
 	}
 
 	for _, tt := range tests {
@@ -264,3 +277,97 @@ func TestExecutor_FilesystemTest(t *testing.T) {
 		})
 	}
 }
+
+func TestExecutor_FilesystemTest_BSD(t *testing.T) {
+	tests := []struct {
+		name           string
+		filesystemTest core.FilesystemTest
+		setupMock      func(*core.MockProvider)
+		wantStatus     core.Status
+		wantContains   string
+	}{
+		{
+			name: "mounted with type and options",
+			filesystemTest: core.FilesystemTest{
+				Name:    "Root filesystem",
+				Path:    "/",
+				State:   "mounted",
+				Fstype:  "apfs",
+				Options: []string{"journaled"},
+			},
+			setupMock: func(m *core.MockProvider) {
+				m.SetCommandResult("df -P -k / 2>/dev/null | tail -1", "/dev/disk3s1s1  482797652 10438808 228327652     5%    /\n", "", 0, nil)
+				m.SetCommandResult("mount 2>/dev/null", "/dev/disk3s1s1 on / (apfs, sealed, local, read-only, journaled)\ndevfs on /dev (devfs, local, nobrowse)\n", "", 0, nil)
+			},
+			wantStatus:   core.StatusPass,
+			wantContains: "as apfs",
+		},
+		{
+			name: "minimum size uses df -g",
+			filesystemTest: core.FilesystemTest{
+				Name:      "Data size",
+				Path:      "/data",
+				State:     "mounted",
+				MinSizeGB: 100,
+			},
+			setupMock: func(m *core.MockProvider) {
+				m.SetCommandResult("df -P -k /data 2>/dev/null | tail -1", "zroot/data  52428800 1048576 51380224     2%    /data\n", "", 0, nil)
+				m.SetCommandResult("mount 2>/dev/null", "zroot/data on /data (zfs, local, noatime, nfsv4acls)\n", "", 0, nil)
+				m.SetCommandResult("df -g /data | tail -1 | awk '{print $2}'", "50\n", "", 0, nil)
+			},
+			wantStatus:   core.StatusFail,
+			wantContains: "minimum required is 100GB",
+		},
+		{
+			name: "not mounted",
+			filesystemTest: core.FilesystemTest{
+				Name:  "Backup volume",
+				Path:  "/Volumes/Backup",
+				State: "mounted",
+			},
+			setupMock: func(m *core.MockProvider) {
+				m.SetCommandResult("df -P -k /Volumes/Backup 2>/dev/null | tail -1", "", "", 1, nil)
+			},
+			wantStatus:   core.StatusFail,
+			wantContains: "not mounted",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := core.NewMockProvider()
+			tt.setupMock(mock)
+
+			ctx := core.WithFacts(context.Background(), &core.Facts{OS: core.OSDarwin})
+			result := executeFilesystemTest(ctx, mock, tt.filesystemTest)
+
+			if result.Status != tt.wantStatus {
+				t.Errorf("Status = %v, want %v (message: %s)", result.Status, tt.wantStatus, result.Message)
+			}
+			if tt.wantContains != "" && !contains(result.Message, tt.wantContains) {
+				t.Errorf("Message %q does not contain %q", result.Message, tt.wantContains)
+			}
+		})
+	}
+}
+
+func TestKilobytesToGB(t *testing.T) {
+	tests := []struct {
+		kb   string
+		want string
+	}{
+		{"0", "0G"},
+		{"1992294", "1.9G"}, // Truncating would report 1G
+		{"1048575", "1G"},   // Just under 1G rounds up
+		{"1048576", "1G"},
+		{"1101005", "1.1G"},
+		{"52428800", "50G"},
+		{"482797652", "460.4G"},
+		{"-", "-"},
+	}
+	for _, tt := range tests {
+		if got := kilobytesToGB(tt.kb); got != tt.want {
+			t.Errorf("kilobytesToGB(%q) = %q, want %q", tt.kb, got, tt.want)
+		}
+	}
+}
@@ -3,10 +3,14 @@ package system
 import (
 	"context"
 	"fmt"
+	"net"
+	"net/url"
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/neilfarmer/platform-spec/pkg/core"
+	"github.com/neilfarmer/platform-spec/pkg/jsonpath"
 )
 
 // executeHTTPTest executes an HTTP endpoint test
@@ -37,8 +41,31 @@ func executeHTTPTest(ctx context.Context, provider core.Provider, test core.HTTP
 		cmdParts = append(cmdParts, "-k")
 	}
 
-	// Add write-out format with proper escaping - use $'...' for newline interpretation
-	cmdParts = append(cmdParts, "-w $'\\n%{http_code}'")
+	// Add proxy flags if needed (without a proxy, curl uses the target's proxy environment)
+	if test.Proxy != "" {
+		if proxyBypassed(test.URL, test.NoProxy) {
+			cmdParts = append(cmdParts, "--noproxy '*'")
+		} else {
+			cmdParts = append(cmdParts, fmt.Sprintf("-x %s", core.ShellQuote(test.Proxy)))
+		}
+	}
+
+	// Add headers in a stable order, and the body verbatim (--data-raw does
+	// not treat a leading @ as a file name)
+	names := make([]string, 0, len(test.Headers))
+	for name := range test.Headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		cmdParts = append(cmdParts, fmt.Sprintf("-H %s", core.ShellQuote(name+": "+test.Headers[name])))
+	}
+	if test.Body != "" {
+		cmdParts = append(cmdParts, fmt.Sprintf("--data-raw %s", core.ShellQuote(test.Body)))
+	}
+
+	// Add write-out format; curl expands \n itself, so no shell-specific quoting is needed
+	cmdParts = append(cmdParts, "-w '\\n%{http_code}'")
 
 	// Add URL
 	cmdParts = append(cmdParts, core.ShellQuote(test.URL))
@@ -89,6 +116,12 @@ func executeHTTPTest(ctx context.Context, provider core.Provider, test core.HTTP
 	result.Details["status_code"] = statusCode
 	result.Details["url"] = test.URL
 	result.Details["method"] = test.Method
+	if test.Proxy != "" && !proxyBypassed(test.URL, test.NoProxy) {
+		result.Details["proxy"] = test.Proxy
+	}
+	if len(test.Headers) > 0 {
+		result.Details["request_headers"] = core.RedactHeaders(test.Headers)
+	}
 
 	// Check status code
 	if statusCode != test.StatusCode {
@@ -115,12 +148,71 @@ func executeHTTPTest(ctx context.Context, provider core.Provider, test core.HTTP
 		}
 	}
 
+	// Check JSON paths against the parsed body
+	if len(test.JSONPath) > 0 {
+		doc, err := jsonpath.Decode([]byte(body))
+		if err != nil {
+			result.Status = core.StatusFail
+			result.Message = fmt.Sprintf("Response body is not valid JSON: %v", err)
+			result.Duration = time.Since(start)
+			return result
+		}
+		values := make(map[string]interface{})
+		result.Details["json_path"] = values
+		if status, message := checkJSONPaths(doc, test.JSONPath, "response body", values); status != core.StatusPass {
+			result.Status = status
+			result.Message = message
+			result.Duration = time.Since(start)
+			return result
+		}
+	}
+
 	// All checks passed
 	result.Message = fmt.Sprintf("HTTP %s returned status %d", test.URL, statusCode)
 	if len(test.Contains) > 0 {
 		result.Message += fmt.Sprintf(" with all expected content (%d strings)", len(test.Contains))
 	}
+	if len(test.JSONPath) > 0 {
+		result.Message += fmt.Sprintf(" matching all %d JSON paths", len(test.JSONPath))
+	}
 
 	result.Duration = time.Since(start)
 	return result
 }
+
+// proxyBypassed reports whether the URL's host matches a NO_PROXY style entry.
+// Entries may be "*", a hostname, a domain suffix (".example.com" or "example.com"),
+// an IP address, or a CIDR range.
+func proxyBypassed(rawURL string, noProxy []string) bool {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return false
+	}
+	host := strings.ToLower(u.Hostname())
+	ip := net.ParseIP(host)
+
+	for _, entry := range noProxy {
+		entry = strings.ToLower(strings.TrimSpace(entry))
+		if entry == "" {
+			continue
+		}
+		if entry == "*" {
+			return true
+		}
+		if _, cidr, err := net.ParseCIDR(entry); err == nil {
+			if ip != nil && cidr.Contains(ip) {
+				return true
+			}
+			continue
+		}
+		// Ignore any port on the entry
+		if h, _, err := net.SplitHostPort(entry); err == nil {
+			entry = h
+		}
+		entry = strings.TrimPrefix(entry, ".")
+		if host == entry || strings.HasSuffix(host, "."+entry) {
+			return true
+		}
+	}
+	return false
+}
@@ -1,8 +1,13 @@
 package system
 
 import (
+	"bytes"
 	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/neilfarmer/platform-spec/pkg/core"
 )
@@ -24,7 +29,7 @@ func TestExecutor_HTTPTest(t *testing.T) {
 				Method:     "GET",
 			},
 			setupMock: func(m *core.MockProvider) {
-				m.SetCommandResult("curl -s -w $'\\n%{http_code}' 'http://localhost:8080/health'", "{\"status\":\"ok\"}\n200", "", 0, nil)
+				m.SetCommandResult("curl -s -w '\\n%{http_code}' 'http://localhost:8080/health'", "{\"status\":\"ok\"}\n200", "", 0, nil)
 			},
 			wantStatus:   core.StatusPass,
 			wantContains: "returned status 200",
@@ -38,7 +43,7 @@ func TestExecutor_HTTPTest(t *testing.T) {
 				Method:     "POST",
 			},
 			setupMock: func(m *core.MockProvider) {
-				m.SetCommandResult("curl -s -X POST -w $'\\n%{http_code}' 'https://api.example.com/webhook'", "{\"accepted\":true}\n202", "", 0, nil)
+				m.SetCommandResult("curl -s -X POST -w '\\n%{http_code}' 'https://api.example.com/webhook'", "{\"accepted\":true}\n202", "", 0, nil)
 			},
 			wantStatus:   core.StatusPass,
 			wantContains: "returned status 202",
@@ -53,7 +58,7 @@ func TestExecutor_HTTPTest(t *testing.T) {
 				Insecure:   true,
 			},
 			setupMock: func(m *core.MockProvider) {
-				m.SetCommandResult("curl -s -k -w $'\\n%{http_code}' 'https://internal.local/api'", "{\"data\":\"test\"}\n200", "", 0, nil)
+				m.SetCommandResult("curl -s -k -w '\\n%{http_code}' 'https://internal.local/api'", "{\"data\":\"test\"}\n200", "", 0, nil)
 			},
 			wantStatus:   core.StatusPass,
 			wantContains: "returned status 200",
@@ -68,7 +73,7 @@ func TestExecutor_HTTPTest(t *testing.T) {
 				Contains:   []string{"healthy", "version"},
 			},
 			setupMock: func(m *core.MockProvider) {
-				m.SetCommandResult("curl -s -w $'\\n%{http_code}' 'http://localhost:3000/status'", "{\"status\":\"healthy\",\"version\":\"1.2.3\"}\n200", "", 0, nil)
+				m.SetCommandResult("curl -s -w '\\n%{http_code}' 'http://localhost:3000/status'", "{\"status\":\"healthy\",\"version\":\"1.2.3\"}\n200", "", 0, nil)
 			},
 			wantStatus:   core.StatusPass,
 			wantContains: "with all expected content (2 strings)",
@@ -82,7 +87,7 @@ func TestExecutor_HTTPTest(t *testing.T) {
 				Method:     "GET",
 			},
 			setupMock: func(m *core.MockProvider) {
-				m.SetCommandResult("curl -s -w $'\\n%{http_code}' 'http://localhost:8080/missing'", "Not Found\n404", "", 0, nil)
+				m.SetCommandResult("curl -s -w '\\n%{http_code}' 'http://localhost:8080/missing'", "Not Found\n404", "", 0, nil)
 			},
 			wantStatus:   core.StatusFail,
 			wantContains: "Status code is 404, expected 200",
@@ -97,7 +102,7 @@ func TestExecutor_HTTPTest(t *testing.T) {
 				Contains:   []string{"expected_field"},
 			},
 			setupMock: func(m *core.MockProvider) {
-				m.SetCommandResult("curl -s -w $'\\n%{http_code}' 'http://localhost:8080/api'", "{\"other\":\"data\"}\n200", "", 0, nil)
+				m.SetCommandResult("curl -s -w '\\n%{http_code}' 'http://localhost:8080/api'", "{\"other\":\"data\"}\n200", "", 0, nil)
 			},
 			wantStatus:   core.StatusFail,
 			wantContains: "Response body missing expected strings: 'expected_field'",
@@ -111,7 +116,7 @@ func TestExecutor_HTTPTest(t *testing.T) {
 				Method:     "GET",
 			},
 			setupMock: func(m *core.MockProvider) {
-				m.SetCommandResult("curl -s -w $'\\n%{http_code}' 'http://localhost:9999'", "", "curl: (7) Failed to connect", 7, nil)
+				m.SetCommandResult("curl -s -w '\\n%{http_code}' 'http://localhost:9999'", "", "curl: (7) Failed to connect", 7, nil)
 			},
 			wantStatus:   core.StatusFail,
 			wantContains: "HTTP request failed",
@@ -125,7 +130,7 @@ func TestExecutor_HTTPTest(t *testing.T) {
 				Method:     "GET",
 			},
 			setupMock: func(m *core.MockProvider) {
-				m.SetCommandResult("curl -s -w $'\\n%{http_code}' 'https://example.com/old'", "<html>Moved</html>\n302", "", 0, nil)
+				m.SetCommandResult("curl -s -w '\\n%{http_code}' 'https://example.com/old'", "<html>Moved</html>\n302", "", 0, nil)
 			},
 			wantStatus:   core.StatusFail,
 			wantContains: "Status code is 302, expected 200",
@@ -140,7 +145,7 @@ func TestExecutor_HTTPTest(t *testing.T) {
 				FollowRedirects: true,
 			},
 			setupMock: func(m *core.MockProvider) {
-				m.SetCommandResult("curl -s -L -w $'\\n%{http_code}' 'https://example.com/redirect'", "<html>Final page</html>\n200", "", 0, nil)
+				m.SetCommandResult("curl -s -L -w '\\n%{http_code}' 'https://example.com/redirect'", "<html>Final page</html>\n200", "", 0, nil)
 			},
 			wantStatus:   core.StatusPass,
 			wantContains: "returned status 200",
@@ -156,7 +161,7 @@ func TestExecutor_HTTPTest(t *testing.T) {
 				Insecure:        true,
 			},
 			setupMock: func(m *core.MockProvider) {
-				m.SetCommandResult("curl -s -L -k -w $'\\n%{http_code}' 'https://internal.local/old'", "<html>Redirected page</html>\n200", "", 0, nil)
+				m.SetCommandResult("curl -s -L -k -w '\\n%{http_code}' 'https://internal.local/old'", "<html>Redirected page</html>\n200", "", 0, nil)
 			},
 			wantStatus:   core.StatusPass,
 			wantContains: "returned status 200",
@@ -171,11 +176,118 @@ func TestExecutor_HTTPTest(t *testing.T) {
 				FollowRedirects: true,
 			},
 			setupMock: func(m *core.MockProvider) {
-				m.SetCommandResult("curl -s -X POST -L -w $'\\n%{http_code}' 'https://api.example.com/create'", "{\"id\":123}\n201", "", 0, nil)
+				m.SetCommandResult("curl -s -X POST -L -w '\\n%{http_code}' 'https://api.example.com/create'", "{\"id\":123}\n201", "", 0, nil)
 			},
 			wantStatus:   core.StatusPass,
 			wantContains: "returned status 201",
 		},
+		{
+			name: "request through explicit proxy",
+			httpTest: core.HTTPTest{
+				Name:       "Proxied request",
+				URL:        "https://api.example.com/health",
+				StatusCode: 200,
+				Method:     "GET",
+				Proxy:      "http://proxy.corp:3128",
+			},
+			setupMock: func(m *core.MockProvider) {
+				m.SetCommandResult("curl -s -x 'http://proxy.corp:3128' -w '\\n%{http_code}' 'https://api.example.com/health'", "ok\n200", "", 0, nil)
+			},
+			wantStatus:   core.StatusPass,
+			wantContains: "returned status 200",
+		},
+		{
+			name: "no_proxy entry bypasses proxy",
+			httpTest: core.HTTPTest{
+				Name:       "Internal host bypasses proxy",
+				URL:        "http://app.internal.corp:8080/health",
+				StatusCode: 200,
+				Method:     "GET",
+				Proxy:      "http://proxy.corp:3128",
+				NoProxy:    []string{"localhost", ".internal.corp"},
+			},
+			setupMock: func(m *core.MockProvider) {
+				m.SetCommandResult("curl -s --noproxy '*' -w '\\n%{http_code}' 'http://app.internal.corp:8080/health'", "ok\n200", "", 0, nil)
+			},
+			wantStatus:   core.StatusPass,
+			wantContains: "returned status 200",
+		},
+		{
+			name: "authenticated POST with headers and body",
+			httpTest: core.HTTPTest{
+				Name:       "Authenticated health",
+				URL:        "https://api.example.com/health",
+				StatusCode: 200,
+				Method:     "POST",
+				Headers:    map[string]string{"Content-Type": "application/json", "Authorization": "Bearer s3cr3t"},
+				Body:       `{"probe":"it's me"}`,
+			},
+			setupMock: func(m *core.MockProvider) {
+				m.SetCommandResult(`curl -s -X POST -H 'Authorization: Bearer s3cr3t' -H 'Content-Type: application/json' --data-raw '{"probe":"it'\''s me"}' -w '\n%{http_code}' 'https://api.example.com/health'`, "ok\n200", "", 0, nil)
+			},
+			wantStatus:   core.StatusPass,
+			wantContains: "returned status 200",
+		},
+		{
+			name: "JSON paths match",
+			httpTest: core.HTTPTest{
+				Name:       "Health details",
+				URL:        "http://localhost:8080/health",
+				StatusCode: 200,
+				Method:     "GET",
+				JSONPath:   map[string]string{"$.status": "ok", "$.checks[*].name": "db", "$.version": "3"},
+			},
+			setupMock: func(m *core.MockProvider) {
+				m.SetCommandResult("curl -s -w '\\n%{http_code}' 'http://localhost:8080/health'", `{"status":"ok","version":3,"checks":[{"name":"cache"},{"name":"db"}]}`+"\n200", "", 0, nil)
+			},
+			wantStatus:   core.StatusPass,
+			wantContains: "matching all 3 JSON paths",
+		},
+		{
+			name: "JSON path value differs",
+			httpTest: core.HTTPTest{
+				Name:       "Health details",
+				URL:        "http://localhost:8080/health",
+				StatusCode: 200,
+				Method:     "GET",
+				JSONPath:   map[string]string{"$.status": "ok"},
+			},
+			setupMock: func(m *core.MockProvider) {
+				m.SetCommandResult("curl -s -w '\\n%{http_code}' 'http://localhost:8080/health'", `{"status":"degraded"}`+"\n200", "", 0, nil)
+			},
+			wantStatus:   core.StatusFail,
+			wantContains: "Path $.status is degraded, expected ok",
+		},
+		{
+			name: "JSON path missing",
+			httpTest: core.HTTPTest{
+				Name:       "Health details",
+				URL:        "http://localhost:8080/health",
+				StatusCode: 200,
+				Method:     "GET",
+				JSONPath:   map[string]string{"$.checks[0].name": "db"},
+			},
+			setupMock: func(m *core.MockProvider) {
+				m.SetCommandResult("curl -s -w '\\n%{http_code}' 'http://localhost:8080/health'", `{"status":"ok"}`+"\n200", "", 0, nil)
+			},
+			wantStatus:   core.StatusFail,
+			wantContains: "Path $.checks[0].name not found in response body",
+		},
+		{
+			name: "JSON paths on a non-JSON body",
+			httpTest: core.HTTPTest{
+				Name:       "Health details",
+				URL:        "http://localhost:8080/health",
+				StatusCode: 200,
+				Method:     "GET",
+				JSONPath:   map[string]string{"$.status": "ok"},
+			},
+			setupMock: func(m *core.MockProvider) {
+				m.SetCommandResult("curl -s -w '\\n%{http_code}' 'http://localhost:8080/health'", "<html>OK</html>\n200", "", 0, nil)
+			},
+			wantStatus:   core.StatusFail,
+			wantContains: "Response body is not valid JSON",
+		},
 	}
 
 	for _, tt := range tests {
@@ -199,3 +311,199 @@ func TestExecutor_HTTPTest(t *testing.T) {
 		})
 	}
 }
+
+func TestProxyBypassed(t *testing.T) {
+	tests := []struct {
+		name    string
+		url     string
+		noProxy []string
+		want    bool
+	}{
+		{"empty list", "http://example.com", nil, false},
+		{"wildcard", "http://example.com", []string{"*"}, true},
+		{"exact host", "http://example.com/path", []string{"example.com"}, true},
+		{"domain suffix with dot", "http://api.example.com", []string{".example.com"}, true},
+		{"domain suffix without dot", "http://api.example.com", []string{"example.com"}, true},
+		{"partial name does not match", "http://notexample.com", []string{"example.com"}, false},
+		{"entry with port", "http://localhost:8080", []string{"localhost:8080"}, true},
+		{"cidr match", "http://10.1.2.3:9000", []string{"10.0.0.0/8"}, true},
+		{"cidr miss", "http://192.168.1.1", []string{"10.0.0.0/8"}, false},
+		{"case insensitive", "http://API.Example.COM", []string{"example.com"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := proxyBypassed(tt.url, tt.noProxy); got != tt.want {
+				t.Errorf("proxyBypassed(%q, %v) = %v, want %v", tt.url, tt.noProxy, got, tt.want)
+			}
+		})
+	}
+}
+
+// flakyProvider returns queued responses for one command before falling back
+// to the mock, simulating an endpoint that changes between checks
+type flakyProvider struct {
+	*core.MockProvider
+	command   string
+	responses []flakyResponse
+	calls     int
+}
+
+type flakyResponse struct {
+	stdout, stderr string
+	exitCode       int
+}
+
+func (f *flakyProvider) ExecuteCommand(ctx context.Context, command string) (string, string, int, error) {
+	if command != f.command {
+		return f.MockProvider.ExecuteCommand(ctx, command)
+	}
+	f.calls++
+	r := f.responses[min(f.calls, len(f.responses))-1]
+	return r.stdout, r.stderr, r.exitCode, nil
+}
+
+func TestSystemPlugin_HTTPRetry(t *testing.T) {
+	const curl = "curl -s -w '\\n%{http_code}' 'http://localhost:8080/health'"
+	refused := flakyResponse{stderr: "curl: (7) Failed to connect to localhost port 8080: Connection refused", exitCode: 7}
+	healthy := flakyResponse{stdout: "ok\n200"}
+	notFound := flakyResponse{stdout: "not found\n404"}
+	retry := &core.TestRetry{Attempts: 3, Interval: time.Millisecond}
+
+	tests := []struct {
+		name         string
+		retry        *core.TestRetry
+		responses    []flakyResponse
+		wantStatus   core.Status
+		wantCalls    int
+		wantAttempts int
+	}{
+		{
+			name:         "passes on second attempt",
+			retry:        retry,
+			responses:    []flakyResponse{refused, healthy},
+			wantStatus:   core.StatusPass,
+			wantCalls:    2,
+			wantAttempts: 2,
+		},
+		{
+			name:         "transient failure exhausts attempts",
+			retry:        retry,
+			responses:    []flakyResponse{refused},
+			wantStatus:   core.StatusFail,
+			wantCalls:    3,
+			wantAttempts: 3,
+		},
+		{
+			name:         "assertion mismatch is not retried",
+			retry:        retry,
+			responses:    []flakyResponse{notFound, healthy},
+			wantStatus:   core.StatusFail,
+			wantCalls:    1,
+			wantAttempts: 1,
+		},
+		{
+			name:       "no retry without options",
+			responses:  []flakyResponse{refused, healthy},
+			wantStatus: core.StatusFail,
+			wantCalls:  1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider := &flakyProvider{MockProvider: core.NewMockProvider(), command: curl, responses: tt.responses}
+			test := core.HTTPTest{Name: "API health", URL: "http://localhost:8080/health", StatusCode: 200, Method: "GET"}
+			test.Retry = tt.retry
+			spec := &core.Spec{Tests: core.Tests{HTTP: []core.HTTPTest{test}}}
+
+			results, _ := NewSystemPlugin().Execute(context.Background(), spec, provider, false)
+			if len(results) != 1 {
+				t.Fatalf("got %d results, want 1", len(results))
+			}
+			if results[0].Status != tt.wantStatus {
+				t.Errorf("status = %v, want %v (%s)", results[0].Status, tt.wantStatus, results[0].Message)
+			}
+			if provider.calls != tt.wantCalls {
+				t.Errorf("endpoint checked %d times, want %d", provider.calls, tt.wantCalls)
+			}
+			if got := results[0].Attempts; got != tt.wantAttempts {
+				t.Errorf("attempts = %d, want %d", got, tt.wantAttempts)
+			}
+		})
+	}
+}
+
+func TestExecutor_HTTPTestRedactsHeaders(t *testing.T) {
+	mock := core.NewMockProvider()
+	mock.SetCommandResult("curl -s -H 'Accept: application/json' -H 'Cookie: session=s3cr3t' -w '\\n%{http_code}' 'http://localhost/api'", "ok\n200", "", 0, nil)
+	test := core.HTTPTest{
+		Name:       "cookie auth",
+		URL:        "http://localhost/api",
+		StatusCode: 200,
+		Method:     "GET",
+		Headers:    map[string]string{"Cookie": "session=s3cr3t", "Accept": "application/json"},
+	}
+
+	result := executeHTTPTest(context.Background(), mock, test)
+	if result.Status != core.StatusPass {
+		t.Fatalf("Status = %v (%s), want pass", result.Status, result.Message)
+	}
+	headers, _ := result.Details["request_headers"].(map[string]string)
+	if headers["Cookie"] != core.Redacted || headers["Accept"] != "application/json" {
+		t.Errorf("Details[request_headers] = %v, want only the cookie redacted", headers)
+	}
+}
+
+// shellProvider runs commands through core.ShellCommand with a real shell,
+// with a fake curl first on the PATH
+type shellProvider struct {
+	path string
+}
+
+func (p shellProvider) ExecuteCommand(ctx context.Context, command string) (string, string, int, error) {
+	cmd := exec.CommandContext(ctx, "/bin/sh", "-c", core.ShellCommand(ctx, command))
+	cmd.Env = append(os.Environ(), "PATH="+p.path)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+	err := cmd.Run()
+	if exitErr, ok := err.(*exec.ExitError); ok {
+		return stdout.String(), stderr.String(), exitErr.ExitCode(), nil
+	}
+	return stdout.String(), stderr.String(), 0, err
+}
+
+// fakeCurl fails unless the -w format arrives exactly as curl documents it,
+// then prints a JSON body followed by the format with 200 for %{http_code}
+const fakeCurl = `#!/bin/sh
+while [ $# -gt 0 ]; do
+	if [ "$1" = "-w" ]; then format=$2; fi
+	shift
+done
+if [ "$format" != '\n%{http_code}' ]; then
+	echo "curl: unexpected write-out format: $format" >&2
+	exit 2
+fi
+printf '{"status":"ok"}'
+printf '%b' "$format" | sed 's/%{http_code}/200/'
+`
+
+func TestExecutor_HTTPTestDefaultShell(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "curl"), []byte(fakeCurl), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	provider := shellProvider{path: dir + string(os.PathListSeparator) + os.Getenv("PATH")}
+	test := core.HTTPTest{
+		Name:       "API health",
+		URL:        "http://localhost:8080/health",
+		StatusCode: 200,
+		Method:     "GET",
+		JSONPath:   map[string]string{"$.status": "ok"},
+	}
+
+	result := executeHTTPTest(context.Background(), provider, test)
+	if result.Status != core.StatusPass {
+		t.Errorf("Status = %v (%s), want pass under %s", result.Status, result.Message, core.DefaultShell)
+	}
+}
@@ -0,0 +1,132 @@
+package system
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/neilfarmer/platform-spec/pkg/core"
+)
+
+// logrotateConfigCommand prints the main logrotate config and its drop-in files
+const logrotateConfigCommand = "cat /etc/logrotate.conf /etc/logrotate.d/* 2>/dev/null"
+
+// executeLogRotateTest executes a log file size and rotation test
+func executeLogRotateTest(ctx context.Context, provider core.Provider, test core.LogRotateTest) core.Result {
+	start := time.Now()
+	result := core.Result{
+		Name:    test.Name,
+		Status:  core.StatusPass,
+		Details: make(map[string]interface{}),
+	}
+
+	var checks []string
+
+	if test.MaxSizeBytes != "" {
+		maxBytes, err := core.ParseSize(test.MaxSizeBytes)
+		if err != nil {
+			result.Status = core.StatusError
+			result.Message = fmt.Sprintf("Invalid max_size: %v", err)
+			result.Duration = time.Since(start)
+			return result
+		}
+
+		statFormat := "-c %s"
+		if core.IsBSD(core.TargetOS(ctx, provider)) {
+			statFormat = "-f %z"
+		}
+		cmd := fmt.Sprintf("stat %s %s 2>/dev/null || echo 'notfound'", statFormat, core.ShellQuote(test.Path))
+		stdout, _, _, err := provider.ExecuteCommand(ctx, cmd)
+		if err != nil {
+			result.Status = core.StatusError
+			result.Message = fmt.Sprintf("Error checking size of %s: %v", test.Path, err)
+			result.Duration = time.Since(start)
+			return result
+		}
+
+		output := strings.TrimSpace(stdout)
+		if output == "notfound" {
+			result.Status = core.StatusFail
+			result.Message = fmt.Sprintf("Log file %s does not exist", test.Path)
+			result.Duration = time.Since(start)
+			return result
+		}
+		size, err := strconv.ParseInt(output, 10, 64)
+		if err != nil {
+			result.Status = core.StatusError
+			result.Message = fmt.Sprintf("Failed to parse size of %s: %q", test.Path, output)
+			result.Duration = time.Since(start)
+			return result
+		}
+		result.Details["size_bytes"] = size
+
+		if size > maxBytes {
+			result.Status = core.StatusFail
+			result.Message = fmt.Sprintf("Log file %s is %d bytes, expected at most %s (%d bytes)", test.Path, size, test.MaxSizeBytes, maxBytes)
+			result.Duration = time.Since(start)
+			return result
+		}
+		checks = append(checks, fmt.Sprintf("is %d bytes", size))
+	}
+
+	if test.ConfigPresent {
+		stdout, _, _, err := provider.ExecuteCommand(ctx, logrotateConfigCommand)
+		if err != nil {
+			result.Status = core.StatusError
+			result.Message = fmt.Sprintf("Error reading logrotate config: %v", err)
+			result.Duration = time.Since(start)
+			return result
+		}
+
+		pattern, ok := findLogrotatePattern(stdout, test.Path)
+		if !ok {
+			result.Status = core.StatusFail
+			result.Message = fmt.Sprintf("No logrotate config covers %s", test.Path)
+			result.Duration = time.Since(start)
+			return result
+		}
+		result.Details["logrotate_pattern"] = pattern
+		checks = append(checks, fmt.Sprintf("is rotated by logrotate (%s)", pattern))
+	}
+
+	result.Message = fmt.Sprintf("Log file %s %s", test.Path, strings.Join(checks, " and "))
+	result.Duration = time.Since(start)
+	return result
+}
+
+// findLogrotatePattern returns the logrotate log pattern that covers file.
+// Patterns are the paths or globs listed before a block's opening brace, e.g.
+// "/var/log/nginx/*.log {" or "/var/log/app.log /var/log/app-error.log {".
+func findLogrotatePattern(config, file string) (string, bool) {
+	var pending []string
+	for _, line := range strings.Split(config, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		head, isBlock := strings.CutSuffix(line, "{")
+		fields := strings.Fields(head)
+		if !isBlock {
+			// Patterns may be listed on lines of their own before the brace
+			if len(fields) > 0 && strings.HasPrefix(strings.Trim(fields[0], `"'`), "/") {
+				pending = append(pending, fields...)
+			} else {
+				pending = nil
+			}
+			continue
+		}
+
+		for _, p := range append(pending, fields...) {
+			p = strings.Trim(p, `"'`)
+			if matched, _ := path.Match(p, file); matched {
+				return p, true
+			}
+		}
+		pending = nil
+	}
+	return "", false
+}
@@ -0,0 +1,135 @@
+package system
+
+import (
+	"context"
+	"testing"
+
+	"github.com/neilfarmer/platform-spec/pkg/core"
+)
+
+func TestExecutor_LogRotateTest(t *testing.T) {
+	const logrotateConfig = `# see "man logrotate" for details
+weekly
+rotate 4
+include /etc/logrotate.d
+
+/var/log/nginx/*.log {
+	daily
+	missingok
+	postrotate
+		/usr/sbin/invoke-rc.d nginx rotate >/dev/null 2>&1
+	endscript
+}
+
+/var/log/app/app.log
+/var/log/app/error.log {
+	size 100M
+	rotate 5
+}
+`
+
+	tests := []struct {
+		name         string
+		logTest      core.LogRotateTest
+		setupMock    func(*core.MockProvider)
+		wantStatus   core.Status
+		wantContains string
+	}{
+		{
+			name: "log file under size limit",
+			logTest: core.LogRotateTest{
+				Name:         "Syslog size",
+				Path:         "/var/log/syslog",
+				MaxSizeBytes: "500M",
+			},
+			setupMock: func(m *core.MockProvider) {
+				m.SetCommandResult("stat -c %s '/var/log/syslog' 2>/dev/null || echo 'notfound'", "1048576\n", "", 0, nil)
+			},
+			wantStatus:   core.StatusPass,
+			wantContains: "is 1048576 bytes",
+		},
+		{
+			name: "log file over size limit",
+			logTest: core.LogRotateTest{
+				Name:         "Syslog size",
+				Path:         "/var/log/syslog",
+				MaxSizeBytes: "1Gi",
+			},
+			setupMock: func(m *core.MockProvider) {
+				m.SetCommandResult("stat -c %s '/var/log/syslog' 2>/dev/null || echo 'notfound'", "5368709120\n", "", 0, nil)
+			},
+			wantStatus:   core.StatusFail,
+			wantContains: "is 5368709120 bytes, expected at most 1Gi (1073741824 bytes)",
+		},
+		{
+			name: "log file missing",
+			logTest: core.LogRotateTest{
+				Name:         "App log size",
+				Path:         "/var/log/app/app.log",
+				MaxSizeBytes: "100M",
+			},
+			setupMock: func(m *core.MockProvider) {
+				m.SetCommandResult("stat -c %s '/var/log/app/app.log' 2>/dev/null || echo 'notfound'", "notfound\n", "", 0, nil)
+			},
+			wantStatus:   core.StatusFail,
+			wantContains: "does not exist",
+		},
+		{
+			name: "logrotate config covers glob",
+			logTest: core.LogRotateTest{
+				Name:          "nginx logs rotated",
+				Path:          "/var/log/nginx/access.log",
+				ConfigPresent: true,
+			},
+			setupMock: func(m *core.MockProvider) {
+				m.SetCommandResult(logrotateConfigCommand, logrotateConfig, "", 0, nil)
+			},
+			wantStatus:   core.StatusPass,
+			wantContains: "is rotated by logrotate (/var/log/nginx/*.log)",
+		},
+		{
+			name: "logrotate config lists file on its own line",
+			logTest: core.LogRotateTest{
+				Name:          "App log rotated",
+				Path:          "/var/log/app/app.log",
+				MaxSizeBytes:  "100M",
+				ConfigPresent: true,
+			},
+			setupMock: func(m *core.MockProvider) {
+				m.SetCommandResult("stat -c %s '/var/log/app/app.log' 2>/dev/null || echo 'notfound'", "2048\n", "", 0, nil)
+				m.SetCommandResult(logrotateConfigCommand, logrotateConfig, "", 0, nil)
+			},
+			wantStatus:   core.StatusPass,
+			wantContains: "is 2048 bytes and is rotated by logrotate",
+		},
+		{
+			name: "no logrotate config",
+			logTest: core.LogRotateTest{
+				Name:          "Worker log rotated",
+				Path:          "/var/log/worker.log",
+				ConfigPresent: true,
+			},
+			setupMock: func(m *core.MockProvider) {
+				m.SetCommandResult(logrotateConfigCommand, logrotateConfig, "", 0, nil)
+			},
+			wantStatus:   core.StatusFail,
+			wantContains: "No logrotate config covers /var/log/worker.log",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := core.NewMockProvider()
+			tt.setupMock(mock)
+
+			result := executeLogRotateTest(context.Background(), mock, tt.logTest)
+
+			if result.Status != tt.wantStatus {
+				t.Errorf("Status = %v, want %v (message: %s)", result.Status, tt.wantStatus, result.Message)
+			}
+			if tt.wantContains != "" && !contains(result.Message, tt.wantContains) {
+				t.Errorf("Message %q does not contain %q", result.Message, tt.wantContains)
+			}
+		})
+	}
+}
@@ -0,0 +1,174 @@
+package system
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/neilfarmer/platform-spec/pkg/core"
+)
+
+// kcatMetadata is the subset of `kcat -L -J` output used by Kafka tests
+type kcatMetadata struct {
+	Brokers []struct {
+		ID   int    `json:"id"`
+		Name string `json:"name"`
+	} `json:"brokers"`
+	Topics []struct {
+		Topic      string            `json:"topic"`
+		Error      string            `json:"error"`
+		Partitions []json.RawMessage `json:"partitions"`
+	} `json:"topics"`
+}
+
+// rabbitmqQueue is the subset of a RabbitMQ management API queue used by RabbitMQ tests
+type rabbitmqQueue struct {
+	Name      string `json:"name"`
+	Consumers int    `json:"consumers"`
+	Messages  int    `json:"messages"`
+}
+
+// executeMessageQueueTest executes a Kafka or RabbitMQ reachability test
+func executeMessageQueueTest(ctx context.Context, provider core.Provider, test core.MessageQueueTest) core.Result {
+	if test.Backend == "kafka" {
+		return executeKafkaTest(ctx, provider, test)
+	}
+	return executeRabbitMQTest(ctx, provider, test)
+}
+
+// executeKafkaTest checks broker reachability and topic metadata with kcat
+func executeKafkaTest(ctx context.Context, provider core.Provider, test core.MessageQueueTest) core.Result {
+	start := time.Now()
+	result := core.Result{
+		Name:    test.Name,
+		Status:  core.StatusPass,
+		Details: make(map[string]interface{}),
+	}
+
+	cmd := fmt.Sprintf("kcat -b %s -L -J -t %s -m 10", core.ShellQuote(test.Address), core.ShellQuote(test.Topic))
+	stdout, stderr, exitCode, err := provider.ExecuteCommand(ctx, cmd)
+	if err != nil {
+		result.Status = core.StatusError
+		result.Message = fmt.Sprintf("Error querying Kafka metadata: %v", err)
+		result.Duration = time.Since(start)
+		return result
+	}
+	if exitCode == 127 {
+		result.Status = core.StatusError
+		result.Message = "kcat is not installed on the target"
+		result.Duration = time.Since(start)
+		return result
+	}
+	if exitCode != 0 {
+		result.Status = core.StatusFail
+		result.Message = fmt.Sprintf("Kafka broker %s is not reachable: %s", test.Address, strings.TrimSpace(stderr))
+		result.Duration = time.Since(start)
+		return result
+	}
+
+	var metadata kcatMetadata
+	if err := json.Unmarshal([]byte(stdout), &metadata); err != nil {
+		result.Status = core.StatusError
+		result.Message = fmt.Sprintf("Failed to parse Kafka metadata: %v", err)
+		result.Duration = time.Since(start)
+		return result
+	}
+	result.Details["brokers"] = len(metadata.Brokers)
+
+	partitions := -1
+	for _, topic := range metadata.Topics {
+		if topic.Topic == test.Topic && topic.Error == "" {
+			partitions = len(topic.Partitions)
+		}
+	}
+	if partitions < 0 {
+		result.Status = core.StatusFail
+		result.Message = fmt.Sprintf("Kafka topic %s not found", test.Topic)
+		result.Duration = time.Since(start)
+		return result
+	}
+	result.Details["partitions"] = partitions
+
+	// Check partition count
+	if partitions < test.MinPartitions {
+		result.Status = core.StatusFail
+		result.Message = fmt.Sprintf("Kafka topic %s has %d partitions, expected at least %d", test.Topic, partitions, test.MinPartitions)
+		result.Duration = time.Since(start)
+		return result
+	}
+
+	result.Message = fmt.Sprintf("Kafka topic %s has %d partitions across %d brokers", test.Topic, partitions, len(metadata.Brokers))
+	result.Duration = time.Since(start)
+	return result
+}
+
+// executeRabbitMQTest checks a queue's existence and consumers via the management API
+func executeRabbitMQTest(ctx context.Context, provider core.Provider, test core.MessageQueueTest) core.Result {
+	start := time.Now()
+	result := core.Result{
+		Name:    test.Name,
+		Status:  core.StatusPass,
+		Details: make(map[string]interface{}),
+	}
+
+	resp, err := runCurl(ctx, provider, curlRequest{
+		URL:  fmt.Sprintf("%s/api/queues/%s/%s", strings.TrimRight(test.Address, "/"), url.PathEscape(test.VHost), url.PathEscape(test.Topic)),
+		User: test.Username + ":" + test.Password,
+	})
+	if err != nil {
+		result.Status = core.StatusError
+		result.Message = fmt.Sprintf("Error querying RabbitMQ: %v", err)
+		result.Duration = time.Since(start)
+		return result
+	}
+	if resp.ExitCode != 0 {
+		result.Status = core.StatusFail
+		result.Message = fmt.Sprintf("RabbitMQ management API at %s is not reachable: %s", test.Address, resp.Stderr)
+		result.Duration = time.Since(start)
+		return result
+	}
+
+	switch resp.StatusCode {
+	case 200:
+	case 404:
+		result.Status = core.StatusFail
+		result.Message = fmt.Sprintf("RabbitMQ queue %s not found in vhost %s", test.Topic, test.VHost)
+		result.Duration = time.Since(start)
+		return result
+	case 401:
+		result.Status = core.StatusError
+		result.Message = fmt.Sprintf("RabbitMQ management API rejected credentials for user %s", test.Username)
+		result.Duration = time.Since(start)
+		return result
+	default:
+		result.Status = core.StatusFail
+		result.Message = fmt.Sprintf("RabbitMQ management API returned status %d", resp.StatusCode)
+		result.Duration = time.Since(start)
+		return result
+	}
+
+	var queue rabbitmqQueue
+	if err := json.Unmarshal([]byte(resp.Body), &queue); err != nil {
+		result.Status = core.StatusError
+		result.Message = fmt.Sprintf("Failed to parse RabbitMQ queue: %v", err)
+		result.Duration = time.Since(start)
+		return result
+	}
+	result.Details["consumers"] = queue.Consumers
+	result.Details["messages"] = queue.Messages
+
+	// Check consumer count
+	if queue.Consumers < test.MinConsumers {
+		result.Status = core.StatusFail
+		result.Message = fmt.Sprintf("RabbitMQ queue %s has %d consumers, expected at least %d", test.Topic, queue.Consumers, test.MinConsumers)
+		result.Duration = time.Since(start)
+		return result
+	}
+
+	result.Message = fmt.Sprintf("RabbitMQ queue %s exists with %d consumers", test.Topic, queue.Consumers)
+	result.Duration = time.Since(start)
+	return result
+}
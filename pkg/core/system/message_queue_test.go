@@ -0,0 +1,170 @@
+package system
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os/exec"
+	"testing"
+
+	"github.com/neilfarmer/platform-spec/pkg/core"
+	"github.com/neilfarmer/platform-spec/pkg/providers/local"
+)
+
+// kcatOrdersMetadata is `kcat -L -J` output for a two-broker cluster with a three-partition topic
+const kcatOrdersMetadata = `{"originating_broker":{"id":1,"name":"kafka-1:9092/1"},"query":{"topic":"orders"},` +
+	`"brokers":[{"id":1,"name":"kafka-1:9092"},{"id":2,"name":"kafka-2:9092"}],` +
+	`"topics":[{"topic":"orders","partitions":[` +
+	`{"partition":0,"leader":1,"replicas":[{"id":1},{"id":2}],"isrs":[{"id":1},{"id":2}]},` +
+	`{"partition":1,"leader":2,"replicas":[{"id":2},{"id":1}],"isrs":[{"id":2},{"id":1}]},` +
+	`{"partition":2,"leader":1,"replicas":[{"id":1},{"id":2}],"isrs":[{"id":1},{"id":2}]}]}]}`
+
+func TestExecutor_MessageQueueTest_Kafka(t *testing.T) {
+	kcatCmd := "kcat -b 'kafka-1:9092' -L -J -t 'orders' -m 10"
+
+	tests := []struct {
+		name         string
+		queueTest    core.MessageQueueTest
+		setupMock    func(*core.MockProvider)
+		wantStatus   core.Status
+		wantContains string
+	}{
+		{
+			name:      "topic with enough partitions",
+			queueTest: core.MessageQueueTest{Name: "orders", Backend: "kafka", Address: "kafka-1:9092", Topic: "orders", MinPartitions: 3},
+			setupMock: func(m *core.MockProvider) {
+				m.SetCommandResult(kcatCmd, kcatOrdersMetadata, "", 0, nil)
+			},
+			wantStatus:   core.StatusPass,
+			wantContains: "has 3 partitions across 2 brokers",
+		},
+		{
+			name:      "too few partitions",
+			queueTest: core.MessageQueueTest{Name: "orders", Backend: "kafka", Address: "kafka-1:9092", Topic: "orders", MinPartitions: 6},
+			setupMock: func(m *core.MockProvider) {
+				m.SetCommandResult(kcatCmd, kcatOrdersMetadata, "", 0, nil)
+			},
+			wantStatus:   core.StatusFail,
+			wantContains: "has 3 partitions, expected at least 6",
+		},
+		{
+			name:      "unknown topic",
+			queueTest: core.MessageQueueTest{Name: "orders", Backend: "kafka", Address: "kafka-1:9092", Topic: "orders"},
+			setupMock: func(m *core.MockProvider) {
+				m.SetCommandResult(kcatCmd, `{"brokers":[{"id":1,"name":"kafka-1:9092"}],"topics":[{"topic":"orders","error":"Broker: Unknown topic or partition","partitions":[]}]}`, "", 0, nil)
+			},
+			wantStatus:   core.StatusFail,
+			wantContains: "Kafka topic orders not found",
+		},
+		{
+			name:      "broker unreachable",
+			queueTest: core.MessageQueueTest{Name: "orders", Backend: "kafka", Address: "kafka-1:9092", Topic: "orders"},
+			setupMock: func(m *core.MockProvider) {
+				m.SetCommandResult(kcatCmd, "", "% ERROR: Failed to acquire metadata: Local: Broker transport failure", 1, nil)
+			},
+			wantStatus:   core.StatusFail,
+			wantContains: "is not reachable",
+		},
+		{
+			name:      "kcat missing",
+			queueTest: core.MessageQueueTest{Name: "orders", Backend: "kafka", Address: "kafka-1:9092", Topic: "orders"},
+			setupMock: func(m *core.MockProvider) {
+				m.SetCommandResult(kcatCmd, "", "sh: kcat: not found", 127, nil)
+			},
+			wantStatus:   core.StatusError,
+			wantContains: "kcat is not installed",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := core.NewMockProvider()
+			tt.setupMock(mock)
+
+			result := executeMessageQueueTest(context.Background(), mock, tt.queueTest)
+
+			if result.Status != tt.wantStatus {
+				t.Errorf("Status = %v, want %v (message: %s)", result.Status, tt.wantStatus, result.Message)
+			}
+			if tt.wantContains != "" && !contains(result.Message, tt.wantContains) {
+				t.Errorf("Message %q does not contain %q", result.Message, tt.wantContains)
+			}
+		})
+	}
+}
+
+func TestExecutor_MessageQueueTest_RabbitMQ(t *testing.T) {
+	if _, err := exec.LookPath("curl"); err != nil {
+		t.Skip("curl not available")
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, pass, ok := r.BasicAuth()
+		if !ok || user != "monitor" || pass != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			fmt.Fprint(w, `{"error":"not_authorised","reason":"Login failed"}`)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.EscapedPath() {
+		case "/api/queues/%2F/orders":
+			fmt.Fprint(w, `{"name":"orders","vhost":"/","consumers":2,"messages":14,"state":"running"}`)
+		case "/api/queues/billing/invoices":
+			fmt.Fprint(w, `{"name":"invoices","vhost":"billing","consumers":0,"messages":3,"state":"running"}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"error":"Object Not Found","reason":"Not Found"}`)
+		}
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name         string
+		queueTest    core.MessageQueueTest
+		wantStatus   core.Status
+		wantContains string
+	}{
+		{
+			name:         "queue with consumers",
+			queueTest:    core.MessageQueueTest{Name: "orders", Topic: "orders", VHost: "/", MinConsumers: 2, Username: "monitor", Password: "secret"},
+			wantStatus:   core.StatusPass,
+			wantContains: "exists with 2 consumers",
+		},
+		{
+			name:         "queue without enough consumers",
+			queueTest:    core.MessageQueueTest{Name: "invoices", Topic: "invoices", VHost: "billing", MinConsumers: 1, Username: "monitor", Password: "secret"},
+			wantStatus:   core.StatusFail,
+			wantContains: "has 0 consumers, expected at least 1",
+		},
+		{
+			name:         "missing queue",
+			queueTest:    core.MessageQueueTest{Name: "refunds", Topic: "refunds", VHost: "/", Username: "monitor", Password: "secret"},
+			wantStatus:   core.StatusFail,
+			wantContains: "queue refunds not found in vhost /",
+		},
+		{
+			name:         "bad credentials",
+			queueTest:    core.MessageQueueTest{Name: "orders", Topic: "orders", VHost: "/", Username: "guest", Password: "guest"},
+			wantStatus:   core.StatusError,
+			wantContains: "rejected credentials for user guest",
+		},
+	}
+
+	provider := local.NewProvider()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.queueTest.Backend = "rabbitmq"
+			tt.queueTest.Address = server.URL
+
+			result := executeMessageQueueTest(context.Background(), provider, tt.queueTest)
+
+			if result.Status != tt.wantStatus {
+				t.Errorf("Status = %v, want %v (message: %s)", result.Status, tt.wantStatus, result.Message)
+			}
+			if tt.wantContains != "" && !contains(result.Message, tt.wantContains) {
+				t.Errorf("Message %q does not contain %q", result.Message, tt.wantContains)
+			}
+		})
+	}
+}
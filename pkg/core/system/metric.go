@@ -0,0 +1,78 @@
+package system
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/neilfarmer/platform-spec/pkg/core"
+)
+
+// metricOperators maps metric test operators to their comparison and symbol
+var metricOperators = map[string]struct {
+	compare func(value, threshold float64) bool
+	symbol  string
+}{
+	"gt":  {func(v, t float64) bool { return v > t }, ">"},
+	"lt":  {func(v, t float64) bool { return v < t }, "<"},
+	"gte": {func(v, t float64) bool { return v >= t }, ">="},
+	"lte": {func(v, t float64) bool { return v <= t }, "<="},
+	"eq":  {func(v, t float64) bool { return v == t }, "=="},
+	"ne":  {func(v, t float64) bool { return v != t }, "!="},
+}
+
+// executeMetricTest executes a numeric metric threshold test
+func executeMetricTest(ctx context.Context, provider core.Provider, test core.MetricTest) core.Result {
+	start := time.Now()
+	result := core.Result{
+		Name:    test.Name,
+		Status:  core.StatusPass,
+		Details: make(map[string]interface{}),
+	}
+
+	op, ok := metricOperators[test.Operator]
+	if !ok {
+		result.Status = core.StatusError
+		result.Message = fmt.Sprintf("Unknown operator '%s'", test.Operator)
+		result.Duration = time.Since(start)
+		return result
+	}
+
+	stdout, stderr, exitCode, err := provider.ExecuteCommand(ctx, test.Command)
+	if err != nil {
+		result.Status = core.StatusError
+		result.Message = fmt.Sprintf("Error executing command '%s': %v", test.Command, err)
+		result.Duration = time.Since(start)
+		return result
+	}
+	if exitCode != 0 {
+		result.Status = core.StatusError
+		result.Message = fmt.Sprintf("Command exited with code %d: %s", exitCode, strings.TrimSpace(stderr))
+		result.Duration = time.Since(start)
+		return result
+	}
+
+	output := strings.TrimSpace(stdout)
+	value, err := strconv.ParseFloat(output, 64)
+	if err != nil {
+		result.Status = core.StatusError
+		result.Message = fmt.Sprintf("Command output %q is not a number", output)
+		result.Duration = time.Since(start)
+		return result
+	}
+	result.Details["value"] = value
+	result.Details["threshold"] = test.Threshold
+
+	if !op.compare(value, test.Threshold) {
+		result.Status = core.StatusFail
+		result.Message = fmt.Sprintf("Metric is %g, expected %s %g", value, op.symbol, test.Threshold)
+		result.Duration = time.Since(start)
+		return result
+	}
+
+	result.Message = fmt.Sprintf("Metric is %g (%s %g)", value, op.symbol, test.Threshold)
+	result.Duration = time.Since(start)
+	return result
+}
@@ -0,0 +1,59 @@
+package system
+
+import (
+	"context"
+	"testing"
+
+	"github.com/neilfarmer/platform-spec/pkg/core"
+)
+
+func TestExecutor_MetricTest(t *testing.T) {
+	const cmd = "/usr/local/bin/queue-depth"
+
+	tests := []struct {
+		name         string
+		operator     string
+		threshold    float64
+		stdout       string
+		exitCode     int
+		wantStatus   core.Status
+		wantContains string
+	}{
+		{name: "gt pass", operator: "gt", threshold: 10, stdout: "11\n", wantStatus: core.StatusPass},
+		{name: "gt fail", operator: "gt", threshold: 10, stdout: "10\n", wantStatus: core.StatusFail, wantContains: "Metric is 10, expected > 10"},
+		{name: "lt pass", operator: "lt", threshold: 100, stdout: "42.5", wantStatus: core.StatusPass, wantContains: "Metric is 42.5 (< 100)"},
+		{name: "lt fail", operator: "lt", threshold: 100, stdout: "250", wantStatus: core.StatusFail, wantContains: "expected < 100"},
+		{name: "gte pass", operator: "gte", threshold: 3, stdout: "3", wantStatus: core.StatusPass},
+		{name: "gte fail", operator: "gte", threshold: 3, stdout: "2", wantStatus: core.StatusFail, wantContains: "expected >= 3"},
+		{name: "lte pass", operator: "lte", threshold: 0.9, stdout: " 0.85 \n", wantStatus: core.StatusPass},
+		{name: "lte fail", operator: "lte", threshold: 0.9, stdout: "0.95", wantStatus: core.StatusFail, wantContains: "expected <= 0.9"},
+		{name: "eq pass", operator: "eq", threshold: 0, stdout: "0", wantStatus: core.StatusPass},
+		{name: "eq fail", operator: "eq", threshold: 0, stdout: "4", wantStatus: core.StatusFail, wantContains: "expected == 0"},
+		{name: "ne pass", operator: "ne", threshold: 0, stdout: "-1", wantStatus: core.StatusPass},
+		{name: "ne fail", operator: "ne", threshold: 0, stdout: "0", wantStatus: core.StatusFail, wantContains: "expected != 0"},
+		{name: "non-numeric output", operator: "lt", threshold: 100, stdout: "queue: 42\n", wantStatus: core.StatusError, wantContains: "is not a number"},
+		{name: "empty output", operator: "lt", threshold: 100, stdout: "", wantStatus: core.StatusError, wantContains: "is not a number"},
+		{name: "command fails", operator: "lt", threshold: 100, exitCode: 2, wantStatus: core.StatusError, wantContains: "exited with code 2"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := core.NewMockProvider()
+			mock.SetCommandResult(cmd, tt.stdout, "", tt.exitCode, nil)
+
+			result := executeMetricTest(context.Background(), mock, core.MetricTest{
+				Name:      "Queue depth",
+				Command:   cmd,
+				Operator:  tt.operator,
+				Threshold: tt.threshold,
+			})
+
+			if result.Status != tt.wantStatus {
+				t.Errorf("Status = %v, want %v (message: %s)", result.Status, tt.wantStatus, result.Message)
+			}
+			if tt.wantContains != "" && !contains(result.Message, tt.wantContains) {
+				t.Errorf("Message %q does not contain %q", result.Message, tt.wantContains)
+			}
+		})
+	}
+}
@@ -16,131 +16,339 @@ func NewSystemPlugin() *SystemPlugin {
 
 // Execute runs all system-level tests
 func (p *SystemPlugin) Execute(ctx context.Context, spec *core.Spec, provider core.Provider, failFast bool) ([]core.Result, bool) {
+	if core.TargetOS(ctx, provider) == core.OSWindows {
+		return p.executeWindows(ctx, spec, provider, failFast)
+	}
+
 	var results []core.Result
 	shouldStop := false
 
 	// Execute package tests
 	for _, test := range spec.Tests.Packages {
-		result := executePackageTest(ctx, provider, test)
+		result := core.RunTest(ctx, test.TestOptions, func(ctx context.Context) core.Result {
+			return executePackageTest(ctx, provider, test)
+		})
 		results = append(results, result)
-		if failFast && result.Status == core.StatusFail {
+		if failFast && result.Status == core.StatusFail && !test.ContinueOnFailure {
+			return results, true
+		}
+	}
+
+	// Execute repository tests
+	for _, test := range spec.Tests.Repositories {
+		result := core.RunTest(ctx, test.TestOptions, func(ctx context.Context) core.Result {
+			return executeRepositoryTest(ctx, provider, test)
+		})
+		results = append(results, result)
+		if failFast && result.Status == core.StatusFail && !test.ContinueOnFailure {
 			return results, true
 		}
 	}
 
 	// Execute file tests
 	for _, test := range spec.Tests.Files {
-		result := executeFileTest(ctx, provider, test)
+		result := core.RunTest(ctx, test.TestOptions, func(ctx context.Context) core.Result {
+			return executeFileTest(ctx, provider, test)
+		})
 		results = append(results, result)
-		if failFast && result.Status == core.StatusFail {
+		if failFast && result.Status == core.StatusFail && !test.ContinueOnFailure {
 			return results, true
 		}
 	}
 
 	// Execute service tests
 	for _, test := range spec.Tests.Services {
-		result := executeServiceTest(ctx, provider, test)
+		result := core.RunTest(ctx, test.TestOptions, func(ctx context.Context) core.Result {
+			return executeServiceTest(ctx, provider, test)
+		})
 		results = append(results, result)
-		if failFast && result.Status == core.StatusFail {
+		if failFast && result.Status == core.StatusFail && !test.ContinueOnFailure {
+			return results, true
+		}
+	}
+
+	// Execute systemd property tests
+	for _, test := range spec.Tests.SystemdProps {
+		result := core.RunTest(ctx, test.TestOptions, func(ctx context.Context) core.Result {
+			return executeSystemdPropertyTest(ctx, provider, test)
+		})
+		results = append(results, result)
+		if failFast && result.Status == core.StatusFail && !test.ContinueOnFailure {
+			return results, true
+		}
+	}
+
+	// Execute sshd config tests
+	for _, test := range spec.Tests.SSHConfig {
+		result := core.RunTest(ctx, test.TestOptions, func(ctx context.Context) core.Result {
+			return executeSSHConfigTest(ctx, provider, test)
+		})
+		results = append(results, result)
+		if failFast && result.Status == core.StatusFail && !test.ContinueOnFailure {
 			return results, true
 		}
 	}
 
 	// Execute user tests
 	for _, test := range spec.Tests.Users {
-		result := executeUserTest(ctx, provider, test)
+		result := core.RunTest(ctx, test.TestOptions, func(ctx context.Context) core.Result {
+			return executeUserTest(ctx, provider, test)
+		})
 		results = append(results, result)
-		if failFast && result.Status == core.StatusFail {
+		if failFast && result.Status == core.StatusFail && !test.ContinueOnFailure {
 			return results, true
 		}
 	}
 
 	// Execute group tests
 	for _, test := range spec.Tests.Groups {
-		result := executeGroupTest(ctx, provider, test)
+		result := core.RunTest(ctx, test.TestOptions, func(ctx context.Context) core.Result {
+			return executeGroupTest(ctx, provider, test)
+		})
 		results = append(results, result)
-		if failFast && result.Status == core.StatusFail {
+		if failFast && result.Status == core.StatusFail && !test.ContinueOnFailure {
+			return results, true
+		}
+	}
+
+	// Execute logrotate tests
+	for _, test := range spec.Tests.LogRotate {
+		result := core.RunTest(ctx, test.TestOptions, func(ctx context.Context) core.Result {
+			return executeLogRotateTest(ctx, provider, test)
+		})
+		results = append(results, result)
+		if failFast && result.Status == core.StatusFail && !test.ContinueOnFailure {
+			return results, true
+		}
+	}
+
+	// Execute cron tests
+	for _, test := range spec.Tests.Cron {
+		result := core.RunTest(ctx, test.TestOptions, func(ctx context.Context) core.Result {
+			return executeCronTest(ctx, provider, test)
+		})
+		results = append(results, result)
+		if failFast && result.Status == core.StatusFail && !test.ContinueOnFailure {
+			return results, true
+		}
+	}
+
+	// Execute process tests
+	for _, test := range spec.Tests.Processes {
+		result := core.RunTest(ctx, test.TestOptions, func(ctx context.Context) core.Result {
+			return executeProcessTest(ctx, provider, test)
+		})
+		results = append(results, result)
+		if failFast && result.Status == core.StatusFail && !test.ContinueOnFailure {
 			return results, true
 		}
 	}
 
 	// Execute file content tests
 	for _, test := range spec.Tests.FileContent {
-		result := executeFileContentTest(ctx, provider, test)
+		result := core.RunTest(ctx, test.TestOptions, func(ctx context.Context) core.Result {
+			return executeFileContentTest(ctx, provider, test)
+		})
 		results = append(results, result)
-		if failFast && result.Status == core.StatusFail {
+		if failFast && result.Status == core.StatusFail && !test.ContinueOnFailure {
 			return results, true
 		}
 	}
 
 	// Execute command content tests
 	for _, test := range spec.Tests.CommandContent {
-		result := executeCommandContentTest(ctx, provider, test)
+		result := core.RunTest(ctx, test.TestOptions, func(ctx context.Context) core.Result {
+			return executeCommandContentTest(ctx, provider, test)
+		})
+		results = append(results, result)
+		if failFast && result.Status == core.StatusFail && !test.ContinueOnFailure {
+			return results, true
+		}
+	}
+
+	// Execute command JSON tests
+	for _, test := range spec.Tests.CommandJSON {
+		result := core.RunTest(ctx, test.TestOptions, func(ctx context.Context) core.Result {
+			return executeCommandJSONTest(ctx, provider, test)
+		})
+		results = append(results, result)
+		if failFast && result.Status == core.StatusFail && !test.ContinueOnFailure {
+			return results, true
+		}
+	}
+
+	// Execute metric tests
+	for _, test := range spec.Tests.Metrics {
+		result := core.RunTest(ctx, test.TestOptions, func(ctx context.Context) core.Result {
+			return executeMetricTest(ctx, provider, test)
+		})
 		results = append(results, result)
-		if failFast && result.Status == core.StatusFail {
+		if failFast && result.Status == core.StatusFail && !test.ContinueOnFailure {
 			return results, true
 		}
 	}
 
 	// Execute Docker tests
 	for _, test := range spec.Tests.Docker {
-		result := executeDockerTest(ctx, provider, test)
+		result := core.RunTest(ctx, test.TestOptions, func(ctx context.Context) core.Result {
+			return executeDockerTest(ctx, provider, test)
+		})
+		results = append(results, result)
+		if failFast && result.Status == core.StatusFail && !test.ContinueOnFailure {
+			return results, true
+		}
+	}
+
+	// Execute Docker image tests
+	for _, test := range spec.Tests.DockerImages {
+		result := core.RunTest(ctx, test.TestOptions, func(ctx context.Context) core.Result {
+			return executeDockerImageTest(ctx, provider, test)
+		})
+		results = append(results, result)
+		if failFast && result.Status == core.StatusFail && !test.ContinueOnFailure {
+			return results, true
+		}
+	}
+
+	// Execute Docker network tests
+	for _, test := range spec.Tests.DockerNetworks {
+		result := core.RunTest(ctx, test.TestOptions, func(ctx context.Context) core.Result {
+			return executeDockerNetworkTest(ctx, provider, test)
+		})
+		results = append(results, result)
+		if failFast && result.Status == core.StatusFail && !test.ContinueOnFailure {
+			return results, true
+		}
+	}
+
+	// Execute Docker volume tests
+	for _, test := range spec.Tests.DockerVolumes {
+		result := core.RunTest(ctx, test.TestOptions, func(ctx context.Context) core.Result {
+			return executeDockerVolumeTest(ctx, provider, test)
+		})
+		results = append(results, result)
+		if failFast && result.Status == core.StatusFail && !test.ContinueOnFailure {
+			return results, true
+		}
+	}
+
+	// Execute Docker Compose tests
+	for _, test := range spec.Tests.DockerCompose {
+		result := core.RunTest(ctx, test.TestOptions, func(ctx context.Context) core.Result {
+			return executeDockerComposeTest(ctx, provider, test)
+		})
 		results = append(results, result)
-		if failFast && result.Status == core.StatusFail {
+		if failFast && result.Status == core.StatusFail && !test.ContinueOnFailure {
 			return results, true
 		}
 	}
 
 	// Execute filesystem tests
 	for _, test := range spec.Tests.Filesystems {
-		result := executeFilesystemTest(ctx, provider, test)
+		result := core.RunTest(ctx, test.TestOptions, func(ctx context.Context) core.Result {
+			return executeFilesystemTest(ctx, provider, test)
+		})
 		results = append(results, result)
-		if failFast && result.Status == core.StatusFail {
+		if failFast && result.Status == core.StatusFail && !test.ContinueOnFailure {
 			return results, true
 		}
 	}
 
 	// Execute ping tests
 	for _, test := range spec.Tests.Ping {
-		result := executePingTest(ctx, provider, test)
+		result := core.RunTest(ctx, test.TestOptions, func(ctx context.Context) core.Result {
+			return executePingTest(ctx, provider, test)
+		})
 		results = append(results, result)
-		if failFast && result.Status == core.StatusFail {
+		if failFast && result.Status == core.StatusFail && !test.ContinueOnFailure {
 			return results, true
 		}
 	}
 
 	// Execute DNS tests
 	for _, test := range spec.Tests.DNS {
-		result := executeDNSTest(ctx, provider, test)
+		result := core.RunTest(ctx, test.TestOptions, func(ctx context.Context) core.Result {
+			return executeDNSTest(ctx, provider, test)
+		})
 		results = append(results, result)
-		if failFast && result.Status == core.StatusFail {
+		if failFast && result.Status == core.StatusFail && !test.ContinueOnFailure {
 			return results, true
 		}
 	}
 
 	// Execute system info tests
 	for _, test := range spec.Tests.SystemInfo {
-		result := executeSystemInfoTest(ctx, provider, test)
+		result := core.RunTest(ctx, test.TestOptions, func(ctx context.Context) core.Result {
+			return executeSystemInfoTest(ctx, provider, test)
+		})
 		results = append(results, result)
-		if failFast && result.Status == core.StatusFail {
+		if failFast && result.Status == core.StatusFail && !test.ContinueOnFailure {
 			return results, true
 		}
 	}
 
 	// Execute HTTP tests
 	for _, test := range spec.Tests.HTTP {
-		result := executeHTTPTest(ctx, provider, test)
+		result := core.RunTest(ctx, test.TestOptions, func(ctx context.Context) core.Result {
+			return executeHTTPTest(ctx, provider, test)
+		})
 		results = append(results, result)
-		if failFast && result.Status == core.StatusFail {
+		if failFast && result.Status == core.StatusFail && !test.ContinueOnFailure {
 			return results, true
 		}
 	}
 
 	// Execute port tests
 	for _, test := range spec.Tests.Ports {
-		result := executePortTest(ctx, provider, test)
+		result := core.RunTest(ctx, test.TestOptions, func(ctx context.Context) core.Result {
+			return executePortTest(ctx, provider, test)
+		})
+		results = append(results, result)
+		if failFast && result.Status == core.StatusFail && !test.ContinueOnFailure {
+			return results, true
+		}
+	}
+
+	// Execute service registry tests
+	for _, test := range spec.Tests.ServiceRegistry {
+		result := core.RunTest(ctx, test.TestOptions, func(ctx context.Context) core.Result {
+			return executeServiceRegistryTest(ctx, provider, test)
+		})
+		results = append(results, result)
+		if failFast && result.Status == core.StatusFail && !test.ContinueOnFailure {
+			return results, true
+		}
+	}
+
+	// Execute Vault tests
+	for _, test := range spec.Tests.Vault {
+		result := core.RunTest(ctx, test.TestOptions, func(ctx context.Context) core.Result {
+			return executeVaultTest(ctx, provider, test)
+		})
+		results = append(results, result)
+		if failFast && result.Status == core.StatusFail && !test.ContinueOnFailure {
+			return results, true
+		}
+	}
+
+	// Execute message queue tests
+	for _, test := range spec.Tests.MessageQueues {
+		result := core.RunTest(ctx, test.TestOptions, func(ctx context.Context) core.Result {
+			return executeMessageQueueTest(ctx, provider, test)
+		})
+		results = append(results, result)
+		if failFast && result.Status == core.StatusFail && !test.ContinueOnFailure {
+			return results, true
+		}
+	}
+
+	// Execute capabilities tests
+	for _, test := range spec.Tests.Capabilities {
+		result := core.RunTest(ctx, test.TestOptions, func(ctx context.Context) core.Result {
+			return executeCapabilitiesTest(ctx, provider, test)
+		})
 		results = append(results, result)
-		if failFast && result.Status == core.StatusFail {
+		if failFast && result.Status == core.StatusFail && !test.ContinueOnFailure {
 			return results, true
 		}
 	}
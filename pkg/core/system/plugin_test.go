@@ -31,7 +31,7 @@ func TestSystemPlugin_Execute(t *testing.T) {
 	// Command content test
 	mock.SetCommandResult("echo hello", "hello", "", 0, nil)
 	// Docker test
-	mock.SetCommandResult("docker inspect --format '{{.State.Status}}|{{.Config.Image}}|{{.HostConfig.RestartPolicy.Name}}|{{if .State.Health}}{{.State.Health.Status}}{{else}}none{{end}}' testcontainer 2>/dev/null", "running|nginx:latest|always|none", "", 0, nil)
+	mock.SetCommandResult("docker inspect --format '{{.State.Status}}|{{.Config.Image}}|{{.HostConfig.RestartPolicy.Name}}|{{if .State.Health}}{{.State.Health.Status}}{{else}}none{{end}}|{{.HostConfig.Memory}}|{{.HostConfig.CpuShares}}|{{.State.StartedAt}}|{{.RestartCount}}' testcontainer 2>/dev/null", "running|nginx:latest|always|none|0|0|2026-01-01T00:00:00Z|0", "", 0, nil)
 	// Filesystem test
 	mock.SetCommandResult("findmnt --noheadings --output TARGET,FSTYPE,OPTIONS,SIZE,USED,USE% --target /mnt 2>/dev/null", "/mnt               ext4   rw,relatime    100G  50G   50%", "", 0, nil)
 	// Ping test
@@ -41,7 +41,7 @@ func TestSystemPlugin_Execute(t *testing.T) {
 	// SystemInfo test - just OS check
 	mock.SetCommandResult("grep '^ID=' /etc/os-release 2>/dev/null | cut -d= -f2 | tr -d '\"'", "ubuntu", "", 0, nil)
 	// HTTP test
-	mock.SetCommandResult("curl -s -w $'\\n%{http_code}' 'http://example.com'", "content\n200", "", 0, nil)
+	mock.SetCommandResult("curl -s -w '\\n%{http_code}' 'http://example.com'", "content\n200", "", 0, nil)
 	// Port test
 	mock.SetCommandResult("ss -tln | grep -E ':80\\s' || true", "LISTEN    0    128    0.0.0.0:80    0.0.0.0:*", "", 0, nil)
 
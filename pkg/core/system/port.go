@@ -3,6 +3,7 @@ package system
 import (
 	"context"
 	"fmt"
+	"net"
 	"strings"
 	"time"
 
@@ -20,7 +21,15 @@ func executePortTest(ctx context.Context, provider core.Provider, test core.Port
 
 	// Build ss command based on protocol
 	var cmd string
-	if test.Protocol == "tcp" {
+	if core.TargetOS(ctx, provider) == core.OSWindows {
+		if test.Address != "" {
+			result.Status = core.StatusError
+			result.Message = "listen_address is not supported on Windows targets"
+			result.Duration = time.Since(start)
+			return result
+		}
+		cmd = windowsPortCommand(test)
+	} else if test.Protocol == "tcp" {
 		cmd = fmt.Sprintf("ss -tln | grep -E ':%d\\s' || true", test.Port)
 	} else { // udp
 		cmd = fmt.Sprintf("ss -uln | grep -E ':%d\\s' || true", test.Port)
@@ -47,21 +56,43 @@ func executePortTest(ctx context.Context, provider core.Provider, test core.Port
 	// Check if port is listening by parsing ss output
 	isListening := strings.TrimSpace(stdout) != ""
 
+	// With a listen address, only listeners bound to it count
+	port := fmt.Sprintf("%d/%s", test.Port, test.Protocol)
+	if test.Address != "" {
+		addresses := listenAddresses(stdout, test.Port)
+		result.Details["listen_address"] = test.Address
+		result.Details["listen_addresses"] = addresses
+		isListening = false
+		for _, address := range addresses {
+			if sameListenAddress(address, test.Address) {
+				isListening = true
+			}
+		}
+		if !isListening && len(addresses) > 0 && test.State == "listening" {
+			result.Status = core.StatusFail
+			result.Message = fmt.Sprintf("Port %s is listening on %s, not %s", port, strings.Join(addresses, ", "), test.Address)
+			result.Details["actual_state"] = "listening"
+			result.Duration = time.Since(start)
+			return result
+		}
+		port += " on " + test.Address
+	}
+
 	if test.State == "listening" {
 		if !isListening {
 			result.Status = core.StatusFail
-			result.Message = fmt.Sprintf("Port %d/%s is not listening", test.Port, test.Protocol)
+			result.Message = fmt.Sprintf("Port %s is not listening", port)
 		} else {
-			result.Message = fmt.Sprintf("Port %d/%s is listening", test.Port, test.Protocol)
+			result.Message = fmt.Sprintf("Port %s is listening", port)
 			result.Details["actual_state"] = "listening"
 		}
 	} else { // state == "closed"
 		if isListening {
 			result.Status = core.StatusFail
-			result.Message = fmt.Sprintf("Port %d/%s is listening, expected closed", test.Port, test.Protocol)
+			result.Message = fmt.Sprintf("Port %s is listening, expected closed", port)
 			result.Details["actual_state"] = "listening"
 		} else {
-			result.Message = fmt.Sprintf("Port %d/%s is closed", test.Port, test.Protocol)
+			result.Message = fmt.Sprintf("Port %s is closed", port)
 			result.Details["actual_state"] = "closed"
 		}
 	}
@@ -74,3 +105,35 @@ func executePortTest(ctx context.Context, provider core.Provider, test core.Port
 	result.Duration = time.Since(start)
 	return result
 }
+
+// listenAddresses returns the local addresses bound to port in ss -ln
+// output, e.g. 0.0.0.0, ::1 or * for every address
+func listenAddresses(output string, port int) []string {
+	suffix := fmt.Sprintf(":%d", port)
+	var addresses []string
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Fields(line)
+		// State, Recv-Q and Send-Q come before the local address
+		if len(fields) < 4 || !strings.HasSuffix(fields[3], suffix) {
+			continue
+		}
+		address := strings.TrimSuffix(fields[3], suffix)
+		address = strings.TrimSuffix(strings.TrimPrefix(address, "["), "]")
+		// Drop the interface of addresses such as 127.0.0.53%lo
+		address, _, _ = strings.Cut(address, "%")
+		addresses = append(addresses, address)
+	}
+	return addresses
+}
+
+// sameListenAddress reports whether a bound address reported by ss is the
+// expected IP. ss shows a dual-stack wildcard listener as *, which binds
+// both 0.0.0.0 and ::.
+func sameListenAddress(bound, expected string) bool {
+	want := net.ParseIP(expected)
+	if bound == "*" {
+		return want.IsUnspecified()
+	}
+	got := net.ParseIP(bound)
+	return got != nil && got.Equal(want)
+}
@@ -99,6 +99,66 @@ func TestExecutor_PortTest(t *testing.T) {
 			wantStatus:   core.StatusPass,
 			wantContains: "Port 22/tcp is listening",
 		},
+		{
+			name: "listening on loopback only",
+			portTest: core.PortTest{
+				Name:     "PostgreSQL on loopback",
+				Port:     5432,
+				Protocol: "tcp",
+				State:    "listening",
+				Address:  "127.0.0.1",
+			},
+			setupMock: func(m *core.MockProvider) {
+				m.SetCommandResult("ss -tln | grep -E ':5432\\s' || true", "LISTEN 0 244 127.0.0.1:5432 0.0.0.0:*\nLISTEN 0 244 [::1]:5432 [::]:*", "", 0, nil)
+			},
+			wantStatus:   core.StatusPass,
+			wantContains: "Port 5432/tcp on 127.0.0.1 is listening",
+		},
+		{
+			name: "listening on another address (fail)",
+			portTest: core.PortTest{
+				Name:     "PostgreSQL on loopback",
+				Port:     5432,
+				Protocol: "tcp",
+				State:    "listening",
+				Address:  "127.0.0.1",
+			},
+			setupMock: func(m *core.MockProvider) {
+				m.SetCommandResult("ss -tln | grep -E ':5432\\s' || true", "LISTEN 0 244 0.0.0.0:5432 0.0.0.0:*", "", 0, nil)
+			},
+			wantStatus:   core.StatusFail,
+			wantContains: "Port 5432/tcp is listening on 0.0.0.0, not 127.0.0.1",
+		},
+		{
+			name: "wildcard address closed (pass)",
+			portTest: core.PortTest{
+				Name:     "Redis not exposed",
+				Port:     6379,
+				Protocol: "tcp",
+				State:    "closed",
+				Address:  "0.0.0.0",
+			},
+			setupMock: func(m *core.MockProvider) {
+				m.SetCommandResult("ss -tln | grep -E ':6379\\s' || true", "LISTEN 0 511 127.0.0.1:6379 0.0.0.0:*", "", 0, nil)
+			},
+			wantStatus:   core.StatusPass,
+			wantContains: "Port 6379/tcp on 0.0.0.0 is closed",
+		},
+		{
+			name: "dual-stack wildcard listening on :: (fail when closed expected)",
+			portTest: core.PortTest{
+				Name:     "DNS not exposed",
+				Port:     53,
+				Protocol: "udp",
+				State:    "closed",
+				Address:  "::",
+			},
+			setupMock: func(m *core.MockProvider) {
+				m.SetCommandResult("ss -uln | grep -E ':53\\s' || true", "UNCONN 0 0 *:53 *:*", "", 0, nil)
+			},
+			wantStatus:   core.StatusFail,
+			wantContains: "Port 53/udp on :: is listening, expected closed",
+		},
 	}
 
 	for _, tt := range tests {
@@ -122,3 +182,11 @@ func TestExecutor_PortTest(t *testing.T) {
 		})
 	}
 }
+
+func TestListenAddresses(t *testing.T) {
+	output := "LISTEN 0 4096 127.0.0.53%lo:53 0.0.0.0:*\nLISTEN 0 128 [::]:53 [::]:*\nLISTEN 0 128 0.0.0.0:5353 0.0.0.0:*"
+	got := listenAddresses(output, 53)
+	if len(got) != 2 || got[0] != "127.0.0.53" || got[1] != "::" {
+		t.Errorf("listenAddresses() = %v, want [127.0.0.53 ::]", got)
+	}
+}
@@ -0,0 +1,124 @@
+package system
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/neilfarmer/platform-spec/pkg/core"
+)
+
+// processListArgs are the ps options listing one full command line per
+// process, without a header or truncation; they work with procps and BSD ps
+const processListArgs = "-ww -o args="
+
+// executeProcessTest executes a running process count test
+func executeProcessTest(ctx context.Context, provider core.Provider, test core.ProcessTest) core.Result {
+	start := time.Now()
+	result := core.Result{
+		Name:    test.Name,
+		Status:  core.StatusPass,
+		Details: make(map[string]interface{}),
+	}
+
+	pattern, err := regexp.Compile(test.Process)
+	if err != nil {
+		result.Status = core.StatusError
+		result.Message = fmt.Sprintf("Invalid process pattern %q: %v", test.Process, err)
+		result.Duration = time.Since(start)
+		return result
+	}
+
+	commands, err := listProcesses(ctx, provider, test.User)
+	if err != nil {
+		result.Status = core.StatusError
+		result.Message = fmt.Sprintf("Error listing processes: %v", err)
+		result.Duration = time.Since(start)
+		return result
+	}
+
+	count := 0
+	for _, command := range commands {
+		if pattern.MatchString(command) {
+			count++
+		}
+	}
+	result.Details["count"] = count
+
+	process := describeProcess(test)
+	if violation := processCountViolation(test, count); violation != "" {
+		result.Status = core.StatusFail
+		result.Message = fmt.Sprintf("Process count for %s is %d, %s", process, count, violation)
+	} else if test.State == "absent" {
+		result.Message = fmt.Sprintf("No process matches %s", process)
+	} else {
+		result.Message = fmt.Sprintf("Process count for %s is %d", process, count)
+	}
+	result.Duration = time.Since(start)
+	return result
+}
+
+// processCountViolation returns the constraint count breaks, or "" if none
+func processCountViolation(test core.ProcessTest, count int) string {
+	if test.State == "absent" {
+		if count > 0 {
+			return "expected none"
+		}
+		return ""
+	}
+
+	minCount := test.MinCount
+	if minCount == 0 {
+		minCount = 1
+	}
+	switch {
+	case test.MaxCount > 0 && minCount == test.MaxCount && count != minCount:
+		return fmt.Sprintf("expected exactly %d", minCount)
+	case count < minCount:
+		return fmt.Sprintf("expected at least %d", minCount)
+	case test.MaxCount > 0 && count > test.MaxCount:
+		return fmt.Sprintf("expected at most %d", test.MaxCount)
+	}
+	return ""
+}
+
+// describeProcess describes the processes a test counts, e.g.
+// 'nginx: worker' as www-data
+func describeProcess(test core.ProcessTest) string {
+	description := fmt.Sprintf("'%s'", test.Process)
+	if test.User != "" {
+		description += " as " + test.User
+	}
+	return description
+}
+
+// listProcesses returns the command lines of the processes on the target,
+// narrowed to those running as user if it is set. The listing's own shell and
+// ps processes are left out so that they are never counted.
+func listProcesses(ctx context.Context, provider core.Provider, user string) ([]string, error) {
+	command := "ps -A " + processListArgs
+	if user != "" {
+		command = fmt.Sprintf("ps -U %s %s", core.ShellQuote(user), processListArgs)
+	}
+
+	stdout, stderr, exitCode, err := provider.ExecuteCommand(ctx, command)
+	if err != nil {
+		return nil, err
+	}
+	// procps ps exits 1 without output when a user has no processes
+	if exitCode != 0 && strings.TrimSpace(stderr) != "" {
+		return nil, fmt.Errorf("%s", strings.TrimSpace(stderr))
+	}
+
+	var commands []string
+	for _, line := range strings.Split(stdout, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.Contains(line, processListArgs) {
+			continue
+		}
+		commands = append(commands, line)
+	}
+	return commands, nil
+}
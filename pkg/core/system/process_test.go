@@ -0,0 +1,144 @@
+package system
+
+import (
+	"context"
+	"testing"
+
+	"github.com/neilfarmer/platform-spec/pkg/core"
+)
+
+func TestExecutor_ProcessTest(t *testing.T) {
+	const processes = `/sbin/init
+[kthreadd]
+nginx: master process /usr/sbin/nginx -g daemon on; master_process on;
+nginx: worker process
+nginx: worker process
+nginx: worker process
+nginx: worker process
+/usr/sbin/sshd -D
+sh -c ps -A -ww -o args=
+ps -A -ww -o args=
+`
+	const allProcesses = "ps -A -ww -o args="
+
+	tests := []struct {
+		name         string
+		processTest  core.ProcessTest
+		setupMock    func(*core.MockProvider)
+		wantStatus   core.Status
+		wantContains string
+		wantCount    int
+	}{
+		{
+			name:        "exact worker count",
+			processTest: core.ProcessTest{Name: "nginx workers", Process: "nginx: worker", State: "running", MinCount: 4, MaxCount: 4},
+			setupMock: func(m *core.MockProvider) {
+				m.SetCommandResult(allProcesses, processes, "", 0, nil)
+			},
+			wantStatus:   core.StatusPass,
+			wantContains: "Process count for 'nginx: worker' is 4",
+			wantCount:    4,
+		},
+		{
+			name:        "exact count differs",
+			processTest: core.ProcessTest{Name: "nginx workers", Process: "nginx: worker", State: "running", MinCount: 8, MaxCount: 8},
+			setupMock: func(m *core.MockProvider) {
+				m.SetCommandResult(allProcesses, processes, "", 0, nil)
+			},
+			wantStatus:   core.StatusFail,
+			wantContains: "Process count for 'nginx: worker' is 4, expected exactly 8",
+			wantCount:    4,
+		},
+		{
+			name:        "too few",
+			processTest: core.ProcessTest{Name: "nginx workers", Process: "nginx: worker", State: "running", MinCount: 6},
+			setupMock: func(m *core.MockProvider) {
+				m.SetCommandResult(allProcesses, processes, "", 0, nil)
+			},
+			wantStatus:   core.StatusFail,
+			wantContains: "expected at least 6",
+		},
+		{
+			name:        "too many",
+			processTest: core.ProcessTest{Name: "nginx processes", Process: "^nginx", State: "running", MaxCount: 3},
+			setupMock: func(m *core.MockProvider) {
+				m.SetCommandResult(allProcesses, processes, "", 0, nil)
+			},
+			wantStatus:   core.StatusFail,
+			wantContains: "Process count for '^nginx' is 5, expected at most 3",
+		},
+		{
+			name:        "running defaults to at least one",
+			processTest: core.ProcessTest{Name: "redis", Process: "redis-server", State: "running"},
+			setupMock: func(m *core.MockProvider) {
+				m.SetCommandResult(allProcesses, processes, "", 0, nil)
+			},
+			wantStatus:   core.StatusFail,
+			wantContains: "Process count for 'redis-server' is 0, expected at least 1",
+		},
+		{
+			name:        "the listing itself is not counted",
+			processTest: core.ProcessTest{Name: "no ps", Process: `\bps\b`, State: "absent"},
+			setupMock: func(m *core.MockProvider) {
+				m.SetCommandResult(allProcesses, processes, "", 0, nil)
+			},
+			wantStatus:   core.StatusPass,
+			wantContains: `No process matches '\bps\b'`,
+		},
+		{
+			name:        "absent but running",
+			processTest: core.ProcessTest{Name: "no sshd", Process: "sshd", State: "absent"},
+			setupMock: func(m *core.MockProvider) {
+				m.SetCommandResult(allProcesses, processes, "", 0, nil)
+			},
+			wantStatus:   core.StatusFail,
+			wantContains: "Process count for 'sshd' is 1, expected none",
+		},
+		{
+			name:        "user's processes",
+			processTest: core.ProcessTest{Name: "app workers", Process: "gunicorn", State: "running", MinCount: 2, User: "app"},
+			setupMock: func(m *core.MockProvider) {
+				m.SetCommandResult("ps -U 'app' -ww -o args=", "gunicorn: master\ngunicorn: worker\ngunicorn: worker\n", "", 0, nil)
+			},
+			wantStatus:   core.StatusPass,
+			wantContains: "Process count for 'gunicorn' as app is 3",
+			wantCount:    3,
+		},
+		{
+			name:        "user without processes",
+			processTest: core.ProcessTest{Name: "app stopped", Process: "gunicorn", State: "absent", User: "app"},
+			setupMock: func(m *core.MockProvider) {
+				m.SetCommandResult("ps -U 'app' -ww -o args=", "", "", 1, nil)
+			},
+			wantStatus: core.StatusPass,
+		},
+		{
+			name:        "unknown user",
+			processTest: core.ProcessTest{Name: "app workers", Process: "gunicorn", State: "running", User: "nobody2"},
+			setupMock: func(m *core.MockProvider) {
+				m.SetCommandResult("ps -U 'nobody2' -ww -o args=", "", "error: user name does not exist\n", 1, nil)
+			},
+			wantStatus:   core.StatusError,
+			wantContains: "Error listing processes: error: user name does not exist",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := core.NewMockProvider()
+			tt.setupMock(mock)
+
+			result := executeProcessTest(context.Background(), mock, tt.processTest)
+
+			if result.Status != tt.wantStatus {
+				t.Errorf("Status = %v, want %v (message: %s)", result.Status, tt.wantStatus, result.Message)
+			}
+			if tt.wantContains != "" && !contains(result.Message, tt.wantContains) {
+				t.Errorf("Message = %q, want it to contain %q", result.Message, tt.wantContains)
+			}
+			if tt.wantCount != 0 && result.Details["count"] != tt.wantCount {
+				t.Errorf("Details[count] = %v, want %d", result.Details["count"], tt.wantCount)
+			}
+		})
+	}
+}
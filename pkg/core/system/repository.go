@@ -0,0 +1,193 @@
+package system
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/neilfarmer/platform-spec/pkg/core"
+)
+
+// aptSourcesCommand prints the source lines of one-line (.list) and deb822 (.sources) apt configuration
+const aptSourcesCommand = "cat /etc/apt/sources.list /etc/apt/sources.list.d/*.list /etc/apt/sources.list.d/*.sources 2>/dev/null | grep -E '^[[:space:]]*(deb|deb-src|URIs:)[[:space:]]'"
+
+// yumRepo is an enabled yum/dnf repository
+type yumRepo struct {
+	ID      string
+	BaseURL string
+}
+
+// executeRepositoryTest executes a package repository test
+func executeRepositoryTest(ctx context.Context, provider core.Provider, test core.RepositoryTest) core.Result {
+	start := time.Now()
+	result := core.Result{
+		Name:    test.Name,
+		Status:  core.StatusPass,
+		Details: make(map[string]interface{}),
+	}
+
+	var configured []string
+	var found bool
+	var err error
+	if test.Manager == "apt" {
+		configured, found, err = findAptRepository(ctx, provider, test.Repo)
+	} else {
+		configured, found, err = findYumRepository(ctx, provider, test.Manager, test.Repo)
+	}
+	if err != nil {
+		result.Status = core.StatusError
+		result.Message = fmt.Sprintf("Error listing %s repositories: %v", test.Manager, err)
+		result.Duration = time.Since(start)
+		return result
+	}
+	result.Details["configured"] = configured
+
+	if test.State == "present" && !found {
+		result.Status = core.StatusFail
+		if len(configured) == 0 {
+			result.Message = fmt.Sprintf("Repository %s is not configured (no %s repositories found)", test.Repo, test.Manager)
+		} else {
+			result.Message = fmt.Sprintf("Repository %s is not configured (configured: %s)", test.Repo, strings.Join(configured, ", "))
+		}
+		result.Duration = time.Since(start)
+		return result
+	}
+	if test.State == "absent" && found {
+		result.Status = core.StatusFail
+		result.Message = fmt.Sprintf("Repository %s is configured but should be absent", test.Repo)
+		result.Duration = time.Since(start)
+		return result
+	}
+
+	if test.GPGKey != "" {
+		_, _, exitCode, err := provider.ExecuteCommand(ctx, fmt.Sprintf("test -s %s", core.ShellQuote(test.GPGKey)))
+		if err != nil {
+			result.Status = core.StatusError
+			result.Message = fmt.Sprintf("Error checking GPG key %s: %v", test.GPGKey, err)
+			result.Duration = time.Since(start)
+			return result
+		}
+		if exitCode != 0 {
+			result.Status = core.StatusFail
+			result.Message = fmt.Sprintf("Repository %s is configured but GPG key %s is missing", test.Repo, test.GPGKey)
+			result.Duration = time.Since(start)
+			return result
+		}
+	}
+
+	if test.State == "present" {
+		result.Message = fmt.Sprintf("Repository %s is configured", test.Repo)
+	} else {
+		result.Message = fmt.Sprintf("Repository %s is absent as expected", test.Repo)
+	}
+	result.Duration = time.Since(start)
+	return result
+}
+
+// findAptRepository returns the configured apt repository URLs and whether repo is one of them
+func findAptRepository(ctx context.Context, provider core.Provider, repo string) ([]string, bool, error) {
+	stdout, _, _, err := provider.ExecuteCommand(ctx, aptSourcesCommand)
+	if err != nil {
+		return nil, false, err
+	}
+
+	urls := parseAptSources(stdout)
+	for _, u := range urls {
+		if sameRepoURL(u, repo) {
+			return urls, true, nil
+		}
+	}
+	return urls, false, nil
+}
+
+// parseAptSources extracts repository URLs from one-line and deb822 apt source lines
+func parseAptSources(output string) []string {
+	var urls []string
+	seen := make(map[string]bool)
+	add := func(u string) {
+		if !seen[u] {
+			seen[u] = true
+			urls = append(urls, u)
+		}
+	}
+
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			continue
+		}
+		if fields[0] == "URIs:" {
+			for _, u := range fields[1:] {
+				add(u)
+			}
+			continue
+		}
+
+		// deb [arch=amd64 signed-by=/usr/share/keyrings/x.gpg] http://mirror/ubuntu jammy main
+		rest := fields[1:]
+		if strings.HasPrefix(rest[0], "[") {
+			for len(rest) > 0 && !strings.HasSuffix(rest[0], "]") {
+				rest = rest[1:]
+			}
+			if len(rest) > 0 {
+				rest = rest[1:]
+			}
+		}
+		if len(rest) > 0 {
+			add(rest[0])
+		}
+	}
+	return urls
+}
+
+// findYumRepository returns the enabled yum/dnf repository ids and whether repo matches an id or base URL
+func findYumRepository(ctx context.Context, provider core.Provider, manager, repo string) ([]string, bool, error) {
+	stdout, stderr, exitCode, err := provider.ExecuteCommand(ctx, fmt.Sprintf("%s -v repolist enabled", manager))
+	if err != nil {
+		return nil, false, err
+	}
+	if exitCode != 0 {
+		return nil, false, fmt.Errorf("%s repolist failed (exit %d): %s", manager, exitCode, strings.TrimSpace(stderr))
+	}
+
+	repos := parseYumRepolist(stdout)
+	var ids []string
+	found := false
+	for _, r := range repos {
+		ids = append(ids, r.ID)
+		if r.ID == repo || (r.BaseURL != "" && sameRepoURL(r.BaseURL, repo)) {
+			found = true
+		}
+	}
+	return ids, found, nil
+}
+
+// parseYumRepolist parses the Repo-id and Repo-baseurl fields of `yum -v repolist` output
+func parseYumRepolist(output string) []yumRepo {
+	var repos []yumRepo
+	for _, line := range strings.Split(output, "\n") {
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		value = strings.TrimSpace(value)
+		switch strings.TrimSpace(key) {
+		case "Repo-id":
+			// yum reports ids as base/7/x86_64
+			id, _, _ := strings.Cut(value, "/")
+			repos = append(repos, yumRepo{ID: id})
+		case "Repo-baseurl":
+			// "http://mirror/centos/7/os/x86_64/ (9 more)"
+			if fields := strings.Fields(value); len(fields) > 0 && len(repos) > 0 {
+				repos[len(repos)-1].BaseURL = strings.TrimSuffix(fields[0], ",")
+			}
+		}
+	}
+	return repos
+}
+
+// sameRepoURL compares repository URLs, ignoring a trailing slash
+func sameRepoURL(a, b string) bool {
+	return strings.TrimRight(a, "/") == strings.TrimRight(b, "/")
+}
@@ -0,0 +1,192 @@
+package system
+
+import (
+	"context"
+	"testing"
+
+	"github.com/neilfarmer/platform-spec/pkg/core"
+)
+
+func TestExecutor_RepositoryTest(t *testing.T) {
+	const aptSources = `deb [signed-by=/usr/share/keyrings/internal.gpg] https://mirror.internal/ubuntu jammy main universe
+deb http://security.ubuntu.com/ubuntu jammy-security main
+URIs: https://download.docker.com/linux/ubuntu
+`
+	const yumRepolist = `Loading "fastestmirror" plugin
+Repo-id      : base/7/x86_64
+Repo-name    : CentOS-7 - Base
+Repo-status  : enabled
+Repo-baseurl : http://mirror.internal/centos/7/os/x86_64/ (2 more)
+
+Repo-id      : epel/x86_64
+Repo-name    : Extra Packages for Enterprise Linux 7 - x86_64
+Repo-status  : enabled
+Repo-metalink: https://mirrors.fedoraproject.org/metalink?repo=epel-7&arch=x86_64
+`
+
+	tests := []struct {
+		name         string
+		repoTest     core.RepositoryTest
+		setupMock    func(*core.MockProvider)
+		wantStatus   core.Status
+		wantContains string
+	}{
+		{
+			name: "apt repository present with key",
+			repoTest: core.RepositoryTest{
+				Name:    "Internal mirror",
+				Manager: "apt",
+				Repo:    "https://mirror.internal/ubuntu/",
+				State:   "present",
+				GPGKey:  "/usr/share/keyrings/internal.gpg",
+			},
+			setupMock: func(m *core.MockProvider) {
+				m.SetCommandResult(aptSourcesCommand, aptSources, "", 0, nil)
+				m.SetCommandResult("test -s '/usr/share/keyrings/internal.gpg'", "", "", 0, nil)
+			},
+			wantStatus:   core.StatusPass,
+			wantContains: "is configured",
+		},
+		{
+			name: "apt deb822 repository present",
+			repoTest: core.RepositoryTest{
+				Name:    "Docker repo",
+				Manager: "apt",
+				Repo:    "https://download.docker.com/linux/ubuntu",
+				State:   "present",
+			},
+			setupMock: func(m *core.MockProvider) {
+				m.SetCommandResult(aptSourcesCommand, aptSources, "", 0, nil)
+			},
+			wantStatus: core.StatusPass,
+		},
+		{
+			name: "apt repository points at wrong URL",
+			repoTest: core.RepositoryTest{
+				Name:    "Internal mirror",
+				Manager: "apt",
+				Repo:    "https://mirror.internal/ubuntu",
+				State:   "present",
+			},
+			setupMock: func(m *core.MockProvider) {
+				m.SetCommandResult(aptSourcesCommand, "deb http://archive.ubuntu.com/ubuntu jammy main\n", "", 0, nil)
+			},
+			wantStatus:   core.StatusFail,
+			wantContains: "configured: http://archive.ubuntu.com/ubuntu",
+		},
+		{
+			name: "apt repository missing GPG key",
+			repoTest: core.RepositoryTest{
+				Name:    "Internal mirror",
+				Manager: "apt",
+				Repo:    "https://mirror.internal/ubuntu",
+				State:   "present",
+				GPGKey:  "/usr/share/keyrings/internal.gpg",
+			},
+			setupMock: func(m *core.MockProvider) {
+				m.SetCommandResult(aptSourcesCommand, aptSources, "", 0, nil)
+				m.SetCommandResult("test -s '/usr/share/keyrings/internal.gpg'", "", "", 1, nil)
+			},
+			wantStatus:   core.StatusFail,
+			wantContains: "GPG key /usr/share/keyrings/internal.gpg is missing",
+		},
+		{
+			name: "apt repository absent as expected",
+			repoTest: core.RepositoryTest{
+				Name:    "No public archive",
+				Manager: "apt",
+				Repo:    "http://archive.ubuntu.com/ubuntu",
+				State:   "absent",
+			},
+			setupMock: func(m *core.MockProvider) {
+				m.SetCommandResult(aptSourcesCommand, aptSources, "", 0, nil)
+			},
+			wantStatus:   core.StatusPass,
+			wantContains: "absent as expected",
+		},
+		{
+			name: "apt repository should be absent",
+			repoTest: core.RepositoryTest{
+				Name:    "No public security mirror",
+				Manager: "apt",
+				Repo:    "http://security.ubuntu.com/ubuntu",
+				State:   "absent",
+			},
+			setupMock: func(m *core.MockProvider) {
+				m.SetCommandResult(aptSourcesCommand, aptSources, "", 0, nil)
+			},
+			wantStatus:   core.StatusFail,
+			wantContains: "should be absent",
+		},
+		{
+			name: "yum repository present by id",
+			repoTest: core.RepositoryTest{
+				Name:    "EPEL enabled",
+				Manager: "yum",
+				Repo:    "epel",
+				State:   "present",
+			},
+			setupMock: func(m *core.MockProvider) {
+				m.SetCommandResult("yum -v repolist enabled", yumRepolist, "", 0, nil)
+			},
+			wantStatus: core.StatusPass,
+		},
+		{
+			name: "yum repository present by base URL",
+			repoTest: core.RepositoryTest{
+				Name:    "Internal base mirror",
+				Manager: "yum",
+				Repo:    "http://mirror.internal/centos/7/os/x86_64",
+				State:   "present",
+			},
+			setupMock: func(m *core.MockProvider) {
+				m.SetCommandResult("yum -v repolist enabled", yumRepolist, "", 0, nil)
+			},
+			wantStatus: core.StatusPass,
+		},
+		{
+			name: "dnf repository absent",
+			repoTest: core.RepositoryTest{
+				Name:    "Internal AppStream",
+				Manager: "dnf",
+				Repo:    "internal-appstream",
+				State:   "present",
+			},
+			setupMock: func(m *core.MockProvider) {
+				m.SetCommandResult("dnf -v repolist enabled", "Repo-id            : appstream\nRepo-baseurl       : http://dl.rockylinux.org/pub/rocky/9/AppStream/x86_64/os/\n", "", 0, nil)
+			},
+			wantStatus:   core.StatusFail,
+			wantContains: "configured: appstream",
+		},
+		{
+			name: "yum repolist fails",
+			repoTest: core.RepositoryTest{
+				Name:    "EPEL enabled",
+				Manager: "yum",
+				Repo:    "epel",
+				State:   "present",
+			},
+			setupMock: func(m *core.MockProvider) {
+				m.SetCommandResult("yum -v repolist enabled", "", "sh: yum: not found", 127, nil)
+			},
+			wantStatus:   core.StatusError,
+			wantContains: "yum repolist failed",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := core.NewMockProvider()
+			tt.setupMock(mock)
+
+			result := executeRepositoryTest(context.Background(), mock, tt.repoTest)
+
+			if result.Status != tt.wantStatus {
+				t.Errorf("Status = %v, want %v (message: %s)", result.Status, tt.wantStatus, result.Message)
+			}
+			if tt.wantContains != "" && !contains(result.Message, tt.wantContains) {
+				t.Errorf("Message %q does not contain %q", result.Message, tt.wantContains)
+			}
+		})
+	}
+}
@@ -0,0 +1,39 @@
+package system
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/neilfarmer/platform-spec/pkg/core"
+)
+
+// runAsCommand wraps a command so it runs as another user. sudo -n fails
+// instead of prompting when a password would be required.
+func runAsCommand(command, user string) string {
+	return fmt.Sprintf("sudo -n -u %s -- sh -c %s", core.ShellQuote(user), core.ShellQuote(command))
+}
+
+// executeCommandAs runs a test command, as another user when runAs is set.
+// A failure to switch user is returned as an error so it is not mistaken for
+// the command's own exit code.
+func executeCommandAs(ctx context.Context, provider core.Provider, command, runAs string) (string, string, int, error) {
+	if runAs == "" {
+		return provider.ExecuteCommand(ctx, command)
+	}
+	if core.TargetOS(ctx, provider) == core.OSWindows {
+		return "", "", 0, fmt.Errorf("run_as is not supported on Windows")
+	}
+
+	stdout, stderr, exitCode, err := provider.ExecuteCommand(ctx, runAsCommand(command, runAs))
+	if err != nil {
+		return "", "", 0, err
+	}
+	if exitCode == 127 && strings.Contains(stderr, "sudo") {
+		return "", "", 0, fmt.Errorf("sudo is not available on the target")
+	}
+	if exitCode != 0 && strings.HasPrefix(strings.TrimSpace(stderr), "sudo:") {
+		return "", "", 0, fmt.Errorf("cannot run as %s: %s", runAs, strings.TrimSpace(stderr))
+	}
+	return stdout, stderr, exitCode, nil
+}
@@ -89,6 +89,17 @@ func executeServiceTest(ctx context.Context, provider core.Provider, test core.S
 
 // checkServiceStatus checks if a service is running and enabled
 func checkServiceStatus(ctx context.Context, provider core.Provider, service string) (running, enabled bool, err error) {
+	switch targetOS := core.TargetOS(ctx, provider); {
+	case targetOS == core.OSWindows:
+		return checkWindowsServiceStatus(ctx, provider, service)
+	case targetOS == core.OSDarwin:
+		return checkLaunchdServiceStatus(ctx, provider, service)
+	case targetOS == core.OSOpenBSD:
+		return checkRcctlServiceStatus(ctx, provider, service)
+	case core.IsBSD(targetOS):
+		return checkRcServiceStatus(ctx, provider, service)
+	}
+
 	// Try systemctl (systemd)
 	stdout, _, exitCode, err := provider.ExecuteCommand(ctx, fmt.Sprintf("systemctl is-active %s 2>/dev/null", service))
 	if err != nil {
@@ -109,3 +120,94 @@ func checkServiceStatus(ctx context.Context, provider core.Provider, service str
 
 	return running, enabled, nil
 }
+
+// checkLaunchdServiceStatus checks a macOS launchd job in the system domain.
+// The job is running if launchd reports it as running (or holding a PID), and
+// enabled if it is loaded and not marked disabled in launchd's override database.
+func checkLaunchdServiceStatus(ctx context.Context, provider core.Provider, service string) (running, enabled bool, err error) {
+	stdout, _, exitCode, err := provider.ExecuteCommand(ctx, fmt.Sprintf("launchctl print %s 2>/dev/null", core.ShellQuote("system/"+service)))
+	if err != nil {
+		return false, false, err
+	}
+
+	if exitCode == 0 && strings.TrimSpace(stdout) != "" {
+		running = launchdPrintState(stdout) == "running"
+	} else {
+		// Fall back to launchctl list, which also works on older macOS releases
+		stdout, _, exitCode, err = provider.ExecuteCommand(ctx, fmt.Sprintf("launchctl list %s 2>/dev/null", core.ShellQuote(service)))
+		if err != nil {
+			return false, false, err
+		}
+		if exitCode != 0 {
+			// Job is not loaded
+			return false, false, nil
+		}
+		running = strings.Contains(stdout, `"PID" =`)
+	}
+
+	stdout, _, _, err = provider.ExecuteCommand(ctx, "launchctl print-disabled system 2>/dev/null")
+	if err != nil {
+		return running, true, nil // Don't fail if we can't check enabled status
+	}
+
+	return running, !launchdDisabled(stdout, service), nil
+}
+
+// launchdPrintState returns the job state ("running", "not running", ...) from launchctl print output
+func launchdPrintState(output string) string {
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "state = ") {
+			return strings.TrimPrefix(line, "state = ")
+		}
+	}
+	return ""
+}
+
+// launchdDisabled reports whether launchctl print-disabled output marks the label as disabled.
+// Newer releases print "=> disabled", older ones "=> true".
+func launchdDisabled(output, label string) bool {
+	prefix := fmt.Sprintf("%q =>", label)
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, prefix) {
+			value := strings.TrimSpace(strings.TrimPrefix(line, prefix))
+			return value == "disabled" || value == "true"
+		}
+	}
+	return false
+}
+
+// checkRcServiceStatus checks a FreeBSD/NetBSD rc.d service using service(8) and sysrc(8)
+func checkRcServiceStatus(ctx context.Context, provider core.Provider, service string) (running, enabled bool, err error) {
+	_, _, exitCode, err := provider.ExecuteCommand(ctx, fmt.Sprintf("service %s onestatus >/dev/null 2>&1", core.ShellQuote(service)))
+	if err != nil {
+		return false, false, err
+	}
+	running = exitCode == 0
+
+	stdout, _, exitCode, err := provider.ExecuteCommand(ctx, fmt.Sprintf("sysrc -n %s 2>/dev/null", core.ShellQuote(service+"_enable")))
+	if err != nil {
+		return running, false, nil // Don't fail if we can't check enabled status
+	}
+	enabled = exitCode == 0 && strings.EqualFold(strings.TrimSpace(stdout), "YES")
+
+	return running, enabled, nil
+}
+
+// checkRcctlServiceStatus checks an OpenBSD service using rcctl(8)
+func checkRcctlServiceStatus(ctx context.Context, provider core.Provider, service string) (running, enabled bool, err error) {
+	_, _, exitCode, err := provider.ExecuteCommand(ctx, fmt.Sprintf("rcctl check %s >/dev/null 2>&1", core.ShellQuote(service)))
+	if err != nil {
+		return false, false, err
+	}
+	running = exitCode == 0
+
+	_, _, exitCode, err = provider.ExecuteCommand(ctx, fmt.Sprintf("rcctl get %s status >/dev/null 2>&1", core.ShellQuote(service)))
+	if err != nil {
+		return running, false, nil // Don't fail if we can't check enabled status
+	}
+	enabled = exitCode == 0
+
+	return running, enabled, nil
+}
@@ -0,0 +1,161 @@
+package system
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/neilfarmer/platform-spec/pkg/core"
+)
+
+// etcdServicePrefix is the key prefix under which etcd service instances are registered
+const etcdServicePrefix = "/services/"
+
+// consulHealthEntry is an entry from Consul's /v1/health/service/:service endpoint
+type consulHealthEntry struct {
+	Service struct {
+		ID string `json:"ID"`
+	} `json:"Service"`
+	Checks []struct {
+		Status string `json:"Status"`
+	} `json:"Checks"`
+}
+
+// executeServiceRegistryTest executes a Consul or etcd service registration test
+func executeServiceRegistryTest(ctx context.Context, provider core.Provider, test core.ServiceRegistryTest) core.Result {
+	start := time.Now()
+	result := core.Result{
+		Name:    test.Name,
+		Status:  core.StatusPass,
+		Details: make(map[string]interface{}),
+	}
+
+	var req curlRequest
+	switch test.Backend {
+	case "consul":
+		req = curlRequest{
+			URL: fmt.Sprintf("%s/v1/health/service/%s", strings.TrimRight(test.Address, "/"), url.PathEscape(test.Service)),
+		}
+	case "etcd":
+		req = etcdRangeRequest(test.Address, etcdServicePrefix+test.Service+"/")
+	}
+
+	resp, err := runCurl(ctx, provider, req)
+	if err != nil {
+		result.Status = core.StatusError
+		result.Message = fmt.Sprintf("Error querying %s: %v", test.Backend, err)
+		result.Duration = time.Since(start)
+		return result
+	}
+	if resp.ExitCode != 0 {
+		result.Status = core.StatusFail
+		result.Message = fmt.Sprintf("%s at %s is not reachable: %s", test.Backend, test.Address, resp.Stderr)
+		result.Duration = time.Since(start)
+		return result
+	}
+	if resp.StatusCode != 200 {
+		result.Status = core.StatusFail
+		result.Message = fmt.Sprintf("%s API returned status %d", test.Backend, resp.StatusCode)
+		result.Duration = time.Since(start)
+		return result
+	}
+
+	var registered, healthy int
+	if test.Backend == "consul" {
+		registered, healthy, err = countConsulInstances(resp.Body)
+	} else {
+		registered, err = parseEtcdCount(resp.Body)
+		// etcd has no health checks; registrations are expected to be held by leases
+		healthy = registered
+	}
+	if err != nil {
+		result.Status = core.StatusError
+		result.Message = fmt.Sprintf("Failed to parse %s response: %v", test.Backend, err)
+		result.Duration = time.Since(start)
+		return result
+	}
+
+	result.Details["registered"] = registered
+	result.Details["healthy"] = healthy
+
+	if registered == 0 {
+		result.Status = core.StatusFail
+		result.Message = fmt.Sprintf("Service %s is not registered in %s", test.Service, test.Backend)
+		result.Duration = time.Since(start)
+		return result
+	}
+
+	if healthy < test.MinHealthy {
+		result.Status = core.StatusFail
+		result.Message = fmt.Sprintf("Service %s has %d healthy instances, expected at least %d (%d registered)", test.Service, healthy, test.MinHealthy, registered)
+		result.Duration = time.Since(start)
+		return result
+	}
+
+	result.Message = fmt.Sprintf("Service %s has %d healthy instances in %s", test.Service, healthy, test.Backend)
+	result.Duration = time.Since(start)
+	return result
+}
+
+// countConsulInstances returns the number of registered and healthy instances
+// in a Consul health response. An instance is healthy when all its checks pass.
+func countConsulInstances(body string) (registered, healthy int, err error) {
+	var entries []consulHealthEntry
+	if err := json.Unmarshal([]byte(body), &entries); err != nil {
+		return 0, 0, err
+	}
+
+	for _, entry := range entries {
+		passing := true
+		for _, check := range entry.Checks {
+			if check.Status != "passing" {
+				passing = false
+				break
+			}
+		}
+		if passing {
+			healthy++
+		}
+	}
+	return len(entries), healthy, nil
+}
+
+// etcdRangeRequest builds a count-only range request for all keys under prefix
+// using etcd's v3 JSON gateway
+func etcdRangeRequest(address, prefix string) curlRequest {
+	// The range end is the prefix with its last byte incremented
+	rangeEnd := []byte(prefix)
+	rangeEnd[len(rangeEnd)-1]++
+
+	body, _ := json.Marshal(map[string]interface{}{
+		"key":        base64.StdEncoding.EncodeToString([]byte(prefix)),
+		"range_end":  base64.StdEncoding.EncodeToString(rangeEnd),
+		"count_only": true,
+	})
+	return curlRequest{
+		Method:  "POST",
+		URL:     strings.TrimRight(address, "/") + "/v3/kv/range",
+		Body:    string(body),
+		Headers: []string{"Content-Type: application/json"},
+	}
+}
+
+// parseEtcdCount returns the key count from an etcd range response.
+// The gateway encodes int64 values as strings and omits zero values.
+func parseEtcdCount(body string) (int, error) {
+	var resp struct {
+		Count json.RawMessage `json:"count"`
+	}
+	if err := json.Unmarshal([]byte(body), &resp); err != nil {
+		return 0, err
+	}
+	if len(resp.Count) == 0 {
+		return 0, nil
+	}
+	return strconv.Atoi(strings.Trim(string(resp.Count), `"`))
+}
@@ -0,0 +1,170 @@
+package system
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os/exec"
+	"testing"
+
+	"github.com/neilfarmer/platform-spec/pkg/core"
+	"github.com/neilfarmer/platform-spec/pkg/providers/local"
+)
+
+// consulHealthJSON builds a Consul /v1/health/service response with the given check status per instance
+func consulHealthJSON(service string, statuses ...string) string {
+	var entries []map[string]interface{}
+	for i, status := range statuses {
+		entries = append(entries, map[string]interface{}{
+			"Node":    map[string]interface{}{"Node": fmt.Sprintf("node-%d", i)},
+			"Service": map[string]interface{}{"ID": fmt.Sprintf("%s-%d", service, i), "Service": service},
+			"Checks": []map[string]interface{}{
+				{"CheckID": "serfHealth", "Status": "passing"},
+				{"CheckID": "service:" + service, "Status": status},
+			},
+		})
+	}
+	if entries == nil {
+		return "[]"
+	}
+	body, _ := json.Marshal(entries)
+	return string(body)
+}
+
+func TestExecutor_ServiceRegistryTest_Consul(t *testing.T) {
+	if _, err := exec.LookPath("curl"); err != nil {
+		t.Skip("curl not available")
+	}
+
+	responses := map[string]string{
+		"api":    consulHealthJSON("api", "passing", "passing", "critical"),
+		"worker": consulHealthJSON("worker", "critical", "warning"),
+		"ghost":  consulHealthJSON("ghost"),
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, ok := responses[r.URL.Path[len("/v1/health/service/"):]]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, body)
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name         string
+		registryTest core.ServiceRegistryTest
+		wantStatus   core.Status
+		wantContains string
+	}{
+		{
+			name:         "enough healthy instances",
+			registryTest: core.ServiceRegistryTest{Name: "api", Backend: "consul", Service: "api", MinHealthy: 2},
+			wantStatus:   core.StatusPass,
+			wantContains: "has 2 healthy instances",
+		},
+		{
+			name:         "too few healthy instances",
+			registryTest: core.ServiceRegistryTest{Name: "api", Backend: "consul", Service: "api", MinHealthy: 3},
+			wantStatus:   core.StatusFail,
+			wantContains: "has 2 healthy instances, expected at least 3 (3 registered)",
+		},
+		{
+			name:         "all instances unhealthy",
+			registryTest: core.ServiceRegistryTest{Name: "worker", Backend: "consul", Service: "worker", MinHealthy: 1},
+			wantStatus:   core.StatusFail,
+			wantContains: "has 0 healthy instances",
+		},
+		{
+			name:         "service not registered",
+			registryTest: core.ServiceRegistryTest{Name: "ghost", Backend: "consul", Service: "ghost", MinHealthy: 1},
+			wantStatus:   core.StatusFail,
+			wantContains: "is not registered in consul",
+		},
+	}
+
+	provider := local.NewProvider()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.registryTest.Address = server.URL
+
+			result := executeServiceRegistryTest(context.Background(), provider, tt.registryTest)
+
+			if result.Status != tt.wantStatus {
+				t.Errorf("Status = %v, want %v (message: %s)", result.Status, tt.wantStatus, result.Message)
+			}
+			if tt.wantContains != "" && !contains(result.Message, tt.wantContains) {
+				t.Errorf("Message %q does not contain %q", result.Message, tt.wantContains)
+			}
+		})
+	}
+}
+
+func TestExecutor_ServiceRegistryTest_Etcd(t *testing.T) {
+	tests := []struct {
+		name         string
+		registryTest core.ServiceRegistryTest
+		setupMock    func(*core.MockProvider)
+		wantStatus   core.Status
+		wantContains string
+	}{
+		{
+			name:         "registered instances counted",
+			registryTest: core.ServiceRegistryTest{Name: "api", Backend: "etcd", Service: "api", MinHealthy: 2, Address: "http://127.0.0.1:2379"},
+			setupMock: func(m *core.MockProvider) {
+				m.SetCommandResult(curlCommand(etcdRangeRequest("http://127.0.0.1:2379", "/services/api/")), `{"header":{"revision":"7"},"count":"2"}`+"\n200", "", 0, nil)
+			},
+			wantStatus:   core.StatusPass,
+			wantContains: "has 2 healthy instances in etcd",
+		},
+		{
+			name:         "no keys under prefix",
+			registryTest: core.ServiceRegistryTest{Name: "api", Backend: "etcd", Service: "api", MinHealthy: 1, Address: "http://127.0.0.1:2379"},
+			setupMock: func(m *core.MockProvider) {
+				m.SetCommandResult(curlCommand(etcdRangeRequest("http://127.0.0.1:2379", "/services/api/")), `{"header":{"revision":"7"}}`+"\n200", "", 0, nil)
+			},
+			wantStatus:   core.StatusFail,
+			wantContains: "is not registered in etcd",
+		},
+		{
+			name:         "registry unreachable",
+			registryTest: core.ServiceRegistryTest{Name: "api", Backend: "etcd", Service: "api", MinHealthy: 1, Address: "http://127.0.0.1:2379"},
+			setupMock: func(m *core.MockProvider) {
+				m.SetCommandResult(curlCommand(etcdRangeRequest("http://127.0.0.1:2379", "/services/api/")), "", "curl: (7) Failed to connect", 7, nil)
+			},
+			wantStatus:   core.StatusFail,
+			wantContains: "is not reachable",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := core.NewMockProvider()
+			tt.setupMock(mock)
+
+			result := executeServiceRegistryTest(context.Background(), mock, tt.registryTest)
+
+			if result.Status != tt.wantStatus {
+				t.Errorf("Status = %v, want %v (message: %s)", result.Status, tt.wantStatus, result.Message)
+			}
+			if tt.wantContains != "" && !contains(result.Message, tt.wantContains) {
+				t.Errorf("Message %q does not contain %q", result.Message, tt.wantContains)
+			}
+		})
+	}
+}
+
+func TestEtcdRangeRequest(t *testing.T) {
+	req := etcdRangeRequest("http://etcd:2379/", "/services/api/")
+
+	if req.URL != "http://etcd:2379/v3/kv/range" {
+		t.Errorf("URL = %q, want http://etcd:2379/v3/kv/range", req.URL)
+	}
+	// "/services/api/" and "/services/api0" base64-encoded
+	if !contains(req.Body, `"key":"L3NlcnZpY2VzL2FwaS8="`) || !contains(req.Body, `"range_end":"L3NlcnZpY2VzL2FwaTA="`) {
+		t.Errorf("Body = %s, want prefix range for /services/api/", req.Body)
+	}
+}
@@ -123,3 +123,161 @@ func TestExecutor_ServiceTest(t *testing.T) {
 		})
 	}
 }
+
+func TestExecutor_ServiceTest_Darwin(t *testing.T) {
+	const sshdRunning = `system/com.openssh.sshd = {
+	active count = 1
+	path = /System/Library/LaunchDaemons/ssh.plist
+	type = LaunchDaemon
+	state = running
+
+	program = /usr/libexec/sshd-keygen-wrapper
+	pid = 412
+	event triggers = {
+		com.openssh.sshd.DEF0 => {
+			state = 1
+		}
+	}
+}`
+	const nginxStopped = `system/homebrew.mxcl.nginx = {
+	active count = 0
+	path = /Library/LaunchDaemons/homebrew.mxcl.nginx.plist
+	type = LaunchDaemon
+	state = not running
+
+	program = /opt/homebrew/opt/nginx/bin/nginx
+	last exit code = 0
+}`
+	const disabledServices = `disabled services = {
+	"com.apple.ftpd" => disabled
+	"com.openssh.sshd" => enabled
+	"homebrew.mxcl.nginx" => disabled
+}`
+
+	tests := []struct {
+		name         string
+		serviceTest  core.ServiceTest
+		setupMock    func(*core.MockProvider)
+		wantStatus   core.Status
+		wantContains string
+	}{
+		{
+			name: "loaded running service",
+			serviceTest: core.ServiceTest{
+				Name:    "sshd running",
+				Service: "com.openssh.sshd",
+				State:   "running",
+				Enabled: true,
+			},
+			setupMock: func(m *core.MockProvider) {
+				m.SetCommandResult("launchctl print 'system/com.openssh.sshd' 2>/dev/null", sshdRunning, "", 0, nil)
+			},
+			wantStatus:   core.StatusPass,
+			wantContains: "running and enabled",
+		},
+		{
+			name: "stopped service expected running",
+			serviceTest: core.ServiceTest{
+				Name:    "nginx running",
+				Service: "homebrew.mxcl.nginx",
+				State:   "running",
+			},
+			setupMock: func(m *core.MockProvider) {
+				m.SetCommandResult("launchctl print 'system/homebrew.mxcl.nginx' 2>/dev/null", nginxStopped, "", 0, nil)
+			},
+			wantStatus:   core.StatusFail,
+			wantContains: "not running",
+		},
+		{
+			name: "stopped and disabled service",
+			serviceTest: core.ServiceTest{
+				Name:    "nginx stopped",
+				Service: "homebrew.mxcl.nginx",
+				State:   "stopped",
+			},
+			setupMock: func(m *core.MockProvider) {
+				m.SetCommandResult("launchctl print 'system/homebrew.mxcl.nginx' 2>/dev/null", nginxStopped, "", 0, nil)
+			},
+			wantStatus:   core.StatusPass,
+			wantContains: "stopped",
+		},
+		{
+			name: "falls back to launchctl list",
+			serviceTest: core.ServiceTest{
+				Name:    "sshd running",
+				Service: "com.openssh.sshd",
+				State:   "running",
+			},
+			setupMock: func(m *core.MockProvider) {
+				m.SetCommandResult("launchctl print 'system/com.openssh.sshd' 2>/dev/null", "", "", 113, nil)
+				m.SetCommandResult("launchctl list 'com.openssh.sshd' 2>/dev/null", "{\n\t\"Label\" = \"com.openssh.sshd\";\n\t\"PID\" = 412;\n};", "", 0, nil)
+			},
+			wantStatus:   core.StatusPass,
+			wantContains: "running",
+		},
+		{
+			name: "service not loaded",
+			serviceTest: core.ServiceTest{
+				Name:    "redis running",
+				Service: "homebrew.mxcl.redis",
+				State:   "running",
+			},
+			setupMock: func(m *core.MockProvider) {
+				m.SetCommandResult("launchctl print 'system/homebrew.mxcl.redis' 2>/dev/null", "", "Could not find service", 113, nil)
+				m.SetCommandResult("launchctl list 'homebrew.mxcl.redis' 2>/dev/null", "", "Could not find service", 113, nil)
+			},
+			wantStatus:   core.StatusFail,
+			wantContains: "not running",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := core.NewMockProvider()
+			mock.SetCommandResult("uname -sm 2>/dev/null", "Darwin arm64\n", "", 0, nil)
+			mock.SetCommandResult("launchctl print-disabled system 2>/dev/null", disabledServices, "", 0, nil)
+			tt.setupMock(mock)
+
+			ctx := context.Background()
+			ctx = core.WithFacts(ctx, core.GatherFacts(ctx, mock))
+
+			result := executeServiceTest(ctx, mock, tt.serviceTest)
+
+			if result.Status != tt.wantStatus {
+				t.Errorf("Status = %v, want %v (message: %s)", result.Status, tt.wantStatus, result.Message)
+			}
+			if tt.wantContains != "" && !contains(result.Message, tt.wantContains) {
+				t.Errorf("Message %q does not contain %q", result.Message, tt.wantContains)
+			}
+		})
+	}
+}
+
+func TestLaunchdDisabled(t *testing.T) {
+	output := `disabled services = {
+	"com.apple.ftpd" => true
+	"com.openssh.sshd" => false
+}`
+	if !launchdDisabled(output, "com.apple.ftpd") {
+		t.Error("com.apple.ftpd should be disabled")
+	}
+	if launchdDisabled(output, "com.openssh.sshd") {
+		t.Error("com.openssh.sshd should not be disabled")
+	}
+	if launchdDisabled(output, "com.example.unknown") {
+		t.Error("unlisted jobs should not be disabled")
+	}
+}
+
+func TestExecutor_ServiceTest_FreeBSD(t *testing.T) {
+	mock := core.NewMockProvider()
+	mock.SetCommandResult("service 'nginx' onestatus >/dev/null 2>&1", "", "", 0, nil)
+	mock.SetCommandResult("sysrc -n 'nginx_enable' 2>/dev/null", "YES\n", "", 0, nil)
+
+	ctx := core.WithFacts(context.Background(), &core.Facts{OS: core.OSFreeBSD})
+	result := executeServiceTest(ctx, mock, core.ServiceTest{Name: "nginx", Service: "nginx", State: "running", Enabled: true})
+
+	if result.Status != core.StatusPass {
+		t.Errorf("Status = %v, want %v (message: %s)", result.Status, core.StatusPass, result.Message)
+	}
+}
@@ -0,0 +1,84 @@
+package system
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/neilfarmer/platform-spec/pkg/core"
+)
+
+// sshdEffectiveConfigCommand prints the effective sshd configuration.
+// sshd usually lives in sbin, which is not on PATH for unprivileged users.
+const sshdEffectiveConfigCommand = `PATH="$PATH:/usr/sbin:/sbin" sshd -T`
+
+// executeSSHConfigTest executes an effective sshd configuration test
+func executeSSHConfigTest(ctx context.Context, provider core.Provider, test core.SSHConfigTest) core.Result {
+	start := time.Now()
+	result := core.Result{
+		Name:    test.Name,
+		Status:  core.StatusPass,
+		Details: make(map[string]interface{}),
+	}
+
+	stdout, stderr, exitCode, err := provider.ExecuteCommand(ctx, sshdEffectiveConfigCommand)
+	if err != nil {
+		result.Status = core.StatusError
+		result.Message = fmt.Sprintf("Error running sshd -T: %v", err)
+		result.Duration = time.Since(start)
+		return result
+	}
+	if exitCode == 127 {
+		result.Status = core.StatusError
+		result.Message = "sshd is not installed on the target"
+		result.Duration = time.Since(start)
+		return result
+	}
+	if exitCode != 0 {
+		result.Status = core.StatusError
+		result.Message = fmt.Sprintf("sshd -T failed (usually requires root): %s", strings.TrimSpace(stderr))
+		result.Duration = time.Since(start)
+		return result
+	}
+
+	setting := strings.ToLower(strings.TrimSpace(test.Setting))
+	expected := strings.Join(strings.Fields(test.Value), " ")
+	values := parseSSHDConfig(stdout)[setting]
+	result.Details["values"] = values
+
+	if len(values) == 0 {
+		result.Status = core.StatusFail
+		result.Message = fmt.Sprintf("sshd setting %s is not present in the effective configuration", setting)
+		result.Duration = time.Since(start)
+		return result
+	}
+
+	// Keywords such as hostkey or acceptenv can appear several times
+	for _, v := range values {
+		if strings.EqualFold(v, expected) {
+			result.Message = fmt.Sprintf("sshd %s is %s", setting, v)
+			result.Duration = time.Since(start)
+			return result
+		}
+	}
+
+	result.Status = core.StatusFail
+	result.Message = fmt.Sprintf("sshd %s is %s, expected %s", setting, strings.Join(values, ", "), expected)
+	result.Duration = time.Since(start)
+	return result
+}
+
+// parseSSHDConfig parses `sshd -T` output into lowercase keywords and their values
+func parseSSHDConfig(output string) map[string][]string {
+	config := make(map[string][]string)
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		key := strings.ToLower(fields[0])
+		config[key] = append(config[key], strings.Join(fields[1:], " "))
+	}
+	return config
+}
@@ -0,0 +1,92 @@
+package system
+
+import (
+	"context"
+	"testing"
+
+	"github.com/neilfarmer/platform-spec/pkg/core"
+)
+
+func TestExecutor_SSHConfigTest(t *testing.T) {
+	const compliant = `port 22
+addressfamily any
+permitrootlogin no
+passwordauthentication no
+hostkey /etc/ssh/ssh_host_ed25519_key
+hostkey /etc/ssh/ssh_host_rsa_key
+ciphers chacha20-poly1305@openssh.com,aes256-gcm@openssh.com
+`
+	const nonCompliant = `port 22
+permitrootlogin yes
+passwordauthentication yes
+`
+
+	tests := []struct {
+		name         string
+		sshTest      core.SSHConfigTest
+		stdout       string
+		stderr       string
+		exitCode     int
+		wantStatus   core.Status
+		wantContains string
+	}{
+		{
+			name:         "root login disabled",
+			sshTest:      core.SSHConfigTest{Name: "No root login", Setting: "PermitRootLogin", Value: "no"},
+			stdout:       compliant,
+			wantStatus:   core.StatusPass,
+			wantContains: "permitrootlogin is no",
+		},
+		{
+			name:         "root login enabled",
+			sshTest:      core.SSHConfigTest{Name: "No root login", Setting: "PermitRootLogin", Value: "no"},
+			stdout:       nonCompliant,
+			wantStatus:   core.StatusFail,
+			wantContains: "permitrootlogin is yes, expected no",
+		},
+		{
+			name:         "password authentication enabled",
+			sshTest:      core.SSHConfigTest{Name: "Keys only", Setting: "passwordauthentication", Value: "NO"},
+			stdout:       nonCompliant,
+			wantStatus:   core.StatusFail,
+			wantContains: "expected NO",
+		},
+		{
+			name:       "repeated keyword matches any value",
+			sshTest:    core.SSHConfigTest{Name: "RSA host key", Setting: "HostKey", Value: "/etc/ssh/ssh_host_rsa_key"},
+			stdout:     compliant,
+			wantStatus: core.StatusPass,
+		},
+		{
+			name:         "setting not present",
+			sshTest:      core.SSHConfigTest{Name: "Banner", Setting: "Banner", Value: "/etc/issue.net"},
+			stdout:       compliant,
+			wantStatus:   core.StatusFail,
+			wantContains: "not present",
+		},
+		{
+			name:         "sshd -T requires root",
+			sshTest:      core.SSHConfigTest{Name: "No root login", Setting: "PermitRootLogin", Value: "no"},
+			stderr:       "sshd: no hostkeys available -- exiting.",
+			exitCode:     1,
+			wantStatus:   core.StatusError,
+			wantContains: "no hostkeys available",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := core.NewMockProvider()
+			mock.SetCommandResult(sshdEffectiveConfigCommand, tt.stdout, tt.stderr, tt.exitCode, nil)
+
+			result := executeSSHConfigTest(context.Background(), mock, tt.sshTest)
+
+			if result.Status != tt.wantStatus {
+				t.Errorf("Status = %v, want %v (message: %s)", result.Status, tt.wantStatus, result.Message)
+			}
+			if tt.wantContains != "" && !contains(result.Message, tt.wantContains) {
+				t.Errorf("Message %q does not contain %q", result.Message, tt.wantContains)
+			}
+		})
+	}
+}
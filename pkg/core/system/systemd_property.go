@@ -0,0 +1,113 @@
+package system
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/neilfarmer/platform-spec/pkg/core"
+)
+
+// executeSystemdPropertyTest executes a systemd unit property test
+func executeSystemdPropertyTest(ctx context.Context, provider core.Provider, test core.SystemdPropertyTest) core.Result {
+	start := time.Now()
+	result := core.Result{
+		Name:    test.Name,
+		Status:  core.StatusPass,
+		Details: make(map[string]interface{}),
+	}
+
+	// systemctl show succeeds for unknown units, so check the load state first
+	loadState, err := systemdShow(ctx, provider, test.Unit, "LoadState")
+	if err != nil {
+		result.Status = core.StatusError
+		result.Message = fmt.Sprintf("Error querying unit %s: %v", test.Unit, err)
+		result.Duration = time.Since(start)
+		return result
+	}
+	if loadState == "not-found" {
+		result.Status = core.StatusFail
+		result.Message = fmt.Sprintf("Unit %s does not exist", test.Unit)
+		result.Duration = time.Since(start)
+		return result
+	}
+
+	props := make([]string, 0, len(test.Properties))
+	for prop := range test.Properties {
+		props = append(props, prop)
+	}
+	sort.Strings(props)
+
+	for _, prop := range props {
+		expected := test.Properties[prop]
+		actual, err := systemdShow(ctx, provider, test.Unit, prop)
+		if err != nil {
+			result.Status = core.StatusError
+			result.Message = fmt.Sprintf("Error reading %s of unit %s: %v", prop, test.Unit, err)
+			result.Duration = time.Since(start)
+			return result
+		}
+		result.Details[prop] = actual
+
+		if !systemdValuesEqual(actual, expected) {
+			result.Status = core.StatusFail
+			result.Message = fmt.Sprintf("Unit %s has %s=%s, expected %s", test.Unit, prop, actual, expected)
+			result.Duration = time.Since(start)
+			return result
+		}
+	}
+
+	result.Message = fmt.Sprintf("Unit %s has all %d expected properties", test.Unit, len(props))
+	result.Duration = time.Since(start)
+	return result
+}
+
+// systemdShow returns the value of a single unit property
+func systemdShow(ctx context.Context, provider core.Provider, unit, prop string) (string, error) {
+	cmd := fmt.Sprintf("systemctl show %s -p %s", core.ShellQuote(unit), prop)
+	stdout, stderr, exitCode, err := provider.ExecuteCommand(ctx, cmd)
+	if err != nil {
+		return "", err
+	}
+	if exitCode != 0 {
+		return "", fmt.Errorf("systemctl show failed: %s", strings.TrimSpace(stderr))
+	}
+
+	line := strings.TrimSpace(stdout)
+	value, ok := strings.CutPrefix(line, prop+"=")
+	if !ok {
+		return "", fmt.Errorf("unexpected systemctl output: %s", line)
+	}
+	return value, nil
+}
+
+// systemdValuesEqual compares a property value with its expectation. Sizes such
+// as MemoryMax are shown in bytes, so "2G" matches "2147483648".
+func systemdValuesEqual(actual, expected string) bool {
+	if actual == expected {
+		return true
+	}
+	a, aok := parseSystemdSize(actual)
+	e, eok := parseSystemdSize(expected)
+	return aok && eok && a == e
+}
+
+// parseSystemdSize parses a byte size with an optional K, M, G, T, P or E
+// suffix (base 1024), as accepted by systemd resource control settings
+func parseSystemdSize(s string) (uint64, bool) {
+	multiplier := uint64(1)
+	if n := len(s); n > 0 {
+		if i := strings.IndexByte("KMGTPE", s[n-1]); i >= 0 {
+			multiplier = 1 << (10 * (i + 1))
+			s = s[:n-1]
+		}
+	}
+	v, err := strconv.ParseUint(s, 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	return v * multiplier, true
+}
@@ -0,0 +1,109 @@
+package system
+
+import (
+	"context"
+	"testing"
+
+	"github.com/neilfarmer/platform-spec/pkg/core"
+)
+
+func TestExecutor_SystemdPropertyTest(t *testing.T) {
+	tests := []struct {
+		name         string
+		propTest     core.SystemdPropertyTest
+		setupMock    func(*core.MockProvider)
+		wantStatus   core.Status
+		wantContains string
+	}{
+		{
+			name: "all properties match",
+			propTest: core.SystemdPropertyTest{
+				Name: "App unit hardening",
+				Unit: "app.service",
+				Properties: map[string]string{
+					"Restart":   "always",
+					"MemoryMax": "2G",
+					"User":      "app",
+				},
+			},
+			setupMock: func(m *core.MockProvider) {
+				m.SetCommandResult("systemctl show 'app.service' -p LoadState", "LoadState=loaded\n", "", 0, nil)
+				m.SetCommandResult("systemctl show 'app.service' -p Restart", "Restart=always\n", "", 0, nil)
+				m.SetCommandResult("systemctl show 'app.service' -p MemoryMax", "MemoryMax=2147483648\n", "", 0, nil)
+				m.SetCommandResult("systemctl show 'app.service' -p User", "User=app\n", "", 0, nil)
+			},
+			wantStatus:   core.StatusPass,
+			wantContains: "has all 3 expected properties",
+		},
+		{
+			name: "mismatched property",
+			propTest: core.SystemdPropertyTest{
+				Name:       "App restarts",
+				Unit:       "app.service",
+				Properties: map[string]string{"Restart": "always"},
+			},
+			setupMock: func(m *core.MockProvider) {
+				m.SetCommandResult("systemctl show 'app.service' -p LoadState", "LoadState=loaded\n", "", 0, nil)
+				m.SetCommandResult("systemctl show 'app.service' -p Restart", "Restart=no\n", "", 0, nil)
+			},
+			wantStatus:   core.StatusFail,
+			wantContains: "has Restart=no, expected always",
+		},
+		{
+			name: "unlimited memory does not match size",
+			propTest: core.SystemdPropertyTest{
+				Name:       "App memory limit",
+				Unit:       "app.service",
+				Properties: map[string]string{"MemoryMax": "512M"},
+			},
+			setupMock: func(m *core.MockProvider) {
+				m.SetCommandResult("systemctl show 'app.service' -p LoadState", "LoadState=loaded\n", "", 0, nil)
+				m.SetCommandResult("systemctl show 'app.service' -p MemoryMax", "MemoryMax=infinity\n", "", 0, nil)
+			},
+			wantStatus:   core.StatusFail,
+			wantContains: "has MemoryMax=infinity, expected 512M",
+		},
+		{
+			name: "unit not found",
+			propTest: core.SystemdPropertyTest{
+				Name:       "Missing unit",
+				Unit:       "missing.service",
+				Properties: map[string]string{"Restart": "always"},
+			},
+			setupMock: func(m *core.MockProvider) {
+				m.SetCommandResult("systemctl show 'missing.service' -p LoadState", "LoadState=not-found\n", "", 0, nil)
+			},
+			wantStatus:   core.StatusFail,
+			wantContains: "does not exist",
+		},
+		{
+			name: "systemctl unavailable",
+			propTest: core.SystemdPropertyTest{
+				Name:       "App restarts",
+				Unit:       "app.service",
+				Properties: map[string]string{"Restart": "always"},
+			},
+			setupMock: func(m *core.MockProvider) {
+				m.SetCommandResult("systemctl show 'app.service' -p LoadState", "", "System has not been booted with systemd", 1, nil)
+			},
+			wantStatus:   core.StatusError,
+			wantContains: "systemctl show failed",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := core.NewMockProvider()
+			tt.setupMock(mock)
+
+			result := executeSystemdPropertyTest(context.Background(), mock, tt.propTest)
+
+			if result.Status != tt.wantStatus {
+				t.Errorf("Status = %v, want %v (message: %s)", result.Status, tt.wantStatus, result.Message)
+			}
+			if tt.wantContains != "" && !contains(result.Message, tt.wantContains) {
+				t.Errorf("Message %q does not contain %q", result.Message, tt.wantContains)
+			}
+		})
+	}
+}
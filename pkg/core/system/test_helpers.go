@@ -6,3 +6,13 @@ import "strings"
 func contains(s, substr string) bool {
 	return strings.Contains(s, substr)
 }
+
+// boolPtr returns a pointer to b (test helper)
+func boolPtr(b bool) *bool {
+	return &b
+}
+
+// intPtr returns a pointer to n (test helper)
+func intPtr(n int) *int {
+	return &n
+}
@@ -0,0 +1,108 @@
+package system
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/neilfarmer/platform-spec/pkg/core"
+)
+
+// vaultHealthQuery makes /v1/sys/health return 200 for every node state so the
+// state can be read from the response body instead of the status code
+const vaultHealthQuery = "standbyok=true&perfstandbyok=true&sealedcode=200&uninitcode=200&drsecondarycode=200"
+
+// vaultHealth is the response from Vault's /v1/sys/health endpoint
+type vaultHealth struct {
+	Initialized bool   `json:"initialized"`
+	Sealed      bool   `json:"sealed"`
+	Standby     bool   `json:"standby"`
+	Version     string `json:"version"`
+}
+
+// executeVaultTest executes a Vault seal-status/health test
+func executeVaultTest(ctx context.Context, provider core.Provider, test core.VaultTest) core.Result {
+	start := time.Now()
+	result := core.Result{
+		Name:    test.Name,
+		Status:  core.StatusPass,
+		Details: make(map[string]interface{}),
+	}
+
+	resp, err := runCurl(ctx, provider, curlRequest{
+		URL:      fmt.Sprintf("%s/v1/sys/health?%s", strings.TrimRight(test.Address, "/"), vaultHealthQuery),
+		Insecure: test.Insecure,
+		CACert:   test.CACert,
+	})
+	if err != nil {
+		result.Status = core.StatusError
+		result.Message = fmt.Sprintf("Error querying Vault: %v", err)
+		result.Duration = time.Since(start)
+		return result
+	}
+	if resp.ExitCode != 0 {
+		result.Status = core.StatusFail
+		result.Message = fmt.Sprintf("Vault at %s is not reachable: %s", test.Address, resp.Stderr)
+		result.Duration = time.Since(start)
+		return result
+	}
+	if resp.StatusCode != 200 {
+		result.Status = core.StatusFail
+		result.Message = fmt.Sprintf("Vault health endpoint returned status %d", resp.StatusCode)
+		result.Duration = time.Since(start)
+		return result
+	}
+
+	var health vaultHealth
+	if err := json.Unmarshal([]byte(resp.Body), &health); err != nil {
+		result.Status = core.StatusError
+		result.Message = fmt.Sprintf("Failed to parse Vault health response: %v", err)
+		result.Duration = time.Since(start)
+		return result
+	}
+
+	result.Details["initialized"] = health.Initialized
+	result.Details["sealed"] = health.Sealed
+	result.Details["standby"] = health.Standby
+	result.Details["version"] = health.Version
+
+	// Check initialized
+	if test.ExpectInitialized && !health.Initialized {
+		result.Status = core.StatusFail
+		result.Message = fmt.Sprintf("Vault at %s is not initialized", test.Address)
+		result.Duration = time.Since(start)
+		return result
+	}
+
+	// Check seal status
+	if test.ExpectUnsealed && health.Sealed {
+		result.Status = core.StatusFail
+		result.Message = fmt.Sprintf("Vault at %s is sealed", test.Address)
+		result.Duration = time.Since(start)
+		return result
+	}
+
+	// Check active/standby
+	if test.ExpectActive && health.Standby {
+		result.Status = core.StatusFail
+		result.Message = fmt.Sprintf("Vault at %s is a standby node, expected active", test.Address)
+		result.Duration = time.Since(start)
+		return result
+	}
+
+	state := "unsealed"
+	if health.Sealed {
+		state = "sealed"
+	}
+	if !health.Initialized {
+		state = "uninitialized"
+	}
+	if health.Standby {
+		state += " standby"
+	}
+	result.Message = fmt.Sprintf("Vault at %s is %s", test.Address, state)
+	result.Duration = time.Since(start)
+	return result
+}
@@ -0,0 +1,171 @@
+package system
+
+import (
+	"context"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/neilfarmer/platform-spec/pkg/core"
+	"github.com/neilfarmer/platform-spec/pkg/providers/local"
+)
+
+// vaultHealthHandler serves a Vault /v1/sys/health response, honouring the
+// status code overrides Vault supports so the test exercises the real query
+func vaultHealthHandler(initialized, sealed, standby bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/sys/health" {
+			http.NotFound(w, r)
+			return
+		}
+		status := http.StatusOK
+		switch {
+		case !initialized:
+			status = 501
+			if r.URL.Query().Get("uninitcode") == "200" {
+				status = http.StatusOK
+			}
+		case sealed:
+			status = 503
+			if r.URL.Query().Get("sealedcode") == "200" {
+				status = http.StatusOK
+			}
+		case standby:
+			status = 429
+			if r.URL.Query().Get("standbyok") == "true" {
+				status = http.StatusOK
+			}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		fmt.Fprintf(w, `{"initialized":%t,"sealed":%t,"standby":%t,"performance_standby":false,"version":"1.15.4","cluster_name":"vault-cluster"}`, initialized, sealed, standby)
+	}
+}
+
+func TestExecutor_VaultTest(t *testing.T) {
+	if _, err := exec.LookPath("curl"); err != nil {
+		t.Skip("curl not available")
+	}
+
+	tests := []struct {
+		name         string
+		handler      http.HandlerFunc
+		vaultTest    core.VaultTest
+		wantStatus   core.Status
+		wantContains string
+	}{
+		{
+			name:         "unsealed active node",
+			handler:      vaultHealthHandler(true, false, false),
+			vaultTest:    core.VaultTest{Name: "vault", ExpectUnsealed: true, ExpectInitialized: true, ExpectActive: true},
+			wantStatus:   core.StatusPass,
+			wantContains: "is unsealed",
+		},
+		{
+			name:         "sealed node",
+			handler:      vaultHealthHandler(true, true, false),
+			vaultTest:    core.VaultTest{Name: "vault", ExpectUnsealed: true, ExpectInitialized: true},
+			wantStatus:   core.StatusFail,
+			wantContains: "is sealed",
+		},
+		{
+			name:         "sealed node without unseal expectation",
+			handler:      vaultHealthHandler(true, true, false),
+			vaultTest:    core.VaultTest{Name: "vault", ExpectInitialized: true},
+			wantStatus:   core.StatusPass,
+			wantContains: "is sealed",
+		},
+		{
+			name:         "uninitialized node",
+			handler:      vaultHealthHandler(false, true, false),
+			vaultTest:    core.VaultTest{Name: "vault", ExpectInitialized: true},
+			wantStatus:   core.StatusFail,
+			wantContains: "is not initialized",
+		},
+		{
+			name:         "standby node accepted",
+			handler:      vaultHealthHandler(true, false, true),
+			vaultTest:    core.VaultTest{Name: "vault", ExpectUnsealed: true},
+			wantStatus:   core.StatusPass,
+			wantContains: "is unsealed standby",
+		},
+		{
+			name:         "standby node when active expected",
+			handler:      vaultHealthHandler(true, false, true),
+			vaultTest:    core.VaultTest{Name: "vault", ExpectUnsealed: true, ExpectActive: true},
+			wantStatus:   core.StatusFail,
+			wantContains: "is a standby node",
+		},
+	}
+
+	provider := local.NewProvider()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(tt.handler)
+			defer server.Close()
+			tt.vaultTest.Address = server.URL
+
+			result := executeVaultTest(context.Background(), provider, tt.vaultTest)
+
+			if result.Status != tt.wantStatus {
+				t.Errorf("Status = %v, want %v (message: %s)", result.Status, tt.wantStatus, result.Message)
+			}
+			if tt.wantContains != "" && !contains(result.Message, tt.wantContains) {
+				t.Errorf("Message %q does not contain %q", result.Message, tt.wantContains)
+			}
+		})
+	}
+}
+
+func TestExecutor_VaultTest_TLS(t *testing.T) {
+	if _, err := exec.LookPath("curl"); err != nil {
+		t.Skip("curl not available")
+	}
+
+	server := httptest.NewTLSServer(vaultHealthHandler(true, false, false))
+	defer server.Close()
+
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	if err := os.WriteFile(caFile, caPEM, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name       string
+		vaultTest  core.VaultTest
+		wantStatus core.Status
+	}{
+		{
+			name:       "untrusted certificate",
+			vaultTest:  core.VaultTest{Name: "vault", Address: server.URL, ExpectUnsealed: true},
+			wantStatus: core.StatusFail,
+		},
+		{
+			name:       "insecure",
+			vaultTest:  core.VaultTest{Name: "vault", Address: server.URL, ExpectUnsealed: true, Insecure: true},
+			wantStatus: core.StatusPass,
+		},
+		{
+			name:       "custom CA",
+			vaultTest:  core.VaultTest{Name: "vault", Address: server.URL, ExpectUnsealed: true, CACert: caFile},
+			wantStatus: core.StatusPass,
+		},
+	}
+
+	provider := local.NewProvider()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := executeVaultTest(context.Background(), provider, tt.vaultTest)
+
+			if result.Status != tt.wantStatus {
+				t.Errorf("Status = %v, want %v (message: %s)", result.Status, tt.wantStatus, result.Message)
+			}
+		})
+	}
+}
@@ -0,0 +1,293 @@
+package system
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/neilfarmer/platform-spec/pkg/core"
+)
+
+// executeWindows runs the subset of system tests supported on Windows targets.
+// Files, services, ports and command tests (content, JSON, metrics) run
+// natively via PowerShell; all other test types are skipped.
+func (p *SystemPlugin) executeWindows(ctx context.Context, spec *core.Spec, provider core.Provider, failFast bool) ([]core.Result, bool) {
+	var results []core.Result
+
+	results = append(results, skipOnWindows(spec)...)
+
+	// Execute file tests
+	for _, test := range spec.Tests.Files {
+		result := core.RunTest(ctx, test.TestOptions, func(ctx context.Context) core.Result {
+			return executeFileTest(ctx, provider, test)
+		})
+		results = append(results, result)
+		if failFast && result.Status == core.StatusFail && !test.ContinueOnFailure {
+			return results, true
+		}
+	}
+
+	// Execute service tests
+	for _, test := range spec.Tests.Services {
+		result := core.RunTest(ctx, test.TestOptions, func(ctx context.Context) core.Result {
+			return executeServiceTest(ctx, provider, test)
+		})
+		results = append(results, result)
+		if failFast && result.Status == core.StatusFail && !test.ContinueOnFailure {
+			return results, true
+		}
+	}
+
+	// Execute command content tests
+	for _, test := range spec.Tests.CommandContent {
+		result := core.RunTest(ctx, test.TestOptions, func(ctx context.Context) core.Result {
+			return executeCommandContentTest(ctx, provider, test)
+		})
+		results = append(results, result)
+		if failFast && result.Status == core.StatusFail && !test.ContinueOnFailure {
+			return results, true
+		}
+	}
+
+	// Execute command JSON tests
+	for _, test := range spec.Tests.CommandJSON {
+		result := core.RunTest(ctx, test.TestOptions, func(ctx context.Context) core.Result {
+			return executeCommandJSONTest(ctx, provider, test)
+		})
+		results = append(results, result)
+		if failFast && result.Status == core.StatusFail && !test.ContinueOnFailure {
+			return results, true
+		}
+	}
+
+	// Execute metric tests
+	for _, test := range spec.Tests.Metrics {
+		result := core.RunTest(ctx, test.TestOptions, func(ctx context.Context) core.Result {
+			return executeMetricTest(ctx, provider, test)
+		})
+		results = append(results, result)
+		if failFast && result.Status == core.StatusFail && !test.ContinueOnFailure {
+			return results, true
+		}
+	}
+
+	// Execute port tests
+	for _, test := range spec.Tests.Ports {
+		result := core.RunTest(ctx, test.TestOptions, func(ctx context.Context) core.Result {
+			return executePortTest(ctx, provider, test)
+		})
+		results = append(results, result)
+		if failFast && result.Status == core.StatusFail && !test.ContinueOnFailure {
+			return results, true
+		}
+	}
+
+	return results, false
+}
+
+// skipOnWindows returns skipped results for test types that are not supported on Windows
+func skipOnWindows(spec *core.Spec) []core.Result {
+	var results []core.Result
+	skip := func(name, testType string) {
+		results = append(results, core.Result{
+			Name:       name,
+			Status:     core.StatusSkip,
+			Message:    fmt.Sprintf("%s tests are not supported on Windows", testType),
+			SkipReason: core.SkipUnsupportedPlatform,
+			Details:    make(map[string]interface{}),
+		})
+	}
+
+	for _, test := range spec.Tests.Packages {
+		skip(test.Name, "Package")
+	}
+	for _, test := range spec.Tests.Users {
+		skip(test.Name, "User")
+	}
+	for _, test := range spec.Tests.Groups {
+		skip(test.Name, "Group")
+	}
+	for _, test := range spec.Tests.FileContent {
+		skip(test.Name, "File content")
+	}
+	for _, test := range spec.Tests.Docker {
+		skip(test.Name, "Docker")
+	}
+	for _, test := range spec.Tests.DockerImages {
+		skip(test.Name, "Docker image")
+	}
+	for _, test := range spec.Tests.DockerNetworks {
+		skip(test.Name, "Docker network")
+	}
+	for _, test := range spec.Tests.DockerVolumes {
+		skip(test.Name, "Docker volume")
+	}
+	for _, test := range spec.Tests.DockerCompose {
+		skip(test.Name, "Docker Compose")
+	}
+	for _, test := range spec.Tests.Filesystems {
+		skip(test.Name, "Filesystem")
+	}
+	for _, test := range spec.Tests.Ping {
+		skip(test.Name, "Ping")
+	}
+	for _, test := range spec.Tests.DNS {
+		skip(test.Name, "DNS")
+	}
+	for _, test := range spec.Tests.SystemInfo {
+		skip(test.Name, "System info")
+	}
+	for _, test := range spec.Tests.HTTP {
+		skip(test.Name, "HTTP")
+	}
+	for _, test := range spec.Tests.ServiceRegistry {
+		skip(test.Name, "Service registry")
+	}
+	for _, test := range spec.Tests.Vault {
+		skip(test.Name, "Vault")
+	}
+	for _, test := range spec.Tests.MessageQueues {
+		skip(test.Name, "Message queue")
+	}
+	for _, test := range spec.Tests.Capabilities {
+		skip(test.Name, "Capabilities")
+	}
+	for _, test := range spec.Tests.Repositories {
+		skip(test.Name, "Repository")
+	}
+	for _, test := range spec.Tests.SystemdProps {
+		skip(test.Name, "Systemd property")
+	}
+	for _, test := range spec.Tests.SSHConfig {
+		skip(test.Name, "sshd_config")
+	}
+	for _, test := range spec.Tests.LogRotate {
+		skip(test.Name, "Logrotate")
+	}
+	for _, test := range spec.Tests.Cron {
+		skip(test.Name, "Cron")
+	}
+	for _, test := range spec.Tests.Processes {
+		skip(test.Name, "Process")
+	}
+	return results
+}
+
+// psQuote quotes a string for safe use as a PowerShell literal
+func psQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
+
+// executeWindowsFileTest executes a file test using Test-Path and Get-Acl
+func executeWindowsFileTest(ctx context.Context, provider core.Provider, test core.FileTest) core.Result {
+	start := time.Now()
+	result := core.Result{
+		Name:    test.Name,
+		Status:  core.StatusPass,
+		Details: make(map[string]interface{}),
+	}
+
+	if test.Group != "" || test.Mode != "" {
+		result.Status = core.StatusError
+		result.Message = fmt.Sprintf("Group and mode checks are not supported on Windows (path %s)", test.Path)
+		result.Duration = time.Since(start)
+		return result
+	}
+
+	if test.MaxAgeSeconds > 0 || test.MinAgeSeconds > 0 || test.MinEntries > 0 || test.MaxEntries > 0 || test.Empty != nil || (test.Recursive && test.Owner != "") {
+		result.Status = core.StatusError
+		result.Message = fmt.Sprintf("File age, directory entry and recursive checks are not supported on Windows (path %s)", test.Path)
+		result.Duration = time.Since(start)
+		return result
+	}
+
+	stdout, _, exitCode, err := provider.ExecuteCommand(ctx, windowsFileCommand(test.Path))
+	if err != nil {
+		result.Status = core.StatusError
+		result.Message = fmt.Sprintf("Error checking path %s: %v", test.Path, err)
+		result.Duration = time.Since(start)
+		return result
+	}
+
+	stdout = strings.TrimSpace(stdout)
+	if stdout == "notfound" || exitCode != 0 {
+		result.Status = core.StatusFail
+		result.Message = fmt.Sprintf("Path %s does not exist", test.Path)
+		result.Duration = time.Since(start)
+		return result
+	}
+
+	parts := strings.SplitN(stdout, "|", 2)
+	if len(parts) != 2 {
+		result.Status = core.StatusError
+		result.Message = fmt.Sprintf("Unexpected Get-Item output for %s", test.Path)
+		result.Duration = time.Since(start)
+		return result
+	}
+
+	fileType := parts[0]
+	owner := parts[1]
+
+	result.Details["type"] = fileType
+	result.Details["owner"] = owner
+
+	if test.Type != "" && !matchesFileType(fileType, test.Type) {
+		result.Status = core.StatusFail
+		result.Message = fmt.Sprintf("Path %s is a %s, expected %s", test.Path, fileType, test.Type)
+		result.Duration = time.Since(start)
+		return result
+	}
+
+	// Windows account names are case-insensitive
+	if test.Owner != "" && !strings.EqualFold(owner, test.Owner) {
+		result.Status = core.StatusFail
+		result.Message = fmt.Sprintf("Path %s owner is %s, expected %s", test.Path, owner, test.Owner)
+		result.Duration = time.Since(start)
+		return result
+	}
+
+	result.Message = fmt.Sprintf("Path %s exists with correct properties", test.Path)
+	result.Duration = time.Since(start)
+	return result
+}
+
+// checkWindowsServiceStatus checks if a Windows service is running and set to start automatically
+func checkWindowsServiceStatus(ctx context.Context, provider core.Provider, service string) (running, enabled bool, err error) {
+	stdout, _, _, err := provider.ExecuteCommand(ctx, windowsServiceCommand(service))
+	if err != nil {
+		return false, false, err
+	}
+
+	parts := strings.SplitN(strings.TrimSpace(stdout), "|", 2)
+	if len(parts) != 2 {
+		// Service does not exist
+		return false, false, nil
+	}
+
+	running = parts[0] == "Running"
+	enabled = strings.HasPrefix(parts[1], "Automatic")
+	return running, enabled, nil
+}
+
+// windowsFileCommand returns a PowerShell command printing "type|owner" for a path, or "notfound"
+func windowsFileCommand(path string) string {
+	quoted := psQuote(path)
+	return fmt.Sprintf("if (Test-Path -LiteralPath %s) { $i = Get-Item -LiteralPath %s -Force; "+
+		"$t = if ($i.LinkType) { 'symlink' } elseif ($i.PSIsContainer) { 'directory' } else { 'file' }; "+
+		"\"$t|$((Get-Acl -LiteralPath %s).Owner)\" } else { 'notfound' }", quoted, quoted, quoted)
+}
+
+// windowsServiceCommand returns a PowerShell command printing "Status|StartType" for a service, or "notfound"
+func windowsServiceCommand(service string) string {
+	return fmt.Sprintf("$s = Get-Service -Name %s -ErrorAction SilentlyContinue; "+
+		"if ($s) { \"$($s.Status)|$($s.StartType)\" } else { 'notfound' }", psQuote(service))
+}
+
+// windowsPortCommand returns a PowerShell command that prints output only if the port is listening
+func windowsPortCommand(test core.PortTest) string {
+	if test.Protocol == "udp" {
+		return fmt.Sprintf("if (Get-NetUDPEndpoint -LocalPort %d -ErrorAction SilentlyContinue) { 'listening' }", test.Port)
+	}
+	return fmt.Sprintf("if (Test-NetConnection -ComputerName localhost -Port %d -InformationLevel Quiet -WarningAction SilentlyContinue) { 'listening' }", test.Port)
+}
@@ -0,0 +1,235 @@
+package system
+
+import (
+	"context"
+	"testing"
+
+	"github.com/neilfarmer/platform-spec/pkg/core"
+)
+
+func TestExecutor_WindowsServiceTest(t *testing.T) {
+	tests := []struct {
+		name         string
+		serviceTest  core.ServiceTest
+		setupMock    func(*core.MockProvider)
+		wantStatus   core.Status
+		wantContains string
+	}{
+		{
+			name: "service running and automatic",
+			serviceTest: core.ServiceTest{
+				Name:    "W3SVC running",
+				Service: "W3SVC",
+				State:   "running",
+				Enabled: true,
+			},
+			setupMock: func(m *core.MockProvider) {
+				m.SetCommandResult(windowsServiceCommand("W3SVC"), "Running|Automatic\r\n", "", 0, nil)
+			},
+			wantStatus:   core.StatusPass,
+			wantContains: "running and enabled",
+		},
+		{
+			name: "service stopped",
+			serviceTest: core.ServiceTest{
+				Name:    "W3SVC running",
+				Service: "W3SVC",
+				State:   "running",
+			},
+			setupMock: func(m *core.MockProvider) {
+				m.SetCommandResult(windowsServiceCommand("W3SVC"), "Stopped|Manual\r\n", "", 0, nil)
+			},
+			wantStatus:   core.StatusFail,
+			wantContains: "not running",
+		},
+		{
+			name: "service running but manual start",
+			serviceTest: core.ServiceTest{
+				Name:    "Spooler enabled",
+				Service: "Spooler",
+				State:   "running",
+				Enabled: true,
+			},
+			setupMock: func(m *core.MockProvider) {
+				m.SetCommandResult(windowsServiceCommand("Spooler"), "Running|Manual", "", 0, nil)
+			},
+			wantStatus:   core.StatusFail,
+			wantContains: "not enabled",
+		},
+		{
+			name: "service does not exist",
+			serviceTest: core.ServiceTest{
+				Name:    "Missing service",
+				Service: "nosuchsvc",
+				State:   "running",
+			},
+			setupMock: func(m *core.MockProvider) {
+				m.SetCommandResult(windowsServiceCommand("nosuchsvc"), "notfound", "", 0, nil)
+			},
+			wantStatus:   core.StatusFail,
+			wantContains: "not running",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := core.NewMockProvider()
+			mock.SetOS(core.OSWindows)
+			tt.setupMock(mock)
+
+			result := executeServiceTest(context.Background(), mock, tt.serviceTest)
+
+			if result.Status != tt.wantStatus {
+				t.Errorf("Status = %v, want %v (message: %s)", result.Status, tt.wantStatus, result.Message)
+			}
+			if tt.wantContains != "" && !contains(result.Message, tt.wantContains) {
+				t.Errorf("Message = %q, want to contain %q", result.Message, tt.wantContains)
+			}
+		})
+	}
+}
+
+func TestExecutor_WindowsFileTest(t *testing.T) {
+	tests := []struct {
+		name         string
+		fileTest     core.FileTest
+		setupMock    func(*core.MockProvider)
+		wantStatus   core.Status
+		wantContains string
+	}{
+		{
+			name: "directory exists with owner",
+			fileTest: core.FileTest{
+				Name:  "IIS root",
+				Path:  `C:\inetpub\wwwroot`,
+				Type:  "directory",
+				Owner: `nt service\trustedinstaller`,
+			},
+			setupMock: func(m *core.MockProvider) {
+				m.SetCommandResult(windowsFileCommand(`C:\inetpub\wwwroot`), "directory|NT SERVICE\\TrustedInstaller\r\n", "", 0, nil)
+			},
+			wantStatus:   core.StatusPass,
+			wantContains: "exists with correct properties",
+		},
+		{
+			name: "path does not exist",
+			fileTest: core.FileTest{
+				Name: "Missing config",
+				Path: `C:\app\config.json`,
+				Type: "file",
+			},
+			setupMock: func(m *core.MockProvider) {
+				m.SetCommandResult(windowsFileCommand(`C:\app\config.json`), "notfound", "", 0, nil)
+			},
+			wantStatus:   core.StatusFail,
+			wantContains: "does not exist",
+		},
+		{
+			name: "wrong type",
+			fileTest: core.FileTest{
+				Name: "Logs dir",
+				Path: `C:\app\logs`,
+				Type: "directory",
+			},
+			setupMock: func(m *core.MockProvider) {
+				m.SetCommandResult(windowsFileCommand(`C:\app\logs`), "file|BUILTIN\\Administrators", "", 0, nil)
+			},
+			wantStatus:   core.StatusFail,
+			wantContains: "expected directory",
+		},
+		{
+			name: "mode not supported",
+			fileTest: core.FileTest{
+				Name: "Mode check",
+				Path: `C:\app`,
+				Mode: "0755",
+			},
+			setupMock:    func(m *core.MockProvider) {},
+			wantStatus:   core.StatusError,
+			wantContains: "not supported on Windows",
+		},
+		{
+			name: "path with quote is escaped",
+			fileTest: core.FileTest{
+				Name: "Quoted path",
+				Path: `C:\it's here`,
+			},
+			setupMock: func(m *core.MockProvider) {
+				m.SetCommandResult(windowsFileCommand(`C:\it's here`), "file|BUILTIN\\Administrators", "", 0, nil)
+			},
+			wantStatus:   core.StatusPass,
+			wantContains: "exists",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := core.NewMockProvider()
+			mock.SetOS(core.OSWindows)
+			tt.setupMock(mock)
+
+			result := executeFileTest(context.Background(), mock, tt.fileTest)
+
+			if result.Status != tt.wantStatus {
+				t.Errorf("Status = %v, want %v (message: %s)", result.Status, tt.wantStatus, result.Message)
+			}
+			if tt.wantContains != "" && !contains(result.Message, tt.wantContains) {
+				t.Errorf("Message = %q, want to contain %q", result.Message, tt.wantContains)
+			}
+		})
+	}
+}
+
+func TestExecutor_WindowsPortTest(t *testing.T) {
+	mock := core.NewMockProvider()
+	mock.SetOS(core.OSWindows)
+	test := core.PortTest{Name: "RDP", Port: 3389, Protocol: "tcp", State: "listening"}
+	mock.SetCommandResult(windowsPortCommand(test), "listening\r\n", "", 0, nil)
+
+	result := executePortTest(context.Background(), mock, test)
+	if result.Status != core.StatusPass {
+		t.Errorf("Status = %v, want %v (message: %s)", result.Status, core.StatusPass, result.Message)
+	}
+
+	udp := core.PortTest{Name: "DNS", Port: 53, Protocol: "udp", State: "listening"}
+	result = executePortTest(context.Background(), mock, udp)
+	if result.Status != core.StatusFail {
+		t.Errorf("Status = %v, want %v (message: %s)", result.Status, core.StatusFail, result.Message)
+	}
+}
+
+func TestPSQuote(t *testing.T) {
+	if got := psQuote("it's"); got != "'it''s'" {
+		t.Errorf("psQuote() = %q, want %q", got, "'it''s'")
+	}
+}
+
+func TestSystemPlugin_Execute_Windows(t *testing.T) {
+	mock := core.NewMockProvider()
+	mock.SetOS(core.OSWindows)
+	mock.SetCommandResult(windowsServiceCommand("W3SVC"), "Running|Automatic", "", 0, nil)
+
+	spec := &core.Spec{
+		Tests: core.Tests{
+			Packages: []core.PackageTest{{Name: "nginx installed", Packages: []string{"nginx"}, State: "present"}},
+			Services: []core.ServiceTest{{Name: "IIS running", Service: "W3SVC", State: "running"}},
+		},
+	}
+
+	results, stop := NewSystemPlugin().Execute(context.Background(), spec, mock, false)
+	if stop {
+		t.Error("Execute() should not stop")
+	}
+	if len(results) != 2 {
+		t.Fatalf("Execute() returned %d results, want 2", len(results))
+	}
+	if results[0].Status != core.StatusSkip || !contains(results[0].Message, "not supported on Windows") {
+		t.Errorf("package test = %v (%s), want skipped as unsupported", results[0].Status, results[0].Message)
+	}
+	if results[0].SkipReason != core.SkipUnsupportedPlatform {
+		t.Errorf("package test SkipReason = %q, want %q", results[0].SkipReason, core.SkipUnsupportedPlatform)
+	}
+	if results[1].Status != core.StatusPass {
+		t.Errorf("service test = %v (%s), want passed", results[1].Status, results[1].Message)
+	}
+}
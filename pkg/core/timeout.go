@@ -0,0 +1,53 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// testTimeoutKey is the context key for the per-test timeout
+type testTimeoutKey struct{}
+
+// withTestTimeout returns a context in which RunTest bounds each check of a
+// test to timeout
+func withTestTimeout(ctx context.Context, timeout time.Duration) context.Context {
+	return context.WithValue(ctx, testTimeoutKey{}, timeout)
+}
+
+// testTimeoutFromContext returns the per-test timeout, or 0 for none
+func testTimeoutFromContext(ctx context.Context) time.Duration {
+	timeout, _ := ctx.Value(testTimeoutKey{}).(time.Duration)
+	return timeout
+}
+
+// runCheck runs check with a context that expires after timeout, if one is
+// set. A check that runs out of time is reported as timed out, whatever it
+// made of its cancelled commands; cancelling ctx itself is left to the check.
+func runCheck(ctx context.Context, timeout time.Duration, check func(context.Context) Result) Result {
+	if timeout <= 0 {
+		return check(ctx)
+	}
+
+	checkCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	result := check(checkCtx)
+	if checkCtx.Err() == context.DeadlineExceeded && ctx.Err() == nil {
+		return timedOutResult(result, timeout)
+	}
+	return result
+}
+
+// timedOutResult replaces the result of a check that ran out of time with an
+// error saying so
+func timedOutResult(result Result, timeout time.Duration) Result {
+	return Result{
+		Name:        result.Name,
+		Category:    result.Category,
+		Status:      StatusError,
+		Message:     fmt.Sprintf("timed out after %s", timeout),
+		Duration:    result.Duration,
+		Details:     result.Details,
+		Attachments: result.Attachments,
+	}
+}
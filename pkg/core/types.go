@@ -12,18 +12,59 @@ const (
 	StatusError Status = "error"
 )
 
+// SkipReason says why a test was skipped, so reports can group skips by cause
+type SkipReason string
+
+const (
+	SkipUnsupportedPlatform SkipReason = "unsupported platform"  // Test type cannot run on the target OS
+	SkipSubTestsSkipped     SkipReason = "sub-tests skipped"     // Every sub-test of a composite test was skipped
+	SkipCategoryNotSelected SkipReason = "category not selected" // Left out by --categories or --skip-categories
+)
+
 // Result represents the result of a single test
 type Result struct {
-	Name     string
-	Status   Status
-	Message  string
-	Duration time.Duration
-	Details  map[string]interface{}
+	Name       string
+	Category   string // Test category, e.g. packages or kubernetes.pods; set by the executor
+	Status     Status
+	Message    string
+	SkipReason SkipReason // Set when Status is StatusSkip
+	Duration   time.Duration
+	Details    map[string]interface{}
+
+	// Attempts is the number of checks made of a test with retry options,
+	// out of at most MaxAttempts; both are 0 for a test checked without them
+	Attempts    int
+	MaxAttempts int
+
+	// Attachments hold evidence of what the test observed, such as the
+	// matching lines of a config file or kubectl JSON, keyed by name
+	Attachments map[string]string
+	sensitive   map[string]bool // Attachment names redacted from output by default
+}
+
+// Attach records evidence of what the test observed under name
+func (r *Result) Attach(name, content string) {
+	if r.Attachments == nil {
+		r.Attachments = make(map[string]string)
+	}
+	r.Attachments[name] = content
+}
+
+// AttachSensitive is Attach for evidence that may hold secrets, such as the
+// data of a Kubernetes secret. It is replaced with Redacted in results unless
+// the spec sets config.show_sensitive_attachments.
+func (r *Result) AttachSensitive(name, content string) {
+	r.Attach(name, content)
+	if r.sensitive == nil {
+		r.sensitive = make(map[string]bool)
+	}
+	r.sensitive[name] = true
 }
 
 // TestResults represents the aggregated results of all tests
 type TestResults struct {
 	SpecName  string
+	SpecHash  string // Hash of the resolved spec, see Spec.Hash
 	Target    string
 	StartTime time.Time
 	Duration  time.Duration
@@ -54,18 +95,32 @@ func (tr *TestResults) Success() bool {
 	return failed == 0 && errors == 0
 }
 
+// ConnectionAttempt describes a failed attempt to connect to a host
+type ConnectionAttempt struct {
+	Attempt   int           // Attempt number, starting at 1
+	Error     string        // Why the attempt failed
+	Retryable bool          // Whether the retry classifier considered the error transient
+	NextDelay time.Duration // Backoff before the next attempt (0 if none followed)
+}
+
 // HostResults represents the results of testing a single host
 type HostResults struct {
-	Target          string         // Resolved target (user@host)
-	Connected       bool           // Did SSH connection succeed?
-	ConnectionError error          // Connection error if any
-	SpecResults     []*TestResults // Results for each spec file
-	Duration        time.Duration  // Total time for this host
+	Target          string              // Resolved target (user@host)
+	Labels          map[string]string   // Inventory labels such as role or datacenter
+	Connected       bool                // Did SSH connection succeed?
+	ConnectionError error               // Connection error if any
+	TimedOut        bool                // Ran out of its per-host time limit; results are partial
+	Skipped         bool                // Not tested because the run halted at an earlier failing host
+	SpecResults     []*TestResults      // Results for each spec file
+	Duration        time.Duration       // Total time for this host
+	Metrics         ConnectionMetrics   // Connection statistics (connect time, commands, bytes)
+	Attempts        []ConnectionAttempt // Failed connection attempts, in order
+	Artifacts       []string            // Diagnostic files collected because the host failed
 }
 
-// Success returns true if the host connected and all tests passed
+// Success returns true if the host connected, finished in time and all tests passed
 func (hr *HostResults) Success() bool {
-	if !hr.Connected {
+	if !hr.Connected || hr.TimedOut {
 		return false
 	}
 	for _, spec := range hr.SpecResults {
@@ -92,10 +147,14 @@ func (mhr *MultiHostResults) Success() bool {
 	return true
 }
 
-// Summary returns counts: totalHosts, passedHosts, failedHosts, connectionErrors
+// Summary returns counts: totalHosts, passedHosts, failedHosts, connectionErrors.
+// Skipped hosts count toward totalHosts only; see SkippedHosts.
 func (mhr *MultiHostResults) Summary() (totalHosts, passedHosts, failedHosts, connectionErrors int) {
 	totalHosts = len(mhr.Hosts)
 	for _, host := range mhr.Hosts {
+		if host.Skipped {
+			continue
+		}
 		if !host.Connected {
 			connectionErrors++
 			failedHosts++
@@ -107,3 +166,14 @@ func (mhr *MultiHostResults) Summary() (totalHosts, passedHosts, failedHosts, co
 	}
 	return
 }
+
+// SkippedHosts returns the number of hosts left untested because the run halted
+func (mhr *MultiHostResults) SkippedHosts() int {
+	skipped := 0
+	for _, host := range mhr.Hosts {
+		if host.Skipped {
+			skipped++
+		}
+	}
+	return skipped
+}
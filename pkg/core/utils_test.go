@@ -58,3 +58,55 @@ func TestShellQuote(t *testing.T) {
 		})
 	}
 }
+
+func TestParseSize(t *testing.T) {
+	tests := []struct {
+		name      string
+		size      string
+		wantBytes int64
+		wantErr   bool
+	}{
+		// Binary units (IEC)
+		{"1Ki", "1Ki", 1024, false},
+		{"500Mi", "500Mi", 500 * 1024 * 1024, false},
+		{"100Gi", "100Gi", 100 * 1024 * 1024 * 1024, false},
+		{"1Ti", "1Ti", 1024 * 1024 * 1024 * 1024, false},
+		{"1Pi", "1Pi", 1024 * 1024 * 1024 * 1024 * 1024, false},
+		{"1Ei", "1Ei", 1024 * 1024 * 1024 * 1024 * 1024 * 1024, false},
+
+		// Decimal units (SI)
+		{"1K", "1K", 1000, false},
+		{"1M", "1M", 1000 * 1000, false},
+		{"1G", "1G", 1000 * 1000 * 1000, false},
+		{"1T", "1T", 1000 * 1000 * 1000 * 1000, false},
+		{"1P", "1P", 1000 * 1000 * 1000 * 1000 * 1000, false},
+		{"1E", "1E", 1000 * 1000 * 1000 * 1000 * 1000 * 1000, false},
+
+		// No unit (bytes)
+		{"100", "100", 100, false},
+		{"1024", "1024", 1024, false},
+
+		// Decimal values
+		{"1.5Gi", "1.5Gi", int64(1.5 * 1024 * 1024 * 1024), false},
+		{"0.5Ti", "0.5Ti", int64(0.5 * 1024 * 1024 * 1024 * 1024), false},
+
+		// Error cases
+		{"invalid", "invalid", 0, true},
+		{"", "", 0, true},
+		{"100XYZ", "100XYZ", 0, true},
+		{"-100Gi", "-100Gi", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotBytes, err := core.ParseSize(tt.size)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseSize() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if gotBytes != tt.wantBytes {
+				t.Errorf("ParseSize() = %v, want %v", gotBytes, tt.wantBytes)
+			}
+		})
+	}
+}
@@ -0,0 +1,172 @@
+package core
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// variablePlaceholder matches ${var.name} and ${env.NAME} references
+var variablePlaceholder = regexp.MustCompile(`\$\{(var|env)\.([^}]*)\}`)
+
+// variableName matches valid variable names
+var variableName = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_-]*$`)
+
+// ParseVariables parses name=value assignments, as given to --var
+func ParseVariables(assignments []string) (map[string]string, error) {
+	vars := make(map[string]string, len(assignments))
+	for _, assignment := range assignments {
+		name, value, found := strings.Cut(assignment, "=")
+		if !found {
+			return nil, fmt.Errorf("'%s' must be name=value", assignment)
+		}
+		if !variableName.MatchString(name) {
+			return nil, fmt.Errorf("variable name '%s' must be letters, digits, dashes and underscores", name)
+		}
+		vars[name] = value
+	}
+	return vars, nil
+}
+
+// UsesVariable reports whether the spec's files reference ${var.name}. Parsing
+// substitutes the references, so they no longer appear in the tests.
+func (s *Spec) UsesVariable(name string) bool {
+	return s.usedVariables[name]
+}
+
+// variableNodes converts name=value pairs to YAML scalars, which decode to
+// whatever type the value looks like, as unquoted YAML would
+func variableNodes(vars map[string]string) map[string]*yaml.Node {
+	nodes := make(map[string]*yaml.Node, len(vars))
+	for name, value := range vars {
+		nodes[name] = &yaml.Node{Kind: yaml.ScalarNode, Value: value}
+	}
+	return nodes
+}
+
+// fileVariables returns the values ${var.name} resolves to in a spec file:
+// the file's own variables section, overridden by inherited, which holds the
+// variables of the files importing it and --var values. ${env.NAME}
+// references in the variables section are resolved first.
+func fileVariables(doc *yaml.Node, inherited map[string]*yaml.Node) (map[string]*yaml.Node, error) {
+	vars := make(map[string]*yaml.Node)
+	var section *yaml.Node
+	if root := documentRoot(doc); root != nil {
+		section = mappingValue(root, "variables")
+	}
+	if section != nil && section.Kind == yaml.MappingNode {
+		var err error
+		walkScalars(section, func(scalar *yaml.Node) {
+			if err == nil {
+				err = substituteScalar(scalar, nil, true)
+			}
+		})
+		if err != nil {
+			return nil, err
+		}
+		for i := 0; i+1 < len(section.Content); i += 2 {
+			vars[section.Content[i].Value] = section.Content[i+1]
+		}
+	}
+	for name, value := range inherited {
+		vars[name] = value
+	}
+	return vars, nil
+}
+
+// substituteVariables resolves ${var.name} and ${env.NAME} placeholders in
+// every scalar of the document outside its variables section, and returns
+// the names of the variables used. A reference to an undefined variable or an
+// unset environment variable is an error.
+func substituteVariables(doc *yaml.Node, vars map[string]*yaml.Node) (map[string]bool, error) {
+	root := documentRoot(doc)
+	if root == nil || root.Kind != yaml.MappingNode {
+		return nil, nil
+	}
+	used := make(map[string]bool)
+	var err error
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		if root.Content[i].Value == "variables" {
+			continue
+		}
+		walkScalars(root.Content[i+1], func(scalar *yaml.Node) {
+			for _, match := range variablePlaceholder.FindAllStringSubmatch(scalar.Value, -1) {
+				if match[1] == "var" {
+					used[match[2]] = true
+				}
+			}
+			if err == nil {
+				err = substituteScalar(scalar, vars, false)
+			}
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return used, nil
+}
+
+// substituteScalar replaces the placeholders in one scalar. A scalar that is
+// exactly one ${var.name} placeholder takes the variable's YAML type, so
+// port: ${var.port} stays an integer. If envOnly is set, ${var.name}
+// references are left as they are.
+func substituteScalar(scalar *yaml.Node, vars map[string]*yaml.Node, envOnly bool) error {
+	if !strings.Contains(scalar.Value, "${") {
+		return nil
+	}
+	if match := variablePlaceholder.FindStringSubmatch(scalar.Value); match != nil && match[0] == scalar.Value && match[1] == "var" && !envOnly {
+		value, err := lookupVariable(vars, match[2], scalar.Line)
+		if err != nil {
+			return err
+		}
+		scalar.Tag = value.Tag
+		scalar.Style = value.Style
+		scalar.Value = value.Value
+		return nil
+	}
+
+	var err error
+	scalar.Value = variablePlaceholder.ReplaceAllStringFunc(scalar.Value, func(placeholder string) string {
+		match := variablePlaceholder.FindStringSubmatch(placeholder)
+		if err != nil || (match[1] == "var" && envOnly) {
+			return placeholder
+		}
+		if match[1] == "env" {
+			value, ok := os.LookupEnv(match[2])
+			if !ok {
+				err = fmt.Errorf("line %d: environment variable '%s' is not set", scalar.Line, match[2])
+			}
+			return value
+		}
+		value, lookupErr := lookupVariable(vars, match[2], scalar.Line)
+		if lookupErr != nil {
+			err = lookupErr
+			return placeholder
+		}
+		return value.Value
+	})
+	return err
+}
+
+// lookupVariable returns the scalar value of a variable referenced on line
+func lookupVariable(vars map[string]*yaml.Node, name string, line int) (*yaml.Node, error) {
+	value, ok := vars[name]
+	if !ok {
+		return nil, fmt.Errorf("line %d: variable '%s' is not defined; add it to variables or pass --var %s=VALUE", line, name, name)
+	}
+	if value.Kind != yaml.ScalarNode {
+		return nil, fmt.Errorf("line %d: variable '%s' must be a string, number or boolean to be substituted", line, name)
+	}
+	return value, nil
+}
+
+// documentRoot returns the top-level node of a YAML document, or nil if it is empty
+func documentRoot(doc *yaml.Node) *yaml.Node {
+	if len(doc.Content) == 0 {
+		return nil
+	}
+	return doc.Content[0]
+}
@@ -0,0 +1,172 @@
+package core
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeVariablesSpec writes a spec file into dir and returns its path
+func writeVariablesSpec(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestVariables_Substitution(t *testing.T) {
+	t.Setenv("PLATFORM_SPEC_TEST_DOMAIN", "example.com")
+	path := writeVariablesSpec(t, t.TempDir(), "spec.yaml", `version: "1.0"
+metadata:
+  name: variables
+variables:
+  app: web
+  port: 8443
+  version: "1.25"
+  host: app.${env.PLATFORM_SPEC_TEST_DOMAIN}
+tests:
+  ports:
+    - name: "${var.app} listening"
+      port: ${var.port}
+      state: listening
+  http:
+    - name: "${var.app} health"
+      url: https://${var.host}:${var.port}/health
+      contains: ["${var.version}"]
+  ping:
+    - name: "${matrix.target} reachable"
+      host: ${matrix.target}
+      matrix:
+        target: ["${var.host}", "db.${env.PLATFORM_SPEC_TEST_DOMAIN}"]
+`)
+
+	spec, err := ParseSpecWithVariables(path, map[string]string{"version": "1.26"})
+	if err != nil {
+		t.Fatalf("ParseSpecWithVariables() error = %v", err)
+	}
+
+	port := spec.Tests.Ports[0]
+	if port.Name != "web listening" || port.Port != 8443 {
+		t.Errorf("port test = %q port %d, want 'web listening' port 8443", port.Name, port.Port)
+	}
+	http := spec.Tests.HTTP[0]
+	if http.URL != "https://app.example.com:8443/health" {
+		t.Errorf("http url = %q", http.URL)
+	}
+	// --var values override the spec's variables
+	if len(http.Contains) != 1 || http.Contains[0] != "1.26" {
+		t.Errorf("http contains = %v, want [1.26]", http.Contains)
+	}
+	if len(spec.Tests.Ping) != 2 || spec.Tests.Ping[0].Host != "app.example.com" || spec.Tests.Ping[1].Host != "db.example.com" {
+		t.Errorf("ping tests = %+v, want matrix over substituted hosts", spec.Tests.Ping)
+	}
+	for _, name := range []string{"app", "port", "version", "host"} {
+		if !spec.UsesVariable(name) {
+			t.Errorf("UsesVariable(%q) = false, want true", name)
+		}
+	}
+}
+
+func TestVariables_Imports(t *testing.T) {
+	dir := t.TempDir()
+	writeVariablesSpec(t, dir, "common.yaml", `version: "1.0"
+variables:
+  package: curl
+tests:
+  packages:
+    - name: "${var.package} for ${var.env_name}"
+      packages: ["${var.package}"]
+`)
+	path := writeVariablesSpec(t, dir, "spec.yaml", `version: "1.0"
+imports: ["common.yaml"]
+variables:
+  env_name: staging
+`)
+
+	spec, err := ParseSpec(path)
+	if err != nil {
+		t.Fatalf("ParseSpec() error = %v", err)
+	}
+	// Imported files see the variables of the file importing them
+	if got := spec.Tests.Packages[0].Name; got != "curl for staging" {
+		t.Errorf("imported test name = %q, want 'curl for staging'", got)
+	}
+	if !spec.UsesVariable("env_name") || !spec.UsesVariable("package") {
+		t.Error("UsesVariable() = false for variables used by an imported file")
+	}
+}
+
+func TestVariables_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{
+			name: "undefined variable",
+			content: `tests:
+  packages:
+    - name: "nginx"
+      packages: ["${var.missing}"]
+`,
+			wantErr: "line 4: variable 'missing' is not defined; add it to variables or pass --var missing=VALUE",
+		},
+		{
+			name: "unset environment variable",
+			content: `tests:
+  http:
+    - name: "api"
+      url: https://${env.PLATFORM_SPEC_TEST_UNSET}/health
+`,
+			wantErr: "line 4: environment variable 'PLATFORM_SPEC_TEST_UNSET' is not set",
+		},
+		{
+			name: "unset environment variable in variables",
+			content: `variables:
+  token: ${env.PLATFORM_SPEC_TEST_UNSET}
+tests: {}
+`,
+			wantErr: "line 2: environment variable 'PLATFORM_SPEC_TEST_UNSET' is not set",
+		},
+		{
+			name: "list variable",
+			content: `variables:
+  hosts: [a, b]
+tests:
+  ping:
+    - name: "ping"
+      host: ${var.hosts}
+`,
+			wantErr: "line 6: variable 'hosts' must be a string, number or boolean",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := writeVariablesSpec(t, t.TempDir(), "spec.yaml", tt.content)
+			_, err := ParseSpec(path)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("ParseSpec() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestParseVariables(t *testing.T) {
+	vars, err := ParseVariables([]string{"region=us-east-1", "url=https://x?a=b", "empty="})
+	if err != nil {
+		t.Fatalf("ParseVariables() error = %v", err)
+	}
+	if vars["region"] != "us-east-1" || vars["url"] != "https://x?a=b" || vars["empty"] != "" {
+		t.Errorf("ParseVariables() = %v", vars)
+	}
+
+	for _, bad := range []string{"region", "1st=x", "a.b=c"} {
+		if _, err := ParseVariables([]string{bad}); err == nil {
+			t.Errorf("ParseVariables(%q) expected error", bad)
+		}
+	}
+}
@@ -0,0 +1,116 @@
+package core
+
+import (
+	"context"
+	"time"
+)
+
+// ChangedResults returns current with only the tests whose status differs
+// from previous, such as a fail that now passes, and tests previous did not
+// report. Tests are matched by name; repeated names are matched in the order
+// they ran. A nil previous keeps every test.
+func ChangedResults(previous, current *TestResults) *TestResults {
+	changed := *current
+	changed.Results = nil
+
+	if previous == nil {
+		changed.Results = append(changed.Results, current.Results...)
+		return &changed
+	}
+
+	type key struct {
+		name string
+		n    int
+	}
+	before := make(map[key]Status, len(previous.Results))
+	seen := make(map[string]int)
+	for _, r := range previous.Results {
+		before[key{r.Name, seen[r.Name]}] = r.Status
+		seen[r.Name]++
+	}
+
+	seen = make(map[string]int)
+	for _, r := range current.Results {
+		status, ok := before[key{r.Name, seen[r.Name]}]
+		seen[r.Name]++
+		if !ok || status != r.Status {
+			changed.Results = append(changed.Results, r)
+		}
+	}
+	return &changed
+}
+
+// WatchReport receives the results of one watch iteration. full is false
+// when results hold only the tests that changed since the previous iteration.
+type WatchReport func(iteration int, results []*TestResults, full bool)
+
+// Watch calls run every interval until all its results pass or ctx is done,
+// passing each iteration's results to report, and returns the last results.
+// With changesOnly, iterations between the first and the final one report
+// only the tests whose status changed, keeping the output readable while a
+// host converges. The first and final iterations are always reported in
+// full; if ctx is done between iterations, the last results are reported
+// again in full.
+func Watch(ctx context.Context, interval time.Duration, changesOnly bool, run func(context.Context) []*TestResults, report WatchReport) []*TestResults {
+	var previous []*TestResults
+	for iteration := 1; ; iteration++ {
+		current := run(ctx)
+		converged := allPassed(current)
+
+		if previous == nil || converged || !changesOnly {
+			report(iteration, current, true)
+		} else {
+			report(iteration, changedSpecResults(previous, current), false)
+		}
+		if converged {
+			return current
+		}
+		previous = current
+
+		if !waitInterval(ctx, interval) {
+			if changesOnly && iteration > 1 {
+				report(iteration, current, true)
+			}
+			return current
+		}
+	}
+}
+
+// waitInterval waits for interval and returns false if ctx is done first
+func waitInterval(ctx context.Context, interval time.Duration) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	timer := time.NewTimer(interval)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// changedSpecResults applies ChangedResults to each spec's results, matching
+// specs by position since every iteration runs the same spec files
+func changedSpecResults(previous, current []*TestResults) []*TestResults {
+	changed := make([]*TestResults, len(current))
+	for i, results := range current {
+		var before *TestResults
+		if i < len(previous) {
+			before = previous[i]
+		}
+		changed[i] = ChangedResults(before, results)
+	}
+	return changed
+}
+
+// allPassed reports whether every spec's results succeeded
+func allPassed(results []*TestResults) bool {
+	for _, r := range results {
+		if !r.Success() {
+			return false
+		}
+	}
+	return true
+}
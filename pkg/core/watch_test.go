@@ -0,0 +1,155 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func watchResults(statuses ...Status) []*TestResults {
+	results := &TestResults{SpecName: "web"}
+	for i, status := range statuses {
+		results.Results = append(results.Results, Result{Name: fmt.Sprintf("test %d", i+1), Status: status})
+	}
+	return []*TestResults{results}
+}
+
+// watchPrint is what one report printed: the iteration, whether it was in
+// full, and each test as "name: status"
+type watchPrint struct {
+	iteration int
+	full      bool
+	tests     []string
+}
+
+func recordWatch(prints *[]watchPrint) WatchReport {
+	return func(iteration int, results []*TestResults, full bool) {
+		p := watchPrint{iteration: iteration, full: full}
+		for _, spec := range results {
+			for _, r := range spec.Results {
+				p.tests = append(p.tests, fmt.Sprintf("%s: %s", r.Name, r.Status))
+			}
+		}
+		*prints = append(*prints, p)
+	}
+}
+
+func TestChangedResults(t *testing.T) {
+	previous := &TestResults{SpecName: "web", Results: []Result{
+		{Name: "nginx running", Status: StatusFail},
+		{Name: "port 80", Status: StatusPass},
+		{Name: "dup", Status: StatusPass},
+		{Name: "dup", Status: StatusFail},
+	}}
+	current := &TestResults{SpecName: "web", Results: []Result{
+		{Name: "nginx running", Status: StatusPass},
+		{Name: "port 80", Status: StatusPass},
+		{Name: "dup", Status: StatusPass},
+		{Name: "dup", Status: StatusPass},
+		{Name: "new test", Status: StatusFail},
+	}}
+
+	got := ChangedResults(previous, current)
+	want := []Result{
+		{Name: "nginx running", Status: StatusPass},
+		{Name: "dup", Status: StatusPass},
+		{Name: "new test", Status: StatusFail},
+	}
+	if !reflect.DeepEqual(got.Results, want) {
+		t.Errorf("ChangedResults() = %v, want %v", got.Results, want)
+	}
+	if got.SpecName != "web" || len(current.Results) != 5 {
+		t.Errorf("ChangedResults() changed the spec name or current results")
+	}
+
+	if all := ChangedResults(nil, current); len(all.Results) != 5 {
+		t.Errorf("ChangedResults(nil) kept %d tests, want all 5", len(all.Results))
+	}
+}
+
+func TestWatch_ChangesOnly(t *testing.T) {
+	iterations := [][]*TestResults{
+		watchResults(StatusFail, StatusFail, StatusPass),
+		watchResults(StatusFail, StatusFail, StatusPass), // nothing changed
+		watchResults(StatusPass, StatusFail, StatusPass),
+		watchResults(StatusPass, StatusPass, StatusPass),
+	}
+	runs := 0
+	run := func(ctx context.Context) []*TestResults {
+		runs++
+		return iterations[runs-1]
+	}
+
+	var prints []watchPrint
+	final := Watch(context.Background(), time.Millisecond, true, run, recordWatch(&prints))
+
+	want := []watchPrint{
+		{iteration: 1, full: true, tests: []string{"test 1: failed", "test 2: failed", "test 3: passed"}},
+		{iteration: 2, full: false},
+		{iteration: 3, full: false, tests: []string{"test 1: passed"}},
+		{iteration: 4, full: true, tests: []string{"test 1: passed", "test 2: passed", "test 3: passed"}},
+	}
+	if !reflect.DeepEqual(prints, want) {
+		t.Errorf("printed %+v, want %+v", prints, want)
+	}
+	if !reflect.DeepEqual(final, iterations[3]) {
+		t.Errorf("Watch() returned %v, want the last iteration's results", final)
+	}
+}
+
+func TestWatch_EveryIterationInFull(t *testing.T) {
+	iterations := [][]*TestResults{
+		watchResults(StatusFail),
+		watchResults(StatusFail),
+		watchResults(StatusPass),
+	}
+	runs := 0
+	run := func(ctx context.Context) []*TestResults {
+		runs++
+		return iterations[runs-1]
+	}
+
+	var prints []watchPrint
+	Watch(context.Background(), time.Millisecond, false, run, recordWatch(&prints))
+
+	if len(prints) != 3 {
+		t.Fatalf("printed %d iterations, want 3", len(prints))
+	}
+	for _, p := range prints {
+		if !p.full || len(p.tests) != 1 {
+			t.Errorf("iteration %d printed %v (full %v), want the full results", p.iteration, p.tests, p.full)
+		}
+	}
+}
+
+func TestWatch_CancelledReprintsInFull(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	runs := 0
+	run := func(ctx context.Context) []*TestResults {
+		runs++
+		if runs == 2 {
+			cancel()
+			return watchResults(StatusPass, StatusFail)
+		}
+		return watchResults(StatusFail, StatusFail)
+	}
+
+	var prints []watchPrint
+	final := Watch(ctx, time.Millisecond, true, run, recordWatch(&prints))
+
+	want := []watchPrint{
+		{iteration: 1, full: true, tests: []string{"test 1: failed", "test 2: failed"}},
+		{iteration: 2, full: false, tests: []string{"test 1: passed"}},
+		{iteration: 2, full: true, tests: []string{"test 1: passed", "test 2: failed"}},
+	}
+	if !reflect.DeepEqual(prints, want) {
+		t.Errorf("printed %+v, want %+v", prints, want)
+	}
+	if runs != 2 || allPassed(final) {
+		t.Errorf("ran %d iterations, final passed %v; want 2 and the failing results", runs, allPassed(final))
+	}
+}
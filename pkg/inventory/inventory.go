@@ -4,15 +4,22 @@ import (
 	"bufio"
 	"fmt"
 	"os"
+	"regexp"
 	"strings"
 )
 
+// labelKeyPattern matches label keys such as "role" or "datacenter"
+var labelKeyPattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_.-]*$`)
+
 // Inventory represents a parsed inventory file containing a list of hosts
 type Inventory struct {
-	Hosts []string // List of hostnames or IP addresses
+	Hosts  []string                     // List of hostnames or IP addresses
+	Labels map[string]map[string]string // Labels per host entry, for entries that have any
 }
 
 // ParseInventoryFile reads and parses an inventory file
+// Each line holds a host optionally followed by key=value labels, e.g.
+// "web-01.example.com role=web datacenter=us-east"
 // Returns an error if the file doesn't exist, is empty, or contains malformed entries
 func ParseInventoryFile(path string) (*Inventory, error) {
 	// #nosec G304 -- Reading user-specified inventory file is intentional and required functionality
@@ -23,6 +30,7 @@ func ParseInventoryFile(path string) (*Inventory, error) {
 	defer file.Close()
 
 	var hosts []string
+	labels := make(map[string]map[string]string)
 	scanner := bufio.NewScanner(file)
 	lineNum := 0
 
@@ -40,12 +48,24 @@ func ParseInventoryFile(path string) (*Inventory, error) {
 			continue
 		}
 
+		// Split the host from its labels
+		fields := strings.Fields(line)
+		host := fields[0]
+
 		// Validate the host entry
-		if err := validateHost(line); err != nil {
+		if err := validateHost(host); err != nil {
 			return nil, fmt.Errorf("invalid entry at line %d: %w", lineNum, err)
 		}
 
-		hosts = append(hosts, line)
+		if len(fields) > 1 {
+			hostLabels, err := parseLabels(fields[1:])
+			if err != nil {
+				return nil, fmt.Errorf("invalid entry at line %d: %w", lineNum, err)
+			}
+			labels[host] = hostLabels
+		}
+
+		hosts = append(hosts, host)
 	}
 
 	if err := scanner.Err(); err != nil {
@@ -56,7 +76,69 @@ func ParseInventoryFile(path string) (*Inventory, error) {
 		return nil, fmt.Errorf("inventory file is empty (no valid hosts found)")
 	}
 
-	return &Inventory{Hosts: hosts}, nil
+	return &Inventory{Hosts: hosts, Labels: labels}, nil
+}
+
+// parseLabels parses key=value label fields
+func parseLabels(fields []string) (map[string]string, error) {
+	labels := make(map[string]string, len(fields))
+	for _, field := range fields {
+		key, value, err := parseLabel(field)
+		if err != nil {
+			return nil, err
+		}
+		labels[key] = value
+	}
+	return labels, nil
+}
+
+// parseLabel parses a single key=value label
+func parseLabel(field string) (string, string, error) {
+	key, value, ok := strings.Cut(field, "=")
+	if !ok || value == "" {
+		return "", "", fmt.Errorf("label '%s' must be in key=value form", field)
+	}
+	if !labelKeyPattern.MatchString(key) {
+		return "", "", fmt.Errorf("label key '%s' is invalid", key)
+	}
+	return key, value, nil
+}
+
+// ParseSelectors parses --limit selectors of the form key=value
+func ParseSelectors(selectors []string) (map[string]string, error) {
+	parsed := make(map[string]string, len(selectors))
+	for _, selector := range selectors {
+		key, value, err := parseLabel(strings.TrimSpace(selector))
+		if err != nil {
+			return nil, fmt.Errorf("invalid --limit selector: %w", err)
+		}
+		parsed[key] = value
+	}
+	return parsed, nil
+}
+
+// Limit returns the hosts whose labels match every selector. An empty
+// selector set matches all hosts.
+func (inv *Inventory) Limit(selectors map[string]string) *Inventory {
+	limited := &Inventory{Labels: make(map[string]map[string]string)}
+	for _, host := range inv.Hosts {
+		hostLabels := inv.Labels[host]
+		matches := true
+		for key, value := range selectors {
+			if hostLabels[key] != value {
+				matches = false
+				break
+			}
+		}
+		if !matches {
+			continue
+		}
+		limited.Hosts = append(limited.Hosts, host)
+		if hostLabels != nil {
+			limited.Labels[host] = hostLabels
+		}
+	}
+	return limited
 }
 
 // validateHost checks if a host entry is valid
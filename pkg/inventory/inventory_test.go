@@ -3,6 +3,7 @@ package inventory
 import (
 	"os"
 	"path/filepath"
+	"reflect"
 	"testing"
 )
 
@@ -258,6 +259,120 @@ db-server-01.example.com
 	}
 }
 
+func TestParseInventoryFile_Labels(t *testing.T) {
+	content := `# host followed by key=value labels
+web-01.example.com role=web datacenter=us-east
+web-02.example.com   role=web	datacenter=eu-west
+db-01.example.com role=db datacenter=us-east
+192.168.1.10
+`
+
+	tmpfile := createTempFile(t, content)
+	defer os.Remove(tmpfile)
+
+	inv, err := ParseInventoryFile(tmpfile)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expectedHosts := []string{"web-01.example.com", "web-02.example.com", "db-01.example.com", "192.168.1.10"}
+	if len(inv.Hosts) != len(expectedHosts) {
+		t.Fatalf("expected %d hosts, got %d: %v", len(expectedHosts), len(inv.Hosts), inv.Hosts)
+	}
+	for i, expected := range expectedHosts {
+		if inv.Hosts[i] != expected {
+			t.Errorf("host %d: expected %s, got %s", i, expected, inv.Hosts[i])
+		}
+	}
+
+	if got := inv.Labels["web-02.example.com"]; got["role"] != "web" || got["datacenter"] != "eu-west" {
+		t.Errorf("web-02 labels = %v, want role=web datacenter=eu-west", got)
+	}
+	if _, ok := inv.Labels["192.168.1.10"]; ok {
+		t.Errorf("host without labels should have no entry, got %v", inv.Labels["192.168.1.10"])
+	}
+}
+
+func TestParseInventoryFile_InvalidLabels(t *testing.T) {
+	testCases := []struct {
+		name    string
+		content string
+		errMsg  string
+	}{
+		{"missing value", "web-01 role=", "key=value"},
+		{"no equals sign", "web-01 web", "key=value"},
+		{"invalid key", "web-01 =web", "label key '' is invalid"},
+		{"key with symbols", "web-01 ro!e=web", "label key 'ro!e' is invalid"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tmpfile := createTempFile(t, "db-01\n"+tc.content)
+			defer os.Remove(tmpfile)
+
+			_, err := ParseInventoryFile(tmpfile)
+			if err == nil {
+				t.Fatal("expected error, got nil")
+			}
+			if !contains(err.Error(), "line 2") || !contains(err.Error(), tc.errMsg) {
+				t.Errorf("error = %q, want line 2 and %q", err.Error(), tc.errMsg)
+			}
+		})
+	}
+}
+
+func TestInventoryLimit(t *testing.T) {
+	inv := &Inventory{
+		Hosts: []string{"web-01", "web-02", "db-01", "bare"},
+		Labels: map[string]map[string]string{
+			"web-01": {"role": "web", "datacenter": "us-east"},
+			"web-02": {"role": "web", "datacenter": "eu-west"},
+			"db-01":  {"role": "db", "datacenter": "us-east"},
+		},
+	}
+
+	testCases := []struct {
+		name      string
+		selectors []string
+		expected  []string
+	}{
+		{"no selectors", nil, []string{"web-01", "web-02", "db-01", "bare"}},
+		{"single label", []string{"role=web"}, []string{"web-01", "web-02"}},
+		{"all labels must match", []string{"role=web", "datacenter=us-east"}, []string{"web-01"}},
+		{"no match", []string{"role=cache"}, nil},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			selectors, err := ParseSelectors(tc.selectors)
+			if err != nil {
+				t.Fatalf("ParseSelectors() error = %v", err)
+			}
+
+			limited := inv.Limit(selectors)
+			if len(limited.Hosts) != len(tc.expected) {
+				t.Fatalf("expected hosts %v, got %v", tc.expected, limited.Hosts)
+			}
+			for i, expected := range tc.expected {
+				if limited.Hosts[i] != expected {
+					t.Errorf("host %d: expected %s, got %s", i, expected, limited.Hosts[i])
+				}
+				if !reflect.DeepEqual(limited.Labels[expected], inv.Labels[expected]) {
+					t.Errorf("labels for %s not carried over: %v", expected, limited.Labels[expected])
+				}
+			}
+		})
+	}
+}
+
+func TestParseSelectors_Invalid(t *testing.T) {
+	for _, selector := range []string{"role", "role=", "=web"} {
+		if _, err := ParseSelectors([]string{selector}); err == nil {
+			t.Errorf("ParseSelectors(%q) expected error", selector)
+		}
+	}
+}
+
 // Helper function to create a temporary file with content
 func createTempFile(t *testing.T, content string) string {
 	t.Helper()
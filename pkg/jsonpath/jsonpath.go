@@ -0,0 +1,224 @@
+// Package jsonpath evaluates a small subset of JSONPath against decoded JSON
+// documents. Supported expressions are dotted keys, array indexes and
+// wildcards, e.g. $.Plugins.Volume[0], $["Server Version"] or $.items[*].name.
+// A wildcard selects every element of an array or every value of an object,
+// so a path can match several values.
+package jsonpath
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ErrNoMatch is returned when a path does not select any value
+var ErrNoMatch = errors.New("path not found")
+
+// segmentKind identifies what a path segment selects
+type segmentKind int
+
+const (
+	keySegment      segmentKind = iota // An object key
+	indexSegment                       // An array index
+	wildcardSegment                    // Every array element or object value
+)
+
+// segment is one step of a path
+type segment struct {
+	kind  segmentKind
+	key   string
+	index int
+}
+
+// Path is a compiled JSONPath expression
+type Path struct {
+	expr     string
+	segments []segment
+}
+
+// Compile parses a JSONPath expression. The leading "$" is optional.
+func Compile(expr string) (*Path, error) {
+	p := &Path{expr: expr}
+	s := strings.TrimSpace(expr)
+	s = strings.TrimPrefix(s, "$")
+
+	for s != "" {
+		switch s[0] {
+		case '.':
+			s = s[1:]
+			end := strings.IndexAny(s, ".[")
+			if end < 0 {
+				end = len(s)
+			}
+			if end == 0 {
+				return nil, fmt.Errorf("invalid path %q: empty key", expr)
+			}
+			if s[:end] == "*" {
+				p.segments = append(p.segments, segment{kind: wildcardSegment})
+			} else {
+				p.segments = append(p.segments, segment{kind: keySegment, key: s[:end]})
+			}
+			s = s[end:]
+		case '[':
+			end := strings.IndexByte(s, ']')
+			if end < 0 {
+				return nil, fmt.Errorf("invalid path %q: missing ]", expr)
+			}
+			inner := strings.TrimSpace(s[1:end])
+			s = s[end+1:]
+			if len(inner) >= 2 && (inner[0] == '"' || inner[0] == '\'') && inner[len(inner)-1] == inner[0] {
+				p.segments = append(p.segments, segment{kind: keySegment, key: inner[1 : len(inner)-1]})
+				continue
+			}
+			if inner == "*" {
+				p.segments = append(p.segments, segment{kind: wildcardSegment})
+				continue
+			}
+			index, err := strconv.Atoi(inner)
+			if err != nil || index < 0 {
+				return nil, fmt.Errorf("invalid path %q: bad index [%s]", expr, inner)
+			}
+			p.segments = append(p.segments, segment{kind: indexSegment, index: index})
+		default:
+			if len(p.segments) > 0 || strings.HasPrefix(strings.TrimSpace(expr), "$") {
+				return nil, fmt.Errorf("invalid path %q: unexpected %q", expr, s[0])
+			}
+			// Allow a bare first key such as "ServerVersion"
+			s = "." + s
+		}
+	}
+
+	return p, nil
+}
+
+// String returns the expression the path was compiled from
+func (p *Path) String() string {
+	return p.expr
+}
+
+// Evaluate returns the values selected by the path, formatted as strings.
+// Strings are returned unquoted, numbers keep their JSON representation and
+// objects or arrays are returned as compact JSON. Paths without wildcards
+// return a single value; a wildcard returns one value per match, skipping
+// elements the rest of the path does not apply to. ErrNoMatch is returned
+// when nothing matches.
+func (p *Path) Evaluate(doc interface{}) ([]string, error) {
+	current := []interface{}{doc}
+	for _, seg := range p.segments {
+		var next []interface{}
+		var firstErr error
+		for _, node := range current {
+			matched, err := p.step(node, seg)
+			if err != nil && firstErr == nil {
+				firstErr = err
+			}
+			next = append(next, matched...)
+		}
+		if len(next) == 0 {
+			if firstErr == nil {
+				firstErr = fmt.Errorf("%w: %s ([*] matched nothing)", ErrNoMatch, p.expr)
+			}
+			return nil, firstErr
+		}
+		current = next
+	}
+
+	values := make([]string, 0, len(current))
+	for _, node := range current {
+		value, err := format(node)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+	}
+	return values, nil
+}
+
+// step applies a single segment to node and returns the selected values
+func (p *Path) step(node interface{}, seg segment) ([]interface{}, error) {
+	switch seg.kind {
+	case keySegment:
+		obj, ok := node.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("%w: %s (%s is not an object)", ErrNoMatch, p.expr, seg.key)
+		}
+		value, ok := obj[seg.key]
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", ErrNoMatch, p.expr)
+		}
+		return []interface{}{value}, nil
+	case indexSegment:
+		arr, ok := node.([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("%w: %s ([%d] applied to a non-array)", ErrNoMatch, p.expr, seg.index)
+		}
+		if seg.index >= len(arr) {
+			return nil, fmt.Errorf("%w: %s (index %d out of range)", ErrNoMatch, p.expr, seg.index)
+		}
+		return []interface{}{arr[seg.index]}, nil
+	default:
+		switch v := node.(type) {
+		case []interface{}:
+			return v, nil
+		case map[string]interface{}:
+			// Object values are returned in key order so results are stable
+			keys := make([]string, 0, len(v))
+			for k := range v {
+				keys = append(keys, k)
+			}
+			sort.Strings(keys)
+			values := make([]interface{}, 0, len(keys))
+			for _, k := range keys {
+				values = append(values, v[k])
+			}
+			return values, nil
+		}
+		return nil, fmt.Errorf("%w: %s ([*] applied to a scalar)", ErrNoMatch, p.expr)
+	}
+}
+
+// Evaluate compiles expr and evaluates it against doc
+func Evaluate(doc interface{}, expr string) ([]string, error) {
+	p, err := Compile(expr)
+	if err != nil {
+		return nil, err
+	}
+	return p.Evaluate(doc)
+}
+
+// Decode parses a JSON document, keeping numbers in their original form
+func Decode(data []byte) (interface{}, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var doc interface{}
+	if err := dec.Decode(&doc); err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
+
+// format renders a decoded JSON value as a string
+func format(value interface{}) (string, error) {
+	switch v := value.(type) {
+	case nil:
+		return "null", nil
+	case string:
+		return v, nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	case json.Number:
+		return v.String(), nil
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	default:
+		data, err := json.Marshal(v)
+		if err != nil {
+			return "", err
+		}
+		return string(data), nil
+	}
+}
@@ -0,0 +1,188 @@
+package jsonpath
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+const testDoc = `{
+	"ServerVersion": "24.0.7",
+	"Containers": 12,
+	"Swarm": {"LocalNodeState": "inactive", "Managers": null},
+	"Plugins": {"Volume": ["local"], "Network": ["bridge", "host"]},
+	"Server Version": "legacy",
+	"Debug": false
+}`
+
+func TestEvaluate(t *testing.T) {
+	doc, err := Decode([]byte(testDoc))
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		expr    string
+		want    []string
+		wantErr error
+	}{
+		{name: "top-level string", expr: "$.ServerVersion", want: []string{"24.0.7"}},
+		{name: "without dollar", expr: "ServerVersion", want: []string{"24.0.7"}},
+		{name: "number keeps representation", expr: "$.Containers", want: []string{"12"}},
+		{name: "bool", expr: "$.Debug", want: []string{"false"}},
+		{name: "null", expr: "$.Swarm.Managers", want: []string{"null"}},
+		{name: "nested key", expr: "$.Swarm.LocalNodeState", want: []string{"inactive"}},
+		{name: "array index", expr: "$.Plugins.Network[1]", want: []string{"host"}},
+		{name: "quoted key", expr: `$["Server Version"]`, want: []string{"legacy"}},
+		{name: "array as JSON", expr: "$.Plugins.Volume", want: []string{`["local"]`}},
+		{name: "missing key", expr: "$.Swarm.NodeID", wantErr: ErrNoMatch},
+		{name: "index out of range", expr: "$.Plugins.Volume[3]", wantErr: ErrNoMatch},
+		{name: "key on scalar", expr: "$.ServerVersion.Major", wantErr: ErrNoMatch},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Evaluate(doc, tt.expr)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Evaluate(%q) error = %v, want %v", tt.expr, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Evaluate(%q) unexpected error: %v", tt.expr, err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Evaluate(%q) = %v, want %v", tt.expr, got, tt.want)
+			}
+		})
+	}
+}
+
+const podsDoc = `{
+	"kind": "List",
+	"items": [
+		{
+			"metadata": {"name": "api-0", "labels": {"app": "api", "tier": "backend"}},
+			"spec": {"containers": [{"name": "api", "image": "api:1.4"}, {"name": "proxy", "image": "envoy:1.29"}]},
+			"status": {"phase": "Running", "restarts": 0}
+		},
+		{
+			"metadata": {"name": "api-1", "labels": {"app": "api"}},
+			"spec": {"containers": [{"name": "api", "image": "api:1.3"}]},
+			"status": {"phase": "Pending"}
+		},
+		{
+			"metadata": {"name": "worker-0"},
+			"spec": {"containers": []},
+			"status": {"phase": "Running", "restarts": 2.5}
+		}
+	],
+	"empty": [],
+	"matrix": [[1, 2], [3, 4]]
+}`
+
+func TestEvaluateWildcards(t *testing.T) {
+	doc, err := Decode([]byte(podsDoc))
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		expr    string
+		want    []string
+		wantErr error
+	}{
+		{name: "wildcard over array", expr: "$.items[*].metadata.name", want: []string{"api-0", "api-1", "worker-0"}},
+		{name: "dotted wildcard", expr: "$.items.*.status.phase", want: []string{"Running", "Pending", "Running"}},
+		{name: "nested wildcards", expr: "$.items[*].spec.containers[*].image", want: []string{"api:1.4", "envoy:1.29", "api:1.3"}},
+		{name: "wildcard then index", expr: "$.items[*].spec.containers[0].name", want: []string{"api", "api"}},
+		{name: "index then wildcard", expr: "$.items[0].spec.containers[*].name", want: []string{"api", "proxy"}},
+		{name: "wildcard skips missing keys", expr: "$.items[*].status.restarts", want: []string{"0", "2.5"}},
+		{name: "wildcard over object values in key order", expr: "$.items[0].metadata.labels.*", want: []string{"api", "backend"}},
+		{name: "wildcard over nested arrays", expr: "$.matrix[*][1]", want: []string{"2", "4"}},
+		{name: "wildcard returns objects as JSON", expr: "$.items[1].spec.containers[*]", want: []string{`{"image":"api:1.3","name":"api"}`}},
+		{name: "bare key then wildcard", expr: "items[*].metadata.labels.app", want: []string{"api", "api"}},
+		{name: "wildcard over empty array", expr: "$.empty[*]", wantErr: ErrNoMatch},
+		{name: "wildcard with no matching key", expr: "$.items[*].metadata.namespace", wantErr: ErrNoMatch},
+		{name: "wildcard on scalar", expr: "$.kind[*]", wantErr: ErrNoMatch},
+		{name: "missing key before wildcard", expr: "$.pods[*].name", wantErr: ErrNoMatch},
+		{name: "index out of range after wildcard", expr: "$.items[*].spec.containers[2]", wantErr: ErrNoMatch},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Evaluate(doc, tt.expr)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Evaluate(%q) error = %v, want %v", tt.expr, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Evaluate(%q) unexpected error: %v", tt.expr, err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Evaluate(%q) = %v, want %v", tt.expr, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompiledPathReuse(t *testing.T) {
+	p, err := Compile("$.items[*].status.phase")
+	if err != nil {
+		t.Fatalf("Compile() error = %v", err)
+	}
+	if p.String() != "$.items[*].status.phase" {
+		t.Errorf("String() = %q", p.String())
+	}
+
+	for _, tt := range []struct {
+		doc  string
+		want []string
+	}{
+		{doc: `{"items": [{"status": {"phase": "Running"}}]}`, want: []string{"Running"}},
+		{doc: `{"items": [{"status": {"phase": "Failed"}}, {"status": {"phase": "Running"}}]}`, want: []string{"Failed", "Running"}},
+	} {
+		doc, err := Decode([]byte(tt.doc))
+		if err != nil {
+			t.Fatalf("Decode() error = %v", err)
+		}
+		got, err := p.Evaluate(doc)
+		if err != nil {
+			t.Fatalf("Evaluate() error = %v", err)
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Evaluate() = %v, want %v", got, tt.want)
+		}
+	}
+}
+
+func TestDecode(t *testing.T) {
+	doc, err := Decode([]byte(`{"big": 12345678901234567890, "pi": 3.14}`))
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	got, err := Evaluate(doc, "$.big")
+	if err != nil {
+		t.Fatalf("Evaluate() error = %v", err)
+	}
+	if got[0] != "12345678901234567890" {
+		t.Errorf("large number = %s, want it unchanged", got[0])
+	}
+
+	if _, err := Decode([]byte(`{"unterminated": `)); err == nil {
+		t.Error("Decode() expected error for invalid JSON")
+	}
+}
+
+func TestCompileErrors(t *testing.T) {
+	for _, expr := range []string{"$.", "$.a[", "$.a[x]", "$.a[-1]", "$a", "$.a[**]", "$.a..b"} {
+		if _, err := Compile(expr); err == nil {
+			t.Errorf("Compile(%q) expected error", expr)
+		}
+	}
+}
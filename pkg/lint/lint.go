@@ -0,0 +1,62 @@
+// Package lint checks parsed specs for suspicious patterns that pass
+// validation but are probably mistakes.
+package lint
+
+import (
+	"fmt"
+
+	"github.com/neilfarmer/platform-spec/pkg/core"
+)
+
+// Severity indicates how serious a finding is
+type Severity string
+
+const (
+	SeverityWarning Severity = "warning"
+	SeverityError   Severity = "error"
+)
+
+// Finding is a single problem reported by a rule
+type Finding struct {
+	Rule     string
+	Severity Severity
+	Test     string // Name of the offending test, empty for spec-wide findings
+	Message  string
+}
+
+// String formats the finding for display, e.g.
+// "warning [plain-http] http test 'login': ..."
+func (f Finding) String() string {
+	return fmt.Sprintf("%s [%s] %s", f.Severity, f.Rule, f.Message)
+}
+
+// Rule is a single lint check
+type Rule struct {
+	Name        string
+	Description string
+	Severity    Severity
+	Check       func(spec *core.Spec) []Finding // Returns findings with Test and Message set
+}
+
+// Lint runs rules against spec and returns their findings in rule order
+func Lint(spec *core.Spec, rules []Rule) []Finding {
+	var findings []Finding
+	for _, rule := range rules {
+		for _, f := range rule.Check(spec) {
+			f.Rule = rule.Name
+			f.Severity = rule.Severity
+			findings = append(findings, f)
+		}
+	}
+	return findings
+}
+
+// HasErrors reports whether any finding has error severity
+func HasErrors(findings []Finding) bool {
+	for _, f := range findings {
+		if f.Severity == SeverityError {
+			return true
+		}
+	}
+	return false
+}
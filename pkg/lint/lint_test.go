@@ -0,0 +1,204 @@
+package lint
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/neilfarmer/platform-spec/pkg/core"
+)
+
+func TestLint(t *testing.T) {
+	tests := []struct {
+		name      string
+		spec      core.Spec
+		wantRules []string
+	}{
+		{
+			name: "clean spec",
+			spec: core.Spec{
+				Variables: map[string]interface{}{"app": "web"},
+				Tests: core.Tests{
+					Packages: []core.PackageTest{
+						{Name: "nginx installed", Packages: []string{"nginx"}},
+					},
+					HTTP: []core.HTTPTest{
+						{Name: "login page", URL: "https://app.example.com/login", Contains: []string{"Sign in"}},
+						{Name: "local admin", URL: "http://127.0.0.1:8080/admin", Contains: []string{"${var.app}"}, Insecure: true},
+					},
+					Vault: []core.VaultTest{
+						{Name: "vault unsealed", Address: "https://vault.internal:8200", ExpectUnsealed: true},
+					},
+				},
+			},
+		},
+		{
+			name: "duplicate names within a category",
+			spec: core.Spec{
+				Tests: core.Tests{
+					Packages: []core.PackageTest{
+						{Name: "tools", Packages: []string{"curl"}},
+						{Name: "tools", Packages: []string{"jq"}},
+					},
+					Services: []core.ServiceTest{
+						{Name: "tools", Service: "cron", State: "running"},
+					},
+				},
+			},
+			wantRules: []string{"duplicate-name"},
+		},
+		{
+			name: "duplicate kubernetes names",
+			spec: core.Spec{
+				Tests: core.Tests{
+					Kubernetes: core.KubernetesTests{
+						Pods: []core.KubernetesPodTest{
+							{Name: "api", Pod: "api-0"},
+							{Name: "api", Pod: "api-1"},
+						},
+					},
+				},
+			},
+			wantRules: []string{"duplicate-name"},
+		},
+		{
+			name: "http test with only defaults",
+			spec: core.Spec{
+				Tests: core.Tests{
+					HTTP: []core.HTTPTest{
+						{Name: "health", URL: "https://app.example.com/health"},
+					},
+				},
+			},
+			wantRules: []string{"no-assertions"},
+		},
+		{
+			name: "sensitive endpoint over plain http",
+			spec: core.Spec{
+				Tests: core.Tests{
+					HTTP: []core.HTTPTest{
+						{Name: "sso", URL: "http://app.example.com/oauth/token", StatusCode: 401},
+					},
+				},
+			},
+			wantRules: []string{"plain-http"},
+		},
+		{
+			name: "vault over plain http with insecure tls",
+			spec: core.Spec{
+				Tests: core.Tests{
+					Vault: []core.VaultTest{
+						{Name: "vault", Address: "http://vault.internal:8200", Insecure: true},
+					},
+				},
+			},
+			wantRules: []string{"no-assertions", "plain-http", "insecure-tls"},
+		},
+		{
+			name: "rabbitmq credentials over plain http",
+			spec: core.Spec{
+				Tests: core.Tests{
+					MessageQueues: []core.MessageQueueTest{
+						{Name: "orders", Backend: "rabbitmq", Address: "http://mq.internal:15672", Topic: "orders", Password: "s3cret"},
+					},
+				},
+			},
+			wantRules: []string{"plain-http"},
+		},
+		{
+			name: "unused variable",
+			spec: core.Spec{
+				Variables: map[string]interface{}{"region": "us-east-1"},
+				Tests: core.Tests{
+					Packages: []core.PackageTest{
+						{Name: "nginx installed", Packages: []string{"nginx"}},
+					},
+				},
+			},
+			wantRules: []string{"unused-variable"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := tt.spec
+			if err := spec.Validate(); err != nil {
+				t.Fatalf("Validate() error = %v", err)
+			}
+
+			findings := Lint(&spec, DefaultRules())
+			if len(findings) != len(tt.wantRules) {
+				t.Fatalf("got %d findings %v, want rules %v", len(findings), findings, tt.wantRules)
+			}
+			for i, f := range findings {
+				if f.Rule != tt.wantRules[i] {
+					t.Errorf("finding %d rule = %s, want %s (%s)", i, f.Rule, tt.wantRules[i], f.Message)
+				}
+			}
+		})
+	}
+}
+
+func TestLint_ParsedVariables(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "spec.yaml")
+	content := `version: "1.0"
+variables:
+  pkg: nginx
+  region: us-east-1
+tests:
+  packages:
+    - name: "${var.pkg} installed"
+      packages: ["${var.pkg}"]
+`
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	spec, err := core.ParseSpec(path)
+	if err != nil {
+		t.Fatalf("ParseSpec() error = %v", err)
+	}
+
+	// Parsing substitutes pkg, which still counts as used
+	findings := Lint(spec, DefaultRules())
+	if len(findings) != 1 || findings[0].Rule != "unused-variable" || !strings.Contains(findings[0].Message, "'region'") {
+		t.Errorf("findings = %v, want only region unused", findings)
+	}
+}
+
+func TestHasErrors(t *testing.T) {
+	spec := &core.Spec{
+		Tests: core.Tests{
+			HTTP: []core.HTTPTest{
+				{Name: "health", URL: "https://app.example.com/health"},
+			},
+		},
+	}
+	if err := spec.Validate(); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+
+	findings := Lint(spec, DefaultRules())
+	if HasErrors(findings) {
+		t.Errorf("HasErrors() = true for warnings only: %v", findings)
+	}
+
+	spec.Tests.HTTP = append(spec.Tests.HTTP, spec.Tests.HTTP[0])
+	findings = Lint(spec, DefaultRules())
+	if !HasErrors(findings) {
+		t.Errorf("HasErrors() = false, want true for duplicate names: %v", findings)
+	}
+	for _, f := range findings {
+		if f.Rule == "duplicate-name" && f.Severity != SeverityError {
+			t.Errorf("duplicate-name severity = %s, want %s", f.Severity, SeverityError)
+		}
+	}
+}
+
+func TestFindingString(t *testing.T) {
+	f := Finding{Rule: "plain-http", Severity: SeverityWarning, Message: "http test 'x': uses http://"}
+	want := "warning [plain-http] http test 'x': uses http://"
+	if got := f.String(); got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,232 @@
+package lint
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/neilfarmer/platform-spec/pkg/core"
+	"gopkg.in/yaml.v3"
+)
+
+// sensitiveKeywords mark HTTP checks that likely carry credentials or guard access
+var sensitiveKeywords = []string{"login", "auth", "token", "password", "secret", "admin", "session", "credential"}
+
+// DefaultRules returns the built-in lint rules
+func DefaultRules() []Rule {
+	return []Rule{
+		{
+			Name:        "duplicate-name",
+			Description: "Two tests in the same category share a name, so their results cannot be told apart",
+			Severity:    SeverityError,
+			Check:       checkDuplicateNames,
+		},
+		{
+			Name:        "no-assertions",
+			Description: "A test only checks the defaults, e.g. an HTTP test without contains that expects a 200",
+			Severity:    SeverityWarning,
+			Check:       checkNoAssertions,
+		},
+		{
+			Name:        "plain-http",
+			Description: "A security-sensitive check talks to a remote host over http://",
+			Severity:    SeverityWarning,
+			Check:       checkPlainHTTP,
+		},
+		{
+			Name:        "insecure-tls",
+			Description: "insecure: true skips TLS verification for a non-local host",
+			Severity:    SeverityWarning,
+			Check:       checkInsecureTLS,
+		},
+		{
+			Name:        "unused-variable",
+			Description: "A spec variable is never referenced as ${var.name}",
+			Severity:    SeverityWarning,
+			Check:       checkUnusedVariables,
+		},
+	}
+}
+
+// checkDuplicateNames reports test names used more than once within a category
+func checkDuplicateNames(spec *core.Spec) []Finding {
+	var findings []Finding
+	walkCategories(reflect.ValueOf(spec.Tests), "", func(category string, tests reflect.Value) {
+		seen := make(map[string]bool)
+		for i := 0; i < tests.Len(); i++ {
+			name := tests.Index(i).FieldByName("Name").String()
+			if seen[name] {
+				findings = append(findings, Finding{
+					Test:    name,
+					Message: fmt.Sprintf("%s test '%s': name is used by more than one test", category, name),
+				})
+			}
+			seen[name] = true
+		}
+	})
+	return findings
+}
+
+// walkCategories calls fn for every test category slice in tests, recursing
+// into provider groups such as kubernetes. Categories are named by their YAML
+// keys, e.g. "http" or "kubernetes.pods".
+func walkCategories(tests reflect.Value, prefix string, fn func(category string, tests reflect.Value)) {
+	t := tests.Type()
+	for i := 0; i < t.NumField(); i++ {
+		key, _, _ := strings.Cut(t.Field(i).Tag.Get("yaml"), ",")
+		field := tests.Field(i)
+		switch field.Kind() {
+		case reflect.Slice:
+			fn(prefix+key, field)
+		case reflect.Struct:
+			walkCategories(field, prefix+key+".", fn)
+		}
+	}
+}
+
+// checkNoAssertions reports tests that only verify default expectations
+func checkNoAssertions(spec *core.Spec) []Finding {
+	var findings []Finding
+	for _, test := range spec.Tests.HTTP {
+		if test.StatusCode == 200 && len(test.Contains) == 0 {
+			findings = append(findings, Finding{
+				Test:    test.Name,
+				Message: fmt.Sprintf("http test '%s': only checks for a 200 response; add contains to verify the body", test.Name),
+			})
+		}
+	}
+	for _, test := range spec.Tests.Vault {
+		if !test.ExpectUnsealed && !test.ExpectInitialized && !test.ExpectActive {
+			findings = append(findings, Finding{
+				Test:    test.Name,
+				Message: fmt.Sprintf("vault test '%s': only checks that Vault responds; set expect_unsealed, expect_initialized or expect_active", test.Name),
+			})
+		}
+	}
+	return findings
+}
+
+// checkPlainHTTP reports security-sensitive checks sent unencrypted to remote hosts
+func checkPlainHTTP(spec *core.Spec) []Finding {
+	var findings []Finding
+	for _, test := range spec.Tests.HTTP {
+		u, ok := remotePlainHTTP(test.URL)
+		if !ok {
+			continue
+		}
+		if keyword := sensitiveKeyword(test.Name + " " + u.Path + " " + u.RawQuery); keyword != "" {
+			findings = append(findings, Finding{
+				Test:    test.Name,
+				Message: fmt.Sprintf("http test '%s': %s looks security-sensitive (%q) but uses http://", test.Name, test.URL, keyword),
+			})
+		}
+	}
+	for _, test := range spec.Tests.Vault {
+		if _, ok := remotePlainHTTP(test.Address); ok {
+			findings = append(findings, Finding{
+				Test:    test.Name,
+				Message: fmt.Sprintf("vault test '%s': %s uses http://", test.Name, test.Address),
+			})
+		}
+	}
+	for _, test := range spec.Tests.MessageQueues {
+		if test.Backend != "rabbitmq" || test.Password == "" {
+			continue
+		}
+		if _, ok := remotePlainHTTP(test.Address); ok {
+			findings = append(findings, Finding{
+				Test:    test.Name,
+				Message: fmt.Sprintf("message queue test '%s': credentials are sent to %s over http://", test.Name, test.Address),
+			})
+		}
+	}
+	return findings
+}
+
+// checkInsecureTLS reports insecure: true on tests against non-local hosts
+func checkInsecureTLS(spec *core.Spec) []Finding {
+	var findings []Finding
+	for _, test := range spec.Tests.HTTP {
+		if test.Insecure && !isLocalURL(test.URL) {
+			findings = append(findings, Finding{
+				Test:    test.Name,
+				Message: fmt.Sprintf("http test '%s': insecure: true skips TLS verification for %s", test.Name, test.URL),
+			})
+		}
+	}
+	for _, test := range spec.Tests.Vault {
+		if test.Insecure && !isLocalURL(test.Address) {
+			findings = append(findings, Finding{
+				Test:    test.Name,
+				Message: fmt.Sprintf("vault test '%s': insecure: true skips TLS verification for %s; set ca_cert instead", test.Name, test.Address),
+			})
+		}
+	}
+	return findings
+}
+
+// checkUnusedVariables reports variables that no test references
+func checkUnusedVariables(spec *core.Spec) []Finding {
+	if len(spec.Variables) == 0 {
+		return nil
+	}
+	data, err := yaml.Marshal(spec.Tests)
+	if err != nil {
+		return nil
+	}
+	body := string(data)
+
+	names := make([]string, 0, len(spec.Variables))
+	for name := range spec.Variables {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var findings []Finding
+	for _, name := range names {
+		if !spec.UsesVariable(name) && !strings.Contains(body, "${var."+name+"}") {
+			findings = append(findings, Finding{
+				Message: fmt.Sprintf("variable '%s' is never used", name),
+			})
+		}
+	}
+	return findings
+}
+
+// remotePlainHTTP parses rawURL and reports whether it is an http:// URL for a non-local host
+func remotePlainHTTP(rawURL string) (*url.URL, bool) {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Scheme != "http" {
+		return nil, false
+	}
+	return u, !isLocalHost(u.Hostname())
+}
+
+// isLocalURL reports whether rawURL points at the loopback interface
+func isLocalURL(rawURL string) bool {
+	u, err := url.Parse(rawURL)
+	return err == nil && isLocalHost(u.Hostname())
+}
+
+// isLocalHost reports whether host is localhost or a loopback address
+func isLocalHost(host string) bool {
+	if strings.EqualFold(host, "localhost") {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// sensitiveKeyword returns the first sensitive keyword found in s
+func sensitiveKeyword(s string) string {
+	s = strings.ToLower(s)
+	for _, keyword := range sensitiveKeywords {
+		if strings.Contains(s, keyword) {
+			return keyword
+		}
+	}
+	return ""
+}
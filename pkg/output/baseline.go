@@ -0,0 +1,85 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/neilfarmer/platform-spec/pkg/core"
+)
+
+// baselineVersion is bumped when the baseline file format changes
+const baselineVersion = 1
+
+// Baseline is a normalized snapshot of a run's results. Volatile data such as
+// durations, timestamps and messages (which often embed measured values) is
+// left out, so two runs with the same outcomes produce identical files.
+type Baseline struct {
+	Version int            `json:"version"`
+	Specs   []BaselineSpec `json:"specs"`
+}
+
+// BaselineSpec holds the results of one spec against one target
+type BaselineSpec struct {
+	Target   string           `json:"target"`
+	Spec     string           `json:"spec"`
+	SpecHash string           `json:"spec_hash"`
+	Results  []BaselineResult `json:"results"`
+}
+
+// BaselineResult is the outcome of a single test
+type BaselineResult struct {
+	Name   string      `json:"name"`
+	Status core.Status `json:"status"`
+}
+
+// NewBaseline normalizes results into a baseline. Specs are sorted by target
+// and spec name so parallel runs, which finish hosts in any order, compare
+// equal; tests keep their execution order.
+func NewBaseline(results []*core.TestResults) *Baseline {
+	baseline := &Baseline{Version: baselineVersion, Specs: []BaselineSpec{}}
+	for _, tr := range results {
+		spec := BaselineSpec{
+			Target:   tr.Target,
+			Spec:     tr.SpecName,
+			SpecHash: tr.SpecHash,
+			Results:  make([]BaselineResult, 0, len(tr.Results)),
+		}
+		for _, r := range tr.Results {
+			spec.Results = append(spec.Results, BaselineResult{Name: r.Name, Status: r.Status})
+		}
+		baseline.Specs = append(baseline.Specs, spec)
+	}
+
+	sort.SliceStable(baseline.Specs, func(i, j int) bool {
+		a, b := baseline.Specs[i], baseline.Specs[j]
+		if a.Target != b.Target {
+			return a.Target < b.Target
+		}
+		return a.Spec < b.Spec
+	})
+	return baseline
+}
+
+// FormatBaseline renders results as an indented baseline JSON document
+func FormatBaseline(results []*core.TestResults) ([]byte, error) {
+	data, err := json.MarshalIndent(NewBaseline(results), "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode baseline: %w", err)
+	}
+	return append(data, '\n'), nil
+}
+
+// WriteBaseline writes the baseline for results to path
+func WriteBaseline(path string, results []*core.TestResults) error {
+	data, err := FormatBaseline(results)
+	if err != nil {
+		return err
+	}
+	// #nosec G306 -- Baselines are meant to be committed and shared, not secret
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write baseline: %w", err)
+	}
+	return nil
+}
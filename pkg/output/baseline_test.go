@@ -0,0 +1,113 @@
+package output
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/neilfarmer/platform-spec/pkg/core"
+)
+
+// baselineRun builds a two-host run; timing varies with offset, outcomes do not
+func baselineRun(offset time.Duration, nginxStatus core.Status, hostsReversed bool) []*core.TestResults {
+	web := &core.TestResults{
+		SpecName:  "Web",
+		SpecHash:  "abc123",
+		Target:    "root@web1",
+		StartTime: time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC).Add(offset),
+		Duration:  2*time.Second + offset,
+		Results: []core.Result{
+			{Name: "nginx installed", Status: core.StatusPass, Message: "Package nginx is installed", Duration: 300*time.Millisecond + offset},
+			{Name: "nginx running", Status: nginxStatus, Message: "Service nginx checked", Duration: 120*time.Millisecond + offset},
+		},
+	}
+	db := &core.TestResults{
+		SpecName:  "Database",
+		SpecHash:  "def456",
+		Target:    "root@db1",
+		StartTime: time.Date(2026, 3, 1, 12, 0, 1, 0, time.UTC).Add(offset),
+		Duration:  time.Second + offset,
+		Results: []core.Result{
+			{Name: "disk usage", Status: core.StatusPass, Message: "Usage is 42%", Duration: 80*time.Millisecond + offset},
+		},
+	}
+	if hostsReversed {
+		return []*core.TestResults{db, web}
+	}
+	return []*core.TestResults{web, db}
+}
+
+func TestFormatBaseline_IgnoresVolatileFields(t *testing.T) {
+	first, err := FormatBaseline(baselineRun(0, core.StatusPass, false))
+	if err != nil {
+		t.Fatalf("FormatBaseline() error = %v", err)
+	}
+	// Same outcomes, different durations and timestamps, hosts finished in another order
+	second, err := FormatBaseline(baselineRun(1337*time.Millisecond, core.StatusPass, true))
+	if err != nil {
+		t.Fatalf("FormatBaseline() error = %v", err)
+	}
+
+	if string(first) != string(second) {
+		t.Errorf("baselines differ for runs with identical outcomes:\n%s\n---\n%s", first, second)
+	}
+	for _, volatile := range []string{"duration", "start", "2026-03-01", "message"} {
+		if strings.Contains(strings.ToLower(string(first)), volatile) {
+			t.Errorf("baseline contains volatile field %q:\n%s", volatile, first)
+		}
+	}
+}
+
+func TestFormatBaseline_StatusChange(t *testing.T) {
+	passing, err := FormatBaseline(baselineRun(0, core.StatusPass, false))
+	if err != nil {
+		t.Fatalf("FormatBaseline() error = %v", err)
+	}
+	failing, err := FormatBaseline(baselineRun(0, core.StatusFail, false))
+	if err != nil {
+		t.Fatalf("FormatBaseline() error = %v", err)
+	}
+	if string(passing) == string(failing) {
+		t.Error("baselines should differ when a test's status changes")
+	}
+	if !strings.Contains(string(failing), `"status": "failed"`) {
+		t.Errorf("baseline does not record the failure:\n%s", failing)
+	}
+}
+
+func TestNewBaseline_SortsSpecs(t *testing.T) {
+	baseline := NewBaseline(baselineRun(0, core.StatusPass, false))
+	if len(baseline.Specs) != 2 {
+		t.Fatalf("got %d specs, want 2", len(baseline.Specs))
+	}
+	if baseline.Specs[0].Target != "root@db1" || baseline.Specs[1].Target != "root@web1" {
+		t.Errorf("specs not sorted by target: %s, %s", baseline.Specs[0].Target, baseline.Specs[1].Target)
+	}
+	web := baseline.Specs[1]
+	if web.Results[0].Name != "nginx installed" || web.Results[1].Name != "nginx running" {
+		t.Errorf("tests should keep execution order, got %+v", web.Results)
+	}
+}
+
+func TestWriteBaseline(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "baseline.json")
+	results := baselineRun(0, core.StatusPass, false)
+	if err := WriteBaseline(path, results); err != nil {
+		t.Fatalf("WriteBaseline() error = %v", err)
+	}
+
+	got, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("reading baseline: %v", err)
+	}
+	want, _ := FormatBaseline(results)
+	if string(got) != string(want) {
+		t.Errorf("written baseline = %s, want %s", got, want)
+	}
+
+	if err := WriteBaseline(filepath.Join(t.TempDir(), "missing", "baseline.json"), results); err == nil {
+		t.Error("WriteBaseline() expected error for a missing directory")
+	}
+}
@@ -0,0 +1,44 @@
+package output
+
+import (
+	"strings"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/neilfarmer/platform-spec/pkg/core"
+)
+
+// FormatCatalog formats the supported test categories as one table of fields
+// per category
+func FormatCatalog(categories []core.CategoryInfo) string {
+	var sb strings.Builder
+	for i, c := range categories {
+		if i > 0 {
+			sb.WriteString("\n")
+		}
+		sb.WriteString(applyColor(colorBold, c.Category) + "\n")
+
+		t := table.NewWriter()
+		t.SetOutputMirror(&sb)
+		t.SetStyle(table.StyleDefault)
+		t.AppendHeader(table.Row{"Field", "Type", "Required", "Allowed Values", "Default"})
+		for _, f := range c.Fields {
+			required := ""
+			if f.Required {
+				required = "yes"
+			}
+			t.AppendRow(table.Row{f.Name, f.Type, required, strings.Join(f.Enum, ", "), f.Default})
+		}
+		t.Render()
+
+		for _, group := range c.RequiresOneOf {
+			sb.WriteString("Requires one of: " + strings.Join(group, ", ") + "\n")
+		}
+	}
+	return sb.String()
+}
+
+// FormatCatalogJSON formats the supported test categories as an indented JSON
+// array
+func FormatCatalogJSON(categories []core.CategoryInfo) (string, error) {
+	return marshalJSON(categories)
+}
@@ -0,0 +1,54 @@
+package output
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/neilfarmer/platform-spec/pkg/core"
+)
+
+// FormatComparison formats the tests whose outcome differs between two hosts
+// as a table with one status column per host
+func FormatComparison(targetA, targetB string, diffs []core.ResultDiff) string {
+	var sb strings.Builder
+	sb.WriteString("\n")
+	sb.WriteString(strings.Repeat("=", 80) + "\n")
+	sb.WriteString(fmt.Sprintf("Comparing %s and %s\n", targetA, targetB))
+	sb.WriteString(strings.Repeat("=", 80) + "\n\n")
+
+	if len(diffs) == 0 {
+		sb.WriteString(applyColor(colorGreen, "No drift: every test has the same status on both hosts") + "\n")
+		return sb.String()
+	}
+
+	t := table.NewWriter()
+	t.SetOutputMirror(&sb)
+	t.SetStyle(table.StyleDefault)
+	t.AppendHeader(table.Row{"Spec", "Test", targetA, targetB})
+	for _, d := range diffs {
+		t.AppendRow(table.Row{d.Spec, d.Name, comparisonStatus(d.StatusA), comparisonStatus(d.StatusB)})
+	}
+	t.Render()
+
+	sb.WriteString(applyColor(colorRed, fmt.Sprintf("\nDrift: %d test(s) differ between %s and %s", len(diffs), targetA, targetB)) + "\n")
+	for _, d := range diffs {
+		sb.WriteString(fmt.Sprintf("  %s: %s\n", d.Name, d.Describe(targetA, targetB)))
+		if d.MessageA != "" {
+			sb.WriteString(fmt.Sprintf("    %s: %s\n", targetA, d.MessageA))
+		}
+		if d.MessageB != "" {
+			sb.WriteString(fmt.Sprintf("    %s: %s\n", targetB, d.MessageB))
+		}
+	}
+	return sb.String()
+}
+
+// comparisonStatus renders a status in a comparison table, where an empty
+// status means the host did not report the test
+func comparisonStatus(status core.Status) string {
+	if status == "" {
+		return applyColor(colorYellow, "missing")
+	}
+	return applyColor(getStatusColor(status), string(status))
+}
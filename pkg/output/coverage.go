@@ -0,0 +1,40 @@
+package output
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/neilfarmer/platform-spec/pkg/core"
+)
+
+// ShowCoverage adds a per-category coverage summary to human and JSON output
+var ShowCoverage = false
+
+// FormatCoverage formats a coverage report as a table of result counts per
+// test category. Categories whose tests were all skipped are highlighted,
+// since they usually point at a spec that does not exercise what was intended.
+func FormatCoverage(report core.CoverageReport) string {
+	var sb strings.Builder
+	sb.WriteString("\n")
+	sb.WriteString(strings.Repeat("=", 80) + "\n")
+	sb.WriteString("Coverage\n")
+	sb.WriteString(strings.Repeat("=", 80) + "\n\n")
+
+	t := table.NewWriter()
+	t.SetOutputMirror(&sb)
+	t.SetStyle(table.StyleDefault)
+	t.AppendHeader(table.Row{"Category", "Total", "Passed", "Failed", "Skipped", "Errors"})
+	for _, c := range report.Categories {
+		category := c.Category
+		if c.Total > 0 && c.Skipped == c.Total {
+			category = applyColor(colorYellow, category+" (all skipped)")
+		}
+		t.AppendRow(table.Row{category, c.Total, c.Passed, c.Failed, c.Skipped, c.Errors})
+	}
+	t.AppendFooter(table.Row{"Total", report.Total.Total, report.Total.Passed, report.Total.Failed, report.Total.Skipped, report.Total.Errors})
+	t.Render()
+
+	sb.WriteString(fmt.Sprintf("\nAssertions evaluated: %d of %d\n", report.Evaluated, report.Total.Total))
+	return sb.String()
+}
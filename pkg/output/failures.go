@@ -0,0 +1,95 @@
+package output
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/neilfarmer/platform-spec/pkg/core"
+)
+
+// ShowRepresentativeFailures adds the first failure of each test across hosts
+// to human output
+var ShowRepresentativeFailures = false
+
+// representativeFailure is the first failure of a test across hosts
+type representativeFailure struct {
+	name    string
+	host    string
+	message string
+	hosts   int // Hosts on which the test failed
+}
+
+// FormatRepresentativeFailures formats, for each test that failed or errored
+// anywhere, the first host it failed on and that host's message, along with
+// how many hosts it failed on. Tests are listed in the order they first
+// failed, so triage can start from one representative failure per test
+// rather than every host's copy of it.
+func FormatRepresentativeFailures(results *core.MultiHostResults) string {
+	var sb strings.Builder
+	sb.WriteString("\n")
+	sb.WriteString(strings.Repeat("=", 80) + "\n")
+	sb.WriteString("First Failures\n")
+	sb.WriteString(strings.Repeat("=", 80) + "\n\n")
+
+	failures := representativeFailures(results)
+	if len(failures) == 0 {
+		sb.WriteString("No tests failed\n")
+		return sb.String()
+	}
+
+	t := table.NewWriter()
+	t.SetOutputMirror(&sb)
+	t.SetStyle(table.StyleDefault)
+	t.AppendHeader(table.Row{"Test", "First Host", "Hosts", "Message"})
+	tested := testedHosts(results)
+	for _, f := range failures {
+		t.AppendRow(table.Row{
+			f.name,
+			f.host,
+			fmt.Sprintf("%d of %d", f.hosts, tested),
+			applyColor(colorRed, truncateLines(f.message, MaxOutputLines)),
+		})
+	}
+	t.Render()
+	return sb.String()
+}
+
+// representativeFailures returns the first failure of each test by name, in
+// host and then result order
+func representativeFailures(results *core.MultiHostResults) []*representativeFailure {
+	var failures []*representativeFailure
+	byName := make(map[string]*representativeFailure)
+	for _, host := range results.Hosts {
+		counted := make(map[string]bool)
+		for _, spec := range host.SpecResults {
+			for _, r := range spec.Results {
+				if r.Status != core.StatusFail && r.Status != core.StatusError {
+					continue
+				}
+				failure, ok := byName[r.Name]
+				if !ok {
+					failure = &representativeFailure{name: r.Name, host: host.Target, message: r.Message}
+					byName[r.Name] = failure
+					failures = append(failures, failure)
+				}
+				if !counted[r.Name] {
+					counted[r.Name] = true
+					failure.hosts++
+				}
+			}
+		}
+	}
+	return failures
+}
+
+// testedHosts counts the hosts that ran tests
+func testedHosts(results *core.MultiHostResults) int {
+	count := 0
+	for _, host := range results.Hosts {
+		if host.Connected && !host.Skipped {
+			count++
+		}
+	}
+	return count
+}
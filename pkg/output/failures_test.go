@@ -0,0 +1,74 @@
+package output
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/neilfarmer/platform-spec/pkg/core"
+)
+
+func TestFormatRepresentativeFailures(t *testing.T) {
+	NoColor = true
+	defer func() { NoColor = false }()
+
+	results := &core.MultiHostResults{
+		Hosts: []*core.HostResults{
+			{
+				Target:    "root@web1",
+				Connected: true,
+				SpecResults: []*core.TestResults{{Results: []core.Result{
+					{Name: "nginx installed", Status: core.StatusPass},
+					{Name: "nginx running", Status: core.StatusFail, Message: "Service nginx is inactive on web1"},
+				}}},
+			},
+			{Target: "root@web2", ConnectionError: errors.New("dial tcp: connection refused")},
+			{
+				Target:    "root@web3",
+				Connected: true,
+				SpecResults: []*core.TestResults{{Results: []core.Result{
+					{Name: "nginx installed", Status: core.StatusFail, Message: "Package nginx is not installed"},
+					{Name: "nginx running", Status: core.StatusFail, Message: "Service nginx is inactive on web3"},
+				}}},
+			},
+			{
+				Target:    "root@web4",
+				Connected: true,
+				SpecResults: []*core.TestResults{{Results: []core.Result{
+					{Name: "nginx running", Status: core.StatusError, Message: "systemctl: command not found"},
+					{Name: "port 443", Status: core.StatusSkip},
+				}}},
+			},
+		},
+	}
+
+	got := FormatRepresentativeFailures(results)
+	for _, want := range []string{
+		"First Failures",
+		"| nginx running   | root@web1  | 3 of 3 | Service nginx is inactive on web1 |",
+		"| nginx installed | root@web3  | 1 of 3 | Package nginx is not installed    |",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("FormatRepresentativeFailures() missing %q in\n%s", want, got)
+		}
+	}
+	for _, unwanted := range []string{"inactive on web3", "command not found", "port 443", "root@web2"} {
+		if strings.Contains(got, unwanted) {
+			t.Errorf("FormatRepresentativeFailures() shows %q, want only the first failure of each test:\n%s", unwanted, got)
+		}
+	}
+	if strings.Index(got, "nginx running") > strings.Index(got, "nginx installed") {
+		t.Errorf("tests are not in the order they first failed:\n%s", got)
+	}
+}
+
+func TestFormatRepresentativeFailures_NoFailures(t *testing.T) {
+	results := &core.MultiHostResults{Hosts: []*core.HostResults{{
+		Target:      "root@web1",
+		Connected:   true,
+		SpecResults: []*core.TestResults{{Results: []core.Result{{Name: "nginx installed", Status: core.StatusPass}}}},
+	}}}
+	if got := FormatRepresentativeFailures(results); !strings.HasSuffix(got, "No tests failed\n") {
+		t.Errorf("FormatRepresentativeFailures() = %q, want it to report no failures", got)
+	}
+}
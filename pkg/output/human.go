@@ -3,6 +3,7 @@ package output
 import (
 	"fmt"
 	"os"
+	"sort"
 	"strings"
 
 	"github.com/jedib0t/go-pretty/v6/table"
@@ -48,15 +49,16 @@ func FormatHuman(results *core.TestResults) string {
 	for _, result := range results.Results {
 		symbol := getStatusSymbol(result.Status)
 		color := getStatusColor(result.Status)
-		sb.WriteString(fmt.Sprintf("%s (%.2fs)\n",
-			applyColor(color, symbol+" "+result.Name), result.Duration.Seconds()))
+		sb.WriteString(fmt.Sprintf("%s (%s)\n",
+			applyColor(color, symbol+" "+result.Name), formatTiming(result)))
 
 		if result.Message != "" && result.Status != core.StatusPass {
-			sb.WriteString(fmt.Sprintf("  %s\n", applyColor(color, result.Message)))
+			sb.WriteString(fmt.Sprintf("  %s\n", applyColor(color, truncateLines(result.Message, MaxOutputLines))))
 		}
 	}
 
 	sb.WriteString("\n")
+	sb.WriteString(formatSkipped(results.Results))
 
 	// Summary
 	_, passed, failed, skipped, errors := results.Summary()
@@ -87,9 +89,16 @@ func FormatMultiHostHuman(results *core.MultiHostResults) string {
 
 		sb.WriteString(strings.Repeat("=", 40) + "\n")
 		sb.WriteString(fmt.Sprintf("Testing: %s\n", host.Target))
+		if len(host.Labels) > 0 {
+			sb.WriteString(fmt.Sprintf("Labels: %s\n", formatLabels(host.Labels)))
+		}
 		sb.WriteString(strings.Repeat("=", 40) + "\n\n")
 
-		if !host.Connected {
+		if host.Skipped {
+			sb.WriteString(applyColor(colorYellow, "Not tested: the run halted at an earlier failing host\n"))
+			sb.WriteString(applyColor(colorBold+colorYellow, "○ SKIPPED"))
+			sb.WriteString("\n")
+		} else if !host.Connected {
 			// Connection error
 			sb.WriteString(applyColor(colorRed, fmt.Sprintf("Connection failed: %v\n", host.ConnectionError)))
 			sb.WriteString(applyColor(colorBold+colorRed, "❌ FAILED"))
@@ -105,15 +114,16 @@ func FormatMultiHostHuman(results *core.MultiHostResults) string {
 				for _, result := range specResult.Results {
 					symbol := getStatusSymbol(result.Status)
 					color := getStatusColor(result.Status)
-					sb.WriteString(fmt.Sprintf("%s (%.2fs)\n",
-						applyColor(color, symbol+" "+result.Name), result.Duration.Seconds()))
+					sb.WriteString(fmt.Sprintf("%s (%s)\n",
+						applyColor(color, symbol+" "+result.Name), formatTiming(result)))
 
 					if result.Message != "" && result.Status != core.StatusPass {
-						sb.WriteString(fmt.Sprintf("  %s\n", applyColor(color, result.Message)))
+						sb.WriteString(fmt.Sprintf("  %s\n", applyColor(color, truncateLines(result.Message, MaxOutputLines))))
 					}
 				}
 
 				sb.WriteString("\n")
+				sb.WriteString(formatSkipped(specResult.Results))
 
 				// Summary for this spec
 				_, passed, failed, skipped, errors := specResult.Summary()
@@ -123,6 +133,10 @@ func FormatMultiHostHuman(results *core.MultiHostResults) string {
 				sb.WriteString("\n")
 			}
 
+			if host.TimedOut {
+				sb.WriteString(applyColor(colorRed, "Timed out before all specs finished\n"))
+			}
+
 			// Overall status for this host
 			if host.Success() {
 				sb.WriteString(applyColor(colorBold+colorGreen, "✅ PASSED"))
@@ -146,6 +160,9 @@ func FormatMultiHostHuman(results *core.MultiHostResults) string {
 	if connectionErrors > 0 {
 		sb.WriteString(fmt.Sprintf("Connection errors: %d\n", connectionErrors))
 	}
+	if skippedHosts := results.SkippedHosts(); skippedHosts > 0 {
+		sb.WriteString(fmt.Sprintf("Skipped: %d\n", skippedHosts))
+	}
 
 	// Results table using go-pretty
 	sb.WriteString("\n")
@@ -188,7 +205,10 @@ func FormatMultiHostHuman(results *core.MultiHostResults) string {
 		status := ""
 		details := ""
 
-		if !host.Connected {
+		if host.Skipped {
+			status = applyColor(colorBold+colorYellow, "SKIPPED")
+			details = applyColor(colorYellow, "• Not tested (halted)")
+		} else if !host.Connected {
 			// Connection error - simplified message
 			status = applyColor(colorBold+colorRed, "FAILED")
 			details = applyColor(colorRed, "• Unable to connect via SSH")
@@ -202,6 +222,9 @@ func FormatMultiHostHuman(results *core.MultiHostResults) string {
 
 			// Collect failed and error test names
 			var failedTests []string
+			if host.TimedOut {
+				failedTests = append(failedTests, "Timed out")
+			}
 
 			for _, specResult := range host.SpecResults {
 				for _, result := range specResult.Results {
@@ -229,6 +252,49 @@ func FormatMultiHostHuman(results *core.MultiHostResults) string {
 	return sb.String()
 }
 
+// formatSkipped lists skipped tests grouped by reason, in order of first
+// appearance, so users can confirm skips were intended. Returns "" if
+// nothing was skipped.
+func formatSkipped(results []core.Result) string {
+	var reasons []core.SkipReason
+	names := make(map[core.SkipReason][]string)
+	for _, result := range results {
+		if result.Status != core.StatusSkip {
+			continue
+		}
+		reason := result.SkipReason
+		if reason == "" {
+			reason = "other"
+		}
+		if _, seen := names[reason]; !seen {
+			reasons = append(reasons, reason)
+		}
+		names[reason] = append(names[reason], result.Name)
+	}
+	if len(reasons) == 0 {
+		return ""
+	}
+
+	var sb strings.Builder
+	sb.WriteString("Skipped tests:\n")
+	for _, reason := range reasons {
+		sb.WriteString(fmt.Sprintf("  %s (%d): %s\n", applyColor(colorYellow, string(reason)),
+			len(names[reason]), strings.Join(names[reason], ", ")))
+	}
+	sb.WriteString("\n")
+	return sb.String()
+}
+
+// formatLabels renders labels as sorted key=value pairs
+func formatLabels(labels map[string]string) string {
+	pairs := make([]string, 0, len(labels))
+	for key, value := range labels {
+		pairs = append(pairs, key+"="+value)
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ", ")
+}
+
 // wrapText wraps text to fit within a specified width, preserving ANSI color codes
 func wrapText(text string, width int) []string {
 	if width <= 0 {
@@ -369,3 +435,13 @@ func PrintPassed() string {
 func ClearProgressLine() {
 	fmt.Fprint(os.Stderr, "\r\033[K") // Carriage return + clear line
 }
+
+// formatTiming describes how long a test took and, if it was checked more
+// than once, on which of its attempts it finished
+func formatTiming(result core.Result) string {
+	timing := fmt.Sprintf("%.2fs", result.Duration.Seconds())
+	if result.Attempts > 1 {
+		timing += fmt.Sprintf(", attempt %d/%d", result.Attempts, result.MaxAttempts)
+	}
+	return timing
+}
@@ -97,8 +97,41 @@ func TestFormatHuman(t *testing.T) {
 				"FAILED",
 			},
 		},
+		{
+			name: "skip reasons",
+			results: &core.TestResults{
+				Results: []core.Result{
+					{Name: "nginx installed", Status: core.StatusSkip, SkipReason: core.SkipUnsupportedPlatform},
+					{Name: "Service running", Status: core.StatusPass},
+					{Name: "deploy user", Status: core.StatusSkip, SkipReason: core.SkipUnsupportedPlatform},
+					{Name: "legacy", Status: core.StatusSkip},
+				},
+			},
+			contains: []string{
+				"Skipped tests:\n",
+				"unsupported platform (2): nginx installed, deploy user\n",
+				"other (1): legacy\n",
+				"1 passed, 0 failed, 3 skipped",
+			},
+		},
+		{
+			name: "retried tests",
+			results: &core.TestResults{
+				Results: []core.Result{
+					{Name: "API up", Status: core.StatusPass, Duration: 1500 * time.Millisecond, Attempts: 3, MaxAttempts: 3},
+					{Name: "DB up", Status: core.StatusPass, Duration: 100 * time.Millisecond, Attempts: 1, MaxAttempts: 3},
+				},
+			},
+			contains: []string{
+				"✓ API up (1.50s, attempt 3/3)\n",
+				"✓ DB up (0.10s)\n",
+			},
+		},
 	}
 
+	NoColor = true
+	defer func() { NoColor = false }()
+
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			output := FormatHuman(tt.results)
@@ -509,6 +542,28 @@ func TestFormatMultiHostHuman(t *testing.T) {
 				"Failed: 0",
 			},
 		},
+		{
+			name: "host labels",
+			results: &core.MultiHostResults{
+				Hosts: []*core.HostResults{
+					{
+						Target:    "ubuntu@web-01.example.com",
+						Labels:    map[string]string{"role": "web", "datacenter": "us-east"},
+						Connected: true,
+						SpecResults: []*core.TestResults{
+							{
+								Results: []core.Result{
+									{Name: "nginx installed", Status: core.StatusPass},
+								},
+							},
+						},
+					},
+				},
+			},
+			contains: []string{
+				"Testing: ubuntu@web-01.example.com\nLabels: datacenter=us-east, role=web\n",
+			},
+		},
 		{
 			name: "mixed host results",
 			results: &core.MultiHostResults{
@@ -865,3 +920,30 @@ func TestFormatMultiHostHuman_TableOutput(t *testing.T) {
 		})
 	}
 }
+
+func TestFormatComparison(t *testing.T) {
+	NoColor = true
+	defer func() { NoColor = false }()
+
+	diffs := []core.ResultDiff{
+		{Spec: "web", Name: "nginx config", StatusA: core.StatusPass, StatusB: core.StatusFail, MessageB: "exit code 1"},
+		{Spec: "web", Name: "only a", StatusA: core.StatusPass},
+	}
+	out := FormatComparison("root@a", "root@b", diffs)
+	for _, want := range []string{
+		"Comparing root@a and root@b",
+		"| web  | nginx config | passed | failed",
+		"| missing",
+		"Drift: 2 test(s) differ",
+		"nginx config: passed on root@a, failed on root@b",
+		"root@b: exit code 1",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("FormatComparison() missing %q:\n%s", want, out)
+		}
+	}
+
+	if out := FormatComparison("root@a", "root@b", nil); !strings.Contains(out, "No drift") {
+		t.Errorf("FormatComparison() without diffs = %q, want No drift", out)
+	}
+}
@@ -0,0 +1,259 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/neilfarmer/platform-spec/pkg/core"
+)
+
+// jsonDuration carries a duration both as nanoseconds, for tooling, and as a
+// Go duration string, for people reading the file
+type jsonDuration struct {
+	Nanoseconds int64  `json:"nanoseconds"`
+	String      string `json:"string"`
+}
+
+func newJSONDuration(d time.Duration) jsonDuration {
+	return jsonDuration{Nanoseconds: d.Nanoseconds(), String: d.String()}
+}
+
+// jsonCounts counts test results by status
+type jsonCounts struct {
+	Total   int `json:"total"`
+	Passed  int `json:"passed"`
+	Failed  int `json:"failed"`
+	Skipped int `json:"skipped"`
+	Errors  int `json:"errors"`
+}
+
+func (c *jsonCounts) add(results *core.TestResults) {
+	total, passed, failed, skipped, errors := results.Summary()
+	c.Total += total
+	c.Passed += passed
+	c.Failed += failed
+	c.Skipped += skipped
+	c.Errors += errors
+}
+
+type jsonResult struct {
+	Name        string                 `json:"name"`
+	Category    string                 `json:"category,omitempty"`
+	Status      core.Status            `json:"status"`
+	Message     string                 `json:"message"`
+	SkipReason  core.SkipReason        `json:"skip_reason,omitempty"`
+	Duration    jsonDuration           `json:"duration"`
+	Attempts    int                    `json:"attempts,omitempty"`
+	MaxAttempts int                    `json:"max_attempts,omitempty"`
+	Details     map[string]interface{} `json:"details,omitempty"`
+	Attachments map[string]string      `json:"attachments,omitempty"`
+}
+
+type jsonTestResults struct {
+	SpecName  string       `json:"spec_name"`
+	SpecHash  string       `json:"spec_hash"`
+	Target    string       `json:"target"`
+	StartTime time.Time    `json:"start_time"`
+	Duration  jsonDuration `json:"duration"`
+	Success   bool         `json:"success"`
+	Summary   jsonCounts   `json:"summary"`
+	Results   []jsonResult `json:"results"`
+}
+
+type jsonHostResults struct {
+	Target          string            `json:"target"`
+	Labels          map[string]string `json:"labels,omitempty"`
+	Connected       bool              `json:"connected"`
+	ConnectionError string            `json:"connection_error,omitempty"`
+	Attempts        []jsonAttempt     `json:"connection_attempts,omitempty"`
+	Metrics         *jsonMetrics      `json:"connection_metrics,omitempty"`
+	TimedOut        bool              `json:"timed_out,omitempty"`
+	Skipped         bool              `json:"skipped,omitempty"`
+	Duration        jsonDuration      `json:"duration"`
+	Success         bool              `json:"success"`
+	Summary         jsonCounts        `json:"summary"`
+	Specs           []jsonTestResults `json:"specs"`
+	Artifacts       []string          `json:"artifacts,omitempty"`
+}
+
+// jsonAttempt is a failed connection attempt
+type jsonAttempt struct {
+	Attempt   int           `json:"attempt"`
+	Error     string        `json:"error"`
+	Retryable bool          `json:"retryable"`
+	NextDelay *jsonDuration `json:"next_delay,omitempty"`
+}
+
+// jsonMetrics holds the connection statistics of a host that connected
+type jsonMetrics struct {
+	ConnectTime      jsonDuration `json:"connect_time"`
+	Commands         int          `json:"commands"`
+	BytesTransferred int64        `json:"bytes_transferred"`
+}
+
+type jsonHostCounts struct {
+	Total            int `json:"total"`
+	Passed           int `json:"passed"`
+	Failed           int `json:"failed"`
+	ConnectionErrors int `json:"connection_errors"`
+	Skipped          int `json:"skipped,omitempty"`
+}
+
+// jsonCategoryCounts counts the results of one test category
+type jsonCategoryCounts struct {
+	Category string `json:"category"`
+	jsonCounts
+}
+
+type jsonCoverage struct {
+	Categories []jsonCategoryCounts `json:"categories"`
+	Evaluated  int                  `json:"evaluated"`
+}
+
+func newJSONCoverage(report core.CoverageReport) *jsonCoverage {
+	out := &jsonCoverage{
+		Categories: make([]jsonCategoryCounts, 0, len(report.Categories)),
+		Evaluated:  report.Evaluated,
+	}
+	for _, c := range report.Categories {
+		out.Categories = append(out.Categories, jsonCategoryCounts{
+			Category:   c.Category,
+			jsonCounts: jsonCounts{Total: c.Total, Passed: c.Passed, Failed: c.Failed, Skipped: c.Skipped, Errors: c.Errors},
+		})
+	}
+	return out
+}
+
+type jsonMultiHostResults struct {
+	Duration jsonDuration      `json:"duration"`
+	Success  bool              `json:"success"`
+	Hosts    jsonHostCounts    `json:"hosts"`
+	Summary  jsonCounts        `json:"summary"`
+	Coverage *jsonCoverage     `json:"coverage,omitempty"`
+	Results  []jsonHostResults `json:"results"`
+}
+
+func newJSONTestResults(results *core.TestResults) jsonTestResults {
+	out := jsonTestResults{
+		SpecName:  results.SpecName,
+		SpecHash:  results.SpecHash,
+		Target:    results.Target,
+		StartTime: results.StartTime,
+		Duration:  newJSONDuration(results.Duration),
+		Success:   results.Success(),
+		Results:   make([]jsonResult, 0, len(results.Results)),
+	}
+	out.Summary.add(results)
+	maxLines := MaxOutputLines
+	if FullOutput {
+		maxLines = 0
+	}
+	for _, r := range results.Results {
+		out.Results = append(out.Results, newJSONResult(r, maxLines))
+	}
+	return out
+}
+
+// newJSONResult converts one test result, truncating its output to maxLines
+func newJSONResult(r core.Result, maxLines int) jsonResult {
+	return jsonResult{
+		Name:        r.Name,
+		Category:    r.Category,
+		Status:      r.Status,
+		Message:     truncateLines(r.Message, maxLines),
+		SkipReason:  r.SkipReason,
+		Duration:    newJSONDuration(r.Duration),
+		Attempts:    r.Attempts,
+		MaxAttempts: r.MaxAttempts,
+		Details:     truncateDetails(r.Details, maxLines),
+		Attachments: truncateAttachments(r.Attachments, maxLines),
+	}
+}
+
+func newJSONHostResults(host *core.HostResults) jsonHostResults {
+	out := jsonHostResults{
+		Target:    host.Target,
+		Labels:    host.Labels,
+		Connected: host.Connected,
+		TimedOut:  host.TimedOut,
+		Skipped:   host.Skipped,
+		Duration:  newJSONDuration(host.Duration),
+		Success:   host.Success(),
+		Specs:     make([]jsonTestResults, 0, len(host.SpecResults)),
+		Artifacts: host.Artifacts,
+	}
+	if host.ConnectionError != nil {
+		out.ConnectionError = host.ConnectionError.Error()
+	}
+	if host.Connected {
+		out.Metrics = &jsonMetrics{
+			ConnectTime:      newJSONDuration(host.Metrics.ConnectDuration),
+			Commands:         host.Metrics.CommandCount,
+			BytesTransferred: host.Metrics.BytesTransferred,
+		}
+	}
+	for _, a := range host.Attempts {
+		attempt := jsonAttempt{Attempt: a.Attempt, Error: a.Error, Retryable: a.Retryable}
+		if a.NextDelay > 0 {
+			delay := newJSONDuration(a.NextDelay)
+			attempt.NextDelay = &delay
+		}
+		out.Attempts = append(out.Attempts, attempt)
+	}
+	for _, spec := range host.SpecResults {
+		out.Summary.add(spec)
+		out.Specs = append(out.Specs, newJSONTestResults(spec))
+	}
+	return out
+}
+
+// FormatJSON formats the results of one spec as a JSON document, indented
+// unless PrettyJSON is off
+func FormatJSON(results *core.TestResults) (string, error) {
+	return marshalJSON(newJSONTestResults(results))
+}
+
+// FormatMultiHostJSON formats multi-host results as a JSON document,
+// including each host's connection status. It is indented unless PrettyJSON
+// is off.
+func FormatMultiHostJSON(results *core.MultiHostResults) (string, error) {
+	out := jsonMultiHostResults{
+		Duration: newJSONDuration(results.TotalDuration),
+		Success:  results.Success(),
+		Results:  make([]jsonHostResults, 0, len(results.Hosts)),
+	}
+	out.Hosts.Total, out.Hosts.Passed, out.Hosts.Failed, out.Hosts.ConnectionErrors = results.Summary()
+	out.Hosts.Skipped = results.SkippedHosts()
+	for _, host := range results.Hosts {
+		hostOut := newJSONHostResults(host)
+		out.Summary.Total += hostOut.Summary.Total
+		out.Summary.Passed += hostOut.Summary.Passed
+		out.Summary.Failed += hostOut.Summary.Failed
+		out.Summary.Skipped += hostOut.Summary.Skipped
+		out.Summary.Errors += hostOut.Summary.Errors
+		out.Results = append(out.Results, hostOut)
+	}
+	if ShowCoverage {
+		out.Coverage = newJSONCoverage(core.Coverage(results))
+	}
+	return marshalJSON(out)
+}
+
+// PrettyJSON indents JSON output for people to read; when false, each
+// document is written compactly on a single line for ingestion
+var PrettyJSON = true
+
+func marshalJSON(v interface{}) (string, error) {
+	var data []byte
+	var err error
+	if PrettyJSON {
+		data, err = json.MarshalIndent(v, "", "  ")
+	} else {
+		data, err = json.Marshal(v)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to encode JSON output: %w", err)
+	}
+	return string(data) + "\n", nil
+}
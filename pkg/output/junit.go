@@ -0,0 +1,157 @@
+package output
+
+import (
+	"encoding/xml"
+	"fmt"
+	"time"
+
+	"github.com/neilfarmer/platform-spec/pkg/core"
+)
+
+// JUnit XML as read by common CI systems (Jenkins, GitLab, GitHub Actions
+// reporters): one testsuite per spec and target, one testcase per test
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Errors   int              `xml:"errors,attr"`
+	Skipped  int              `xml:"skipped,attr"`
+	Time     string           `xml:"time,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Hostname  string          `xml:"hostname,attr,omitempty"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Errors    int             `xml:"errors,attr"`
+	Skipped   int             `xml:"skipped,attr"`
+	Time      string          `xml:"time,attr"`
+	Timestamp string          `xml:"timestamp,attr,omitempty"`
+	Cases     []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitMessage `xml:"failure,omitempty"`
+	Error     *junitMessage `xml:"error,omitempty"`
+	Skipped   *junitMessage `xml:"skipped,omitempty"`
+}
+
+type junitMessage struct {
+	Message string `xml:"message,attr,omitempty"`
+	Text    string `xml:",chardata"`
+}
+
+// junitSeconds formats a duration the way JUnit expects: seconds with
+// millisecond precision
+func junitSeconds(d time.Duration) string {
+	return fmt.Sprintf("%.3f", d.Seconds())
+}
+
+func newJUnitTestSuite(results *core.TestResults) junitTestSuite {
+	suite := junitTestSuite{
+		Name:     results.SpecName,
+		Hostname: results.Target,
+		Time:     junitSeconds(results.Duration),
+		Cases:    make([]junitTestCase, 0, len(results.Results)),
+	}
+	if !results.StartTime.IsZero() {
+		suite.Timestamp = results.StartTime.UTC().Format(time.RFC3339)
+	}
+	suite.Tests, _, suite.Failures, suite.Skipped, suite.Errors = results.Summary()
+
+	for _, r := range results.Results {
+		tc := junitTestCase{
+			Name:      r.Name,
+			ClassName: results.Target,
+			Time:      junitSeconds(r.Duration),
+		}
+		message := truncateLines(r.Message, MaxOutputLines)
+		switch r.Status {
+		case core.StatusFail:
+			tc.Failure = &junitMessage{Message: message, Text: message}
+		case core.StatusError:
+			tc.Error = &junitMessage{Message: message, Text: message}
+		case core.StatusSkip:
+			tc.Skipped = &junitMessage{Message: message}
+		}
+		suite.Cases = append(suite.Cases, tc)
+	}
+	return suite
+}
+
+// newJUnitConnectionSuite reports a host that could not be reached as a suite
+// with a single errored testcase, so the failure shows up in CI reports
+func newJUnitConnectionSuite(host *core.HostResults) junitTestSuite {
+	message := "connection failed"
+	if host.ConnectionError != nil {
+		message = fmt.Sprintf("connection failed: %v", host.ConnectionError)
+	}
+	return junitTestSuite{
+		Name:     host.Target,
+		Hostname: host.Target,
+		Tests:    1,
+		Errors:   1,
+		Time:     junitSeconds(host.Duration),
+		Cases: []junitTestCase{{
+			Name:      "connect",
+			ClassName: host.Target,
+			Time:      junitSeconds(host.Duration),
+			Error:     &junitMessage{Message: message, Text: message},
+		}},
+	}
+}
+
+// newJUnitSkippedSuite reports a host left untested because the run halted as
+// a suite with a single skipped testcase
+func newJUnitSkippedSuite(host *core.HostResults) junitTestSuite {
+	message := "not tested: the run halted at an earlier failing host"
+	return junitTestSuite{
+		Name:     host.Target,
+		Hostname: host.Target,
+		Tests:    1,
+		Skipped:  1,
+		Time:     junitSeconds(host.Duration),
+		Cases: []junitTestCase{{
+			Name:      "connect",
+			ClassName: host.Target,
+			Time:      junitSeconds(host.Duration),
+			Skipped:   &junitMessage{Message: message},
+		}},
+	}
+}
+
+// FormatJUnit formats multi-host results as a JUnit XML report
+func FormatJUnit(results *core.MultiHostResults) (string, error) {
+	report := junitTestSuites{Time: junitSeconds(results.TotalDuration)}
+	for _, host := range results.Hosts {
+		if host.Skipped {
+			report.Suites = append(report.Suites, newJUnitSkippedSuite(host))
+			continue
+		}
+		if !host.Connected {
+			report.Suites = append(report.Suites, newJUnitConnectionSuite(host))
+			continue
+		}
+		for _, spec := range host.SpecResults {
+			report.Suites = append(report.Suites, newJUnitTestSuite(spec))
+		}
+	}
+	for _, suite := range report.Suites {
+		report.Tests += suite.Tests
+		report.Failures += suite.Failures
+		report.Errors += suite.Errors
+		report.Skipped += suite.Skipped
+	}
+
+	data, err := xml.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to encode JUnit output: %w", err)
+	}
+	return xml.Header + string(data) + "\n", nil
+}
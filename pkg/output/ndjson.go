@@ -0,0 +1,93 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/neilfarmer/platform-spec/pkg/core"
+)
+
+// ndjsonResult is one test result line of an NDJSON stream
+type ndjsonResult struct {
+	Type   string `json:"type"`
+	Target string `json:"target"`
+	Spec   string `json:"spec"`
+	jsonResult
+}
+
+// ndjsonHost reports a host that ran no tests, because it could not be
+// reached or the run halted before it
+type ndjsonHost struct {
+	Type            string `json:"type"`
+	Target          string `json:"target"`
+	Connected       bool   `json:"connected"`
+	ConnectionError string `json:"connection_error,omitempty"`
+	Skipped         bool   `json:"skipped,omitempty"`
+}
+
+// ndjsonSummary is the last line of an NDJSON stream
+type ndjsonSummary struct {
+	Type     string         `json:"type"`
+	Success  bool           `json:"success"`
+	Duration jsonDuration   `json:"duration"`
+	Hosts    jsonHostCounts `json:"hosts"`
+	jsonCounts
+}
+
+// FormatNDJSON formats multi-host results as newline-delimited JSON: one
+// "result" object per test, a "host" object for each host that ran no tests,
+// and a final "summary" object with the totals, so a reader knows the stream
+// is complete. Lines are always compact, whatever PrettyJSON says.
+func FormatNDJSON(results *core.MultiHostResults) (string, error) {
+	maxLines := MaxOutputLines
+	if FullOutput {
+		maxLines = 0
+	}
+
+	var b strings.Builder
+	summary := ndjsonSummary{
+		Type:     "summary",
+		Success:  results.Success(),
+		Duration: newJSONDuration(results.TotalDuration),
+	}
+	summary.Hosts.Total, summary.Hosts.Passed, summary.Hosts.Failed, summary.Hosts.ConnectionErrors = results.Summary()
+	summary.Hosts.Skipped = results.SkippedHosts()
+
+	for _, host := range results.Hosts {
+		if !host.Connected || host.Skipped {
+			line := ndjsonHost{Type: "host", Target: host.Target, Connected: host.Connected, Skipped: host.Skipped}
+			if host.ConnectionError != nil {
+				line.ConnectionError = host.ConnectionError.Error()
+			}
+			if err := writeNDJSONLine(&b, line); err != nil {
+				return "", err
+			}
+		}
+		for _, spec := range host.SpecResults {
+			summary.add(spec)
+			for _, r := range spec.Results {
+				line := ndjsonResult{Type: "result", Target: host.Target, Spec: spec.SpecName, jsonResult: newJSONResult(r, maxLines)}
+				if err := writeNDJSONLine(&b, line); err != nil {
+					return "", err
+				}
+			}
+		}
+	}
+
+	if err := writeNDJSONLine(&b, summary); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+// writeNDJSONLine appends v to b as a single line of JSON
+func writeNDJSONLine(b *strings.Builder, v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("failed to encode NDJSON output: %w", err)
+	}
+	b.Write(data)
+	b.WriteByte('\n')
+	return nil
+}
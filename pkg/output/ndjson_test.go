@@ -0,0 +1,109 @@
+package output
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/neilfarmer/platform-spec/pkg/core"
+)
+
+func TestFormatNDJSON(t *testing.T) {
+	results := &core.MultiHostResults{
+		TotalDuration: 3 * time.Second,
+		Hosts: []*core.HostResults{
+			{
+				Target:    "root@web1",
+				Connected: true,
+				SpecResults: []*core.TestResults{{SpecName: "web.yaml", Results: []core.Result{
+					{Name: "nginx installed", Status: core.StatusPass, Duration: time.Second},
+					{Name: "nginx running", Status: core.StatusFail, Message: "Service nginx is inactive"},
+					{Name: "selinux", Status: core.StatusSkip, SkipReason: core.SkipUnsupportedPlatform},
+				}}},
+			},
+			{
+				Target:    "root@web2",
+				Connected: true,
+				SpecResults: []*core.TestResults{{SpecName: "web.yaml", Results: []core.Result{
+					{Name: "nginx installed", Status: core.StatusPass},
+					{Name: "port 443", Status: core.StatusError, Message: "ss: command not found"},
+				}}},
+			},
+			{Target: "root@db1", ConnectionError: errors.New("dial tcp: connection refused")},
+		},
+	}
+
+	PrettyJSON = true
+	defer func() { PrettyJSON = true }()
+
+	got, err := FormatNDJSON(results)
+	if err != nil {
+		t.Fatalf("FormatNDJSON() error = %v", err)
+	}
+	lines := strings.Split(strings.TrimSuffix(got, "\n"), "\n")
+	if len(lines) != 7 {
+		t.Fatalf("FormatNDJSON() wrote %d lines, want 7:\n%s", len(lines), got)
+	}
+
+	var summaries int
+	for i, line := range lines {
+		var obj map[string]interface{}
+		if err := json.Unmarshal([]byte(line), &obj); err != nil {
+			t.Fatalf("line %d is not a JSON object: %v\n%s", i+1, err, line)
+		}
+		if obj["type"] == "summary" {
+			summaries++
+			if i != len(lines)-1 {
+				t.Errorf("summary is line %d, want the last line", i+1)
+			}
+		}
+	}
+	if summaries != 1 {
+		t.Errorf("stream has %d summary lines, want exactly 1", summaries)
+	}
+
+	var first ndjsonResult
+	if err := json.Unmarshal([]byte(lines[0]), &first); err != nil {
+		t.Fatalf("first line: %v", err)
+	}
+	if first.Type != "result" || first.Target != "root@web1" || first.Spec != "web.yaml" || first.Name != "nginx installed" || first.Status != core.StatusPass {
+		t.Errorf("first line = %+v, want the passing result of root@web1", first)
+	}
+	if !strings.Contains(got, `{"type":"host","target":"root@db1","connected":false,"connection_error":"dial tcp: connection refused"}`) {
+		t.Errorf("FormatNDJSON() has no host line for root@db1:\n%s", got)
+	}
+
+	var summary ndjsonSummary
+	if err := json.Unmarshal([]byte(lines[len(lines)-1]), &summary); err != nil {
+		t.Fatalf("summary line: %v", err)
+	}
+	wantCounts := jsonCounts{Total: 5, Passed: 2, Failed: 1, Skipped: 1, Errors: 1}
+	if summary.jsonCounts != wantCounts {
+		t.Errorf("summary counts = %+v, want %+v", summary.jsonCounts, wantCounts)
+	}
+	wantHosts := jsonHostCounts{Total: 3, Passed: 0, Failed: 3, ConnectionErrors: 1}
+	if summary.Hosts != wantHosts {
+		t.Errorf("summary hosts = %+v, want %+v", summary.Hosts, wantHosts)
+	}
+	if summary.Success || summary.Duration.String != "3s" {
+		t.Errorf("summary = %+v, want an unsuccessful 3s run", summary)
+	}
+
+	// Rendering as a sink uses the same stream
+	rendered, err := Render("ndjson", results)
+	if err != nil || rendered != got {
+		t.Errorf("Render(ndjson) = %q, %v", rendered, err)
+	}
+}
+
+func TestFormatNDJSON_Empty(t *testing.T) {
+	got, err := FormatNDJSON(&core.MultiHostResults{})
+	if err != nil {
+		t.Fatalf("FormatNDJSON() error = %v", err)
+	}
+	if !strings.HasPrefix(got, `{"type":"summary","success":true,`) || strings.Count(got, "\n") != 1 {
+		t.Errorf("FormatNDJSON() = %q, want a single summary line", got)
+	}
+}
@@ -0,0 +1,75 @@
+package output
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/neilfarmer/platform-spec/pkg/core"
+)
+
+// Sink is a destination for one rendering of a run's results, e.g. human
+// output on stdout alongside a JUnit file for CI
+type Sink struct {
+	Format string
+	Out    io.Writer
+}
+
+// Render formats results in the named format. Human output for a single
+// connected host keeps the per-spec layout; anything else uses the
+// multi-host layout. With ShowCoverage, human output ends with the coverage
+// summary, and with ShowRepresentativeFailures, with each test's first failure.
+func Render(format string, results *core.MultiHostResults) (string, error) {
+	switch format {
+	case "human":
+		var out string
+		if len(results.Hosts) == 1 && results.Hosts[0].Connected {
+			for _, spec := range results.Hosts[0].SpecResults {
+				out += FormatHuman(spec)
+			}
+		} else {
+			out = FormatMultiHostHuman(results)
+		}
+		if ShowCoverage {
+			out += FormatCoverage(core.Coverage(results))
+		}
+		if ShowRepresentativeFailures {
+			out += FormatRepresentativeFailures(results)
+		}
+		return out, nil
+	case "json":
+		return FormatMultiHostJSON(results)
+	case "junit":
+		return FormatJUnit(results)
+	case "tap":
+		return FormatMultiHostTAP(results), nil
+	case "ndjson":
+		return FormatNDJSON(results)
+	default:
+		return "", ValidateFormat(format)
+	}
+}
+
+// ValidateFormat returns an error if format is not a supported output format
+func ValidateFormat(format string) error {
+	switch format {
+	case "human", "json", "junit", "tap", "ndjson":
+		return nil
+	}
+	return fmt.Errorf("unknown output format '%s' (must be human, json, junit, tap, or ndjson)", format)
+}
+
+// WriteSinks renders results once per sink. Every sink is attempted; the
+// first error is returned.
+func WriteSinks(results *core.MultiHostResults, sinks ...Sink) error {
+	var firstErr error
+	for _, sink := range sinks {
+		out, err := Render(sink.Format, results)
+		if err == nil {
+			_, err = io.WriteString(sink.Out, out)
+		}
+		if err != nil && firstErr == nil {
+			firstErr = fmt.Errorf("%s output: %w", sink.Format, err)
+		}
+	}
+	return firstErr
+}
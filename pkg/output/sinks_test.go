@@ -0,0 +1,445 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/neilfarmer/platform-spec/pkg/core"
+)
+
+// sinkRun builds a two-host run: one host with mixed results, one unreachable
+func sinkRun() *core.MultiHostResults {
+	return &core.MultiHostResults{
+		TotalDuration: 3 * time.Second,
+		Hosts: []*core.HostResults{
+			{
+				Target:    "root@web1",
+				Labels:    map[string]string{"role": "web"},
+				Connected: true,
+				Duration:  2 * time.Second,
+				Metrics:   core.ConnectionMetrics{ConnectDuration: 250 * time.Millisecond, CommandCount: 12, BytesTransferred: 4096},
+				SpecResults: []*core.TestResults{{
+					SpecName:  "Web",
+					SpecHash:  "abc123",
+					Target:    "root@web1",
+					StartTime: time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC),
+					Duration:  2 * time.Second,
+					Results: []core.Result{
+						{Name: "nginx installed", Status: core.StatusPass, Message: "Package nginx is installed", Duration: 1500 * time.Millisecond, Attempts: 2, MaxAttempts: 3},
+						{Name: "nginx running", Status: core.StatusFail, Message: "Service nginx is stopped", Duration: 250 * time.Millisecond},
+						{Name: "certs valid", Status: core.StatusError, Message: "openssl not found"},
+						{Name: "selinux", Status: core.StatusSkip, Message: "Not supported"},
+					},
+				}},
+			},
+			{
+				Target:          "root@db1",
+				ConnectionError: errors.New("dial tcp: connection refused"),
+				Attempts: []core.ConnectionAttempt{
+					{Attempt: 1, Error: "dial tcp: connection refused", Retryable: true, NextDelay: 2 * time.Second},
+					{Attempt: 2, Error: "dial tcp: connection refused", Retryable: true},
+				},
+				Duration: time.Second,
+			},
+		},
+	}
+}
+
+func TestWriteSinks(t *testing.T) {
+	NoColor = true
+	defer func() { NoColor = false }()
+
+	var human, jsonOut, junitOut bytes.Buffer
+	err := WriteSinks(sinkRun(),
+		Sink{Format: "human", Out: &human},
+		Sink{Format: "json", Out: &jsonOut},
+		Sink{Format: "junit", Out: &junitOut},
+	)
+	if err != nil {
+		t.Fatalf("WriteSinks() error = %v", err)
+	}
+
+	if want := FormatMultiHostHuman(sinkRun()); human.String() != want {
+		t.Errorf("human sink = %q, want %q", human.String(), want)
+	}
+
+	var doc struct {
+		Success bool `json:"success"`
+		Hosts   struct {
+			Total            int `json:"total"`
+			ConnectionErrors int `json:"connection_errors"`
+		} `json:"hosts"`
+		Summary struct {
+			Passed, Failed, Skipped, Errors int
+		} `json:"summary"`
+		Results []struct {
+			Target          string            `json:"target"`
+			Labels          map[string]string `json:"labels"`
+			Connected       bool              `json:"connected"`
+			ConnectionError string            `json:"connection_error"`
+			Attempts        []struct {
+				Attempt   int    `json:"attempt"`
+				Retryable bool   `json:"retryable"`
+				Error     string `json:"error"`
+				NextDelay *struct {
+					String string `json:"string"`
+				} `json:"next_delay"`
+			} `json:"connection_attempts"`
+			Metrics *struct {
+				ConnectTime struct {
+					Nanoseconds int64  `json:"nanoseconds"`
+					String      string `json:"string"`
+				} `json:"connect_time"`
+				Commands         int   `json:"commands"`
+				BytesTransferred int64 `json:"bytes_transferred"`
+			} `json:"connection_metrics"`
+			Specs []struct {
+				SpecName string `json:"spec_name"`
+				Results  []struct {
+					Name        string `json:"name"`
+					Status      string `json:"status"`
+					Attempts    int    `json:"attempts"`
+					MaxAttempts int    `json:"max_attempts"`
+					Duration    struct {
+						Nanoseconds int64  `json:"nanoseconds"`
+						String      string `json:"string"`
+					} `json:"duration"`
+				} `json:"results"`
+			} `json:"specs"`
+		} `json:"results"`
+	}
+	if err := json.Unmarshal(jsonOut.Bytes(), &doc); err != nil {
+		t.Fatalf("json sink is not valid JSON: %v\n%s", err, jsonOut.String())
+	}
+	if doc.Success || doc.Hosts.Total != 2 || doc.Hosts.ConnectionErrors != 1 {
+		t.Errorf("json host summary = %+v, success %v", doc.Hosts, doc.Success)
+	}
+	if doc.Summary.Passed != 1 || doc.Summary.Failed != 1 || doc.Summary.Errors != 1 || doc.Summary.Skipped != 1 {
+		t.Errorf("json test summary = %+v", doc.Summary)
+	}
+	web, db := doc.Results[0], doc.Results[1]
+	if web.Labels["role"] != "web" || len(web.Specs) != 1 || len(web.Specs[0].Results) != 4 {
+		t.Fatalf("json web host = %+v", web)
+	}
+	if d := web.Specs[0].Results[0].Duration; d.Nanoseconds != 1.5e9 || d.String != "1.5s" {
+		t.Errorf("json duration = %+v, want 1.5e9ns / 1.5s", d)
+	}
+	if r := web.Specs[0].Results; r[0].Attempts != 2 || r[0].MaxAttempts != 3 || r[1].Attempts != 0 {
+		t.Errorf("json attempts = %d/%d and %d, want 2/3 and none", r[0].Attempts, r[0].MaxAttempts, r[1].Attempts)
+	}
+	if m := web.Metrics; m == nil || m.ConnectTime.Nanoseconds != 250e6 || m.ConnectTime.String != "250ms" || m.Commands != 12 || m.BytesTransferred != 4096 {
+		t.Errorf("json web connection metrics = %+v, want 250ms, 12 commands and 4096 bytes", m)
+	}
+	if db.Metrics != nil {
+		t.Errorf("json db connection metrics = %+v, want none for a host that never connected", db.Metrics)
+	}
+	if db.Connected || db.ConnectionError != "dial tcp: connection refused" {
+		t.Errorf("json db host = %+v", db)
+	}
+	if len(db.Attempts) != 2 || db.Attempts[0].NextDelay == nil || db.Attempts[0].NextDelay.String != "2s" ||
+		db.Attempts[1].Attempt != 2 || !db.Attempts[1].Retryable || db.Attempts[1].NextDelay != nil {
+		t.Errorf("json db connection attempts = %+v", db.Attempts)
+	}
+
+	if !strings.HasPrefix(junitOut.String(), xml.Header) {
+		t.Errorf("junit sink missing XML header:\n%s", junitOut.String())
+	}
+	var report junitTestSuites
+	if err := xml.Unmarshal(junitOut.Bytes(), &report); err != nil {
+		t.Fatalf("junit sink is not valid XML: %v\n%s", err, junitOut.String())
+	}
+	if report.Tests != 5 || report.Failures != 1 || report.Errors != 2 || report.Skipped != 1 {
+		t.Errorf("junit totals = tests %d, failures %d, errors %d, skipped %d",
+			report.Tests, report.Failures, report.Errors, report.Skipped)
+	}
+	if len(report.Suites) != 2 {
+		t.Fatalf("got %d junit suites, want 2", len(report.Suites))
+	}
+	cases := report.Suites[0].Cases
+	if cases[0].Failure != nil || cases[0].Time != "1.500" {
+		t.Errorf("passing case = %+v", cases[0])
+	}
+	if cases[1].Failure == nil || cases[1].Failure.Message != "Service nginx is stopped" {
+		t.Errorf("failing case = %+v", cases[1])
+	}
+	if cases[2].Error == nil || cases[3].Skipped == nil {
+		t.Errorf("error/skipped cases = %+v, %+v", cases[2], cases[3])
+	}
+	conn := report.Suites[1]
+	if conn.Name != "root@db1" || conn.Cases[0].Error == nil ||
+		!strings.Contains(conn.Cases[0].Error.Message, "connection refused") {
+		t.Errorf("connection suite = %+v", conn)
+	}
+}
+
+func TestRender_SingleHostHuman(t *testing.T) {
+	results := sinkRun()
+	results.Hosts = results.Hosts[:1]
+
+	got, err := Render("human", results)
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	if want := FormatHuman(results.Hosts[0].SpecResults[0]); got != want {
+		t.Errorf("single host human output should use the per-spec layout, got:\n%s", got)
+	}
+}
+
+func TestWriteSinks_UnknownFormat(t *testing.T) {
+	var human, bogus bytes.Buffer
+	err := WriteSinks(sinkRun(), Sink{Format: "yaml", Out: &bogus}, Sink{Format: "human", Out: &human})
+	if err == nil || !strings.Contains(err.Error(), "unknown output format 'yaml'") {
+		t.Errorf("WriteSinks() error = %v, want unknown format error", err)
+	}
+	if human.Len() == 0 {
+		t.Error("an invalid sink should not stop the remaining sinks")
+	}
+	if err := ValidateFormat("junit"); err != nil {
+		t.Errorf("ValidateFormat(junit) error = %v", err)
+	}
+}
+
+func TestFormatJSON(t *testing.T) {
+	out, err := FormatJSON(sinkRun().Hosts[0].SpecResults[0])
+	if err != nil {
+		t.Fatalf("FormatJSON() error = %v", err)
+	}
+	for _, want := range []string{`"spec_name": "Web"`, `"failed": 1`, `"string": "250ms"`, `"start_time": "2026-03-01T12:00:00Z"`} {
+		if !strings.Contains(out, want) {
+			t.Errorf("FormatJSON() missing %s:\n%s", want, out)
+		}
+	}
+}
+
+func TestRenderCoverage(t *testing.T) {
+	NoColor = true
+	ShowCoverage = true
+	defer func() { NoColor, ShowCoverage = false, false }()
+
+	run := sinkRun()
+	for i, category := range []string{"packages", "services", "ssl", "selinux"} {
+		run.Hosts[0].SpecResults[0].Results[i].Category = category
+	}
+
+	human, err := Render("human", run)
+	if err != nil {
+		t.Fatalf("Render(human) error = %v", err)
+	}
+	for _, want := range []string{"Coverage", "| packages", "selinux (all skipped)", "Assertions evaluated: 3 of 4"} {
+		if !strings.Contains(human, want) {
+			t.Errorf("human output missing %q:\n%s", want, human)
+		}
+	}
+
+	out, err := Render("json", run)
+	if err != nil {
+		t.Fatalf("Render(json) error = %v", err)
+	}
+	var parsed struct {
+		Coverage struct {
+			Categories []struct {
+				Category string `json:"category"`
+				Failed   int    `json:"failed"`
+			} `json:"categories"`
+			Evaluated int `json:"evaluated"`
+		} `json:"coverage"`
+	}
+	if err := json.Unmarshal([]byte(out), &parsed); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if len(parsed.Coverage.Categories) != 4 || parsed.Coverage.Evaluated != 3 {
+		t.Errorf("coverage = %+v, want 4 categories and 3 evaluated", parsed.Coverage)
+	}
+	if c := parsed.Coverage.Categories[2]; c.Category != "services" || c.Failed != 1 {
+		t.Errorf("categories[2] = %+v, want services with 1 failure", c)
+	}
+}
+
+func TestRenderSkippedHosts(t *testing.T) {
+	NoColor = true
+	defer func() { NoColor = false }()
+
+	run := sinkRun()
+	run.Hosts = append(run.Hosts, &core.HostResults{Target: "root@web2", Skipped: true})
+
+	human, err := Render("human", run)
+	if err != nil {
+		t.Fatalf("Render(human) error = %v", err)
+	}
+	for _, want := range []string{"○ SKIPPED", "Failed: 2", "Skipped: 1", "| root@web2 | SKIPPED"} {
+		if !strings.Contains(human, want) {
+			t.Errorf("human output missing %q:\n%s", want, human)
+		}
+	}
+
+	out, err := Render("json", run)
+	if err != nil {
+		t.Fatalf("Render(json) error = %v", err)
+	}
+	var parsed struct {
+		Hosts struct {
+			Total   int `json:"total"`
+			Failed  int `json:"failed"`
+			Skipped int `json:"skipped"`
+		} `json:"hosts"`
+		Results []struct {
+			Skipped bool `json:"skipped"`
+		} `json:"results"`
+	}
+	if err := json.Unmarshal([]byte(out), &parsed); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if parsed.Hosts.Total != 3 || parsed.Hosts.Failed != 2 || parsed.Hosts.Skipped != 1 || !parsed.Results[2].Skipped {
+		t.Errorf("JSON hosts = %+v, results = %+v; want 3 total, 2 failed, 1 skipped", parsed.Hosts, parsed.Results)
+	}
+
+	junitOut, err := Render("junit", run)
+	if err != nil {
+		t.Fatalf("Render(junit) error = %v", err)
+	}
+	var report junitTestSuites
+	if err := xml.Unmarshal([]byte(junitOut), &report); err != nil {
+		t.Fatalf("invalid JUnit XML: %v", err)
+	}
+	last := report.Suites[len(report.Suites)-1]
+	if last.Name != "root@web2" || last.Skipped != 1 || last.Cases[0].Skipped == nil {
+		t.Errorf("last JUnit suite = %+v, want root@web2 with a skipped testcase", last)
+	}
+}
+
+func TestFormatCatalog(t *testing.T) {
+	NoColor = true
+	defer func() { NoColor = false }()
+
+	human := FormatCatalog(core.Catalog())
+	for _, want := range []string{
+		"kubernetes.pods",
+		"| packages            | list of string | yes",
+		"| present, absent",
+		"Requires one of: service, services",
+	} {
+		if !strings.Contains(human, want) {
+			t.Errorf("FormatCatalog() missing %q", want)
+		}
+	}
+
+	out, err := FormatCatalogJSON(core.Catalog())
+	if err != nil {
+		t.Fatalf("FormatCatalogJSON() error = %v", err)
+	}
+	var parsed []struct {
+		Category string `json:"category"`
+		Fields   []struct {
+			Name     string   `json:"name"`
+			Required bool     `json:"required"`
+			Enum     []string `json:"enum"`
+			Default  string   `json:"default"`
+		} `json:"fields"`
+	}
+	if err := json.Unmarshal([]byte(out), &parsed); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	found := false
+	for _, c := range parsed {
+		if c.Category != "docker" {
+			continue
+		}
+		for _, f := range c.Fields {
+			if f.Name == "state" {
+				found = true
+				if f.Default != "running" || strings.Join(f.Enum, ",") != "running,stopped,exists" || f.Required {
+					t.Errorf("docker state = %+v", f)
+				}
+			}
+		}
+	}
+	if !found {
+		t.Errorf("docker state field missing from JSON catalog")
+	}
+}
+
+func TestFormatJSON_Attachments(t *testing.T) {
+	result := core.Result{Name: "sshd hardened", Status: core.StatusPass}
+	result.Attach("matches", "34:PermitRootLogin no\n")
+	out, err := FormatJSON(&core.TestResults{SpecName: "Audit", Results: []core.Result{result, {Name: "no evidence", Status: core.StatusPass}}})
+	if err != nil {
+		t.Fatalf("FormatJSON() error = %v", err)
+	}
+	if !strings.Contains(out, `"attachments": {`) || !strings.Contains(out, `"matches": "34:PermitRootLogin no\n"`) {
+		t.Errorf("FormatJSON() missing attachments:\n%s", out)
+	}
+	if strings.Count(out, `"attachments"`) != 1 {
+		t.Errorf("FormatJSON() should omit empty attachments:\n%s", out)
+	}
+}
+
+func TestFormatMultiHostJSON_Pretty(t *testing.T) {
+	defer func() { PrettyJSON = true }()
+
+	pretty, err := FormatMultiHostJSON(sinkRun())
+	if err != nil {
+		t.Fatalf("FormatMultiHostJSON() error = %v", err)
+	}
+	if !strings.Contains(pretty, "{\n  \"duration\": {") || strings.Count(pretty, "\n") < 10 {
+		t.Errorf("pretty JSON is not indented:\n%s", pretty)
+	}
+
+	PrettyJSON = false
+	compact, err := FormatMultiHostJSON(sinkRun())
+	if err != nil {
+		t.Fatalf("FormatMultiHostJSON() error = %v", err)
+	}
+	if strings.Count(compact, "\n") != 1 || !strings.HasSuffix(compact, "}\n") || strings.Contains(compact, "  ") {
+		t.Errorf("compact JSON should be a single unindented line:\n%s", compact)
+	}
+
+	// Both encode the same document
+	var a, b interface{}
+	if err := json.Unmarshal([]byte(pretty), &a); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal([]byte(compact), &b); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(a, b) {
+		t.Error("pretty and compact JSON differ in content")
+	}
+}
+
+func TestFormatMultiHostJSON_StableOrder(t *testing.T) {
+	run := func() *core.MultiHostResults {
+		r := sinkRun()
+		r.Hosts[0].Labels = map[string]string{"zone": "b", "role": "web", "env": "prod"}
+		r.Hosts[0].SpecResults[0].Results[1].Details = map[string]interface{}{"stderr": "", "exit_code": 3, "command": "systemctl is-active nginx"}
+		return r
+	}
+
+	first, err := FormatMultiHostJSON(run())
+	if err != nil {
+		t.Fatalf("FormatMultiHostJSON() error = %v", err)
+	}
+	for i := 0; i < 10; i++ {
+		if again, _ := FormatMultiHostJSON(run()); again != first {
+			t.Fatalf("FormatMultiHostJSON() output changed between runs:\n%s\n---\n%s", first, again)
+		}
+	}
+
+	// Map keys are sorted; results keep the order they ran in
+	for _, keys := range [][]string{
+		{`"env"`, `"role"`, `"zone"`},
+		{`"command"`, `"exit_code"`, `"stderr"`},
+		{`"nginx installed"`, `"nginx running"`, `"certs valid"`, `"selinux"`},
+	} {
+		for i := 1; i < len(keys); i++ {
+			if strings.Index(first, keys[i-1]) > strings.Index(first, keys[i]) {
+				t.Errorf("%s should come before %s:\n%s", keys[i-1], keys[i], first)
+			}
+		}
+	}
+}
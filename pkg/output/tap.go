@@ -0,0 +1,132 @@
+package output
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/neilfarmer/platform-spec/pkg/core"
+	"gopkg.in/yaml.v3"
+)
+
+// TAP (Test Anything Protocol) version 13: a plan line giving the number of
+// tests, then one ok / not ok line per test, failures followed by an
+// indented YAML diagnostic block
+
+// tapLine is one test point of a TAP stream
+type tapLine struct {
+	ok        bool
+	name      string
+	directive string            // e.g. "SKIP not supported", without the leading #
+	diag      map[string]string // YAML diagnostics for a failing test
+}
+
+// newTAPLine converts a test result into a test point
+func newTAPLine(r core.Result, name string) tapLine {
+	line := tapLine{ok: r.Status == core.StatusPass || r.Status == core.StatusSkip, name: name}
+	switch r.Status {
+	case core.StatusSkip:
+		reason := string(r.SkipReason)
+		if reason == "" {
+			reason = r.Message
+		}
+		line.directive = strings.TrimSpace("SKIP " + tapEscape(reason))
+	case core.StatusFail, core.StatusError:
+		line.diag = map[string]string{
+			"message":  truncateLines(r.Message, MaxOutputLines),
+			"severity": string(r.Status),
+		}
+	}
+	return line
+}
+
+// tapEscape makes s safe for a test point line: newlines would end it and
+// an unescaped # would start a directive
+func tapEscape(s string) string {
+	s = strings.Join(strings.Fields(s), " ")
+	return strings.ReplaceAll(s, "#", `\#`)
+}
+
+// writeTAP renders test points as a TAP stream, the plan counting every
+// point including skips
+func writeTAP(lines []tapLine) string {
+	var sb strings.Builder
+	sb.WriteString("TAP version 13\n")
+	sb.WriteString(fmt.Sprintf("1..%d\n", len(lines)))
+	for i, line := range lines {
+		status := "ok"
+		if !line.ok {
+			status = "not ok"
+		}
+		sb.WriteString(fmt.Sprintf("%s %d - %s", status, i+1, tapEscape(line.name)))
+		if line.directive != "" {
+			sb.WriteString(" # " + line.directive)
+		}
+		sb.WriteString("\n")
+		if len(line.diag) > 0 {
+			sb.WriteString(tapDiagnostic(line.diag))
+		}
+	}
+	return sb.String()
+}
+
+// tapDiagnostic renders a YAML diagnostic block indented under a test point
+func tapDiagnostic(diag map[string]string) string {
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(diag); err != nil {
+		// A map of strings always encodes; keep the stream valid regardless
+		buf.Reset()
+		fmt.Fprintf(&buf, "message: %q\n", diag["message"])
+	}
+	var sb strings.Builder
+	sb.WriteString("  ---\n")
+	for _, l := range strings.Split(strings.TrimRight(buf.String(), "\n"), "\n") {
+		sb.WriteString("  " + l + "\n")
+	}
+	sb.WriteString("  ...\n")
+	return sb.String()
+}
+
+// FormatTAP formats the results of one spec as a TAP version 13 stream
+func FormatTAP(results *core.TestResults) string {
+	lines := make([]tapLine, 0, len(results.Results))
+	for _, r := range results.Results {
+		lines = append(lines, newTAPLine(r, r.Name))
+	}
+	return writeTAP(lines)
+}
+
+// FormatMultiHostTAP formats multi-host results as a single TAP stream. With
+// more than one host, test names are prefixed with their target. A host that
+// cannot be reached is one failing connect test; a host left untested because
+// the run halted is one skipped test.
+func FormatMultiHostTAP(results *core.MultiHostResults) string {
+	prefix := len(results.Hosts) > 1
+	var lines []tapLine
+	for _, host := range results.Hosts {
+		switch {
+		case host.Skipped:
+			lines = append(lines, tapLine{ok: true, name: host.Target + " connect", directive: "SKIP not tested: the run halted at an earlier failing host"})
+			continue
+		case !host.Connected:
+			message := "connection failed"
+			if host.ConnectionError != nil {
+				message = fmt.Sprintf("connection failed: %v", host.ConnectionError)
+			}
+			lines = append(lines, tapLine{name: host.Target + " connect", diag: map[string]string{"message": message, "severity": string(core.StatusError)}})
+			continue
+		}
+		for _, spec := range host.SpecResults {
+			for _, r := range spec.Results {
+				name := r.Name
+				if prefix {
+					name = host.Target + " " + name
+				}
+				lines = append(lines, newTAPLine(r, name))
+			}
+		}
+	}
+	return writeTAP(lines)
+}
@@ -0,0 +1,85 @@
+package output
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/neilfarmer/platform-spec/pkg/core"
+)
+
+func TestFormatTAP(t *testing.T) {
+	results := &core.TestResults{
+		SpecName: "web.yaml",
+		Results: []core.Result{
+			{Name: "nginx installed", Status: core.StatusPass, Duration: time.Second},
+			{Name: "nginx running", Status: core.StatusFail, Message: "Service nginx is inactive\nsee: journalctl -u nginx"},
+			{Name: "selinux", Status: core.StatusSkip, SkipReason: core.SkipUnsupportedPlatform},
+			{Name: "port #443", Status: core.StatusError, Message: "ss: command not found"},
+		},
+	}
+
+	want := `TAP version 13
+1..4
+ok 1 - nginx installed
+not ok 2 - nginx running
+  ---
+  message: |-
+    Service nginx is inactive
+    see: journalctl -u nginx
+  severity: failed
+  ...
+ok 3 - selinux # SKIP unsupported platform
+not ok 4 - port \#443
+  ---
+  message: 'ss: command not found'
+  severity: error
+  ...
+`
+	if got := FormatTAP(results); got != want {
+		t.Errorf("FormatTAP() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestFormatTAP_Empty(t *testing.T) {
+	if got, want := FormatTAP(&core.TestResults{}), "TAP version 13\n1..0\n"; got != want {
+		t.Errorf("FormatTAP() = %q, want %q", got, want)
+	}
+}
+
+func TestFormatMultiHostTAP(t *testing.T) {
+	results := &core.MultiHostResults{
+		Hosts: []*core.HostResults{
+			{
+				Target:    "root@web1",
+				Connected: true,
+				SpecResults: []*core.TestResults{{Results: []core.Result{
+					{Name: "nginx installed", Status: core.StatusPass},
+					{Name: "tmp cleaned", Status: core.StatusSkip, Message: "category not selected"},
+				}}},
+			},
+			{Target: "root@db1", ConnectionError: errors.New("dial tcp: connection refused")},
+			{Target: "root@db2", Skipped: true},
+		},
+	}
+
+	got := FormatMultiHostTAP(results)
+	for _, want := range []string{
+		"TAP version 13\n1..4\n",
+		"ok 1 - root@web1 nginx installed\n",
+		"ok 2 - root@web1 tmp cleaned # SKIP category not selected\n",
+		"not ok 3 - root@db1 connect\n  ---\n  message: 'connection failed: dial tcp: connection refused'\n",
+		"ok 4 - root@db2 connect # SKIP not tested",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("FormatMultiHostTAP() missing %q in\n%s", want, got)
+		}
+	}
+
+	// Rendering as a sink uses the same stream
+	rendered, err := Render("tap", results)
+	if err != nil || rendered != got {
+		t.Errorf("Render(tap) = %q, %v", rendered, err)
+	}
+}
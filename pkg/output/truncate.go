@@ -0,0 +1,55 @@
+package output
+
+import (
+	"fmt"
+	"strings"
+)
+
+// MaxOutputLines caps how many lines of a result message or captured command
+// output the formatters print; 0 means no limit
+var MaxOutputLines = 0
+
+// FullOutput keeps JSON output untruncated regardless of MaxOutputLines
+var FullOutput = false
+
+// truncateLines keeps the first max lines of s and replaces the rest with a
+// marker saying how many lines were dropped. A trailing newline does not
+// count as an extra line.
+func truncateLines(s string, max int) string {
+	if max <= 0 {
+		return s
+	}
+	lines := strings.Split(strings.TrimSuffix(s, "\n"), "\n")
+	if len(lines) <= max {
+		return s
+	}
+	return strings.Join(lines[:max], "\n") + fmt.Sprintf("\n... (truncated, %d more lines)", len(lines)-max)
+}
+
+// truncateDetails returns details with every string value truncated. The
+// original map is left untouched.
+func truncateDetails(details map[string]interface{}, max int) map[string]interface{} {
+	if max <= 0 || len(details) == 0 {
+		return details
+	}
+	out := make(map[string]interface{}, len(details))
+	for key, value := range details {
+		if s, ok := value.(string); ok {
+			value = truncateLines(s, max)
+		}
+		out[key] = value
+	}
+	return out
+}
+
+// truncateAttachments is truncateDetails for result attachments
+func truncateAttachments(attachments map[string]string, max int) map[string]string {
+	if max <= 0 || len(attachments) == 0 {
+		return attachments
+	}
+	out := make(map[string]string, len(attachments))
+	for name, content := range attachments {
+		out[name] = truncateLines(content, max)
+	}
+	return out
+}
@@ -0,0 +1,80 @@
+package output
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/neilfarmer/platform-spec/pkg/core"
+)
+
+func TestTruncateLines(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		max  int
+		want string
+	}{
+		{name: "no limit", in: "a\nb\nc", max: 0, want: "a\nb\nc"},
+		{name: "under limit", in: "a\nb", max: 3, want: "a\nb"},
+		{name: "exactly at limit", in: "a\nb\nc", max: 3, want: "a\nb\nc"},
+		{name: "trailing newline is not a line", in: "a\nb\nc\n", max: 3, want: "a\nb\nc\n"},
+		{name: "one over limit", in: "a\nb\nc\nd", max: 3, want: "a\nb\nc\n... (truncated, 1 more lines)"},
+		{name: "far over limit", in: "1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n", max: 2, want: "1\n2\n... (truncated, 8 more lines)"},
+		{name: "single line", in: "only", max: 1, want: "only"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := truncateLines(tt.in, tt.max); got != tt.want {
+				t.Errorf("truncateLines(%q, %d) = %q, want %q", tt.in, tt.max, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMaxOutputLines_Formatters(t *testing.T) {
+	NoColor = true
+	MaxOutputLines = 2
+	defer func() {
+		NoColor = false
+		MaxOutputLines = 0
+		FullOutput = false
+	}()
+
+	stderr := "line 1\nline 2\nline 3\nline 4"
+	results := &core.TestResults{
+		SpecName: "Cluster",
+		Results: []core.Result{{
+			Name:    "pods ready",
+			Status:  core.StatusError,
+			Message: "kubectl error: " + stderr,
+			Details: map[string]interface{}{"stderr": stderr, "exit_code": 1},
+		}},
+	}
+
+	human := FormatHuman(results)
+	if !strings.Contains(human, "kubectl error: line 1\nline 2\n... (truncated, 2 more lines)") ||
+		strings.Contains(human, "line 3") {
+		t.Errorf("FormatHuman() did not truncate the message:\n%s", human)
+	}
+
+	jsonOut, err := FormatJSON(results)
+	if err != nil {
+		t.Fatalf("FormatJSON() error = %v", err)
+	}
+	if strings.Contains(jsonOut, "line 3") || !strings.Contains(jsonOut, `... (truncated, 2 more lines)`) {
+		t.Errorf("FormatJSON() did not truncate:\n%s", jsonOut)
+	}
+	if results.Results[0].Details["stderr"] != stderr {
+		t.Error("truncation must not modify the results themselves")
+	}
+
+	FullOutput = true
+	jsonOut, err = FormatJSON(results)
+	if err != nil {
+		t.Fatalf("FormatJSON() error = %v", err)
+	}
+	if strings.Contains(jsonOut, "truncated") || strings.Count(jsonOut, "line 4") != 2 {
+		t.Errorf("FormatJSON() with FullOutput should keep the message and details whole:\n%s", jsonOut)
+	}
+}
@@ -0,0 +1,156 @@
+package gcp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"path"
+
+	compute "cloud.google.com/go/compute/apiv1"
+	"cloud.google.com/go/compute/apiv1/computepb"
+	"cloud.google.com/go/storage"
+	"github.com/googleapis/gax-go/v2"
+	"github.com/googleapis/gax-go/v2/apierror"
+	gcpplugin "github.com/neilfarmer/platform-spec/pkg/core/gcp"
+	"google.golang.org/api/googleapi"
+)
+
+// InstancesAPI is the subset of the Compute Engine instances client used by the provider
+type InstancesAPI interface {
+	Get(ctx context.Context, req *computepb.GetInstanceRequest, opts ...gax.CallOption) (*computepb.Instance, error)
+}
+
+// BucketsAPI looks up Cloud Storage bucket attributes
+type BucketsAPI interface {
+	Attrs(ctx context.Context, bucket string) (*storage.BucketAttrs, error)
+}
+
+// Provider implements GCP API testing via the Google Cloud Go SDKs
+type Provider struct {
+	config    *Config
+	instances InstancesAPI
+	buckets   BucketsAPI
+	closers   []func() error
+}
+
+// Config holds GCP connection configuration
+type Config struct {
+	Project string // Default project ID for instance tests
+}
+
+// NewProvider creates a new GCP provider
+func NewProvider(config *Config) *Provider {
+	return &Provider{
+		config: config,
+	}
+}
+
+// NewProviderWithClients creates a GCP provider using existing SDK clients
+func NewProviderWithClients(config *Config, instances InstancesAPI, buckets BucketsAPI) *Provider {
+	return &Provider{
+		config:    config,
+		instances: instances,
+		buckets:   buckets,
+	}
+}
+
+// Connect creates the Compute Engine and Cloud Storage clients using
+// Application Default Credentials
+func (p *Provider) Connect(ctx context.Context) error {
+	instancesClient, err := compute.NewInstancesRESTClient(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create compute client: %w", err)
+	}
+	storageClient, err := storage.NewClient(ctx)
+	if err != nil {
+		instancesClient.Close()
+		return fmt.Errorf("failed to create storage client: %w", err)
+	}
+
+	p.instances = instancesClient
+	p.buckets = &storageBuckets{client: storageClient}
+	p.closers = []func() error{instancesClient.Close, storageClient.Close}
+	return nil
+}
+
+// Close closes the SDK clients
+func (p *Provider) Close() error {
+	var errs []error
+	for _, closeFn := range p.closers {
+		if err := closeFn(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	p.closers = nil
+	return errors.Join(errs...)
+}
+
+// ExecuteCommand is not supported: GCP tests query the APIs directly
+func (p *Provider) ExecuteCommand(ctx context.Context, command string) (stdout, stderr string, exitCode int, err error) {
+	return "", "", -1, fmt.Errorf("the GCP provider does not support running commands")
+}
+
+// GetInstance returns the GCE instance with the given name
+func (p *Provider) GetInstance(ctx context.Context, project, zone, name string) (*gcpplugin.Instance, error) {
+	if project == "" {
+		project = p.config.Project
+	}
+	if project == "" {
+		return nil, fmt.Errorf("no project set for instance %s (use --project or the test's project field)", name)
+	}
+
+	instance, err := p.instances.Get(ctx, &computepb.GetInstanceRequest{
+		Project:  project,
+		Zone:     zone,
+		Instance: name,
+	})
+	if err != nil {
+		if isNotFound(err) {
+			return nil, fmt.Errorf("instance %s: %w", name, gcpplugin.ErrNotFound)
+		}
+		return nil, err
+	}
+
+	return &gcpplugin.Instance{
+		Name:        instance.GetName(),
+		Status:      instance.GetStatus(),
+		MachineType: path.Base(instance.GetMachineType()), // API returns the machine type URL
+		Labels:      instance.GetLabels(),
+	}, nil
+}
+
+// GetBucket returns the GCS bucket with the given name
+func (p *Provider) GetBucket(ctx context.Context, name string) (*gcpplugin.Bucket, error) {
+	attrs, err := p.buckets.Attrs(ctx, name)
+	if err != nil {
+		if errors.Is(err, storage.ErrBucketNotExist) || isNotFound(err) {
+			return nil, fmt.Errorf("bucket %s: %w", name, gcpplugin.ErrNotFound)
+		}
+		return nil, err
+	}
+
+	return &gcpplugin.Bucket{
+		Name:     attrs.Name,
+		Location: attrs.Location,
+	}, nil
+}
+
+// isNotFound reports whether an SDK error is an HTTP 404
+func isNotFound(err error) bool {
+	var apiErr *apierror.APIError
+	if errors.As(err, &apiErr) && apiErr.HTTPCode() == http.StatusNotFound {
+		return true
+	}
+	var gErr *googleapi.Error
+	return errors.As(err, &gErr) && gErr.Code == http.StatusNotFound
+}
+
+// storageBuckets adapts a storage.Client to BucketsAPI
+type storageBuckets struct {
+	client *storage.Client
+}
+
+func (s *storageBuckets) Attrs(ctx context.Context, bucket string) (*storage.BucketAttrs, error) {
+	return s.client.Bucket(bucket).Attrs(ctx)
+}
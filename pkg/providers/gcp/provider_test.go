@@ -0,0 +1,129 @@
+package gcp
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"cloud.google.com/go/compute/apiv1/computepb"
+	"cloud.google.com/go/storage"
+	"github.com/googleapis/gax-go/v2"
+	"github.com/googleapis/gax-go/v2/apierror"
+	"github.com/neilfarmer/platform-spec/pkg/core"
+	gcpplugin "github.com/neilfarmer/platform-spec/pkg/core/gcp"
+	"google.golang.org/api/googleapi"
+	"google.golang.org/protobuf/proto"
+)
+
+// fakeInstances is an in-memory InstancesAPI keyed by project/zone/name
+type fakeInstances struct {
+	instances map[string]*computepb.Instance
+}
+
+func (f *fakeInstances) Get(ctx context.Context, req *computepb.GetInstanceRequest, opts ...gax.CallOption) (*computepb.Instance, error) {
+	instance, ok := f.instances[req.GetProject()+"/"+req.GetZone()+"/"+req.GetInstance()]
+	if !ok {
+		apiErr, _ := apierror.FromError(&googleapi.Error{Code: http.StatusNotFound, Message: "instance not found"})
+		return nil, apiErr
+	}
+	return instance, nil
+}
+
+// fakeBuckets is an in-memory BucketsAPI keyed by bucket name
+type fakeBuckets struct {
+	buckets map[string]*storage.BucketAttrs
+}
+
+func (f *fakeBuckets) Attrs(ctx context.Context, bucket string) (*storage.BucketAttrs, error) {
+	attrs, ok := f.buckets[bucket]
+	if !ok {
+		return nil, storage.ErrBucketNotExist
+	}
+	return attrs, nil
+}
+
+func newFakeProvider() *Provider {
+	instances := &fakeInstances{instances: map[string]*computepb.Instance{
+		"my-project/us-central1-a/web-01": {
+			Name:        proto.String("web-01"),
+			Status:      proto.String("RUNNING"),
+			MachineType: proto.String("https://www.googleapis.com/compute/v1/projects/my-project/zones/us-central1-a/machineTypes/e2-medium"),
+			Labels:      map[string]string{"env": "prod"},
+		},
+	}}
+	buckets := &fakeBuckets{buckets: map[string]*storage.BucketAttrs{
+		"my-assets": {Name: "my-assets", Location: "US"},
+	}}
+	return NewProviderWithClients(&Config{Project: "my-project"}, instances, buckets)
+}
+
+func TestNewProvider(t *testing.T) {
+	config := &Config{Project: "my-project"}
+
+	provider := NewProvider(config)
+
+	if provider == nil {
+		t.Fatal("NewProvider() returned nil")
+	}
+	if provider.config != config {
+		t.Error("Provider config not set correctly")
+	}
+}
+
+func TestGetInstance(t *testing.T) {
+	provider := newFakeProvider()
+
+	instance, err := provider.GetInstance(context.Background(), "", "us-central1-a", "web-01")
+	if err != nil {
+		t.Fatalf("GetInstance() unexpected error: %v", err)
+	}
+	if instance.Status != "RUNNING" {
+		t.Errorf("Status = %q, want RUNNING", instance.Status)
+	}
+	if instance.MachineType != "e2-medium" {
+		t.Errorf("MachineType = %q, want e2-medium", instance.MachineType)
+	}
+
+	_, err = provider.GetInstance(context.Background(), "", "us-central1-a", "web-99")
+	if !errors.Is(err, gcpplugin.ErrNotFound) {
+		t.Errorf("GetInstance() error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestGetInstance_NoProject(t *testing.T) {
+	provider := NewProviderWithClients(&Config{}, &fakeInstances{}, &fakeBuckets{})
+
+	_, err := provider.GetInstance(context.Background(), "", "us-central1-a", "web-01")
+	if err == nil || errors.Is(err, gcpplugin.ErrNotFound) {
+		t.Errorf("GetInstance() error = %v, want missing project error", err)
+	}
+}
+
+func TestGCPPlugin_WithProvider(t *testing.T) {
+	spec := &core.Spec{
+		Tests: core.Tests{
+			GCP: core.GCPTests{
+				Instances: []core.GCPInstanceTest{
+					{Name: "Web server running", Instance: "web-01", Zone: "us-central1-a", State: "present", Status: "RUNNING", MachineType: "e2-medium", Labels: map[string]string{"env": "prod"}},
+				},
+				Buckets: []core.GCPBucketTest{
+					{Name: "Assets bucket", Bucket: "my-assets", State: "present", Location: "us"},
+					{Name: "Backups bucket", Bucket: "my-backups", State: "present"},
+				},
+			},
+		},
+	}
+
+	results, _ := gcpplugin.NewGCPPlugin().Execute(context.Background(), spec, newFakeProvider(), false)
+	if len(results) != 3 {
+		t.Fatalf("Execute() returned %d results, want 3", len(results))
+	}
+
+	want := []core.Status{core.StatusPass, core.StatusPass, core.StatusFail}
+	for i, result := range results {
+		if result.Status != want[i] {
+			t.Errorf("%s: status = %v, want %v (message: %s)", result.Name, result.Status, want[i], result.Message)
+		}
+	}
+}
@@ -7,6 +7,8 @@ import (
 	"os"
 	"os/exec"
 	"syscall"
+
+	"github.com/neilfarmer/platform-spec/pkg/core"
 )
 
 // Provider implements Kubernetes testing via kubectl
@@ -30,18 +32,15 @@ func NewProvider(config *Config) *Provider {
 
 // ExecuteCommand executes a kubectl command and returns stdout, stderr, and exit code
 func (p *Provider) ExecuteCommand(ctx context.Context, command string) (stdout, stderr string, exitCode int, err error) {
-	// Build environment with KUBECONFIG
+	// Build environment with KUBECONFIG; the spec's env comes last so that a
+	// KUBECONFIG set there wins over the provider's default
 	env := os.Environ()
 	if p.config.Kubeconfig != "" {
 		env = append(env, fmt.Sprintf("KUBECONFIG=%s", p.config.Kubeconfig))
 	}
+	env = append(env, core.EnvFromContext(ctx)...)
 
-	// If context is specified, inject --context into kubectl commands
-	if p.config.Context != "" && len(command) >= 7 && command[:7] == "kubectl" {
-		command = fmt.Sprintf("kubectl --context=%s%s", p.config.Context, command[7:])
-	}
-
-	cmd := exec.CommandContext(ctx, "sh", "-c", command)
+	cmd := exec.CommandContext(ctx, core.ShellFromContext(ctx), "-c", p.PlanCommand(command))
 	cmd.Env = env
 
 	var stdoutBuf, stderrBuf bytes.Buffer
@@ -69,3 +68,12 @@ func (p *Provider) ExecuteCommand(ctx context.Context, command string) (stdout,
 
 	return stdout, stderr, exitCode, nil
 }
+
+// PlanCommand returns command as ExecuteCommand runs it: if a context is
+// specified, --context is injected into kubectl commands
+func (p *Provider) PlanCommand(command string) string {
+	if p.config.Context != "" && len(command) >= 7 && command[:7] == "kubectl" {
+		command = fmt.Sprintf("kubectl --context=%s%s", p.config.Context, command[7:])
+	}
+	return command
+}
@@ -2,7 +2,10 @@ package kubernetes
 
 import (
 	"context"
+	"strings"
 	"testing"
+
+	"github.com/neilfarmer/platform-spec/pkg/core"
 )
 
 func TestNewProvider(t *testing.T) {
@@ -56,3 +59,50 @@ func TestExecuteCommandWithContext(t *testing.T) {
 		t.Logf("kubectl not available (expected in some test environments): %v", err)
 	}
 }
+
+func TestExecuteCommandSpecKubeconfig(t *testing.T) {
+	provider := NewProvider(&Config{Kubeconfig: "/home/user/.kube/config"})
+
+	tests := []struct {
+		name string
+		env  map[string]string
+		want string
+	}{
+		{name: "provider default", want: "/home/user/.kube/config"},
+		{name: "spec env wins", env: map[string]string{"KUBECONFIG": "/etc/kube/ci.yaml"}, want: "/etc/kube/ci.yaml"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := core.WithEnv(context.Background(), tt.env)
+			stdout, _, exitCode, err := provider.ExecuteCommand(ctx, "echo $KUBECONFIG")
+			if err != nil || exitCode != 0 {
+				t.Fatalf("ExecuteCommand() = %d, %v", exitCode, err)
+			}
+			if got := strings.TrimSpace(stdout); got != tt.want {
+				t.Errorf("KUBECONFIG = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPlanCommand(t *testing.T) {
+	tests := []struct {
+		name    string
+		context string
+		command string
+		want    string
+	}{
+		{name: "no context", command: "kubectl get nodes -o json", want: "kubectl get nodes -o json"},
+		{name: "kubectl with context", context: "prod", command: "kubectl get nodes -o json", want: "kubectl --context=prod get nodes -o json"},
+		{name: "helm is unchanged", context: "prod", command: "helm list -o json", want: "helm list -o json"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewProvider(&Config{Context: tt.context}).PlanCommand(tt.command); got != tt.want {
+				t.Errorf("PlanCommand() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,261 @@
+package local
+
+import (
+	"fmt"
+	"strings"
+)
+
+// argumentRule limits the arguments of an allowed binary that could otherwise
+// run other programs, load code or write files, e.g. find -exec or docker run
+type argumentRule struct {
+	rejected     []string                // Options that are never allowed
+	clusters     bool                    // Single-letter options may be combined, as in -sO
+	required     []string                // At least one of these options must be given
+	subcommands  map[string]argumentRule // If set, the first non-option argument must be one of these
+	valueOptions map[string]bool         // Options taking a separate value, skipped when finding the subcommand
+	custom       func(args []string) error
+}
+
+// argumentRules holds the rules for allowed binaries that need them
+var argumentRules = map[string]argumentRule{
+	"find": {rejected: []string{"-exec", "-execdir", "-ok", "-okdir", "-delete", "-fprint", "-fprint0", "-fprintf", "-fls"}},
+	"awk":  {custom: checkAwkProgram},
+	"curl": {
+		rejected: []string{
+			"-o", "--output", "-O", "--remote-name", "--remote-name-all", "--output-dir", "-J", "--remote-header-name",
+			"-D", "--dump-header", "-c", "--cookie-jar", "-K", "--config", "--trace", "--trace-ascii",
+			"--libcurl", "--stderr", "--etag-save", "--hsts", "--alt-svc",
+		},
+		clusters: true,
+	},
+	"sort": {rejected: []string{"-o", "--output", "--compress-program"}, clusters: true},
+	"tail": {rejected: []string{"-f", "-F", "--follow", "--retry"}, clusters: true}, // Following never ends
+	"date": {rejected: []string{"-s", "--set"}, clusters: true, custom: checkDateArgs},
+	"hostname": {
+		rejected: []string{"-F", "--file", "-b", "--boot"},
+		clusters: true,
+		custom:   checkHostnameArgs,
+	},
+	"command": {custom: checkCommandLookup},
+	"ss":      {rejected: []string{"-K", "--kill", "-D", "--diag"}, clusters: true},
+	"kcat":    {rejected: []string{"-X", "-F"}, clusters: true},
+	"crontab": {rejected: []string{"-e", "-r", "-i"}, required: []string{"-l"}, clusters: true},
+	"sshd":    {rejected: []string{"-E"}, required: []string{"-T"}, clusters: true}, // -E appends its log to a file
+	"rpm": {
+		rejected: []string{"--eval", "-E", "--define", "-D", "--macros", "--rcfile", "--pipe"},
+		required: []string{"-q", "--query"},
+		clusters: true,
+	},
+	"dpkg": {required: []string{"-l", "--list", "-s", "--status", "-L", "--listfiles", "-S", "--search", "-W", "--show"}},
+	"apk":  {subcommands: subcommandSet("info", "list", "search", "policy", "version")},
+	"dnf":  packageManagerRule,
+	"yum":  packageManagerRule,
+	"systemctl": {
+		rejected:     []string{"-H", "--host", "-M", "--machine"},
+		subcommands:  subcommandSet("is-active", "is-enabled", "is-failed", "is-system-running", "status", "show", "cat", "list-units", "list-unit-files", "list-timers", "list-sockets"),
+		valueOptions: optionSet("-t", "--type", "--state", "-p", "--property"),
+	},
+	"docker": {
+		// Another host or context could be reached over ssh, which docker runs
+		rejected: []string{"--config", "-H", "--host", "-c", "--context"},
+		clusters: true,
+		subcommands: map[string]argumentRule{
+			"inspect": {}, "info": {}, "version": {}, "ps": {}, "images": {}, "logs": {},
+			"image":     {subcommands: subcommandSet("inspect", "ls")},
+			"network":   {subcommands: subcommandSet("inspect", "ls")},
+			"volume":    {subcommands: subcommandSet("inspect", "ls")},
+			"container": {subcommands: subcommandSet("inspect", "ls")},
+			"compose": {
+				subcommands:  subcommandSet("ps", "ls", "config", "images", "version"),
+				valueOptions: optionSet("-p", "--project-name", "-f", "--file", "--project-directory", "--env-file", "--profile", "--ansi", "--progress"),
+			},
+		},
+		valueOptions: optionSet("-l", "--log-level", "--tlscacert", "--tlscert", "--tlskey"),
+	},
+	"kubectl": {
+		// A kubeconfig can name credential plugins, which kubectl runs
+		rejected: []string{"--kubeconfig"},
+		subcommands: map[string]argumentRule{
+			"get": {}, "describe": {}, "version": {}, "explain": {}, "api-resources": {}, "api-versions": {},
+			"cluster-info": {}, "top": {}, "logs": {},
+			"auth":    {subcommands: subcommandSet("can-i", "whoami")},
+			"rollout": {subcommands: subcommandSet("status", "history")},
+			"config":  {subcommands: subcommandSet("view", "current-context", "get-contexts")},
+		},
+		valueOptions: optionSet("-n", "--namespace", "--context", "--cluster", "--user", "-s", "--server", "--request-timeout", "--as", "--as-group", "-o", "--output", "-l", "--selector"),
+	},
+}
+
+// packageManagerRule allows dnf and yum to read repositories and packages;
+// configuration options could load plugins
+var packageManagerRule = argumentRule{
+	rejected:     []string{"-c", "--config", "--setopt", "--installroot", "--enableplugin"},
+	clusters:     true,
+	subcommands:  subcommandSet("repolist", "repoinfo", "list", "info", "search"),
+	valueOptions: optionSet("--repo", "--repoid", "--enablerepo", "--disablerepo", "-x", "--exclude"),
+}
+
+// check returns an error describing the first argument the rule does not allow
+func (r argumentRule) check(args []string) error {
+	for _, arg := range args {
+		for _, option := range r.rejected {
+			if matchesOption(arg, option, r.clusters) {
+				return fmt.Errorf("option %q is not allowed", option)
+			}
+		}
+	}
+	if len(r.required) > 0 && !hasAnyOption(args, r.required, r.clusters) {
+		return fmt.Errorf("requires one of the options %s", strings.Join(r.required, ", "))
+	}
+	if r.custom != nil {
+		if err := r.custom(args); err != nil {
+			return err
+		}
+	}
+	if r.subcommands == nil {
+		return nil
+	}
+
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if strings.HasPrefix(arg, "-") {
+			if r.valueOptions[arg] {
+				i++
+			}
+			continue
+		}
+		sub, ok := r.subcommands[arg]
+		if !ok {
+			return fmt.Errorf("subcommand %q is not allowed", arg)
+		}
+		if err := sub.check(args[i+1:]); err != nil {
+			return fmt.Errorf("%s %w", arg, err)
+		}
+		return nil
+	}
+	// Options alone, such as docker --version
+	return nil
+}
+
+// matchesOption reports whether arg gives option, also as --option=value or
+// abbreviated, as GNU tools accept for long options (--compress-prog for
+// --compress-program), or, for single-letter options with clusters, combined
+// with others or its value
+func matchesOption(arg, option string, clusters bool) bool {
+	if arg == option || strings.HasPrefix(arg, option+"=") {
+		return true
+	}
+	if strings.HasPrefix(option, "--") {
+		name, _, _ := strings.Cut(arg, "=")
+		return len(name) > 2 && strings.HasPrefix(name, "--") && strings.HasPrefix(option, name)
+	}
+	if !clusters || len(option) != 2 || option[0] != '-' {
+		return false
+	}
+	return len(arg) > 2 && arg[0] == '-' && arg[1] != '-' && strings.ContainsRune(arg[1:], rune(option[1]))
+}
+
+// hasAnyOption reports whether any of args gives one of options
+func hasAnyOption(args, options []string, clusters bool) bool {
+	for _, arg := range args {
+		for _, option := range options {
+			if matchesOption(arg, option, clusters) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// checkAwkProgram rejects awk programs that could run commands or write
+// files, and program files or extensions, which cannot be inspected. The
+// program is the first operand or the value of -e or --source.
+func checkAwkProgram(args []string) error {
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		for _, option := range []string{"-f", "--file", "-i", "--include", "-l", "--load", "-E", "--exec"} {
+			if matchesOption(arg, option, true) {
+				return fmt.Errorf("option %q is not allowed", option)
+			}
+		}
+
+		program := arg
+		switch {
+		case arg == "-e" || matchesOption(arg, "--source", false) && !strings.Contains(arg, "="):
+			if i+1 < len(args) {
+				i++
+				program = args[i]
+			}
+		case matchesOption(arg, "--source", false):
+			_, program, _ = strings.Cut(arg, "=")
+		case strings.HasPrefix(arg, "-e"):
+			program = arg[2:]
+		case strings.HasPrefix(arg, "-"):
+			continue
+		}
+		for _, token := range []string{"system", "getline", "|", ">", "@load"} {
+			if strings.Contains(program, token) {
+				return fmt.Errorf("program may not use %q", token)
+			}
+		}
+	}
+	return nil
+}
+
+// checkDateArgs rejects operands other than a +FORMAT, which would set the
+// clock, e.g. date 010112002030
+func checkDateArgs(args []string) error {
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		switch {
+		case optionSet("-d", "--date", "-r", "--reference", "-f", "--file")[arg]:
+			i++
+		case strings.HasPrefix(arg, "-"), strings.HasPrefix(arg, "+"):
+		default:
+			return fmt.Errorf("operand %q would set the clock", arg)
+		}
+	}
+	return nil
+}
+
+// checkHostnameArgs rejects operands, which would set the host name
+func checkHostnameArgs(args []string) error {
+	for _, arg := range args {
+		if !strings.HasPrefix(arg, "-") {
+			return fmt.Errorf("operand %q would set the host name", arg)
+		}
+	}
+	return nil
+}
+
+// checkCommandLookup allows command only with -v or -V, which look a command
+// up, as the requires preflight does, instead of running it
+func checkCommandLookup(args []string) error {
+	for _, arg := range args {
+		if !strings.HasPrefix(arg, "-") || arg == "--" {
+			break
+		}
+		if strings.ContainsAny(arg, "vV") {
+			return nil
+		}
+	}
+	return fmt.Errorf("may only look commands up with -v or -V")
+}
+
+// subcommandSet returns subcommands that take any further arguments
+func subcommandSet(names ...string) map[string]argumentRule {
+	set := make(map[string]argumentRule, len(names))
+	for _, name := range names {
+		set[name] = argumentRule{}
+	}
+	return set
+}
+
+// optionSet returns the given options as a set
+func optionSet(options ...string) map[string]bool {
+	set := make(map[string]bool, len(options))
+	for _, option := range options {
+		set[option] = true
+	}
+	return set
+}
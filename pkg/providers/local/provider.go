@@ -4,21 +4,41 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"os"
 	"os/exec"
 	"syscall"
+
+	"github.com/neilfarmer/platform-spec/pkg/core"
 )
 
 // Provider implements local system testing
-type Provider struct{}
+type Provider struct {
+	allowlist map[string]bool // Allowed binaries in sandbox mode (nil = unrestricted)
+}
 
 // NewProvider creates a new local provider
 func NewProvider() *Provider {
 	return &Provider{}
 }
 
+// NewSandboxedProvider creates a local provider that only runs commands whose
+// binaries are in the allowlist. Shell builtins such as echo and test are always allowed.
+func NewSandboxedProvider(allowlist []string) *Provider {
+	allowed := make(map[string]bool, len(allowlist))
+	for _, name := range allowlist {
+		allowed[name] = true
+	}
+	return &Provider{allowlist: allowed}
+}
+
 // ExecuteCommand executes a command on the local system and returns stdout, stderr, and exit code
 func (p *Provider) ExecuteCommand(ctx context.Context, command string) (stdout, stderr string, exitCode int, err error) {
-	cmd := exec.CommandContext(ctx, "sh", "-c", command)
+	if err := p.CheckCommand(command); err != nil {
+		return "", "", -1, err
+	}
+
+	cmd := exec.CommandContext(ctx, core.ShellFromContext(ctx), "-c", command)
+	cmd.Env = append(os.Environ(), core.EnvFromContext(ctx)...)
 
 	var stdoutBuf, stderrBuf bytes.Buffer
 	cmd.Stdout = &stdoutBuf
@@ -5,6 +5,8 @@ import (
 	"strings"
 	"testing"
 	"time"
+
+	"github.com/neilfarmer/platform-spec/pkg/core"
 )
 
 func TestNewProvider(t *testing.T) {
@@ -99,3 +101,71 @@ func TestExecuteCommandWithContext(t *testing.T) {
 		t.Error("ExecuteCommand() should have failed for timed out command")
 	}
 }
+
+func TestExecuteCommandShell(t *testing.T) {
+	provider := NewProvider()
+
+	stdout, _, _, err := provider.ExecuteCommand(context.Background(), "echo $0")
+	if err != nil {
+		t.Fatalf("ExecuteCommand() error = %v", err)
+	}
+	if got := strings.TrimSpace(stdout); got != core.DefaultShell {
+		t.Errorf("default shell = %q, want %q", got, core.DefaultShell)
+	}
+
+	ctx := core.WithShell(context.Background(), "/bin/bash")
+	stdout, _, _, err = provider.ExecuteCommand(ctx, "echo $0 ${BASH_VERSINFO[0]}")
+	if err != nil {
+		t.Fatalf("ExecuteCommand() error = %v", err)
+	}
+	if fields := strings.Fields(stdout); len(fields) != 2 || fields[0] != "/bin/bash" {
+		t.Errorf("configured shell output = %q, want /bin/bash and its version", stdout)
+	}
+}
+
+func TestExecuteCommandEnv(t *testing.T) {
+	provider := NewProvider()
+
+	ctx := core.WithEnv(context.Background(), map[string]string{"PLATFORM_SPEC_GREETING": "hello world"})
+	stdout, _, _, err := provider.ExecuteCommand(ctx, "echo \"$PLATFORM_SPEC_GREETING\"")
+	if err != nil {
+		t.Fatalf("ExecuteCommand() error = %v", err)
+	}
+	if got := strings.TrimSpace(stdout); got != "hello world" {
+		t.Errorf("injected env = %q, want %q", got, "hello world")
+	}
+
+	// The rest of the environment is kept
+	stdout, _, _, err = provider.ExecuteCommand(ctx, "echo \"$PATH\"")
+	if err != nil {
+		t.Fatalf("ExecuteCommand() error = %v", err)
+	}
+	if strings.TrimSpace(stdout) == "" {
+		t.Error("PATH is empty with injected env")
+	}
+}
+
+func TestExecuteCommandLocale(t *testing.T) {
+	provider := NewProvider()
+	t.Setenv("LANG", "de_DE.UTF-8")
+	t.Setenv("LC_ALL", "de_DE.UTF-8")
+
+	// Commands run in the C locale whatever the host's, so output such as
+	// day names parses the same everywhere
+	stdout, _, _, err := provider.ExecuteCommand(context.Background(), "echo \"$LANG $LC_ALL\" $(date -u -d @0 +%A)")
+	if err != nil {
+		t.Fatalf("ExecuteCommand() error = %v", err)
+	}
+	if got := strings.TrimSpace(stdout); got != "C C Thursday" {
+		t.Errorf("locale output = %q, want %q", got, "C C Thursday")
+	}
+
+	ctx := core.WithEnv(context.Background(), map[string]string{"LC_ALL": "POSIX"})
+	stdout, _, _, err = provider.ExecuteCommand(ctx, "echo \"$LANG $LC_ALL\"")
+	if err != nil {
+		t.Fatalf("ExecuteCommand() error = %v", err)
+	}
+	if got := strings.TrimSpace(stdout); got != "C POSIX" {
+		t.Errorf("overridden locale = %q, want %q", got, "C POSIX")
+	}
+}
@@ -0,0 +1,137 @@
+package local
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"github.com/neilfarmer/platform-spec/pkg/core"
+)
+
+// MinResources is the free memory and disk space, in bytes, the local machine
+// needs before tests run. Zero values are not checked.
+type MinResources struct {
+	Memory int64
+	Disk   int64
+}
+
+// ParseMinResources parses --min-resources entries such as memory=512Mi or
+// disk=2G
+func ParseMinResources(entries []string) (MinResources, error) {
+	var min MinResources
+	for _, entry := range entries {
+		key, value, found := strings.Cut(entry, "=")
+		if !found {
+			return MinResources{}, fmt.Errorf("'%s' must be memory=SIZE or disk=SIZE", entry)
+		}
+		size, err := core.ParseSize(value)
+		if err != nil || size <= 0 {
+			return MinResources{}, fmt.Errorf("'%s': size must be positive, e.g. 512Mi or 2G", entry)
+		}
+		switch key {
+		case "memory":
+			min.Memory = size
+		case "disk":
+			min.Disk = size
+		default:
+			return MinResources{}, fmt.Errorf("'%s': unknown resource '%s' (must be memory or disk)", entry, key)
+		}
+	}
+	return min, nil
+}
+
+// ResourceReader reports free resources on the local machine
+type ResourceReader interface {
+	FreeMemory() (int64, error)
+	FreeDisk(path string) (int64, error)
+}
+
+// SystemResources reads free memory from /proc/meminfo and free disk space
+// with statfs
+type SystemResources struct{}
+
+// FreeMemory returns the memory available for new processes (MemAvailable)
+func (SystemResources) FreeMemory() (int64, error) {
+	f, err := os.Open("/proc/meminfo")
+	if err != nil {
+		return 0, fmt.Errorf("failed to read free memory: %w", err)
+	}
+	defer f.Close()
+	return parseMemAvailable(f)
+}
+
+// FreeDisk returns the space available to unprivileged users on the
+// filesystem holding path
+func (SystemResources) FreeDisk(path string) (int64, error) {
+	var st syscall.Statfs_t
+	if err := syscall.Statfs(path, &st); err != nil {
+		return 0, fmt.Errorf("failed to read free disk space on %s: %w", path, err)
+	}
+	// #nosec G115 -- Block counts and sizes of real filesystems fit in int64
+	return int64(st.Bavail) * int64(st.Bsize), nil
+}
+
+// parseMemAvailable extracts MemAvailable from /proc/meminfo, which reports it
+// in kB
+func parseMemAvailable(r io.Reader) (int64, error) {
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 2 || fields[0] != "MemAvailable:" {
+			continue
+		}
+		kb, err := strconv.ParseInt(fields[1], 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("unexpected MemAvailable value '%s'", fields[1])
+		}
+		return kb * 1024, nil
+	}
+	if err := scanner.Err(); err != nil {
+		return 0, fmt.Errorf("failed to read free memory: %w", err)
+	}
+	return 0, fmt.Errorf("failed to read free memory: MemAvailable not found in /proc/meminfo")
+}
+
+// CheckResources returns an error if the machine has less free memory or
+// disk space at diskPath than min requires
+func CheckResources(min MinResources, reader ResourceReader, diskPath string) error {
+	if min.Memory > 0 {
+		free, err := reader.FreeMemory()
+		if err != nil {
+			return err
+		}
+		if free < min.Memory {
+			return fmt.Errorf("only %s of memory free, %s required", formatBytes(free), formatBytes(min.Memory))
+		}
+	}
+	if min.Disk > 0 {
+		free, err := reader.FreeDisk(diskPath)
+		if err != nil {
+			return err
+		}
+		if free < min.Disk {
+			return fmt.Errorf("only %s of disk free on %s, %s required", formatBytes(free), diskPath, formatBytes(min.Disk))
+		}
+	}
+	return nil
+}
+
+// formatBytes renders n in the largest binary unit that keeps it at least 1,
+// e.g. 1.5Gi
+func formatBytes(n int64) string {
+	units := []string{"", "Ki", "Mi", "Gi", "Ti"}
+	value := float64(n)
+	i := 0
+	for value >= 1024 && i < len(units)-1 {
+		value /= 1024
+		i++
+	}
+	if i == 0 {
+		return fmt.Sprintf("%d bytes", n)
+	}
+	return strconv.FormatFloat(value, 'f', 1, 64) + units[i]
+}
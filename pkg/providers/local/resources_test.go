@@ -0,0 +1,124 @@
+package local
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+// fakeResources reports fixed free resources
+type fakeResources struct {
+	memory, disk int64
+	err          error
+	diskPath     string
+}
+
+func (f *fakeResources) FreeMemory() (int64, error) {
+	return f.memory, f.err
+}
+
+func (f *fakeResources) FreeDisk(path string) (int64, error) {
+	f.diskPath = path
+	return f.disk, f.err
+}
+
+func TestParseMinResources(t *testing.T) {
+	tests := []struct {
+		name    string
+		entries []string
+		want    MinResources
+		wantErr string
+	}{
+		{name: "memory and disk", entries: []string{"memory=512Mi", "disk=2G"}, want: MinResources{Memory: 512 << 20, Disk: 2_000_000_000}},
+		{name: "none", entries: nil, want: MinResources{}},
+		{name: "missing size", entries: []string{"memory"}, wantErr: "must be memory=SIZE or disk=SIZE"},
+		{name: "invalid size", entries: []string{"disk=lots"}, wantErr: "size must be positive"},
+		{name: "zero size", entries: []string{"disk=0"}, wantErr: "size must be positive"},
+		{name: "unknown resource", entries: []string{"cpu=2"}, wantErr: "unknown resource 'cpu'"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseMinResources(tt.entries)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("ParseMinResources() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseMinResources() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("ParseMinResources() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckResources(t *testing.T) {
+	const gi = 1 << 30
+	tests := []struct {
+		name    string
+		min     MinResources
+		reader  *fakeResources
+		wantErr string
+	}{
+		{
+			name:   "enough memory and disk",
+			min:    MinResources{Memory: gi, Disk: 10 * gi},
+			reader: &fakeResources{memory: 2 * gi, disk: 20 * gi},
+		},
+		{
+			name:    "low memory",
+			min:     MinResources{Memory: 2 * gi},
+			reader:  &fakeResources{memory: 512 << 20, disk: 20 * gi},
+			wantErr: "only 512.0Mi of memory free, 2.0Gi required",
+		},
+		{
+			name:    "low disk",
+			min:     MinResources{Disk: 10 * gi},
+			reader:  &fakeResources{memory: 2 * gi, disk: 3 * gi / 2},
+			wantErr: "only 1.5Gi of disk free on /, 10.0Gi required",
+		},
+		{
+			name:    "unreadable",
+			min:     MinResources{Memory: gi},
+			reader:  &fakeResources{err: errors.New("failed to read free memory: no /proc")},
+			wantErr: "no /proc",
+		},
+		{
+			name:   "nothing required reads nothing",
+			min:    MinResources{},
+			reader: &fakeResources{err: errors.New("should not be read")},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckResources(tt.min, tt.reader, "/")
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("CheckResources() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("CheckResources() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestParseMemAvailable(t *testing.T) {
+	meminfo := "MemTotal:       16315888 kB\nMemFree:          900000 kB\nMemAvailable:    8157944 kB\n"
+	got, err := parseMemAvailable(strings.NewReader(meminfo))
+	if err != nil {
+		t.Fatalf("parseMemAvailable() error = %v", err)
+	}
+	if want := int64(8157944 * 1024); got != want {
+		t.Errorf("parseMemAvailable() = %d, want %d", got, want)
+	}
+
+	if _, err := parseMemAvailable(strings.NewReader("MemTotal: 1 kB\n")); err == nil {
+		t.Error("parseMemAvailable() without MemAvailable should fail")
+	}
+}
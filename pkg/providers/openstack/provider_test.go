@@ -0,0 +1,158 @@
+package openstack
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	th "github.com/gophercloud/gophercloud/v2/testhelper"
+	"github.com/gophercloud/gophercloud/v2/testhelper/client"
+	"github.com/neilfarmer/platform-spec/pkg/core"
+	osplugin "github.com/neilfarmer/platform-spec/pkg/core/openstack"
+)
+
+const serverJSON = `{
+	"id": "9e5476bd-a4ec-4653-93d6-72c93aa682ba",
+	"name": "web-01",
+	"status": "ACTIVE",
+	"addresses": {
+		"private": [
+			{"addr": "10.0.0.5", "version": 4, "OS-EXT-IPS:type": "fixed"},
+			{"addr": "203.0.113.10", "version": 4, "OS-EXT-IPS:type": "floating"}
+		]
+	}
+}`
+
+func notFound(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusNotFound)
+	fmt.Fprint(w, `{"itemNotFound": {"message": "not found", "code": 404}}`)
+}
+
+func jsonResponse(w http.ResponseWriter, body string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	fmt.Fprint(w, body)
+}
+
+func setupFakeServer(t *testing.T) (*Provider, func()) {
+	t.Helper()
+	fakeServer := th.SetupHTTP()
+
+	fakeServer.Mux.HandleFunc("/servers/9e5476bd-a4ec-4653-93d6-72c93aa682ba", func(w http.ResponseWriter, r *http.Request) {
+		jsonResponse(w, `{"server": `+serverJSON+`}`)
+	})
+	fakeServer.Mux.HandleFunc("/servers/web-01", notFound)
+	fakeServer.Mux.HandleFunc("/servers/detail", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("name") == "^web-01$" {
+			jsonResponse(w, `{"servers": [`+serverJSON+`]}`)
+			return
+		}
+		jsonResponse(w, `{"servers": []}`)
+	})
+	fakeServer.Mux.HandleFunc("/servers/missing", notFound)
+	fakeServer.Mux.HandleFunc("/volumes/data-vol", notFound)
+	fakeServer.Mux.HandleFunc("/volumes/detail", func(w http.ResponseWriter, r *http.Request) {
+		jsonResponse(w, `{"volumes": []}`)
+	})
+
+	sc := client.ServiceClient(fakeServer)
+	return NewProviderWithClients(sc, sc), fakeServer.Teardown
+}
+
+func TestNewProvider(t *testing.T) {
+	config := &Config{Cloud: "mycloud", Region: "RegionOne"}
+
+	provider := NewProvider(config)
+
+	if provider == nil {
+		t.Fatal("NewProvider() returned nil")
+	}
+	if provider.config != config {
+		t.Error("Provider config not set correctly")
+	}
+}
+
+func TestExecuteCommand_Unsupported(t *testing.T) {
+	provider := NewProvider(&Config{})
+
+	_, _, _, err := provider.ExecuteCommand(context.Background(), "echo hello")
+	if err == nil {
+		t.Error("ExecuteCommand() expected error, got nil")
+	}
+}
+
+func TestGetInstance(t *testing.T) {
+	provider, teardown := setupFakeServer(t)
+	defer teardown()
+
+	tests := []struct {
+		name     string
+		nameOrID string
+		wantErr  bool
+		notFound bool
+	}{
+		{name: "by ID", nameOrID: "9e5476bd-a4ec-4653-93d6-72c93aa682ba"},
+		{name: "by name", nameOrID: "web-01"},
+		{name: "missing", nameOrID: "missing", wantErr: true, notFound: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			instance, err := provider.GetInstance(context.Background(), tt.nameOrID)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("GetInstance() expected error, got nil")
+				}
+				if tt.notFound && !errors.Is(err, osplugin.ErrNotFound) {
+					t.Errorf("GetInstance() error = %v, want ErrNotFound", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("GetInstance() unexpected error: %v", err)
+			}
+			if instance.Status != "ACTIVE" {
+				t.Errorf("Status = %q, want ACTIVE", instance.Status)
+			}
+			if len(instance.FloatingIPs) != 1 || instance.FloatingIPs[0] != "203.0.113.10" {
+				t.Errorf("FloatingIPs = %v, want [203.0.113.10]", instance.FloatingIPs)
+			}
+		})
+	}
+}
+
+func TestOpenStackPlugin_WithProvider(t *testing.T) {
+	provider, teardown := setupFakeServer(t)
+	defer teardown()
+
+	spec := &core.Spec{
+		Tests: core.Tests{
+			OpenStack: core.OpenStackTests{
+				Instances: []core.OpenStackInstanceTest{
+					{Name: "Web server active", Instance: "web-01", State: "present", Status: "ACTIVE"},
+				},
+				Volumes: []core.OpenStackVolumeTest{
+					{Name: "Data volume", Volume: "data-vol", State: "present"},
+				},
+				FloatingIPs: []core.OpenStackFloatingIPTest{
+					{Name: "Web floating IP", FloatingIP: "203.0.113.10", Instance: "web-01"},
+				},
+			},
+		},
+	}
+
+	results, _ := osplugin.NewOpenStackPlugin().Execute(context.Background(), spec, provider, false)
+	if len(results) != 3 {
+		t.Fatalf("Execute() returned %d results, want 3", len(results))
+	}
+
+	want := []core.Status{core.StatusPass, core.StatusFail, core.StatusPass}
+	for i, result := range results {
+		if result.Status != want[i] {
+			t.Errorf("%s: status = %v, want %v (message: %s)", result.Name, result.Status, want[i], result.Message)
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package remote
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"golang.org/x/crypto/ssh"
+)
+
+// Algorithms restricts the SSH algorithms offered to a target host, for
+// devices with non-default crypto policies. Empty lists keep the defaults of
+// golang.org/x/crypto/ssh.
+type Algorithms struct {
+	KeyExchanges []string // Key exchange algorithms, e.g. curve25519-sha256
+	Ciphers      []string // Ciphers, e.g. aes128-ctr
+	HostKeys     []string // Host key algorithms, e.g. rsa-sha2-256
+}
+
+// Validate returns an error if any algorithm is not implemented by
+// golang.org/x/crypto/ssh. Algorithms it considers insecure are allowed, as
+// legacy hosts may only offer those.
+func (a Algorithms) Validate() error {
+	supported, insecure := ssh.SupportedAlgorithms(), ssh.InsecureAlgorithms()
+	checks := []struct {
+		kind      string
+		names     []string
+		supported []string
+	}{
+		{"key exchange", a.KeyExchanges, append(supported.KeyExchanges, insecure.KeyExchanges...)},
+		{"cipher", a.Ciphers, append(supported.Ciphers, insecure.Ciphers...)},
+		{"host key algorithm", a.HostKeys, append(supported.HostKeys, insecure.HostKeys...)},
+	}
+	for _, check := range checks {
+		for _, name := range check.names {
+			if !slices.Contains(check.supported, name) {
+				return fmt.Errorf("unsupported %s '%s' (supported: %s)", check.kind, name, strings.Join(check.supported, ", "))
+			}
+		}
+	}
+	return nil
+}
+
+// apply sets the algorithms on an SSH client config
+func (a Algorithms) apply(config *ssh.ClientConfig) {
+	config.KeyExchanges = a.KeyExchanges
+	config.Ciphers = a.Ciphers
+	config.HostKeyAlgorithms = a.HostKeys
+}
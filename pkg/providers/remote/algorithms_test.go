@@ -0,0 +1,67 @@
+package remote
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"golang.org/x/crypto/ssh"
+)
+
+func TestAlgorithms_Validate(t *testing.T) {
+	valid := []Algorithms{
+		{},
+		{KeyExchanges: []string{"curve25519-sha256"}, Ciphers: []string{"aes128-ctr"}, HostKeys: []string{"rsa-sha2-256"}},
+		// Insecure algorithms are allowed for legacy hosts
+		{KeyExchanges: []string{"diffie-hellman-group1-sha1"}, HostKeys: []string{"ssh-rsa"}},
+	}
+	for _, algorithms := range valid {
+		if err := algorithms.Validate(); err != nil {
+			t.Errorf("Validate(%+v) error = %v", algorithms, err)
+		}
+	}
+
+	invalid := []struct {
+		algorithms Algorithms
+		want       string
+	}{
+		{Algorithms{KeyExchanges: []string{"curve25519"}}, "unsupported key exchange 'curve25519'"},
+		{Algorithms{Ciphers: []string{"blowfish-cbc"}}, "unsupported cipher 'blowfish-cbc'"},
+		{Algorithms{HostKeys: []string{"ssh-dss-512"}}, "unsupported host key algorithm 'ssh-dss-512'"},
+	}
+	for _, tt := range invalid {
+		err := tt.algorithms.Validate()
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("Validate(%+v) error = %v, want %q", tt.algorithms, err, tt.want)
+		}
+	}
+}
+
+func TestTargetClientConfig_Algorithms(t *testing.T) {
+	algorithms := Algorithms{
+		KeyExchanges: []string{"diffie-hellman-group14-sha1"},
+		Ciphers:      []string{"aes256-ctr", "aes128-ctr"},
+		HostKeys:     []string{"ssh-rsa"},
+	}
+	p := NewProvider(&Config{User: "admin", Algorithms: algorithms})
+	config := p.targetClientConfig(nil, ssh.InsecureIgnoreHostKey())
+
+	if config.User != "admin" {
+		t.Errorf("User = %q, want admin", config.User)
+	}
+	if !reflect.DeepEqual(config.KeyExchanges, algorithms.KeyExchanges) {
+		t.Errorf("KeyExchanges = %v, want %v", config.KeyExchanges, algorithms.KeyExchanges)
+	}
+	if !reflect.DeepEqual(config.Ciphers, algorithms.Ciphers) {
+		t.Errorf("Ciphers = %v, want %v", config.Ciphers, algorithms.Ciphers)
+	}
+	if !reflect.DeepEqual(config.HostKeyAlgorithms, algorithms.HostKeys) {
+		t.Errorf("HostKeyAlgorithms = %v, want %v", config.HostKeyAlgorithms, algorithms.HostKeys)
+	}
+
+	// Without options the library defaults apply
+	config = NewProvider(&Config{User: "admin"}).targetClientConfig(nil, ssh.InsecureIgnoreHostKey())
+	if config.KeyExchanges != nil || config.Ciphers != nil || config.HostKeyAlgorithms != nil {
+		t.Errorf("default config sets algorithms: %+v", config)
+	}
+}
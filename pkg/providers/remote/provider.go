@@ -1,16 +1,21 @@
 package remote
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"net"
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 	"time"
 
-	"github.com/neilfarmer/platform-spec/pkg/retry"
 	ssh_config "github.com/kevinburke/ssh_config"
+	"github.com/neilfarmer/platform-spec/pkg/core"
+	"github.com/neilfarmer/platform-spec/pkg/retry"
 	"golang.org/x/crypto/ssh"
 	"golang.org/x/crypto/ssh/agent"
 	"golang.org/x/crypto/ssh/knownhosts"
@@ -21,23 +26,33 @@ type Provider struct {
 	client     *ssh.Client
 	jumpClient *ssh.Client // Jump host client (if using jump host)
 	config     *Config
+	attempts   []core.ConnectionAttempt // Failed connection attempts
 }
 
 // Config holds remote connection configuration
 type Config struct {
-	Host                   string
-	Port                   int
-	User                   string
-	IdentityFile           string
-	Timeout                time.Duration
-	StrictHostKeyChecking  bool          // Enable strict host key checking (default: true)
-	KnownHostsFile         string        // Path to known_hosts file (default: ~/.ssh/known_hosts)
-	InsecureIgnoreHostKey  bool          // Disable host key verification (INSECURE, not recommended)
-	JumpHost               string        // Jump host (bastion) hostname or IP
-	JumpPort               int           // Jump host SSH port (default: 22)
-	JumpUser               string        // Jump host SSH user
-	JumpIdentityFile       string        // SSH private key for jump host (optional, defaults to IdentityFile)
-	RetryConfig            *retry.Config // Retry configuration (nil = no retries)
+	Host                  string
+	Port                  int
+	User                  string
+	BecomeUser            string // Run every command as this user via sudo -u (empty = User)
+	BecomePassword        string // Password sent to sudo on stdin for BecomeUser (empty = passwordless sudo)
+	IdentityFile          string
+	Timeout               time.Duration
+	StrictHostKeyChecking bool                  // Enable strict host key checking (default: true)
+	KnownHostsFile        string                // Path to known_hosts file (default: ~/.ssh/known_hosts)
+	InsecureIgnoreHostKey bool                  // Disable host key verification (INSECURE, not recommended)
+	AcceptNewHostKeys     bool                  // Record keys of hosts not in known_hosts on first contact; changed keys are still rejected
+	Algorithms            Algorithms            // SSH algorithms offered to the target host (empty = library defaults)
+	ForwardAgent          bool                  // Forward the local SSH agent to command sessions on the target, like ssh -A
+	JumpHost              string                // Jump host (bastion) hostname or IP
+	JumpPort              int                   // Jump host SSH port (default: 22)
+	JumpUser              string                // Jump host SSH user
+	JumpIdentityFile      string                // SSH private key for jump host (optional, defaults to IdentityFile)
+	RetryConfig           *retry.Config         // Connection retry configuration (nil = no retries)
+	CommandRetryConfig    *retry.Config         // Retry configuration for commands that fail transiently (nil = no retries)
+	RetryClassifier       retry.ErrorClassifier // Retry classifier (nil = retry.IsRetryableSSHError)
+	JumpBreaker           *retry.Breaker        // Circuit breaker shared by hosts using the same jump host (nil = disabled)
+	MaxReconnects         int                   // Times a dead connection is re-dialled before a command fails (0 = 1)
 }
 
 // ParseTarget parses a target string like "user@host" or "host"
@@ -65,19 +80,123 @@ func NewProvider(config *Config) *Provider {
 // Connect establishes the SSH connection with optional retry logic
 // If a jump host is configured, it will connect through the jump host
 func (p *Provider) Connect(ctx context.Context) error {
-	// If retry config is nil, execute directly without retries
+	if err := p.connectWithRetry(ctx); err != nil {
+		return err
+	}
+
+	// Fail the connection up front rather than every test if sudo is refused
+	if err := p.checkBecome(ctx); err != nil {
+		p.Close()
+		return err
+	}
+	return nil
+}
+
+// connectWithRetry connects, retrying transient failures if configured, and
+// records every failed attempt
+func (p *Provider) connectWithRetry(ctx context.Context) error {
+	p.attempts = nil
+	classifier := p.retryClassifier()
+	attempt := 0
+	var lastErr error
+	connect := func() error {
+		attempt++
+		lastErr = p.connectOnce(ctx)
+		return lastErr
+	}
+
+	var err error
 	if p.config.RetryConfig == nil {
-		return p.connectOnce(ctx)
+		// If retry config is nil, execute directly without retries
+		err = connect()
+	} else {
+		// Record retried attempts as they happen, then pass them on to the
+		// caller's own hook
+		config := *p.config.RetryConfig
+		config.OnRetry = func(n int, err error, nextDelay time.Duration) {
+			p.attempts = append(p.attempts, core.ConnectionAttempt{Attempt: n, Error: err.Error(), Retryable: true, NextDelay: nextDelay})
+			if p.config.RetryConfig.OnRetry != nil {
+				p.config.RetryConfig.OnRetry(n, err, nextDelay)
+			}
+		}
+		err = retry.Do(ctx, &config, classifier, connect)
 	}
 
-	// Wrap connection logic with retry
-	return retry.Do(ctx, p.config.RetryConfig, retry.IsRetryableSSHError, func() error {
-		return p.connectOnce(ctx)
-	})
+	// The final attempt is not retried, so OnRetry has not recorded it. It was
+	// already recorded if the context ended during the backoff.
+	if lastErr != nil && (len(p.attempts) == 0 || p.attempts[len(p.attempts)-1].Attempt != attempt) {
+		p.attempts = append(p.attempts, core.ConnectionAttempt{Attempt: attempt, Error: lastErr.Error(), Retryable: classifier(lastErr)})
+	}
+	return err
+}
+
+// ConnectionAttempts returns the failed attempts of the last Connect
+func (p *Provider) ConnectionAttempts() []core.ConnectionAttempt {
+	return p.attempts
+}
+
+// becomes reports whether commands run as a user other than the SSH user
+func (p *Provider) becomes() bool {
+	return p.config.BecomeUser != "" && p.config.BecomeUser != p.config.User
+}
+
+// shellCommand wraps command to run with the context's shell rather than the
+// SSH user's login shell, and as BecomeUser if set. sudo -n fails instead of
+// prompting for a password. With BecomePassword, sudo reads the password from
+// stdin without a prompt instead; -k makes it read the password every time,
+// so a cached credential never leaves it for the command.
+func (p *Provider) shellCommand(ctx context.Context, command string) string {
+	if !p.becomes() {
+		return core.ShellCommand(ctx, command)
+	}
+	if p.config.BecomePassword != "" {
+		return fmt.Sprintf("sudo -k -S -p '' -u %s -- %s", core.ShellQuote(p.config.BecomeUser), core.ShellCommand(ctx, command))
+	}
+	return fmt.Sprintf("sudo -n -u %s -- %s", core.ShellQuote(p.config.BecomeUser), core.ShellCommand(ctx, command))
+}
+
+// checkBecome verifies that commands can be run as BecomeUser
+func (p *Provider) checkBecome(ctx context.Context) error {
+	if !p.becomes() {
+		return nil
+	}
+	command := p.shellCommand(ctx, "true")
+	_, stderr, exitCode, err := p.executeCommandOnce(ctx, command)
+	if err != nil {
+		return fmt.Errorf("cannot become %s: %w", p.config.BecomeUser, err)
+	}
+	if exitCode != 0 {
+		return fmt.Errorf("cannot become %s: %s", p.config.BecomeUser, becomeFailure(stderr))
+	}
+	return nil
+}
+
+// becomeFailure explains why sudo refused to run a command, given its stderr
+func becomeFailure(stderr string) string {
+	switch {
+	case strings.Contains(stderr, "a password is required"):
+		return "sudo requires a password but none was given"
+	case strings.Contains(stderr, "incorrect password"), strings.Contains(stderr, "Sorry, try again"):
+		return "sudo rejected the password"
+	}
+	return strings.TrimSpace(stderr)
+}
+
+// retryClassifier returns the configured retry classifier or the built-in SSH classifier
+func (p *Provider) retryClassifier() retry.ErrorClassifier {
+	if p.config.RetryClassifier != nil {
+		return p.config.RetryClassifier
+	}
+	return retry.IsRetryableSSHError
 }
 
-// connectOnce performs a single connection attempt without retry logic
+// connectOnce performs a single connection attempt without retry logic.
+// Clients left by an earlier attempt or a dead connection are closed first,
+// so retries and reconnects never leak them.
 func (p *Provider) connectOnce(ctx context.Context) error {
+	// #nosec G104 -- Stale clients are being discarded, close errors don't matter
+	p.Close()
+
 	// Configure host key verification
 	hostKeyCallback, err := p.getHostKeyCallback()
 	if err != nil {
@@ -98,12 +217,12 @@ func (p *Provider) connectOnce(ctx context.Context) error {
 			return err
 		}
 
-		client, err := p.connectViaJumpHost(jumpAuthMethods, targetAuthMethods, hostKeyCallback)
+		client, err := p.connectViaJumpHost(ctx, jumpAuthMethods, targetAuthMethods, hostKeyCallback)
 		if err != nil {
 			return err
 		}
 		p.client = client
-		return nil
+		return p.forwardAgent()
 	}
 
 	// Direct connection (no jump host) - use target auth methods
@@ -112,25 +231,148 @@ func (p *Provider) connectOnce(ctx context.Context) error {
 		return err
 	}
 
-	sshConfig := &ssh.ClientConfig{
-		User:            p.config.User,
-		Auth:            targetAuthMethods,
-		HostKeyCallback: hostKeyCallback,
-		Timeout:         p.config.Timeout,
-	}
+	sshConfig := p.targetClientConfig(targetAuthMethods, hostKeyCallback)
 
 	// Resolve hostname via SSH config before DNS resolution
 	resolvedHost := resolveHostFromSSHConfig(p.config.Host)
 	addr := fmt.Sprintf("%s:%d", resolvedHost, p.config.Port)
-	client, err := ssh.Dial("tcp", addr, sshConfig)
+	client, err := dialSSH(ctx, addr, sshConfig)
 	if err != nil {
 		return fmt.Errorf("failed to connect to %s: %w", addr, err)
 	}
 
 	p.client = client
+	return p.forwardAgent()
+}
+
+// forwardAgent serves agent channels the target opens from the local SSH
+// agent, if ForwardAgent is set. Sessions request forwarding in newSession.
+func (p *Provider) forwardAgent() error {
+	if !p.config.ForwardAgent {
+		return nil
+	}
+	sshAgent, err := getSSHAgent()
+	if err != nil {
+		// #nosec G104 -- Already in error path, ignoring close error is acceptable
+		p.Close()
+		return fmt.Errorf("agent forwarding requested but no SSH agent is available: %w", err)
+	}
+	if err := agent.ForwardToAgent(p.client, sshAgent); err != nil {
+		// #nosec G104 -- Already in error path, ignoring close error is acceptable
+		p.Close()
+		return fmt.Errorf("failed to forward SSH agent: %w", err)
+	}
 	return nil
 }
 
+// Reconnect replaces the connection with a new one, dialled through the jump
+// host if one is configured and authenticated as by Connect. Unlike Connect,
+// it makes a single attempt and does not record it in ConnectionAttempts.
+func (p *Provider) Reconnect(ctx context.Context) error {
+	return p.connectOnce(ctx)
+}
+
+// openSession opens a session for a command. If the connection is dead, e.g.
+// dropped by the target while idle, it is re-dialled up to MaxReconnects
+// times, backing off per RetryConfig if set. Each reconnect counts towards
+// the limit whether or not it yields a session, so a target that accepts
+// connections but refuses sessions cannot keep a command reconnecting.
+func (p *Provider) openSession(ctx context.Context) (*ssh.Session, error) {
+	// A failed reconnect leaves no client behind, so treat that as a dead connection
+	if p.client != nil {
+		if session, err := p.newSession(); err == nil {
+			return session, nil
+		}
+	}
+
+	maxReconnects := p.config.MaxReconnects
+	if maxReconnects <= 0 {
+		maxReconnects = 1
+	}
+	var lastErr error
+	var delay time.Duration
+	for reconnect := 0; reconnect < maxReconnects; reconnect++ {
+		if reconnect > 0 && p.config.RetryConfig != nil {
+			delay = p.config.RetryConfig.NextDelay(reconnect-1, delay)
+			select {
+			case <-ctx.Done():
+				return nil, fmt.Errorf("reconnect canceled: %w", ctx.Err())
+			case <-time.After(delay):
+			}
+		}
+		if err := p.Reconnect(ctx); err != nil {
+			lastErr = &core.ConnectionLostError{Err: fmt.Errorf("failed to reconnect after session error: %w", err)}
+			continue
+		}
+		session, err := p.newSession()
+		if err == nil {
+			return session, nil
+		}
+		lastErr = fmt.Errorf("failed to create session after reconnect: %w", err)
+	}
+	return nil, lastErr
+}
+
+// newSession opens a session on the target, with agent forwarding if enabled
+func (p *Provider) newSession() (*ssh.Session, error) {
+	session, err := p.client.NewSession()
+	if err != nil || !p.config.ForwardAgent {
+		return session, err
+	}
+	if err := agent.RequestAgentForwarding(session); err != nil {
+		// #nosec G104 -- Already in error path, ignoring close error is acceptable
+		session.Close()
+		return nil, fmt.Errorf("failed to request agent forwarding: %w", err)
+	}
+	return session, nil
+}
+
+// targetClientConfig returns the SSH client config for the target host,
+// offering only the configured algorithms if any are set
+func (p *Provider) targetClientConfig(authMethods []ssh.AuthMethod, hostKeyCallback ssh.HostKeyCallback) *ssh.ClientConfig {
+	config := &ssh.ClientConfig{
+		User:            p.config.User,
+		Auth:            authMethods,
+		HostKeyCallback: hostKeyCallback,
+		Timeout:         p.config.Timeout,
+	}
+	p.config.Algorithms.apply(config)
+	return config
+}
+
+// dialSSH is ssh.Dial bounded by ctx as well as the config's dial timeout
+func dialSSH(ctx context.Context, addr string, config *ssh.ClientConfig) (*ssh.Client, error) {
+	dialer := &net.Dialer{Timeout: config.Timeout}
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+	return newSSHClient(ctx, conn, addr, config)
+}
+
+// newSSHClient runs the SSH handshake over conn. The connection is closed if
+// ctx is done first, so a host that stalls mid-handshake cannot hang the run.
+func newSSHClient(ctx context.Context, conn net.Conn, addr string, config *ssh.ClientConfig) (*ssh.Client, error) {
+	stop := context.AfterFunc(ctx, func() {
+		// #nosec G104 -- Aborting the handshake, the close error is irrelevant
+		conn.Close()
+	})
+	ncc, chans, reqs, err := ssh.NewClientConn(conn, addr, config)
+	if !stop() {
+		if err == nil {
+			// #nosec G104 -- Already closed by the AfterFunc, the handshake is abandoned
+			ncc.Close()
+		}
+		return nil, ctx.Err()
+	}
+	if err != nil {
+		// #nosec G104 -- Already in error path, cleanup errors can be safely ignored
+		conn.Close()
+		return nil, err
+	}
+	return ssh.NewClient(ncc, chans, reqs), nil
+}
+
 // buildAuthMethods creates SSH authentication methods for a given identity file
 // If identityFile is empty, it falls back to SSH agent only
 func (p *Provider) buildAuthMethods(identityFile string, hostType string) ([]ssh.AuthMethod, error) {
@@ -168,7 +410,7 @@ func (p *Provider) buildAuthMethods(identityFile string, hostType string) ([]ssh
 // connectViaJumpHost establishes an SSH connection through a jump host
 // jumpAuthMethods: authentication for the jump host
 // targetAuthMethods: authentication for the target host (can be different)
-func (p *Provider) connectViaJumpHost(jumpAuthMethods, targetAuthMethods []ssh.AuthMethod, hostKeyCallback ssh.HostKeyCallback) (*ssh.Client, error) {
+func (p *Provider) connectViaJumpHost(ctx context.Context, jumpAuthMethods, targetAuthMethods []ssh.AuthMethod, hostKeyCallback ssh.HostKeyCallback) (*ssh.Client, error) {
 	// First, connect to the jump host using jump host auth methods
 	jumpConfig := &ssh.ClientConfig{
 		User:            p.config.JumpUser,
@@ -180,18 +422,29 @@ func (p *Provider) connectViaJumpHost(jumpAuthMethods, targetAuthMethods []ssh.A
 	// Resolve jump host hostname via SSH config before DNS resolution
 	resolvedJumpHost := resolveHostFromSSHConfig(p.config.JumpHost)
 	jumpAddr := fmt.Sprintf("%s:%d", resolvedJumpHost, p.config.JumpPort)
-	jumpClient, err := ssh.Dial("tcp", jumpAddr, jumpConfig)
+
+	// Short-circuit if too many recent connections through this jump host failed
+	if p.config.JumpBreaker != nil {
+		if err := p.config.JumpBreaker.Allow(); err != nil {
+			return nil, fmt.Errorf("jump host %s unavailable: %w", jumpAddr, err)
+		}
+	}
+
+	jumpClient, err := dialSSH(ctx, jumpAddr, jumpConfig)
 	if err != nil {
+		if p.config.JumpBreaker != nil {
+			p.config.JumpBreaker.RecordFailure()
+		}
 		return nil, fmt.Errorf("failed to connect to jump host %s: %w", jumpAddr, err)
 	}
-
-	// Store the jump client so it can be closed later
-	p.jumpClient = jumpClient
+	if p.config.JumpBreaker != nil {
+		p.config.JumpBreaker.RecordSuccess()
+	}
 
 	// Resolve target host hostname via SSH config before DNS resolution
 	resolvedTargetHost := resolveHostFromSSHConfig(p.config.Host)
 	targetAddr := fmt.Sprintf("%s:%d", resolvedTargetHost, p.config.Port)
-	targetConn, err := jumpClient.Dial("tcp", targetAddr)
+	targetConn, err := jumpClient.DialContext(ctx, "tcp", targetAddr)
 	if err != nil {
 		// #nosec G104 -- Already in error path, ignoring close error is acceptable
 		jumpClient.Close()
@@ -199,30 +452,24 @@ func (p *Provider) connectViaJumpHost(jumpAuthMethods, targetAuthMethods []ssh.A
 	}
 
 	// Create SSH connection to target using target host auth methods
-	targetConfig := &ssh.ClientConfig{
-		User:            p.config.User,
-		Auth:            targetAuthMethods,
-		HostKeyCallback: hostKeyCallback,
-		Timeout:         p.config.Timeout,
-	}
+	targetConfig := p.targetClientConfig(targetAuthMethods, hostKeyCallback)
 
-	ncc, chans, reqs, err := ssh.NewClientConn(targetConn, targetAddr, targetConfig)
+	targetClient, err := newSSHClient(ctx, targetConn, targetAddr, targetConfig)
 	if err != nil {
-		// #nosec G104 -- Already in error path, cleanup errors can be safely ignored
-		targetConn.Close()
 		// #nosec G104 -- Already in error path, cleanup errors can be safely ignored
 		jumpClient.Close()
 		return nil, fmt.Errorf("failed to establish SSH connection to target %s: %w", targetAddr, err)
 	}
 
-	// Create the target client
-	targetClient := ssh.NewClient(ncc, chans, reqs)
+	// Only now store the jump client so a failed attempt never leaves one behind
+	p.jumpClient = jumpClient
 
 	return targetClient, nil
 }
 
 // Close closes the SSH connection(s)
-// If using a jump host, both the target and jump host connections are closed
+// If using a jump host, both the target and jump host connections are closed.
+// Closing a provider that is not connected, or already closed, is a no-op.
 func (p *Provider) Close() error {
 	var err error
 
@@ -231,6 +478,7 @@ func (p *Provider) Close() error {
 		if closeErr := p.client.Close(); closeErr != nil {
 			err = closeErr
 		}
+		p.client = nil
 	}
 
 	// Close jump host client if it exists
@@ -241,52 +489,82 @@ func (p *Provider) Close() error {
 				err = closeErr
 			}
 		}
+		p.jumpClient = nil
 	}
 
 	return err
 }
 
-// ExecuteCommand executes a command via SSH with optional retry logic
+// ExecuteCommand executes a command via SSH, retrying transient failures per
+// CommandRetryConfig if set. A command cut short by a dropped connection
+// returns a *core.ConnectionLostError holding the output received so far,
+// reported to the test running in ctx unless a retry succeeds; the next
+// command reconnects.
 func (p *Provider) ExecuteCommand(ctx context.Context, command string) (stdout, stderr string, exitCode int, err error) {
-	// If retry config is nil, execute directly without retries
-	if p.config.RetryConfig == nil {
+	defer func() {
+		var lost *core.ConnectionLostError
+		if errors.As(err, &lost) {
+			core.ReportConnectionLost(ctx, lost)
+		}
+	}()
+	command = p.shellCommand(ctx, command)
+
+	// If command retry config is nil, execute directly without retries
+	if p.config.CommandRetryConfig == nil {
 		return p.executeCommandOnce(ctx, command)
 	}
 
-	// Wrap execution with retry logic
+	// Retry transient failures such as a connection reset mid-command; a
+	// dropped connection is re-dialled by the next attempt. The last
+	// attempt's outcome is returned as is.
 	var stdoutResult, stderrResult string
 	var exitCodeResult int
+	var lastErr error
 
-	retryErr := retry.Do(ctx, p.config.RetryConfig, retry.IsRetryableSSHError, func() error {
-		var execErr error
-		stdoutResult, stderrResult, exitCodeResult, execErr = p.executeCommandOnce(ctx, command)
-		return execErr
+	_ = retry.Do(ctx, p.config.CommandRetryConfig, p.commandRetryClassifier(), func() error {
+		stdoutResult, stderrResult, exitCodeResult, lastErr = p.executeCommandOnce(ctx, command)
+		return lastErr
 	})
 
-	return stdoutResult, stderrResult, exitCodeResult, retryErr
+	return stdoutResult, stderrResult, exitCodeResult, lastErr
+}
+
+// commandRetryClassifier returns the classifier for failed commands: a
+// dropped connection is always worth another attempt on a new one, anything
+// else is judged by the retry classifier
+func (p *Provider) commandRetryClassifier() retry.ErrorClassifier {
+	classifier := p.retryClassifier()
+	return func(err error) bool {
+		var lost *core.ConnectionLostError
+		return errors.As(err, &lost) || classifier(err)
+	}
 }
 
 // executeCommandOnce performs a single command execution attempt with automatic reconnection
 func (p *Provider) executeCommandOnce(ctx context.Context, command string) (stdout, stderr string, exitCode int, err error) {
-	session, err := p.client.NewSession()
+	session, err := p.openSession(ctx)
 	if err != nil {
-		// Connection might be dead - try to reconnect once
-		if reconnectErr := p.connectOnce(ctx); reconnectErr != nil {
-			return "", "", -1, fmt.Errorf("failed to reconnect after session error: %w", reconnectErr)
-		}
-		// Retry session creation after reconnect
-		session, err = p.client.NewSession()
-		if err != nil {
-			return "", "", -1, fmt.Errorf("failed to create session after reconnect: %w", err)
-		}
+		return "", "", -1, err
 	}
 	defer session.Close()
 
 	var stdoutBuf, stderrBuf strings.Builder
 	session.Stdout = &stdoutBuf
 	session.Stderr = &stderrBuf
+	if p.becomes() && p.config.BecomePassword != "" {
+		// Read by sudo -S, see shellCommand
+		session.Stdin = strings.NewReader(p.config.BecomePassword + "\n")
+	}
 
+	// Closing the session makes Run return when ctx is done (e.g. the host's time is up)
+	stop := context.AfterFunc(ctx, func() {
+		// #nosec G104 -- Abandoning the command, the close error is irrelevant
+		session.Close()
+	})
 	err = session.Run(command)
+	if !stop() {
+		return "", "", -1, fmt.Errorf("command execution failed: %w", ctx.Err())
+	}
 	stdout = stdoutBuf.String()
 	stderr = stderrBuf.String()
 
@@ -294,6 +572,11 @@ func (p *Provider) executeCommandOnce(ctx context.Context, command string) (stdo
 		if exitErr, ok := err.(*ssh.ExitError); ok {
 			exitCode = exitErr.ExitStatus()
 			err = nil
+		} else if isConnectionDrop(err) {
+			// Discard the dead client so the next command reconnects
+			// #nosec G104 -- The connection is gone, close errors don't matter
+			p.Close()
+			return stdout, stderr, -1, &core.ConnectionLostError{Err: err, Stdout: stdout}
 		} else {
 			return stdout, stderr, -1, fmt.Errorf("command execution failed: %w", err)
 		}
@@ -304,6 +587,19 @@ func (p *Provider) executeCommandOnce(ctx context.Context, command string) (stdo
 	return stdout, stderr, exitCode, nil
 }
 
+// isConnectionDrop reports whether a command failed because its connection
+// went away rather than because of the command, e.g. the session ended
+// without an exit status or the transport hit EOF or a network error
+func isConnectionDrop(err error) bool {
+	var exitMissing *ssh.ExitMissingError
+	var netErr net.Error
+	return errors.As(err, &exitMissing) ||
+		errors.Is(err, io.EOF) ||
+		errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, net.ErrClosed) ||
+		errors.As(err, &netErr)
+}
+
 // getHostKeyCallback returns the appropriate host key callback based on configuration
 func (p *Provider) getHostKeyCallback() (ssh.HostKeyCallback, error) {
 	// If explicitly set to insecure mode, use InsecureIgnoreHostKey
@@ -326,7 +622,19 @@ func (p *Provider) getHostKeyCallback() (ssh.HostKeyCallback, error) {
 	}
 
 	// Check if known_hosts file exists
-	if _, err := os.Stat(knownHostsPath); os.IsNotExist(err) {
+	if _, err := os.Stat(knownHostsPath); os.IsNotExist(err) && p.config.AcceptNewHostKeys {
+		// Start an empty file that first contact will add to, as OpenSSH does
+		if err := os.MkdirAll(filepath.Dir(knownHostsPath), 0700); err != nil {
+			return nil, fmt.Errorf("failed to create known_hosts directory: %w", err)
+		}
+		// #nosec G304 -- Path comes from --known-hosts-file or the user's home directory
+		f, err := os.OpenFile(knownHostsPath, os.O_CREATE|os.O_WRONLY, 0600)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create known_hosts file: %w", err)
+		}
+		// #nosec G104 -- Nothing was written to the file
+		f.Close()
+	} else if os.IsNotExist(err) {
 		// If StrictHostKeyChecking is enabled (default), return error
 		// This matches OpenSSH behavior
 		if p.config.StrictHostKeyChecking {
@@ -344,10 +652,77 @@ func (p *Provider) getHostKeyCallback() (ssh.HostKeyCallback, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to create host key callback from %s: %w", knownHostsPath, err)
 	}
+	hostKeyCallback = matchHostOrAddress(hostKeyCallback)
+	if p.config.AcceptNewHostKeys {
+		return acceptNewHostKeys(knownHostsPath, hostKeyCallback), nil
+	}
 
 	return hostKeyCallback, nil
 }
 
+// matchHostOrAddress wraps a known_hosts callback so that a host with no entry
+// under its name matches an entry for its resolved IP address instead, as
+// written when it was first reached by IP. Entries may be plaintext or hashed
+// (|1|salt|hash); a key that differs from either form is still rejected.
+func matchHostOrAddress(callback ssh.HostKeyCallback) ssh.HostKeyCallback {
+	return func(hostname string, remote net.Addr, key ssh.PublicKey) error {
+		err := callback(hostname, remote, key)
+		var keyErr *knownhosts.KeyError
+		if !errors.As(err, &keyErr) || len(keyErr.Want) > 0 || remote == nil {
+			return err
+		}
+		// Connections through a jump host report no IP address to check
+		ip, _, splitErr := net.SplitHostPort(remote.String())
+		if splitErr != nil || net.ParseIP(ip) == nil || remote.String() == hostname {
+			return err
+		}
+		// An empty hostname makes knownhosts check the remote address
+		return callback("", remote, key)
+	}
+}
+
+// knownHostsMu serializes appends to known_hosts files by hosts connecting in parallel
+var knownHostsMu sync.Mutex
+
+// acceptNewHostKeys wraps a known_hosts callback to append the key of a host
+// with no known keys to the file, like OpenSSH's StrictHostKeyChecking=accept-new.
+// A host whose key differs from a known one is still rejected.
+func acceptNewHostKeys(knownHostsPath string, callback ssh.HostKeyCallback) ssh.HostKeyCallback {
+	accepted := make(map[string]ssh.PublicKey)
+	return func(hostname string, remote net.Addr, key ssh.PublicKey) error {
+		err := callback(hostname, remote, key)
+		var keyErr *knownhosts.KeyError
+		if !errors.As(err, &keyErr) || len(keyErr.Want) > 0 {
+			return err
+		}
+
+		knownHostsMu.Lock()
+		defer knownHostsMu.Unlock()
+
+		// The callback read the file before this connection added to it
+		address := knownhosts.Normalize(hostname)
+		if previous, ok := accepted[address]; ok {
+			if !bytes.Equal(previous.Marshal(), key.Marshal()) {
+				return fmt.Errorf("host key for %s changed since it was accepted on first contact", address)
+			}
+			return nil
+		}
+
+		// #nosec G304 -- Path comes from --known-hosts-file or the user's home directory
+		f, err := os.OpenFile(knownHostsPath, os.O_APPEND|os.O_WRONLY, 0600)
+		if err != nil {
+			return fmt.Errorf("failed to record host key for %s: %w", address, err)
+		}
+		defer f.Close()
+		if _, err := fmt.Fprintln(f, knownhosts.Line([]string{address}, key)); err != nil {
+			return fmt.Errorf("failed to record host key for %s: %w", address, err)
+		}
+		accepted[address] = key
+		fmt.Fprintf(os.Stderr, "WARNING: permanently added %s (%s) to %s\n", address, key.Type(), knownHostsPath)
+		return nil
+	}
+}
+
 // getSSHAgent connects to the SSH agent
 func getSSHAgent() (agent.Agent, error) {
 	socket := os.Getenv("SSH_AUTH_SOCK")
@@ -367,31 +742,39 @@ func getSSHAgent() (agent.Agent, error) {
 // It checks ~/.ssh/config and /etc/ssh/ssh_config for Host patterns
 // and returns the HostName directive value if found, or the original hostname if not
 func resolveHostFromSSHConfig(host string) string {
-	// Try to read SSH config files in order of precedence:
-	// 1. User config: ~/.ssh/config
-	// 2. System config: /etc/ssh/ssh_config
+	if hostname := sshConfigValue(host, "HostName"); hostname != "" {
+		return hostname
+	}
+
+	// No HostName found in config, return original host
+	return host
+}
 
+// sshConfigValue returns the value of an SSH config directive for host, or ""
+// if it is not set. Config files are read in order of precedence:
+// 1. User config: ~/.ssh/config
+// 2. System config: /etc/ssh/ssh_config
+func sshConfigValue(host, key string) string {
 	// Try user config first
 	home, err := os.UserHomeDir()
 	if err == nil {
 		userConfigPath := filepath.Join(home, ".ssh", "config")
-		if hostname := getHostnameFromConfig(userConfigPath, host); hostname != "" {
-			return hostname
+		if value := getConfigValue(userConfigPath, host, key); value != "" {
+			return value
 		}
 	}
 
 	// Try system config
-	systemConfigPath := "/etc/ssh/ssh_config"
-	if hostname := getHostnameFromConfig(systemConfigPath, host); hostname != "" {
-		return hostname
-	}
-
-	// No HostName found in config, return original host
-	return host
+	return getConfigValue("/etc/ssh/ssh_config", host, key)
 }
 
 // getHostnameFromConfig reads an SSH config file and returns the HostName for the given host
 func getHostnameFromConfig(configPath string, host string) string {
+	return getConfigValue(configPath, host, "HostName")
+}
+
+// getConfigValue reads an SSH config file and returns the value of key for the given host
+func getConfigValue(configPath, host, key string) string {
 	// #nosec G304 -- Reading SSH config files is intentional and required functionality
 	f, err := os.Open(configPath)
 	if err != nil {
@@ -406,8 +789,7 @@ func getHostnameFromConfig(configPath string, host string) string {
 		return ""
 	}
 
-	// Get the HostName directive for this host
 	// ssh_config.Config.Get returns empty string if not found
-	hostname, _ := cfg.Get(host, "HostName")
-	return hostname
+	value, _ := cfg.Get(host, key)
+	return value
 }
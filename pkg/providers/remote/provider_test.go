@@ -1,10 +1,29 @@
 package remote
 
 import (
+	"bufio"
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/pem"
+	"errors"
 	"fmt"
+	"net"
 	"os"
 	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
+
+	"github.com/neilfarmer/platform-spec/pkg/core"
+	"github.com/neilfarmer/platform-spec/pkg/retry"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/agent"
+	"golang.org/x/crypto/ssh/knownhosts"
 )
 
 func TestParseTarget(t *testing.T) {
@@ -458,3 +477,779 @@ func TestGetHostnameFromConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestConnectViaJumpHost_CircuitBreaker(t *testing.T) {
+	// Reserve a local port and close it so dialing fails fast
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to reserve port: %v", err)
+	}
+	port := listener.Addr().(*net.TCPAddr).Port
+	listener.Close()
+
+	breaker := retry.NewBreaker(2, time.Minute)
+	provider := NewProvider(&Config{
+		Host:        "target.internal",
+		Port:        22,
+		User:        "testuser",
+		Timeout:     time.Second,
+		JumpHost:    "127.0.0.1",
+		JumpPort:    port,
+		JumpUser:    "jumpuser",
+		JumpBreaker: breaker,
+	})
+
+	// Two real failures trip the breaker
+	for i := 0; i < 2; i++ {
+		_, err := provider.connectViaJumpHost(context.Background(), nil, nil, ssh.InsecureIgnoreHostKey())
+		if err == nil || errors.Is(err, retry.ErrBreakerOpen) {
+			t.Fatalf("attempt %d: expected dial failure, got %v", i, err)
+		}
+	}
+	if breaker.State() != retry.BreakerOpen {
+		t.Fatalf("expected breaker to be open, got %s", breaker.State())
+	}
+
+	// Further attempts are short-circuited without dialing
+	_, err = provider.connectViaJumpHost(context.Background(), nil, nil, ssh.InsecureIgnoreHostKey())
+	if !errors.Is(err, retry.ErrBreakerOpen) {
+		t.Errorf("expected ErrBreakerOpen, got %v", err)
+	}
+}
+
+func TestShellCommand(t *testing.T) {
+	tests := []struct {
+		name       string
+		user       string
+		becomeUser string
+		shell      string
+		want       string
+	}{
+		{"default shell", "ubuntu", "", "", "env 'LANG=C' 'LC_ALL=C' /bin/sh -c 'cat /etc/shadow'"},
+		{"configured shell", "ubuntu", "", "/bin/bash", "env 'LANG=C' 'LC_ALL=C' /bin/bash -c 'cat /etc/shadow'"},
+		{"become the login user", "root", "root", "", "env 'LANG=C' 'LC_ALL=C' /bin/sh -c 'cat /etc/shadow'"},
+		{"become another user", "ubuntu", "root", "", "sudo -n -u 'root' -- env 'LANG=C' 'LC_ALL=C' /bin/sh -c 'cat /etc/shadow'"},
+		{"become with configured shell", "ubuntu", "root", "/bin/bash", "sudo -n -u 'root' -- env 'LANG=C' 'LC_ALL=C' /bin/bash -c 'cat /etc/shadow'"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.shell != "" {
+				ctx = core.WithShell(ctx, tt.shell)
+			}
+			p := NewProvider(&Config{User: tt.user, BecomeUser: tt.becomeUser})
+			if got := p.shellCommand(ctx, "cat /etc/shadow"); got != tt.want {
+				t.Errorf("shellCommand() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestShellCommand_BecomePassword(t *testing.T) {
+	p := NewProvider(&Config{User: "ubuntu", BecomeUser: "root", BecomePassword: "secret"})
+	want := "sudo -k -S -p '' -u 'root' -- env 'LANG=C' 'LC_ALL=C' /bin/sh -c 'cat /etc/shadow'"
+	if got := p.shellCommand(context.Background(), "cat /etc/shadow"); got != want {
+		t.Errorf("shellCommand() = %q, want %q", got, want)
+	}
+}
+
+// testSSHServer is an in-process SSH server that accepts any public key and
+// rejects every channel, so a jump through it always fails at the target dial.
+// It counts open connections to detect clients that were never closed, and
+// every connection made.
+type testSSHServer struct {
+	port        int
+	active      atomic.Int32
+	connections atomic.Int32
+
+	// With acceptSessions, session channels are accepted and every exec
+	// succeeds. The server records each session request type, and on exec
+	// lists the keys of a forwarded agent if one was requested.
+	acceptSessions bool
+	mu             sync.Mutex
+	requests       []string
+	agentKeys      []string
+
+	// With dropOutput set, the first exec writes it and then drops the
+	// connection without an exit status
+	dropOutput string
+	dropped    atomic.Bool
+
+	// With sudoPassword set, every exec acts as sudo -S does: it reads a
+	// password from stdin and fails unless it is sudoPassword
+	sudoPassword string
+}
+
+func newTestSSHServer(t *testing.T) *testSSHServer {
+	t.Helper()
+	return startTestSSHServer(t, &testSSHServer{})
+}
+
+func startTestSSHServer(t *testing.T, server *testSSHServer) *testSSHServer {
+	t.Helper()
+	_, hostKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("Failed to generate host key: %v", err)
+	}
+	signer, err := ssh.NewSignerFromKey(hostKey)
+	if err != nil {
+		t.Fatalf("Failed to create host key signer: %v", err)
+	}
+	config := &ssh.ServerConfig{
+		PublicKeyCallback: func(ssh.ConnMetadata, ssh.PublicKey) (*ssh.Permissions, error) {
+			return nil, nil
+		},
+	}
+	config.AddHostKey(signer)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	t.Cleanup(func() { listener.Close() })
+
+	server.port = listener.Addr().(*net.TCPAddr).Port
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go server.serve(conn, config)
+		}
+	}()
+	return server
+}
+
+func (s *testSSHServer) serve(conn net.Conn, config *ssh.ServerConfig) {
+	sconn, chans, reqs, err := ssh.NewServerConn(conn, config)
+	if err != nil {
+		conn.Close()
+		return
+	}
+	s.active.Add(1)
+	s.connections.Add(1)
+	defer s.active.Add(-1)
+
+	go ssh.DiscardRequests(reqs)
+	for newChannel := range chans {
+		if !s.acceptSessions || newChannel.ChannelType() != "session" {
+			newChannel.Reject(ssh.Prohibited, "no channels in tests")
+			continue
+		}
+		channel, requests, err := newChannel.Accept()
+		if err != nil {
+			continue
+		}
+		go s.serveSession(sconn, channel, requests)
+	}
+	sconn.Close()
+}
+
+func (s *testSSHServer) serveSession(sconn *ssh.ServerConn, channel ssh.Channel, requests <-chan *ssh.Request) {
+	defer channel.Close()
+	forwarding := false
+	for req := range requests {
+		s.mu.Lock()
+		s.requests = append(s.requests, req.Type)
+		s.mu.Unlock()
+		if req.WantReply {
+			req.Reply(true, nil)
+		}
+		switch req.Type {
+		case "auth-agent-req@openssh.com":
+			forwarding = true
+		case "exec":
+			if s.dropOutput != "" && !s.dropped.Swap(true) {
+				channel.Write([]byte(s.dropOutput))
+				sconn.Close()
+				return
+			}
+			if forwarding {
+				s.listForwardedKeys(sconn)
+			}
+			channel.SendRequest("exit-status", false, ssh.Marshal(struct{ Status uint32 }{s.sudo(channel)}))
+			return
+		}
+	}
+}
+
+// sudo checks the password written to the channel against sudoPassword,
+// returning the exit status sudo would
+func (s *testSSHServer) sudo(channel ssh.Channel) uint32 {
+	if s.sudoPassword == "" {
+		return 0
+	}
+	line, _ := bufio.NewReader(channel).ReadString('\n')
+	switch {
+	case line == "":
+		channel.Stderr().Write([]byte("sudo: a password is required\n"))
+		return 1
+	case strings.TrimSuffix(line, "\n") != s.sudoPassword:
+		channel.Stderr().Write([]byte("Sorry, try again.\nsudo: 1 incorrect password attempt\n"))
+		return 1
+	}
+	return 0
+}
+
+// listForwardedKeys records the comments of the keys in the client's agent,
+// as a command on the target using the forwarded agent would see them
+func (s *testSSHServer) listForwardedKeys(sconn *ssh.ServerConn) {
+	channel, requests, err := sconn.OpenChannel("auth-agent@openssh.com", nil)
+	if err != nil {
+		return
+	}
+	defer channel.Close()
+	go ssh.DiscardRequests(requests)
+	keys, err := agent.NewClient(channel).List()
+	if err != nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, key := range keys {
+		s.agentKeys = append(s.agentKeys, key.Comment)
+	}
+}
+
+// waitForActive waits for the server's open connection count to reach want
+func (s *testSSHServer) waitForActive(t *testing.T, want int32) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for s.active.Load() != want {
+		if time.Now().After(deadline) {
+			t.Fatalf("server has %d open connections, want %d", s.active.Load(), want)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+// writeTestKey writes a fresh private key and returns its path
+func writeTestKey(t *testing.T) string {
+	t.Helper()
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+	block, err := ssh.MarshalPrivateKey(key, "")
+	if err != nil {
+		t.Fatalf("Failed to marshal key: %v", err)
+	}
+	path := filepath.Join(t.TempDir(), "id_ed25519")
+	if err := os.WriteFile(path, pem.EncodeToMemory(block), 0600); err != nil {
+		t.Fatalf("Failed to write key: %v", err)
+	}
+	return path
+}
+
+func TestClose_NotConnected(t *testing.T) {
+	provider := NewProvider(&Config{Host: "example.com", Port: 22, User: "testuser"})
+	for i := 0; i < 2; i++ {
+		if err := provider.Close(); err != nil {
+			t.Errorf("Close() #%d on unconnected provider = %v, want nil", i+1, err)
+		}
+	}
+}
+
+func TestConnect_FailedJumpAttemptsReleaseClients(t *testing.T) {
+	server := newTestSSHServer(t)
+	keyFile := writeTestKey(t)
+	provider := NewProvider(&Config{
+		Host:                  "target.internal",
+		Port:                  22,
+		User:                  "testuser",
+		IdentityFile:          keyFile,
+		Timeout:               2 * time.Second,
+		InsecureIgnoreHostKey: true,
+		JumpHost:              "127.0.0.1",
+		JumpPort:              server.port,
+		JumpUser:              "jumpuser",
+		JumpIdentityFile:      keyFile,
+		RetryConfig:           &retry.Config{MaxRetries: 2, Strategy: retry.StrategyConstant},
+		RetryClassifier:       func(error) bool { return true },
+	})
+
+	// Each Connect makes three attempts that reach the jump host, then fail
+	for i := 0; i < 2; i++ {
+		if err := provider.Connect(context.Background()); err == nil {
+			t.Fatalf("Connect() #%d expected target dial to fail", i+1)
+		}
+		if provider.client != nil || provider.jumpClient != nil {
+			t.Errorf("Connect() #%d left clients behind: client=%v jumpClient=%v", i+1, provider.client, provider.jumpClient)
+		}
+	}
+	server.waitForActive(t, 0)
+
+	if err := provider.Close(); err != nil {
+		t.Errorf("Close() after failed connects = %v, want nil", err)
+	}
+}
+
+func TestConnect_RecordsAttempts(t *testing.T) {
+	// Nothing listens on a port just released
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	port := listener.Addr().(*net.TCPAddr).Port
+	listener.Close()
+
+	var retried []int
+	provider := NewProvider(&Config{
+		Host:                  "127.0.0.1",
+		Port:                  port,
+		User:                  "testuser",
+		IdentityFile:          writeTestKey(t),
+		Timeout:               2 * time.Second,
+		InsecureIgnoreHostKey: true,
+		RetryConfig: &retry.Config{
+			MaxRetries:   2,
+			InitialDelay: time.Millisecond,
+			MaxDelay:     time.Millisecond,
+			Strategy:     retry.StrategyConstant,
+			OnRetry:      func(attempt int, err error, nextDelay time.Duration) { retried = append(retried, attempt) },
+		},
+	})
+
+	if err := provider.Connect(context.Background()); err == nil {
+		t.Fatal("Connect() expected connection refused")
+	}
+	attempts := provider.ConnectionAttempts()
+	if len(attempts) != 3 {
+		t.Fatalf("ConnectionAttempts() = %+v, want 3 attempts", attempts)
+	}
+	for i, a := range attempts {
+		wantDelay := time.Millisecond
+		if i == 2 {
+			wantDelay = 0
+		}
+		if a.Attempt != i+1 || !a.Retryable || a.NextDelay != wantDelay || !strings.Contains(a.Error, "connection refused") {
+			t.Errorf("attempt %d = %+v, want retryable connection refused with next delay %s", i+1, a, wantDelay)
+		}
+	}
+	// The caller's hook still sees every retry
+	if len(retried) != 2 || retried[0] != 1 || retried[1] != 2 {
+		t.Errorf("OnRetry attempts = %v, want [1 2]", retried)
+	}
+}
+
+func TestConnect_ReconnectClosesPreviousClient(t *testing.T) {
+	server := newTestSSHServer(t)
+	provider := NewProvider(&Config{
+		Host:                  "127.0.0.1",
+		Port:                  server.port,
+		User:                  "testuser",
+		IdentityFile:          writeTestKey(t),
+		Timeout:               2 * time.Second,
+		InsecureIgnoreHostKey: true,
+	})
+
+	for i := 0; i < 3; i++ {
+		if err := provider.Connect(context.Background()); err != nil {
+			t.Fatalf("Connect() #%d error = %v", i+1, err)
+		}
+	}
+	server.waitForActive(t, 1)
+
+	if err := provider.Close(); err != nil {
+		t.Errorf("Close() error = %v", err)
+	}
+	server.waitForActive(t, 0)
+	if err := provider.Close(); err != nil {
+		t.Errorf("second Close() = %v, want nil", err)
+	}
+}
+
+// testHostKey returns a freshly generated SSH public key
+func testHostKey(t *testing.T) ssh.PublicKey {
+	t.Helper()
+	public, _, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+	key, err := ssh.NewPublicKey(public)
+	if err != nil {
+		t.Fatalf("Failed to convert key: %v", err)
+	}
+	return key
+}
+
+func TestAcceptNewHostKeys_FirstContact(t *testing.T) {
+	knownHosts := filepath.Join(t.TempDir(), "ssh", "known_hosts")
+	key := testHostKey(t)
+	addr := &net.TCPAddr{IP: net.ParseIP("10.0.0.5"), Port: 2222}
+
+	// Without accept-new, a missing known_hosts file is an error
+	strict := NewProvider(&Config{KnownHostsFile: knownHosts, StrictHostKeyChecking: true})
+	if _, err := strict.getHostKeyCallback(); err == nil {
+		t.Fatal("getHostKeyCallback() expected an error for a missing known_hosts file")
+	}
+
+	provider := NewProvider(&Config{KnownHostsFile: knownHosts, StrictHostKeyChecking: true, AcceptNewHostKeys: true})
+	callback, err := provider.getHostKeyCallback()
+	if err != nil {
+		t.Fatalf("getHostKeyCallback() error = %v", err)
+	}
+	if err := callback("web-1:2222", addr, key); err != nil {
+		t.Fatalf("first contact error = %v, want the key accepted", err)
+	}
+	// The same key is accepted again by this callback and by a fresh one
+	if err := callback("web-1:2222", addr, key); err != nil {
+		t.Errorf("second contact error = %v", err)
+	}
+	callback, err = NewProvider(&Config{KnownHostsFile: knownHosts, StrictHostKeyChecking: true}).getHostKeyCallback()
+	if err != nil {
+		t.Fatalf("getHostKeyCallback() error = %v", err)
+	}
+	if err := callback("web-1:2222", addr, key); err != nil {
+		t.Errorf("recorded key rejected by strict checking: %v", err)
+	}
+
+	content, err := os.ReadFile(knownHosts)
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+	if got := strings.Count(string(content), "[web-1]:2222 ssh-ed25519 "); got != 1 {
+		t.Errorf("known_hosts = %q, want one entry for [web-1]:2222", content)
+	}
+}
+
+func TestAcceptNewHostKeys_ChangedKeyRejected(t *testing.T) {
+	knownHosts := filepath.Join(t.TempDir(), "known_hosts")
+	original, changed := testHostKey(t), testHostKey(t)
+	addr := &net.TCPAddr{IP: net.ParseIP("10.0.0.6"), Port: 22}
+	if err := os.WriteFile(knownHosts, []byte(knownhosts.Line([]string{"web-2"}, original)+"\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	callback, err := NewProvider(&Config{KnownHostsFile: knownHosts, AcceptNewHostKeys: true}).getHostKeyCallback()
+	if err != nil {
+		t.Fatalf("getHostKeyCallback() error = %v", err)
+	}
+	err = callback("web-2:22", addr, changed)
+	var keyErr *knownhosts.KeyError
+	if !errors.As(err, &keyErr) || len(keyErr.Want) == 0 {
+		t.Fatalf("changed key error = %v, want a known_hosts key mismatch", err)
+	}
+
+	// A key accepted on first contact cannot change within the run either
+	if err := callback("web-3:22", addr, original); err != nil {
+		t.Fatalf("first contact error = %v", err)
+	}
+	if err := callback("web-3:22", addr, changed); err == nil || !strings.Contains(err.Error(), "changed since it was accepted") {
+		t.Errorf("changed key after first contact error = %v, want it rejected", err)
+	}
+
+	content, err := os.ReadFile(knownHosts)
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+	if lines := strings.Count(string(content), "\n"); lines != 2 {
+		t.Errorf("known_hosts has %d lines, want the original entry plus web-3:\n%s", lines, content)
+	}
+}
+
+func TestHostKeyCallback_HashedEntries(t *testing.T) {
+	key, other := testHostKey(t), testHostKey(t)
+	addr := &net.TCPAddr{IP: net.ParseIP("10.0.0.7"), Port: 22}
+
+	tests := []struct {
+		name    string
+		entry   string
+		key     ssh.PublicKey
+		wantErr bool
+	}{
+		{"plaintext hostname", knownhosts.Line([]string{"web-4"}, key), key, false},
+		{"hashed hostname", knownhosts.Line([]string{knownhosts.HashHostname("web-4")}, key), key, false},
+		{"plaintext IP", knownhosts.Line([]string{"10.0.0.7"}, key), key, false},
+		{"hashed IP", knownhosts.Line([]string{knownhosts.HashHostname("10.0.0.7")}, key), key, false},
+		{"hashed IP with another key", knownhosts.Line([]string{knownhosts.HashHostname("10.0.0.7")}, other), key, true},
+		{"hashed hostname with another key", knownhosts.Line([]string{knownhosts.HashHostname("web-4")}, other), key, true},
+		{"hashed entry for another host", knownhosts.Line([]string{knownhosts.HashHostname("web-5")}, key), key, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			knownHosts := filepath.Join(t.TempDir(), "known_hosts")
+			if err := os.WriteFile(knownHosts, []byte(tt.entry+"\n"), 0600); err != nil {
+				t.Fatal(err)
+			}
+			callback, err := NewProvider(&Config{KnownHostsFile: knownHosts, StrictHostKeyChecking: true}).getHostKeyCallback()
+			if err != nil {
+				t.Fatalf("getHostKeyCallback() error = %v", err)
+			}
+			err = callback("web-4:22", addr, tt.key)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("callback() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// startTestAgent serves an in-memory agent holding one key on a unix socket
+// and points SSH_AUTH_SOCK at it
+func startTestAgent(t *testing.T, comment string) {
+	t.Helper()
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+	keyring := agent.NewKeyring()
+	if err := keyring.Add(agent.AddedKey{PrivateKey: key, Comment: comment}); err != nil {
+		t.Fatalf("Failed to add key to agent: %v", err)
+	}
+
+	socket := filepath.Join(t.TempDir(), "agent.sock")
+	listener, err := net.Listen("unix", socket)
+	if err != nil {
+		t.Fatalf("Failed to listen on agent socket: %v", err)
+	}
+	t.Cleanup(func() { listener.Close() })
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go agent.ServeAgent(keyring, conn)
+		}
+	}()
+	t.Setenv("SSH_AUTH_SOCK", socket)
+}
+
+func TestExecuteCommand_ForwardAgent(t *testing.T) {
+	startTestAgent(t, "deploy@laptop")
+
+	for _, forward := range []bool{false, true} {
+		server := startTestSSHServer(t, &testSSHServer{acceptSessions: true})
+		provider := NewProvider(&Config{
+			Host:                  "127.0.0.1",
+			Port:                  server.port,
+			User:                  "testuser",
+			IdentityFile:          writeTestKey(t),
+			Timeout:               2 * time.Second,
+			InsecureIgnoreHostKey: true,
+			ForwardAgent:          forward,
+		})
+		if err := provider.Connect(context.Background()); err != nil {
+			t.Fatalf("Connect() error = %v", err)
+		}
+		if _, _, exitCode, err := provider.ExecuteCommand(context.Background(), "git ls-remote git@example.com:repo"); err != nil || exitCode != 0 {
+			t.Fatalf("ExecuteCommand() = %d, %v", exitCode, err)
+		}
+		provider.Close()
+
+		server.mu.Lock()
+		requested := slices.Contains(server.requests, "auth-agent-req@openssh.com")
+		keys := server.agentKeys
+		server.mu.Unlock()
+		if requested != forward {
+			t.Errorf("ForwardAgent=%v: session requests = %v", forward, server.requests)
+		}
+		if forward && !slices.Equal(keys, []string{"deploy@laptop"}) {
+			t.Errorf("forwarded agent keys = %v, want the local agent's key", keys)
+		}
+	}
+}
+
+func TestConnect_ForwardAgentWithoutAgent(t *testing.T) {
+	t.Setenv("SSH_AUTH_SOCK", "")
+	server := startTestSSHServer(t, &testSSHServer{acceptSessions: true})
+	provider := NewProvider(&Config{
+		Host:                  "127.0.0.1",
+		Port:                  server.port,
+		User:                  "testuser",
+		IdentityFile:          writeTestKey(t),
+		Timeout:               2 * time.Second,
+		InsecureIgnoreHostKey: true,
+		ForwardAgent:          true,
+	})
+	err := provider.Connect(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "no SSH agent is available") {
+		t.Errorf("Connect() error = %v, want a missing agent error", err)
+	}
+	if provider.client != nil {
+		t.Error("Connect() left a client behind after failing")
+	}
+}
+
+func TestConnect_BecomePassword(t *testing.T) {
+	tests := []struct {
+		name     string
+		password string
+		wantErr  string
+	}{
+		{"no password", "", "cannot become root: sudo requires a password but none was given"},
+		{"wrong password", "hunter2", "cannot become root: sudo rejected the password"},
+		{"right password", "secret", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := startTestSSHServer(t, &testSSHServer{acceptSessions: true, sudoPassword: "secret"})
+			provider := NewProvider(&Config{
+				Host:                  "127.0.0.1",
+				Port:                  server.port,
+				User:                  "testuser",
+				BecomeUser:            "root",
+				BecomePassword:        tt.password,
+				IdentityFile:          writeTestKey(t),
+				Timeout:               2 * time.Second,
+				InsecureIgnoreHostKey: true,
+			})
+			err := provider.Connect(context.Background())
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Errorf("Connect() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Connect() error = %v", err)
+			}
+			defer provider.Close()
+
+			// Every command sends the password again
+			if _, stderr, exitCode, err := provider.ExecuteCommand(context.Background(), "docker ps"); err != nil || exitCode != 0 {
+				t.Errorf("ExecuteCommand() = %d, %v (stderr %q)", exitCode, err, stderr)
+			}
+		})
+	}
+}
+
+func TestExecuteCommand_ConnectionDropped(t *testing.T) {
+	server := startTestSSHServer(t, &testSSHServer{acceptSessions: true, dropOutput: "partial\n"})
+	provider := NewProvider(&Config{
+		Host:                  "127.0.0.1",
+		Port:                  server.port,
+		User:                  "testuser",
+		IdentityFile:          writeTestKey(t),
+		Timeout:               2 * time.Second,
+		InsecureIgnoreHostKey: true,
+	})
+	if err := provider.Connect(context.Background()); err != nil {
+		t.Fatalf("Connect() error = %v", err)
+	}
+	defer provider.Close()
+
+	_, _, exitCode, err := provider.ExecuteCommand(context.Background(), "journalctl -u nginx")
+	var lost *core.ConnectionLostError
+	if !errors.As(err, &lost) || exitCode != -1 {
+		t.Fatalf("ExecuteCommand() = %d, %v, want a lost connection", exitCode, err)
+	}
+	if lost.Stdout != "partial\n" {
+		t.Errorf("lost connection output = %q, want the output received before the drop", lost.Stdout)
+	}
+
+	// The next command reconnects
+	if _, _, exitCode, err := provider.ExecuteCommand(context.Background(), "true"); err != nil || exitCode != 0 {
+		t.Errorf("ExecuteCommand() after drop = %d, %v, want a reconnect", exitCode, err)
+	}
+}
+
+func TestExecuteCommand_RetriesDroppedCommand(t *testing.T) {
+	tests := []struct {
+		name          string
+		config        Config
+		wantRetried   []int
+		wantRecovered bool
+	}{
+		{
+			name:          "command retries",
+			config:        Config{CommandRetryConfig: &retry.Config{MaxRetries: 2, Strategy: retry.StrategyConstant}},
+			wantRetried:   []int{1},
+			wantRecovered: true,
+		},
+		{
+			name:   "connection retries only",
+			config: Config{RetryConfig: &retry.Config{MaxRetries: 2, Strategy: retry.StrategyConstant}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := startTestSSHServer(t, &testSSHServer{acceptSessions: true, dropOutput: "partial\n"})
+			var retried []int
+			config := tt.config
+			config.Host = "127.0.0.1"
+			config.Port = server.port
+			config.User = "testuser"
+			config.IdentityFile = writeTestKey(t)
+			config.Timeout = 2 * time.Second
+			config.InsecureIgnoreHostKey = true
+			if config.CommandRetryConfig != nil {
+				config.CommandRetryConfig.OnRetry = func(attempt int, err error, nextDelay time.Duration) { retried = append(retried, attempt) }
+			}
+			provider := NewProvider(&config)
+			if err := provider.Connect(context.Background()); err != nil {
+				t.Fatalf("Connect() error = %v", err)
+			}
+			defer provider.Close()
+
+			_, _, exitCode, err := provider.ExecuteCommand(context.Background(), "journalctl -u nginx")
+			if recovered := err == nil && exitCode == 0; recovered != tt.wantRecovered {
+				t.Errorf("ExecuteCommand() = %d, %v, want recovered = %v", exitCode, err, tt.wantRecovered)
+			}
+			if fmt.Sprint(retried) != fmt.Sprint(tt.wantRetried) {
+				t.Errorf("retried attempts = %v, want %v", retried, tt.wantRetried)
+			}
+		})
+	}
+}
+
+func TestExecuteCommand_ReconnectsDeadClient(t *testing.T) {
+	server := startTestSSHServer(t, &testSSHServer{acceptSessions: true})
+	provider := NewProvider(&Config{
+		Host:                  "127.0.0.1",
+		Port:                  server.port,
+		User:                  "testuser",
+		IdentityFile:          writeTestKey(t),
+		Timeout:               2 * time.Second,
+		InsecureIgnoreHostKey: true,
+	})
+	if err := provider.Connect(context.Background()); err != nil {
+		t.Fatalf("Connect() error = %v", err)
+	}
+	defer provider.Close()
+
+	// The connection dies while idle, leaving a client that cannot open sessions
+	provider.client.Close()
+	if _, _, exitCode, err := provider.ExecuteCommand(context.Background(), "true"); err != nil || exitCode != 0 {
+		t.Fatalf("ExecuteCommand() = %d, %v, want it to reconnect and succeed", exitCode, err)
+	}
+	if got := server.connections.Load(); got != 2 {
+		t.Errorf("server saw %d connections, want 2", got)
+	}
+	server.waitForActive(t, 1)
+}
+
+func TestExecuteCommand_MaxReconnects(t *testing.T) {
+	for _, maxReconnects := range []int{0, 3} {
+		// Sessions are always refused, so no reconnect helps
+		server := newTestSSHServer(t)
+		provider := NewProvider(&Config{
+			Host:                  "127.0.0.1",
+			Port:                  server.port,
+			User:                  "testuser",
+			IdentityFile:          writeTestKey(t),
+			Timeout:               2 * time.Second,
+			InsecureIgnoreHostKey: true,
+			MaxReconnects:         maxReconnects,
+		})
+		if err := provider.Connect(context.Background()); err != nil {
+			t.Fatalf("Connect() error = %v", err)
+		}
+
+		_, _, _, err := provider.ExecuteCommand(context.Background(), "true")
+		if err == nil || !strings.Contains(err.Error(), "failed to create session after reconnect") {
+			t.Errorf("MaxReconnects=%d: ExecuteCommand() error = %v, want a session error", maxReconnects, err)
+		}
+		wantConnections := int32(max(maxReconnects, 1) + 1)
+		if got := server.connections.Load(); got != wantConnections {
+			t.Errorf("MaxReconnects=%d: server saw %d connections, want %d", maxReconnects, got, wantConnections)
+		}
+		provider.Close()
+		server.waitForActive(t, 0)
+	}
+}
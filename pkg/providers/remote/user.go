@@ -0,0 +1,85 @@
+package remote
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// UserSource is a fallback for the SSH user of a target without a user@ prefix
+type UserSource string
+
+const (
+	// UserSourceSSHConfig uses the User directive for the host in ~/.ssh/config or /etc/ssh/ssh_config
+	UserSourceSSHConfig UserSource = "ssh-config"
+	// UserSourceEnv uses the local $USER, as the ssh client does
+	UserSourceEnv UserSource = "env"
+	// UserSourceRoot always resolves to root
+	UserSourceRoot UserSource = "root"
+)
+
+// DefaultUserSources is the fallback order used when none is configured
+var DefaultUserSources = []UserSource{UserSourceSSHConfig, UserSourceEnv, UserSourceRoot}
+
+// ParseUserSources converts source names (e.g. from a flag) into UserSources
+func ParseUserSources(names []string) ([]UserSource, error) {
+	sources := make([]UserSource, 0, len(names))
+	for _, name := range names {
+		source := UserSource(strings.TrimSpace(name))
+		switch source {
+		case UserSourceSSHConfig, UserSourceEnv, UserSourceRoot:
+			sources = append(sources, source)
+		default:
+			return nil, fmt.Errorf("unknown user source '%s' (must be ssh-config, env, or root)", name)
+		}
+	}
+	return sources, nil
+}
+
+// ResolveUser returns the SSH user for host. The first non-empty value wins:
+//  1. explicitUser, from a user@host target or inventory entry
+//  2. flagUser, a user given for every target (e.g. --user)
+//  3. each of sources in order (DefaultUserSources if empty)
+//  4. root
+func ResolveUser(host, explicitUser, flagUser string, sources []UserSource) string {
+	if explicitUser != "" {
+		return explicitUser
+	}
+	if flagUser != "" {
+		return flagUser
+	}
+
+	if len(sources) == 0 {
+		sources = DefaultUserSources
+	}
+	for _, source := range sources {
+		var user string
+		switch source {
+		case UserSourceSSHConfig:
+			user = sshConfigValue(host, "User")
+		case UserSourceEnv:
+			user = os.Getenv("USER")
+		case UserSourceRoot:
+			user = "root"
+		}
+		if user != "" {
+			return user
+		}
+	}
+	return "root"
+}
+
+// ResolveTarget parses a "user@host" or "host" target and resolves the user
+// with ResolveUser
+func ResolveTarget(target, flagUser string, sources []UserSource) (user, host string, err error) {
+	parts := strings.Split(target, "@")
+	switch len(parts) {
+	case 1:
+		host = parts[0]
+	case 2:
+		user, host = parts[0], parts[1]
+	default:
+		return "", "", fmt.Errorf("invalid target format: %s", target)
+	}
+	return ResolveUser(host, user, flagUser, sources), host, nil
+}
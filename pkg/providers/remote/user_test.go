@@ -0,0 +1,155 @@
+package remote
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// withSSHConfig points HOME at a temp dir whose ~/.ssh/config sets User for web-*
+func withSSHConfig(t *testing.T) {
+	t.Helper()
+	home := t.TempDir()
+	sshDir := filepath.Join(home, ".ssh")
+	if err := os.MkdirAll(sshDir, 0700); err != nil {
+		t.Fatalf("Failed to create .ssh directory: %v", err)
+	}
+	config := "Host web-*\n    User deploy\n"
+	if err := os.WriteFile(filepath.Join(sshDir, "config"), []byte(config), 0600); err != nil {
+		t.Fatalf("Failed to write SSH config: %v", err)
+	}
+	t.Setenv("HOME", home)
+}
+
+func TestResolveUser(t *testing.T) {
+	withSSHConfig(t)
+	t.Setenv("USER", "alice")
+
+	tests := []struct {
+		name         string
+		host         string
+		explicitUser string
+		flagUser     string
+		sources      []UserSource
+		want         string
+	}{
+		{
+			name:         "explicit user wins over everything",
+			host:         "web-1",
+			explicitUser: "ubuntu",
+			flagUser:     "ops",
+			want:         "ubuntu",
+		},
+		{
+			name:     "flag user wins over fallbacks",
+			host:     "web-1",
+			flagUser: "ops",
+			want:     "ops",
+		},
+		{
+			name: "ssh config user",
+			host: "web-1",
+			want: "deploy",
+		},
+		{
+			name: "env user when ssh config has none",
+			host: "db-1",
+			want: "alice",
+		},
+		{
+			name:    "configured order puts env first",
+			host:    "web-1",
+			sources: []UserSource{UserSourceEnv, UserSourceSSHConfig},
+			want:    "alice",
+		},
+		{
+			name:    "root only",
+			host:    "web-1",
+			sources: []UserSource{UserSourceRoot},
+			want:    "root",
+		},
+		{
+			name:    "root when no source matches",
+			host:    "db-1",
+			sources: []UserSource{UserSourceSSHConfig},
+			want:    "root",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ResolveUser(tt.host, tt.explicitUser, tt.flagUser, tt.sources); got != tt.want {
+				t.Errorf("ResolveUser() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolveUser_EmptyEnv(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("USER", "")
+
+	if got := ResolveUser("db-1", "", "", nil); got != "root" {
+		t.Errorf("ResolveUser() = %q, want root", got)
+	}
+}
+
+func TestResolveTarget(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("USER", "alice")
+
+	user, host, err := ResolveTarget("ubuntu@10.0.0.5", "", nil)
+	if err != nil || user != "ubuntu" || host != "10.0.0.5" {
+		t.Errorf("ResolveTarget(user@host) = (%q, %q, %v)", user, host, err)
+	}
+
+	user, host, err = ResolveTarget("10.0.0.5", "", nil)
+	if err != nil || user != "alice" || host != "10.0.0.5" {
+		t.Errorf("ResolveTarget(host) = (%q, %q, %v)", user, host, err)
+	}
+
+	if _, _, err := ResolveTarget("a@b@c", "", nil); err == nil {
+		t.Error("ResolveTarget() expected error for invalid target")
+	}
+}
+
+func TestParseUserSources(t *testing.T) {
+	sources, err := ParseUserSources([]string{"env", " root"})
+	if err != nil {
+		t.Fatalf("ParseUserSources() error = %v", err)
+	}
+	if len(sources) != 2 || sources[0] != UserSourceEnv || sources[1] != UserSourceRoot {
+		t.Errorf("ParseUserSources() = %v", sources)
+	}
+
+	if _, err := ParseUserSources([]string{"ldap"}); err == nil {
+		t.Error("ParseUserSources() expected error for unknown source")
+	}
+}
+
+func TestResolveTarget_InventoryWithUserFlag(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("USER", "")
+
+	// --user replaces the root default but not a per-host user@ override
+	entries := map[string]string{
+		"web-1.example.com":        "ops",
+		"admin@db-1.example.com":   "admin",
+		"10.0.0.5":                 "ops",
+		"ubuntu@cache.example.com": "ubuntu",
+	}
+	for entry, want := range entries {
+		user, _, err := ResolveTarget(entry, "ops", []UserSource{UserSourceRoot})
+		if err != nil {
+			t.Fatalf("ResolveTarget(%q) error = %v", entry, err)
+		}
+		if user != want {
+			t.Errorf("ResolveTarget(%q) user = %q, want %q", entry, user, want)
+		}
+	}
+
+	// Without --user the same entries fall back to root
+	if user, _, _ := ResolveTarget("web-1.example.com", "", []UserSource{UserSourceRoot}); user != "root" {
+		t.Errorf("ResolveTarget() without --user = %q, want root", user)
+	}
+}
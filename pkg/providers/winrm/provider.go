@@ -0,0 +1,147 @@
+package winrm
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/masterzen/winrm"
+	"github.com/neilfarmer/platform-spec/pkg/core"
+)
+
+// Runner executes PowerShell commands on a Windows host
+type Runner interface {
+	Run(ctx context.Context, command string) (stdout, stderr string, exitCode int, err error)
+}
+
+// Provider implements Windows system testing via WinRM and PowerShell
+type Provider struct {
+	runner Runner
+	config *Config
+}
+
+// Config holds WinRM connection configuration
+type Config struct {
+	Host     string
+	Port     int // WinRM port (default: 5985 for HTTP, 5986 for HTTPS)
+	User     string
+	Password string
+	HTTPS    bool          // Use HTTPS transport
+	Insecure bool          // Skip TLS certificate verification (INSECURE, not recommended)
+	Timeout  time.Duration // Operation timeout
+}
+
+// ParseTarget parses a target string like "user@host" or "host"
+func ParseTarget(target string, defaultUser string) (user, host string, err error) {
+	parts := strings.Split(target, "@")
+	if len(parts) == 2 {
+		return parts[0], parts[1], nil
+	} else if len(parts) == 1 {
+		user = defaultUser
+		if user == "" {
+			user = "Administrator"
+		}
+		return user, parts[0], nil
+	}
+	return "", "", fmt.Errorf("invalid target format: %s", target)
+}
+
+// NewProvider creates a new WinRM provider
+func NewProvider(config *Config) *Provider {
+	return &Provider{
+		config: config,
+	}
+}
+
+// NewProviderWithRunner creates a WinRM provider that executes commands with the given runner
+func NewProviderWithRunner(runner Runner) *Provider {
+	return &Provider{
+		runner: runner,
+	}
+}
+
+// Connect creates the WinRM client and verifies that PowerShell commands can be run
+func (p *Provider) Connect(ctx context.Context) error {
+	if p.runner == nil {
+		port := p.config.Port
+		if port == 0 {
+			port = 5985
+			if p.config.HTTPS {
+				port = 5986
+			}
+		}
+
+		endpoint := winrm.NewEndpoint(p.config.Host, port, p.config.HTTPS, p.config.Insecure, nil, nil, nil, p.config.Timeout)
+		client, err := winrm.NewClient(endpoint, p.config.User, p.config.Password)
+		if err != nil {
+			return fmt.Errorf("failed to create WinRM client: %w", err)
+		}
+		p.runner = &clientRunner{client: client}
+	}
+
+	_, stderr, exitCode, err := p.runner.Run(ctx, "$PSVersionTable.PSVersion.Major")
+	if err != nil {
+		return fmt.Errorf("failed to connect to %s: %w", p.target(), err)
+	}
+	if exitCode != 0 {
+		return fmt.Errorf("failed to run PowerShell on %s: %s", p.target(), strings.TrimSpace(stderr))
+	}
+	return nil
+}
+
+// target returns a printable description of the connection target
+func (p *Provider) target() string {
+	if p.config == nil {
+		return "WinRM host"
+	}
+	return fmt.Sprintf("%s@%s", p.config.User, p.config.Host)
+}
+
+// Close releases the WinRM client. WinRM is stateless HTTP, so there is nothing to tear down.
+func (p *Provider) Close() error {
+	return nil
+}
+
+// OS reports that WinRM targets are always Windows
+func (p *Provider) OS() string {
+	return core.OSWindows
+}
+
+// ExecuteCommand executes a PowerShell command on the Windows host
+func (p *Provider) ExecuteCommand(ctx context.Context, command string) (stdout, stderr string, exitCode int, err error) {
+	if p.runner == nil {
+		return "", "", -1, fmt.Errorf("not connected")
+	}
+	return p.runner.Run(ctx, command)
+}
+
+// clientRunner runs commands with a WinRM client
+type clientRunner struct {
+	client *winrm.Client
+}
+
+// Run executes a PowerShell command, abandoning it if the context is cancelled
+func (r *clientRunner) Run(ctx context.Context, command string) (stdout, stderr string, exitCode int, err error) {
+	type runResult struct {
+		stdout, stderr string
+		exitCode       int
+		err            error
+	}
+
+	done := make(chan runResult, 1)
+	go func() {
+		stdout, stderr, exitCode, err := r.client.RunPSWithString(command, "")
+		done <- runResult{stdout, stderr, exitCode, err}
+	}()
+
+	select {
+	case <-ctx.Done():
+		return "", "", -1, ctx.Err()
+	case res := <-done:
+		if res.err != nil {
+			return res.stdout, res.stderr, -1, fmt.Errorf("command execution failed: %w", res.err)
+		}
+		return res.stdout, res.stderr, res.exitCode, nil
+	}
+}
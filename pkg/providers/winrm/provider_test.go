@@ -0,0 +1,152 @@
+package winrm
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/neilfarmer/platform-spec/pkg/core"
+	"github.com/neilfarmer/platform-spec/pkg/core/system"
+)
+
+// fakeRunner returns canned output for the first rule whose substrings all appear in the command
+type fakeRunner struct {
+	rules    []fakeRule
+	commands []string
+}
+
+type fakeRule struct {
+	match    []string
+	stdout   string
+	exitCode int
+	err      error
+}
+
+func (f *fakeRunner) Run(ctx context.Context, command string) (string, string, int, error) {
+	f.commands = append(f.commands, command)
+	for _, rule := range f.rules {
+		matched := true
+		for _, m := range rule.match {
+			if !strings.Contains(command, m) {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return rule.stdout, "", rule.exitCode, rule.err
+		}
+	}
+	return "", "", 0, nil
+}
+
+func TestParseTarget(t *testing.T) {
+	tests := []struct {
+		target   string
+		wantUser string
+		wantHost string
+		wantErr  bool
+	}{
+		{"admin@win01", "admin", "win01", false},
+		{"win01", "Administrator", "win01", false},
+		{"a@b@c", "", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.target, func(t *testing.T) {
+			user, host, err := ParseTarget(tt.target, "")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseTarget() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if user != tt.wantUser || host != tt.wantHost {
+				t.Errorf("ParseTarget() = (%q, %q), want (%q, %q)", user, host, tt.wantUser, tt.wantHost)
+			}
+		})
+	}
+}
+
+func TestConnect(t *testing.T) {
+	runner := &fakeRunner{rules: []fakeRule{{match: []string{"$PSVersionTable"}, stdout: "5\r\n"}}}
+	provider := NewProviderWithRunner(runner)
+
+	if err := provider.Connect(context.Background()); err != nil {
+		t.Fatalf("Connect() error = %v", err)
+	}
+	if len(runner.commands) != 1 {
+		t.Errorf("Connect() ran %d commands, want 1", len(runner.commands))
+	}
+}
+
+func TestConnect_Error(t *testing.T) {
+	runner := &fakeRunner{rules: []fakeRule{{match: []string{"$PSVersionTable"}, err: errors.New("http 401")}}}
+	provider := NewProviderWithRunner(runner)
+
+	err := provider.Connect(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "http 401") {
+		t.Errorf("Connect() error = %v, want wrapped runner error", err)
+	}
+}
+
+func TestExecuteCommand_NotConnected(t *testing.T) {
+	provider := NewProvider(&Config{Host: "win01", User: "admin"})
+
+	_, _, exitCode, err := provider.ExecuteCommand(context.Background(), "Get-Service")
+	if err == nil {
+		t.Error("ExecuteCommand() should fail before Connect()")
+	}
+	if exitCode != -1 {
+		t.Errorf("ExecuteCommand() exitCode = %d, want -1", exitCode)
+	}
+}
+
+func TestProviderReportsWindows(t *testing.T) {
+	if got := core.ProviderOS(NewProviderWithRunner(&fakeRunner{})); got != core.OSWindows {
+		t.Errorf("ProviderOS() = %q, want %q", got, core.OSWindows)
+	}
+}
+
+func TestSystemPlugin_ServiceAndFileChecks(t *testing.T) {
+	runner := &fakeRunner{rules: []fakeRule{
+		{match: []string{"Get-Service", "'W3SVC'"}, stdout: "Running|Automatic\r\n"},
+		{match: []string{"Get-Service", "'Spooler'"}, stdout: "Stopped|Disabled\r\n"},
+		{match: []string{"Test-Path", `'C:\inetpub\wwwroot'`}, stdout: "directory|BUILTIN\\Administrators\r\n"},
+		{match: []string{"Test-Path", `'C:\missing.txt'`}, stdout: "notfound\r\n"},
+	}}
+	provider := NewProviderWithRunner(runner)
+
+	spec := &core.Spec{
+		Tests: core.Tests{
+			Files: []core.FileTest{
+				{Name: "IIS root exists", Path: `C:\inetpub\wwwroot`, Type: "directory", Owner: `BUILTIN\Administrators`},
+				{Name: "Missing file", Path: `C:\missing.txt`, Type: "file"},
+			},
+			Services: []core.ServiceTest{
+				{Name: "IIS running", Service: "W3SVC", State: "running", Enabled: true},
+				{Name: "Spooler stopped", Service: "Spooler", State: "stopped"},
+			},
+		},
+	}
+
+	results, _ := system.NewSystemPlugin().Execute(context.Background(), spec, provider, false)
+
+	want := map[string]core.Status{
+		"IIS root exists": core.StatusPass,
+		"Missing file":    core.StatusFail,
+		"IIS running":     core.StatusPass,
+		"Spooler stopped": core.StatusPass,
+	}
+	if len(results) != len(want) {
+		t.Fatalf("Execute() returned %d results, want %d", len(results), len(want))
+	}
+	for _, result := range results {
+		if result.Status != want[result.Name] {
+			t.Errorf("%s: status = %v, want %v (message: %s)", result.Name, result.Status, want[result.Name], result.Message)
+		}
+	}
+
+	for _, cmd := range runner.commands {
+		if strings.Contains(cmd, "systemctl") || strings.Contains(cmd, "stat -c") {
+			t.Errorf("Linux command sent to Windows host: %s", cmd)
+		}
+	}
+}
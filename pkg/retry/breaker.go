@@ -0,0 +1,102 @@
+package retry
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// BreakerState represents the state of a circuit breaker
+type BreakerState string
+
+const (
+	// BreakerClosed allows all attempts through
+	BreakerClosed BreakerState = "closed"
+	// BreakerOpen rejects all attempts until the cool-down elapses
+	BreakerOpen BreakerState = "open"
+	// BreakerHalfOpen allows a single probe attempt through
+	BreakerHalfOpen BreakerState = "half-open"
+)
+
+// ErrBreakerOpen is returned when an attempt is short-circuited by an open breaker
+var ErrBreakerOpen = errors.New("circuit breaker open")
+
+// Breaker is a circuit breaker that stops attempts after a number of
+// consecutive failures and allows a probe once a cool-down has elapsed
+type Breaker struct {
+	mu        sync.Mutex
+	threshold int           // Consecutive failures before opening
+	cooldown  time.Duration // Time to stay open before allowing a probe
+	state     BreakerState
+	failures  int
+	openedAt  time.Time
+	probing   bool
+	now       func() time.Time
+}
+
+// NewBreaker creates a circuit breaker that opens after threshold consecutive
+// failures and stays open for cooldown before moving to half-open
+func NewBreaker(threshold int, cooldown time.Duration) *Breaker {
+	return &Breaker{
+		threshold: threshold,
+		cooldown:  cooldown,
+		state:     BreakerClosed,
+		now:       time.Now,
+	}
+}
+
+// Allow reports whether an attempt may proceed, returning ErrBreakerOpen if not
+func (b *Breaker) Allow() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case BreakerOpen:
+		if b.now().Sub(b.openedAt) < b.cooldown {
+			return ErrBreakerOpen
+		}
+		// Cool-down elapsed: let a single probe through
+		b.state = BreakerHalfOpen
+		b.probing = true
+		return nil
+	case BreakerHalfOpen:
+		if b.probing {
+			return ErrBreakerOpen
+		}
+		b.probing = true
+		return nil
+	default:
+		return nil
+	}
+}
+
+// RecordSuccess records a successful attempt and closes the breaker
+func (b *Breaker) RecordSuccess() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.state = BreakerClosed
+	b.failures = 0
+	b.probing = false
+}
+
+// RecordFailure records a failed attempt, opening the breaker once the
+// threshold is reached or when a half-open probe fails
+func (b *Breaker) RecordFailure() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.failures++
+	b.probing = false
+	if b.state == BreakerHalfOpen || b.failures >= b.threshold {
+		b.state = BreakerOpen
+		b.openedAt = b.now()
+	}
+}
+
+// State returns the current breaker state
+func (b *Breaker) State() BreakerState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.state
+}
@@ -0,0 +1,98 @@
+package retry
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestBreaker_TripsAfterThreshold(t *testing.T) {
+	b := NewBreaker(3, time.Minute)
+
+	for i := 0; i < 2; i++ {
+		if err := b.Allow(); err != nil {
+			t.Fatalf("attempt %d: expected breaker to allow, got %v", i, err)
+		}
+		b.RecordFailure()
+	}
+	if b.State() != BreakerClosed {
+		t.Fatalf("expected closed below threshold, got %s", b.State())
+	}
+
+	b.RecordFailure()
+	if b.State() != BreakerOpen {
+		t.Fatalf("expected open after threshold, got %s", b.State())
+	}
+	if err := b.Allow(); !errors.Is(err, ErrBreakerOpen) {
+		t.Errorf("expected ErrBreakerOpen, got %v", err)
+	}
+}
+
+func TestBreaker_SuccessResetsFailures(t *testing.T) {
+	b := NewBreaker(2, time.Minute)
+
+	b.RecordFailure()
+	b.RecordSuccess()
+	b.RecordFailure()
+
+	if b.State() != BreakerClosed {
+		t.Errorf("expected non-consecutive failures to keep breaker closed, got %s", b.State())
+	}
+}
+
+func TestBreaker_RecoversAfterCooldown(t *testing.T) {
+	now := time.Now()
+	b := NewBreaker(1, 30*time.Second)
+	b.now = func() time.Time { return now }
+
+	b.RecordFailure()
+	if err := b.Allow(); !errors.Is(err, ErrBreakerOpen) {
+		t.Fatalf("expected open breaker to reject, got %v", err)
+	}
+
+	// Cool-down elapses: one probe is allowed, concurrent attempts are not
+	now = now.Add(31 * time.Second)
+	if err := b.Allow(); err != nil {
+		t.Fatalf("expected probe to be allowed, got %v", err)
+	}
+	if b.State() != BreakerHalfOpen {
+		t.Fatalf("expected half-open, got %s", b.State())
+	}
+	if err := b.Allow(); !errors.Is(err, ErrBreakerOpen) {
+		t.Errorf("expected second attempt during probe to be rejected, got %v", err)
+	}
+
+	b.RecordSuccess()
+	if b.State() != BreakerClosed {
+		t.Errorf("expected closed after successful probe, got %s", b.State())
+	}
+	if err := b.Allow(); err != nil {
+		t.Errorf("expected closed breaker to allow, got %v", err)
+	}
+}
+
+func TestBreaker_FailedProbeReopens(t *testing.T) {
+	now := time.Now()
+	b := NewBreaker(1, 10*time.Second)
+	b.now = func() time.Time { return now }
+
+	b.RecordFailure()
+	now = now.Add(11 * time.Second)
+	if err := b.Allow(); err != nil {
+		t.Fatalf("expected probe to be allowed, got %v", err)
+	}
+
+	b.RecordFailure()
+	if b.State() != BreakerOpen {
+		t.Fatalf("expected failed probe to reopen breaker, got %s", b.State())
+	}
+	if err := b.Allow(); !errors.Is(err, ErrBreakerOpen) {
+		t.Errorf("expected reopened breaker to reject, got %v", err)
+	}
+}
+
+func TestBreakerOpenIsNotRetryable(t *testing.T) {
+	if IsRetryableSSHError(ErrBreakerOpen) {
+		t.Error("an open breaker should not be retried")
+	}
+}
@@ -2,7 +2,9 @@ package retry
 
 import (
 	"errors"
+	"fmt"
 	"net"
+	"regexp"
 	"strings"
 	"syscall"
 )
@@ -127,3 +129,122 @@ func IsNonRetryableSSHError(err error) bool {
 
 	return false
 }
+
+// IsTransientError determines if a failed test check may pass when re-run,
+// e.g. a service that is still starting or a pod that is not ready yet.
+// Assertion mismatches such as an unexpected status code or missing content
+// are not transient.
+func IsTransientError(err error) bool {
+	if IsNetworkError(err) {
+		return true
+	}
+
+	// Resources that exist but have not settled yet
+	return ContainsAny("not ready", "not all ready", "not available", "phase is pending", "ready replicas")(err)
+}
+
+// IsNetworkError determines if a failed test check could not reach its
+// target, e.g. a refused connection or a timeout
+func IsNetworkError(err error) bool {
+	if err == nil {
+		return false
+	}
+	if IsRetryableSSHError(err) {
+		return true
+	}
+	return ContainsAny(
+		"connection refused",
+		"connection reset",
+		"timed out",
+		"timeout",
+		"no route to host",
+		"network is unreachable",
+		"empty reply from server",
+		"temporary failure",
+	)(err)
+}
+
+// ContainsAny returns a classifier for errors containing any of the
+// patterns, matched case-insensitively since tools like curl capitalize
+// them ("Connection refused")
+func ContainsAny(patterns ...string) ErrorClassifier {
+	return func(err error) bool {
+		if err == nil {
+			return false
+		}
+		errStr := strings.ToLower(err.Error())
+		for _, pattern := range patterns {
+			if strings.Contains(errStr, strings.ToLower(pattern)) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// AnyOf returns a classifier for errors any of the classifiers retries
+func AnyOf(classifiers ...ErrorClassifier) ErrorClassifier {
+	return func(err error) bool {
+		for _, classifier := range classifiers {
+			if classifier(err) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// NewPatternClassifier builds a classifier that overlays user-supplied regex
+// patterns on top of the built-in SSH classifiers. User patterns take precedence:
+// an error matching a noRetryIf pattern is never retried, an error matching a
+// retryIf pattern is always retried, and anything else falls through to
+// IsNonRetryableSSHError/IsRetryableSSHError.
+func NewPatternClassifier(retryIf, noRetryIf []string) (ErrorClassifier, error) {
+	retryPatterns, err := compilePatterns(retryIf)
+	if err != nil {
+		return nil, fmt.Errorf("invalid retry-if pattern: %w", err)
+	}
+	noRetryPatterns, err := compilePatterns(noRetryIf)
+	if err != nil {
+		return nil, fmt.Errorf("invalid no-retry-if pattern: %w", err)
+	}
+
+	return func(err error) bool {
+		if err == nil {
+			return false
+		}
+
+		errStr := err.Error()
+
+		// User-supplied patterns win, with no-retry taking priority over retry
+		for _, re := range noRetryPatterns {
+			if re.MatchString(errStr) {
+				return false
+			}
+		}
+		for _, re := range retryPatterns {
+			if re.MatchString(errStr) {
+				return true
+			}
+		}
+
+		// Fall back to built-in classification
+		if IsNonRetryableSSHError(err) {
+			return false
+		}
+		return IsRetryableSSHError(err)
+	}, nil
+}
+
+// compilePatterns compiles a list of regular expressions
+func compilePatterns(patterns []string) ([]*regexp.Regexp, error) {
+	compiled := make([]*regexp.Regexp, 0, len(patterns))
+	for _, pattern := range patterns {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("%q: %w", pattern, err)
+		}
+		compiled = append(compiled, re)
+	}
+	return compiled, nil
+}
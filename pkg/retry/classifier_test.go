@@ -373,3 +373,108 @@ type testTimeoutError struct{}
 func (e *testTimeoutError) Error() string   { return "timeout error" }
 func (e *testTimeoutError) Timeout() bool   { return true }
 func (e *testTimeoutError) Temporary() bool { return true }
+
+func TestNewPatternClassifier(t *testing.T) {
+	classifier, err := NewPatternClassifier(
+		[]string{`bastion: upstream busy`, `(?i)permission denied \(try again\)`},
+		[]string{`connection refused by policy`},
+	)
+	if err != nil {
+		t.Fatalf("NewPatternClassifier returned error: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		errMsg string
+		want   bool
+	}{
+		{"custom retry pattern on unknown error", "bastion: upstream busy, retry later", true},
+		{"custom retry pattern flips built-in non-retryable", "Permission denied (try again)", true},
+		{"custom no-retry pattern flips built-in retryable", "connection refused by policy", false},
+		{"built-in retryable still applies", "connection reset by peer", true},
+		{"built-in non-retryable still applies", "unable to authenticate", false},
+		{"unknown error is not retried", "some unexpected error", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := classifier(errors.New(tt.errMsg)); got != tt.want {
+				t.Errorf("classifier(%q) = %v, want %v", tt.errMsg, got, tt.want)
+			}
+		})
+	}
+
+	if classifier(nil) {
+		t.Error("nil error should not be retryable")
+	}
+}
+
+func TestNewPatternClassifier_NoRetryWinsOverRetry(t *testing.T) {
+	classifier, err := NewPatternClassifier([]string{"flaky"}, []string{"flaky but fatal"})
+	if err != nil {
+		t.Fatalf("NewPatternClassifier returned error: %v", err)
+	}
+
+	if classifier(errors.New("flaky but fatal")) {
+		t.Error("no-retry pattern should take priority over retry pattern")
+	}
+	if !classifier(errors.New("flaky network")) {
+		t.Error("retry pattern should match")
+	}
+}
+
+func TestNewPatternClassifier_InvalidPattern(t *testing.T) {
+	if _, err := NewPatternClassifier([]string{"("}, nil); err == nil {
+		t.Error("expected error for invalid retry-if pattern")
+	}
+	if _, err := NewPatternClassifier(nil, []string{"["}); err == nil {
+		t.Error("expected error for invalid no-retry-if pattern")
+	}
+}
+
+func TestIsTransientError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"nil error", nil, false},
+		{"curl connection refused", errors.New("HTTP request failed: curl: (7) Failed to connect to localhost port 8080: Connection refused"), true},
+		{"curl timeout", errors.New("HTTP request failed: curl: (28) Operation timed out after 5000 milliseconds"), true},
+		{"ssh session drop", errors.New("Error making HTTP request: failed to create session: EOF"), true},
+		{"pod not ready", errors.New("Pod web-0 containers not all ready"), true},
+		{"pod pending", errors.New("Pod web-0 phase is Pending, expected Running"), true},
+		{"deployment scaling", errors.New("Deployment web has 1 ready replicas, expected 3"), true},
+		{"status code mismatch", errors.New("Status code is 404, expected 200"), false},
+		{"missing content", errors.New("Response body missing expected strings: healthy"), false},
+		{"package missing", errors.New("Package nginx is not installed"), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsTransientError(tt.err); got != tt.want {
+				t.Errorf("IsTransientError() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestContainsAnyAndAnyOf(t *testing.T) {
+	unavailable := ContainsAny("Status code is 503")
+	if !unavailable(errors.New("status code is 503, expected 200")) {
+		t.Error("ContainsAny() should match case-insensitively")
+	}
+	if unavailable(nil) || unavailable(errors.New("Status code is 404, expected 200")) {
+		t.Error("ContainsAny() matched nil or a different message")
+	}
+
+	classifier := AnyOf(IsNetworkError, unavailable)
+	for _, msg := range []string{"dial tcp: connection refused", "Status code is 503, expected 200"} {
+		if !classifier(errors.New(msg)) {
+			t.Errorf("AnyOf() = false for %q, want true", msg)
+		}
+	}
+	if classifier(errors.New("Status code is 404, expected 200")) {
+		t.Error("AnyOf() = true for a 404, want false")
+	}
+}
@@ -18,6 +18,12 @@ const (
 	StrategyExponential Strategy = "exponential"
 	// StrategyJittered uses exponential backoff with random jitter
 	StrategyJittered Strategy = "jittered"
+	// StrategyConstant waits the initial delay between every retry
+	StrategyConstant Strategy = "constant"
+	// StrategyDecorrelated picks each delay at random between the initial
+	// delay and three times the previous delay (AWS "decorrelated jitter"),
+	// spreading out clients that failed at the same moment
+	StrategyDecorrelated Strategy = "decorrelated"
 )
 
 // Config holds retry configuration
@@ -26,6 +32,11 @@ type Config struct {
 	InitialDelay time.Duration // Initial delay between retries (default: 1s)
 	MaxDelay     time.Duration // Maximum delay between retries (default: 30s)
 	Strategy     Strategy      // Backoff strategy (default: linear)
+	MaxElapsed   time.Duration // Total time budget for all attempts and delays (0 = unlimited)
+
+	// OnRetry is called after a failed attempt that will be retried, before
+	// waiting nextDelay. attempt counts from 1. Optional.
+	OnRetry func(attempt int, err error, nextDelay time.Duration)
 }
 
 // DefaultConfig returns default retry configuration
@@ -78,6 +89,13 @@ func (c *Config) CalculateDelay(attempt int) time.Duration {
 			delay = exponentialDelay
 		}
 
+	case StrategyConstant:
+		delay = c.InitialDelay
+
+	case StrategyDecorrelated:
+		// Without the previous delay, assume it was the initial delay
+		return c.NextDelay(attempt, c.InitialDelay)
+
 	default:
 		// Fallback to initial delay
 		delay = c.InitialDelay
@@ -91,6 +109,36 @@ func (c *Config) CalculateDelay(attempt int) time.Duration {
 	return delay
 }
 
+// NextDelay calculates the delay for a retry attempt given the delay before
+// the previous one (0 if there was none). Only the decorrelated strategy
+// depends on the previous delay: it returns a random delay in
+// [InitialDelay, 3*prev], capped at MaxDelay. Other strategies return
+// CalculateDelay(attempt).
+func (c *Config) NextDelay(attempt int, prev time.Duration) time.Duration {
+	if c.Strategy != StrategyDecorrelated {
+		return c.CalculateDelay(attempt)
+	}
+
+	if prev < c.InitialDelay {
+		prev = c.InitialDelay
+	}
+	upper := prev * 3
+	if upper > c.MaxDelay {
+		upper = c.MaxDelay
+	}
+	delay := c.InitialDelay
+	if spread := int64(upper - c.InitialDelay); spread > 0 {
+		// Use crypto/rand for jitter to satisfy security scanner
+		if n, err := rand.Int(rand.Reader, big.NewInt(spread+1)); err == nil {
+			delay += time.Duration(n.Int64())
+		}
+	}
+	if delay > c.MaxDelay {
+		delay = c.MaxDelay
+	}
+	return delay
+}
+
 // ErrorClassifier determines if an error is retryable
 type ErrorClassifier func(error) bool
 
@@ -101,6 +149,8 @@ func Do(ctx context.Context, config *Config, classifier ErrorClassifier, fn func
 	}
 
 	var lastErr error
+	var delay time.Duration
+	start := time.Now()
 
 	for attempt := 0; attempt <= config.MaxRetries; attempt++ {
 		// Execute the function
@@ -122,7 +172,16 @@ func Do(ctx context.Context, config *Config, classifier ErrorClassifier, fn func
 		}
 
 		// Calculate backoff delay
-		delay := config.CalculateDelay(attempt)
+		delay = config.NextDelay(attempt, delay)
+
+		// Stop rather than wait past the time budget
+		if config.MaxElapsed > 0 && time.Since(start)+delay > config.MaxElapsed {
+			return fmt.Errorf("retry time budget (%s) exceeded after %d attempts: %w", config.MaxElapsed, attempt+1, lastErr)
+		}
+
+		if config.OnRetry != nil {
+			config.OnRetry(attempt+1, err, delay)
+		}
 
 		// Wait with context support
 		select {
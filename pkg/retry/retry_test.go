@@ -3,6 +3,7 @@ package retry
 import (
 	"context"
 	"errors"
+	"strings"
 	"testing"
 	"time"
 )
@@ -33,6 +34,21 @@ func TestCalculateDelay_Linear(t *testing.T) {
 	}
 }
 
+func TestCalculateDelay_Constant(t *testing.T) {
+	config := &Config{
+		MaxRetries:   3,
+		InitialDelay: 2 * time.Second,
+		MaxDelay:     30 * time.Second,
+		Strategy:     StrategyConstant,
+	}
+
+	for attempt := 0; attempt < 4; attempt++ {
+		if delay := config.CalculateDelay(attempt); delay != 2*time.Second {
+			t.Errorf("Constant backoff attempt %d: expected 2s, got %v", attempt, delay)
+		}
+	}
+}
+
 func TestCalculateDelay_Exponential(t *testing.T) {
 	config := &Config{
 		MaxRetries:   4,
@@ -85,6 +101,54 @@ func TestCalculateDelay_Jittered(t *testing.T) {
 	}
 }
 
+func TestNextDelay_Decorrelated(t *testing.T) {
+	config := &Config{
+		MaxRetries:   10,
+		InitialDelay: 1 * time.Second,
+		MaxDelay:     20 * time.Second,
+		Strategy:     StrategyDecorrelated,
+	}
+
+	// Each delay lies in [InitialDelay, min(MaxDelay, 3*previous)]
+	for run := 0; run < 50; run++ {
+		prev := time.Duration(0)
+		for attempt := 0; attempt < 10; attempt++ {
+			delay := config.NextDelay(attempt, prev)
+			upper := 3 * prev
+			if upper < 3*config.InitialDelay {
+				upper = 3 * config.InitialDelay
+			}
+			if upper > config.MaxDelay {
+				upper = config.MaxDelay
+			}
+			if delay < config.InitialDelay || delay > upper {
+				t.Fatalf("Decorrelated attempt %d after %v: delay %v outside [%v, %v]",
+					attempt, prev, delay, config.InitialDelay, upper)
+			}
+			prev = delay
+		}
+	}
+
+	// Without a previous delay, CalculateDelay assumes the initial delay
+	for attempt := 0; attempt < 10; attempt++ {
+		if delay := config.CalculateDelay(attempt); delay < config.InitialDelay || delay > 3*config.InitialDelay {
+			t.Errorf("CalculateDelay(%d) = %v, want within [1s, 3s]", attempt, delay)
+		}
+	}
+
+	// A previous delay beyond the cap still yields at most MaxDelay
+	if delay := config.NextDelay(5, time.Minute); delay > config.MaxDelay {
+		t.Errorf("NextDelay after 1m = %v, want capped at %v", delay, config.MaxDelay)
+	}
+}
+
+func TestNextDelay_OtherStrategies(t *testing.T) {
+	config := &Config{InitialDelay: time.Second, MaxDelay: 30 * time.Second, Strategy: StrategyExponential}
+	if got, want := config.NextDelay(2, 10*time.Second), config.CalculateDelay(2); got != want {
+		t.Errorf("NextDelay(2) = %v, want CalculateDelay(2) = %v", got, want)
+	}
+}
+
 func TestCalculateDelay_MaxCap(t *testing.T) {
 	config := &Config{
 		MaxRetries:   10,
@@ -259,6 +323,134 @@ func TestDo_ContextCancellation(t *testing.T) {
 	}
 }
 
+func TestDo_OnRetry(t *testing.T) {
+	type call struct {
+		attempt int
+		err     error
+		delay   time.Duration
+	}
+	var calls []call
+	config := &Config{
+		MaxRetries:   5,
+		InitialDelay: 1 * time.Millisecond,
+		MaxDelay:     10 * time.Millisecond,
+		Strategy:     StrategyLinear,
+		OnRetry: func(attempt int, err error, nextDelay time.Duration) {
+			calls = append(calls, call{attempt, err, nextDelay})
+		},
+	}
+
+	errs := []error{errors.New("timeout 1"), errors.New("timeout 2"), errors.New("auth failed")}
+	callCount := 0
+	fn := func() error {
+		err := errs[callCount]
+		callCount++
+		return err
+	}
+	// The third error is not retryable, so it is not reported as a retry
+	err := Do(context.Background(), config, func(e error) bool { return e != errs[2] }, fn)
+	if !errors.Is(err, errs[2]) {
+		t.Fatalf("Expected wrapped non-retryable error, got %v", err)
+	}
+
+	want := []call{
+		{1, errs[0], 1 * time.Millisecond},
+		{2, errs[1], 2 * time.Millisecond},
+	}
+	if len(calls) != len(want) {
+		t.Fatalf("Expected %d OnRetry calls, got %d: %v", len(want), len(calls), calls)
+	}
+	for i, w := range want {
+		if calls[i] != w {
+			t.Errorf("OnRetry call %d = %v, want %v", i, calls[i], w)
+		}
+	}
+}
+
+func TestDo_OnRetryBeforeEachSleep(t *testing.T) {
+	config := &Config{
+		MaxRetries:   3,
+		InitialDelay: 1 * time.Millisecond,
+		MaxDelay:     10 * time.Millisecond,
+		Strategy:     StrategyExponential,
+	}
+	callCount := 0
+	fn := func() error {
+		callCount++
+		return errors.New("temporary error")
+	}
+
+	// Without a hook, retries behave as before
+	if err := Do(context.Background(), config, func(e error) bool { return true }, fn); err == nil {
+		t.Fatal("Expected error, got nil")
+	}
+	if callCount != 4 {
+		t.Fatalf("Expected 4 calls without OnRetry, got %d", callCount)
+	}
+
+	var attempts []int
+	var delays []time.Duration
+	config.OnRetry = func(attempt int, err error, nextDelay time.Duration) {
+		if callCount != attempt {
+			t.Errorf("OnRetry(%d) called after %d calls, want before the next call", attempt, callCount)
+		}
+		attempts = append(attempts, attempt)
+		delays = append(delays, nextDelay)
+	}
+	callCount = 0
+	if err := Do(context.Background(), config, func(e error) bool { return true }, fn); err == nil {
+		t.Fatal("Expected error, got nil")
+	}
+
+	// No sleep follows the last attempt, so it is not reported
+	if len(attempts) != config.MaxRetries {
+		t.Fatalf("Expected %d OnRetry calls, got %d", config.MaxRetries, len(attempts))
+	}
+	for i := range attempts {
+		if attempts[i] != i+1 {
+			t.Errorf("OnRetry call %d attempt = %d, want %d", i, attempts[i], i+1)
+		}
+		if want := config.CalculateDelay(i); delays[i] != want {
+			t.Errorf("OnRetry call %d delay = %v, want %v", i, delays[i], want)
+		}
+	}
+}
+
+func TestDo_MaxElapsed(t *testing.T) {
+	config := &Config{
+		MaxRetries:   10,
+		InitialDelay: 20 * time.Millisecond,
+		MaxDelay:     20 * time.Millisecond,
+		Strategy:     StrategyConstant,
+		MaxElapsed:   50 * time.Millisecond,
+	}
+
+	callCount := 0
+	retryableErr := errors.New("temporary error")
+	fn := func() error {
+		callCount++
+		return retryableErr
+	}
+
+	start := time.Now()
+	err := Do(context.Background(), config, func(e error) bool { return true }, fn)
+	duration := time.Since(start)
+
+	if !errors.Is(err, retryableErr) {
+		t.Fatalf("Expected wrapped retryableErr, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "retry time budget (50ms) exceeded") {
+		t.Errorf("Expected time budget error, got %v", err)
+	}
+	// Two 20ms waits fit in the budget; a third would not
+	if callCount != 3 {
+		t.Errorf("Expected 3 calls within the budget, got %d", callCount)
+	}
+	if duration > config.MaxElapsed {
+		t.Errorf("Expected to stop within %v, took %v", config.MaxElapsed, duration)
+	}
+}
+
 func TestDo_NilConfig(t *testing.T) {
 	ctx := context.Background()
 
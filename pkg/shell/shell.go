@@ -0,0 +1,284 @@
+// Package shell splits shell command lines into the simple commands they
+// would run, so that callers can check which binaries a command invokes and
+// where it redirects output without running it. It understands quoting,
+// command substitutions, redirects and the operators that separate commands,
+// not the full shell grammar.
+package shell
+
+import (
+	"regexp"
+	"strings"
+)
+
+// keywords introduce compound commands and are followed by another command
+var keywords = map[string]bool{
+	"if": true, "then": true, "else": true, "elif": true, "fi": true,
+	"do": true, "done": true, "while": true, "until": true, "!": true,
+	"{": true, "}": true, "time": true, "esac": true,
+}
+
+var (
+	assignmentPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*=`)
+	fdPattern         = regexp.MustCompile(`^([0-9]+|-)$`)
+)
+
+// Command is a simple command found in a shell command line
+type Command struct {
+	Name        string     // Empty for assignments or redirects without a command
+	Args        []string   // Arguments, without redirects
+	Assignments []string   // Variable assignments before the command
+	Redirects   []Redirect // Redirects anywhere in the command
+}
+
+// Redirect is a redirect operator, such as > or 2>&, and its target
+type Redirect struct {
+	Op     string
+	Target string
+}
+
+// Writes reports whether the redirect can create or change a file. Output to
+// /dev/null, /dev/stdout and /dev/stderr and duplicating or closing a file
+// descriptor (2>&1, >&-) cannot.
+func (r Redirect) Writes() bool {
+	if !strings.Contains(r.Op, ">") {
+		return false
+	}
+	if strings.HasSuffix(r.Op, "&") && fdPattern.MatchString(r.Target) {
+		return false
+	}
+	switch r.Target {
+	case "/dev/null", "/dev/stdout", "/dev/stderr":
+		return false
+	}
+	return true
+}
+
+// Parse splits a shell command line into the simple commands it would run,
+// including those inside command substitutions, in the order their names
+// appear. Quotes are removed from names, arguments and redirect targets.
+func Parse(command string) []Command {
+	var commands []Command
+	var assignments []string
+	var redirects []Redirect
+	current := -1 // Index in commands of the current segment's command
+	var word strings.Builder
+	hasWord := false
+	quoted := false  // The word contains quotes, so cannot be a file descriptor number
+	redirectOp := "" // The next word is the target of this redirect
+	expectCommand := true
+	skipSegment := false
+
+	startWord := func() {
+		quoted = true
+		hasWord = true
+	}
+
+	finishWord := func() {
+		if !hasWord {
+			return
+		}
+		w := word.String()
+		word.Reset()
+		hasWord = false
+		quoted = false
+
+		switch {
+		case redirectOp != "":
+			r := Redirect{Op: redirectOp, Target: w}
+			redirectOp = ""
+			if current >= 0 && !expectCommand {
+				commands[current].Redirects = append(commands[current].Redirects, r)
+			} else {
+				redirects = append(redirects, r)
+			}
+		case skipSegment:
+		case !expectCommand:
+			commands[current].Args = append(commands[current].Args, w)
+		case w == "for" || w == "case":
+			// Loop variables and case subjects are not commands
+			skipSegment = true
+		case keywords[w]:
+		case assignmentPattern.MatchString(w):
+			assignments = append(assignments, w)
+		default:
+			commands = append(commands, Command{Name: w, Assignments: assignments, Redirects: redirects})
+			assignments, redirects = nil, nil
+			current = len(commands) - 1
+			expectCommand = false
+		}
+	}
+
+	endSegment := func() {
+		finishWord()
+		if redirectOp != "" {
+			// A redirect without a target is a syntax error; keep it so it is checked
+			redirects = append(redirects, Redirect{Op: redirectOp})
+			redirectOp = ""
+		}
+		if len(assignments) > 0 || len(redirects) > 0 {
+			// Assignments on their own set the variable for later commands,
+			// and a redirect on its own still creates its target
+			commands = append(commands, Command{Assignments: assignments, Redirects: redirects})
+			assignments, redirects = nil, nil
+		}
+		expectCommand = true
+		skipSegment = false
+	}
+
+	runes := []rune(command)
+	for i := 0; i < len(runes); i++ {
+		c := runes[i]
+		switch {
+		case c == '\\' && i+1 < len(runes):
+			startWord()
+			i++
+			word.WriteRune(runes[i])
+		case c == '\'':
+			startWord()
+			end := indexRune(runes, i+1, '\'')
+			word.WriteString(string(runes[i+1 : end]))
+			i = end
+		case c == '"':
+			startWord()
+			i = scanDoubleQuoted(runes, i+1, &word, &commands)
+		case c == '$' && i+1 < len(runes) && runes[i+1] == '(':
+			end := matchParen(runes, i+1)
+			if i+2 < len(runes) && runes[i+2] == '(' {
+				// Arithmetic expansion: > and < compare numbers
+				commands = append(commands, arithmeticCommands(runes[i+3:end])...)
+				word.WriteString(string(runes[i : end+1]))
+			} else {
+				commands = append(commands, Parse(string(runes[i+2:end]))...)
+				word.WriteString("$()")
+			}
+			hasWord = true
+			i = end
+		case c == '`':
+			end := indexRune(runes, i+1, '`')
+			commands = append(commands, Parse(string(runes[i+1:end]))...)
+			word.WriteString("$()")
+			hasWord = true
+			i = end
+		case c == '>' || c == '<' || (c == '&' && i+1 < len(runes) && runes[i+1] == '>'):
+			// A file descriptor number such as the 2 in 2> belongs to the redirect
+			fd := ""
+			if hasWord && !quoted && strings.Trim(word.String(), "0123456789") == "" {
+				fd = word.String()
+				word.Reset()
+				hasWord = false
+			}
+			finishWord()
+			if redirectOp != "" {
+				// Two operators in a row: keep the first so it is checked
+				redirects = append(redirects, Redirect{Op: redirectOp})
+			}
+			op := string(c)
+			for i+1 < len(runes) && strings.ContainsRune("<>&|", runes[i+1]) {
+				i++
+				op += string(runes[i])
+			}
+			redirectOp = fd + op
+		case c == '(' && i+1 < len(runes) && runes[i+1] == '(' && expectCommand && !hasWord:
+			// An arithmetic command such as ((n > 2))
+			end := matchParen(runes, i)
+			endSegment()
+			commands = append(commands, arithmeticCommands(runes[i+2:end])...)
+			i = end
+		case c == '|' || c == '&' || c == ';' || c == '\n' || c == '(' || c == ')':
+			endSegment()
+		case c == ' ' || c == '\t':
+			finishWord()
+		default:
+			word.WriteRune(c)
+			hasWord = true
+		}
+	}
+	endSegment()
+
+	return commands
+}
+
+// scanDoubleQuoted appends the contents of a double-quoted string starting at i to word,
+// collecting commands from any substitutions, and returns the index of the closing quote
+func scanDoubleQuoted(runes []rune, i int, word *strings.Builder, commands *[]Command) int {
+	for ; i < len(runes); i++ {
+		c := runes[i]
+		switch {
+		case c == '"':
+			return i
+		case c == '\\' && i+1 < len(runes):
+			i++
+			word.WriteRune(runes[i])
+		case c == '$' && i+1 < len(runes) && runes[i+1] == '(':
+			end := matchParen(runes, i+1)
+			if i+2 < len(runes) && runes[i+2] == '(' {
+				*commands = append(*commands, arithmeticCommands(runes[i+3:end])...)
+			} else {
+				*commands = append(*commands, Parse(string(runes[i+2:end]))...)
+			}
+			i = end
+		case c == '`':
+			end := indexRune(runes, i+1, '`')
+			*commands = append(*commands, Parse(string(runes[i+1:end]))...)
+			i = end
+		default:
+			word.WriteRune(c)
+		}
+	}
+	return len(runes)
+}
+
+// arithmeticCommands returns the commands of the substitutions in an
+// arithmetic expression, whose operators are not redirects
+func arithmeticCommands(runes []rune) []Command {
+	var commands []Command
+	for i := 0; i < len(runes); i++ {
+		switch {
+		case runes[i] == '$' && i+1 < len(runes) && runes[i+1] == '(':
+			end := matchParen(runes, i+1)
+			if i+2 < len(runes) && runes[i+2] == '(' {
+				commands = append(commands, arithmeticCommands(runes[i+3:end])...)
+			} else {
+				commands = append(commands, Parse(string(runes[i+2:end]))...)
+			}
+			i = end
+		case runes[i] == '`':
+			end := indexRune(runes, i+1, '`')
+			commands = append(commands, Parse(string(runes[i+1:end]))...)
+			i = end
+		}
+	}
+	return commands
+}
+
+// matchParen returns the index of the parenthesis closing the one at open,
+// or the end of input if it is unbalanced
+func matchParen(runes []rune, open int) int {
+	depth := 0
+	for i := open; i < len(runes); i++ {
+		switch runes[i] {
+		case '\\':
+			i++
+		case '\'':
+			i = indexRune(runes, i+1, '\'')
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return len(runes)
+}
+
+// indexRune returns the index of the next r at or after start, or the end of input
+func indexRune(runes []rune, start int, r rune) int {
+	for i := start; i < len(runes); i++ {
+		if runes[i] == r {
+			return i
+		}
+	}
+	return len(runes)
+}
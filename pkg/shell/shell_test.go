@@ -0,0 +1,99 @@
+package shell
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParse_Names(t *testing.T) {
+	tests := []struct {
+		name    string
+		command string
+		want    []string
+	}{
+		{"simple command", "echo hello", []string{"echo"}},
+		{"absolute path", "/usr/bin/curl -s http://example.com", []string{"/usr/bin/curl"}},
+		{"pipeline", "ss -tln | grep -E ':22\\s' || true", []string{"ss", "grep", "true"}},
+		{"redirects", "stat -c '%F' /etc/hosts 2>/dev/null || echo 'notfound'", []string{"stat", "echo"}},
+		{"redirect before command", ">/dev/null 2>&1 id root", []string{"id"}},
+		{"redirect without spaces", "echo x>/tmp/out; id", []string{"echo", "id"}},
+		{"bare redirect operator", "cat < /etc/hosts", []string{"cat"}},
+		{"env assignment", "LANG=C FOO=bar dpkg -l nginx", []string{"dpkg"}},
+		{"quoted separators", "echo 'a | rm -rf /; b'", []string{"echo"}},
+		{"command substitution", "echo $(rm -rf /tmp/x)", []string{"echo", "rm"}},
+		{"substitution in double quotes", `echo "today is $(date)"`, []string{"echo", "date"}},
+		{"backticks", "echo `whoami`", []string{"echo", "whoami"}},
+		{"arithmetic expansion", "echo $((1 + 2))", []string{"echo"}},
+		{"substitution in arithmetic", "echo $(( $(wc -l < f) > 2 ))", []string{"echo", "wc"}},
+		{"arithmetic command", "((n > 2)) && echo big", []string{"echo"}},
+		{"subshell", "(cd /tmp && wget http://example.com)", []string{"cd", "wget"}},
+		{"if statement", "if test -f /etc/hosts; then cat /etc/hosts; fi", []string{"test", "cat"}},
+		{"for loop", "for f in a b; do ls $f; done", []string{"ls"}},
+		{"background and redirect", "sleep 1 &>/dev/null", []string{"sleep"}},
+		{"sequence", "uname -m; hostname -f", []string{"uname", "hostname"}},
+		{"awk program", "getent hosts example.com | awk '{print $1}'", []string{"getent", "awk"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, cmd := range Parse(tt.command) {
+				if cmd.Name != "" {
+					got = append(got, cmd.Name)
+				}
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Parse(%q) names = %v, want %v", tt.command, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParse(t *testing.T) {
+	got := Parse(`PATH=/tmp/x grep -q 'a > b' /etc/hosts 2>/dev/null; FOO=1; find / -exec sh \; > out; >'/etc/x' ; cat x>>y 2>&1`)
+	want := []Command{
+		{Name: "grep", Args: []string{"-q", "a > b", "/etc/hosts"}, Assignments: []string{"PATH=/tmp/x"}, Redirects: []Redirect{{"2>", "/dev/null"}}},
+		{Assignments: []string{"FOO=1"}},
+		{Name: "find", Args: []string{"/", "-exec", "sh", ";"}, Redirects: []Redirect{{">", "out"}}},
+		{Redirects: []Redirect{{">", "/etc/x"}}},
+		{Name: "cat", Args: []string{"x"}, Redirects: []Redirect{{">>", "y"}, {"2>&", "1"}}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Parse() = %+v, want %+v", got, want)
+	}
+}
+
+func TestParse_Arithmetic(t *testing.T) {
+	// Comparisons in arithmetic are not redirects
+	for _, command := range []string{"echo $((3>2))", `echo "$((1<2))"`, "((n >= 2)) && echo ok"} {
+		for _, cmd := range Parse(command) {
+			if len(cmd.Redirects) > 0 {
+				t.Errorf("Parse(%q) found redirects %v", command, cmd.Redirects)
+			}
+		}
+	}
+}
+
+func TestRedirect_Writes(t *testing.T) {
+	tests := []struct {
+		redirect Redirect
+		want     bool
+	}{
+		{Redirect{">", "/etc/passwd"}, true},
+		{Redirect{">>", "log"}, true},
+		{Redirect{"&>", "out"}, true},
+		{Redirect{"<>", "/etc/passwd"}, true},
+		{Redirect{">&", "/tmp/out"}, true},
+		{Redirect{">", ""}, true},
+		{Redirect{"2>", "/dev/null"}, false},
+		{Redirect{">", "/dev/stderr"}, false},
+		{Redirect{"2>&", "1"}, false},
+		{Redirect{">&", "-"}, false},
+		{Redirect{"<", "/etc/hosts"}, false},
+	}
+	for _, tt := range tests {
+		if got := tt.redirect.Writes(); got != tt.want {
+			t.Errorf("%+v.Writes() = %v, want %v", tt.redirect, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,117 @@
+// Package store records test results in a SQLite database so results can be
+// queried over time for trend and drift analysis.
+package store
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/neilfarmer/platform-spec/pkg/core"
+
+	// Pure Go SQLite driver, registered as "sqlite"
+	_ "modernc.org/sqlite"
+)
+
+// migrations are applied in order. The number applied so far is kept in the
+// database's user_version, so only append to this list.
+var migrations = []string{
+	`CREATE TABLE results (
+		id          INTEGER PRIMARY KEY AUTOINCREMENT,
+		run_at      TEXT NOT NULL,
+		host        TEXT NOT NULL,
+		spec_name   TEXT NOT NULL,
+		spec_hash   TEXT NOT NULL,
+		test_name   TEXT NOT NULL,
+		status      TEXT NOT NULL,
+		message     TEXT NOT NULL,
+		duration_ms INTEGER NOT NULL
+	)`,
+	`CREATE INDEX results_host_test ON results (host, test_name, run_at)`,
+}
+
+// Store is a results database
+type Store struct {
+	db *sql.DB
+}
+
+// Open opens or creates the database at path and brings its schema up to date
+func Open(ctx context.Context, path string) (*Store, error) {
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open results database %s: %w", path, err)
+	}
+
+	s := &Store{db: db}
+	if err := s.migrate(ctx); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to migrate results database %s: %w", path, err)
+	}
+	return s, nil
+}
+
+// Close closes the database
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// migrate applies the migrations the database has not seen yet
+func (s *Store) migrate(ctx context.Context) error {
+	var version int
+	if err := s.db.QueryRowContext(ctx, "PRAGMA user_version").Scan(&version); err != nil {
+		return err
+	}
+	if version > len(migrations) {
+		return fmt.Errorf("schema version %d is newer than this release supports (%d)", version, len(migrations))
+	}
+
+	for i := version; i < len(migrations); i++ {
+		tx, err := s.db.BeginTx(ctx, nil)
+		if err != nil {
+			return err
+		}
+		if _, err := tx.ExecContext(ctx, migrations[i]); err != nil {
+			tx.Rollback()
+			return fmt.Errorf("migration %d: %w", i+1, err)
+		}
+		// PRAGMA does not accept bound parameters
+		if _, err := tx.ExecContext(ctx, fmt.Sprintf("PRAGMA user_version = %d", i+1)); err != nil {
+			tx.Rollback()
+			return fmt.Errorf("migration %d: %w", i+1, err)
+		}
+		if err := tx.Commit(); err != nil {
+			return fmt.Errorf("migration %d: %w", i+1, err)
+		}
+	}
+	return nil
+}
+
+// Record appends one row per test result. All rows share runAt, stored as
+// RFC 3339 UTC text, so a run can be selected as a whole and runs sort by time.
+func (s *Store) Record(ctx context.Context, runAt time.Time, results ...*core.TestResults) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	stmt, err := tx.PrepareContext(ctx, `INSERT INTO results
+		(run_at, host, spec_name, spec_hash, test_name, status, message, duration_ms)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)`)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	defer stmt.Close()
+
+	for _, tr := range results {
+		for _, r := range tr.Results {
+			if _, err := stmt.ExecContext(ctx, runAt.UTC().Format(time.RFC3339), tr.Target, tr.SpecName, tr.SpecHash,
+				r.Name, string(r.Status), r.Message, r.Duration.Milliseconds()); err != nil {
+				tx.Rollback()
+				return fmt.Errorf("failed to record result %q: %w", r.Name, err)
+			}
+		}
+	}
+	return tx.Commit()
+}
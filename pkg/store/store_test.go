@@ -0,0 +1,137 @@
+package store
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/neilfarmer/platform-spec/pkg/core"
+)
+
+func TestOpenIsIdempotent(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "results.sqlite")
+
+	for i := 0; i < 2; i++ {
+		s, err := Open(ctx, path)
+		if err != nil {
+			t.Fatalf("Open() #%d error = %v", i+1, err)
+		}
+
+		var version int
+		if err := s.db.QueryRow("PRAGMA user_version").Scan(&version); err != nil {
+			t.Fatalf("reading user_version: %v", err)
+		}
+		if version != len(migrations) {
+			t.Errorf("user_version = %d, want %d", version, len(migrations))
+		}
+		if err := s.Close(); err != nil {
+			t.Fatalf("Close() error = %v", err)
+		}
+	}
+}
+
+func TestOpenRejectsNewerSchema(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "results.sqlite")
+
+	s, err := Open(ctx, path)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	if _, err := s.db.Exec("PRAGMA user_version = 99"); err != nil {
+		t.Fatalf("setting user_version: %v", err)
+	}
+	s.Close()
+
+	if _, err := Open(ctx, path); err == nil {
+		t.Error("Open() expected error for a schema newer than this release")
+	}
+}
+
+func TestRecord(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "results.sqlite")
+
+	s, err := Open(ctx, path)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	defer s.Close()
+
+	runAt := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	web := &core.TestResults{
+		SpecName: "Web",
+		SpecHash: "abc123",
+		Target:   "root@web1",
+		Results: []core.Result{
+			{Name: "nginx installed", Status: core.StatusPass, Message: "Package nginx is installed", Duration: 1500 * time.Millisecond},
+			{Name: "nginx running", Status: core.StatusFail, Message: "Service nginx is stopped"},
+		},
+	}
+	db := &core.TestResults{
+		SpecName: "Database",
+		SpecHash: "def456",
+		Target:   "root@db1",
+		Results: []core.Result{
+			{Name: "postgres running", Status: core.StatusError, Message: "connection lost"},
+		},
+	}
+
+	if err := s.Record(ctx, runAt, web, db); err != nil {
+		t.Fatalf("Record() error = %v", err)
+	}
+	if err := s.Record(ctx, runAt.Add(time.Hour), web); err != nil {
+		t.Fatalf("Record() error = %v", err)
+	}
+
+	var count int
+	if err := s.db.QueryRow("SELECT COUNT(*) FROM results").Scan(&count); err != nil {
+		t.Fatalf("counting rows: %v", err)
+	}
+	if count != 5 {
+		t.Errorf("got %d rows, want 5", count)
+	}
+
+	rows, err := s.db.Query(`SELECT run_at, host, spec_name, spec_hash, test_name, status, message, duration_ms
+		FROM results WHERE run_at = ? ORDER BY id`, "2026-03-01T12:00:00Z")
+	if err != nil {
+		t.Fatalf("querying rows: %v", err)
+	}
+	defer rows.Close()
+
+	type row struct {
+		host, specName, specHash, testName, status, message string
+		durationMS                                          int64
+	}
+	want := []row{
+		{"root@web1", "Web", "abc123", "nginx installed", "passed", "Package nginx is installed", 1500},
+		{"root@web1", "Web", "abc123", "nginx running", "failed", "Service nginx is stopped", 0},
+		{"root@db1", "Database", "def456", "postgres running", "error", "connection lost", 0},
+	}
+	var got []row
+	for rows.Next() {
+		var r row
+		var at string
+		if err := rows.Scan(&at, &r.host, &r.specName, &r.specHash, &r.testName, &r.status, &r.message, &r.durationMS); err != nil {
+			t.Fatalf("scanning row: %v", err)
+		}
+		if at != "2026-03-01T12:00:00Z" {
+			t.Errorf("run_at = %s, want 2026-03-01T12:00:00Z", at)
+		}
+		got = append(got, r)
+	}
+	if err := rows.Err(); err != nil {
+		t.Fatalf("iterating rows: %v", err)
+	}
+
+	if len(got) != len(want) {
+		t.Fatalf("got %d rows for the first run, want %d", len(got), len(want))
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("row %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}